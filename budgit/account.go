@@ -17,4 +17,7 @@ type ExternalAccount struct {
 	IntegrationID     string
 	LastSyncTimestamp time.Time
 	Balance           Balance
+	// WriteBack is whether changes to transactions imported from the external account are written back to it,
+	// for integrations which support it.
+	WriteBack bool
 }
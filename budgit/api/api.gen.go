@@ -0,0 +1,6717 @@
+//go:build go1.22
+
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for IntegrationCapabilities.
+const (
+	AttachmentImport  IntegrationCapabilities = "attachment_import"
+	ScheduledPayments IntegrationCapabilities = "scheduled_payments"
+	TransactionImport IntegrationCapabilities = "transaction_import"
+	TransactionWrite  IntegrationCapabilities = "transaction_write"
+)
+
+// Defines values for QuickAddMatchField.
+const (
+	QuickAddMatchFieldAccount  QuickAddMatchField = "account"
+	QuickAddMatchFieldCategory QuickAddMatchField = "category"
+	QuickAddMatchFieldPayee    QuickAddMatchField = "payee"
+)
+
+// Defines values for Role.
+const (
+	Editor Role = "editor"
+	Owner  Role = "owner"
+	Viewer Role = "viewer"
+)
+
+// Defines values for Scope.
+const (
+	AccountsRead      Scope = "accounts:read"
+	AccountsSync      Scope = "accounts:sync"
+	AccountsWrite     Scope = "accounts:write"
+	BudgetsManage     Scope = "budgets:manage"
+	BudgetsRead       Scope = "budgets:read"
+	CategoriesRead    Scope = "categories:read"
+	CategoriesWrite   Scope = "categories:write"
+	PayeesRead        Scope = "payees:read"
+	PayeesWrite       Scope = "payees:write"
+	TokensManage      Scope = "tokens:manage"
+	TransactionsRead  Scope = "transactions:read"
+	TransactionsWrite Scope = "transactions:write"
+)
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	// BudgetID The budget the token may be used for. Tokens without one may be used for every budget of their User.
+	BudgetID          *string    `json:"budget_id,omitempty"`
+	CreatedTimestamp  time.Time  `json:"created_timestamp"`
+	ExpiresTimestamp  *time.Time `json:"expires_timestamp,omitempty"`
+	ID                string     `json:"id"`
+	LastUsedTimestamp *time.Time `json:"last_used_timestamp,omitempty"`
+	Name              string     `json:"name"`
+	Scopes            []Scope    `json:"scopes"`
+}
+
+// APITokenInput defines model for APITokenInput.
+type APITokenInput struct {
+	// ExpiresTimestamp When the token expires. It does not if this is not given.
+	ExpiresTimestamp *time.Time `json:"expires_timestamp,omitempty"`
+
+	// Name What uses the token, such as "phone".
+	Name   string  `json:"name"`
+	Scopes []Scope `json:"scopes"`
+}
+
+// AcceptInvitationInput defines model for AcceptInvitationInput.
+type AcceptInvitationInput struct {
+	Token string `json:"token"`
+}
+
+// Account defines model for Account.
+type Account struct {
+	ClearedBalance   int64 `json:"cleared_balance"`
+	EffectiveBalance int64 `json:"effective_balance"`
+
+	// ExternalAccount The account of an Integration an Account is linked to. It is ignored when creating an Account.
+	ExternalAccount *ExternalAccount `json:"external_account,omitempty"`
+
+	// ID Generated if not given when creating an Account.
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// Assignment defines model for Assignment.
+type Assignment struct {
+	Amount     int64  `json:"amount"`
+	CategoryID string `json:"category_id"`
+
+	// ID Kept from any amount already assigned to the Category for the month, otherwise generated.
+	ID string `json:"id,omitempty"`
+
+	// Month The first day of the month. Other dates are truncated to it.
+	Month openapi_types.Date `json:"month"`
+}
+
+// Attachment defines model for Attachment.
+type Attachment struct {
+	ContentType string `json:"content_type"`
+
+	// ExternalID The ID of the attachment in the Integration it was imported from, if it was imported.
+	ExternalID string `json:"external_id,omitempty"`
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+
+	// Size The size of the content, in bytes.
+	Size          int64  `json:"size"`
+	TransactionID string `json:"transaction_id"`
+}
+
+// AttachmentUpload defines model for AttachmentUpload.
+type AttachmentUpload struct {
+	// File The file, named by its filename and typed by its content type.
+	File openapi_types.File `json:"file"`
+}
+
+// Balance defines model for Balance.
+type Balance struct {
+	ClearedBalance   int64 `json:"cleared_balance"`
+	EffectiveBalance int64 `json:"effective_balance"`
+}
+
+// Budget defines model for Budget.
+type Budget struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// BudgetInput defines model for BudgetInput.
+type BudgetInput struct {
+	Name string `json:"name"`
+}
+
+// BudgetInvitation defines model for BudgetInvitation.
+type BudgetInvitation struct {
+	BudgetID         string    `json:"budget_id"`
+	CreatedTimestamp time.Time `json:"created_timestamp"`
+	ExpiresTimestamp time.Time `json:"expires_timestamp"`
+	ID               string    `json:"id"`
+	Role             Role      `json:"role"`
+}
+
+// BudgetMember defines model for BudgetMember.
+type BudgetMember struct {
+	CreatedTimestamp time.Time `json:"created_timestamp"`
+	Role             Role      `json:"role"`
+	UserID           string    `json:"user_id"`
+	Username         string    `json:"username"`
+}
+
+// BudgetMemberInput defines model for BudgetMemberInput.
+type BudgetMemberInput struct {
+	Role Role `json:"role"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	GroupID string `json:"group_id"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+}
+
+// CategoryGroup defines model for CategoryGroup.
+type CategoryGroup struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ExternalAccount The account of an Integration an Account is linked to. It is ignored when creating an Account.
+type ExternalAccount struct {
+	ClearedBalance    int64     `json:"cleared_balance"`
+	EffectiveBalance  int64     `json:"effective_balance"`
+	ID                string    `json:"id"`
+	IntegrationID     string    `json:"integration_id"`
+	LastSyncTimestamp time.Time `json:"last_sync_timestamp"`
+	Name              string    `json:"name"`
+	WriteBack         bool      `json:"write_back"`
+}
+
+// ExternalTransaction defines model for ExternalTransaction.
+type ExternalTransaction struct {
+	Amount            int64              `json:"amount"`
+	Cleared           bool               `json:"cleared"`
+	EffectiveDate     openapi_types.Date `json:"effective_date"`
+	ExternalAccountID string             `json:"external_account_id"`
+	ID                string             `json:"id"`
+	IntegrationID     string             `json:"integration_id"`
+	Memo              string             `json:"memo,omitempty"`
+	PayeeName         string             `json:"payee_name"`
+	Reference         string             `json:"reference,omitempty"`
+}
+
+// Integration defines model for Integration.
+type Integration struct {
+	Capabilities []IntegrationCapabilities `json:"capabilities"`
+	ID           string                    `json:"id"`
+}
+
+// IntegrationCapabilities defines model for Integration.Capabilities.
+type IntegrationCapabilities string
+
+// LoginInput defines model for LoginInput.
+type LoginInput struct {
+	Password string `json:"password"`
+
+	// TOTPCode A current code of the TOTP second factor of the User, required if they have enabled it.
+	TOTPCode string `json:"totp_code,omitempty"`
+	Username string `json:"username"`
+}
+
+// NewAPIToken defines model for NewAPIToken.
+type NewAPIToken struct {
+	APIToken APIToken `json:"api_token"`
+	Token    string   `json:"token"`
+}
+
+// NewBudgetInvitation defines model for NewBudgetInvitation.
+type NewBudgetInvitation struct {
+	Invitation BudgetInvitation `json:"invitation"`
+	Token      string           `json:"token"`
+}
+
+// Payee defines model for Payee.
+type Payee struct {
+	// ID Generated if not given when creating a Payee.
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// Problem defines model for Problem.
+type Problem struct {
+	AccountIDs  []string `json:"account_ids,omitempty"`
+	AccountName string   `json:"account_name,omitempty"`
+
+	// Candidates The names matching a name given in a quick-add input equally well.
+	Candidates       []string `json:"candidates,omitempty"`
+	Capability       string   `json:"capability,omitempty"`
+	CategoryGroupIDs []string `json:"category_group_ids,omitempty"`
+	CategoryIDs      []string `json:"category_ids,omitempty"`
+	Detail           string   `json:"detail,omitempty"`
+	ExternalBalance  *Balance `json:"external_balance,omitempty"`
+	IntegrationID    string   `json:"integration_id,omitempty"`
+	InternalBalance  *Balance `json:"internal_balance,omitempty"`
+	PayeeIDs         []string `json:"payee_ids,omitempty"`
+	PayeeNames       []string `json:"payee_names,omitempty"`
+	Status           int      `json:"status"`
+	Title            string   `json:"title"`
+
+	// Type A URN identifying the type of problem, e.g. "urn:budgit:problem:missing-accounts".
+	Type string `json:"type"`
+}
+
+// QuickAdd defines model for QuickAdd.
+type QuickAdd struct {
+	// Matches The names of Accounts, Payees and Categories matched by the names given.
+	Matches     []QuickAddMatch `json:"matches"`
+	NewPayee    *Payee          `json:"new_payee,omitempty"`
+	Transaction Transaction     `json:"transaction"`
+}
+
+// QuickAddInput defines model for QuickAddInput.
+type QuickAddInput struct {
+	Text string `json:"text"`
+
+	// Today The date relative dates, such as "yesterday", are resolved against. Today on the server if not given.
+	Today *openapi_types.Date `json:"today,omitempty"`
+}
+
+// QuickAddMatch defines model for QuickAddMatch.
+type QuickAddMatch struct {
+	Field   QuickAddMatchField `json:"field"`
+	Given   string             `json:"given"`
+	Matched string             `json:"matched"`
+}
+
+// QuickAddMatchField defines model for QuickAddMatch.Field.
+type QuickAddMatchField string
+
+// Role defines model for Role.
+type Role string
+
+// ScheduledPayment defines model for ScheduledPayment.
+type ScheduledPayment struct {
+	Amount            int64  `json:"amount"`
+	ExternalAccountID string `json:"external_account_id"`
+	Frequency         string `json:"frequency"`
+	ID                string `json:"id"`
+	IntegrationID     string `json:"integration_id"`
+
+	// NextDate Absent if the integration does not know when the payment is next due, as for Open Banking direct debits.
+	NextDate  *openapi_types.Date `json:"next_date,omitempty"`
+	PayeeName string              `json:"payee_name"`
+	Reference string              `json:"reference,omitempty"`
+}
+
+// Scope A capability an API token may be granted.
+type Scope string
+
+// Session defines model for Session.
+type Session struct {
+	ExpiresTimestamp time.Time `json:"expires_timestamp"`
+	Token            string    `json:"token"`
+	User             User      `json:"user"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	AccountID     string             `json:"account_id"`
+	Amount        int64              `json:"amount"`
+	CategoryID    string             `json:"category_id,omitempty"`
+	Cleared       bool               `json:"cleared"`
+	EffectiveDate openapi_types.Date `json:"effective_date"`
+
+	// ID Generated if not given when creating a Transaction.
+	ID       string `json:"id,omitempty"`
+	ImportID string `json:"import_id,omitempty"`
+
+	// ImportIntegrationID The integration the transaction was imported from, if it was not imported from a file.
+	ImportIntegrationID string `json:"import_integration_id,omitempty"`
+	IsPayeeInternal     bool   `json:"is_payee_internal,omitempty"`
+	Memo                string `json:"memo,omitempty"`
+
+	// PayeeID The ID of an Account if is_payee_internal, for transfers between Accounts.
+	PayeeID string `json:"payee_id"`
+	SplitID string `json:"split_id,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	CreatedTimestamp time.Time `json:"created_timestamp"`
+	ID               string    `json:"id"`
+	TOTPEnabled      bool      `json:"totp_enabled"`
+	Username         string    `json:"username"`
+}
+
+// WriteBack defines model for WriteBack.
+type WriteBack struct {
+	WriteBack bool `json:"write_back"`
+}
+
+// AccountID defines model for AccountID.
+type AccountID = string
+
+// BudgetID defines model for BudgetID.
+type BudgetID = string
+
+// TransactionID defines model for TransactionID.
+type TransactionID = string
+
+// CreateAccountsJSONBody defines parameters for CreateAccounts.
+type CreateAccountsJSONBody = []Account
+
+// ListExternalTransactionsParams defines parameters for ListExternalTransactions.
+type ListExternalTransactionsParams struct {
+	// Since The date to list transactions from. Every transaction is listed if it is not given.
+	Since *openapi_types.Date `form:"since,omitempty" json:"since,omitempty"`
+}
+
+// ImportExternalTransactionsParams defines parameters for ImportExternalTransactions.
+type ImportExternalTransactionsParams struct {
+	// Since The date to import transactions from. Every transaction is imported if it is not given.
+	Since *openapi_types.Date `form:"since,omitempty" json:"since,omitempty"`
+}
+
+// ListAssignmentsParams defines parameters for ListAssignments.
+type ListAssignmentsParams struct {
+	// Month A date within the month.
+	Month openapi_types.Date `form:"month" json:"month"`
+}
+
+// AssignJSONBody defines parameters for Assign.
+type AssignJSONBody = []Assignment
+
+// CreatePayeesJSONBody defines parameters for CreatePayees.
+type CreatePayeesJSONBody = []Payee
+
+// CreateTransactionsJSONBody defines parameters for CreateTransactions.
+type CreateTransactionsJSONBody = []Transaction
+
+// UpdateTransactionsJSONBody defines parameters for UpdateTransactions.
+type UpdateTransactionsJSONBody = []Transaction
+
+// CreateAccountsJSONRequestBody defines body for CreateAccounts for application/json ContentType.
+type CreateAccountsJSONRequestBody = CreateAccountsJSONBody
+
+// SetAccountWriteBackJSONRequestBody defines body for SetAccountWriteBack for application/json ContentType.
+type SetAccountWriteBackJSONRequestBody = WriteBack
+
+// AssignJSONRequestBody defines body for Assign for application/json ContentType.
+type AssignJSONRequestBody = AssignJSONBody
+
+// CreateBudgetJSONRequestBody defines body for CreateBudget for application/json ContentType.
+type CreateBudgetJSONRequestBody = BudgetInput
+
+// CreateBudgetInvitationJSONRequestBody defines body for CreateBudgetInvitation for application/json ContentType.
+type CreateBudgetInvitationJSONRequestBody = BudgetMemberInput
+
+// SetBudgetMemberJSONRequestBody defines body for SetBudgetMember for application/json ContentType.
+type SetBudgetMemberJSONRequestBody = BudgetMemberInput
+
+// AcceptBudgetInvitationJSONRequestBody defines body for AcceptBudgetInvitation for application/json ContentType.
+type AcceptBudgetInvitationJSONRequestBody = AcceptInvitationInput
+
+// CreatePayeesJSONRequestBody defines body for CreatePayees for application/json ContentType.
+type CreatePayeesJSONRequestBody = CreatePayeesJSONBody
+
+// QuickAddJSONRequestBody defines body for QuickAdd for application/json ContentType.
+type QuickAddJSONRequestBody = QuickAddInput
+
+// PreviewQuickAddJSONRequestBody defines body for PreviewQuickAdd for application/json ContentType.
+type PreviewQuickAddJSONRequestBody = QuickAddInput
+
+// LoginJSONRequestBody defines body for Login for application/json ContentType.
+type LoginJSONRequestBody = LoginInput
+
+// CreateAPITokenJSONRequestBody defines body for CreateAPIToken for application/json ContentType.
+type CreateAPITokenJSONRequestBody = APITokenInput
+
+// CreateTransactionsJSONRequestBody defines body for CreateTransactions for application/json ContentType.
+type CreateTransactionsJSONRequestBody = CreateTransactionsJSONBody
+
+// UpdateTransactionsJSONRequestBody defines body for UpdateTransactions for application/json ContentType.
+type UpdateTransactionsJSONRequestBody = UpdateTransactionsJSONBody
+
+// UploadAttachmentMultipartRequestBody defines body for UploadAttachment for multipart/form-data ContentType.
+type UploadAttachmentMultipartRequestBody = AttachmentUpload
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ListAccounts request
+	ListAccounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAccountsWithBody request with any body
+	CreateAccountsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAccounts(ctx context.Context, body CreateAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListExternalTransactions request
+	ListExternalTransactions(ctx context.Context, accountID AccountID, params *ListExternalTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportExternalTransactions request
+	ImportExternalTransactions(ctx context.Context, accountID AccountID, params *ImportExternalTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListScheduledPayments request
+	ListScheduledPayments(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SyncAccount request
+	SyncAccount(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTransactions request
+	ListTransactions(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetAccountWriteBackWithBody request with any body
+	SetAccountWriteBackWithBody(ctx context.Context, accountID AccountID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetAccountWriteBack(ctx context.Context, accountID AccountID, body SetAccountWriteBackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAssignments request
+	ListAssignments(ctx context.Context, params *ListAssignmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AssignWithBody request with any body
+	AssignWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Assign(ctx context.Context, body AssignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DownloadAttachment request
+	DownloadAttachment(ctx context.Context, attachmentID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBudgets request
+	ListBudgets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBudgetWithBody request with any body
+	CreateBudgetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateBudget(ctx context.Context, body CreateBudgetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateBudgetInvitationWithBody request with any body
+	CreateBudgetInvitationWithBody(ctx context.Context, budgetID BudgetID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateBudgetInvitation(ctx context.Context, budgetID BudgetID, body CreateBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteBudgetInvitation request
+	DeleteBudgetInvitation(ctx context.Context, budgetID BudgetID, invitationID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListBudgetMembers request
+	ListBudgetMembers(ctx context.Context, budgetID BudgetID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RemoveBudgetMember request
+	RemoveBudgetMember(ctx context.Context, budgetID BudgetID, username string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetBudgetMemberWithBody request with any body
+	SetBudgetMemberWithBody(ctx context.Context, budgetID BudgetID, username string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetBudgetMember(ctx context.Context, budgetID BudgetID, username string, body SetBudgetMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCategories request
+	ListCategories(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListCategoryGroups request
+	ListCategoryGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListIntegrations request
+	ListIntegrations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LoadAccountsFromIntegration request
+	LoadAccountsFromIntegration(ctx context.Context, integrationID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AcceptBudgetInvitationWithBody request with any body
+	AcceptBudgetInvitationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AcceptBudgetInvitation(ctx context.Context, body AcceptBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListPayees request
+	ListPayees(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePayeesWithBody request with any body
+	CreatePayeesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePayees(ctx context.Context, body CreatePayeesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// QuickAddWithBody request with any body
+	QuickAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	QuickAdd(ctx context.Context, body QuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PreviewQuickAddWithBody request with any body
+	PreviewQuickAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PreviewQuickAdd(ctx context.Context, body PreviewQuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LoginWithBody request with any body
+	LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Logout request
+	Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAPITokens request
+	ListAPITokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAPITokenWithBody request with any body
+	CreateAPITokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAPIToken(ctx context.Context, body CreateAPITokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteAPIToken request
+	DeleteAPIToken(ctx context.Context, tokenID string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTransactionsWithBody request with any body
+	CreateTransactionsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTransactions(ctx context.Context, body CreateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateTransactionsWithBody request with any body
+	UpdateTransactionsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTransactions(ctx context.Context, body UpdateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListAttachments request
+	ListAttachments(ctx context.Context, transactionID TransactionID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadAttachmentWithBody request with any body
+	UploadAttachmentWithBody(ctx context.Context, transactionID TransactionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCurrentUser request
+	GetCurrentUser(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ListAccounts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAccountsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAccountsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAccountsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAccounts(ctx context.Context, body CreateAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAccountsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListExternalTransactions(ctx context.Context, accountID AccountID, params *ListExternalTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListExternalTransactionsRequest(c.Server, accountID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportExternalTransactions(ctx context.Context, accountID AccountID, params *ImportExternalTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportExternalTransactionsRequest(c.Server, accountID, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListScheduledPayments(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListScheduledPaymentsRequest(c.Server, accountID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SyncAccount(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSyncAccountRequest(c.Server, accountID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTransactions(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTransactionsRequest(c.Server, accountID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAccountWriteBackWithBody(ctx context.Context, accountID AccountID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAccountWriteBackRequestWithBody(c.Server, accountID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAccountWriteBack(ctx context.Context, accountID AccountID, body SetAccountWriteBackJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAccountWriteBackRequest(c.Server, accountID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAssignments(ctx context.Context, params *ListAssignmentsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAssignmentsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AssignWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Assign(ctx context.Context, body AssignJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAssignRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DownloadAttachment(ctx context.Context, attachmentID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDownloadAttachmentRequest(c.Server, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListBudgets(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBudgetsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBudgetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBudgetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBudget(ctx context.Context, body CreateBudgetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBudgetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBudgetInvitationWithBody(ctx context.Context, budgetID BudgetID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBudgetInvitationRequestWithBody(c.Server, budgetID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateBudgetInvitation(ctx context.Context, budgetID BudgetID, body CreateBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateBudgetInvitationRequest(c.Server, budgetID, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteBudgetInvitation(ctx context.Context, budgetID BudgetID, invitationID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteBudgetInvitationRequest(c.Server, budgetID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListBudgetMembers(ctx context.Context, budgetID BudgetID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListBudgetMembersRequest(c.Server, budgetID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RemoveBudgetMember(ctx context.Context, budgetID BudgetID, username string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRemoveBudgetMemberRequest(c.Server, budgetID, username)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetBudgetMemberWithBody(ctx context.Context, budgetID BudgetID, username string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetBudgetMemberRequestWithBody(c.Server, budgetID, username, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetBudgetMember(ctx context.Context, budgetID BudgetID, username string, body SetBudgetMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetBudgetMemberRequest(c.Server, budgetID, username, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCategories(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCategoriesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListCategoryGroups(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListCategoryGroupsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListIntegrations(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListIntegrationsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoadAccountsFromIntegration(ctx context.Context, integrationID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoadAccountsFromIntegrationRequest(c.Server, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptBudgetInvitationWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptBudgetInvitationRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AcceptBudgetInvitation(ctx context.Context, body AcceptBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAcceptBudgetInvitationRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPISpecRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListPayees(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListPayeesRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePayeesWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePayeesRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePayees(ctx context.Context, body CreatePayeesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePayeesRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QuickAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQuickAddRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) QuickAdd(ctx context.Context, body QuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQuickAddRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewQuickAddWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewQuickAddRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PreviewQuickAdd(ctx context.Context, body PreviewQuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPreviewQuickAddRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LoginWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Login(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLoginRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Logout(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogoutRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAPITokens(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAPITokensRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPITokenWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPITokenRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAPIToken(ctx context.Context, body CreateAPITokenJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAPITokenRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteAPIToken(ctx context.Context, tokenID string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteAPITokenRequest(c.Server, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransactionsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransactions(ctx context.Context, body CreateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTransactionsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTransactionsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTransactions(ctx context.Context, body UpdateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTransactionsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListAttachments(ctx context.Context, transactionID TransactionID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAttachmentsRequest(c.Server, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadAttachmentWithBody(ctx context.Context, transactionID TransactionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadAttachmentRequestWithBody(c.Server, transactionID, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCurrentUserRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewListAccountsRequest generates requests for ListAccounts
+func NewListAccountsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateAccountsRequest calls the generic CreateAccounts builder with application/json body
+func NewCreateAccountsRequest(server string, body CreateAccountsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAccountsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateAccountsRequestWithBody generates requests for CreateAccounts with any type of body
+func NewCreateAccountsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListExternalTransactionsRequest generates requests for ListExternalTransactions
+func NewListExternalTransactionsRequest(server string, accountID AccountID, params *ListExternalTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/external-transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewImportExternalTransactionsRequest generates requests for ImportExternalTransactions
+func NewImportExternalTransactionsRequest(server string, accountID AccountID, params *ImportExternalTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/external-transactions/import", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListScheduledPaymentsRequest generates requests for ListScheduledPayments
+func NewListScheduledPaymentsRequest(server string, accountID AccountID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/scheduled-payments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSyncAccountRequest generates requests for SyncAccount
+func NewSyncAccountRequest(server string, accountID AccountID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/sync", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTransactionsRequest generates requests for ListTransactions
+func NewListTransactionsRequest(server string, accountID AccountID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetAccountWriteBackRequest calls the generic SetAccountWriteBack builder with application/json body
+func NewSetAccountWriteBackRequest(server string, accountID AccountID, body SetAccountWriteBackJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetAccountWriteBackRequestWithBody(server, accountID, "application/json", bodyReader)
+}
+
+// NewSetAccountWriteBackRequestWithBody generates requests for SetAccountWriteBack with any type of body
+func NewSetAccountWriteBackRequestWithBody(server string, accountID AccountID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "accountID", runtime.ParamLocationPath, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/accounts/%s/write-back", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListAssignmentsRequest generates requests for ListAssignments
+func NewListAssignmentsRequest(server string, params *ListAssignmentsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/assignments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "month", runtime.ParamLocationQuery, params.Month); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAssignRequest calls the generic Assign builder with application/json body
+func NewAssignRequest(server string, body AssignJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAssignRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAssignRequestWithBody generates requests for Assign with any type of body
+func NewAssignRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/assignments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDownloadAttachmentRequest generates requests for DownloadAttachment
+func NewDownloadAttachmentRequest(server string, attachmentID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "attachmentID", runtime.ParamLocationPath, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/attachments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListBudgetsRequest generates requests for ListBudgets
+func NewListBudgetsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateBudgetRequest calls the generic CreateBudget builder with application/json body
+func NewCreateBudgetRequest(server string, body CreateBudgetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBudgetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateBudgetRequestWithBody generates requests for CreateBudget with any type of body
+func NewCreateBudgetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateBudgetInvitationRequest calls the generic CreateBudgetInvitation builder with application/json body
+func NewCreateBudgetInvitationRequest(server string, budgetID BudgetID, body CreateBudgetInvitationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateBudgetInvitationRequestWithBody(server, budgetID, "application/json", bodyReader)
+}
+
+// NewCreateBudgetInvitationRequestWithBody generates requests for CreateBudgetInvitation with any type of body
+func NewCreateBudgetInvitationRequestWithBody(server string, budgetID BudgetID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budgetID", runtime.ParamLocationPath, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets/%s/invitations", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteBudgetInvitationRequest generates requests for DeleteBudgetInvitation
+func NewDeleteBudgetInvitationRequest(server string, budgetID BudgetID, invitationID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budgetID", runtime.ParamLocationPath, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "invitationID", runtime.ParamLocationPath, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets/%s/invitations/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListBudgetMembersRequest generates requests for ListBudgetMembers
+func NewListBudgetMembersRequest(server string, budgetID BudgetID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budgetID", runtime.ParamLocationPath, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets/%s/members", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRemoveBudgetMemberRequest generates requests for RemoveBudgetMember
+func NewRemoveBudgetMemberRequest(server string, budgetID BudgetID, username string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budgetID", runtime.ParamLocationPath, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "username", runtime.ParamLocationPath, username)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetBudgetMemberRequest calls the generic SetBudgetMember builder with application/json body
+func NewSetBudgetMemberRequest(server string, budgetID BudgetID, username string, body SetBudgetMemberJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetBudgetMemberRequestWithBody(server, budgetID, username, "application/json", bodyReader)
+}
+
+// NewSetBudgetMemberRequestWithBody generates requests for SetBudgetMember with any type of body
+func NewSetBudgetMemberRequestWithBody(server string, budgetID BudgetID, username string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budgetID", runtime.ParamLocationPath, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "username", runtime.ParamLocationPath, username)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/budgets/%s/members/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListCategoriesRequest generates requests for ListCategories
+func NewListCategoriesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/categories")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListCategoryGroupsRequest generates requests for ListCategoryGroups
+func NewListCategoryGroupsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/category-groups")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListIntegrationsRequest generates requests for ListIntegrations
+func NewListIntegrationsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/integrations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLoadAccountsFromIntegrationRequest generates requests for LoadAccountsFromIntegration
+func NewLoadAccountsFromIntegrationRequest(server string, integrationID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "integrationID", runtime.ParamLocationPath, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/integrations/%s/accounts", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAcceptBudgetInvitationRequest calls the generic AcceptBudgetInvitation builder with application/json body
+func NewAcceptBudgetInvitationRequest(server string, body AcceptBudgetInvitationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAcceptBudgetInvitationRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAcceptBudgetInvitationRequestWithBody generates requests for AcceptBudgetInvitation with any type of body
+func NewAcceptBudgetInvitationRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/invitations/accept")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetOpenAPISpecRequest generates requests for GetOpenAPISpec
+func NewGetOpenAPISpecRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListPayeesRequest generates requests for ListPayees
+func NewListPayeesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/payees")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePayeesRequest calls the generic CreatePayees builder with application/json body
+func NewCreatePayeesRequest(server string, body CreatePayeesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePayeesRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreatePayeesRequestWithBody generates requests for CreatePayees with any type of body
+func NewCreatePayeesRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/payees")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewQuickAddRequest calls the generic QuickAdd builder with application/json body
+func NewQuickAddRequest(server string, body QuickAddJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewQuickAddRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewQuickAddRequestWithBody generates requests for QuickAdd with any type of body
+func NewQuickAddRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/quick-add")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPreviewQuickAddRequest calls the generic PreviewQuickAdd builder with application/json body
+func NewPreviewQuickAddRequest(server string, body PreviewQuickAddJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPreviewQuickAddRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPreviewQuickAddRequestWithBody generates requests for PreviewQuickAdd with any type of body
+func NewPreviewQuickAddRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/quick-add/preview")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLoginRequest calls the generic Login builder with application/json body
+func NewLoginRequest(server string, body LoginJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLoginRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewLoginRequestWithBody generates requests for Login with any type of body
+func NewLoginRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/sessions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLogoutRequest generates requests for Logout
+func NewLogoutRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/sessions/current")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListAPITokensRequest generates requests for ListAPITokens
+func NewListAPITokensRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateAPITokenRequest calls the generic CreateAPIToken builder with application/json body
+func NewCreateAPITokenRequest(server string, body CreateAPITokenJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAPITokenRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateAPITokenRequestWithBody generates requests for CreateAPIToken with any type of body
+func NewCreateAPITokenRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteAPITokenRequest generates requests for DeleteAPIToken
+func NewDeleteAPITokenRequest(server string, tokenID string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "tokenID", runtime.ParamLocationPath, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/tokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTransactionsRequest calls the generic CreateTransactions builder with application/json body
+func NewCreateTransactionsRequest(server string, body CreateTransactionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTransactionsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTransactionsRequestWithBody generates requests for CreateTransactions with any type of body
+func NewCreateTransactionsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUpdateTransactionsRequest calls the generic UpdateTransactions builder with application/json body
+func NewUpdateTransactionsRequest(server string, body UpdateTransactionsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTransactionsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateTransactionsRequestWithBody generates requests for UpdateTransactions with any type of body
+func NewUpdateTransactionsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListAttachmentsRequest generates requests for ListAttachments
+func NewListAttachmentsRequest(server string, transactionID TransactionID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "transactionID", runtime.ParamLocationPath, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/transactions/%s/attachments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUploadAttachmentRequestWithBody generates requests for UploadAttachment with any type of body
+func NewUploadAttachmentRequestWithBody(server string, transactionID TransactionID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "transactionID", runtime.ParamLocationPath, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/transactions/%s/attachments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetCurrentUserRequest generates requests for GetCurrentUser
+func NewGetCurrentUserRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/user")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListAccountsWithResponse request
+	ListAccountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAccountsResponse, error)
+
+	// CreateAccountsWithBodyWithResponse request with any body
+	CreateAccountsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAccountsResponse, error)
+
+	CreateAccountsWithResponse(ctx context.Context, body CreateAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAccountsResponse, error)
+
+	// ListExternalTransactionsWithResponse request
+	ListExternalTransactionsWithResponse(ctx context.Context, accountID AccountID, params *ListExternalTransactionsParams, reqEditors ...RequestEditorFn) (*ListExternalTransactionsResponse, error)
+
+	// ImportExternalTransactionsWithResponse request
+	ImportExternalTransactionsWithResponse(ctx context.Context, accountID AccountID, params *ImportExternalTransactionsParams, reqEditors ...RequestEditorFn) (*ImportExternalTransactionsResponse, error)
+
+	// ListScheduledPaymentsWithResponse request
+	ListScheduledPaymentsWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*ListScheduledPaymentsResponse, error)
+
+	// SyncAccountWithResponse request
+	SyncAccountWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*SyncAccountResponse, error)
+
+	// ListTransactionsWithResponse request
+	ListTransactionsWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
+
+	// SetAccountWriteBackWithBodyWithResponse request with any body
+	SetAccountWriteBackWithBodyWithResponse(ctx context.Context, accountID AccountID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAccountWriteBackResponse, error)
+
+	SetAccountWriteBackWithResponse(ctx context.Context, accountID AccountID, body SetAccountWriteBackJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAccountWriteBackResponse, error)
+
+	// ListAssignmentsWithResponse request
+	ListAssignmentsWithResponse(ctx context.Context, params *ListAssignmentsParams, reqEditors ...RequestEditorFn) (*ListAssignmentsResponse, error)
+
+	// AssignWithBodyWithResponse request with any body
+	AssignWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignResponse, error)
+
+	AssignWithResponse(ctx context.Context, body AssignJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignResponse, error)
+
+	// DownloadAttachmentWithResponse request
+	DownloadAttachmentWithResponse(ctx context.Context, attachmentID string, reqEditors ...RequestEditorFn) (*DownloadAttachmentResponse, error)
+
+	// ListBudgetsWithResponse request
+	ListBudgetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBudgetsResponse, error)
+
+	// CreateBudgetWithBodyWithResponse request with any body
+	CreateBudgetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBudgetResponse, error)
+
+	CreateBudgetWithResponse(ctx context.Context, body CreateBudgetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBudgetResponse, error)
+
+	// CreateBudgetInvitationWithBodyWithResponse request with any body
+	CreateBudgetInvitationWithBodyWithResponse(ctx context.Context, budgetID BudgetID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBudgetInvitationResponse, error)
+
+	CreateBudgetInvitationWithResponse(ctx context.Context, budgetID BudgetID, body CreateBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBudgetInvitationResponse, error)
+
+	// DeleteBudgetInvitationWithResponse request
+	DeleteBudgetInvitationWithResponse(ctx context.Context, budgetID BudgetID, invitationID string, reqEditors ...RequestEditorFn) (*DeleteBudgetInvitationResponse, error)
+
+	// ListBudgetMembersWithResponse request
+	ListBudgetMembersWithResponse(ctx context.Context, budgetID BudgetID, reqEditors ...RequestEditorFn) (*ListBudgetMembersResponse, error)
+
+	// RemoveBudgetMemberWithResponse request
+	RemoveBudgetMemberWithResponse(ctx context.Context, budgetID BudgetID, username string, reqEditors ...RequestEditorFn) (*RemoveBudgetMemberResponse, error)
+
+	// SetBudgetMemberWithBodyWithResponse request with any body
+	SetBudgetMemberWithBodyWithResponse(ctx context.Context, budgetID BudgetID, username string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetBudgetMemberResponse, error)
+
+	SetBudgetMemberWithResponse(ctx context.Context, budgetID BudgetID, username string, body SetBudgetMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SetBudgetMemberResponse, error)
+
+	// ListCategoriesWithResponse request
+	ListCategoriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCategoriesResponse, error)
+
+	// ListCategoryGroupsWithResponse request
+	ListCategoryGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCategoryGroupsResponse, error)
+
+	// ListIntegrationsWithResponse request
+	ListIntegrationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListIntegrationsResponse, error)
+
+	// LoadAccountsFromIntegrationWithResponse request
+	LoadAccountsFromIntegrationWithResponse(ctx context.Context, integrationID string, reqEditors ...RequestEditorFn) (*LoadAccountsFromIntegrationResponse, error)
+
+	// AcceptBudgetInvitationWithBodyWithResponse request with any body
+	AcceptBudgetInvitationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AcceptBudgetInvitationResponse, error)
+
+	AcceptBudgetInvitationWithResponse(ctx context.Context, body AcceptBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*AcceptBudgetInvitationResponse, error)
+
+	// GetOpenAPISpecWithResponse request
+	GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error)
+
+	// ListPayeesWithResponse request
+	ListPayeesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPayeesResponse, error)
+
+	// CreatePayeesWithBodyWithResponse request with any body
+	CreatePayeesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePayeesResponse, error)
+
+	CreatePayeesWithResponse(ctx context.Context, body CreatePayeesJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePayeesResponse, error)
+
+	// QuickAddWithBodyWithResponse request with any body
+	QuickAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QuickAddResponse, error)
+
+	QuickAddWithResponse(ctx context.Context, body QuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*QuickAddResponse, error)
+
+	// PreviewQuickAddWithBodyWithResponse request with any body
+	PreviewQuickAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewQuickAddResponse, error)
+
+	PreviewQuickAddWithResponse(ctx context.Context, body PreviewQuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewQuickAddResponse, error)
+
+	// LoginWithBodyWithResponse request with any body
+	LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error)
+
+	// LogoutWithResponse request
+	LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error)
+
+	// ListAPITokensWithResponse request
+	ListAPITokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAPITokensResponse, error)
+
+	// CreateAPITokenWithBodyWithResponse request with any body
+	CreateAPITokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPITokenResponse, error)
+
+	CreateAPITokenWithResponse(ctx context.Context, body CreateAPITokenJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPITokenResponse, error)
+
+	// DeleteAPITokenWithResponse request
+	DeleteAPITokenWithResponse(ctx context.Context, tokenID string, reqEditors ...RequestEditorFn) (*DeleteAPITokenResponse, error)
+
+	// CreateTransactionsWithBodyWithResponse request with any body
+	CreateTransactionsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionsResponse, error)
+
+	CreateTransactionsWithResponse(ctx context.Context, body CreateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionsResponse, error)
+
+	// UpdateTransactionsWithBodyWithResponse request with any body
+	UpdateTransactionsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTransactionsResponse, error)
+
+	UpdateTransactionsWithResponse(ctx context.Context, body UpdateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTransactionsResponse, error)
+
+	// ListAttachmentsWithResponse request
+	ListAttachmentsWithResponse(ctx context.Context, transactionID TransactionID, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error)
+
+	// UploadAttachmentWithBodyWithResponse request with any body
+	UploadAttachmentWithBodyWithResponse(ctx context.Context, transactionID TransactionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error)
+
+	// GetCurrentUserWithResponse request
+	GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error)
+}
+
+type ListAccountsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Account
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAccountsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *[]Account
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListExternalTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]ExternalTransaction
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListExternalTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListExternalTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportExternalTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *[]Transaction
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportExternalTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportExternalTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListScheduledPaymentsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]ScheduledPayment
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListScheduledPaymentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListScheduledPaymentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SyncAccountResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SyncAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SyncAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Transaction
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetAccountWriteBackResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SetAccountWriteBackResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetAccountWriteBackResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAssignmentsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Assignment
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAssignmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAssignmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AssignResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Assignment
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r AssignResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AssignResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DownloadAttachmentResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DownloadAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DownloadAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBudgetsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Budget
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBudgetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBudgetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateBudgetResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *Budget
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBudgetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBudgetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateBudgetInvitationResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *NewBudgetInvitation
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateBudgetInvitationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateBudgetInvitationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteBudgetInvitationResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteBudgetInvitationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteBudgetInvitationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListBudgetMembersResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]BudgetMember
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListBudgetMembersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListBudgetMembersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RemoveBudgetMemberResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r RemoveBudgetMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RemoveBudgetMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetBudgetMemberResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r SetBudgetMemberResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetBudgetMemberResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCategoriesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Category
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCategoriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCategoriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListCategoryGroupsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]CategoryGroup
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListCategoryGroupsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListCategoryGroupsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListIntegrationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Integration
+}
+
+// Status returns HTTPResponse.Status
+func (r ListIntegrationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListIntegrationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoadAccountsFromIntegrationResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *[]Account
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r LoadAccountsFromIntegrationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoadAccountsFromIntegrationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AcceptBudgetInvitationResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *Budget
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r AcceptBudgetInvitationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AcceptBudgetInvitationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPISpecResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPISpecResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPISpecResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListPayeesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Payee
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListPayeesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListPayeesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePayeesResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *[]Payee
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePayeesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePayeesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type QuickAddResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *QuickAdd
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r QuickAddResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r QuickAddResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PreviewQuickAddResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *QuickAdd
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r PreviewQuickAddResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PreviewQuickAddResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LoginResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *Session
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r LoginResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LoginResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LogoutResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r LogoutResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogoutResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAPITokensResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]APIToken
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAPITokensResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAPITokensResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAPITokenResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *NewAPIToken
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAPITokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAPITokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteAPITokenResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteAPITokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteAPITokenResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *[]Transaction
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTransactionsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Transaction
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]Attachment
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAttachmentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAttachmentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadAttachmentResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON201                       *Attachment
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadAttachmentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadAttachmentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCurrentUserResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *User
+	ApplicationProblemJSONDefault *Problem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCurrentUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCurrentUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListAccountsWithResponse request returning *ListAccountsResponse
+func (c *ClientWithResponses) ListAccountsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAccountsResponse, error) {
+	rsp, err := c.ListAccounts(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAccountsResponse(rsp)
+}
+
+// CreateAccountsWithBodyWithResponse request with arbitrary body returning *CreateAccountsResponse
+func (c *ClientWithResponses) CreateAccountsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAccountsResponse, error) {
+	rsp, err := c.CreateAccountsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAccountsResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAccountsWithResponse(ctx context.Context, body CreateAccountsJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAccountsResponse, error) {
+	rsp, err := c.CreateAccounts(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAccountsResponse(rsp)
+}
+
+// ListExternalTransactionsWithResponse request returning *ListExternalTransactionsResponse
+func (c *ClientWithResponses) ListExternalTransactionsWithResponse(ctx context.Context, accountID AccountID, params *ListExternalTransactionsParams, reqEditors ...RequestEditorFn) (*ListExternalTransactionsResponse, error) {
+	rsp, err := c.ListExternalTransactions(ctx, accountID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListExternalTransactionsResponse(rsp)
+}
+
+// ImportExternalTransactionsWithResponse request returning *ImportExternalTransactionsResponse
+func (c *ClientWithResponses) ImportExternalTransactionsWithResponse(ctx context.Context, accountID AccountID, params *ImportExternalTransactionsParams, reqEditors ...RequestEditorFn) (*ImportExternalTransactionsResponse, error) {
+	rsp, err := c.ImportExternalTransactions(ctx, accountID, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportExternalTransactionsResponse(rsp)
+}
+
+// ListScheduledPaymentsWithResponse request returning *ListScheduledPaymentsResponse
+func (c *ClientWithResponses) ListScheduledPaymentsWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*ListScheduledPaymentsResponse, error) {
+	rsp, err := c.ListScheduledPayments(ctx, accountID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListScheduledPaymentsResponse(rsp)
+}
+
+// SyncAccountWithResponse request returning *SyncAccountResponse
+func (c *ClientWithResponses) SyncAccountWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*SyncAccountResponse, error) {
+	rsp, err := c.SyncAccount(ctx, accountID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSyncAccountResponse(rsp)
+}
+
+// ListTransactionsWithResponse request returning *ListTransactionsResponse
+func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, accountID AccountID, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
+	rsp, err := c.ListTransactions(ctx, accountID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTransactionsResponse(rsp)
+}
+
+// SetAccountWriteBackWithBodyWithResponse request with arbitrary body returning *SetAccountWriteBackResponse
+func (c *ClientWithResponses) SetAccountWriteBackWithBodyWithResponse(ctx context.Context, accountID AccountID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAccountWriteBackResponse, error) {
+	rsp, err := c.SetAccountWriteBackWithBody(ctx, accountID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAccountWriteBackResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetAccountWriteBackWithResponse(ctx context.Context, accountID AccountID, body SetAccountWriteBackJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAccountWriteBackResponse, error) {
+	rsp, err := c.SetAccountWriteBack(ctx, accountID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAccountWriteBackResponse(rsp)
+}
+
+// ListAssignmentsWithResponse request returning *ListAssignmentsResponse
+func (c *ClientWithResponses) ListAssignmentsWithResponse(ctx context.Context, params *ListAssignmentsParams, reqEditors ...RequestEditorFn) (*ListAssignmentsResponse, error) {
+	rsp, err := c.ListAssignments(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAssignmentsResponse(rsp)
+}
+
+// AssignWithBodyWithResponse request with arbitrary body returning *AssignResponse
+func (c *ClientWithResponses) AssignWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AssignResponse, error) {
+	rsp, err := c.AssignWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignResponse(rsp)
+}
+
+func (c *ClientWithResponses) AssignWithResponse(ctx context.Context, body AssignJSONRequestBody, reqEditors ...RequestEditorFn) (*AssignResponse, error) {
+	rsp, err := c.Assign(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAssignResponse(rsp)
+}
+
+// DownloadAttachmentWithResponse request returning *DownloadAttachmentResponse
+func (c *ClientWithResponses) DownloadAttachmentWithResponse(ctx context.Context, attachmentID string, reqEditors ...RequestEditorFn) (*DownloadAttachmentResponse, error) {
+	rsp, err := c.DownloadAttachment(ctx, attachmentID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDownloadAttachmentResponse(rsp)
+}
+
+// ListBudgetsWithResponse request returning *ListBudgetsResponse
+func (c *ClientWithResponses) ListBudgetsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListBudgetsResponse, error) {
+	rsp, err := c.ListBudgets(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBudgetsResponse(rsp)
+}
+
+// CreateBudgetWithBodyWithResponse request with arbitrary body returning *CreateBudgetResponse
+func (c *ClientWithResponses) CreateBudgetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBudgetResponse, error) {
+	rsp, err := c.CreateBudgetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBudgetResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateBudgetWithResponse(ctx context.Context, body CreateBudgetJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBudgetResponse, error) {
+	rsp, err := c.CreateBudget(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBudgetResponse(rsp)
+}
+
+// CreateBudgetInvitationWithBodyWithResponse request with arbitrary body returning *CreateBudgetInvitationResponse
+func (c *ClientWithResponses) CreateBudgetInvitationWithBodyWithResponse(ctx context.Context, budgetID BudgetID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateBudgetInvitationResponse, error) {
+	rsp, err := c.CreateBudgetInvitationWithBody(ctx, budgetID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBudgetInvitationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateBudgetInvitationWithResponse(ctx context.Context, budgetID BudgetID, body CreateBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateBudgetInvitationResponse, error) {
+	rsp, err := c.CreateBudgetInvitation(ctx, budgetID, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateBudgetInvitationResponse(rsp)
+}
+
+// DeleteBudgetInvitationWithResponse request returning *DeleteBudgetInvitationResponse
+func (c *ClientWithResponses) DeleteBudgetInvitationWithResponse(ctx context.Context, budgetID BudgetID, invitationID string, reqEditors ...RequestEditorFn) (*DeleteBudgetInvitationResponse, error) {
+	rsp, err := c.DeleteBudgetInvitation(ctx, budgetID, invitationID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteBudgetInvitationResponse(rsp)
+}
+
+// ListBudgetMembersWithResponse request returning *ListBudgetMembersResponse
+func (c *ClientWithResponses) ListBudgetMembersWithResponse(ctx context.Context, budgetID BudgetID, reqEditors ...RequestEditorFn) (*ListBudgetMembersResponse, error) {
+	rsp, err := c.ListBudgetMembers(ctx, budgetID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListBudgetMembersResponse(rsp)
+}
+
+// RemoveBudgetMemberWithResponse request returning *RemoveBudgetMemberResponse
+func (c *ClientWithResponses) RemoveBudgetMemberWithResponse(ctx context.Context, budgetID BudgetID, username string, reqEditors ...RequestEditorFn) (*RemoveBudgetMemberResponse, error) {
+	rsp, err := c.RemoveBudgetMember(ctx, budgetID, username, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRemoveBudgetMemberResponse(rsp)
+}
+
+// SetBudgetMemberWithBodyWithResponse request with arbitrary body returning *SetBudgetMemberResponse
+func (c *ClientWithResponses) SetBudgetMemberWithBodyWithResponse(ctx context.Context, budgetID BudgetID, username string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetBudgetMemberResponse, error) {
+	rsp, err := c.SetBudgetMemberWithBody(ctx, budgetID, username, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetBudgetMemberResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetBudgetMemberWithResponse(ctx context.Context, budgetID BudgetID, username string, body SetBudgetMemberJSONRequestBody, reqEditors ...RequestEditorFn) (*SetBudgetMemberResponse, error) {
+	rsp, err := c.SetBudgetMember(ctx, budgetID, username, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetBudgetMemberResponse(rsp)
+}
+
+// ListCategoriesWithResponse request returning *ListCategoriesResponse
+func (c *ClientWithResponses) ListCategoriesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCategoriesResponse, error) {
+	rsp, err := c.ListCategories(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCategoriesResponse(rsp)
+}
+
+// ListCategoryGroupsWithResponse request returning *ListCategoryGroupsResponse
+func (c *ClientWithResponses) ListCategoryGroupsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListCategoryGroupsResponse, error) {
+	rsp, err := c.ListCategoryGroups(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListCategoryGroupsResponse(rsp)
+}
+
+// ListIntegrationsWithResponse request returning *ListIntegrationsResponse
+func (c *ClientWithResponses) ListIntegrationsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListIntegrationsResponse, error) {
+	rsp, err := c.ListIntegrations(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListIntegrationsResponse(rsp)
+}
+
+// LoadAccountsFromIntegrationWithResponse request returning *LoadAccountsFromIntegrationResponse
+func (c *ClientWithResponses) LoadAccountsFromIntegrationWithResponse(ctx context.Context, integrationID string, reqEditors ...RequestEditorFn) (*LoadAccountsFromIntegrationResponse, error) {
+	rsp, err := c.LoadAccountsFromIntegration(ctx, integrationID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoadAccountsFromIntegrationResponse(rsp)
+}
+
+// AcceptBudgetInvitationWithBodyWithResponse request with arbitrary body returning *AcceptBudgetInvitationResponse
+func (c *ClientWithResponses) AcceptBudgetInvitationWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AcceptBudgetInvitationResponse, error) {
+	rsp, err := c.AcceptBudgetInvitationWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptBudgetInvitationResponse(rsp)
+}
+
+func (c *ClientWithResponses) AcceptBudgetInvitationWithResponse(ctx context.Context, body AcceptBudgetInvitationJSONRequestBody, reqEditors ...RequestEditorFn) (*AcceptBudgetInvitationResponse, error) {
+	rsp, err := c.AcceptBudgetInvitation(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAcceptBudgetInvitationResponse(rsp)
+}
+
+// GetOpenAPISpecWithResponse request returning *GetOpenAPISpecResponse
+func (c *ClientWithResponses) GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error) {
+	rsp, err := c.GetOpenAPISpec(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPISpecResponse(rsp)
+}
+
+// ListPayeesWithResponse request returning *ListPayeesResponse
+func (c *ClientWithResponses) ListPayeesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListPayeesResponse, error) {
+	rsp, err := c.ListPayees(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListPayeesResponse(rsp)
+}
+
+// CreatePayeesWithBodyWithResponse request with arbitrary body returning *CreatePayeesResponse
+func (c *ClientWithResponses) CreatePayeesWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePayeesResponse, error) {
+	rsp, err := c.CreatePayeesWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePayeesResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePayeesWithResponse(ctx context.Context, body CreatePayeesJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePayeesResponse, error) {
+	rsp, err := c.CreatePayees(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePayeesResponse(rsp)
+}
+
+// QuickAddWithBodyWithResponse request with arbitrary body returning *QuickAddResponse
+func (c *ClientWithResponses) QuickAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*QuickAddResponse, error) {
+	rsp, err := c.QuickAddWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuickAddResponse(rsp)
+}
+
+func (c *ClientWithResponses) QuickAddWithResponse(ctx context.Context, body QuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*QuickAddResponse, error) {
+	rsp, err := c.QuickAdd(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseQuickAddResponse(rsp)
+}
+
+// PreviewQuickAddWithBodyWithResponse request with arbitrary body returning *PreviewQuickAddResponse
+func (c *ClientWithResponses) PreviewQuickAddWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PreviewQuickAddResponse, error) {
+	rsp, err := c.PreviewQuickAddWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewQuickAddResponse(rsp)
+}
+
+func (c *ClientWithResponses) PreviewQuickAddWithResponse(ctx context.Context, body PreviewQuickAddJSONRequestBody, reqEditors ...RequestEditorFn) (*PreviewQuickAddResponse, error) {
+	rsp, err := c.PreviewQuickAdd(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePreviewQuickAddResponse(rsp)
+}
+
+// LoginWithBodyWithResponse request with arbitrary body returning *LoginResponse
+func (c *ClientWithResponses) LoginWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.LoginWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+func (c *ClientWithResponses) LoginWithResponse(ctx context.Context, body LoginJSONRequestBody, reqEditors ...RequestEditorFn) (*LoginResponse, error) {
+	rsp, err := c.Login(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLoginResponse(rsp)
+}
+
+// LogoutWithResponse request returning *LogoutResponse
+func (c *ClientWithResponses) LogoutWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LogoutResponse, error) {
+	rsp, err := c.Logout(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogoutResponse(rsp)
+}
+
+// ListAPITokensWithResponse request returning *ListAPITokensResponse
+func (c *ClientWithResponses) ListAPITokensWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAPITokensResponse, error) {
+	rsp, err := c.ListAPITokens(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAPITokensResponse(rsp)
+}
+
+// CreateAPITokenWithBodyWithResponse request with arbitrary body returning *CreateAPITokenResponse
+func (c *ClientWithResponses) CreateAPITokenWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAPITokenResponse, error) {
+	rsp, err := c.CreateAPITokenWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPITokenResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAPITokenWithResponse(ctx context.Context, body CreateAPITokenJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAPITokenResponse, error) {
+	rsp, err := c.CreateAPIToken(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAPITokenResponse(rsp)
+}
+
+// DeleteAPITokenWithResponse request returning *DeleteAPITokenResponse
+func (c *ClientWithResponses) DeleteAPITokenWithResponse(ctx context.Context, tokenID string, reqEditors ...RequestEditorFn) (*DeleteAPITokenResponse, error) {
+	rsp, err := c.DeleteAPIToken(ctx, tokenID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteAPITokenResponse(rsp)
+}
+
+// CreateTransactionsWithBodyWithResponse request with arbitrary body returning *CreateTransactionsResponse
+func (c *ClientWithResponses) CreateTransactionsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionsResponse, error) {
+	rsp, err := c.CreateTransactionsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTransactionsWithResponse(ctx context.Context, body CreateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionsResponse, error) {
+	rsp, err := c.CreateTransactions(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionsResponse(rsp)
+}
+
+// UpdateTransactionsWithBodyWithResponse request with arbitrary body returning *UpdateTransactionsResponse
+func (c *ClientWithResponses) UpdateTransactionsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTransactionsResponse, error) {
+	rsp, err := c.UpdateTransactionsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTransactionsResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTransactionsWithResponse(ctx context.Context, body UpdateTransactionsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTransactionsResponse, error) {
+	rsp, err := c.UpdateTransactions(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTransactionsResponse(rsp)
+}
+
+// ListAttachmentsWithResponse request returning *ListAttachmentsResponse
+func (c *ClientWithResponses) ListAttachmentsWithResponse(ctx context.Context, transactionID TransactionID, reqEditors ...RequestEditorFn) (*ListAttachmentsResponse, error) {
+	rsp, err := c.ListAttachments(ctx, transactionID, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAttachmentsResponse(rsp)
+}
+
+// UploadAttachmentWithBodyWithResponse request with arbitrary body returning *UploadAttachmentResponse
+func (c *ClientWithResponses) UploadAttachmentWithBodyWithResponse(ctx context.Context, transactionID TransactionID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadAttachmentResponse, error) {
+	rsp, err := c.UploadAttachmentWithBody(ctx, transactionID, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadAttachmentResponse(rsp)
+}
+
+// GetCurrentUserWithResponse request returning *GetCurrentUserResponse
+func (c *ClientWithResponses) GetCurrentUserWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetCurrentUserResponse, error) {
+	rsp, err := c.GetCurrentUser(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCurrentUserResponse(rsp)
+}
+
+// ParseListAccountsResponse parses an HTTP response from a ListAccountsWithResponse call
+func ParseListAccountsResponse(rsp *http.Response) (*ListAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Account
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAccountsResponse parses an HTTP response from a CreateAccountsWithResponse call
+func ParseCreateAccountsResponse(rsp *http.Response) (*CreateAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest []Account
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListExternalTransactionsResponse parses an HTTP response from a ListExternalTransactionsWithResponse call
+func ParseListExternalTransactionsResponse(rsp *http.Response) (*ListExternalTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListExternalTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ExternalTransaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportExternalTransactionsResponse parses an HTTP response from a ImportExternalTransactionsWithResponse call
+func ParseImportExternalTransactionsResponse(rsp *http.Response) (*ImportExternalTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportExternalTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListScheduledPaymentsResponse parses an HTTP response from a ListScheduledPaymentsWithResponse call
+func ParseListScheduledPaymentsResponse(rsp *http.Response) (*ListScheduledPaymentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListScheduledPaymentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ScheduledPayment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSyncAccountResponse parses an HTTP response from a SyncAccountWithResponse call
+func ParseSyncAccountResponse(rsp *http.Response) (*SyncAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SyncAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTransactionsResponse parses an HTTP response from a ListTransactionsWithResponse call
+func ParseListTransactionsResponse(rsp *http.Response) (*ListTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetAccountWriteBackResponse parses an HTTP response from a SetAccountWriteBackWithResponse call
+func ParseSetAccountWriteBackResponse(rsp *http.Response) (*SetAccountWriteBackResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetAccountWriteBackResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAssignmentsResponse parses an HTTP response from a ListAssignmentsWithResponse call
+func ParseListAssignmentsResponse(rsp *http.Response) (*ListAssignmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAssignmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Assignment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAssignResponse parses an HTTP response from a AssignWithResponse call
+func ParseAssignResponse(rsp *http.Response) (*AssignResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AssignResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Assignment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDownloadAttachmentResponse parses an HTTP response from a DownloadAttachmentWithResponse call
+func ParseDownloadAttachmentResponse(rsp *http.Response) (*DownloadAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DownloadAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListBudgetsResponse parses an HTTP response from a ListBudgetsWithResponse call
+func ParseListBudgetsResponse(rsp *http.Response) (*ListBudgetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBudgetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Budget
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBudgetResponse parses an HTTP response from a CreateBudgetWithResponse call
+func ParseCreateBudgetResponse(rsp *http.Response) (*CreateBudgetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBudgetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Budget
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateBudgetInvitationResponse parses an HTTP response from a CreateBudgetInvitationWithResponse call
+func ParseCreateBudgetInvitationResponse(rsp *http.Response) (*CreateBudgetInvitationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateBudgetInvitationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest NewBudgetInvitation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteBudgetInvitationResponse parses an HTTP response from a DeleteBudgetInvitationWithResponse call
+func ParseDeleteBudgetInvitationResponse(rsp *http.Response) (*DeleteBudgetInvitationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteBudgetInvitationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListBudgetMembersResponse parses an HTTP response from a ListBudgetMembersWithResponse call
+func ParseListBudgetMembersResponse(rsp *http.Response) (*ListBudgetMembersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListBudgetMembersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []BudgetMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRemoveBudgetMemberResponse parses an HTTP response from a RemoveBudgetMemberWithResponse call
+func ParseRemoveBudgetMemberResponse(rsp *http.Response) (*RemoveBudgetMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RemoveBudgetMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetBudgetMemberResponse parses an HTTP response from a SetBudgetMemberWithResponse call
+func ParseSetBudgetMemberResponse(rsp *http.Response) (*SetBudgetMemberResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetBudgetMemberResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCategoriesResponse parses an HTTP response from a ListCategoriesWithResponse call
+func ParseListCategoriesResponse(rsp *http.Response) (*ListCategoriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCategoriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Category
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListCategoryGroupsResponse parses an HTTP response from a ListCategoryGroupsWithResponse call
+func ParseListCategoryGroupsResponse(rsp *http.Response) (*ListCategoryGroupsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListCategoryGroupsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []CategoryGroup
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListIntegrationsResponse parses an HTTP response from a ListIntegrationsWithResponse call
+func ParseListIntegrationsResponse(rsp *http.Response) (*ListIntegrationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListIntegrationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Integration
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoadAccountsFromIntegrationResponse parses an HTTP response from a LoadAccountsFromIntegrationWithResponse call
+func ParseLoadAccountsFromIntegrationResponse(rsp *http.Response) (*LoadAccountsFromIntegrationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoadAccountsFromIntegrationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest []Account
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAcceptBudgetInvitationResponse parses an HTTP response from a AcceptBudgetInvitationWithResponse call
+func ParseAcceptBudgetInvitationResponse(rsp *http.Response) (*AcceptBudgetInvitationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AcceptBudgetInvitationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Budget
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPISpecResponse parses an HTTP response from a GetOpenAPISpecWithResponse call
+func ParseGetOpenAPISpecResponse(rsp *http.Response) (*GetOpenAPISpecResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPISpecResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListPayeesResponse parses an HTTP response from a ListPayeesWithResponse call
+func ParseListPayeesResponse(rsp *http.Response) (*ListPayeesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListPayeesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Payee
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreatePayeesResponse parses an HTTP response from a CreatePayeesWithResponse call
+func ParseCreatePayeesResponse(rsp *http.Response) (*CreatePayeesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePayeesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest []Payee
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseQuickAddResponse parses an HTTP response from a QuickAddWithResponse call
+func ParseQuickAddResponse(rsp *http.Response) (*QuickAddResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QuickAddResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest QuickAdd
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePreviewQuickAddResponse parses an HTTP response from a PreviewQuickAddWithResponse call
+func ParsePreviewQuickAddResponse(rsp *http.Response) (*PreviewQuickAddResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewQuickAddResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuickAdd
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLoginResponse parses an HTTP response from a LoginWithResponse call
+func ParseLoginResponse(rsp *http.Response) (*LoginResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LoginResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Session
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLogoutResponse parses an HTTP response from a LogoutWithResponse call
+func ParseLogoutResponse(rsp *http.Response) (*LogoutResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogoutResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAPITokensResponse parses an HTTP response from a ListAPITokensWithResponse call
+func ParseListAPITokensResponse(rsp *http.Response) (*ListAPITokensResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAPITokensResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []APIToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAPITokenResponse parses an HTTP response from a CreateAPITokenWithResponse call
+func ParseCreateAPITokenResponse(rsp *http.Response) (*CreateAPITokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAPITokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest NewAPIToken
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteAPITokenResponse parses an HTTP response from a DeleteAPITokenWithResponse call
+func ParseDeleteAPITokenResponse(rsp *http.Response) (*DeleteAPITokenResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteAPITokenResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTransactionsResponse parses an HTTP response from a CreateTransactionsWithResponse call
+func ParseCreateTransactionsResponse(rsp *http.Response) (*CreateTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateTransactionsResponse parses an HTTP response from a UpdateTransactionsWithResponse call
+func ParseUpdateTransactionsResponse(rsp *http.Response) (*UpdateTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Transaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListAttachmentsResponse parses an HTTP response from a ListAttachmentsWithResponse call
+func ParseListAttachmentsResponse(rsp *http.Response) (*ListAttachmentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAttachmentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Attachment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadAttachmentResponse parses an HTTP response from a UploadAttachmentWithResponse call
+func ParseUploadAttachmentResponse(rsp *http.Response) (*UploadAttachmentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadAttachmentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Attachment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCurrentUserResponse parses an HTTP response from a GetCurrentUserWithResponse call
+func ParseGetCurrentUserResponse(rsp *http.Response) (*GetCurrentUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCurrentUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Problem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationProblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List Accounts
+	// (GET /v1/accounts)
+	ListAccounts(w http.ResponseWriter, r *http.Request)
+	// Create Accounts
+	// (POST /v1/accounts)
+	CreateAccounts(w http.ResponseWriter, r *http.Request)
+	// List the transactions of the external account linked to an Account
+	// (GET /v1/accounts/{accountID}/external-transactions)
+	ListExternalTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID, params ListExternalTransactionsParams)
+	// Import the transactions of the external account linked to an Account
+	// (POST /v1/accounts/{accountID}/external-transactions/import)
+	ImportExternalTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID, params ImportExternalTransactionsParams)
+	// List the scheduled payments of the external account linked to an Account
+	// (GET /v1/accounts/{accountID}/scheduled-payments)
+	ListScheduledPayments(w http.ResponseWriter, r *http.Request, accountID AccountID)
+	// Sync an Account with its linked external account
+	// (POST /v1/accounts/{accountID}/sync)
+	SyncAccount(w http.ResponseWriter, r *http.Request, accountID AccountID)
+	// List the Transactions of an Account
+	// (GET /v1/accounts/{accountID}/transactions)
+	ListTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID)
+	// Opt an Account in or out of writing changes back to its linked external account
+	// (PUT /v1/accounts/{accountID}/write-back)
+	SetAccountWriteBack(w http.ResponseWriter, r *http.Request, accountID AccountID)
+	// List the amounts assigned to Categories for a month
+	// (GET /v1/assignments)
+	ListAssignments(w http.ResponseWriter, r *http.Request, params ListAssignmentsParams)
+	// Assign amounts to Categories
+	// (PUT /v1/assignments)
+	Assign(w http.ResponseWriter, r *http.Request)
+	// Download the content of an Attachment
+	// (GET /v1/attachments/{attachmentID})
+	DownloadAttachment(w http.ResponseWriter, r *http.Request, attachmentID string)
+	// List the Budgets the User authenticating the request is a member of
+	// (GET /v1/budgets)
+	ListBudgets(w http.ResponseWriter, r *http.Request)
+	// Create a Budget, owned by the User authenticating the request
+	// (POST /v1/budgets)
+	CreateBudget(w http.ResponseWriter, r *http.Request)
+	// Invite a User to a Budget
+	// (POST /v1/budgets/{budgetID}/invitations)
+	CreateBudgetInvitation(w http.ResponseWriter, r *http.Request, budgetID BudgetID)
+	// Revoke an invitation to a Budget which has not been accepted
+	// (DELETE /v1/budgets/{budgetID}/invitations/{invitationID})
+	DeleteBudgetInvitation(w http.ResponseWriter, r *http.Request, budgetID BudgetID, invitationID string)
+	// List the members of a Budget
+	// (GET /v1/budgets/{budgetID}/members)
+	ListBudgetMembers(w http.ResponseWriter, r *http.Request, budgetID BudgetID)
+	// Remove a User from a Budget
+	// (DELETE /v1/budgets/{budgetID}/members/{username})
+	RemoveBudgetMember(w http.ResponseWriter, r *http.Request, budgetID BudgetID, username string)
+	// Add a User to a Budget, or change their role in it
+	// (PUT /v1/budgets/{budgetID}/members/{username})
+	SetBudgetMember(w http.ResponseWriter, r *http.Request, budgetID BudgetID, username string)
+	// List Categories
+	// (GET /v1/categories)
+	ListCategories(w http.ResponseWriter, r *http.Request)
+	// List Category Groups
+	// (GET /v1/category-groups)
+	ListCategoryGroups(w http.ResponseWriter, r *http.Request)
+	// List the configured Integrations
+	// (GET /v1/integrations)
+	ListIntegrations(w http.ResponseWriter, r *http.Request)
+	// Create Accounts linked to the external accounts of an Integration
+	// (POST /v1/integrations/{integrationID}/accounts)
+	LoadAccountsFromIntegration(w http.ResponseWriter, r *http.Request, integrationID string)
+	// Accept an invitation to a Budget, becoming a member of it
+	// (POST /v1/invitations/accept)
+	AcceptBudgetInvitation(w http.ResponseWriter, r *http.Request)
+	// Get this OpenAPI document
+	// (GET /v1/openapi.json)
+	GetOpenAPISpec(w http.ResponseWriter, r *http.Request)
+	// List Payees
+	// (GET /v1/payees)
+	ListPayees(w http.ResponseWriter, r *http.Request)
+	// Create Payees
+	// (POST /v1/payees)
+	CreatePayees(w http.ResponseWriter, r *http.Request)
+	// Create the Transaction written in a quick-add input
+	// (POST /v1/quick-add)
+	QuickAdd(w http.ResponseWriter, r *http.Request)
+	// Preview the Transaction written in a quick-add input
+	// (POST /v1/quick-add/preview)
+	PreviewQuickAdd(w http.ResponseWriter, r *http.Request)
+	// Log in, starting a session
+	// (POST /v1/sessions)
+	Login(w http.ResponseWriter, r *http.Request)
+	// Log out, ending the session authenticating the request
+	// (DELETE /v1/sessions/current)
+	Logout(w http.ResponseWriter, r *http.Request)
+	// List the API tokens of the User authenticating the request
+	// (GET /v1/tokens)
+	ListAPITokens(w http.ResponseWriter, r *http.Request)
+	// Create an API token of the User authenticating the request
+	// (POST /v1/tokens)
+	CreateAPIToken(w http.ResponseWriter, r *http.Request)
+	// Revoke an API token of the User authenticating the request
+	// (DELETE /v1/tokens/{tokenID})
+	DeleteAPIToken(w http.ResponseWriter, r *http.Request, tokenID string)
+	// Create Transactions
+	// (POST /v1/transactions)
+	CreateTransactions(w http.ResponseWriter, r *http.Request)
+	// Update Transactions
+	// (PUT /v1/transactions)
+	UpdateTransactions(w http.ResponseWriter, r *http.Request)
+	// List the Attachments of a Transaction
+	// (GET /v1/transactions/{transactionID}/attachments)
+	ListAttachments(w http.ResponseWriter, r *http.Request, transactionID TransactionID)
+	// Attach a file, such as a receipt, to a Transaction
+	// (POST /v1/transactions/{transactionID}/attachments)
+	UploadAttachment(w http.ResponseWriter, r *http.Request, transactionID TransactionID)
+	// Get the User authenticating the request
+	// (GET /v1/user)
+	GetCurrentUser(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAccounts operation middleware
+func (siw *ServerInterfaceWrapper) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAccounts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAccounts operation middleware
+func (siw *ServerInterfaceWrapper) CreateAccounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAccounts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListExternalTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListExternalTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListExternalTransactionsParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListExternalTransactions(w, r, accountID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ImportExternalTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ImportExternalTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write", "payees:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ImportExternalTransactionsParams
+
+	// ------------- Optional query parameter "since" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ImportExternalTransactions(w, r, accountID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListScheduledPayments operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduledPayments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListScheduledPayments(w, r, accountID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SyncAccount operation middleware
+func (siw *ServerInterfaceWrapper) SyncAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:sync"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SyncAccount(w, r, accountID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTransactions(w, r, accountID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetAccountWriteBack operation middleware
+func (siw *ServerInterfaceWrapper) SetAccountWriteBack(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "accountID" -------------
+	var accountID AccountID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "accountID", r.PathValue("accountID"), &accountID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "accountID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetAccountWriteBack(w, r, accountID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListAssignments operation middleware
+func (siw *ServerInterfaceWrapper) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"categories:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAssignmentsParams
+
+	// ------------- Required query parameter "month" -------------
+
+	if paramValue := r.URL.Query().Get("month"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "month"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "month", r.URL.Query(), &params.Month)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "month", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAssignments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Assign operation middleware
+func (siw *ServerInterfaceWrapper) Assign(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"categories:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Assign(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DownloadAttachment operation middleware
+func (siw *ServerInterfaceWrapper) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "attachmentID" -------------
+	var attachmentID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentID", r.PathValue("attachmentID"), &attachmentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "attachmentID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DownloadAttachment(w, r, attachmentID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListBudgets operation middleware
+func (siw *ServerInterfaceWrapper) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBudgets(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateBudget operation middleware
+func (siw *ServerInterfaceWrapper) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBudget(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateBudgetInvitation operation middleware
+func (siw *ServerInterfaceWrapper) CreateBudgetInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "budgetID" -------------
+	var budgetID BudgetID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "budgetID", r.PathValue("budgetID"), &budgetID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "budgetID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBudgetInvitation(w, r, budgetID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteBudgetInvitation operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBudgetInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "budgetID" -------------
+	var budgetID BudgetID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "budgetID", r.PathValue("budgetID"), &budgetID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "budgetID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "invitationID" -------------
+	var invitationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "invitationID", r.PathValue("invitationID"), &invitationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "invitationID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBudgetInvitation(w, r, budgetID, invitationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListBudgetMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListBudgetMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "budgetID" -------------
+	var budgetID BudgetID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "budgetID", r.PathValue("budgetID"), &budgetID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "budgetID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBudgetMembers(w, r, budgetID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// RemoveBudgetMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveBudgetMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "budgetID" -------------
+	var budgetID BudgetID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "budgetID", r.PathValue("budgetID"), &budgetID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "budgetID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveBudgetMember(w, r, budgetID, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetBudgetMember operation middleware
+func (siw *ServerInterfaceWrapper) SetBudgetMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "budgetID" -------------
+	var budgetID BudgetID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "budgetID", r.PathValue("budgetID"), &budgetID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "budgetID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "username" -------------
+	var username string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "username", r.PathValue("username"), &username, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetBudgetMember(w, r, budgetID, username)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListCategories(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"categories:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategories(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListCategoryGroups operation middleware
+func (siw *ServerInterfaceWrapper) ListCategoryGroups(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"categories:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategoryGroups(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListIntegrations operation middleware
+func (siw *ServerInterfaceWrapper) ListIntegrations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListIntegrations(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// LoadAccountsFromIntegration operation middleware
+func (siw *ServerInterfaceWrapper) LoadAccountsFromIntegration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "integrationID" -------------
+	var integrationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "integrationID", r.PathValue("integrationID"), &integrationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "integrationID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"accounts:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LoadAccountsFromIntegration(w, r, integrationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// AcceptBudgetInvitation operation middleware
+func (siw *ServerInterfaceWrapper) AcceptBudgetInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"budgets:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AcceptBudgetInvitation(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetOpenAPISpec operation middleware
+func (siw *ServerInterfaceWrapper) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOpenAPISpec(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListPayees operation middleware
+func (siw *ServerInterfaceWrapper) ListPayees(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"payees:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPayees(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreatePayees operation middleware
+func (siw *ServerInterfaceWrapper) CreatePayees(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"payees:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePayees(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// QuickAdd operation middleware
+func (siw *ServerInterfaceWrapper) QuickAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write", "payees:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.QuickAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PreviewQuickAdd operation middleware
+func (siw *ServerInterfaceWrapper) PreviewQuickAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PreviewQuickAdd(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Logout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListAPITokens operation middleware
+func (siw *ServerInterfaceWrapper) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"tokens:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAPITokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"tokens:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateAPIToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteAPIToken operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAPIToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "tokenID" -------------
+	var tokenID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tokenID", r.PathValue("tokenID"), &tokenID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tokenID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"tokens:manage"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAPIToken(w, r, tokenID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateTransactions operation middleware
+func (siw *ServerInterfaceWrapper) CreateTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTransactions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UpdateTransactions operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTransactions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ListAttachments operation middleware
+func (siw *ServerInterfaceWrapper) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "transactionID" -------------
+	var transactionID TransactionID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transactionID", r.PathValue("transactionID"), &transactionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:read"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAttachments(w, r, transactionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UploadAttachment operation middleware
+func (siw *ServerInterfaceWrapper) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "transactionID" -------------
+	var transactionID TransactionID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "transactionID", r.PathValue("transactionID"), &transactionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "transactionID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"transactions:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadAttachment(w, r, transactionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCurrentUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       *http.ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m *http.ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m *http.ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/v1/accounts", wrapper.ListAccounts)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/accounts", wrapper.CreateAccounts)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/accounts/{accountID}/external-transactions", wrapper.ListExternalTransactions)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/accounts/{accountID}/external-transactions/import", wrapper.ImportExternalTransactions)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/accounts/{accountID}/scheduled-payments", wrapper.ListScheduledPayments)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/accounts/{accountID}/sync", wrapper.SyncAccount)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/accounts/{accountID}/transactions", wrapper.ListTransactions)
+	m.HandleFunc("PUT "+options.BaseURL+"/v1/accounts/{accountID}/write-back", wrapper.SetAccountWriteBack)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/assignments", wrapper.ListAssignments)
+	m.HandleFunc("PUT "+options.BaseURL+"/v1/assignments", wrapper.Assign)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/attachments/{attachmentID}", wrapper.DownloadAttachment)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/budgets", wrapper.ListBudgets)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/budgets", wrapper.CreateBudget)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/budgets/{budgetID}/invitations", wrapper.CreateBudgetInvitation)
+	m.HandleFunc("DELETE "+options.BaseURL+"/v1/budgets/{budgetID}/invitations/{invitationID}", wrapper.DeleteBudgetInvitation)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/budgets/{budgetID}/members", wrapper.ListBudgetMembers)
+	m.HandleFunc("DELETE "+options.BaseURL+"/v1/budgets/{budgetID}/members/{username}", wrapper.RemoveBudgetMember)
+	m.HandleFunc("PUT "+options.BaseURL+"/v1/budgets/{budgetID}/members/{username}", wrapper.SetBudgetMember)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/categories", wrapper.ListCategories)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/category-groups", wrapper.ListCategoryGroups)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/integrations", wrapper.ListIntegrations)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/integrations/{integrationID}/accounts", wrapper.LoadAccountsFromIntegration)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/invitations/accept", wrapper.AcceptBudgetInvitation)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/openapi.json", wrapper.GetOpenAPISpec)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/payees", wrapper.ListPayees)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/payees", wrapper.CreatePayees)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/quick-add", wrapper.QuickAdd)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/quick-add/preview", wrapper.PreviewQuickAdd)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/sessions", wrapper.Login)
+	m.HandleFunc("DELETE "+options.BaseURL+"/v1/sessions/current", wrapper.Logout)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/tokens", wrapper.ListAPITokens)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/tokens", wrapper.CreateAPIToken)
+	m.HandleFunc("DELETE "+options.BaseURL+"/v1/tokens/{tokenID}", wrapper.DeleteAPIToken)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/transactions", wrapper.CreateTransactions)
+	m.HandleFunc("PUT "+options.BaseURL+"/v1/transactions", wrapper.UpdateTransactions)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/transactions/{transactionID}/attachments", wrapper.ListAttachments)
+	m.HandleFunc("POST "+options.BaseURL+"/v1/transactions/{transactionID}/attachments", wrapper.UploadAttachment)
+	m.HandleFunc("GET "+options.BaseURL+"/v1/user", wrapper.GetCurrentUser)
+
+	return m
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+w963LjtnqvguHpr3NoyXuStlP/6t5OxpNN1t3L5Efk8UDkJwkxCTAAaK/i8dP0Tfpk",
+	"HVwJUiBFSZbstOfXrkgQl+9+hR+SjJUVo0ClSC4ekgpzXIIErn+9zjJWU3n5Tv0gNLlIKixXSZpQXEJy",
+	"kWD/Pk04/F4TDnlyIXkNaSKyFZRYfSjXlRosJCd0mTw+psmbOl9C/7Rz93q3Wb9wTAXOJGG0d2rZGrPL",
+	"/I9qsKgYFaBhc8XZvIBS/TdjVAKV6r+4qgqSYTX/tDIj/vabYFS9a+b+Fw6L5CL5y7QB/tS8FVM3r14x",
+	"B5FxUqnpkovkNUXAOeP6tHa8RtPV5Rd2C3qRirMKuCRmkwaSNyRXP9qTfVkBMq+RXAGSagJU4jWaA6oF",
+	"5GjB+ATpeQW6J3LFaokYhe4YBHfA124qtlCzEY6+CuCTJO2CMU0yDlhCfiNJCULislJbWzBeYplcJDmW",
+	"cKZexT6FbxXhIPb51EBg43GBhbxRJ9lnTkNSkVlFxioDfyKhFNsw/lkNV9/ZiTDneJ08PobU+as6gV3S",
+	"LxAD5rWfhs1/g0yqeR19XNKqNlSa50SRAS6uAnJZ4EJA2qGgKMzblPTLCmhAQ/aLCbqUKGcgEGUSEUUX",
+	"RCBifi7JHVBFHrtBursulooMRbN4ikSdrRAWaJZUK0ZhlqhVSkI/AF3KVXLxKn06fJWEXpoPXm1BXhtv",
+	"URxlGVTykt4RqaXHPriSTggMnrezNfNRz5aUdN9xE1kBmEN+M8cFphm02IlQ+W/fNwgmVMISeJIm386W",
+	"7Ew9PRO3pDpjlVnsrGJqDHfS+dsZKxWKKrm2qyupsFhAJskdnHDJbxI4xcUNbkA0RDHv7XgHUS+Q2gT9",
+	"A1Dgip8Vu3g2QfeKvzSnE7pEmCI7zaZ4HXmqftkVI9soaQhBlrSEnakDlw5eYzAUAX2GJSwZX9/0SPQY",
+	"XH+ESqIFZyXCdI3MFhAuOOB8jbA+CuRIMi1H3toFtG5TD0pG5SpFTK6A3xMBaOnQdAgC9KxxrbwgXEiU",
+	"47XVpmYHE/RR7QApQSkQ5oAkr2mmyUUyROSGOE22sX4IS7ej1KEoinYpcbZyaO/wvTGCbsxHDzHtbZmm",
+	"zxq5fOcOjP06iBjVcqmogmvJiIhE91ggUlaMq9MrzKaKZTovDkFPD3X1q3zyB8RPpd64c1kYpepU87UE",
+	"0cJZDyM8pqHVGqf7mK3Q+cgbDy1E2Z0PI/trVTCcb6J8QQroI+ECUqQWzNF8jYgU+pF6gDDNkVrMv7Eb",
+	"0g9bAJkTivl6KxnrbcRO8KbRBwfrqH0VzQbPdZaOTRs9i7avN4+yK6FyVsA2dfVJjRmyP/Uk/bvcx3Zx",
+	"G97FdOlVT24bzpja4hi9bB/lMIw1B7UzxU4X23Y/XH+Ccg48wlX7Q238GdOkFsD7EKfejTNt3CzBNwMA",
+	"2gaMfSh+b7z2cp8zXTZRs+SsrqJAsyrRAC35QY27fJfsrAFjpOcXTfs51W1ZL3yodOuVWLGVuzZ5VI9Z",
+	"+17pb0xbRkhjhiuftiD0Vtth2utVfu6SMg75oO3+fDqpF7ukOeHNUMhErGn2pCGTe06kOkN2G7yeM1YA",
+	"poOqqLPj+P7SUWq3tYshkgkCjZskO97DefTbip053KGC6QaQ49qn7ZgOe0n7oL+EkvVKkRGGdYXXADf9",
+	"5gksgAPNIm/HrhEjlhhcIrTTgXhrt94xarAWo5FARkQ0JK7wnBTE/fbhJqB1qTbbstm1G5OYSG9eF5Df",
+	"VHhd6kh927rXZKs26C129/F1hEbagaoeWogBsbX72Nk/sCXZK3BVYSHuGY9TnGSyuslYHnE1XqOs5hyo",
+	"ROq987K+fPxyhQRkjOZogTPJuHujgtIpcgczQUlYoxW+AwQUzwv1sD+uYpWkmv+t2s94qt/NLrH05qES",
+	"g/XPcN8f9scVufHBwCH7wk+h4Ww/GN5gM3c6EDv8Ge63G+Gk9W5onxtzjd9vsMjQhq8Up+9ItvvH8JBe",
+	"7ikCeE/iLwW5rA4heXHZllfDQqXNLj6JKHY4nls4zjTj58kwzYkOmcUNPDW/QCWW2cogRj2wOCMUYfR7",
+	"TbLbM5zniCjBhuD3GhfFGt1DUSj87QaSUTu2UnZ92LltaM8Z4fvjr2Wi74bFIMB4+Pq7LZ2DxKQ4BITe",
+	"Zggs60EZZYeNsKPGb4LQvTdhbJdDIK+F1G5gbwymPZYdMb+QWNbhjGGUlMgCBhbbtB6+fvoZkRyoJIu1",
+	"Yn+dR1xX2pKw2fsUwWQ5QbOk5vRCBVOIvLCvLkoiBKHLMyushEk2bkm3rXXU1ezVnycmlP9LSZ7XeSTu",
+	"qsXVsERjC+dpitQoG6EDr5aZiBN6Jgor/Xc+MTsqE+q2+JOaKmZbUri/qZxmHax60IPaoe5t34Ru2AaY",
+	"g3epB9gQmPdKuMI3uVUJp4lkOV7HsaVUE+JQYOV16F8izGGvQUjgOV7PklTnfDgIVtxBjvASEyqkqtDQ",
+	"mSKTJhHA74C3TI/d00L6VEOgMuiOpAOgyENvxvKF86OSRiFEHRO93bjTaUh1u7FntuCmaj6MneaTjb+5",
+	"3bJ7qtOOkBPJ1H/uCNwDj271s3PJroxHdmAYYKzbvlBnBZqtn9ipp/BN+iBDR0jOhU7DGQcqmKup7bil",
+	"7N4YuGqI9VF1pQd8kyivIVXErNKpHyug6A2mt0rY5oRDJlEOcyLFCCp94bGDeKigwViMBE0lScyt9Uag",
+	"jhleXbbrs5YcU5vg7PCauOCA1Xb8bx8ccA9UbMzt1w+3v9zgQHz6Ia1nbmDm9YkbFjxxg0wOwo9wP0tM",
+	"8RKcV+Z/R/kNhIi6kAekW/r8R+Otb1M+KpTQU0sTy6bYSWNE0Ikm7lJMMSwtdotEDtVV7GDx9wY0n6rI",
+	"Z3Qs9ADvPEDJQUUEOgh3qAdgJ9mQ4pvGRDDGmLLNMYYrJhQkWi8R1vn6gw4vbqwHYl2Y/WniycLOw5Un",
+	"YUpngTb2n5qKIAXTBXCB5iDvAfw3IlrtKqqCHEYBHRGzEaJuaSV/zMEynq/iidO3ZCBya+Oq/dgPoqrv",
+	"7didIqbdJG5r1bHJ3F+Uqnpj0087iOCd8laD6SVFLJDVnMi1si6tAz0HzIG/rvtqxYxZoIgXCaMjEeMt",
+	"m2FiMwimsl5P16BwJWVlKs0JXbD4EmomtkAqBHtGZKpXKhZnKyaUtPgDODubY6EcSa3XtR/NWDGZ0Rl9",
+	"rYnQlKtZZaN905JQxlFNiRTWwf6f/351rmzGV+fn5xP0nnPGzWef/vEW/cf3//rvziFHJrIjUl2UruWc",
+	"tvrVvDNqCot0lXzgSEnN43Zl7bMH3jEWSJl4VAOv1Ol8oTf/SZluwu4e13IFVBJTcDdfI4wMMF3hMRBd",
+	"niejSElDRVOw5VJtgVBzBIwc9c6o8tFd3D/tohJZWjbLq2mUIqOm1l6D2400e9blxvkEvcfZSpuOjBbt",
+	"2n3ZNAFYVeCWWDCeovsVyVaIOzjUAto5E8pm9I2OiZy9MbOsAOfAUx1rcAsoftFKSehyL70rgTJ2pwYW",
+	"RJORRZJ9Z9M1DfEjxxozahlK+xhsgUCdza/giiT9lheYFNb7mJM8BzpB1pY0IFKaryAlsVA162tYaqDZ",
+	"eZCyXE0ARfOw0I0QBnApkivsGh9QHBoWEDkscF1ID3Gtf1da/1KYGFCCpTexwtzsyfRTWMJM7YEX6H7F",
+	"SsUwmCLtuSqYI+O7arpBxn31sNRzT5AnaoUdPKNBC4giI1egj+2C6nMHQ7Nf7/bBNyLkxMeyLhIrIRS9",
+	"Ku8ZuDHZk1eT88m5ErKsAoorklwk303OJ99pfSVXWs5N715NnYeifttaN4/Yyzy5SD4QIR3fJp12nL+f",
+	"nw+04my24IyKbwX12p3y/o3enI8/JvqZxnDfvH7HQZdPI/aTi1/bAr/r1V0/XqeJqMsS87UFBwrgIfFS",
+	"qI/8o2tl+DARgeRbzeUtWGqyeMPy9YnB2O7AetxA66tnRasBVH5U3Bo3uYNcs/AW9D6mLcaZPviGvMep",
+	"C1uchX77IGtFyltEkrZaAn/tjWFKpkV56HMI7UpM0HvdIBa8MBVTwrphRG50BenGvd9r0MW/1j4UxNTo",
+	"NLjeFtW8PoWIiADtJYuLjlvoVa2jFl/x5ivaArcoIMKgzMXKmTaVxI7TDJk2jaWP1zsT8dQWt1w8HLBq",
+	"Ixk7BB0Cx/WIeN9Ya+ZbUlWQGxvHpVY4hJZTkFBRmSQzxhhTOQuU54y6Ynlhex6aPpTwxI0lyyEDUmmz",
+	"lUOzL2cKly5QG+AHibpSw4QtbWkz/qWe4lDWNxsZzfx+36dl/yOokh3Z/tIe/BjMH43PtuK6HYFwaZF2",
+	"HJEwxNa+ou3MV7QNKaZutuU0xl931Zcu1j1QXQrmpQt3nYh4IiHeJprPa5o1x+qQyvebQl+NP7KRpw/b",
+	"QZ1aNow6aimuXGSLny7e9mO20fZfR/gfn8NehM20merqY7AvHSEZ5Z1w0BF5Rwv0Mxd3PIiD6hgDgXO0",
+	"m8Do/k7iEA0084/yBmPMC/L07tnHSrbyBTrsqm/IWOgQkYrvZStMlyCQwpJp0T2As33D9ZbwSDBui/32",
+	"2lhvSujYJlvTZ9xjgLn24P4bU16EP9YA4PlESjcp3idQsIuOBy3oQYGWjtAhB3dHH7YR9PrRs277SJ+g",
+	"KnAGYluvO97sdBe4DIigTVwGrkePFG3B3jbx8H+foKLSyOzR01OLjGKk44SK7xdRysX/uHz32Ctj3rF7",
+	"qprBg5sAtiLhr9O/tuG+vcH7MY04m7573id+ws5xZ+w2O5s8i7ngINTaoLUWQqCNNRhi124FqNrpDitn",
+	"UtgKnEFNYpMRJzEEzVrPx1+tAqU+ae2yMz5VEqQEXfGwyxcREWZQNlhwe2jejDuSzRV26R8hBj8G0yeN",
+	"tHcKzuKRdmwRnOp0mi+L3oLpKGrbXDZ9cPfZPU6bZiSxu93ub83rj16as+ikYLNUijjImlO1dSKFS1ib",
+	"eKSNvd1CJZFg7qf5wJUZT9AXB4n7FVM2K1RSzKipCHXLoDlkrISA8r0/y1kBE/RRZZ8VcLnQ2Wj9KWya",
+	"GiEHXIbdW8fjhbCL/8QcEeuUe0nsobel2EPjXxuOXjjtSfrTh+aHNTZyKMDU9XXsDf08Sgvb/MKfGXpr",
+	"EXRyoH2CO3YLbTYMYWeZb2Vr7+YA1LIV5FG4pnvLijRqQoQIOMSECDFsuH6MVfGTHXk628Ks+MItDAtA",
+	"Uzk0wGT7E8N27E0fXDFShy87UGrEOIeS3YF2N80cJjNmrgU1T8JxKksloLgDkaKaFiCEKSjSN5qtABVY",
+	"SKMlNjXDJz1FC6MvXxIY6BjxaWtrj4LbOKOHt9uMZ/Ke2EJXgwuQvmIOfWkhLyRiPdbIOVTiHNqVSqZO",
+	"aRPZn0FuYPrF6P8XRmWv8zyioXXhmYlC2ioyZYipYCUZ1N5NuGFQlrdiDMcX5P52oxcd1xsVeLETrc90",
+	"d/YoKJv269NCWi/5pwD3Gnnw9MGchHH1IYC3AvCnAHew4FhgP0W+OGN0QZY1hxx1jjyciggBOX0Ifikr",
+	"Iqzh3B7Ian28s4aKhk8+qOCk3cQ/OCtD4P6ztjBaWxiUA8SqBcTm7WcjaKRx9oxfowkiijFz8/WJ3P34",
+	"NdtHyC3sFwT7+OMz2A4aJP2+amoCO6YzrqkKHzYhbKn3xAEmKm1/AKk6gl9fXX6uIDtU1nb7aUYIzhYc",
+	"ftBl8EQguyeUs6zuBOt/AombQ5riqkFlYuoDT6JGmisUnkVbh/3EMZXjIeFgaR9si4QHEDxi4nEAeM9Q",
+	"oD4alUdUIUOVg1aB9KPUMoi/PCmU/vGIdafABlUctGPY1NPq+5dMbIFIYRY3bbymmlYJKPPQXvfhriMA",
+	"aEpL2xTmr1g5jrJpXy1y4riyP9tpyWbnKlRLS138q7Gy5xKu/uxpl+6mloz66e8Kc+ESJlUtg7tXMrZY",
+	"AKDvJn8/R/95xUEifxuLieN8lpgXiuoq4IIpi+kvpoP8jNVyliBCtRYNtpjOqPtLOr7bnMgULeo//iDF",
+	"urmHrKkfJ6YuWrgLX5BcMQEj7veZtLsvRQVUuoCbA65tPywA52rVWfK3WbLJJVcGhi+PWc5PwiwnKDuM",
+	"MoYF+9Nyhu1HFf0MoahaiqZ11QTydGjJ9LCaSJJrUlVUl8Zv0sSRmzhThFt3ds6oNiVN9ND3zZqrjMzy",
+	"YeOtQAWWYXsnFp0+3E3q1ZeSHolmgwtPTyzd3YUoxxXubSuOqZblFAlFIMYbsDgKm9MabKkXG3Q3tcgf",
+	"yvl9YEtWy5NF9lsHZMrEAJo7IRyhwv4KgL7Da8rcUrdpL2I9UV9reO3r81Rdt6/86QlRBf3swQ2+eyJj",
+	"RM2EX6/bHM8W4SphQUXT70+kuhdhsK5iRs2HTWFFc8fAxv0C3XufdPN+Zq2l5s/ytXvmCVfpl76aCo/3",
+	"IwVXWn9n7vR1FA1Zn9bkHaJlV10UYvNwWm4JlumD/ndUJUWLAp43ozUItqaA4inBNqa40sJyr6KIbqvN",
+	"QItp9AohJTyYcE4rEai5eyjsMLVme0k4Z7x7WVWM7zc6e44YSdna1PMM8ZQdG41O6x7HBUYHZf3VwvXW",
+	"JmZ9bahuCtCK5fJdinD+Wy0859i7hQMd4u+00nb5JqGZoY6OZ1StUVc5bvUib1LjVz3kT0WN/y/a3qKE",
+	"aJA1khAjAnD60Ppjy49hu8GwLRyMO4k17Nf7M3QhBtAxrvmX1lXHT9SH2P5b2v3pVvOnATvdIH38XNaF",
+	"JBXmcqo6QM5yLPEOxmX3zxGe2L4MqeSFqQyzNXtnY3NXBHa3RaRIsrGEYhnZXYHal7d7a9x4ZYslR4zL",
+	"2VtWn5QJOwm/w+zwQdRdP14//u8AACcoE2F/AAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
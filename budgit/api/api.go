@@ -0,0 +1,454 @@
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen --config=config.yaml openapi.yaml
+
+// Package api serves a JSON REST API over the budgit service, exposing Accounts, Payees, Transactions, the budget and
+// the operations of Integrations.
+//
+// The API is defined by openapi.yaml, from which the models and ServerInterface are generated. Requests are validated
+// against it, and it is served at /v1/openapi.json so clients can be generated. ServiceClient calls the API with the
+// methods of the service, using the client generated from it.
+//
+// Requests are authenticated by a bearer token, the token of a session started by logging in or an API token, and run
+// under the Principal it authenticates, on the budget of their Budgit-Budget header, that of their API token or the
+// default budget. API tokens may only be used for the operations their scopes cover, which each operation lists as the
+// scopes of its security requirement in openapi.yaml.
+//
+// Amounts are integers of minor units, e.g. £10 is 1000, and dates are written "YYYY-MM-DD". Errors are written as
+// RFC 9457 problem details, with the fields of typed errors, such as the IDs of missing Accounts, as extension members.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"go.uber.org/zap"
+)
+
+// Service is the budgit service the API serves, implemented by svc.Service.
+type Service interface {
+	CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error)
+	ListAccounts(ctx context.Context) ([]*budgit.Account, error)
+	CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error)
+	ListPayees(ctx context.Context) ([]*budgit.Payee, error)
+	CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error)
+	UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListAttachments(ctx context.Context, transactionID string) ([]*budgit.Attachment, error)
+	UploadAttachment(ctx context.Context, transactionID, name, contentType string, content io.Reader) (*budgit.Attachment, error)
+	DownloadAttachment(ctx context.Context, attachmentID string) (*budgit.Attachment, io.ReadCloser, error)
+	PreviewQuickAdd(ctx context.Context, input string, today time.Time) (*svc.QuickAdd, error)
+	ConfirmQuickAdd(ctx context.Context, quickAdd *svc.QuickAdd) (*budgit.Transaction, error)
+	ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error)
+	ListCategories(ctx context.Context) ([]*budgit.Category, error)
+	ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error)
+	Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error)
+	ListIntegrations() []svc.IntegrationInfo
+	LoadAccountsFromIntegration(ctx context.Context, integrationID string) ([]*budgit.Account, error)
+	SyncAccount(ctx context.Context, accountID string) error
+	SetAccountWriteBack(ctx context.Context, accountID string, writeBack bool) error
+	ListExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.ExternalTransaction, error)
+	ImportExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.Transaction, error)
+	ListScheduledPayments(ctx context.Context, accountID string) ([]*budgit.ScheduledPayment, error)
+	Login(ctx context.Context, username, password, totpCode string) (*svc.Session, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (svc.Principal, error)
+	CurrentUser(ctx context.Context) (*budgit.User, error)
+	CreateAPIToken(ctx context.Context, username, name string, scopes []budgit.Scope, expires time.Time) (*budgit.APIToken, string, error)
+	ListAPITokens(ctx context.Context, username string) ([]*budgit.APIToken, error)
+	DeleteAPIToken(ctx context.Context, username, tokenID string) error
+	CreateBudget(ctx context.Context, name string) (*budgit.Budget, error)
+	ListBudgets(ctx context.Context) ([]*budgit.Budget, error)
+	ListMembers(ctx context.Context) ([]*budgit.BudgetMember, error)
+	SetMember(ctx context.Context, username string, role budgit.Role) error
+	RemoveMember(ctx context.Context, username string) error
+	CreateInvitation(ctx context.Context, role budgit.Role) (*budgit.BudgetInvitation, string, error)
+	DeleteInvitation(ctx context.Context, invitationID string) error
+	AcceptInvitation(ctx context.Context, token string) (*budgit.Budget, error)
+}
+
+const (
+	// maxRequestBodySize is the largest request body accepted, in bytes.
+	maxRequestBodySize = 1 << 20
+	// shutdownTimeout is how long requests in flight are waited for when shutting down.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Server is an http.Handler serving the API. It implements the ServerInterface generated from openapi.yaml.
+type Server struct {
+	log     *zap.SugaredLogger
+	service Service
+	spec    *openapi3.T
+	router  routers.Router
+	mux     *http.ServeMux
+	handler http.Handler
+}
+
+var _ ServerInterface = (*Server)(nil)
+
+func New(log *zap.SugaredLogger, service Service) (*Server, error) {
+	spec, err := GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("loading OpenAPI spec: %w", err)
+	}
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("routing OpenAPI spec: %w", err)
+	}
+	s := &Server{
+		log:     log,
+		service: service,
+		spec:    spec,
+		router:  router,
+		mux:     http.NewServeMux(),
+	}
+	s.handler = HandlerWithOptions(s, StdHTTPServerOptions{
+		BaseRouter:  s.mux,
+		Middlewares: []MiddlewareFunc{s.validateRequest, s.authenticate},
+		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			s.writeError(w, r, badRequestError{err})
+		},
+	})
+	return s, nil
+}
+
+// Mount serves a handler alongside the API for requests matching a pattern, as http.ServeMux matches them, such as the
+// web UI at "/". Requests are logged and limited in size as requests of the API are.
+func (s *Server) Mount(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	r.Body = http.MaxBytesReader(recorder, r.Body, maxRequestBodySize)
+	s.handler.ServeHTTP(recorder, r)
+	s.log.Infow("Served request",
+		zap.String("method", r.Method),
+		zap.String("path", r.URL.Path),
+		zap.Int("status", recorder.status),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+// ListenAndServe serves the API on the given address until the context is done, then shuts down gracefully, waiting
+// for requests in flight to complete.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listening on %q: %w", addr, err)
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves the API on the given listener until the context is done, then shuts down gracefully, waiting for
+// requests in flight to complete.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.log.Infow("Serving API", zap.String("addr", listener.Addr().String()))
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("serving API: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.log.Info("Shutting down API")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutting down API: %w", err)
+	}
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving API: %w", err)
+	}
+	return nil
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *Server) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	accounts, err := s.service.ListAccounts(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(accounts, ToAccount))
+}
+
+func (s *Server) CreateAccounts(w http.ResponseWriter, r *http.Request) {
+	var body CreateAccountsJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	accounts, err := s.service.CreateAccounts(r.Context(), mapSlice(body, fromAccount)...)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mapSlice(accounts, ToAccount))
+}
+
+func (s *Server) ListTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID) {
+	transactions, err := s.service.ListTransactions(r.Context(), accountID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(transactions, ToTransaction))
+}
+
+func (s *Server) SyncAccount(w http.ResponseWriter, r *http.Request, accountID AccountID) {
+	if err := s.service.SyncAccount(r.Context(), accountID); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) SetAccountWriteBack(w http.ResponseWriter, r *http.Request, accountID AccountID) {
+	var body SetAccountWriteBackJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := s.service.SetAccountWriteBack(r.Context(), accountID, body.WriteBack); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ListExternalTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID, params ListExternalTransactionsParams) {
+	var since time.Time
+	if params.Since != nil {
+		since = params.Since.Time
+	}
+	transactions, err := s.service.ListExternalTransactions(r.Context(), accountID, since)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(transactions, toExternalTransaction))
+}
+
+func (s *Server) ImportExternalTransactions(w http.ResponseWriter, r *http.Request, accountID AccountID, params ImportExternalTransactionsParams) {
+	var since time.Time
+	if params.Since != nil {
+		since = params.Since.Time
+	}
+	transactions, err := s.service.ImportExternalTransactions(r.Context(), accountID, since)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mapSlice(transactions, ToTransaction))
+}
+
+func (s *Server) ListScheduledPayments(w http.ResponseWriter, r *http.Request, accountID AccountID) {
+	payments, err := s.service.ListScheduledPayments(r.Context(), accountID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(payments, toScheduledPayment))
+}
+
+func (s *Server) ListPayees(w http.ResponseWriter, r *http.Request) {
+	payees, err := s.service.ListPayees(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(payees, ToPayee))
+}
+
+func (s *Server) CreatePayees(w http.ResponseWriter, r *http.Request) {
+	var body CreatePayeesJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	payees, err := s.service.CreatePayees(r.Context(), mapSlice(body, fromPayee)...)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mapSlice(payees, ToPayee))
+}
+
+func (s *Server) CreateTransactions(w http.ResponseWriter, r *http.Request) {
+	var body CreateTransactionsJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	transactions, err := s.service.CreateTransactions(r.Context(), mapSlice(body, fromTransaction)...)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mapSlice(transactions, ToTransaction))
+}
+
+func (s *Server) UpdateTransactions(w http.ResponseWriter, r *http.Request) {
+	var body UpdateTransactionsJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	transactions, err := s.service.UpdateTransactions(r.Context(), mapSlice(body, fromTransaction)...)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(transactions, ToTransaction))
+}
+
+func (s *Server) PreviewQuickAdd(w http.ResponseWriter, r *http.Request) {
+	var body PreviewQuickAddJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	preview, err := s.service.PreviewQuickAdd(r.Context(), body.Text, quickAddToday(body))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toQuickAdd(preview))
+}
+
+func (s *Server) QuickAdd(w http.ResponseWriter, r *http.Request) {
+	var body QuickAddJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	quickAdd, err := s.service.PreviewQuickAdd(r.Context(), body.Text, quickAddToday(body))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if quickAdd.Transaction, err = s.service.ConfirmQuickAdd(r.Context(), quickAdd); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toQuickAdd(quickAdd))
+}
+
+// quickAddToday returns the date the relative dates of a quick-add input are resolved against.
+func quickAddToday(input QuickAddInput) time.Time {
+	if input.Today != nil {
+		return input.Today.Time
+	}
+	return time.Now()
+}
+
+func (s *Server) ListCategoryGroups(w http.ResponseWriter, r *http.Request) {
+	groups, err := s.service.ListCategoryGroups(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(groups, toCategoryGroup))
+}
+
+func (s *Server) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.service.ListCategories(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(categories, toCategory))
+}
+
+func (s *Server) ListAssignments(w http.ResponseWriter, r *http.Request, params ListAssignmentsParams) {
+	assignments, err := s.service.ListAssignments(r.Context(), params.Month.Time)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(assignments, toAssignment))
+}
+
+func (s *Server) Assign(w http.ResponseWriter, r *http.Request) {
+	var body AssignJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	assignments, err := s.service.Assign(r.Context(), mapSlice(body, fromAssignment)...)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(assignments, toAssignment))
+}
+
+func (s *Server) ListIntegrations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, mapSlice(s.service.ListIntegrations(), toIntegration))
+}
+
+func (s *Server) LoadAccountsFromIntegration(w http.ResponseWriter, r *http.Request, integrationID string) {
+	accounts, err := s.service.LoadAccountsFromIntegration(r.Context(), integrationID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, mapSlice(accounts, ToAccount))
+}
+
+// decodeJSON decodes the JSON body of a request, returning a badRequestError if it is not valid.
+func decodeJSON(r *http.Request, v any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return badRequestError{fmt.Errorf("decoding request body: %w", err)}
+	}
+	if decoder.More() {
+		return badRequestError{fmt.Errorf("decoding request body: unexpected content after JSON value")}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func mapSlice[S ~[]E, E, T any](slice S, f func(E) T) []T {
+	mapped := make([]T, 0, len(slice))
+	for _, e := range slice {
+		mapped = append(mapped, f(e))
+	}
+	return mapped
+}
@@ -0,0 +1,1078 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/api"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestAPI(t *testing.T) {
+	suite.Run(t, new(apiSuite))
+}
+
+type apiSuite struct {
+	suite.Suite
+
+	service *fakeService
+	server  *httptest.Server
+	// token authenticates the requests of do, if set.
+	token string
+	// budget is the budget the requests of do run on, if set.
+	budget string
+}
+
+// testToken is the token fakeService authenticates, as the User alice.
+const testToken = "budgit_s_test"
+
+// testScopedToken is an API token of alice fakeService authenticates, which may only read the Accounts of budget-2.
+const testScopedToken = "budgit_t_scoped"
+
+func (s *apiSuite) SetupTest() {
+	s.service = &fakeService{}
+	server, err := api.New(zap.NewNop().Sugar(), s.service)
+	s.Require().NoError(err)
+	s.server = httptest.NewServer(server)
+	s.token = testToken
+	s.budget = ""
+}
+
+func (s *apiSuite) TearDownTest() {
+	s.server.Close()
+}
+
+// fakeService is an api.Service returning the accounts, payees and transactions it holds, or err if set.
+type fakeService struct {
+	accounts     []*budgit.Account
+	payees       []*budgit.Payee
+	transactions []*budgit.Transaction
+	categories   []*budgit.Category
+	assignments  []*budgit.Assignment
+	integrations []svc.IntegrationInfo
+	err          error
+
+	syncedAccountIDs []string
+	since            time.Time
+	quickAddInput    string
+	quickAddToday    time.Time
+	loggedOutToken   string
+	apiTokens        []*budgit.APIToken
+	// budgetID is the budget the latest call ran on.
+	budgetID      string
+	budgets       []*budgit.Budget
+	members       []*budgit.BudgetMember
+	invitationIDs []string
+	attachments   []*budgit.Attachment
+	// attachmentContents are the contents of attachments, by Attachment ID.
+	attachmentContents map[string]string
+}
+
+func (f *fakeService) CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.accounts = append(f.accounts, accounts...)
+	return accounts, nil
+}
+
+func (f *fakeService) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	return f.accounts, f.err
+}
+
+func (f *fakeService) CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.payees = append(f.payees, payees...)
+	return payees, nil
+}
+
+func (f *fakeService) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	return f.payees, f.err
+}
+
+func (f *fakeService) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.transactions = append(f.transactions, transactions...)
+	return transactions, nil
+}
+
+func (f *fakeService) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	transactions := []*budgit.Transaction{}
+	for _, transaction := range f.transactions {
+		if transaction.AccountID == accountID {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+func (f *fakeService) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.transactions = transactions
+	return transactions, nil
+}
+
+// PreviewQuickAdd previews any input as a Transaction of a new Payee.
+func (f *fakeService) PreviewQuickAdd(ctx context.Context, input string, today time.Time) (*svc.QuickAdd, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.quickAddInput, f.quickAddToday = input, today
+	return &svc.QuickAdd{
+		Transaction: &budgit.Transaction{ID: "transaction-1", EffectiveDate: today.AddDate(0, 0, -1), AccountID: "account-1", PayeeID: "payee-1", Amount: -320, Memo: "coffee"},
+		NewPayee:    &budgit.Payee{ID: "payee-1", Name: "Pret"},
+		Matches:     []svc.QuickAddMatch{{Field: "account", Given: "current", Matched: "Current"}},
+	}, nil
+}
+
+func (f *fakeService) ConfirmQuickAdd(ctx context.Context, quickAdd *svc.QuickAdd) (*budgit.Transaction, error) {
+	f.payees = append(f.payees, quickAdd.NewPayee)
+	f.transactions = append(f.transactions, quickAdd.Transaction)
+	return quickAdd.Transaction, nil
+}
+
+func (f *fakeService) ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error) {
+	return []*budgit.CategoryGroup{}, f.err
+}
+
+func (f *fakeService) ListCategories(ctx context.Context) ([]*budgit.Category, error) {
+	return f.categories, f.err
+}
+
+func (f *fakeService) ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	assignments := []*budgit.Assignment{}
+	for _, assignment := range f.assignments {
+		if assignment.Month.Equal(month) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+func (f *fakeService) Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, assignment := range assignments {
+		assignment.ID = "assignment-" + assignment.CategoryID
+	}
+	f.assignments = append(f.assignments, assignments...)
+	return assignments, nil
+}
+
+func (f *fakeService) ListIntegrations() []svc.IntegrationInfo {
+	return f.integrations
+}
+
+func (f *fakeService) LoadAccountsFromIntegration(ctx context.Context, integrationID string) ([]*budgit.Account, error) {
+	return f.accounts, f.err
+}
+
+func (f *fakeService) SyncAccount(ctx context.Context, accountID string) error {
+	f.syncedAccountIDs = append(f.syncedAccountIDs, accountID)
+	return f.err
+}
+
+func (f *fakeService) SetAccountWriteBack(ctx context.Context, accountID string, writeBack bool) error {
+	return f.err
+}
+
+func (f *fakeService) ListExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.ExternalTransaction, error) {
+	f.since = since
+	return []*budgit.ExternalTransaction{}, f.err
+}
+
+// ImportExternalTransactions imports nothing but the Transactions held for the Account.
+func (f *fakeService) ImportExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.Transaction, error) {
+	f.since = since
+	return f.ListTransactions(ctx, accountID)
+}
+
+func (f *fakeService) ListAttachments(ctx context.Context, transactionID string) ([]*budgit.Attachment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	attachments := []*budgit.Attachment{}
+	for _, attachment := range f.attachments {
+		if attachment.TransactionID == transactionID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (f *fakeService) UploadAttachment(ctx context.Context, transactionID, name, contentType string, content io.Reader) (*budgit.Attachment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	contents, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &budgit.Attachment{
+		ID:            fmt.Sprintf("attachment-%d", len(f.attachments)+1),
+		TransactionID: transactionID,
+		Name:          name,
+		ContentType:   contentType,
+		Size:          int64(len(contents)),
+	}
+	f.attachments = append(f.attachments, attachment)
+	if f.attachmentContents == nil {
+		f.attachmentContents = map[string]string{}
+	}
+	f.attachmentContents[attachment.ID] = string(contents)
+	return attachment, nil
+}
+
+func (f *fakeService) DownloadAttachment(ctx context.Context, attachmentID string) (*budgit.Attachment, io.ReadCloser, error) {
+	if f.err != nil {
+		return nil, nil, f.err
+	}
+	for _, attachment := range f.attachments {
+		if attachment.ID == attachmentID {
+			return attachment, io.NopCloser(strings.NewReader(f.attachmentContents[attachmentID])), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, svc.ErrAttachmentNotFound)
+}
+
+func (f *fakeService) ListScheduledPayments(ctx context.Context, accountID string) ([]*budgit.ScheduledPayment, error) {
+	return []*budgit.ScheduledPayment{}, f.err
+}
+
+// Login logs alice in with the password "correct horse" and no TOTP code.
+func (f *fakeService) Login(ctx context.Context, username, password, totpCode string) (*svc.Session, error) {
+	if username != "alice" || password != "correct horse" {
+		return nil, fmt.Errorf("logging in user %q: %w", username, svc.ErrInvalidCredentials)
+	}
+	return &svc.Session{Token: testToken, User: testUser, ExpiresTimestamp: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}, nil
+}
+
+func (f *fakeService) Logout(ctx context.Context, token string) error {
+	f.loggedOutToken = token
+	return f.err
+}
+
+// Authenticate authenticates testToken and testScopedToken as alice. It ignores err, so that errors of other methods can
+// be tested.
+func (f *fakeService) Authenticate(ctx context.Context, token string) (svc.Principal, error) {
+	switch token {
+	case testToken:
+		return svc.Principal{UserID: testUser.ID, Username: testUser.Username}, nil
+	case testScopedToken:
+		return svc.Principal{
+			UserID:           testUser.ID,
+			Username:         testUser.Username,
+			APITokenID:       "token-9",
+			APITokenBudgetID: "budget-2",
+			Scopes:           []budgit.Scope{budgit.ScopeAccountsRead},
+		}, nil
+	default:
+		return svc.Principal{}, svc.ErrUnauthenticated
+	}
+}
+
+func (f *fakeService) CurrentUser(ctx context.Context) (*budgit.User, error) {
+	if principal, ok := svc.PrincipalFrom(ctx); !ok || principal.UserID != testUser.ID {
+		return nil, svc.ErrUnauthenticated
+	}
+	return testUser, f.err
+}
+
+func (f *fakeService) CreateAPIToken(ctx context.Context, username, name string, scopes []budgit.Scope, expires time.Time) (*budgit.APIToken, string, error) {
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	token := &budgit.APIToken{
+		ID:               "token-1",
+		UserID:           testUser.ID,
+		Name:             name,
+		BudgetID:         f.budgetID,
+		Scopes:           scopes,
+		CreatedTimestamp: time.Date(2024, 6, 2, 12, 0, 0, 0, time.UTC),
+		ExpiresTimestamp: expires,
+	}
+	f.apiTokens = append(f.apiTokens, token)
+	return token, "budgit_t_secret", nil
+}
+
+func (f *fakeService) ListAPITokens(ctx context.Context, username string) ([]*budgit.APIToken, error) {
+	return f.apiTokens, f.err
+}
+
+func (f *fakeService) DeleteAPIToken(ctx context.Context, username, tokenID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	for i, token := range f.apiTokens {
+		if token.ID == tokenID {
+			f.apiTokens = append(f.apiTokens[:i], f.apiTokens[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("deleting API token %q of user %q: %w", tokenID, username, svc.ErrAPITokenNotFound)
+}
+
+func (f *fakeService) CreateBudget(ctx context.Context, name string) (*budgit.Budget, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	budget := &budgit.Budget{ID: "budget-1", Name: name, Role: budgit.RoleOwner}
+	f.budgets = append(f.budgets, budget)
+	return budget, nil
+}
+
+func (f *fakeService) ListBudgets(ctx context.Context) ([]*budgit.Budget, error) {
+	return f.budgets, f.err
+}
+
+func (f *fakeService) ListMembers(ctx context.Context) ([]*budgit.BudgetMember, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	return f.members, f.err
+}
+
+func (f *fakeService) SetMember(ctx context.Context, username string, role budgit.Role) error {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	if f.err != nil {
+		return f.err
+	}
+	f.members = append(f.members, &budgit.BudgetMember{
+		BudgetID:         f.budgetID,
+		UserID:           "user-2",
+		Username:         username,
+		Role:             role,
+		CreatedTimestamp: time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC),
+	})
+	return nil
+}
+
+func (f *fakeService) RemoveMember(ctx context.Context, username string) error {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	if f.err != nil {
+		return f.err
+	}
+	for i, member := range f.members {
+		if member.Username == username {
+			f.members = append(f.members[:i], f.members[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("removing member %q of budget %q: %w", username, f.budgetID, svc.ErrMemberNotFound)
+}
+
+func (f *fakeService) CreateInvitation(ctx context.Context, role budgit.Role) (*budgit.BudgetInvitation, string, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	f.invitationIDs = append(f.invitationIDs, "invitation-1")
+	return &budgit.BudgetInvitation{
+		ID:               "invitation-1",
+		BudgetID:         f.budgetID,
+		Role:             role,
+		CreatedTimestamp: time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC),
+		ExpiresTimestamp: time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC),
+	}, "budgit_i_secret", nil
+}
+
+func (f *fakeService) DeleteInvitation(ctx context.Context, invitationID string) error {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	if f.err != nil {
+		return f.err
+	}
+	f.invitationIDs = nil
+	return nil
+}
+
+// AcceptInvitation accepts the token "budgit_i_secret" of the invitation CreateInvitation creates.
+func (f *fakeService) AcceptInvitation(ctx context.Context, token string) (*budgit.Budget, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if token != "budgit_i_secret" {
+		return nil, fmt.Errorf("accepting invitation: %w", svc.ErrInvitationNotFound)
+	}
+	return &budgit.Budget{ID: "budget-2", Name: "Joint", Role: budgit.RoleEditor}, nil
+}
+
+var testUser = &budgit.User{ID: "user-1", Username: "alice", CreatedTimestamp: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+func (s *apiSuite) do(method, path, body string) (*http.Response, string) {
+	req, err := http.NewRequest(method, s.server.URL+path, strings.NewReader(body))
+	s.Require().NoError(err)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+	if s.budget != "" {
+		req.Header.Set("Budgit-Budget", s.budget)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	return resp, string(respBody)
+}
+
+func (s *apiSuite) TestListAccounts() {
+	s.service.accounts = []*budgit.Account{
+		{ID: "account-1", Name: "Current", Balance: budgit.Balance{ClearedBalance: 1000, EffectiveBalance: 900}},
+		{
+			ID:   "account-2",
+			Name: "starling - Joint",
+			ExternalAccount: &budgit.ExternalAccount{
+				ID:                "external-2",
+				Name:              "Joint",
+				IntegrationID:     "starling",
+				LastSyncTimestamp: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+				Balance:           budgit.Balance{ClearedBalance: 50, EffectiveBalance: 50},
+			},
+		},
+	}
+
+	resp, body := s.do(http.MethodGet, "/v1/accounts", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("application/json", resp.Header.Get("Content-Type"))
+	s.JSONEq(`[
+		{"id":"account-1","name":"Current","cleared_balance":1000,"effective_balance":900},
+		{"id":"account-2","name":"starling - Joint","cleared_balance":0,"effective_balance":0,"external_account":{
+			"id":"external-2","name":"Joint","integration_id":"starling","last_sync_timestamp":"2024-06-01T12:00:00Z",
+			"cleared_balance":50,"effective_balance":50,"write_back":false
+		}}
+	]`, body)
+}
+
+func (s *apiSuite) TestCreateTransactions() {
+	resp, body := s.do(http.MethodPost, "/v1/transactions", `[
+		{"id":"transaction-1","effective_date":"2024-06-01","account_id":"account-1","payee_id":"payee-1","amount":-320,"cleared":true,"memo":"Coffee"}
+	]`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`[
+		{"id":"transaction-1","effective_date":"2024-06-01","account_id":"account-1","payee_id":"payee-1","amount":-320,"cleared":true,"memo":"Coffee"}
+	]`, body)
+	s.CMPEqual([]*budgit.Transaction{
+		{
+			ID:            "transaction-1",
+			EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			AccountID:     "account-1",
+			PayeeID:       "payee-1",
+			Amount:        -320,
+			Cleared:       true,
+			Memo:          "Coffee",
+		},
+	}, s.service.transactions)
+}
+
+func (s *apiSuite) TestUpdateTransactions() {
+	resp, body := s.do(http.MethodPut, "/v1/transactions", `[
+		{"id":"transaction-1","effective_date":"2024-06-01","account_id":"account-1","payee_id":"payee-1","amount":-350}
+	]`)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[
+		{"id":"transaction-1","effective_date":"2024-06-01","account_id":"account-1","payee_id":"payee-1","amount":-350,"cleared":false}
+	]`, body)
+}
+
+func (s *apiSuite) TestAssign() {
+	resp, body := s.do(http.MethodPut, "/v1/assignments", `[{"category_id":"category-1","month":"2024-06-01","amount":5000}]`)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[{"id":"assignment-category-1","category_id":"category-1","month":"2024-06-01","amount":5000}]`, body)
+
+	resp, body = s.do(http.MethodGet, "/v1/assignments?month=2024-06-01", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[{"id":"assignment-category-1","category_id":"category-1","month":"2024-06-01","amount":5000}]`, body)
+}
+
+func (s *apiSuite) TestQuickAdd() {
+	expected := `{
+		"transaction":{"id":"transaction-1","effective_date":"2024-06-09","account_id":"account-1","payee_id":"payee-1","amount":-320,"cleared":false,"memo":"coffee"},
+		"new_payee":{"id":"payee-1","name":"Pret"},
+		"matches":[{"field":"account","given":"current","matched":"Current"}]
+	}`
+	resp, body := s.do(http.MethodPost, "/v1/quick-add/preview", `{"text":"coffee 3.20 @Pret yesterday from current","today":"2024-06-10"}`)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(expected, body)
+	s.Equal("coffee 3.20 @Pret yesterday from current", s.service.quickAddInput)
+	s.Equal(time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC), s.service.quickAddToday)
+	s.Empty(s.service.transactions)
+
+	resp, body = s.do(http.MethodPost, "/v1/quick-add", `{"text":"coffee 3.20 @Pret yesterday from current","today":"2024-06-10"}`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(expected, body)
+	s.Len(s.service.transactions, 1)
+	s.Len(s.service.payees, 1)
+}
+
+func (s *apiSuite) TestServiceClient() {
+	s.service.accounts = []*budgit.Account{
+		{
+			ID:              "account-1",
+			Name:            "starling - Joint",
+			Balance:         budgit.Balance{ClearedBalance: 1000, EffectiveBalance: 900},
+			ExternalAccount: &budgit.ExternalAccount{ID: "external-1", Name: "Joint", IntegrationID: "starling", LastSyncTimestamp: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+		},
+	}
+	s.service.categories = []*budgit.Category{{ID: "category-1", GroupID: "group-1", Name: "Groceries"}}
+	client, err := api.NewServiceClient(s.server.URL, api.WithBearerToken(testToken))
+	s.Require().NoError(err)
+
+	accounts, err := client.ListAccounts(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual(s.service.accounts, accounts)
+
+	categories, err := client.ListCategories(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual(s.service.categories, categories)
+
+	transaction := &budgit.Transaction{
+		ID:            "transaction-1",
+		EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		AccountID:     "account-1",
+		PayeeID:       "payee-1",
+		Amount:        -320,
+	}
+	transactions, err := client.CreateTransactions(context.Background(), transaction)
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.Transaction{transaction}, transactions)
+
+	s.service.err = fmt.Errorf("listing transactions of account %q: %w", "account-2", svc.ErrAccountNotFound)
+	_, err = client.ListTransactions(context.Background(), "account-2")
+	problem := &api.ProblemError{}
+	s.Require().ErrorAs(err, &problem)
+	s.Equal(http.StatusNotFound, problem.Status)
+	s.EqualError(err, `listing transactions of account "account-2": the requested Account does not exist`)
+}
+
+func (s *apiSuite) TestAuthentication() {
+	s.token = ""
+	resp, body := s.do(http.MethodGet, "/v1/accounts", "")
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Equal(`Bearer realm="budgit"`, resp.Header.Get("WWW-Authenticate"))
+	s.JSONEq(`{
+		"type":"urn:budgit:problem:unauthenticated","title":"The request is not authenticated","status":401,
+		"detail":"the operation requires authentication"
+	}`, body)
+
+	resp, body = s.do(http.MethodPost, "/v1/sessions", `{"username":"alice","password":"wrong"}`)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.JSONEq(`{
+		"type":"urn:budgit:problem:invalid-credentials","title":"The username, password or TOTP code is incorrect","status":401,
+		"detail":"logging in user \"alice\": the username, password or TOTP code is incorrect"
+	}`, body)
+
+	resp, body = s.do(http.MethodPost, "/v1/sessions", `{"username":"alice","password":"correct horse"}`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`{
+		"token":"budgit_s_test","expires_timestamp":"2024-07-01T12:00:00Z",
+		"user":{"id":"user-1","username":"alice","totp_enabled":false,"created_timestamp":"2024-06-01T12:00:00Z"}
+	}`, body)
+
+	s.token = "budgit_s_expired"
+	resp, _ = s.do(http.MethodGet, "/v1/user", "")
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+
+	s.token = testToken
+	resp, body = s.do(http.MethodGet, "/v1/user", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"id":"user-1","username":"alice","totp_enabled":false,"created_timestamp":"2024-06-01T12:00:00Z"}`, body)
+
+	resp, _ = s.do(http.MethodDelete, "/v1/sessions/current", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+	s.Equal(testToken, s.service.loggedOutToken)
+}
+
+func (s *apiSuite) TestAPITokens() {
+	s.budget = "budget-2"
+	resp, body := s.do(http.MethodPost, "/v1/tokens", `{
+		"name":"phone","scopes":["accounts:read","accounts:sync"],"expires_timestamp":"2024-09-01T00:00:00Z"
+	}`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`{
+		"api_token":{
+			"id":"token-1","name":"phone","budget_id":"budget-2","scopes":["accounts:read","accounts:sync"],
+			"created_timestamp":"2024-06-02T12:00:00Z","expires_timestamp":"2024-09-01T00:00:00Z"
+		},
+		"token":"budgit_t_secret"
+	}`, body)
+
+	resp, body = s.do(http.MethodGet, "/v1/tokens", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[{
+		"id":"token-1","name":"phone","budget_id":"budget-2","scopes":["accounts:read","accounts:sync"],
+		"created_timestamp":"2024-06-02T12:00:00Z","expires_timestamp":"2024-09-01T00:00:00Z"
+	}]`, body)
+
+	s.Run("ScopesRequired", func() {
+		resp, _ := s.do(http.MethodPost, "/v1/tokens", `{"name":"phone"}`)
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	s.Run("UnknownScope", func() {
+		resp, _ := s.do(http.MethodPost, "/v1/tokens", `{"name":"phone","scopes":["everything"]}`)
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+
+	resp, _ = s.do(http.MethodDelete, "/v1/tokens/token-1", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+	s.Empty(s.service.apiTokens)
+
+	resp, body = s.do(http.MethodDelete, "/v1/tokens/token-1", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	s.JSONEq(`{
+		"type":"urn:budgit:problem:api-token-not-found","title":"The API token does not exist","status":404,
+		"detail":"deleting API token \"token-1\" of user \"alice\": the requested API token does not exist"
+	}`, body)
+}
+
+func (s *apiSuite) TestScopedAPIToken() {
+	s.token = testScopedToken
+	resp, _ := s.do(http.MethodGet, "/v1/accounts", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("budget-2", s.service.budgetID, "expected requests without the header to run on the budget of the token")
+
+	resp, body := s.do(http.MethodPost, "/v1/accounts/account-1/sync", "")
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.JSONEq(`{
+		"type":"urn:budgit:problem:forbidden","title":"The operation is not permitted","status":403,
+		"detail":"the API token does not have the scope \"accounts:sync\": the operation is not permitted"
+	}`, body)
+
+	resp, _ = s.do(http.MethodPost, "/v1/tokens", `{"name":"more","scopes":["tokens:manage"]}`)
+	s.Equal(http.StatusForbidden, resp.StatusCode)
+	s.Empty(s.service.apiTokens)
+
+	resp, _ = s.do(http.MethodGet, "/v1/user", "")
+	s.Equal(http.StatusOK, resp.StatusCode, "expected operations without scopes to be permitted")
+}
+
+// TestOperationsHaveScopes guards against operations being added without the scopes API tokens need to use them, which
+// would let every API token use them.
+func (s *apiSuite) TestOperationsHaveScopes() {
+	spec, err := api.GetSwagger()
+	s.Require().NoError(err)
+	unscoped := []string{"getOpenAPISpec", "login", "logout", "getCurrentUser"}
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			if slices.ContainsFunc(unscoped, func(id string) bool { return strings.EqualFold(id, operation.OperationID) }) {
+				continue
+			}
+			s.Run(operation.OperationID, func() {
+				s.Require().NotNil(operation.Security, "expected %s %s to have a security requirement", method, path)
+				s.Require().Len(*operation.Security, 1)
+				scopes := (*operation.Security)[0]["bearerAuth"]
+				s.NotEmpty(scopes, "expected %s %s to require scopes", method, path)
+				for _, scope := range scopes {
+					s.True(budgit.Scope(scope).IsValid(), "expected %q to be a budgit.Scope", scope)
+				}
+			})
+		}
+	}
+}
+
+func (s *apiSuite) TestBudgetHeader() {
+	resp, _ := s.do(http.MethodGet, "/v1/accounts", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(svc.DefaultBudgetID, s.service.budgetID, "expected requests without the header to run on the default budget")
+
+	s.budget = "budget-2"
+	resp, _ = s.do(http.MethodGet, "/v1/accounts", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("budget-2", s.service.budgetID)
+}
+
+func (s *apiSuite) TestBudgets() {
+	resp, body := s.do(http.MethodPost, "/v1/budgets", `{"name":"Joint"}`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`{"id":"budget-1","name":"Joint","role":"owner"}`, body)
+
+	resp, body = s.do(http.MethodGet, "/v1/budgets", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[{"id":"budget-1","name":"Joint","role":"owner"}]`, body)
+
+	s.Run("InvalidRole", func() {
+		resp, _ := s.do(http.MethodPut, "/v1/budgets/budget-1/members/bob", `{"role":"admin"}`)
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+		s.Empty(s.service.members)
+	})
+
+	s.Run("Members", func() {
+		resp, _ := s.do(http.MethodPut, "/v1/budgets/budget-1/members/bob", `{"role":"viewer"}`)
+		s.Equal(http.StatusNoContent, resp.StatusCode)
+		s.Equal("budget-1", s.service.budgetID, "expected the budget of the path rather than the default budget")
+
+		resp, body := s.do(http.MethodGet, "/v1/budgets/budget-1/members", "")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.JSONEq(`[{"user_id":"user-2","username":"bob","role":"viewer","created_timestamp":"2024-06-03T12:00:00Z"}]`, body)
+
+		resp, _ = s.do(http.MethodDelete, "/v1/budgets/budget-1/members/bob", "")
+		s.Equal(http.StatusNoContent, resp.StatusCode)
+		s.Empty(s.service.members)
+
+		resp, body = s.do(http.MethodDelete, "/v1/budgets/budget-1/members/bob", "")
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+		s.JSONEq(`{
+			"type":"urn:budgit:problem:member-not-found","title":"The User is not a member of the Budget","status":404,
+			"detail":"removing member \"bob\" of budget \"budget-1\": the User is not a member of the Budget"
+		}`, body)
+	})
+
+	s.Run("Invitations", func() {
+		resp, body := s.do(http.MethodPost, "/v1/budgets/budget-1/invitations", `{"role":"editor"}`)
+		s.Equal(http.StatusCreated, resp.StatusCode)
+		s.JSONEq(`{
+			"invitation":{
+				"id":"invitation-1","budget_id":"budget-1","role":"editor",
+				"created_timestamp":"2024-06-03T12:00:00Z","expires_timestamp":"2024-06-10T12:00:00Z"
+			},
+			"token":"budgit_i_secret"
+		}`, body)
+
+		resp, body = s.do(http.MethodPost, "/v1/invitations/accept", `{"token":"budgit_i_secret"}`)
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.JSONEq(`{"id":"budget-2","name":"Joint","role":"editor"}`, body)
+
+		resp, _ = s.do(http.MethodPost, "/v1/invitations/accept", `{"token":"budgit_i_other"}`)
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+
+		resp, _ = s.do(http.MethodDelete, "/v1/budgets/budget-1/invitations/invitation-1", "")
+		s.Equal(http.StatusNoContent, resp.StatusCode)
+		s.Empty(s.service.invitationIDs)
+	})
+}
+
+func (s *apiSuite) TestCreatePayeesGeneratesIDs() {
+	resp, _ := s.do(http.MethodPost, "/v1/payees", `[{"name":"Tesco"}]`)
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.Require().Len(s.service.payees, 1)
+	s.NotEmpty(s.service.payees[0].ID)
+	s.Equal("Tesco", s.service.payees[0].Name)
+}
+
+func (s *apiSuite) TestSyncAccount() {
+	resp, body := s.do(http.MethodPost, "/v1/accounts/account-1/sync", "")
+	s.Equal(http.StatusNoContent, resp.StatusCode)
+	s.Empty(body)
+	s.Equal([]string{"account-1"}, s.service.syncedAccountIDs)
+}
+
+func (s *apiSuite) TestListExternalTransactionsSince() {
+	resp, body := s.do(http.MethodGet, "/v1/accounts/account-1/external-transactions?since=2024-06-01", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[]`, body)
+	s.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), s.service.since)
+}
+
+func (s *apiSuite) TestImportExternalTransactions() {
+	s.service.transactions = []*budgit.Transaction{
+		{ID: "transaction-1", AccountID: "account-1", PayeeID: "payee-1", EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Amount: -1000, Cleared: true},
+	}
+	resp, body := s.do(http.MethodPost, "/v1/accounts/account-1/external-transactions/import?since=2024-06-01", "")
+	s.Equal(http.StatusCreated, resp.StatusCode)
+	s.JSONEq(`[{"id":"transaction-1","account_id":"account-1","payee_id":"payee-1","effective_date":"2024-06-02","amount":-1000,"cleared":true}]`, body)
+	s.Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), s.service.since)
+}
+
+func (s *apiSuite) TestAttachments() {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="receipt.txt"`)
+	header.Set("Content-Type", "text/plain")
+	part, err := writer.CreatePart(header)
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("a receipt"))
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req, err := http.NewRequest(http.MethodPost, s.server.URL+"/v1/transactions/transaction-1/attachments", &form)
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	s.Require().NoError(err)
+	s.Equal(http.StatusCreated, resp.StatusCode, string(body))
+	s.JSONEq(`{"id":"attachment-1","transaction_id":"transaction-1","name":"receipt.txt","content_type":"text/plain","size":9}`, string(body))
+
+	s.Run("List", func() {
+		resp, body := s.do(http.MethodGet, "/v1/transactions/transaction-1/attachments", "")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.JSONEq(`[{"id":"attachment-1","transaction_id":"transaction-1","name":"receipt.txt","content_type":"text/plain","size":9}]`, body)
+	})
+
+	s.Run("Download", func() {
+		resp, body := s.do(http.MethodGet, "/v1/attachments/attachment-1", "")
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal("text/plain", resp.Header.Get("Content-Type"))
+		s.Equal(`attachment; filename=receipt.txt`, resp.Header.Get("Content-Disposition"))
+		s.Equal("a receipt", body)
+	})
+
+	s.Run("DownloadNotFound", func() {
+		resp, body := s.do(http.MethodGet, "/v1/attachments/attachment-2", "")
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+		s.Contains(body, `"urn:budgit:problem:attachment-not-found"`)
+	})
+
+	s.Run("UploadWithoutFile", func() {
+		resp, _ := s.do(http.MethodPost, "/v1/transactions/transaction-1/attachments", "")
+		s.Equal(http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func (s *apiSuite) TestListIntegrations() {
+	s.service.integrations = []svc.IntegrationInfo{
+		{ID: "starling", Capabilities: []svc.Capability{svc.CapabilityTransactionImport, svc.CapabilityScheduledPayments}},
+	}
+	resp, body := s.do(http.MethodGet, "/v1/integrations", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`[{"id":"starling","capabilities":["transaction_import","scheduled_payments"]}]`, body)
+}
+
+func (s *apiSuite) TestErrors() {
+	testCases := []struct {
+		name            string
+		err             error
+		method, path    string
+		body            string
+		expectedStatus  int
+		expectedProblem string
+	}{
+		{
+			name:   "MissingAccountsAndPayees",
+			err:    fmt.Errorf("creating transactions: %w", errors.Join(svc.MissingAccountsError{AccountIDs: []string{"account-1"}}, svc.MissingPayeesError{PayeeIDs: []string{"payee-1"}})),
+			method: http.MethodPost, path: "/v1/transactions", body: `[]`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:missing-accounts","title":"Referenced Accounts do not exist","status":422,
+				"detail":"creating transactions: transactions reference Accounts that do not exist: [account-1]\ntransactions reference Payees that do not exist: [payee-1]",
+				"account_ids":["account-1"],"payee_ids":["payee-1"]
+			}`,
+		},
+		{
+			name:   "DuplicatePayees",
+			err:    fmt.Errorf("creating payees: %w", svc.DuplicatePayeesError{PayeeNames: []string{"Tesco"}}),
+			method: http.MethodPost, path: "/v1/payees", body: `[{"name":"Tesco"}]`,
+			expectedStatus: http.StatusConflict,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:duplicate-payees","title":"Payees with the given names already exist","status":409,
+				"detail":"creating payees: payees created with names that already exist: [Tesco]","payee_names":["Tesco"]
+			}`,
+		},
+		{
+			name: "AccountSync",
+			err: svc.AccountSyncError{
+				AccountName:     "Current",
+				ExternalBalance: budgit.Balance{ClearedBalance: 100, EffectiveBalance: 90},
+				InternalBalance: budgit.Balance{ClearedBalance: 100, EffectiveBalance: 100},
+			},
+			method: http.MethodPost, path: "/v1/accounts/account-1/sync",
+			expectedStatus: http.StatusConflict,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:account-sync-mismatch","title":"The synced balance does not match the external account","status":409,
+				"detail":"syncing Account \"Current\" failed, balance synced from external account {ClearedBalance:1.00 EffectiveBalance:0.90} does not match balance of internal account {ClearedBalance:1.00 EffectiveBalance:1.00}",
+				"account_name":"Current",
+				"external_balance":{"cleared_balance":100,"effective_balance":90},
+				"internal_balance":{"cleared_balance":100,"effective_balance":100}
+			}`,
+		},
+		{
+			name:   "AccountNotFound",
+			err:    fmt.Errorf("listing transactions of account %q: %w", "account-1", svc.ErrAccountNotFound),
+			method: http.MethodGet, path: "/v1/accounts/account-1/transactions",
+			expectedStatus: http.StatusNotFound,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:account-not-found","title":"The Account does not exist","status":404,
+				"detail":"listing transactions of account \"account-1\": the requested Account does not exist"
+			}`,
+		},
+		{
+			name:   "UnsupportedCapability",
+			err:    svc.UnsupportedCapabilityError{IntegrationID: "monzo", Capability: svc.CapabilityScheduledPayments},
+			method: http.MethodGet, path: "/v1/accounts/account-1/scheduled-payments",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:unsupported-capability","title":"The Integration does not support the operation","status":422,
+				"detail":"integration \"monzo\" does not support scheduled_payments","integration_id":"monzo","capability":"scheduled_payments"
+			}`,
+		},
+		{
+			name:   "Internal",
+			err:    fmt.Errorf("listing accounts: %w", errors.New("connection refused")),
+			method: http.MethodGet, path: "/v1/accounts",
+			expectedStatus: http.StatusInternalServerError,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:internal","title":"An unexpected error occurred","status":500
+			}`,
+		},
+		{
+			name: "AmbiguousQuickAdd",
+			err: svc.QuickAddError{
+				Input: "3.20 @Pret from starling",
+				Err:   svc.AmbiguousNameError{Field: "account", Name: "starling", Candidates: []string{"Starling - Personal", "Starling - Joint"}},
+			},
+			method: http.MethodPost, path: "/v1/quick-add/preview", body: `{"text":"3.20 @Pret from starling"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:invalid-quick-add","title":"The quick-add input cannot be parsed or its names matched","status":422,
+				"detail":"quick-adding \"3.20 @Pret from starling\": \"starling\" matches more than one account: Starling - Personal, Starling - Joint",
+				"candidates":["Starling - Personal","Starling - Joint"]
+			}`,
+		},
+		{
+			name:   "BudgetNotFound",
+			err:    fmt.Errorf("listing accounts: %w", svc.ErrBudgetNotFound),
+			method: http.MethodGet, path: "/v1/accounts",
+			expectedStatus: http.StatusNotFound,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:budget-not-found","title":"The Budget does not exist","status":404,
+				"detail":"listing accounts: the requested Budget does not exist"
+			}`,
+		},
+		{
+			name:   "Forbidden",
+			err:    fmt.Errorf("creating payees: %w", svc.ErrForbidden),
+			method: http.MethodPost, path: "/v1/payees", body: `[{"name":"Tesco"}]`,
+			expectedStatus: http.StatusForbidden,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:forbidden","title":"The operation is not permitted","status":403,
+				"detail":"creating payees: the operation is not permitted"
+			}`,
+		},
+		{
+			name:   "LastOwner",
+			err:    fmt.Errorf("removing member %q of budget %q: %w", "alice", "budget-1", svc.ErrLastOwner),
+			method: http.MethodDelete, path: "/v1/budgets/budget-1/members/alice",
+			expectedStatus: http.StatusConflict,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:last-owner","title":"A Budget must keep at least one owner","status":409,
+				"detail":"removing member \"alice\" of budget \"budget-1\": a Budget must keep at least one owner"
+			}`,
+		},
+		{
+			name:   "InvalidBody",
+			method: http.MethodPost, path: "/v1/payees", body: `[{"name":"Tesco","colour":"blue"}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:bad-request","title":"The request is not valid","status":400,
+				"detail":"request body at \"/0\": property \"colour\" is unsupported"
+			}`,
+		},
+		{
+			name:   "MissingRequiredField",
+			method: http.MethodPost, path: "/v1/transactions", body: `[{"account_id":"account-1","payee_id":"payee-1","amount":-320}]`,
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:bad-request","title":"The request is not valid","status":400,
+				"detail":"request body at \"/0/effective_date\": property \"effective_date\" is missing"
+			}`,
+		},
+		{
+			name:   "InvalidDate",
+			method: http.MethodGet, path: "/v1/accounts/account-1/external-transactions?since=yesterday",
+			expectedStatus: http.StatusBadRequest,
+			expectedProblem: `{
+				"type":"urn:budgit:problem:bad-request","title":"The request is not valid","status":400,
+				"detail":"Invalid format for parameter since: error parsing 'yesterday' as date: parsing time \"yesterday\" as \"2006-01-02\": cannot parse \"yesterday\" as \"2006\""
+			}`,
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.service.err = tc.err
+			resp, body := s.do(tc.method, tc.path, tc.body)
+			s.Equal(tc.expectedStatus, resp.StatusCode)
+			s.Equal("application/problem+json", resp.Header.Get("Content-Type"))
+			s.JSONEq(tc.expectedProblem, body)
+		})
+	}
+}
+
+func (s *apiSuite) TestGetOpenAPISpec() {
+	resp, body := s.do(http.MethodGet, "/v1/openapi.json", "")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	spec := struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}{}
+	s.Require().NoError(json.Unmarshal([]byte(body), &spec))
+	s.Equal("3.0.3", spec.OpenAPI)
+	s.Contains(spec.Paths, "/v1/accounts")
+}
+
+func (s *apiSuite) TestRequestTooLarge() {
+	resp, body := s.do(http.MethodPost, "/v1/payees", `[{"name":"`+strings.Repeat("a", 2<<20)+`"}]`)
+	s.Equal(http.StatusRequestEntityTooLarge, resp.StatusCode)
+	problem := &api.Problem{}
+	s.Require().NoError(json.Unmarshal([]byte(body), problem))
+	s.Equal("urn:budgit:problem:request-too-large", problem.Type)
+}
+
+func (s *apiSuite) TestServeShutsDownGracefully() {
+	listener, err := net.Listen("tcp", "localhost:0")
+	s.Require().NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	server, err := api.New(zap.NewNop().Sugar(), s.service)
+	s.Require().NoError(err)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(ctx, listener)
+	}()
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/v1/openapi.json")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-served:
+		s.NoError(err)
+	case <-time.After(5 * time.Second):
+		s.Fail("expected Serve to return after its context is done")
+	}
+	_, err = http.Get("http://" + listener.Addr().String() + "/v1/openapi.json")
+	s.Error(err)
+}
+
+func (s *apiSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
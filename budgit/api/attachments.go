@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) ListAttachments(w http.ResponseWriter, r *http.Request, transactionID TransactionID) {
+	attachments, err := s.service.ListAttachments(r.Context(), transactionID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(attachments, toAttachment))
+}
+
+// UploadAttachment attaches the file of the "file" part of a multipart form to a Transaction, named by the filename
+// of the part and of its content type, if given.
+func (s *Server) UploadAttachment(w http.ResponseWriter, r *http.Request, transactionID TransactionID) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		s.writeError(w, r, badRequestError{fmt.Errorf("reading file: %w", err)})
+		return
+	}
+	defer file.Close()
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	attachment, err := s.service.UploadAttachment(r.Context(), transactionID, header.Filename, contentType, file)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAttachment(attachment))
+}
+
+// DownloadAttachment writes the content of an Attachment, as a download named by its name.
+func (s *Server) DownloadAttachment(w http.ResponseWriter, r *http.Request, attachmentID string) {
+	attachment, content, err := s.service.DownloadAttachment(r.Context(), attachmentID)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	defer content.Close()
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		s.log.Warnw("Writing attachment content", zap.String("attachment_id", attachmentID), zap.Error(err))
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+)
+
+// authenticate runs requests of operations requiring a bearer token under the Principal it authenticates, on the budget
+// of the request, writing an unauthenticated problem if it is missing or not valid. Operations which do not, such as
+// logging in, are marked with an empty security requirement in the OpenAPI spec, so the generated wrapper does not set
+// BearerAuthScopes.
+//
+// The scopes of the security requirement of each operation, which the generated wrapper sets as BearerAuthScopes, must
+// all be scopes of the Principal, see svc.Principal.HasScope, or a forbidden problem is written.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(BearerAuthScopes) == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token, ok := bearerToken(r)
+		if !ok {
+			s.writeUnauthenticated(w, r, svc.ErrUnauthenticated)
+			return
+		}
+		principal, err := s.service.Authenticate(r.Context(), token)
+		if err != nil {
+			if errors.Is(err, svc.ErrUnauthenticated) {
+				s.writeUnauthenticated(w, r, err)
+			} else {
+				s.writeError(w, r, err)
+			}
+			return
+		}
+		scopes, _ := r.Context().Value(BearerAuthScopes).([]string)
+		for _, scope := range scopes {
+			if !principal.HasScope(budgit.Scope(scope)) {
+				s.writeError(w, r, fmt.Errorf("the API token does not have the scope %q: %w", scope, svc.ErrForbidden))
+				return
+			}
+		}
+		ctx := svc.WithBudget(svc.WithPrincipal(r.Context(), principal), requestBudget(r, principal))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (s *Server) writeUnauthenticated(w http.ResponseWriter, r *http.Request, err error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="budgit"`)
+	s.writeError(w, r, err)
+}
+
+// bearerToken returns the token of the Authorization header of a request, if it has one.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+func (s *Server) Login(w http.ResponseWriter, r *http.Request) {
+	var body LoginJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	session, err := s.service.Login(r.Context(), body.Username, body.Password, body.TOTPCode)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &Session{
+		Token:            session.Token,
+		ExpiresTimestamp: session.ExpiresTimestamp,
+		User:             *ToUser(session.User),
+	})
+}
+
+// Logout ends the session of the token authenticating the request. Requests authenticated by an API token end no
+// session; the token is revoked by deleting it instead.
+func (s *Server) Logout(w http.ResponseWriter, r *http.Request) {
+	token, _ := bearerToken(r)
+	if err := s.service.Logout(r.Context(), token); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, err := s.service.CurrentUser(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ToUser(user))
+}
+
+func (s *Server) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	principal, _ := svc.PrincipalFrom(r.Context())
+	tokens, err := s.service.ListAPITokens(r.Context(), principal.Username)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(tokens, ToAPIToken))
+}
+
+func (s *Server) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	var body CreateAPITokenJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	scopes := make([]budgit.Scope, 0, len(body.Scopes))
+	for _, scope := range body.Scopes {
+		scopes = append(scopes, budgit.Scope(scope))
+	}
+	var expires time.Time
+	if body.ExpiresTimestamp != nil {
+		expires = *body.ExpiresTimestamp
+	}
+	principal, _ := svc.PrincipalFrom(r.Context())
+	apiToken, token, err := s.service.CreateAPIToken(r.Context(), principal.Username, body.Name, scopes, expires)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &NewAPIToken{APIToken: *ToAPIToken(apiToken), Token: token})
+}
+
+func (s *Server) DeleteAPIToken(w http.ResponseWriter, r *http.Request, tokenID string) {
+	principal, _ := svc.PrincipalFrom(r.Context())
+	if err := s.service.DeleteAPIToken(r.Context(), principal.Username, tokenID); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
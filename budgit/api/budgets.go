@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+)
+
+// budgetHeader is the header naming the budget a request reads and writes, see svc.WithBudget. Requests without it run
+// on the budget of their API token, or the default budget.
+const budgetHeader = "Budgit-Budget"
+
+// requestBudget returns the ID of the budget a request of a Principal runs on.
+func requestBudget(r *http.Request, principal svc.Principal) string {
+	if budgetID := r.Header.Get(budgetHeader); budgetID != "" {
+		return budgetID
+	}
+	if principal.APITokenBudgetID != "" {
+		return principal.APITokenBudgetID
+	}
+	return svc.DefaultBudgetID
+}
+
+func (s *Server) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	budgets, err := s.service.ListBudgets(r.Context())
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(budgets, ToBudget))
+}
+
+func (s *Server) CreateBudget(w http.ResponseWriter, r *http.Request) {
+	var body CreateBudgetJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	budget, err := s.service.CreateBudget(r.Context(), body.Name)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, ToBudget(budget))
+}
+
+func (s *Server) ListBudgetMembers(w http.ResponseWriter, r *http.Request, budgetID string) {
+	members, err := s.service.ListMembers(svc.WithBudget(r.Context(), budgetID))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSlice(members, ToBudgetMember))
+}
+
+func (s *Server) SetBudgetMember(w http.ResponseWriter, r *http.Request, budgetID, username string) {
+	var body SetBudgetMemberJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	if err := s.service.SetMember(svc.WithBudget(r.Context(), budgetID), username, budgit.Role(body.Role)); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) RemoveBudgetMember(w http.ResponseWriter, r *http.Request, budgetID, username string) {
+	if err := s.service.RemoveMember(svc.WithBudget(r.Context(), budgetID), username); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) CreateBudgetInvitation(w http.ResponseWriter, r *http.Request, budgetID string) {
+	var body CreateBudgetInvitationJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	invitation, token, err := s.service.CreateInvitation(svc.WithBudget(r.Context(), budgetID), budgit.Role(body.Role))
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, &NewBudgetInvitation{Invitation: *ToBudgetInvitation(invitation), Token: token})
+}
+
+func (s *Server) DeleteBudgetInvitation(w http.ResponseWriter, r *http.Request, budgetID, invitationID string) {
+	if err := s.service.DeleteInvitation(svc.WithBudget(r.Context(), budgetID), invitationID); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) AcceptBudgetInvitation(w http.ResponseWriter, r *http.Request) {
+	var body AcceptBudgetInvitationJSONRequestBody
+	if err := decodeJSON(r, &body); err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	budget, err := s.service.AcceptInvitation(r.Context(), body.Token)
+	if err != nil {
+		s.writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ToBudget(budget))
+}
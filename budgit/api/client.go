@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ProblemError is the Problem the API responded with to a request which failed.
+type ProblemError struct {
+	Problem
+}
+
+func (e *ProblemError) Error() string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Title
+}
+
+// ServiceClient calls the API with the methods of the budgit service, so that clients such as the terminal UI can use
+// either interchangeably. Errors responded with are returned as a *ProblemError.
+type ServiceClient struct {
+	client ClientWithResponsesInterface
+}
+
+// NewServiceClient returns a ServiceClient calling the API served at the given URL, e.g. "http://localhost:8080".
+func NewServiceClient(server string, opts ...ClientOption) (*ServiceClient, error) {
+	client, err := NewClientWithResponses(server, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating API client: %w", err)
+	}
+	return &ServiceClient{client: client}, nil
+}
+
+func (c *ServiceClient) CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error) {
+	resp, err := c.client.CreateAccountsWithResponse(ctx, mapSlice(accounts, func(account *budgit.Account) Account {
+		return *ToAccount(account)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("creating accounts: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON201, resp.ApplicationProblemJSONDefault, fromAccountResponse)
+}
+
+func (c *ServiceClient) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
+	resp, err := c.client.ListAccountsWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing accounts: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromAccountResponse)
+}
+
+func (c *ServiceClient) CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
+	resp, err := c.client.CreatePayeesWithResponse(ctx, mapSlice(payees, func(payee *budgit.Payee) Payee {
+		return *ToPayee(payee)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("creating payees: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON201, resp.ApplicationProblemJSONDefault, fromPayee)
+}
+
+func (c *ServiceClient) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	resp, err := c.client.ListPayeesWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing payees: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromPayee)
+}
+
+func (c *ServiceClient) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	resp, err := c.client.CreateTransactionsWithResponse(ctx, mapSlice(transactions, func(transaction *budgit.Transaction) Transaction {
+		return *ToTransaction(transaction)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("creating transactions: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON201, resp.ApplicationProblemJSONDefault, fromTransaction)
+}
+
+func (c *ServiceClient) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	resp, err := c.client.ListTransactionsWithResponse(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("listing transactions of account %q: %w", accountID, err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromTransaction)
+}
+
+func (c *ServiceClient) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	resp, err := c.client.UpdateTransactionsWithResponse(ctx, mapSlice(transactions, func(transaction *budgit.Transaction) Transaction {
+		return *ToTransaction(transaction)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("updating transactions: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromTransaction)
+}
+
+func (c *ServiceClient) ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error) {
+	resp, err := c.client.ListCategoryGroupsWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing category groups: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromCategoryGroup)
+}
+
+func (c *ServiceClient) ListCategories(ctx context.Context) ([]*budgit.Category, error) {
+	resp, err := c.client.ListCategoriesWithResponse(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromCategory)
+}
+
+func (c *ServiceClient) ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error) {
+	resp, err := c.client.ListAssignmentsWithResponse(ctx, &ListAssignmentsParams{Month: openapi_types.Date{Time: month}})
+	if err != nil {
+		return nil, fmt.Errorf("listing assignments for %s: %w", month.Format("January 2006"), err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromAssignment)
+}
+
+func (c *ServiceClient) Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	resp, err := c.client.AssignWithResponse(ctx, mapSlice(assignments, func(assignment *budgit.Assignment) Assignment {
+		return *toAssignment(assignment)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("assigning to categories: %w", err)
+	}
+	return decodeResponse(resp.StatusCode(), resp.JSON200, resp.ApplicationProblemJSONDefault, fromAssignment)
+}
+
+// decodeResponse converts the values of a successful response, which the generated client only decodes for the
+// expected status, otherwise returning the Problem responded with.
+func decodeResponse[T, R any](status int, values *[]T, problem *Problem, convert func(T) R) ([]R, error) {
+	if values != nil {
+		return mapSlice(*values, convert), nil
+	}
+	if problem == nil {
+		problem = &Problem{Status: status, Title: http.StatusText(status)}
+	}
+	return nil, &ProblemError{Problem: *problem}
+}
+
+// fromAccountResponse converts an Account responded with, including the external account it is linked to.
+func fromAccountResponse(account Account) *budgit.Account {
+	a := fromAccount(account)
+	if external := account.ExternalAccount; external != nil {
+		a.ExternalAccount = &budgit.ExternalAccount{
+			ID:                external.ID,
+			Name:              external.Name,
+			IntegrationID:     external.IntegrationID,
+			LastSyncTimestamp: external.LastSyncTimestamp,
+			Balance: budgit.Balance{
+				ClearedBalance:   budgit.BalanceAmount(external.ClearedBalance),
+				EffectiveBalance: budgit.BalanceAmount(external.EffectiveBalance),
+			},
+			WriteBack: external.WriteBack,
+		}
+	}
+	return a
+}
+
+// WithBearerToken authenticates the requests of a client with the token of a session or an API token.
+func WithBearerToken(token string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// WithBudget runs the requests of a client on a budget, rather than the default budget.
+func WithBudget(budgetID string) ClientOption {
+	return WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(budgetHeader, budgetID)
+		return nil
+	})
+}
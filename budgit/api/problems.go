@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"go.uber.org/zap"
+)
+
+// problemTypePrefix prefixes the slugs of the types of problems.
+const problemTypePrefix = "urn:budgit:problem:"
+
+// badRequestError is an error in a request itself, such as a body which is not valid JSON.
+type badRequestError struct {
+	err error
+}
+
+func (e badRequestError) Error() string {
+	return e.err.Error()
+}
+
+func (e badRequestError) Unwrap() error {
+	return e.err
+}
+
+// toProblem describes an error as a Problem. An error may join several typed errors, such as the MissingAccountsError
+// and MissingPayeesError of invalid Transactions, in which case the first determines the type and status, and the
+// fields of each are included.
+func toProblem(err error) *Problem {
+	problem := &Problem{}
+	set := func(status int, slug, title string) {
+		if problem.Status == 0 {
+			problem.Status, problem.Type, problem.Title = status, problemTypePrefix+slug, title
+		}
+	}
+
+	var (
+		tooLarge              *http.MaxBytesError
+		badRequest            badRequestError
+		missingAccounts       svc.MissingAccountsError
+		missingPayees         svc.MissingPayeesError
+		missingCategories     svc.MissingCategoriesError
+		missingCategoryGroups svc.MissingCategoryGroupsError
+		duplicatePayees       svc.DuplicatePayeesError
+		accountSync           svc.AccountSyncError
+		unsupportedCapability svc.UnsupportedCapabilityError
+		quickAdd              svc.QuickAddError
+		ambiguousName         svc.AmbiguousNameError
+	)
+	if errors.As(err, &tooLarge) {
+		set(http.StatusRequestEntityTooLarge, "request-too-large", "The request body is too large")
+	}
+	if errors.As(err, &badRequest) {
+		set(http.StatusBadRequest, "bad-request", "The request is not valid")
+	}
+	if errors.As(err, &missingAccounts) {
+		set(http.StatusUnprocessableEntity, "missing-accounts", "Referenced Accounts do not exist")
+		problem.AccountIDs = missingAccounts.AccountIDs
+	}
+	if errors.As(err, &missingPayees) {
+		set(http.StatusUnprocessableEntity, "missing-payees", "Referenced Payees do not exist")
+		problem.PayeeIDs = missingPayees.PayeeIDs
+	}
+	if errors.As(err, &missingCategories) {
+		set(http.StatusUnprocessableEntity, "missing-categories", "Referenced Categories do not exist")
+		problem.CategoryIDs = missingCategories.CategoryIDs
+	}
+	if errors.As(err, &missingCategoryGroups) {
+		set(http.StatusUnprocessableEntity, "missing-category-groups", "Referenced Category Groups do not exist")
+		problem.CategoryGroupIDs = missingCategoryGroups.CategoryGroupIDs
+	}
+	if errors.As(err, &duplicatePayees) {
+		set(http.StatusConflict, "duplicate-payees", "Payees with the given names already exist")
+		problem.PayeeNames = duplicatePayees.PayeeNames
+	}
+	if errors.As(err, &accountSync) {
+		set(http.StatusConflict, "account-sync-mismatch", "The synced balance does not match the external account")
+		problem.AccountName = accountSync.AccountName
+		problem.ExternalBalance = &Balance{
+			ClearedBalance:   int64(accountSync.ExternalBalance.ClearedBalance),
+			EffectiveBalance: int64(accountSync.ExternalBalance.EffectiveBalance),
+		}
+		problem.InternalBalance = &Balance{
+			ClearedBalance:   int64(accountSync.InternalBalance.ClearedBalance),
+			EffectiveBalance: int64(accountSync.InternalBalance.EffectiveBalance),
+		}
+	}
+	if errors.As(err, &unsupportedCapability) {
+		set(http.StatusUnprocessableEntity, "unsupported-capability", "The Integration does not support the operation")
+		problem.IntegrationID = unsupportedCapability.IntegrationID
+		problem.Capability = string(unsupportedCapability.Capability)
+	}
+
+	if errors.As(err, &quickAdd) {
+		set(http.StatusUnprocessableEntity, "invalid-quick-add", "The quick-add input cannot be parsed or its names matched")
+	}
+	if errors.As(err, &ambiguousName) {
+		problem.Candidates = ambiguousName.Candidates
+	}
+
+	switch {
+	case errors.Is(err, svc.ErrAccountNotFound):
+		set(http.StatusNotFound, "account-not-found", "The Account does not exist")
+	case errors.Is(err, svc.ErrIntegrationNotFound):
+		set(http.StatusNotFound, "integration-not-found", "The Integration is not configured")
+	case errors.Is(err, svc.ErrTransactionNotFound):
+		set(http.StatusNotFound, "transaction-not-found", "The Transaction does not exist")
+	case errors.Is(err, svc.ErrAttachmentNotFound):
+		set(http.StatusNotFound, "attachment-not-found", "The Attachment does not exist")
+	case errors.Is(err, svc.ErrAccountNotLinked):
+		set(http.StatusConflict, "account-not-linked", "The Account is not linked to an external account")
+	case errors.Is(err, svc.ErrUnauthenticated):
+		set(http.StatusUnauthorized, "unauthenticated", "The request is not authenticated")
+	case errors.Is(err, svc.ErrInvalidCredentials):
+		set(http.StatusUnauthorized, "invalid-credentials", "The username, password or TOTP code is incorrect")
+	case errors.Is(err, svc.ErrTOTPRequired):
+		set(http.StatusUnauthorized, "totp-required", "A TOTP code is required to log in")
+	case errors.Is(err, svc.ErrForbidden):
+		set(http.StatusForbidden, "forbidden", "The operation is not permitted")
+	case errors.Is(err, svc.ErrUserNotFound):
+		set(http.StatusNotFound, "user-not-found", "The User does not exist")
+	case errors.Is(err, svc.ErrAPITokenNotFound):
+		set(http.StatusNotFound, "api-token-not-found", "The API token does not exist")
+	case errors.Is(err, svc.ErrUsernameTaken):
+		set(http.StatusConflict, "username-taken", "A User with the username already exists")
+	case errors.Is(err, svc.ErrBudgetNotFound):
+		set(http.StatusNotFound, "budget-not-found", "The Budget does not exist")
+	case errors.Is(err, svc.ErrMemberNotFound):
+		set(http.StatusNotFound, "member-not-found", "The User is not a member of the Budget")
+	case errors.Is(err, svc.ErrInvitationNotFound):
+		set(http.StatusNotFound, "invitation-not-found", "The invitation does not exist or has expired")
+	case errors.Is(err, svc.ErrLastOwner):
+		set(http.StatusConflict, "last-owner", "A Budget must keep at least one owner")
+	case errors.Is(err, svc.ErrInvalidBudgetName),
+		errors.Is(err, svc.ErrInvalidRole):
+		set(http.StatusUnprocessableEntity, "invalid-budget", "The budget name or role is not valid")
+	case errors.Is(err, svc.ErrInvalidUsername),
+		errors.Is(err, svc.ErrPasswordTooShort),
+		errors.Is(err, svc.ErrInvalidTOTPCode):
+		set(http.StatusUnprocessableEntity, "invalid-user", "The username, password or TOTP code is not valid")
+	case errors.Is(err, svc.ErrInvalidScopes),
+		errors.Is(err, svc.ErrInvalidExpiry):
+		set(http.StatusUnprocessableEntity, "invalid-api-token", "The scopes or expiry of the API token are not valid")
+	}
+
+	if problem.Status == 0 {
+		// The details of unexpected errors are logged rather than returned, as they may reveal internals.
+		set(http.StatusInternalServerError, "internal", "An unexpected error occurred")
+		return problem
+	}
+	problem.Detail = err.Error()
+	return problem
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	problem := toProblem(err)
+	if problem.Status == http.StatusInternalServerError {
+		s.log.Errorw("Serving request", zap.String("method", r.Method), zap.String("path", r.URL.Path), zap.Error(err))
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
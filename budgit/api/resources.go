@@ -0,0 +1,249 @@
+package api
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ToAccount converts an Account to its API model.
+func ToAccount(account *budgit.Account) *Account {
+	a := &Account{
+		ID:               account.ID,
+		Name:             account.Name,
+		ClearedBalance:   int64(account.Balance.ClearedBalance),
+		EffectiveBalance: int64(account.Balance.EffectiveBalance),
+	}
+	if external := account.ExternalAccount; external != nil {
+		a.ExternalAccount = &ExternalAccount{
+			ID:                external.ID,
+			Name:              external.Name,
+			IntegrationID:     external.IntegrationID,
+			LastSyncTimestamp: external.LastSyncTimestamp,
+			ClearedBalance:    int64(external.Balance.ClearedBalance),
+			EffectiveBalance:  int64(external.Balance.EffectiveBalance),
+			WriteBack:         external.WriteBack,
+		}
+	}
+	return a
+}
+
+// fromAccount converts an Account to create. Accounts are only linked to external accounts by loading them from an
+// Integration, so any ExternalAccount is ignored.
+func fromAccount(account Account) *budgit.Account {
+	return &budgit.Account{
+		ID:   idOrNew(account.ID),
+		Name: account.Name,
+		Balance: budgit.Balance{
+			ClearedBalance:   budgit.BalanceAmount(account.ClearedBalance),
+			EffectiveBalance: budgit.BalanceAmount(account.EffectiveBalance),
+		},
+	}
+}
+
+// ToPayee converts a Payee to its API model.
+func ToPayee(payee *budgit.Payee) *Payee {
+	return &Payee{ID: payee.ID, Name: payee.Name}
+}
+
+func fromPayee(payee Payee) *budgit.Payee {
+	return &budgit.Payee{ID: idOrNew(payee.ID), Name: payee.Name}
+}
+
+// ToTransaction converts a Transaction to its API model.
+func ToTransaction(transaction *budgit.Transaction) *Transaction {
+	return &Transaction{
+		ID:                  transaction.ID,
+		EffectiveDate:       openapi_types.Date{Time: transaction.EffectiveDate},
+		AccountID:           transaction.AccountID,
+		PayeeID:             transaction.PayeeID,
+		IsPayeeInternal:     transaction.IsPayeeInternal,
+		CategoryID:          transaction.CategoryID,
+		Amount:              int64(transaction.Amount),
+		Cleared:             transaction.Cleared,
+		Memo:                transaction.Memo,
+		ImportID:            transaction.ImportID,
+		ImportIntegrationID: transaction.ImportIntegrationID,
+		SplitID:             transaction.SplitID,
+	}
+}
+
+func fromTransaction(transaction Transaction) *budgit.Transaction {
+	return &budgit.Transaction{
+		ID:                  idOrNew(transaction.ID),
+		EffectiveDate:       transaction.EffectiveDate.Time,
+		AccountID:           transaction.AccountID,
+		PayeeID:             transaction.PayeeID,
+		IsPayeeInternal:     transaction.IsPayeeInternal,
+		CategoryID:          transaction.CategoryID,
+		Amount:              budgit.BalanceAmount(transaction.Amount),
+		Cleared:             transaction.Cleared,
+		Memo:                transaction.Memo,
+		ImportID:            transaction.ImportID,
+		ImportIntegrationID: transaction.ImportIntegrationID,
+		SplitID:             transaction.SplitID,
+	}
+}
+
+func toCategoryGroup(group *budgit.CategoryGroup) *CategoryGroup {
+	return &CategoryGroup{ID: group.ID, Name: group.Name}
+}
+
+func fromCategoryGroup(group CategoryGroup) *budgit.CategoryGroup {
+	return &budgit.CategoryGroup{ID: group.ID, Name: group.Name}
+}
+
+func toCategory(category *budgit.Category) *Category {
+	return &Category{ID: category.ID, GroupID: category.GroupID, Name: category.Name}
+}
+
+func fromCategory(category Category) *budgit.Category {
+	return &budgit.Category{ID: category.ID, GroupID: category.GroupID, Name: category.Name}
+}
+
+func toAssignment(assignment *budgit.Assignment) *Assignment {
+	return &Assignment{
+		ID:         assignment.ID,
+		CategoryID: assignment.CategoryID,
+		Month:      openapi_types.Date{Time: assignment.Month},
+		Amount:     int64(assignment.Amount),
+	}
+}
+
+// fromAssignment converts an Assignment to assign. Its ID is left empty if not given, as the ID of any amount already
+// assigned to the Category for the month is kept.
+func fromAssignment(assignment Assignment) *budgit.Assignment {
+	return &budgit.Assignment{
+		ID:         assignment.ID,
+		CategoryID: assignment.CategoryID,
+		Month:      assignment.Month.Time,
+		Amount:     budgit.BalanceAmount(assignment.Amount),
+	}
+}
+
+func toExternalTransaction(transaction *budgit.ExternalTransaction) *ExternalTransaction {
+	return &ExternalTransaction{
+		ID:                transaction.ID,
+		ExternalAccountID: transaction.ExternalAccountID,
+		IntegrationID:     transaction.IntegrationID,
+		EffectiveDate:     openapi_types.Date{Time: transaction.EffectiveDate},
+		PayeeName:         transaction.PayeeName,
+		Reference:         transaction.Reference,
+		Memo:              transaction.Memo,
+		Amount:            int64(transaction.Amount),
+		Cleared:           transaction.Cleared,
+	}
+}
+
+func toAttachment(attachment *budgit.Attachment) *Attachment {
+	return &Attachment{
+		ID:            attachment.ID,
+		TransactionID: attachment.TransactionID,
+		Name:          attachment.Name,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		ExternalID:    attachment.ExternalID,
+	}
+}
+
+func toScheduledPayment(payment *budgit.ScheduledPayment) *ScheduledPayment {
+	scheduledPayment := &ScheduledPayment{
+		ID:                payment.ID,
+		ExternalAccountID: payment.ExternalAccountID,
+		IntegrationID:     payment.IntegrationID,
+		PayeeName:         payment.PayeeName,
+		Reference:         payment.Reference,
+		Amount:            int64(payment.Amount),
+		Frequency:         payment.Frequency,
+	}
+	if !payment.NextDate.IsZero() {
+		scheduledPayment.NextDate = &openapi_types.Date{Time: payment.NextDate}
+	}
+	return scheduledPayment
+}
+
+func toIntegration(info svc.IntegrationInfo) *Integration {
+	return &Integration{
+		ID: info.ID,
+		Capabilities: mapSlice(info.Capabilities, func(capability svc.Capability) IntegrationCapabilities {
+			return IntegrationCapabilities(capability)
+		}),
+	}
+}
+
+func toQuickAdd(quickAdd *svc.QuickAdd) *QuickAdd {
+	converted := &QuickAdd{
+		Transaction: *ToTransaction(quickAdd.Transaction),
+		Matches: mapSlice(quickAdd.Matches, func(match svc.QuickAddMatch) QuickAddMatch {
+			return QuickAddMatch{Field: QuickAddMatchField(match.Field), Given: match.Given, Matched: match.Matched}
+		}),
+	}
+	if quickAdd.NewPayee != nil {
+		converted.NewPayee = ToPayee(quickAdd.NewPayee)
+	}
+	return converted
+}
+
+func idOrNew(id string) string {
+	if id == "" {
+		return uuid.New().String()
+	}
+	return id
+}
+
+// ToUser converts a User to its API model.
+func ToUser(user *budgit.User) *User {
+	return &User{
+		ID:               user.ID,
+		Username:         user.Username,
+		TOTPEnabled:      user.TOTPEnabled,
+		CreatedTimestamp: user.CreatedTimestamp,
+	}
+}
+
+// ToAPIToken converts an APIToken to its API model.
+func ToAPIToken(token *budgit.APIToken) *APIToken {
+	t := &APIToken{
+		ID:               token.ID,
+		Name:             token.Name,
+		Scopes:           make([]Scope, 0, len(token.Scopes)),
+		CreatedTimestamp: token.CreatedTimestamp,
+	}
+	for _, scope := range token.Scopes {
+		t.Scopes = append(t.Scopes, Scope(scope))
+	}
+	if token.BudgetID != "" {
+		t.BudgetID = &token.BudgetID
+	}
+	if !token.ExpiresTimestamp.IsZero() {
+		t.ExpiresTimestamp = &token.ExpiresTimestamp
+	}
+	if !token.LastUsedTimestamp.IsZero() {
+		t.LastUsedTimestamp = &token.LastUsedTimestamp
+	}
+	return t
+}
+
+func ToBudget(budget *budgit.Budget) *Budget {
+	return &Budget{ID: budget.ID, Name: budget.Name, Role: Role(budget.Role)}
+}
+
+func ToBudgetMember(member *budgit.BudgetMember) *BudgetMember {
+	return &BudgetMember{
+		UserID:           member.UserID,
+		Username:         member.Username,
+		Role:             Role(member.Role),
+		CreatedTimestamp: member.CreatedTimestamp,
+	}
+}
+
+func ToBudgetInvitation(invitation *budgit.BudgetInvitation) *BudgetInvitation {
+	return &BudgetInvitation{
+		ID:               invitation.ID,
+		BudgetID:         invitation.BudgetID,
+		Role:             Role(invitation.Role),
+		CreatedTimestamp: invitation.CreatedTimestamp,
+		ExpiresTimestamp: invitation.ExpiresTimestamp,
+	}
+}
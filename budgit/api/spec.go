@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// GetOpenAPISpec serves the OpenAPI document defining the API, so clients can be generated from it.
+func (s *Server) GetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.spec)
+}
+
+// validateRequest validates requests against the OpenAPI spec, writing a bad request problem if they are not valid.
+// Requests which match no operation are passed on, to be answered as not found by the mux.
+func (s *Server) validateRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := s.router.FindRoute(r)
+		if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err != nil {
+			s.writeError(w, r, badRequestError{err})
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		}
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			s.writeError(w, r, badRequestError{describeValidationError(err)})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// describeValidationError describes the schema errors of invalid requests by where they are and why, rather than by
+// kin-openapi's description, which includes the whole schema and value.
+func describeValidationError(err error) error {
+	var schemaErr *openapi3.SchemaError
+	if !errors.As(err, &schemaErr) {
+		return err
+	}
+	location := "request body"
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) && requestErr.Parameter != nil {
+		location = fmt.Sprintf("%s parameter %s", requestErr.Parameter.In, requestErr.Parameter.Name)
+	}
+	return fmt.Errorf("%s at %q: %s", location, "/"+strings.Join(schemaErr.JSONPointer(), "/"), schemaErr.Reason)
+}
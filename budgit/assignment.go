@@ -0,0 +1,12 @@
+package budgit
+
+import "time"
+
+// Assignment is an amount of money assigned to a Category for a month, unique by Category and month.
+type Assignment struct {
+	ID         string
+	CategoryID string
+	// Month is the first day of the month the amount is assigned for.
+	Month  time.Time
+	Amount BalanceAmount
+}
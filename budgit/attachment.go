@@ -0,0 +1,20 @@
+package budgit
+
+// Attachment is a file, such as a receipt, attached to a Transaction. Its content is kept separately, by ID.
+type Attachment struct {
+	ID            string
+	TransactionID string
+	Name          string
+	ContentType   string
+	Size          int64
+	// ExternalID is the ID of the attachment in the integration it was imported from, if any.
+	ExternalID string
+}
+
+// ExternalAttachment is a file attached to a transaction of an ExternalAccount, as reported by its integration.
+type ExternalAttachment struct {
+	ID                    string
+	ExternalTransactionID string
+	Name                  string
+	ContentType           string
+}
@@ -0,0 +1,93 @@
+// Package attachmentstore stores the content of attachments, by attachment ID, outside of Postgres.
+package attachmentstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore stores the content of each attachment as a file in a directory. It is safe for concurrent use,
+// as content is written to a temporary file before being renamed into place.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore in the given directory, creating it if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating attachment store directory: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+type InvalidIDError struct {
+	ID string
+}
+
+func (e InvalidIDError) Error() string {
+	return fmt.Sprintf("attachment ID %q may only contain letters, digits, '-' and '_'", e.ID)
+}
+
+// Put stores the content of the attachment with the given ID, replacing any already stored, and returns its size.
+func (s FileStore) Put(ctx context.Context, id string, content io.Reader) (int64, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.CreateTemp(s.dir, ".tmp-")
+	if err != nil {
+		return 0, fmt.Errorf("storing attachment %q: %w", id, err)
+	}
+	defer os.Remove(file.Name())
+
+	size, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("storing attachment %q: %w", id, err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return 0, fmt.Errorf("storing attachment %q: %w", id, err)
+	}
+	return size, nil
+}
+
+// Open returns the content of the attachment with the given ID. The error wraps fs.ErrNotExist if none is stored.
+func (s FileStore) Open(ctx context.Context, id string) (io.ReadCloser, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening attachment %q: %w", id, err)
+	}
+	return file, nil
+}
+
+// Delete removes the content of the attachment with the given ID, if any is stored.
+func (s FileStore) Delete(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleting attachment %q: %w", id, err)
+	}
+	return nil
+}
+
+// path returns the path of the file holding the content of an attachment, rejecting IDs which could escape the directory.
+func (s FileStore) path(id string) (string, error) {
+	if id == "" || strings.IndexFunc(id, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+	}) != -1 {
+		return "", InvalidIDError{ID: id}
+	}
+	return filepath.Join(s.dir, id), nil
+}
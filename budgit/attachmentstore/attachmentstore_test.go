@@ -0,0 +1,77 @@
+package attachmentstore_test
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/andrewthowell/budgit/budgit/attachmentstore"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAttachmentStore(t *testing.T) {
+	suite.Run(t, new(attachmentStoreSuite))
+}
+
+type attachmentStoreSuite struct {
+	suite.Suite
+	store *attachmentstore.FileStore
+}
+
+func (s *attachmentStoreSuite) SetupTest() {
+	store, err := attachmentstore.NewFileStore(s.T().TempDir())
+	s.Require().NoError(err)
+	s.store = store
+}
+
+func (s *attachmentStoreSuite) TestPutOpenDelete() {
+	size, err := s.store.Put(context.Background(), "attachment-1", strings.NewReader("receipt"))
+	s.Require().NoError(err)
+	s.Equal(int64(7), size)
+
+	content, err := s.store.Open(context.Background(), "attachment-1")
+	s.Require().NoError(err)
+	defer content.Close()
+	read, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	s.Equal("receipt", string(read))
+
+	s.Require().NoError(s.store.Delete(context.Background(), "attachment-1"))
+	_, err = s.store.Open(context.Background(), "attachment-1")
+	s.ErrorIs(err, fs.ErrNotExist)
+	s.NoError(s.store.Delete(context.Background(), "attachment-1"), "expected deleting a missing attachment to succeed")
+}
+
+func (s *attachmentStoreSuite) TestPutReplaces() {
+	_, err := s.store.Put(context.Background(), "attachment-1", strings.NewReader("old"))
+	s.Require().NoError(err)
+	_, err = s.store.Put(context.Background(), "attachment-1", strings.NewReader("new"))
+	s.Require().NoError(err)
+
+	content, err := s.store.Open(context.Background(), "attachment-1")
+	s.Require().NoError(err)
+	defer content.Close()
+	read, err := io.ReadAll(content)
+	s.Require().NoError(err)
+	s.Equal("new", string(read))
+}
+
+func (s *attachmentStoreSuite) TestInvalidID() {
+	for _, id := range []string{"", "../escape", "a/b", "."} {
+		_, err := s.store.Put(context.Background(), id, strings.NewReader("content"))
+		s.ErrorIs(err, attachmentstore.InvalidIDError{ID: id})
+	}
+}
+
+func (s *attachmentStoreSuite) TestNewFileStoreCreatesDirectory() {
+	dir := s.T().TempDir() + "/nested/attachments"
+	_, err := attachmentstore.NewFileStore(dir)
+	s.Require().NoError(err)
+
+	info, err := os.Stat(dir)
+	s.Require().NoError(err)
+	s.True(info.IsDir())
+}
@@ -0,0 +1,97 @@
+// Package auth holds the primitives budgit authenticates users with: argon2id password hashes, random tokens stored
+// only as hashes, and TOTP second factors (RFC 6238) as generated by authenticator apps.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// PasswordParams are the argon2id parameters passwords are hashed with. They are written into each hash, so that they
+// can be raised without invalidating existing hashes.
+type PasswordParams struct {
+	// Memory is the memory used, in KiB.
+	Memory  uint32
+	Time    uint32
+	Threads uint8
+	SaltLen uint32
+	KeyLen  uint32
+}
+
+// DefaultPasswordParams are the second recommended parameters of RFC 9106, for when 2 GiB of memory per hash is too
+// much.
+var DefaultPasswordParams = PasswordParams{Memory: 64 * 1024, Time: 3, Threads: 4, SaltLen: 16, KeyLen: 32}
+
+var (
+	ErrPasswordMismatch    = errors.New("the password does not match")
+	ErrInvalidPasswordHash = errors.New("the password hash is not a valid argon2id hash")
+)
+
+// HashPassword hashes a password with argon2id and a random salt, returning the hash in the PHC string format, such as
+// "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>".
+func HashPassword(password string, params PasswordParams) (string, error) {
+	salt := make([]byte, params.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("hashing password: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, params.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Time, params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword returns ErrPasswordMismatch if a password does not match a hash returned by HashPassword.
+func VerifyPassword(password, hash string) error {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return ErrInvalidPasswordHash
+	}
+	var params PasswordParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return ErrInvalidPasswordHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return ErrInvalidPasswordHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return ErrInvalidPasswordHash
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NewToken returns a random token with a prefix, such as "budgit_s_" for sessions, identifying what it is to those
+// who come across it.
+func NewToken(prefix string) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generating token: %w", err)
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// HashToken returns the hash a token is stored as. Tokens are random, so are hashed quickly with SHA-256 rather than
+// with a password hash.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,113 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/auth"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAuth(t *testing.T) {
+	suite.Run(t, new(authSuite))
+}
+
+type authSuite struct {
+	suite.Suite
+}
+
+// testParams keep hashing quick in tests.
+var testParams = auth.PasswordParams{Memory: 1024, Time: 1, Threads: 1, SaltLen: 16, KeyLen: 32}
+
+func (s *authSuite) TestPassword() {
+	hash, err := auth.HashPassword("correct horse battery staple", testParams)
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), hash)
+
+	s.NoError(auth.VerifyPassword("correct horse battery staple", hash))
+	s.ErrorIs(auth.VerifyPassword("Correct horse battery staple", hash), auth.ErrPasswordMismatch)
+
+	other, err := auth.HashPassword("correct horse battery staple", testParams)
+	s.Require().NoError(err)
+	s.NotEqual(hash, other, "expected hashes of the same password to differ by salt")
+}
+
+func (s *authSuite) TestVerifyPasswordInvalidHash() {
+	for _, hash := range []string{
+		"",
+		"correct horse battery staple",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$!$a2V5",
+	} {
+		s.ErrorIs(auth.VerifyPassword("password", hash), auth.ErrInvalidPasswordHash, hash)
+	}
+}
+
+func (s *authSuite) TestToken() {
+	token, err := auth.NewToken("budgit_s_")
+	s.Require().NoError(err)
+	s.True(strings.HasPrefix(token, "budgit_s_"))
+	s.Len(token, len("budgit_s_")+43)
+
+	other, err := auth.NewToken("budgit_s_")
+	s.Require().NoError(err)
+	s.NotEqual(token, other)
+	s.Equal(auth.HashToken(token), auth.HashToken(token))
+	s.NotEqual(auth.HashToken(token), auth.HashToken(other))
+}
+
+// rfc6238Secret is the SHA-1 secret of the test vectors of RFC 6238, "12345678901234567890", base32 encoded.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func (s *authSuite) TestTOTPCode() {
+	testCases := []struct {
+		at       int64
+		expected string
+	}{
+		// The last 6 digits of the 8 digit codes of RFC 6238.
+		{at: 59, expected: "287082"},
+		{at: 1111111109, expected: "081804"},
+		{at: 1111111111, expected: "050471"},
+		{at: 1234567890, expected: "005924"},
+		{at: 2000000000, expected: "279037"},
+	}
+	for _, tc := range testCases {
+		code, err := auth.TOTPCode(rfc6238Secret, time.Unix(tc.at, 0))
+		s.NoError(err)
+		s.Equal(tc.expected, code, "at %d", tc.at)
+	}
+}
+
+func (s *authSuite) TestValidateTOTP() {
+	at := time.Unix(1111111109, 0)
+	s.True(auth.ValidateTOTP(rfc6238Secret, "081804", at))
+	s.True(auth.ValidateTOTP(rfc6238Secret, "081804", at.Add(30*time.Second)), "expected the previous code to be accepted")
+	s.False(auth.ValidateTOTP(rfc6238Secret, "081804", at.Add(90*time.Second)))
+	s.False(auth.ValidateTOTP(rfc6238Secret, "081805", at))
+	s.False(auth.ValidateTOTP(rfc6238Secret, "", at))
+	s.False(auth.ValidateTOTP("not base32!", "081804", at))
+}
+
+func (s *authSuite) TestNewTOTPSecret() {
+	secret, err := auth.NewTOTPSecret()
+	s.Require().NoError(err)
+	s.Len(secret, 32)
+	code, err := auth.TOTPCode(secret, time.Now())
+	s.Require().NoError(err)
+	s.True(auth.ValidateTOTP(secret, code, time.Now()))
+
+	s.Equal(
+		"otpauth://totp/Budg-it:alex%20smith?issuer=Budg-it&secret="+secret,
+		auth.TOTPURI("Budg-it", "alex smith", secret),
+	)
+}
+
+func (s *authSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
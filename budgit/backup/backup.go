@@ -0,0 +1,297 @@
+// Package backup reads and writes budget archives, which hold every version of every row of a budget, so that a budget
+// can be moved between databases.
+//
+// An archive is newline delimited JSON, written as UTF-8 with one object per line. Every object has a "type", which is
+// one of "header", "row" or "trailer".
+//
+// The first line is the header, which names the format, its version, the schema version of the rows, which is the
+// number of the latest database migration applied when the archive was written, and when it was written:
+//
+//	{"type":"header","format":"budgit-backup","format_version":1,"schema_version":6,"created_at":"2024-06-01T12:00:00Z"}
+//
+// Each following line until the trailer is a row of a table, holding the row's columns by name:
+//
+//	{"type":"row","table":"payees","row":{"request_id":"…","valid_from_timestamp":"2024-06-01T12:00:00Z","valid_to_timestamp":"infinity","id":"…","name":"Tesco"}}
+//
+// Timestamps are RFC 3339, or "infinity" for the valid to timestamp of current versions, dates are "YYYY-MM-DD",
+// amounts are integers of minor units, and NULL columns are null. Rows of a table are written in the order they became
+// valid. The content of attachments is written in rows of the "attachment_contents" table, whose columns are the
+// Attachment's "id" and its base64 "content".
+//
+// The last line is the trailer, which holds the number of rows and the hex SHA-256 checksum of every byte before the
+// trailer, including the header and the newline ending each line:
+//
+//	{"type":"trailer","rows":42,"sha256":"…"}
+//
+// An archive without a trailer, such as one whose writing was interrupted, is invalid.
+//
+// The format version is increased with any change to the layout of lines. Changes to the schema of rows only increase
+// the schema version. Since migrations only add optional columns, an archive can be read into a database of the same
+// or a later schema version, with the columns it lacks left NULL.
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// Format is the name of the format of archives.
+	Format = "budgit-backup"
+	// FormatVersion is the version of the layout of archives written by this package.
+	FormatVersion = 1
+
+	lineTypeHeader  = "header"
+	lineTypeRow     = "row"
+	lineTypeTrailer = "trailer"
+
+	dbStructKey = "db"
+)
+
+var (
+	ErrArchiveInvalid     = fmt.Errorf("the archive is not a valid budget archive")
+	ErrArchiveUnsupported = fmt.Errorf("the archive format version is not supported")
+	ErrChecksumMismatch   = fmt.Errorf("the archive checksum does not match its content")
+)
+
+// Header is the first line of an archive.
+type Header struct {
+	Format        string    `json:"format"`
+	FormatVersion int       `json:"format_version"`
+	SchemaVersion int       `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Row is a row of a table, read from an archive. Its columns are decoded into a struct by Decode.
+type Row struct {
+	Table   string
+	Columns json.RawMessage
+}
+
+type line struct {
+	Type string `json:"type"`
+
+	*Header
+
+	Table string          `json:"table,omitempty"`
+	Row   json.RawMessage `json:"row,omitempty"`
+
+	Rows   int    `json:"rows,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// Writer writes an archive. Close must be called to write the trailer, without which the archive is invalid.
+type Writer struct {
+	w    *bufio.Writer
+	hash hash.Hash
+	rows int
+}
+
+// NewWriter writes the header of an archive of rows of the given schema version, and returns a Writer of its rows.
+func NewWriter(w io.Writer, schemaVersion int, createdAt time.Time) (*Writer, error) {
+	writer := &Writer{w: bufio.NewWriter(w), hash: sha256.New()}
+	header := &Header{Format: Format, FormatVersion: FormatVersion, SchemaVersion: schemaVersion, CreatedAt: createdAt.UTC()}
+	if err := writer.writeLine(line{Type: lineTypeHeader, Header: header}, true); err != nil {
+		return nil, fmt.Errorf("writing archive header: %w", err)
+	}
+	return writer, nil
+}
+
+// Write writes a row of a table. The row must be a struct, or a pointer to one, whose fields are tagged with the names
+// of their columns, as the rows of package db are.
+func (w *Writer) Write(table string, row any) error {
+	columns, err := encodeColumns(row)
+	if err != nil {
+		return fmt.Errorf("writing %s row: %w", table, err)
+	}
+	if err := w.writeLine(line{Type: lineTypeRow, Table: table, Row: columns}, true); err != nil {
+		return fmt.Errorf("writing %s row: %w", table, err)
+	}
+	w.rows++
+	return nil
+}
+
+// Close writes the trailer of the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	trailer := line{Type: lineTypeTrailer, Rows: w.rows, SHA256: hex.EncodeToString(w.hash.Sum(nil))}
+	if err := w.writeLine(trailer, false); err != nil {
+		return fmt.Errorf("writing archive trailer: %w", err)
+	}
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("writing archive trailer: %w", err)
+	}
+	return nil
+}
+
+func (w *Writer) writeLine(l line, checksummed bool) error {
+	encoded, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	encoded = append(encoded, '\n')
+	if checksummed {
+		w.hash.Write(encoded)
+	}
+	_, err = w.w.Write(encoded)
+	return err
+}
+
+// encodeColumns encodes the fields of a row as a JSON object of its columns, in the order of the fields.
+func encodeColumns(row any) (json.RawMessage, error) {
+	value := reflect.Indirect(reflect.ValueOf(row))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a struct, got %T", row)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := range value.NumField() {
+		column := value.Type().Field(i).Tag.Get(dbStructKey)
+		if column == "" {
+			continue
+		}
+		encodedValue, err := json.Marshal(value.Field(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("encoding column %q: %w", column, err)
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		encodedColumn, _ := json.Marshal(column)
+		buf.Write(encodedColumn)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Reader reads an archive, verifying its checksum once every row has been read.
+type Reader struct {
+	r      *bufio.Reader
+	hash   hash.Hash
+	header Header
+	rows   int
+	done   bool
+}
+
+// NewReader reads the header of an archive, and returns a Reader of its rows.
+// It returns ErrArchiveUnsupported if the archive was written in a later version of the format.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{r: bufio.NewReader(r), hash: sha256.New()}
+	l, err := reader.readLine()
+	if err != nil {
+		return nil, fmt.Errorf("reading archive header: %w", err)
+	}
+	if l.Type != lineTypeHeader || l.Header == nil || l.Header.Format != Format {
+		return nil, fmt.Errorf("reading archive header: %w", ErrArchiveInvalid)
+	}
+	if l.Header.FormatVersion < 1 || l.Header.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("reading archive header: format version %d: %w", l.Header.FormatVersion, ErrArchiveUnsupported)
+	}
+	reader.header = *l.Header
+	return reader, nil
+}
+
+// Header returns the header of the archive.
+func (r *Reader) Header() Header {
+	return r.header
+}
+
+// Next returns the next row of the archive. Once every row has been read, it verifies the trailer of the archive, and
+// returns io.EOF if it matches, or ErrChecksumMismatch if it does not.
+func (r *Reader) Next() (*Row, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	checksum := hex.EncodeToString(r.hash.Sum(nil))
+	l, err := r.readLine()
+	if err != nil {
+		return nil, fmt.Errorf("reading archive row %d: %w", r.rows+1, err)
+	}
+
+	switch l.Type {
+	case lineTypeRow:
+		if l.Table == "" || len(l.Row) == 0 {
+			return nil, fmt.Errorf("reading archive row %d: missing table or row: %w", r.rows+1, ErrArchiveInvalid)
+		}
+		r.rows++
+		return &Row{Table: l.Table, Columns: l.Row}, nil
+	case lineTypeTrailer:
+		if l.SHA256 != checksum || l.Rows != r.rows {
+			return nil, fmt.Errorf("reading archive trailer: %w", ErrChecksumMismatch)
+		}
+		if _, err := r.r.ReadByte(); !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading archive trailer: content after trailer: %w", ErrArchiveInvalid)
+		}
+		r.done = true
+		return nil, io.EOF
+	default:
+		return nil, fmt.Errorf("reading archive row %d: unexpected line type %q: %w", r.rows+1, l.Type, ErrArchiveInvalid)
+	}
+}
+
+func (r *Reader) readLine() (*line, error) {
+	encoded, err := r.r.ReadBytes('\n')
+	if errors.Is(err, io.EOF) {
+		if len(bytes.TrimSpace(encoded)) == 0 {
+			return nil, fmt.Errorf("archive ends before its trailer: %w", ErrArchiveInvalid)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	r.hash.Write(encoded)
+
+	l := &line{}
+	if err := json.Unmarshal(encoded, l); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrArchiveInvalid, err)
+	}
+	return l, nil
+}
+
+// Decode decodes the columns of a row into a struct, which must be a pointer to a struct whose fields are tagged with
+// the names of their columns. Columns missing from the row are left unset. It returns ErrArchiveInvalid if the row has
+// a column the struct does not.
+func (row *Row) Decode(dst any) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decoding %s row: expected a pointer to a struct, got %T", row.Table, dst)
+	}
+
+	columns := map[string]json.RawMessage{}
+	if err := json.Unmarshal(row.Columns, &columns); err != nil {
+		return fmt.Errorf("decoding %s row: %w: %w", row.Table, ErrArchiveInvalid, err)
+	}
+	value = value.Elem()
+	for i := range value.NumField() {
+		column := value.Type().Field(i).Tag.Get(dbStructKey)
+		encodedValue, ok := columns[column]
+		if column == "" || !ok {
+			continue
+		}
+		delete(columns, column)
+		if err := json.Unmarshal(encodedValue, value.Field(i).Addr().Interface()); err != nil {
+			return fmt.Errorf("decoding %s row column %q: %w: %w", row.Table, column, ErrArchiveInvalid, err)
+		}
+	}
+	if len(columns) != 0 {
+		unknown := make([]string, 0, len(columns))
+		for column := range columns {
+			unknown = append(unknown, column)
+		}
+		slices.Sort(unknown)
+		return fmt.Errorf("decoding %s row: unknown columns %s: %w", row.Table, strings.Join(unknown, ", "), ErrArchiveInvalid)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package backup_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/backup"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestBackup(t *testing.T) {
+	suite.Run(t, new(backupSuite))
+}
+
+type backupSuite struct {
+	suite.Suite
+}
+
+type testRow struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                 pgtype.Text        `db:"id"`
+	Month              pgtype.Date        `db:"month"`
+	Amount             pgtype.Int8        `db:"amount"`
+	Hidden             pgtype.Bool        `db:"hidden"`
+}
+
+func testRows() []*testRow {
+	return []*testRow{
+		{
+			RequestID:          pgtype.Text{String: "request_id-1", Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			ID:                 pgtype.Text{String: "id-1", Valid: true},
+			Month:              pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			Amount:             pgtype.Int8{Int64: -1234, Valid: true},
+			Hidden:             pgtype.Bool{Bool: true, Valid: true},
+		},
+		{
+			RequestID:          pgtype.Text{String: "request_id-2", Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: "id-1", Valid: true},
+			Month:              pgtype.Date{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			Amount:             pgtype.Int8{Int64: 5678, Valid: true},
+		},
+	}
+}
+
+func (s *backupSuite) writeArchive(rows ...*testRow) string {
+	var buf bytes.Buffer
+	writer, err := backup.NewWriter(&buf, 6, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	for _, row := range rows {
+		s.Require().NoError(writer.Write("test_rows", row))
+	}
+	s.Require().NoError(writer.Close())
+	return buf.String()
+}
+
+func (s *backupSuite) readArchive(archive string) (backup.Header, []*testRow, error) {
+	reader, err := backup.NewReader(strings.NewReader(archive))
+	if err != nil {
+		return backup.Header{}, nil, err
+	}
+	rows := []*testRow{}
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return reader.Header(), rows, nil
+		}
+		if err != nil {
+			return backup.Header{}, nil, err
+		}
+		s.Require().Equal("test_rows", row.Table)
+		decoded := &testRow{}
+		if err := row.Decode(decoded); err != nil {
+			return backup.Header{}, nil, err
+		}
+		rows = append(rows, decoded)
+	}
+}
+
+func (s *backupSuite) TestWrite() {
+	archive := s.writeArchive(testRows()[1])
+
+	lines := strings.Split(strings.TrimSuffix(archive, "\n"), "\n")
+	s.Require().Len(lines, 3)
+	s.Equal(`{"type":"header","format":"budgit-backup","format_version":1,"schema_version":6,"created_at":"2024-06-01T12:00:00Z"}`, lines[0])
+	s.Equal(`{"type":"row","table":"test_rows","row":{"request_id":"request_id-2","valid_from_timestamp":"1970-01-01T00:00:02Z","valid_to_timestamp":"infinity","id":"id-1","month":"2024-06-01","amount":5678,"hidden":null}}`, lines[1])
+	s.Regexp(`^\{"type":"trailer","rows":1,"sha256":"[0-9a-f]{64}"\}$`, lines[2])
+}
+
+func (s *backupSuite) TestRoundTrip() {
+	header, rows, err := s.readArchive(s.writeArchive(testRows()...))
+	s.Require().NoError(err)
+	s.CMPEqual(backup.Header{
+		Format:        backup.Format,
+		FormatVersion: backup.FormatVersion,
+		SchemaVersion: 6,
+		CreatedAt:     time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+	}, header)
+	s.CMPEqual(testRows(), rows)
+}
+
+func (s *backupSuite) TestRead() {
+	s.Run("MissingColumns", func() {
+		archive := `{"type":"header","format":"budgit-backup","format_version":1,"schema_version":5,"created_at":"2024-06-01T12:00:00Z"}
+{"type":"row","table":"test_rows","row":{"id":"id-1"}}
+`
+		archive += trailer(archive, 1)
+		_, rows, err := s.readArchive(archive)
+		s.Require().NoError(err)
+		s.CMPEqual([]*testRow{{ID: pgtype.Text{String: "id-1", Valid: true}}}, rows)
+	})
+	s.Run("NotAnArchive", func() {
+		_, _, err := s.readArchive("Date,Amount\n")
+		s.ErrorIs(err, backup.ErrArchiveInvalid)
+	})
+	s.Run("LaterFormatVersion", func() {
+		_, _, err := s.readArchive(`{"type":"header","format":"budgit-backup","format_version":2,"schema_version":6}` + "\n")
+		s.ErrorIs(err, backup.ErrArchiveUnsupported)
+	})
+	s.Run("Truncated", func() {
+		archive := s.writeArchive(testRows()...)
+		archive = archive[:strings.LastIndex(strings.TrimSuffix(archive, "\n"), "\n")+1]
+		_, _, err := s.readArchive(archive)
+		s.ErrorIs(err, backup.ErrArchiveInvalid)
+	})
+	s.Run("Modified", func() {
+		archive := strings.Replace(s.writeArchive(testRows()...), "-1234", "-1235", 1)
+		_, _, err := s.readArchive(archive)
+		s.ErrorIs(err, backup.ErrChecksumMismatch)
+	})
+	s.Run("RowRemoved", func() {
+		lines := strings.SplitAfter(s.writeArchive(testRows()...), "\n")
+		archive := lines[0] + lines[1] + trailer(lines[0]+lines[1], 2)
+		_, _, err := s.readArchive(archive)
+		s.ErrorIs(err, backup.ErrChecksumMismatch)
+	})
+	s.Run("UnknownColumn", func() {
+		archive := `{"type":"header","format":"budgit-backup","format_version":1,"schema_version":7,"created_at":"2024-06-01T12:00:00Z"}
+{"type":"row","table":"test_rows","row":{"id":"id-1","colour":"red"}}
+`
+		archive += trailer(archive, 1)
+		_, _, err := s.readArchive(archive)
+		s.ErrorIs(err, backup.ErrArchiveInvalid)
+	})
+}
+
+// trailer returns the trailer of an archive of the given content and number of rows.
+func trailer(content string, rows int) string {
+	return fmt.Sprintf(`{"type":"trailer","rows":%d,"sha256":"%x"}`+"\n", rows, sha256.Sum256([]byte(content)))
+}
+
+func (s *backupSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
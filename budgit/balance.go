@@ -1,6 +1,10 @@
 package budgit
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Balance is a Balance, holding BalanceAmounts of the cleared and effective balances.
 type Balance struct {
@@ -26,6 +30,53 @@ func (b Balance) AddAmount(amount BalanceAmount, cleared bool) Balance {
 // e.g. £10 is stored as 100.
 type BalanceAmount int64
 
+// String formats the amount as a decimal with two decimal places, e.g. "-12.05".
 func (b BalanceAmount) String() string {
-	return fmt.Sprintf("%d.%d", b/100, b%100)
+	sign := ""
+	if b < 0 {
+		sign, b = "-", -b
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, b/100, b%100)
+}
+
+// ErrInvalidBalanceAmount is returned when parsing a BalanceAmount from a string that is not a decimal amount.
+var ErrInvalidBalanceAmount = fmt.Errorf("given amount is not a valid decimal amount")
+
+// ParseBalanceAmount parses a decimal amount, such as "-12.3" or "1000.00", into a BalanceAmount.
+// Decimals beyond the second must be zero, so that no amount is silently rounded.
+func ParseBalanceAmount(str string) (BalanceAmount, error) {
+	sign, digits := int64(1), strings.TrimSpace(str)
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		sign, digits = -1, digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if len(fraction) > 2 {
+		if strings.Trim(fraction[2:], "0") != "" {
+			return 0, fmt.Errorf("parsing amount %q: %w", str, ErrInvalidBalanceAmount)
+		}
+		fraction = fraction[:2]
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("parsing amount %q: %w", str, ErrInvalidBalanceAmount)
+	}
+
+	minorUnits, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing amount %q: %w", str, ErrInvalidBalanceAmount)
+	}
+	return BalanceAmount(sign * minorUnits), nil
+}
+
+func isDigits(str string) bool {
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,62 @@
+package budgit_test
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+func (s *budgitSuite) TestParseBalanceAmount() {
+	testCases := []struct {
+		name     string
+		str      string
+		expected budgit.BalanceAmount
+		valid    bool
+	}{
+		{name: "Whole", str: "10", expected: 1000, valid: true},
+		{name: "TwoDecimals", str: "123.45", expected: 12345, valid: true},
+		{name: "OneDecimal", str: "0.5", expected: 50, valid: true},
+		{name: "Negative", str: "-12.30", expected: -1230, valid: true},
+		{name: "Positive", str: "+1.00", expected: 100, valid: true},
+		{name: "TrailingZeroDecimals", str: "1.23000", expected: 123, valid: true},
+		{name: "Spaces", str: " 2.50 ", expected: 250, valid: true},
+		{name: "SubPenny", str: "1.234"},
+		{name: "Empty", str: ""},
+		{name: "NoWhole", str: ".50"},
+		{name: "Letters", str: "12a.00"},
+		{name: "Thousands", str: "1,000.00"},
+		{name: "DoubleSign", str: "--1"},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			amount, err := budgit.ParseBalanceAmount(tc.str)
+			if !tc.valid {
+				s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tc.expected, amount)
+		})
+	}
+}
+
+func (s *budgitSuite) TestBalanceAmountString() {
+	testCases := []struct {
+		amount   budgit.BalanceAmount
+		expected string
+	}{
+		{amount: 0, expected: "0.00"},
+		{amount: 5, expected: "0.05"},
+		{amount: 105, expected: "1.05"},
+		{amount: 12340, expected: "123.40"},
+		{amount: -5, expected: "-0.05"},
+		{amount: -1205, expected: "-12.05"},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.expected, func() {
+			s.Equal(tc.expected, tc.amount.String())
+
+			parsed, err := budgit.ParseBalanceAmount(tc.amount.String())
+			s.Require().NoError(err)
+			s.Equal(tc.amount, parsed)
+		})
+	}
+}
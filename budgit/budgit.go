@@ -16,6 +16,8 @@ type Budget struct {
 	ID       string
 	Name     string
 	Currency string
+	// Role is the role in the Budget of the User it was listed for.
+	Role Role
 }
 
 // NewBudget returns a Budget.
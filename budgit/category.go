@@ -0,0 +1,51 @@
+package budgit
+
+import (
+	"strings"
+	"time"
+)
+
+// CategoryGroup is a named group of Categories, e.g. "Bills".
+type CategoryGroup struct {
+	ID   string
+	Name string
+}
+
+// Category is a Category which Transactions are budgeted against, unique by name within its CategoryGroup.
+type Category struct {
+	ID      string
+	GroupID string
+	Name    string
+}
+
+// SplitCategoryPath splits a category path such as "Bills:Energy", as used by QIF files, into the names of a
+// CategoryGroup and a Category. Nested paths keep their remainder as the Category's name, and a path with a single
+// segment names both the CategoryGroup and the Category.
+func SplitCategoryPath(path string) (groupName, categoryName string) {
+	groupName, categoryName, found := strings.Cut(path, ":")
+	if !found {
+		return path, path
+	}
+	return groupName, categoryName
+}
+
+// CategoryPath joins the names of a CategoryGroup and a Category into a category path, the inverse of SplitCategoryPath.
+func CategoryPath(groupName, categoryName string) string {
+	if groupName == categoryName {
+		return categoryName
+	}
+	return groupName + ":" + categoryName
+}
+
+// CategoryActivity returns the activity of each Category in a month, the sum of the amounts of its Transactions dated
+// within the month, by Category ID. Transactions without a Category are ignored.
+func CategoryActivity(month time.Time, transactions ...*Transaction) map[string]BalanceAmount {
+	activity := map[string]BalanceAmount{}
+	for _, transaction := range transactions {
+		date := transaction.EffectiveDate
+		if transaction.CategoryID != "" && date.Year() == month.Year() && date.Month() == month.Month() {
+			activity[transaction.CategoryID] += transaction.Amount
+		}
+	}
+	return activity
+}
@@ -0,0 +1,37 @@
+package budgit_test
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+func (s *budgitSuite) TestCategoryPath() {
+	testCases := []struct {
+		path, groupName, categoryName string
+	}{
+		{path: "Bills:Energy", groupName: "Bills", categoryName: "Energy"},
+		{path: "Groceries", groupName: "Groceries", categoryName: "Groceries"},
+		{path: "Car:Fuel:Diesel", groupName: "Car", categoryName: "Fuel:Diesel"},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.path, func() {
+			groupName, categoryName := budgit.SplitCategoryPath(tc.path)
+			s.Equal(tc.groupName, groupName)
+			s.Equal(tc.categoryName, categoryName)
+			s.Equal(tc.path, budgit.CategoryPath(groupName, categoryName))
+		})
+	}
+}
+
+func (s *budgitSuite) TestCategoryActivity() {
+	june := func(day int) time.Time { return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC) }
+	activity := budgit.CategoryActivity(june(15),
+		&budgit.Transaction{EffectiveDate: june(1), CategoryID: "groceries", Amount: -5000},
+		&budgit.Transaction{EffectiveDate: june(30), CategoryID: "groceries", Amount: 1000},
+		&budgit.Transaction{EffectiveDate: june(3), CategoryID: "energy", Amount: -4500},
+		&budgit.Transaction{EffectiveDate: june(3), Amount: 200000},
+		&budgit.Transaction{EffectiveDate: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), CategoryID: "groceries", Amount: -300},
+	)
+	s.Equal(map[string]budgit.BalanceAmount{"groceries": -4000, "energy": -4500}, activity)
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func (a *App) accountsCommand() *cobra.Command {
+	return groupCommand("accounts", "Manage Accounts",
+		a.accountsListCommand(),
+		a.accountsCreateCommand(),
+		a.accountsLinkCommand(),
+		a.accountsSyncCommand(),
+	)
+}
+
+func (a *App) accountsListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Accounts",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			accounts, err := service.ListAccounts(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, accountsOutput(accounts))
+		},
+	}
+}
+
+func (a *App) accountsCreateCommand() *cobra.Command {
+	var balance string
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an Account",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			amount, err := parseAmount(balance)
+			if err != nil {
+				return err
+			}
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			accounts, err := service.CreateAccounts(cmd.Context(), &budgit.Account{
+				ID:      uuid.New().String(),
+				Name:    args[0],
+				Balance: budgit.Balance{ClearedBalance: amount, EffectiveBalance: amount},
+			})
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, accountsOutput(accounts))
+		},
+	}
+	cmd.Flags().StringVar(&balance, "balance", "0", "opening balance, e.g. 1250.00, which is cleared")
+	return cmd
+}
+
+func (a *App) accountsLinkCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "link ACCOUNT_ID INTEGRATION_ID EXTERNAL_ACCOUNT_ID",
+		Short: "Link an Account to an account of an Integration, so that it can be synced",
+		Args:  usageArgs(cobra.ExactArgs(3)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			account, err := service.LinkAccount(cmd.Context(), args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, accountsOutput([]*budgit.Account{account}))
+		},
+	}
+}
+
+func (a *App) accountsSyncCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync ACCOUNT_ID...",
+		Short: "Sync Accounts with their linked external accounts",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			for _, accountID := range args {
+				if err := service.SyncAccount(cmd.Context(), accountID); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
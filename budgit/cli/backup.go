@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+func (a *App) backupCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup FILE",
+		Short: "Back up the Budget given by --budget to a file",
+		Long: `Back up the Budget given by --budget to a file, holding every version of its Accounts, Payees, Categories,
+Transactions and budget, and the content of its Attachments. Restore it with "restore".`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			file, err := os.OpenFile(args[0], os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+			if err != nil {
+				return fmt.Errorf("creating backup file: %w", err)
+			}
+			if err := service.Backup(cmd.Context(), file); err != nil {
+				file.Close()
+				os.Remove(args[0])
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("writing backup file: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+func (a *App) restoreCommand() *cobra.Command {
+	var create string
+	cmd := &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore a backup into an empty Budget",
+		Long: `Restore a backup written by "backup" into the empty Budget given by --budget, or into a new Budget named by
+--create. Every row restored is given a new ID, so a backup may be restored alongside the Budget it was written from.`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("opening backup file: %w", err)
+			}
+			defer file.Close()
+
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			budgetID, _ := svc.BudgetFrom(cmd.Context())
+			if create != "" {
+				budget, err := service.CreateBudget(cmd.Context(), create)
+				if err != nil {
+					return err
+				}
+				budgetID = budget.ID
+			}
+			restored, err := service.Restore(cmd.Context(), file, budgetID)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, restoredOutput(budgetID, restored))
+		},
+	}
+	cmd.Flags().StringVar(&create, "create", "", "name of a new Budget to restore into, instead of the Budget given by --budget")
+	return cmd
+}
+
+func restoredOutput(budgetID string, restored map[string]int) output {
+	tables := maps.Keys(restored)
+	slices.Sort(tables)
+	out := output{
+		header: []string{"BUDGET", "TABLE", "ROWS"},
+		rows:   make([][]string, 0, len(tables)),
+		json:   map[string]any{"budget_id": budgetID, "rows": restored},
+	}
+	for _, table := range tables {
+		out.rows = append(out.rows, []string{budgetID, table, strconv.Itoa(restored[table])})
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/spf13/cobra"
+)
+
+const budgetFlag = "budget"
+
+// flagBudgetID returns the ID of the Budget commands run on, given by --budget or $BUDGIT_BUDGET, or the default Budget.
+func flagBudgetID(cmd *cobra.Command) (string, error) {
+	budgetID, err := cmd.Flags().GetString(budgetFlag)
+	if err != nil {
+		return "", err
+	}
+	if budgetID == "" {
+		budgetID = os.Getenv("BUDGIT_BUDGET")
+	}
+	if budgetID == "" {
+		budgetID = svc.DefaultBudgetID
+	}
+	return budgetID, nil
+}
+
+func (a *App) budgetsCommand() *cobra.Command {
+	return groupCommand("budgets", "Manage Budgets, their members and invitations to them",
+		a.budgetsListCommand(),
+		a.budgetsCreateCommand(),
+		groupCommand("members", "Manage the members of the Budget given by --budget",
+			a.budgetsMembersListCommand(),
+			a.budgetsMembersSetCommand(),
+			a.budgetsMembersRemoveCommand(),
+		),
+		a.budgetsInviteCommand(),
+		a.budgetsRevokeInvitationCommand(),
+	)
+}
+
+func (a *App) budgetsListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Budgets",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			budgets, err := service.ListBudgets(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, budgetsOutput(budgets))
+		},
+	}
+}
+
+func (a *App) budgetsCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an empty Budget",
+		Long: `Create an empty Budget, with no members.
+
+Make Users members of it with "budgets members set --budget ID", or invite them with "budgets invite --budget ID".`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			budget, err := service.CreateBudget(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, budgetsOutput([]*budgit.Budget{budget}))
+		},
+	}
+}
+
+func (a *App) budgetsMembersListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the members of a Budget",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			members, err := service.ListMembers(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, budgetMembersOutput(members))
+		},
+	}
+}
+
+func (a *App) budgetsMembersSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set USERNAME ROLE",
+		Short: "Make a User a member of a Budget with a role, owner, editor or viewer, or change their role",
+		Args:  usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.SetMember(cmd.Context(), args[0], budgit.Role(args[1]))
+		},
+	}
+}
+
+func (a *App) budgetsMembersRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove USERNAME",
+		Short: "Remove a User from the members of a Budget",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.RemoveMember(cmd.Context(), args[0])
+		},
+	}
+}
+
+func (a *App) budgetsInviteCommand() *cobra.Command {
+	var role string
+	cmd := &cobra.Command{
+		Use:   "invite",
+		Short: "Create an invitation to a Budget",
+		Long: `Create an invitation to the Budget given by --budget, which makes whichever User accepts it a member with the
+role given by --role. It can be accepted once, within a week, from the budgets page of the web UI or the API.
+
+The token is shown once, as only its hash is kept.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			invitation, token, err := service.CreateInvitation(cmd.Context(), budgit.Role(role))
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, newBudgetInvitationOutput(invitation, token))
+		},
+	}
+	cmd.Flags().StringVar(&role, "role", string(budgit.RoleViewer), "role of the User accepting the invitation, owner, editor or viewer")
+	return cmd
+}
+
+func (a *App) budgetsRevokeInvitationCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke-invitation INVITATION_ID",
+		Short: "Revoke an invitation to a Budget which has not been accepted",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.DeleteInvitation(cmd.Context(), args[0])
+		},
+	}
+}
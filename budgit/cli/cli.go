@@ -0,0 +1,359 @@
+// Package cli is the budgit command line, with subcommands to manage Accounts, Payees and Transactions, import
+// statements, manage Users and their API tokens, manage Budgets and their members, migrate the database, serve the API
+// and run the terminal UI.
+//
+// Commands run against the database as svc.LocalPrincipal, needing no login, as whoever can run them can reach the
+// database directly. The API and web UI served by serve authenticate each request instead. Commands run on the Budget
+// given by --budget or $BUDGIT_BUDGET, or the default Budget.
+//
+// Commands write their results to stdout as a table, or as JSON in the same form as the API with --output json, and
+// errors to stderr. The exit code describes the error, see ExitCode.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/api"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/andrewthowell/budgit/budgit/journal"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/andrewthowell/budgit/budgit/tui"
+	"github.com/spf13/cobra"
+)
+
+// Service is the budgit service the commands are run against, implemented by svc.Service.
+type Service interface {
+	CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error)
+	ListAccounts(ctx context.Context) ([]*budgit.Account, error)
+	LinkAccount(ctx context.Context, accountID, integrationID, externalAccountID string) (*budgit.Account, error)
+	SyncAccount(ctx context.Context, accountID string) error
+	CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error)
+	ListPayees(ctx context.Context) ([]*budgit.Payee, error)
+	MergePayees(ctx context.Context, payeeID string, mergedPayeeIDs ...string) (int, error)
+	CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error)
+	GetTransaction(ctx context.Context, transactionID string) (*budgit.Transaction, error)
+	UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	DeleteTransactions(ctx context.Context, transactionIDs ...string) error
+	ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error)
+	ListCategories(ctx context.Context) ([]*budgit.Category, error)
+	ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error)
+	Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error)
+	ImportCSV(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error)
+	ImportOFX(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error)
+	ImportQIF(ctx context.Context, content io.Reader, accountID string, options fileimport.QIFOptions) ([]*budgit.Transaction, error)
+	ImportCAMT(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error)
+	ImportMT940(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error)
+	ImportYNABCSV(ctx context.Context, register, budget io.Reader, options fileimport.YNABOptions) (*svc.YNABImport, error)
+	ImportYNABAPIExport(ctx context.Context, content io.Reader) (*svc.YNABImport, error)
+	ExportJournal(ctx context.Context, w io.Writer, format journal.Format, currency string) error
+	ExportQIF(ctx context.Context, accountID string, w io.Writer, options fileimport.QIFOptions) error
+	Backup(ctx context.Context, w io.Writer) error
+	Restore(ctx context.Context, r io.Reader, budgetID string) (map[string]int, error)
+	PreviewQuickAdd(ctx context.Context, input string, today time.Time) (*svc.QuickAdd, error)
+	ConfirmQuickAdd(ctx context.Context, quickAdd *svc.QuickAdd) (*budgit.Transaction, error)
+	CreateUser(ctx context.Context, username, password string) (*budgit.User, error)
+	ListUsers(ctx context.Context) ([]*budgit.User, error)
+	SetPassword(ctx context.Context, username, password string) error
+	EnrolTOTP(ctx context.Context, username string) (*svc.TOTPEnrolment, error)
+	EnableTOTP(ctx context.Context, username, secret, code string) error
+	DisableTOTP(ctx context.Context, username string) error
+	CreateAPIToken(ctx context.Context, username, name string, scopes []budgit.Scope, expires time.Time) (*budgit.APIToken, string, error)
+	ListAPITokens(ctx context.Context, username string) ([]*budgit.APIToken, error)
+	DeleteAPIToken(ctx context.Context, username, tokenID string) error
+	CreateBudget(ctx context.Context, name string) (*budgit.Budget, error)
+	ListBudgets(ctx context.Context) ([]*budgit.Budget, error)
+	ListMembers(ctx context.Context) ([]*budgit.BudgetMember, error)
+	SetMember(ctx context.Context, username string, role budgit.Role) error
+	RemoveMember(ctx context.Context, username string) error
+	CreateInvitation(ctx context.Context, role budgit.Role) (*budgit.BudgetInvitation, string, error)
+	DeleteInvitation(ctx context.Context, invitationID string) error
+}
+
+// Migrator migrates the database, implemented by migrations.Migrator.
+type Migrator interface {
+	Up() (uint, error)
+	Down(steps int) (uint, error)
+	Close() error
+}
+
+// App provides the commands with their dependencies. They are created on first use, so that commands which do not
+// need the database, such as help, do not connect to it.
+type App struct {
+	Service  func(ctx context.Context) (Service, error)
+	Migrator func(ctx context.Context) (Migrator, error)
+	// Serve serves the API and web UI until the context is done.
+	Serve func(ctx context.Context) error
+	// Now returns the current time, defaulting the dates of Transactions.
+	Now func() time.Time
+}
+
+// Exit codes, returned by ExitCode.
+const (
+	ExitOK = 0
+	// ExitError is the exit code of unexpected errors, such as failing to connect to the database.
+	ExitError = 1
+	// ExitUsage is the exit code of invalid command lines, such as unknown flags or missing arguments.
+	ExitUsage = 2
+	// ExitNotFound is the exit code of commands referencing Accounts, Payees, Transactions, Integrations, Users, API
+	// tokens, Budgets, members or invitations which do not exist.
+	ExitNotFound = 3
+	// ExitConflict is the exit code of commands which conflict with the state of the budget, such as creating a Payee
+	// or User with a name already taken or syncing an Account whose balance does not match its external account.
+	ExitConflict = 4
+)
+
+// usageError is an error in a command line itself.
+type usageError struct {
+	err error
+}
+
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the exit code describing an error returned by a command.
+func ExitCode(err error) int {
+	var (
+		usage                 usageError
+		quickAdd              svc.QuickAddError
+		missingAccounts       svc.MissingAccountsError
+		missingPayees         svc.MissingPayeesError
+		missingCategories     svc.MissingCategoriesError
+		missingCategoryGroups svc.MissingCategoryGroupsError
+		duplicatePayees       svc.DuplicatePayeesError
+		accountSync           svc.AccountSyncError
+		unsupportedCapability svc.UnsupportedCapabilityError
+	)
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage),
+		errors.As(err, &quickAdd),
+		errors.Is(err, svc.ErrQIFAccountRequired),
+		errors.Is(err, svc.ErrInvalidUsername),
+		errors.Is(err, svc.ErrPasswordTooShort),
+		errors.Is(err, svc.ErrInvalidTOTPCode),
+		errors.Is(err, svc.ErrInvalidBudgetName),
+		errors.Is(err, svc.ErrInvalidRole),
+		errors.Is(err, svc.ErrInvalidScopes),
+		errors.Is(err, svc.ErrInvalidExpiry):
+		return ExitUsage
+	case errors.As(err, &missingAccounts),
+		errors.As(err, &missingPayees),
+		errors.As(err, &missingCategories),
+		errors.As(err, &missingCategoryGroups),
+		errors.Is(err, svc.ErrAccountNotFound),
+		errors.Is(err, svc.ErrIntegrationNotFound),
+		errors.Is(err, svc.ErrTransactionNotFound),
+		errors.Is(err, svc.ErrCSVProfileNotFound),
+		errors.Is(err, svc.ErrUserNotFound),
+		errors.Is(err, svc.ErrAPITokenNotFound),
+		errors.Is(err, svc.ErrBudgetNotFound),
+		errors.Is(err, svc.ErrMemberNotFound),
+		errors.Is(err, svc.ErrInvitationNotFound):
+		return ExitNotFound
+	case errors.As(err, &duplicatePayees),
+		errors.As(err, &accountSync),
+		errors.As(err, &unsupportedCapability),
+		errors.Is(err, svc.ErrAccountNotLinked),
+		errors.Is(err, svc.ErrAccountAlreadyLinked),
+		errors.Is(err, svc.ErrUsernameTaken),
+		errors.Is(err, svc.ErrLastOwner),
+		errors.Is(err, svc.ErrRestoreTargetNotEmpty):
+		return ExitConflict
+	default:
+		return ExitError
+	}
+}
+
+// Execute runs the command line given by args, reading any input from stdin and writing any error to stderr, and
+// returns its exit code.
+func (a *App) Execute(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	root := a.Command()
+	root.SetArgs(args)
+	root.SetIn(stdin)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+	cmd, err := root.ExecuteContextC(ctx)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		if errors.As(err, new(usageError)) {
+			fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		}
+	}
+	return ExitCode(err)
+}
+
+// Command returns the root command of the command line.
+func (a *App) Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "budgit",
+		Short:         "Budg-it is a self-hosted zero-based budgeting tool",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Args:          usageArgs(cobra.NoArgs),
+	}
+	cmd.PersistentFlags().StringP(outputFlag, "o", outputTable, "output format, table or json")
+	cmd.PersistentFlags().String(budgetFlag, "", "ID of the Budget to run on, defaulting to $BUDGIT_BUDGET or the default Budget")
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if _, err := outputFormat(cmd); err != nil {
+			return err
+		}
+		if _, ok := cmd.Annotations[authenticatesAnnotation]; !ok {
+			budgetID, err := flagBudgetID(cmd)
+			if err != nil {
+				return err
+			}
+			cmd.SetContext(svc.WithBudget(svc.WithPrincipal(cmd.Context(), svc.LocalPrincipal), budgetID))
+		}
+		return nil
+	}
+	cmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err}
+	})
+
+	cmd.AddCommand(
+		a.accountsCommand(),
+		a.payeesCommand(),
+		a.transactionsCommand(),
+		a.importCommand(),
+		a.exportCommand(),
+		a.backupCommand(),
+		a.restoreCommand(),
+		a.usersCommand(),
+		a.tokensCommand(),
+		a.budgetsCommand(),
+		a.migrateCommand(),
+		a.serveCommand(),
+		a.tuiCommand(),
+	)
+	return cmd
+}
+
+// authenticatesAnnotation marks commands which authenticate the Principal of each operation themselves, so are not run
+// as the local principal.
+const authenticatesAnnotation = "authenticates"
+
+func (a *App) serveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:         "serve",
+		Short:       "Serve the API and web UI until interrupted",
+		Annotations: map[string]string{authenticatesAnnotation: ""},
+		Args:        usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.Serve(cmd.Context())
+		},
+	}
+}
+
+func (a *App) tuiCommand() *cobra.Command {
+	var apiURL, token string
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Run the interactive terminal UI",
+		Long: `Run the interactive terminal UI, to work through the register of each Account and the budget of each month.
+
+The UI runs against the database, or against the API of a budgit server given by --api, authenticating with the
+API token given by --token or $BUDGIT_TOKEN. Either way it shows the Budget given by --budget.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var backend tui.Backend
+			if apiURL != "" {
+				if token == "" {
+					token = os.Getenv("BUDGIT_TOKEN")
+				}
+				if token == "" {
+					return usageError{errors.New("--api requires an API token, given by --token or $BUDGIT_TOKEN")}
+				}
+				budgetID, err := flagBudgetID(cmd)
+				if err != nil {
+					return err
+				}
+				client, err := api.NewServiceClient(apiURL, api.WithBearerToken(token), api.WithBudget(budgetID))
+				if err != nil {
+					return err
+				}
+				backend = client
+			} else {
+				service, err := a.Service(cmd.Context())
+				if err != nil {
+					return err
+				}
+				backend = service
+			}
+			return tui.Run(cmd.Context(), backend, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&apiURL, "api", "", "URL of a budgit server to run against instead of the database, e.g. http://localhost:8080")
+	cmd.Flags().StringVar(&token, "token", "", "API token authenticating to the server given by --api")
+	return cmd
+}
+
+// groupCommand returns a command grouping subcommands, which does nothing itself.
+func groupCommand(use, short string, subcommands ...*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return usageError{fmt.Errorf("%s requires a subcommand", cmd.CommandPath())}
+		},
+	}
+	cmd.AddCommand(subcommands...)
+	return cmd
+}
+
+// usageArgs wraps the errors of a validator of positional arguments as usage errors. Flags are validated too, so that
+// required and mutually exclusive flags which cobra validates after the arguments are also usage errors.
+func usageArgs(args cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, positional []string) error {
+		if err := args(cmd, positional); err != nil {
+			return usageError{err}
+		}
+		if err := cmd.ValidateRequiredFlags(); err != nil {
+			return usageError{err}
+		}
+		if err := cmd.ValidateFlagGroups(); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
+}
+
+// today returns the date of the current time, as a time at midnight UTC, as the dates of Transactions are.
+func (a *App) today() time.Time {
+	now := time.Now()
+	if a.Now != nil {
+		now = a.Now()
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseDate parses a date written "YYYY-MM-DD".
+func parseDate(str string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, str)
+	if err != nil {
+		return time.Time{}, usageError{fmt.Errorf("parsing date %q: expected YYYY-MM-DD", str)}
+	}
+	return date, nil
+}
+
+// parseAmount parses a decimal amount, such as "-12.30".
+func parseAmount(str string) (budgit.BalanceAmount, error) {
+	amount, err := budgit.ParseBalanceAmount(str)
+	if err != nil {
+		return 0, usageError{err}
+	}
+	return amount, nil
+}
@@ -0,0 +1,876 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/cli"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/andrewthowell/budgit/budgit/journal"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCLI(t *testing.T) {
+	suite.Run(t, new(cliSuite))
+}
+
+type cliSuite struct {
+	suite.Suite
+
+	service  *fakeService
+	migrator *fakeMigrator
+	app      *cli.App
+}
+
+func (s *cliSuite) SetupTest() {
+	s.service = &fakeService{}
+	s.migrator = &fakeMigrator{version: 1}
+	s.app = &cli.App{
+		Service: func(ctx context.Context) (cli.Service, error) {
+			return s.service, nil
+		},
+		Migrator: func(ctx context.Context) (cli.Migrator, error) {
+			return s.migrator, nil
+		},
+		Serve: func(ctx context.Context) error {
+			if principal, ok := svc.PrincipalFrom(ctx); ok {
+				s.service.principal = &principal
+			}
+			return nil
+		},
+		Now: func() time.Time {
+			return time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)
+		},
+	}
+}
+
+// fakeService is a cli.Service holding the accounts, payees and transactions it is given, or returning err if set.
+// Methods the tests do not use are left to the embedded nil Service, panicking if called.
+type fakeService struct {
+	cli.Service
+
+	accounts     []*budgit.Account
+	payees       []*budgit.Payee
+	transactions []*budgit.Transaction
+	err          error
+
+	deletedTransactionIDs []string
+	mergedPayeeIDs        []string
+	quickAddInput         string
+	users                 []*budgit.User
+	passwords             map[string]string
+	totpCodes             map[string]string
+	principal             *svc.Principal
+	budgetID              string
+	members               map[string]budgit.Role
+}
+
+func (f *fakeService) CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.accounts = append(f.accounts, accounts...)
+	return accounts, nil
+}
+
+func (f *fakeService) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
+	return f.accounts, f.err
+}
+
+func (f *fakeService) LinkAccount(ctx context.Context, accountID, integrationID, externalAccountID string) (*budgit.Account, error) {
+	return nil, f.err
+}
+
+func (f *fakeService) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	return f.payees, f.err
+}
+
+func (f *fakeService) MergePayees(ctx context.Context, payeeID string, mergedPayeeIDs ...string) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	f.mergedPayeeIDs = mergedPayeeIDs
+	return 2, nil
+}
+
+func (f *fakeService) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.transactions = append(f.transactions, transactions...)
+	return transactions, nil
+}
+
+func (f *fakeService) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	return f.transactions, f.err
+}
+
+func (f *fakeService) GetTransaction(ctx context.Context, transactionID string) (*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, transaction := range f.transactions {
+		if transaction.ID == transactionID {
+			copied := *transaction
+			return &copied, nil
+		}
+	}
+	return nil, fmt.Errorf("getting transaction %q: %w", transactionID, svc.ErrTransactionNotFound)
+}
+
+func (f *fakeService) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.transactions = transactions
+	return transactions, nil
+}
+
+func (f *fakeService) DeleteTransactions(ctx context.Context, transactionIDs ...string) error {
+	f.deletedTransactionIDs = transactionIDs
+	return f.err
+}
+
+// ExportJournal writes the format and currency of the journal exported, rather than the journal itself.
+func (f *fakeService) ExportJournal(ctx context.Context, w io.Writer, format journal.Format, currency string) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := fmt.Fprintf(w, "%s journal in %s\n", format, currency)
+	return err
+}
+
+// ExportQIF writes the Account exported and whether its dates are day first, rather than the QIF file itself.
+func (f *fakeService) ExportQIF(ctx context.Context, accountID string, w io.Writer, options fileimport.QIFOptions) error {
+	if f.err != nil {
+		return f.err
+	}
+	_, err := fmt.Fprintf(w, "QIF of %s, day first %t\n", accountID, options.DayFirst)
+	return err
+}
+
+// Backup writes the Budget backed up, rather than an archive of it.
+func (f *fakeService) Backup(ctx context.Context, w io.Writer) error {
+	if f.err != nil {
+		return f.err
+	}
+	budgetID, _ := svc.BudgetFrom(ctx)
+	_, err := fmt.Fprintf(w, "backup of %s", budgetID)
+	return err
+}
+
+// Restore restores a row of accounts for each byte of the archive, refusing to restore into the default Budget, which
+// is not empty.
+func (f *fakeService) Restore(ctx context.Context, r io.Reader, budgetID string) (map[string]int, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if budgetID == svc.DefaultBudgetID {
+		return nil, fmt.Errorf("restoring budget %q: %w", budgetID, svc.ErrRestoreTargetNotEmpty)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.budgetID = budgetID
+	return map[string]int{"accounts": len(content), "payees": 1}, nil
+}
+
+// PreviewQuickAdd previews any input as a Transaction of a new Payee.
+func (f *fakeService) PreviewQuickAdd(ctx context.Context, input string, today time.Time) (*svc.QuickAdd, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.quickAddInput = input
+	return &svc.QuickAdd{
+		Transaction: &budgit.Transaction{
+			ID: "transaction-1", EffectiveDate: today.AddDate(0, 0, -1), AccountID: "account-1", PayeeID: "payee-1", CategoryID: "category-1", Amount: -320, Memo: "coffee",
+		},
+		NewPayee: &budgit.Payee{ID: "payee-1", Name: "Pret"},
+		Matches: []svc.QuickAddMatch{
+			{Field: "account", Given: "current", Matched: "Current"},
+			{Field: "category", Given: "eating-out", Matched: "Everyday:Eating Out"},
+		},
+	}, nil
+}
+
+func (f *fakeService) ConfirmQuickAdd(ctx context.Context, quickAdd *svc.QuickAdd) (*budgit.Transaction, error) {
+	f.payees = append(f.payees, quickAdd.NewPayee)
+	f.transactions = append(f.transactions, quickAdd.Transaction)
+	return quickAdd.Transaction, nil
+}
+
+// CreateUser records the password of the User created, and the Principal creating them.
+func (f *fakeService) CreateUser(ctx context.Context, username, password string) (*budgit.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if principal, ok := svc.PrincipalFrom(ctx); ok {
+		f.principal = &principal
+	}
+	user := &budgit.User{ID: "user-1", Username: username, CreatedTimestamp: time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)}
+	f.users = append(f.users, user)
+	f.passwords = map[string]string{username: password}
+	return user, nil
+}
+
+func (f *fakeService) SetPassword(ctx context.Context, username, password string) error {
+	f.passwords = map[string]string{username: password}
+	return f.err
+}
+
+func (f *fakeService) EnrolTOTP(ctx context.Context, username string) (*svc.TOTPEnrolment, error) {
+	return &svc.TOTPEnrolment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/Budg-it:alice?secret=JBSWY3DPEHPK3PXP"}, f.err
+}
+
+func (f *fakeService) EnableTOTP(ctx context.Context, username, secret, code string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if code != "123456" {
+		return fmt.Errorf("enabling TOTP of user %q: %w", username, svc.ErrInvalidTOTPCode)
+	}
+	f.totpCodes = map[string]string{username: code}
+	return nil
+}
+
+func (f *fakeService) CreateAPIToken(ctx context.Context, username, name string, scopes []budgit.Scope, expires time.Time) (*budgit.APIToken, string, error) {
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("creating API token of user %q with scope %q: %w", username, scope, svc.ErrInvalidScopes)
+		}
+	}
+	budgetID, _ := svc.BudgetFrom(ctx)
+	return &budgit.APIToken{
+		ID:               "token-1",
+		UserID:           "user-1",
+		Name:             name,
+		BudgetID:         budgetID,
+		Scopes:           scopes,
+		CreatedTimestamp: time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC),
+		ExpiresTimestamp: expires,
+	}, "budgit_t_secret", nil
+}
+
+func (f *fakeService) ListAPITokens(ctx context.Context, username string) ([]*budgit.APIToken, error) {
+	return []*budgit.APIToken{
+		{
+			ID:                "token-1",
+			Name:              "phone",
+			BudgetID:          "default",
+			Scopes:            []budgit.Scope{budgit.ScopeAccountsRead},
+			CreatedTimestamp:  time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC),
+			ExpiresTimestamp:  time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			LastUsedTimestamp: time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:               "token-2",
+			Name:             "scripts",
+			Scopes:           []budgit.Scope{budgit.ScopeAccountsRead, budgit.ScopeTransactionsWrite},
+			CreatedTimestamp: time.Date(2024, 6, 1, 18, 45, 0, 0, time.UTC),
+		},
+	}, f.err
+}
+
+func (f *fakeService) DeleteAPIToken(ctx context.Context, username, tokenID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	return fmt.Errorf("deleting API token %q of user %q: %w", tokenID, username, svc.ErrAPITokenNotFound)
+}
+
+// ListBudgets records the Budget the command was run on.
+func (f *fakeService) ListBudgets(ctx context.Context) ([]*budgit.Budget, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	return []*budgit.Budget{
+		{ID: "default", Name: "Household", Role: budgit.RoleOwner},
+		{ID: "budget-2", Name: "Holiday", Role: budgit.RoleOwner},
+	}, f.err
+}
+
+func (f *fakeService) CreateBudget(ctx context.Context, name string) (*budgit.Budget, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &budgit.Budget{ID: "budget-3", Name: name, Role: budgit.RoleOwner}, nil
+}
+
+func (f *fakeService) ListMembers(ctx context.Context) ([]*budgit.BudgetMember, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	return []*budgit.BudgetMember{
+		{BudgetID: f.budgetID, UserID: "user-1", Username: "alice", Role: budgit.RoleOwner, CreatedTimestamp: time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC)},
+		{BudgetID: f.budgetID, UserID: "user-2", Username: "bob", Role: budgit.RoleEditor, CreatedTimestamp: time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC)},
+	}, f.err
+}
+
+func (f *fakeService) SetMember(ctx context.Context, username string, role budgit.Role) error {
+	if f.err != nil {
+		return f.err
+	}
+	if !role.IsValid() {
+		return fmt.Errorf("setting member %q: %w", username, svc.ErrInvalidRole)
+	}
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	f.members = map[string]budgit.Role{username: role}
+	return nil
+}
+
+// RemoveMember refuses to remove alice, the only owner.
+func (f *fakeService) RemoveMember(ctx context.Context, username string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if username == "alice" {
+		return fmt.Errorf("removing member %q: %w", username, svc.ErrLastOwner)
+	}
+	return nil
+}
+
+func (f *fakeService) CreateInvitation(ctx context.Context, role budgit.Role) (*budgit.BudgetInvitation, string, error) {
+	if f.err != nil {
+		return nil, "", f.err
+	}
+	budgetID, _ := svc.BudgetFrom(ctx)
+	return &budgit.BudgetInvitation{
+		ID:               "invitation-1",
+		BudgetID:         budgetID,
+		Role:             role,
+		CreatedTimestamp: time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC),
+		ExpiresTimestamp: time.Date(2024, 6, 8, 18, 30, 0, 0, time.UTC),
+	}, "budgit_i_secret", nil
+}
+
+func (f *fakeService) DeleteInvitation(ctx context.Context, invitationID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	return fmt.Errorf("deleting invitation %q: %w", invitationID, svc.ErrInvitationNotFound)
+}
+
+// fakeMigrator is a cli.Migrator moving its version by the migrations applied and reverted.
+type fakeMigrator struct {
+	version uint
+	closed  bool
+}
+
+func (f *fakeMigrator) Up() (uint, error) {
+	f.version = 5
+	return f.version, nil
+}
+
+func (f *fakeMigrator) Down(steps int) (uint, error) {
+	if steps <= 0 {
+		f.version = 0
+	} else {
+		f.version -= uint(steps)
+	}
+	return f.version, nil
+}
+
+func (f *fakeMigrator) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (s *cliSuite) run(args ...string) (int, string, string) {
+	return s.runWithInput("", args...)
+}
+
+func (s *cliSuite) runWithInput(stdin string, args ...string) (int, string, string) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	code := s.app.Execute(context.Background(), args, strings.NewReader(stdin), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *cliSuite) TestListAccounts() {
+	s.service.accounts = []*budgit.Account{
+		{ID: "account-1", Name: "Current", Balance: budgit.Balance{ClearedBalance: 1000, EffectiveBalance: 900}},
+		{
+			ID:              "account-2",
+			Name:            "starling - Joint",
+			ExternalAccount: &budgit.ExternalAccount{ID: "external-2", IntegrationID: "starling"},
+		},
+	}
+
+	code, stdout, stderr := s.run("accounts", "list")
+	s.Equal(cli.ExitOK, code)
+	s.Empty(stderr)
+	s.Equal(""+
+		"ID         NAME              CLEARED  EFFECTIVE  LINKED TO\n"+
+		"account-1  Current           10.00    9.00       \n"+
+		"account-2  starling - Joint  0.00     0.00       starling/external-2\n",
+		stdout)
+}
+
+func (s *cliSuite) TestListAccountsAsJSON() {
+	s.service.accounts = []*budgit.Account{
+		{ID: "account-1", Name: "Current", Balance: budgit.Balance{ClearedBalance: 1000, EffectiveBalance: 900}},
+	}
+
+	code, stdout, _ := s.run("accounts", "list", "--output", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`[{"id":"account-1","name":"Current","cleared_balance":1000,"effective_balance":900}]`, stdout)
+}
+
+func (s *cliSuite) TestCreateAccount() {
+	code, _, _ := s.run("accounts", "create", "Savings", "--balance", "120.50")
+	s.Equal(cli.ExitOK, code)
+	s.CMPEqual([]*budgit.Account{
+		{Name: "Savings", Balance: budgit.Balance{ClearedBalance: 12050, EffectiveBalance: 12050}},
+	}, s.service.accounts, cmpopts.IgnoreFields(budgit.Account{}, "ID"))
+}
+
+func (s *cliSuite) TestMergePayees() {
+	code, stdout, _ := s.run("payees", "merge", "payee-1", "payee-2", "payee-3", "-o", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`{"payee_id":"payee-1","moved_transactions":2}`, stdout)
+	s.Equal([]string{"payee-2", "payee-3"}, s.service.mergedPayeeIDs)
+}
+
+func (s *cliSuite) TestAddTransaction() {
+	code, _, _ := s.run("transactions", "add", "--account", "account-1", "--payee", "payee-1", "--amount", "-3.20", "--memo", "Coffee")
+	s.Equal(cli.ExitOK, code)
+	s.CMPEqual([]*budgit.Transaction{
+		{
+			EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			AccountID:     "account-1",
+			PayeeID:       "payee-1",
+			Amount:        -320,
+			Memo:          "Coffee",
+		},
+	}, s.service.transactions, cmpopts.IgnoreFields(budgit.Transaction{}, "ID"))
+}
+
+func (s *cliSuite) TestAddTransfer() {
+	code, _, _ := s.run("transactions", "add", "--account", "account-1", "--transfer-to", "account-2", "--amount", "-50", "--date", "2024-05-31")
+	s.Equal(cli.ExitOK, code)
+	s.CMPEqual([]*budgit.Transaction{
+		{
+			EffectiveDate:   time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+			AccountID:       "account-1",
+			PayeeID:         "account-2",
+			IsPayeeInternal: true,
+			Amount:          -5000,
+		},
+	}, s.service.transactions, cmpopts.IgnoreFields(budgit.Transaction{}, "ID"))
+}
+
+func (s *cliSuite) TestQuickAdd() {
+	code, stdout, stderr := s.runWithInput("y\n", "transactions", "quick-add", "coffee", "3.20", "@Pret", "yesterday", "from", "current", "#eating-out")
+	s.Equal(cli.ExitOK, code)
+	s.Equal("coffee 3.20 @Pret yesterday from current #eating-out", s.service.quickAddInput)
+	s.Equal(`Date:      2024-05-31
+Account:   Current (from "current")
+Payee:     Pret (new)
+Category:  Everyday:Eating Out (from "eating-out")
+Amount:    -3.20
+Memo:      coffee
+Add this Transaction? [y/N] `, stderr)
+	s.Equal(`ID             DATE        ACCOUNT    PAYEE    CATEGORY    AMOUNT  CLEARED  MEMO
+transaction-1  2024-05-31  account-1  payee-1  category-1  -3.20            coffee
+`, stdout)
+	s.Len(s.service.transactions, 1)
+	s.Len(s.service.payees, 1)
+}
+
+func (s *cliSuite) TestQuickAddDeclined() {
+	code, stdout, stderr := s.runWithInput("n\n", "transactions", "quick-add", "coffee 3.20 @Pret")
+	s.Equal(cli.ExitOK, code)
+	s.Empty(stdout)
+	s.True(strings.HasSuffix(stderr, "Add this Transaction? [y/N] Not added.\n"))
+	s.Empty(s.service.transactions)
+
+	code, _, stderr = s.run("transactions", "quick-add", "coffee 3.20 @Pret", "--yes")
+	s.Equal(cli.ExitOK, code)
+	s.NotContains(stderr, "Add this Transaction?")
+	s.Len(s.service.transactions, 1)
+}
+
+func (s *cliSuite) TestEditTransaction() {
+	s.service.transactions = []*budgit.Transaction{
+		{
+			ID:            "transaction-1",
+			EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			AccountID:     "account-1",
+			PayeeID:       "payee-1",
+			Amount:        -320,
+			Memo:          "Coffee",
+		},
+	}
+
+	code, stdout, _ := s.run("transactions", "edit", "transaction-1", "--amount", "-3.50", "--cleared")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`ID             DATE        ACCOUNT    PAYEE    CATEGORY  AMOUNT  CLEARED  MEMO
+transaction-1  2024-06-01  account-1  payee-1            -3.50   yes      Coffee
+`, stdout)
+}
+
+func (s *cliSuite) TestDeleteTransactions() {
+	code, stdout, _ := s.run("transactions", "delete", "transaction-1", "transaction-2")
+	s.Equal(cli.ExitOK, code)
+	s.Empty(stdout)
+	s.Equal([]string{"transaction-1", "transaction-2"}, s.service.deletedTransactionIDs)
+}
+
+func (s *cliSuite) TestCreateUser() {
+	code, stdout, stderr := s.runWithInput("correct horse\n", "users", "create", "alice")
+	s.Equal(cli.ExitOK, code)
+	s.Equal("Password: ", stderr)
+	s.Equal(`ID      USERNAME  TOTP  CREATED
+user-1  alice           2024-06-01 18:30
+`, stdout)
+	s.Equal(map[string]string{"alice": "correct horse"}, s.service.passwords)
+	s.Equal(&svc.LocalPrincipal, s.service.principal)
+
+	s.service.err = fmt.Errorf("creating user %q: %w", "alice", svc.ErrUsernameTaken)
+	code, _, stderr = s.runWithInput("correct horse\n", "users", "create", "alice")
+	s.Equal(cli.ExitConflict, code)
+	s.Equal("Password: Error: creating user \"alice\": a User with the username already exists\n", stderr)
+}
+
+func (s *cliSuite) TestServeDoesNotRunAsLocalPrincipal() {
+	code, _, _ := s.run("serve")
+	s.Equal(cli.ExitOK, code)
+	s.Nil(s.service.principal)
+}
+
+func (s *cliSuite) TestEnableTOTP() {
+	code, _, stderr := s.runWithInput("123456\n", "users", "enable-totp", "alice")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`Secret:  JBSWY3DPEHPK3PXP
+URI:     otpauth://totp/Budg-it:alice?secret=JBSWY3DPEHPK3PXP
+Code:    `, stderr)
+	s.Equal(map[string]string{"alice": "123456"}, s.service.totpCodes)
+
+	code, _, stderr = s.runWithInput("654321\n", "users", "enable-totp", "alice")
+	s.Equal(cli.ExitUsage, code)
+	s.True(strings.HasSuffix(stderr, "Error: enabling TOTP of user \"alice\": the TOTP code is incorrect\n"))
+}
+
+func (s *cliSuite) TestAPITokens() {
+	code, stdout, _ := s.run("tokens", "create", "alice", "phone", "--scope", "accounts:read,accounts:sync", "--expires", "2024-09-01", "--budget", "budget-2", "-o", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`{
+		"api_token":{
+			"id":"token-1","name":"phone","budget_id":"budget-2","scopes":["accounts:read","accounts:sync"],
+			"created_timestamp":"2024-06-01T18:30:00Z","expires_timestamp":"2024-09-01T00:00:00Z"
+		},
+		"token":"budgit_t_secret"
+	}`, stdout)
+
+	code, stdout, _ = s.run("tokens", "create", "alice", "importer", "--scope", "transactions:write")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`ID       NAME      BUDGET   SCOPES              TOKEN
+token-1  importer  default  transactions:write  budgit_t_secret
+`, stdout)
+
+	code, stdout, _ = s.run("tokens", "list", "alice")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`ID       NAME     BUDGET   SCOPES                            CREATED           EXPIRES           LAST USED
+token-1  phone    default  accounts:read                     2024-06-01 18:30  2024-09-01 00:00  2024-06-02 09:00
+token-2  scripts  all      accounts:read,transactions:write  2024-06-01 18:45                    never
+`, stdout)
+}
+
+func (s *cliSuite) TestBudgets() {
+	code, stdout, _ := s.run("budgets", "list")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`ID        NAME       ROLE
+default   Household  owner
+budget-2  Holiday    owner
+`, stdout)
+
+	code, stdout, _ = s.run("budgets", "create", "Wedding", "-o", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`[{"id":"budget-3","name":"Wedding","role":"owner"}]`, stdout)
+}
+
+func (s *cliSuite) TestBudgetFlag() {
+	s.run("budgets", "list")
+	s.Equal("default", s.service.budgetID)
+
+	s.T().Setenv("BUDGIT_BUDGET", "budget-2")
+	s.run("budgets", "list")
+	s.Equal("budget-2", s.service.budgetID)
+
+	s.run("budgets", "list", "--budget", "budget-3")
+	s.Equal("budget-3", s.service.budgetID)
+}
+
+func (s *cliSuite) TestBudgetMembers() {
+	code, stdout, _ := s.run("budgets", "members", "list", "--budget", "budget-2")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`USERNAME  ROLE    SINCE
+alice     owner   2024-06-01 18:30
+bob       editor  2024-06-02 09:00
+`, stdout)
+
+	code, _, _ = s.run("budgets", "members", "set", "carol", "viewer", "--budget", "budget-2")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(map[string]budgit.Role{"carol": budgit.RoleViewer}, s.service.members)
+	s.Equal("budget-2", s.service.budgetID)
+
+	code, _, stderr := s.run("budgets", "members", "set", "carol", "admin")
+	s.Equal(cli.ExitUsage, code)
+	s.Equal("Error: setting member \"carol\": the role must be owner, editor or viewer\n", stderr)
+
+	code, _, stderr = s.run("budgets", "members", "remove", "alice")
+	s.Equal(cli.ExitConflict, code)
+	s.Equal("Error: removing member \"alice\": a Budget must keep at least one owner\n", stderr)
+}
+
+func (s *cliSuite) TestBudgetInvitations() {
+	code, stdout, _ := s.run("budgets", "invite", "--role", "editor", "--budget", "budget-2", "-o", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`{
+		"invitation": {"id":"invitation-1","budget_id":"budget-2","role":"editor","created_timestamp":"2024-06-01T18:30:00Z","expires_timestamp":"2024-06-08T18:30:00Z"},
+		"token": "budgit_i_secret"
+	}`, stdout)
+
+	code, stdout, _ = s.run("budgets", "invite")
+	s.Equal(cli.ExitOK, code)
+	s.Equal(`ID            ROLE    EXPIRES           TOKEN
+invitation-1  viewer  2024-06-08 18:30  budgit_i_secret
+`, stdout)
+
+	code, _, stderr := s.run("budgets", "revoke-invitation", "invitation-9")
+	s.Equal(cli.ExitNotFound, code)
+	s.Equal("Error: deleting invitation \"invitation-9\": the invitation does not exist or has expired\n", stderr)
+}
+
+func (s *cliSuite) TestMigrate() {
+	code, stdout, _ := s.run("migrate", "up")
+	s.Equal(cli.ExitOK, code)
+	s.Equal("VERSION\n5\n", stdout)
+	s.True(s.migrator.closed)
+
+	code, stdout, _ = s.run("migrate", "down", "--steps", "2", "-o", "json")
+	s.Equal(cli.ExitOK, code)
+	s.JSONEq(`{"version":3}`, stdout)
+
+	code, stdout, _ = s.run("migrate", "down", "--all")
+	s.Equal(cli.ExitOK, code)
+	s.Equal("VERSION\n0\n", stdout)
+}
+
+func (s *cliSuite) TestImportRequiresAccount() {
+	path := filepath.Join(s.T().TempDir(), "statement.csv")
+	s.Require().NoError(os.WriteFile(path, []byte("Date,Amount\n"), 0o600))
+
+	code, _, stderr := s.run("import", "csv", path)
+	s.Equal(cli.ExitUsage, code)
+	s.Equal("Error: importing csv requires the flag --account\nRun 'budgit import --help' for usage.\n", stderr)
+}
+
+func (s *cliSuite) TestExport() {
+	testCases := []struct {
+		name           string
+		args           []string
+		expectedStdout string
+	}{
+		{
+			name:           "LedgerByDefault",
+			args:           []string{"export"},
+			expectedStdout: "ledger journal in GBP\n",
+		},
+		{
+			name:           "Hledger",
+			args:           []string{"export", "--format", "hledger"},
+			expectedStdout: "hledger journal in GBP\n",
+		},
+		{
+			name:           "BeancountWithCurrency",
+			args:           []string{"export", "--format", "beancount", "--currency", "EUR"},
+			expectedStdout: "beancount journal in EUR\n",
+		},
+		{
+			name:           "QIF",
+			args:           []string{"export", "--format", "qif", "--account", "account-1", "--day-first"},
+			expectedStdout: "QIF of account-1, day first true\n",
+		},
+	}
+	for _, testCase := range testCases {
+		s.Run(testCase.name, func() {
+			code, stdout, stderr := s.run(testCase.args...)
+			s.Equal(cli.ExitOK, code, stderr)
+			s.Equal(testCase.expectedStdout, stdout)
+		})
+	}
+}
+
+func (s *cliSuite) TestBackupAndRestore() {
+	path := filepath.Join(s.T().TempDir(), "budget.backup")
+
+	code, stdout, stderr := s.run("backup", path, "--budget", "budget-2")
+	s.Equal(cli.ExitOK, code, stderr)
+	s.Empty(stdout)
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal("backup of budget-2", string(content))
+
+	s.Run("BackupDoesNotOverwrite", func() {
+		code, _, stderr := s.run("backup", path)
+		s.Equal(cli.ExitError, code)
+		s.Contains(stderr, "creating backup file")
+	})
+	s.Run("RestoreIntoBudget", func() {
+		code, stdout, stderr := s.run("restore", path, "--budget", "budget-2")
+		s.Equal(cli.ExitOK, code, stderr)
+		s.Equal(`BUDGET    TABLE     ROWS
+budget-2  accounts  18
+budget-2  payees    1
+`, stdout)
+		s.Equal("budget-2", s.service.budgetID)
+	})
+	s.Run("RestoreIntoNewBudget", func() {
+		code, stdout, stderr := s.run("restore", path, "--create", "Restored", "-o", "json")
+		s.Equal(cli.ExitOK, code, stderr)
+		s.JSONEq(`{"budget_id":"budget-3","rows":{"accounts":18,"payees":1}}`, stdout)
+		s.Equal("budget-3", s.service.budgetID)
+	})
+	s.Run("RestoreIntoNonEmptyBudget", func() {
+		code, _, stderr := s.run("restore", path)
+		s.Equal(cli.ExitConflict, code)
+		s.Contains(stderr, "a backup can only be restored into an empty Budget")
+	})
+}
+
+func (s *cliSuite) TestErrors() {
+	testCases := []struct {
+		name           string
+		err            error
+		args           []string
+		expectedCode   int
+		expectedStderr string
+	}{
+		{
+			name:           "UnknownFlag",
+			args:           []string{"accounts", "list", "--colour"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: unknown flag: --colour\nRun 'budgit accounts list --help' for usage.\n",
+		},
+		{
+			name:           "UnknownExportFormat",
+			args:           []string{"export", "--format", "gnucash"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: unknown format \"gnucash\", expected one of ledger, hledger, beancount, qif\nRun 'budgit export --help' for usage.\n",
+		},
+		{
+			name:           "ExportQIFRequiresAccount",
+			args:           []string{"export", "--format", "qif"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: exporting qif requires the flag --account\nRun 'budgit export --help' for usage.\n",
+		},
+		{
+			name:           "MissingSubcommand",
+			args:           []string{"payees"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: budgit payees requires a subcommand\nRun 'budgit payees --help' for usage.\n",
+		},
+		{
+			name:           "MissingRequiredFlag",
+			args:           []string{"transactions", "list"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: required flag(s) \"account\" not set\nRun 'budgit transactions list --help' for usage.\n",
+		},
+		{
+			name:           "InvalidAmount",
+			args:           []string{"transactions", "add", "--account", "account-1", "--payee", "payee-1", "--amount", "lots"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: parsing amount \"lots\": given amount is not a valid decimal amount\nRun 'budgit transactions add --help' for usage.\n",
+		},
+		{
+			name:           "InvalidOutput",
+			args:           []string{"payees", "list", "-o", "yaml"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: invalid output format \"yaml\", expected table or json\nRun 'budgit payees list --help' for usage.\n",
+		},
+		{
+			name:           "UnknownImportFormat",
+			args:           []string{"import", "xlsx", "statement.xlsx"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: unknown format \"xlsx\", expected one of camt053, csv, mt940, ofx, qif, ynab, ynab-csv\nRun 'budgit import --help' for usage.\n",
+		},
+		{
+			name:           "TransactionNotFound",
+			args:           []string{"transactions", "edit", "transaction-1", "--memo", "Tea"},
+			expectedCode:   cli.ExitNotFound,
+			expectedStderr: "Error: getting transaction \"transaction-1\": the requested Transaction does not exist\n",
+		},
+		{
+			name:           "MissingPayees",
+			err:            fmt.Errorf("merging payees: %w", svc.MissingPayeesError{PayeeIDs: []string{"payee-2"}}),
+			args:           []string{"payees", "merge", "payee-1", "payee-2"},
+			expectedCode:   cli.ExitNotFound,
+			expectedStderr: "Error: merging payees: transactions reference Payees that do not exist: [payee-2]\n",
+		},
+		{
+			name:           "AccountAlreadyLinked",
+			err:            fmt.Errorf("linking account %q: %w", "account-1", svc.ErrAccountAlreadyLinked),
+			args:           []string{"accounts", "link", "account-1", "starling", "external-1"},
+			expectedCode:   cli.ExitConflict,
+			expectedStderr: fmt.Sprintf("Error: linking account \"account-1\": %s\n", svc.ErrAccountAlreadyLinked),
+		},
+		{
+			name:           "InvalidQuickAdd",
+			err:            svc.QuickAddError{Input: "coffee @Pret", Err: errors.New("an amount is required, such as 3.20")},
+			args:           []string{"transactions", "quick-add", "coffee @Pret"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: quick-adding \"coffee @Pret\": an amount is required, such as 3.20\n",
+		},
+		{
+			name:           "APITokenNotFound",
+			args:           []string{"tokens", "delete", "alice", "token-9"},
+			expectedCode:   cli.ExitNotFound,
+			expectedStderr: "Error: deleting API token \"token-9\" of user \"alice\": the requested API token does not exist\n",
+		},
+		{
+			name:           "MissingScope",
+			args:           []string{"tokens", "create", "alice", "phone"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: required flag(s) \"scope\" not set\nRun 'budgit tokens create --help' for usage.\n",
+		},
+		{
+			name:           "UnknownScope",
+			args:           []string{"tokens", "create", "alice", "phone", "--scope", "everything"},
+			expectedCode:   cli.ExitUsage,
+			expectedStderr: "Error: creating API token of user \"alice\" with scope \"everything\": an API token requires at least one scope, each a known scope such as accounts:read\n",
+		},
+		{
+			name:           "Internal",
+			err:            fmt.Errorf("listing accounts: %w", errors.New("connection refused")),
+			args:           []string{"accounts", "list"},
+			expectedCode:   cli.ExitError,
+			expectedStderr: "Error: listing accounts: connection refused\n",
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.service.err = tc.err
+			code, _, stderr := s.run(tc.args...)
+			s.Equal(tc.expectedCode, code)
+			s.Equal(tc.expectedStderr, stderr)
+		})
+	}
+}
+
+func (s *cliSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/andrewthowell/budgit/budgit/journal"
+	"github.com/spf13/cobra"
+)
+
+// exportFormats are the formats of the export command: the journal formats, then QIF.
+var exportFormats = []string{string(journal.FormatLedger), string(journal.FormatHledger), string(journal.FormatBeancount), "qif"}
+
+func (a *App) exportCommand() *cobra.Command {
+	var (
+		format, currency, account string
+		dayFirst                  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the budget to another tool",
+		Long: fmt.Sprintf(`Export the budget to stdout, in the format %s given by --format.
+
+The journal formats hold every Account, Payee and Transaction, with amounts in the currency given by --currency. QIF
+holds the register of the Account given by --account.`, strings.Join(exportFormats, ", ")),
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(exportFormats, format) {
+				return usageError{fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(exportFormats, ", "))}
+			}
+			if format == "qif" && account == "" {
+				return usageError{fmt.Errorf("exporting qif requires the flag --account")}
+			}
+
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if format == "qif" {
+				return service.ExportQIF(cmd.Context(), account, cmd.OutOrStdout(), fileimport.QIFOptions{DayFirst: dayFirst})
+			}
+			return service.ExportJournal(cmd.Context(), cmd.OutOrStdout(), journal.Format(format), currency)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", string(journal.FormatLedger), "format to export, "+strings.Join(exportFormats, ", "))
+	cmd.Flags().StringVar(&currency, "currency", "GBP", "currency of amounts (ledger, hledger, beancount)")
+	cmd.Flags().StringVar(&account, "account", "", "ID of the Account to export (qif)")
+	cmd.Flags().BoolVar(&dayFirst, "day-first", false, "whether to write dates day first, e.g. 31/12/2024 (qif)")
+	return cmd
+}
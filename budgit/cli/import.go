@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+// importFlags are the flags of the import command, not every one of which applies to every format.
+type importFlags struct {
+	account      string
+	budget       string
+	dayFirst     bool
+	decimalComma bool
+}
+
+// importer imports a file of a format, returning the Transactions created.
+type importer func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error)
+
+// importers are the importers of each format, by the name given to the import command.
+var importers = map[string]importer{
+	"csv": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		return service.ImportCSV(cmd.Context(), flags.account, content)
+	},
+	"ofx": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		return service.ImportOFX(cmd.Context(), flags.account, content)
+	},
+	"qif": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		return service.ImportQIF(cmd.Context(), content, flags.account, fileimport.QIFOptions{DayFirst: flags.dayFirst})
+	},
+	"camt053": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		return service.ImportCAMT(cmd.Context(), flags.account, content)
+	},
+	"mt940": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		return service.ImportMT940(cmd.Context(), flags.account, content)
+	},
+	"ynab": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		imported, err := service.ImportYNABAPIExport(cmd.Context(), content)
+		if err != nil {
+			return nil, err
+		}
+		return ynabTransactions(cmd, imported), nil
+	},
+	"ynab-csv": func(cmd *cobra.Command, service Service, content io.Reader, flags importFlags) ([]*budgit.Transaction, error) {
+		var budget io.Reader
+		if flags.budget != "" {
+			file, err := os.Open(flags.budget)
+			if err != nil {
+				return nil, fmt.Errorf("opening YNAB budget export: %w", err)
+			}
+			defer file.Close()
+			budget = file
+		}
+		options := fileimport.YNABOptions{DayFirst: flags.dayFirst, DecimalComma: flags.decimalComma}
+		imported, err := service.ImportYNABCSV(cmd.Context(), content, budget, options)
+		if err != nil {
+			return nil, err
+		}
+		return ynabTransactions(cmd, imported), nil
+	},
+}
+
+// ynabTransactions returns the Transactions of a YNAB import, warning of the parts of the budget not imported.
+func ynabTransactions(cmd *cobra.Command, imported *svc.YNABImport) []*budgit.Transaction {
+	for _, unmapped := range imported.Unmapped {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: not imported: %s\n", unmapped)
+	}
+	return imported.Transactions
+}
+
+// accountlessFormats are the formats which are imported without an Account, as they name their Accounts.
+var accountlessFormats = []string{"qif", "ynab", "ynab-csv"}
+
+func (a *App) importCommand() *cobra.Command {
+	formats := maps.Keys(importers)
+	slices.Sort(formats)
+
+	flags := importFlags{}
+	cmd := &cobra.Command{
+		Use:   "import FORMAT FILE",
+		Short: "Import the Transactions of a file",
+		Long: fmt.Sprintf(`Import the Transactions of a file, of the format %s.
+
+Every format but %s is imported into the Account given by --account.`,
+			strings.Join(formats, ", "), strings.Join(accountlessFormats, ", ")),
+		Args: usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, path := args[0], args[1]
+			importer, ok := importers[format]
+			if !ok {
+				return usageError{fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(formats, ", "))}
+			}
+			if flags.account == "" && !slices.Contains(accountlessFormats, format) {
+				return usageError{fmt.Errorf("importing %s requires the flag --account", format)}
+			}
+
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("opening file to import: %w", err)
+			}
+			defer file.Close()
+
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			transactions, err := importer(cmd, service, file, flags)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, transactionsOutput(transactions))
+		},
+	}
+	cmd.Flags().StringVar(&flags.account, "account", "", "ID of the Account to import into")
+	cmd.Flags().StringVar(&flags.budget, "budget", "", "path of YNAB's budget CSV export, of the amounts assigned each month (ynab-csv)")
+	cmd.Flags().BoolVar(&flags.dayFirst, "day-first", false, "whether dates are written day first, e.g. 31/12/2024 (qif, ynab-csv)")
+	cmd.Flags().BoolVar(&flags.decimalComma, "decimal-comma", false, "whether amounts use a decimal comma, e.g. 1.234,50 (ynab-csv)")
+	return cmd
+}
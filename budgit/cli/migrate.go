@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *App) migrateCommand() *cobra.Command {
+	return groupCommand("migrate", "Migrate the database schema",
+		a.migrateUpCommand(),
+		a.migrateDownCommand(),
+	)
+}
+
+func (a *App) migrateUpCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "up",
+		Short: "Apply every migration not yet applied",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			migrator, err := a.Migrator(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { err = errors.Join(err, migrator.Close()) }()
+
+			version, err := migrator.Up()
+			if err != nil {
+				return err
+			}
+			return writeVersion(cmd, version)
+		},
+	}
+}
+
+func (a *App) migrateDownCommand() *cobra.Command {
+	var (
+		steps int
+		all   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the latest migrations applied, the last only unless --steps or --all is given",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if steps < 1 {
+				return usageError{fmt.Errorf("invalid number of steps %d, expected at least 1", steps)}
+			}
+			if all {
+				steps = 0
+			}
+			migrator, err := a.Migrator(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { err = errors.Join(err, migrator.Close()) }()
+
+			version, err := migrator.Down(steps)
+			if err != nil {
+				return err
+			}
+			return writeVersion(cmd, version)
+		},
+	}
+	cmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+	cmd.Flags().BoolVar(&all, "all", false, "revert every migration, dropping every table")
+	cmd.MarkFlagsMutuallyExclusive("steps", "all")
+	return cmd
+}
+
+func writeVersion(cmd *cobra.Command, version uint) error {
+	return writeOutput(cmd, output{
+		header: []string{"VERSION"},
+		rows:   [][]string{{fmt.Sprint(version)}},
+		json: struct {
+			Version uint `json:"version"`
+		}{Version: version},
+	})
+}
@@ -0,0 +1,236 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFlag  = "output"
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func outputFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return "", err
+	}
+	if format != outputTable && format != outputJSON {
+		return "", usageError{fmt.Errorf("invalid output format %q, expected %s or %s", format, outputTable, outputJSON)}
+	}
+	return format, nil
+}
+
+// output is the result of a command, written as a table or, as the API would return it, as JSON.
+type output struct {
+	header []string
+	rows   [][]string
+	json   any
+}
+
+func writeOutput(cmd *cobra.Command, out output) error {
+	format, err := outputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out.json); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(out.header, "\t"))
+	for _, row := range out.rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	return nil
+}
+
+func accountsOutput(accounts []*budgit.Account) output {
+	out := output{
+		header: []string{"ID", "NAME", "CLEARED", "EFFECTIVE", "LINKED TO"},
+		rows:   make([][]string, 0, len(accounts)),
+		json:   mapSlice(accounts, api.ToAccount),
+	}
+	for _, account := range accounts {
+		linkedTo := ""
+		if external := account.ExternalAccount; external != nil {
+			linkedTo = fmt.Sprintf("%s/%s", external.IntegrationID, external.ID)
+		}
+		out.rows = append(out.rows, []string{
+			account.ID,
+			account.Name,
+			account.Balance.ClearedBalance.String(),
+			account.Balance.EffectiveBalance.String(),
+			linkedTo,
+		})
+	}
+	return out
+}
+
+func payeesOutput(payees []*budgit.Payee) output {
+	out := output{
+		header: []string{"ID", "NAME"},
+		rows:   make([][]string, 0, len(payees)),
+		json:   mapSlice(payees, api.ToPayee),
+	}
+	for _, payee := range payees {
+		out.rows = append(out.rows, []string{payee.ID, payee.Name})
+	}
+	return out
+}
+
+func transactionsOutput(transactions []*budgit.Transaction) output {
+	out := output{
+		header: []string{"ID", "DATE", "ACCOUNT", "PAYEE", "CATEGORY", "AMOUNT", "CLEARED", "MEMO"},
+		rows:   make([][]string, 0, len(transactions)),
+		json:   mapSlice(transactions, api.ToTransaction),
+	}
+	for _, transaction := range transactions {
+		payee := transaction.PayeeID
+		if transaction.IsPayeeInternal {
+			payee = "transfer:" + payee
+		}
+		cleared := ""
+		if transaction.Cleared {
+			cleared = "yes"
+		}
+		out.rows = append(out.rows, []string{
+			transaction.ID,
+			transaction.EffectiveDate.Format("2006-01-02"),
+			transaction.AccountID,
+			payee,
+			transaction.CategoryID,
+			transaction.Amount.String(),
+			cleared,
+			transaction.Memo,
+		})
+	}
+	return out
+}
+
+func mapSlice[S ~[]E, E, T any](slice S, f func(E) T) []T {
+	mapped := make([]T, 0, len(slice))
+	for _, e := range slice {
+		mapped = append(mapped, f(e))
+	}
+	return mapped
+}
+
+func usersOutput(users []*budgit.User) output {
+	out := output{
+		header: []string{"ID", "USERNAME", "TOTP", "CREATED"},
+		rows:   make([][]string, 0, len(users)),
+		json:   mapSlice(users, api.ToUser),
+	}
+	for _, user := range users {
+		totp := ""
+		if user.TOTPEnabled {
+			totp = "yes"
+		}
+		out.rows = append(out.rows, []string{user.ID, user.Username, totp, formatTimestamp(user.CreatedTimestamp)})
+	}
+	return out
+}
+
+func apiTokensOutput(tokens []*budgit.APIToken) output {
+	out := output{
+		header: []string{"ID", "NAME", "BUDGET", "SCOPES", "CREATED", "EXPIRES", "LAST USED"},
+		rows:   make([][]string, 0, len(tokens)),
+		json:   mapSlice(tokens, api.ToAPIToken),
+	}
+	for _, token := range tokens {
+		expires := ""
+		if !token.ExpiresTimestamp.IsZero() {
+			expires = formatTimestamp(token.ExpiresTimestamp)
+		}
+		out.rows = append(out.rows, []string{
+			token.ID,
+			token.Name,
+			formatTokenBudget(token),
+			formatScopes(token.Scopes),
+			formatTimestamp(token.CreatedTimestamp),
+			expires,
+			formatTimestamp(token.LastUsedTimestamp),
+		})
+	}
+	return out
+}
+
+// newAPITokenOutput is the output of an API token created, with the token itself, which cannot be shown again.
+func newAPITokenOutput(apiToken *budgit.APIToken, token string) output {
+	return output{
+		header: []string{"ID", "NAME", "BUDGET", "SCOPES", "TOKEN"},
+		rows:   [][]string{{apiToken.ID, apiToken.Name, formatTokenBudget(apiToken), formatScopes(apiToken.Scopes), token}},
+		json:   &api.NewAPIToken{APIToken: *api.ToAPIToken(apiToken), Token: token},
+	}
+}
+
+// formatTokenBudget formats the Budget an API token may be used for, or "all" for tokens which may be used for every
+// Budget of their User.
+func formatTokenBudget(token *budgit.APIToken) string {
+	if token.BudgetID == "" {
+		return "all"
+	}
+	return token.BudgetID
+}
+
+func formatScopes(scopes []budgit.Scope) string {
+	return strings.Join(mapSlice(scopes, func(scope budgit.Scope) string { return string(scope) }), ",")
+}
+
+// formatTimestamp formats a timestamp to the minute, or as "never" if it is zero.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+func budgetsOutput(budgets []*budgit.Budget) output {
+	out := output{
+		header: []string{"ID", "NAME", "ROLE"},
+		rows:   make([][]string, 0, len(budgets)),
+		json:   mapSlice(budgets, api.ToBudget),
+	}
+	for _, budget := range budgets {
+		out.rows = append(out.rows, []string{budget.ID, budget.Name, string(budget.Role)})
+	}
+	return out
+}
+
+func budgetMembersOutput(members []*budgit.BudgetMember) output {
+	out := output{
+		header: []string{"USERNAME", "ROLE", "SINCE"},
+		rows:   make([][]string, 0, len(members)),
+		json:   mapSlice(members, api.ToBudgetMember),
+	}
+	for _, member := range members {
+		out.rows = append(out.rows, []string{member.Username, string(member.Role), formatTimestamp(member.CreatedTimestamp)})
+	}
+	return out
+}
+
+// newBudgetInvitationOutput is the output of an invitation created, with its token, which cannot be shown again.
+func newBudgetInvitationOutput(invitation *budgit.BudgetInvitation, token string) output {
+	return output{
+		header: []string{"ID", "ROLE", "EXPIRES", "TOKEN"},
+		rows:   [][]string{{invitation.ID, string(invitation.Role), formatTimestamp(invitation.ExpiresTimestamp), token}},
+		json:   &api.NewBudgetInvitation{Invitation: *api.ToBudgetInvitation(invitation), Token: token},
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func (a *App) payeesCommand() *cobra.Command {
+	return groupCommand("payees", "Manage Payees",
+		a.payeesListCommand(),
+		a.payeesCreateCommand(),
+		a.payeesMergeCommand(),
+	)
+}
+
+func (a *App) payeesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Payees",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			payees, err := service.ListPayees(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, payeesOutput(payees))
+		},
+	}
+}
+
+func (a *App) payeesCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create NAME...",
+		Short: "Create Payees",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			payees := make([]*budgit.Payee, 0, len(args))
+			for _, name := range args {
+				payees = append(payees, &budgit.Payee{ID: uuid.New().String(), Name: name})
+			}
+			payees, err = service.CreatePayees(cmd.Context(), payees...)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, payeesOutput(payees))
+		},
+	}
+}
+
+func (a *App) payeesMergeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge PAYEE_ID MERGED_PAYEE_ID...",
+		Short: "Merge Payees into another, moving their Transactions to it",
+		Args:  usageArgs(cobra.MinimumNArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			moved, err := service.MergePayees(cmd.Context(), args[0], args[1:]...)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, output{
+				header: []string{"PAYEE", "MOVED TRANSACTIONS"},
+				rows:   [][]string{{args[0], strconv.Itoa(moved)}},
+				json: struct {
+					PayeeID           string `json:"payee_id"`
+					MovedTransactions int    `json:"moved_transactions"`
+				}{PayeeID: args[0], MovedTransactions: moved},
+			})
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// confirmed reads a line answering a yes or no question, returning whether it is yes.
+func confirmed(r io.Reader) bool {
+	answer, _ := readLine(r)
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// readLine reads a line, without its line ending. It reads a byte at a time, rather than through a bufio.Reader, so
+// that no more than the line is consumed and later prompts can read the lines after it.
+func readLine(r io.Reader) (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := r.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading input: %w", err)
+		}
+	}
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
+
+// prompt writes a prompt to stderr and reads the line answering it.
+func prompt(cmd *cobra.Command, message string) (string, error) {
+	fmt.Fprint(cmd.ErrOrStderr(), message)
+	answer, err := readLine(cmd.InOrStdin())
+	return strings.TrimSpace(answer), err
+}
+
+// promptPassword writes a prompt to stderr and reads a password, without echoing it if stdin is a terminal. Passwords
+// piped to stdin are read a line at a time.
+func promptPassword(cmd *cobra.Command, message string) (string, error) {
+	fmt.Fprint(cmd.ErrOrStderr(), message)
+	if fd, ok := terminalFd(cmd.InOrStdin()); ok {
+		password, err := term.ReadPassword(fd)
+		fmt.Fprintln(cmd.ErrOrStderr())
+		if err != nil {
+			return "", fmt.Errorf("reading password: %w", err)
+		}
+		return string(password), nil
+	}
+	return readLine(cmd.InOrStdin())
+}
+
+// promptNewPassword reads a new password, asking for it twice if stdin is a terminal, so that a typo is not set.
+func promptNewPassword(cmd *cobra.Command) (string, error) {
+	password, err := promptPassword(cmd, "Password: ")
+	if err != nil {
+		return "", err
+	}
+	if _, ok := terminalFd(cmd.InOrStdin()); !ok {
+		return password, nil
+	}
+	confirmation, err := promptPassword(cmd, "Confirm password: ")
+	if err != nil {
+		return "", err
+	}
+	if confirmation != password {
+		return "", usageError{errors.New("the passwords do not match")}
+	}
+	return password, nil
+}
+
+func terminalFd(r io.Reader) (int, bool) {
+	f, ok := r.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0, false
+	}
+	return int(f.Fd()), true
+}
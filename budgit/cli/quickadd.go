@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/spf13/cobra"
+)
+
+func (a *App) transactionsQuickAddCommand() *cobra.Command {
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "quick-add TEXT...",
+		Short: "Add a Transaction written in a short, natural form",
+		Long: `Add a Transaction written in a short, natural form, such as
+
+  budgit transactions quick-add coffee 3.20 @Pret yesterday from Starling personal #eating-out
+
+The words may be in any order:
+  - an amount, spent unless written with a leading +, such as +1500 for income;
+  - @ and the Payee, quoted if it is several words, created if no Payee matches;
+  - # and the Category;
+  - from and the Account, which may be left out if there is only one;
+  - a date: today, yesterday, a weekday, or YYYY-MM-DD, today if none is given.
+Every other word is the memo. Names are matched loosely, so may be abbreviated or in any case.
+
+The Transaction is shown to be confirmed before it is added, unless --yes is given.`,
+		Args: usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			quickAdd, err := service.PreviewQuickAdd(cmd.Context(), strings.Join(args, " "), a.today())
+			if err != nil {
+				return err
+			}
+			writeQuickAdd(cmd.ErrOrStderr(), quickAdd)
+			if !yes {
+				fmt.Fprint(cmd.ErrOrStderr(), "Add this Transaction? [y/N] ")
+				if !confirmed(cmd.InOrStdin()) {
+					fmt.Fprintln(cmd.ErrOrStderr(), "Not added.")
+					return nil
+				}
+			}
+			transaction, err := service.ConfirmQuickAdd(cmd.Context(), quickAdd)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, transactionsOutput([]*budgit.Transaction{transaction}))
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "add the Transaction without asking to confirm it")
+	return cmd
+}
+
+// writeQuickAdd writes the Transaction of a QuickAdd, with the names matched by those given, for confirmation.
+func writeQuickAdd(w io.Writer, quickAdd *svc.QuickAdd) {
+	names := make(map[string]string, len(quickAdd.Matches))
+	for _, match := range quickAdd.Matches {
+		name := match.Matched
+		if match.Given != "" && match.Given != match.Matched {
+			name = fmt.Sprintf("%s (from %q)", match.Matched, match.Given)
+		}
+		names[match.Field] = name
+	}
+	if quickAdd.NewPayee != nil {
+		names["payee"] = quickAdd.NewPayee.Name + " (new)"
+	}
+	transaction := quickAdd.Transaction
+	fmt.Fprintf(w, "Date:      %s\n", transaction.EffectiveDate.Format("2006-01-02"))
+	fmt.Fprintf(w, "Account:   %s\n", names["account"])
+	fmt.Fprintf(w, "Payee:     %s\n", names["payee"])
+	if category, ok := names["category"]; ok {
+		fmt.Fprintf(w, "Category:  %s\n", category)
+	}
+	fmt.Fprintf(w, "Amount:    %s\n", transaction.Amount)
+	if transaction.Memo != "" {
+		fmt.Fprintf(w, "Memo:      %s\n", transaction.Memo)
+	}
+}
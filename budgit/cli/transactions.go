@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func (a *App) transactionsCommand() *cobra.Command {
+	return groupCommand("transactions", "Manage Transactions",
+		a.transactionsAddCommand(),
+		a.transactionsQuickAddCommand(),
+		a.transactionsListCommand(),
+		a.transactionsEditCommand(),
+		a.transactionsDeleteCommand(),
+	)
+}
+
+// transactionFlags are the flags setting the fields of a Transaction.
+type transactionFlags struct {
+	account, payee, transferTo, category, amount, date, memo string
+	cleared                                                  bool
+}
+
+func (f *transactionFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.account, "account", "", "ID of the Account")
+	cmd.Flags().StringVar(&f.payee, "payee", "", "ID of the Payee")
+	cmd.Flags().StringVar(&f.transferTo, "transfer-to", "", "ID of the Account transferred to, instead of a Payee")
+	cmd.Flags().StringVar(&f.category, "category", "", "ID of the Category")
+	cmd.Flags().StringVar(&f.amount, "amount", "", "amount, negative for money leaving the Account, e.g. -12.30")
+	cmd.Flags().StringVar(&f.date, "date", "", "date, written YYYY-MM-DD")
+	cmd.Flags().StringVar(&f.memo, "memo", "", "memo")
+	cmd.Flags().BoolVar(&f.cleared, "cleared", false, "whether the Transaction has cleared")
+	cmd.MarkFlagsMutuallyExclusive("payee", "transfer-to")
+}
+
+var transactionFlagNames = []string{"account", "payee", "transfer-to", "category", "amount", "date", "memo", "cleared"}
+
+func (f *transactionFlags) anyChanged(cmd *cobra.Command) bool {
+	for _, name := range transactionFlagNames {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply sets the fields of a Transaction given by flags which were changed.
+func (f *transactionFlags) apply(cmd *cobra.Command, transaction *budgit.Transaction) error {
+	changed := cmd.Flags().Changed
+	if changed("account") {
+		transaction.AccountID = f.account
+	}
+	if changed("payee") {
+		transaction.PayeeID, transaction.IsPayeeInternal = f.payee, false
+	}
+	if changed("transfer-to") {
+		transaction.PayeeID, transaction.IsPayeeInternal = f.transferTo, true
+	}
+	if changed("category") {
+		transaction.CategoryID = f.category
+	}
+	if changed("amount") {
+		amount, err := parseAmount(f.amount)
+		if err != nil {
+			return err
+		}
+		transaction.Amount = amount
+	}
+	if changed("date") {
+		date, err := parseDate(f.date)
+		if err != nil {
+			return err
+		}
+		transaction.EffectiveDate = date
+	}
+	if changed("memo") {
+		transaction.Memo = f.memo
+	}
+	if changed("cleared") {
+		transaction.Cleared = f.cleared
+	}
+	return nil
+}
+
+func (a *App) transactionsAddCommand() *cobra.Command {
+	flags := &transactionFlags{}
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a Transaction, dated today unless --date is given",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if flags.payee == "" && flags.transferTo == "" {
+				return usageError{errors.New("one of the flags --payee or --transfer-to is required")}
+			}
+			transaction := &budgit.Transaction{ID: uuid.New().String(), EffectiveDate: a.today()}
+			if err := flags.apply(cmd, transaction); err != nil {
+				return err
+			}
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			transactions, err := service.CreateTransactions(cmd.Context(), transaction)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, transactionsOutput(transactions))
+		},
+	}
+	flags.register(cmd)
+	cmd.MarkFlagRequired("account")
+	cmd.MarkFlagRequired("amount")
+	return cmd
+}
+
+func (a *App) transactionsListCommand() *cobra.Command {
+	var accountID string
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the Transactions of an Account",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			transactions, err := service.ListTransactions(cmd.Context(), accountID)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, transactionsOutput(transactions))
+		},
+	}
+	cmd.Flags().StringVar(&accountID, "account", "", "ID of the Account")
+	cmd.MarkFlagRequired("account")
+	return cmd
+}
+
+func (a *App) transactionsEditCommand() *cobra.Command {
+	flags := &transactionFlags{}
+	cmd := &cobra.Command{
+		Use:   "edit TRANSACTION_ID",
+		Short: "Edit the fields of a Transaction given by flags",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !flags.anyChanged(cmd) {
+				return usageError{fmt.Errorf("no fields of transaction %q to edit were given", args[0])}
+			}
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			transaction, err := service.GetTransaction(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if err := flags.apply(cmd, transaction); err != nil {
+				return err
+			}
+			transactions, err := service.UpdateTransactions(cmd.Context(), transaction)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, transactionsOutput(transactions))
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func (a *App) transactionsDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete TRANSACTION_ID...",
+		Short: "Delete Transactions, along with the other side of transfers",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.DeleteTransactions(cmd.Context(), args...)
+		},
+	}
+}
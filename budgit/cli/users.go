@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *App) usersCommand() *cobra.Command {
+	return groupCommand("users", "Manage the Users who log in to the API and web UI",
+		a.usersListCommand(),
+		a.usersCreateCommand(),
+		a.usersSetPasswordCommand(),
+		a.usersEnableTOTPCommand(),
+		a.usersDisableTOTPCommand(),
+	)
+}
+
+func (a *App) usersListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List Users",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			users, err := service.ListUsers(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, usersOutput(users))
+		},
+	}
+}
+
+func (a *App) usersCreateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "create USERNAME",
+		Short: "Create a User, reading their password from stdin",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			password, err := promptNewPassword(cmd)
+			if err != nil {
+				return err
+			}
+			user, err := service.CreateUser(cmd.Context(), args[0], password)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, usersOutput([]*budgit.User{user}))
+		},
+	}
+}
+
+func (a *App) usersSetPasswordCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-password USERNAME",
+		Short: "Set the password of a User, reading it from stdin",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			password, err := promptNewPassword(cmd)
+			if err != nil {
+				return err
+			}
+			return service.SetPassword(cmd.Context(), args[0], password)
+		},
+	}
+}
+
+func (a *App) usersEnableTOTPCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable-totp USERNAME",
+		Short: "Enable a TOTP second factor of a User",
+		Long: `Enable a TOTP second factor of a User, a code of which they must then give along with their password to log in.
+
+A new secret is shown, to be added to an authenticator app, and a code it shows is read from stdin to confirm it has
+the secret before it is enabled.`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			enrolment, err := service.EnrolTOTP(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Secret:  %s\nURI:     %s\n", enrolment.Secret, enrolment.URI)
+			code, err := prompt(cmd, "Code:    ")
+			if err != nil {
+				return err
+			}
+			return service.EnableTOTP(cmd.Context(), args[0], enrolment.Secret, code)
+		},
+	}
+}
+
+func (a *App) usersDisableTOTPCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable-totp USERNAME",
+		Short: "Disable the TOTP second factor of a User",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.DisableTOTP(cmd.Context(), args[0])
+		},
+	}
+}
+
+func (a *App) tokensCommand() *cobra.Command {
+	return groupCommand("tokens", "Manage the API tokens of Users",
+		a.tokensListCommand(),
+		a.tokensCreateCommand(),
+		a.tokensDeleteCommand(),
+	)
+}
+
+func (a *App) tokensListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list USERNAME",
+		Short: "List the API tokens of a User",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			tokens, err := service.ListAPITokens(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, apiTokensOutput(tokens))
+		},
+	}
+}
+
+func (a *App) tokensCreateCommand() *cobra.Command {
+	var scopes []string
+	var expires string
+	scopeNames := mapSlice(budgit.Scopes, func(scope budgit.Scope) string { return string(scope) })
+	cmd := &cobra.Command{
+		Use:   "create USERNAME NAME",
+		Short: "Create an API token of a User, named for what uses it",
+		Long: fmt.Sprintf(`Create an API token of a User, named for what uses it, such as "phone".
+
+The token may only be used for the Budget given by --budget, which the User must be a member of, and for the
+operations of the API covered by its scopes, given by --scope. The scopes are:
+
+  %s
+
+The token is shown once, as only its hash is kept.`, strings.Join(scopeNames, "\n  ")),
+		Args: usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var expiresTimestamp time.Time
+			if expires != "" {
+				var err error
+				if expiresTimestamp, err = parseDate(expires); err != nil {
+					return err
+				}
+			}
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			tokenScopes := mapSlice(scopes, func(scope string) budgit.Scope { return budgit.Scope(scope) })
+			apiToken, token, err := service.CreateAPIToken(cmd.Context(), args[0], args[1], tokenScopes, expiresTimestamp)
+			if err != nil {
+				return err
+			}
+			return writeOutput(cmd, newAPITokenOutput(apiToken, token))
+		},
+	}
+	cmd.Flags().StringSliceVar(&scopes, "scope", nil, "scope of the token, repeated or separated by commas for several")
+	cmd.Flags().StringVar(&expires, "expires", "", "date the token expires at the start of, YYYY-MM-DD, never if not given")
+	cmd.MarkFlagRequired("scope")
+	return cmd
+}
+
+func (a *App) tokensDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete USERNAME TOKEN_ID",
+		Short: "Revoke an API token of a User",
+		Args:  usageArgs(cobra.ExactArgs(2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service, err := a.Service(cmd.Context())
+			if err != nil {
+				return err
+			}
+			return service.DeleteAPIToken(cmd.Context(), args[0], args[1])
+		},
+	}
+}
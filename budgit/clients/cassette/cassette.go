@@ -0,0 +1,363 @@
+// Package cassette records HTTP traffic to integrations, and replays it deterministically in tests.
+//
+// A Cassette holds request and response pairs, with secrets scrubbed, and is stored as JSON in testdata.
+// A Recorder in ModeRecord sends requests to the real integration and appends them to the Cassette,
+// and in ModeReplay answers requests from the Cassette without touching the network.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Version is the version of the Cassette file format.
+const Version = 1
+
+// Cassette is a recording of HTTP interactions with an integration.
+type Cassette struct {
+	Version      int            `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a single request and the response received for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. URI is the path and query, so a Cassette can be replayed against any host.
+type Request struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads the Cassette at the given path.
+func Load(path string) (*Cassette, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading cassette: %w", err)
+	}
+	cassette := &Cassette{}
+	if err := json.Unmarshal(contents, cassette); err != nil {
+		return nil, fmt.Errorf("loading cassette %q: %w", path, err)
+	}
+	if cassette.Version != Version {
+		return nil, fmt.Errorf("loading cassette %q: unsupported version %d", path, cassette.Version)
+	}
+	return cassette, nil
+}
+
+// Save writes the Cassette to the given path, creating any missing directories.
+func (c *Cassette) Save(path string) error {
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	if err := os.WriteFile(path, append(contents, '\n'), 0o644); err != nil {
+		return fmt.Errorf("saving cassette: %w", err)
+	}
+	return nil
+}
+
+// Mode is whether a Recorder records or replays interactions.
+type Mode int
+
+const (
+	// ModeReplay replays recorded interactions, strictly failing any request that was not recorded.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request, recording the interactions.
+	ModeRecord
+	// ModeReplayOrRecord replays recorded interactions, sending and recording any request that was not recorded.
+	ModeReplayOrRecord
+)
+
+// UnexpectedRequestError is returned in ModeReplay for a request that matches no unused Interaction.
+type UnexpectedRequestError struct {
+	Method, URI string
+}
+
+func (e UnexpectedRequestError) Error() string {
+	return fmt.Sprintf("cassette has no interaction for request %s %s", e.Method, e.URI)
+}
+
+// Recorder is a http.RoundTripper which records or replays interactions with a Cassette.
+type Recorder struct {
+	mode     Mode
+	cassette *Cassette
+	base     http.RoundTripper
+	scrubber *Scrubber
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// NewRecorder returns a Recorder. In ModeRecord, requests are sent using base, defaulting to http.DefaultTransport.
+func NewRecorder(mode Mode, cassette *Cassette, base http.RoundTripper, scrubber *Scrubber) *Recorder {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if scrubber == nil {
+		scrubber = DefaultScrubber()
+	}
+	if cassette.Version == 0 {
+		cassette.Version = Version
+	}
+	return &Recorder{
+		mode:     mode,
+		cassette: cassette,
+		base:     base,
+		scrubber: scrubber,
+		used:     make([]bool, len(cassette.Interactions)),
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	recorded := Request{
+		Method: req.Method,
+		URI:    req.URL.RequestURI(),
+		Header: req.Header.Clone(),
+		Body:   reqBody,
+	}
+	r.scrubber.scrubRequest(&recorded)
+
+	if r.mode != ModeRecord {
+		if resp, ok := r.replay(req, recorded); ok {
+			return resp, nil
+		}
+		if r.mode == ModeReplay {
+			return nil, UnexpectedRequestError{Method: recorded.Method, URI: recorded.URI}
+		}
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	interaction := &Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       respBody,
+		},
+	}
+	r.scrubber.scrubResponse(&interaction.Response)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.used = append(r.used, true)
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !matches(interaction.Request, recorded) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, true
+	}
+	return nil, false
+}
+
+// Unused returns the Interactions which have not been replayed, so tests can assert every request was made.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	unused := []*Interaction{}
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// matches returns true if a request matches a recorded one by method, path, query and body.
+func matches(recorded, req Request) bool {
+	if recorded.Method != req.Method || recorded.Body != req.Body {
+		return false
+	}
+	recordedURI, err := url.ParseRequestURI(recorded.URI)
+	if err != nil {
+		return false
+	}
+	reqURI, err := url.ParseRequestURI(req.URI)
+	if err != nil {
+		return false
+	}
+	// Queries are compared as values, so that parameter order does not matter.
+	return recordedURI.Path == reqURI.Path && recordedURI.Query().Encode() == reqURI.Query().Encode()
+}
+
+// readBody reads a body fully, replacing it with an unread copy.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	contents, err := io.ReadAll(*body)
+	if err != nil {
+		return "", err
+	}
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(contents))
+	return string(contents), nil
+}
+
+const redacted = "**REDACTED**"
+
+// Scrubber removes secrets from Interactions before they are recorded, and from requests before they are matched.
+type Scrubber struct {
+	// Headers are replaced with a redacted value.
+	Headers []string
+	// Fields are replaced with a redacted value wherever they appear in form or JSON bodies.
+	Fields []string
+	// Replacements replaces each key with its value in URIs and bodies, e.g. to anonymise account identifiers.
+	Replacements map[string]string
+}
+
+// DefaultScrubber scrubs credentials and cookies.
+func DefaultScrubber() *Scrubber {
+	return &Scrubber{
+		Headers: []string{"Authorization", "Cookie", "Set-Cookie", "X-Api-Key"},
+		Fields:  []string{"access_token", "refresh_token", "client_secret", "password", "code"},
+	}
+}
+
+func (s *Scrubber) scrubRequest(req *Request) {
+	s.scrubHeader(req.Header)
+	req.URI = s.replace(req.URI)
+	req.Body = s.scrubBody(req.Header.Get("Content-Type"), s.replace(req.Body))
+}
+
+func (s *Scrubber) scrubResponse(resp *Response) {
+	s.scrubHeader(resp.Header)
+	// Replayed responses are not compressed or chunked, so these headers would be misleading.
+	resp.Header.Del("Content-Length")
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Date")
+	resp.Body = s.scrubBody(resp.Header.Get("Content-Type"), s.replace(resp.Body))
+}
+
+func (s *Scrubber) scrubHeader(header http.Header) {
+	for _, key := range s.Headers {
+		if header.Get(key) != "" {
+			header.Set(key, redacted)
+		}
+	}
+}
+
+func (s *Scrubber) replace(str string) string {
+	// Replace longest keys first, so that overlapping keys replace deterministically.
+	keys := make([]string, 0, len(s.Replacements))
+	for key := range s.Replacements {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int { return len(b) - len(a) })
+	for _, key := range keys {
+		str = strings.ReplaceAll(str, key, s.Replacements[key])
+	}
+	return str
+}
+
+func (s *Scrubber) scrubBody(contentType, body string) string {
+	if body == "" || len(s.Fields) == 0 {
+		return body
+	}
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(body)
+		if err != nil {
+			return body
+		}
+		for _, field := range s.Fields {
+			if values.Has(field) {
+				values.Set(field, redacted)
+			}
+		}
+		return values.Encode()
+	case strings.Contains(contentType, "json"):
+		var value any
+		if err := json.Unmarshal([]byte(body), &value); err != nil {
+			return body
+		}
+		if !s.scrubJSON(value) {
+			return body
+		}
+		scrubbed, err := json.Marshal(value)
+		if err != nil {
+			return body
+		}
+		return string(scrubbed)
+	}
+	return body
+}
+
+// scrubJSON redacts Fields in a decoded JSON value in place, returning true if any were found.
+func (s *Scrubber) scrubJSON(value any) bool {
+	scrubbed := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if slices.Contains(s.Fields, key) {
+				v[key] = redacted
+				scrubbed = true
+				continue
+			}
+			scrubbed = s.scrubJSON(child) || scrubbed
+		}
+	case []any:
+		for _, child := range v {
+			scrubbed = s.scrubJSON(child) || scrubbed
+		}
+	}
+	return scrubbed
+}
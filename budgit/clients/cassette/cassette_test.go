@@ -0,0 +1,127 @@
+package cassette_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/andrewthowell/budgit/budgit/clients/cassette"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCassette(t *testing.T) {
+	suite.Run(t, new(cassetteSuite))
+}
+
+type cassetteSuite struct {
+	suite.Suite
+}
+
+func newEchoServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write([]byte(`{"path": "` + r.URL.Path + `", "access_token": "secret-token", "echo": "` + string(body) + `"}`))
+	}))
+}
+
+func (s *cassetteSuite) TestRecordAndReplay() {
+	server := newEchoServer()
+	path := filepath.Join(s.T().TempDir(), "cassette.json")
+
+	recordingCassette := &cassette.Cassette{}
+	recorder := cassette.NewRecorder(cassette.ModeRecord, recordingCassette, nil, nil)
+	client := &http.Client{Transport: recorder}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/accounts?b=2&a=1", nil)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	resp, err := client.Do(req)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"secret-refresh"}}
+	resp, err = client.PostForm(server.URL+"/token", form)
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().NoError(recordingCassette.Save(path))
+	server.Close()
+
+	s.Run("SecretsScrubbed", func() {
+		loaded, err := cassette.Load(path)
+		s.Require().NoError(err)
+		s.Require().Len(loaded.Interactions, 2)
+
+		accounts, token := loaded.Interactions[0], loaded.Interactions[1]
+		s.Equal("**REDACTED**", accounts.Request.Header.Get("Authorization"))
+		s.Equal("**REDACTED**", accounts.Response.Header.Get("Set-Cookie"))
+		s.NotContains(accounts.Response.Body, "secret-token")
+		s.NotContains(token.Request.Body, "secret-refresh")
+		s.Contains(token.Request.Body, "grant_type=refresh_token")
+	})
+
+	loaded, err := cassette.Load(path)
+	s.Require().NoError(err)
+	replayer := cassette.NewRecorder(cassette.ModeReplay, loaded, nil, nil)
+	client = &http.Client{Transport: replayer}
+
+	s.Run("ReplaysAgainstAnyHostAndQueryOrder", func() {
+		resp, err := client.Get("http://replay.invalid/accounts?a=1&b=2")
+		s.Require().NoError(err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		s.Require().NoError(err)
+
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal("application/json", resp.Header.Get("Content-Type"))
+		s.JSONEq(`{"path": "/accounts", "access_token": "**REDACTED**", "echo": ""}`, string(body))
+	})
+	s.Run("ScrubbedRequestBodyMatches", func() {
+		resp, err := client.PostForm("http://replay.invalid/token", url.Values{"grant_type": {"refresh_token"}, "refresh_token": {"another-secret"}})
+		s.Require().NoError(err)
+		resp.Body.Close()
+		s.Equal(http.StatusOK, resp.StatusCode)
+	})
+	s.Run("StrictModeFailsUnexpectedRequest", func() {
+		_, err := client.Get("http://replay.invalid/accounts?a=1&b=2")
+		s.ErrorAs(err, &cassette.UnexpectedRequestError{}, "expected each interaction to be replayed once")
+	})
+	s.Empty(replayer.Unused())
+}
+
+func (s *cassetteSuite) TestReplayOrRecord() {
+	server := newEchoServer()
+	defer server.Close()
+
+	c := &cassette.Cassette{}
+	recorder := cassette.NewRecorder(cassette.ModeReplayOrRecord, c, nil, nil)
+	client := &http.Client{Transport: recorder}
+
+	resp, err := client.Get(server.URL + "/new")
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Len(c.Interactions, 1)
+}
+
+func (s *cassetteSuite) TestReplacements() {
+	server := newEchoServer()
+	defer server.Close()
+
+	c := &cassette.Cassette{}
+	scrubber := cassette.DefaultScrubber()
+	scrubber.Replacements = map[string]string{"real-account-id": "account-1"}
+	client := &http.Client{Transport: cassette.NewRecorder(cassette.ModeRecord, c, nil, scrubber)}
+
+	resp, err := client.Get(server.URL + "/accounts/real-account-id")
+	s.Require().NoError(err)
+	resp.Body.Close()
+
+	s.Require().Len(c.Interactions, 1)
+	s.Equal("/accounts/account-1", c.Interactions[0].Request.URI)
+	s.NotContains(c.Interactions[0].Response.Body, "real-account-id")
+}
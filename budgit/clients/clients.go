@@ -5,3 +5,5 @@ import (
 )
 
 var ErrAccountNotFound = fmt.Errorf("the requested Account does not exist")
+
+var ErrAttachmentNotFound = fmt.Errorf("the requested Attachment does not exist")
@@ -0,0 +1,22 @@
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestClients(t *testing.T) {
+	suite.Run(t, new(clientsSuite))
+}
+
+type clientsSuite struct {
+	suite.Suite
+}
+
+func (s *clientsSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/integrations/monzo"
+	"go.uber.org/zap"
+)
+
+// MonzoIntegrationType is the type of the Monzo integration, see Registry.
+const MonzoIntegrationType = "monzo"
+
+// monzoPageSize is the maximum number of transactions Monzo returns in a page.
+const monzoPageSize = 100
+
+type MonzoClient struct {
+	log    *zap.SugaredLogger
+	id     string
+	client *monzo.ClientWithResponses
+}
+
+// MonzoTokenURL returns the URL of the OAuth token endpoint of the Monzo API at the given URL.
+func MonzoTokenURL(url string) string {
+	return strings.TrimSuffix(url, "/") + "/oauth2/token"
+}
+
+// NewMonzoClient returns a MonzoClient with the given integration ID for the Monzo API at the given URL.
+// The given http.Client is expected to authenticate requests, see NewHTTPClient.
+func NewMonzoClient(log *zap.SugaredLogger, id, url string, httpClient *http.Client) (*MonzoClient, error) {
+	log.Debugw("Starting Monzo client", zap.String("id", id), zap.String("url", url))
+
+	client, err := monzo.NewClientWithResponses(url, monzo.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("initialising Monzo client: %w", err)
+	}
+	return &MonzoClient{
+		log:    log,
+		id:     id,
+		client: client,
+	}, nil
+}
+
+func (c MonzoClient) ID() string { return c.id }
+
+// GetExternalAccounts returns the open Monzo accounts, followed by the pots of each.
+// Monzo reports a single balance for each, which is used as both the cleared and effective balance.
+func (c MonzoClient) GetExternalAccounts(ctx context.Context) ([]*budgit.ExternalAccount, error) {
+	c.log.Debug("Getting external Monzo accounts")
+
+	resp, err := c.client.ListAccountsWithResponse(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting Accounts: %w", err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.Accounts == nil {
+		return nil, fmt.Errorf("getting Accounts: %w", monzoResponseError(resp.HTTPResponse, resp.Body))
+	}
+	c.log.Debugw("Retrieved external Monzo accounts", zap.Int("number_of_accounts", len(*resp.JSON200.Accounts)))
+
+	accounts := make([]*budgit.ExternalAccount, 0, len(*resp.JSON200.Accounts))
+	pots := []*budgit.ExternalAccount{}
+	for _, account := range *resp.JSON200.Accounts {
+		if valueOrZero(account.Closed) {
+			continue
+		}
+		c.log.Debugw("Getting account balance of Monzo account", zap.String("account_id", account.Id))
+
+		balanceResp, err := c.client.GetBalanceWithResponse(ctx, &monzo.GetBalanceParams{AccountId: account.Id})
+		if err != nil {
+			return nil, fmt.Errorf("getting Accounts: %w", err)
+		}
+		if balanceResp.JSON200 == nil {
+			return nil, fmt.Errorf("getting Accounts: %w", monzoResponseError(balanceResp.HTTPResponse, balanceResp.Body))
+		}
+		accounts = append(accounts, &budgit.ExternalAccount{
+			ID:            account.Id,
+			Name:          monzoAccountName(account),
+			IntegrationID: c.id,
+			Balance:       monzoBalance(balanceResp.JSON200.Balance),
+		})
+
+		potsResp, err := c.client.ListPotsWithResponse(ctx, &monzo.ListPotsParams{CurrentAccountId: account.Id})
+		if err != nil {
+			return nil, fmt.Errorf("getting Pots: %w", err)
+		}
+		if potsResp.JSON200 == nil || potsResp.JSON200.Pots == nil {
+			return nil, fmt.Errorf("getting Pots: %w", monzoResponseError(potsResp.HTTPResponse, potsResp.Body))
+		}
+		for _, pot := range *potsResp.JSON200.Pots {
+			if valueOrZero(pot.Deleted) {
+				continue
+			}
+			pots = append(pots, &budgit.ExternalAccount{
+				ID:            pot.Id,
+				Name:          valueOrZero(pot.Name),
+				IntegrationID: c.id,
+				Balance:       monzoBalance(pot.Balance),
+			})
+		}
+	}
+	return slices.Concat(accounts, pots), nil
+}
+
+func (c MonzoClient) GetExternalAccount(ctx context.Context, externalID string) (*budgit.ExternalAccount, error) {
+	c.log.Debugw("Getting external Monzo account", zap.String("account_id", externalID))
+
+	accounts, err := c.GetExternalAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting Account %q: %w", externalID, err)
+	}
+	idx := slices.IndexFunc(accounts, func(a *budgit.ExternalAccount) bool {
+		return a.ID == externalID
+	})
+	if idx == -1 {
+		return nil, ErrAccountNotFound
+	}
+	return accounts[idx], nil
+}
+
+// GetExternalTransactions returns the transactions of a Monzo account created since the given time, oldest first.
+// Declined transactions are skipped. Monzo does not list the transactions of pots, so none are returned for them,
+// but deposits and withdrawals appear as transactions of the account the pot belongs to.
+func (c MonzoClient) GetExternalTransactions(ctx context.Context, externalAccountID string, since time.Time) ([]*budgit.ExternalTransaction, error) {
+	c.log.Debugw("Getting external Monzo transactions", zap.String("account_id", externalAccountID), zap.Time("since", since))
+
+	if strings.HasPrefix(externalAccountID, "pot_") {
+		return []*budgit.ExternalTransaction{}, nil
+	}
+
+	transactions := []*budgit.ExternalTransaction{}
+	cursor := since.UTC().Format(time.RFC3339)
+	for {
+		limit := int32(monzoPageSize)
+		resp, err := c.client.ListTransactionsWithResponse(ctx, &monzo.ListTransactionsParams{
+			AccountId: externalAccountID,
+			Since:     &cursor,
+			Limit:     &limit,
+			Expand:    &[]monzo.ListTransactionsParamsExpand{"merchant"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, err)
+		}
+		if resp.JSON200 == nil || resp.JSON200.Transactions == nil {
+			return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, monzoResponseError(resp.HTTPResponse, resp.Body))
+		}
+
+		page := *resp.JSON200.Transactions
+		for _, transaction := range page {
+			if transaction.DeclineReason != nil {
+				continue
+			}
+			transactions = append(transactions, &budgit.ExternalTransaction{
+				ID:                transaction.Id,
+				ExternalAccountID: externalAccountID,
+				IntegrationID:     c.id,
+				EffectiveDate:     transaction.Created,
+				PayeeName:         monzoPayeeName(transaction),
+				Reference:         valueOrZero(transaction.Description),
+				Memo:              valueOrZero(transaction.Notes),
+				Amount:            budgit.BalanceAmount(transaction.Amount),
+				Cleared:           valueOrZero(transaction.Settled) != "",
+			})
+		}
+		if len(page) < monzoPageSize {
+			return transactions, nil
+		}
+		// Monzo paginates by the ID of the last transaction of the previous page.
+		cursor = page[len(page)-1].Id
+	}
+}
+
+// monzoAccountName names a Monzo account by its type, then its owners and the last digits of its account number, so
+// that several accounts of one type can be told apart, e.g. "Joint Account (Alice & Bob, ending 5678)". Accounts with
+// neither are told apart by their description.
+func monzoAccountName(account monzo.Account) string {
+	name := "Current Account"
+	switch valueOrZero(account.Type) {
+	case monzo.UkRetailJoint:
+		name = "Joint Account"
+	case monzo.UkMonzoFlex:
+		name = "Flex"
+	}
+
+	details := []string{}
+	owners := []string{}
+	for _, owner := range valueOrZero(account.Owners) {
+		if ownerName := valueOrZero(owner.PreferredName); ownerName != "" {
+			owners = append(owners, ownerName)
+		}
+	}
+	if len(owners) != 0 {
+		details = append(details, strings.Join(owners, " & "))
+	}
+	if accountNumber := valueOrZero(account.AccountNumber); len(accountNumber) >= 4 {
+		details = append(details, "ending "+accountNumber[len(accountNumber)-4:])
+	}
+	if len(details) == 0 && valueOrZero(account.Description) != "" {
+		details = append(details, *account.Description)
+	}
+	if len(details) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(details, ", "))
+}
+
+func monzoBalance(minorUnits int64) budgit.Balance {
+	return budgit.Balance{
+		ClearedBalance:   budgit.BalanceAmount(minorUnits),
+		EffectiveBalance: budgit.BalanceAmount(minorUnits),
+	}
+}
+
+// monzoPayeeName returns the merchant or counterparty of a transaction, falling back to its description.
+func monzoPayeeName(transaction monzo.Transaction) string {
+	if transaction.Merchant != nil && valueOrZero(transaction.Merchant.Name) != "" {
+		return *transaction.Merchant.Name
+	}
+	if transaction.Counterparty != nil && valueOrZero(transaction.Counterparty.Name) != "" {
+		return *transaction.Counterparty.Name
+	}
+	return valueOrZero(transaction.Description)
+}
+
+// monzoResponseError returns the typed error for a Monzo response without the expected body.
+func monzoResponseError(resp *http.Response, body []byte) error {
+	errResp := monzo.ErrorResponse{}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return responseError(resp, "")
+	}
+	return responseError(resp, valueOrZero(errResp.Message))
+}
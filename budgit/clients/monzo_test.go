@@ -0,0 +1,186 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"github.com/andrewthowell/budgit/integrations/monzo"
+	"github.com/andrewthowell/budgit/integrations/monzo/monzofake"
+	"go.uber.org/zap"
+)
+
+func (s *clientsSuite) newFakeMonzoClient(fake *monzofake.Server, tokenSource clients.TokenSource) *clients.MonzoClient {
+	server := httptest.NewServer(fake)
+	s.T().Cleanup(server.Close)
+
+	client, err := clients.NewMonzoClient(zap.NewNop().Sugar(), "monzo", server.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), tokenSource))
+	s.Require().NoError(err)
+	return client
+}
+
+func (s *clientsSuite) TestMonzoGetExternalAccounts() {
+	fake := monzofake.New()
+	personal := fake.AddAccount(monzofake.Account{OwnerNames: []string{"Alice"}, AccountNumber: "12345678", Balance: 1000})
+	other := fake.AddAccount(monzofake.Account{OwnerNames: []string{"Bob"}, AccountNumber: "87654321"})
+	joint := fake.AddAccount(monzofake.Account{Type: monzo.UkRetailJoint, OwnerNames: []string{"Alice", "Bob"}, AccountNumber: "11115678", Balance: -50})
+	described := fake.AddAccount(monzofake.Account{Description: "user_0001"})
+	fake.AddAccount(monzofake.Account{Closed: true})
+	pots := fake.AddPots(personal.ID,
+		monzofake.Pot{Name: "Holiday", Balance: 500},
+		monzofake.Pot{Name: "Old", Deleted: true},
+	)
+	client := s.newFakeMonzoClient(fake, clients.NewStaticTokenSource("token"))
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ExternalAccount{
+		{
+			ID:            personal.ID,
+			Name:          "Current Account (Alice, ending 5678)",
+			IntegrationID: "monzo",
+			Balance:       budgit.Balance{ClearedBalance: 1000, EffectiveBalance: 1000},
+		},
+		{
+			ID:            other.ID,
+			Name:          "Current Account (Bob, ending 4321)",
+			IntegrationID: "monzo",
+		},
+		{
+			ID:            joint.ID,
+			Name:          "Joint Account (Alice & Bob, ending 5678)",
+			IntegrationID: "monzo",
+			Balance:       budgit.Balance{ClearedBalance: -50, EffectiveBalance: -50},
+		},
+		{
+			ID:            described.ID,
+			Name:          "Current Account (user_0001)",
+			IntegrationID: "monzo",
+		},
+		{
+			ID:            pots[0].ID,
+			Name:          "Holiday",
+			IntegrationID: "monzo",
+			Balance:       budgit.Balance{ClearedBalance: 500, EffectiveBalance: 500},
+		},
+	}, accounts)
+
+	s.Run("GetPot", func() {
+		account, err := client.GetExternalAccount(context.Background(), pots[0].ID)
+		s.Require().NoError(err)
+		s.Equal("Holiday", account.Name)
+	})
+	s.Run("NotFound", func() {
+		_, err := client.GetExternalAccount(context.Background(), pots[1].ID)
+		s.ErrorIs(err, clients.ErrAccountNotFound)
+	})
+}
+
+func (s *clientsSuite) TestMonzoGetExternalTransactions() {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	fake := monzofake.New()
+	personal := fake.AddAccount(monzofake.Account{})
+	pot := fake.AddPots(personal.ID, monzofake.Pot{Name: "Holiday"})[0]
+	transactions := fake.AddTransactions(personal.ID,
+		monzofake.Transaction{Amount: -320, MerchantName: "Pret", Description: "PRET A MANGER", Notes: "coffee", Created: now.AddDate(0, 0, -2), Settled: true},
+		monzofake.Transaction{Amount: -999, MerchantName: "Streaming", Created: now.AddDate(0, 0, -1), DeclineReason: "INSUFFICIENT_FUNDS"},
+		monzofake.Transaction{Amount: 1000, CounterpartyName: "Friend", Description: "DINNER", Created: now.AddDate(0, 0, -1)},
+	)
+	client := s.newFakeMonzoClient(fake, clients.NewStaticTokenSource("token"))
+
+	s.Run("DeclinedSkipped", func() {
+		got, err := client.GetExternalTransactions(context.Background(), personal.ID, now.AddDate(0, -1, 0))
+		s.Require().NoError(err)
+		s.CMPEqual([]*budgit.ExternalTransaction{
+			{
+				ID:                transactions[0].ID,
+				ExternalAccountID: personal.ID,
+				IntegrationID:     "monzo",
+				EffectiveDate:     now.AddDate(0, 0, -2),
+				PayeeName:         "Pret",
+				Reference:         "PRET A MANGER",
+				Memo:              "coffee",
+				Amount:            -320,
+				Cleared:           true,
+			},
+			{
+				ID:                transactions[2].ID,
+				ExternalAccountID: personal.ID,
+				IntegrationID:     "monzo",
+				EffectiveDate:     now.AddDate(0, 0, -1),
+				PayeeName:         "Friend",
+				Reference:         "DINNER",
+				Amount:            1000,
+			},
+		}, got)
+	})
+	s.Run("Paginated", func() {
+		many := make([]monzofake.Transaction, 0, 250)
+		for i := range 250 {
+			many = append(many, monzofake.Transaction{Amount: -1, Description: "PAGE", Created: now.Add(time.Duration(i) * time.Minute)})
+		}
+		fake.AddTransactions(personal.ID, many...)
+
+		got, err := client.GetExternalTransactions(context.Background(), personal.ID, now)
+		s.Require().NoError(err)
+		s.Len(got, 250)
+		s.Equal(many[249].ID, got[249].ID)
+	})
+	s.Run("Pot", func() {
+		got, err := client.GetExternalTransactions(context.Background(), pot.ID, now.AddDate(0, -1, 0))
+		s.Require().NoError(err)
+		s.Empty(got)
+	})
+	s.Run("AccountNotFound", func() {
+		_, err := client.GetExternalTransactions(context.Background(), "acc_unknown", now)
+		s.ErrorIs(err, clients.NotFoundError{Message: "account not found"})
+	})
+}
+
+func (s *clientsSuite) TestMonzoErrors() {
+	s.Run("Unauthorised", func() {
+		fake := monzofake.New()
+		fake.SetCredentials("access", "")
+		client := s.newFakeMonzoClient(fake, clients.NewStaticTokenSource("wrong"))
+
+		_, err := client.GetExternalAccounts(context.Background())
+		s.ErrorIs(err, clients.AuthError{StatusCode: http.StatusUnauthorized, Message: "The access token is invalid"})
+	})
+	s.Run("TransientFailureRetried", func() {
+		fake := monzofake.New()
+		fake.AddAccount(monzofake.Account{})
+		fake.InjectFailure(monzofake.Failure{PathPrefix: "/balance", StatusCode: http.StatusBadGateway, Times: 1})
+		client := s.newFakeMonzoClient(fake, clients.NewStaticTokenSource("token"))
+
+		accounts, err := client.GetExternalAccounts(context.Background())
+		s.Require().NoError(err)
+		s.Len(accounts, 1)
+	})
+}
+
+func (s *clientsSuite) TestMonzoOAuth() {
+	fake := monzofake.New()
+	fake.AddAccount(monzofake.Account{})
+	fake.SetCredentials("expired", "refresh-0")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := clients.NewFileTokenStore(filepath.Join(s.T().TempDir(), "token"), tokenStoreKey)
+	s.Require().NoError(err)
+	tokenSource := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: clients.MonzoTokenURL(server.URL)}, store, "refresh-0")
+
+	client, err := clients.NewMonzoClient(zap.NewNop().Sugar(), "monzo", server.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), tokenSource))
+	s.Require().NoError(err)
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.Len(accounts, 1)
+
+	token, err := store.LoadToken(context.Background())
+	s.Require().NoError(err)
+	s.Equal("fake-access-token-1", token.AccessToken)
+}
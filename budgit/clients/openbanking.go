@@ -0,0 +1,326 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/integrations/openbanking"
+	"go.uber.org/zap"
+)
+
+// OpenBankingIntegrationType is the type of the generic UK Open Banking integration, see Registry.
+const OpenBankingIntegrationType = "openbanking"
+
+// OpenBankingClient is an integration with any bank (ASPSP) implementing the UK Open Banking Account Information API.
+// Consent is expected to have been granted out of band, the resulting tokens being refreshed by the http.Client.
+type OpenBankingClient struct {
+	log         *zap.SugaredLogger
+	id          string
+	financialID *string
+	httpClient  *http.Client
+	client      *openbanking.ClientWithResponses
+}
+
+// NewOpenBankingClient returns an OpenBankingClient with the given integration ID for the Account Information API
+// at the given URL, e.g. https://bank.example/open-banking/v3.1/aisp. financialID is sent as x-fapi-financial-id
+// if not empty. The given http.Client is expected to authenticate requests, see NewHTTPClient.
+func NewOpenBankingClient(log *zap.SugaredLogger, id, url, financialID string, httpClient *http.Client) (*OpenBankingClient, error) {
+	log.Debugw("Starting Open Banking client", zap.String("id", id), zap.String("url", url))
+
+	client, err := openbanking.NewClientWithResponses(url, openbanking.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("initialising Open Banking client: %w", err)
+	}
+	c := &OpenBankingClient{
+		log:        log,
+		id:         id,
+		httpClient: httpClient,
+		client:     client,
+	}
+	if financialID != "" {
+		c.financialID = &financialID
+	}
+	return c, nil
+}
+
+func (c OpenBankingClient) ID() string { return c.id }
+
+// GetExternalAccounts returns the enabled accounts the consent grants access to, with their balances.
+func (c OpenBankingClient) GetExternalAccounts(ctx context.Context) ([]*budgit.ExternalAccount, error) {
+	c.log.Debug("Getting external Open Banking accounts")
+
+	resp, err := c.client.GetAccountsWithResponse(ctx, &openbanking.GetAccountsParams{XFapiFinancialId: c.financialID})
+	if err != nil {
+		return nil, fmt.Errorf("getting Accounts: %w", err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.Data.Account == nil {
+		return nil, fmt.Errorf("getting Accounts: %w", openBankingResponseError(resp.HTTPResponse, resp.Body))
+	}
+	c.log.Debugw("Retrieved external Open Banking accounts", zap.Int("number_of_accounts", len(*resp.JSON200.Data.Account)))
+
+	accounts := make([]*budgit.ExternalAccount, 0, len(*resp.JSON200.Data.Account))
+	for _, account := range *resp.JSON200.Data.Account {
+		if account.Status != nil && *account.Status != openbanking.OBAccountStatusEnabled {
+			continue
+		}
+		balance, err := c.getBalance(ctx, account.AccountId)
+		if err != nil {
+			return nil, fmt.Errorf("getting Accounts: %w", err)
+		}
+		accounts = append(accounts, &budgit.ExternalAccount{
+			ID:            account.AccountId,
+			Name:          openBankingAccountName(account),
+			IntegrationID: c.id,
+			Balance:       balance,
+		})
+	}
+	return accounts, nil
+}
+
+func (c OpenBankingClient) GetExternalAccount(ctx context.Context, externalID string) (*budgit.ExternalAccount, error) {
+	c.log.Debugw("Getting external Open Banking account", zap.String("account_id", externalID))
+
+	accounts, err := c.GetExternalAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting Account %q: %w", externalID, err)
+	}
+	idx := slices.IndexFunc(accounts, func(a *budgit.ExternalAccount) bool {
+		return a.ID == externalID
+	})
+	if idx == -1 {
+		return nil, ErrAccountNotFound
+	}
+	return accounts[idx], nil
+}
+
+// getBalance returns the balance of an account. The cleared balance is the booked balance, and the effective balance
+// is the available balance, falling back to the booked balance for banks which do not report one.
+func (c OpenBankingClient) getBalance(ctx context.Context, accountID string) (budgit.Balance, error) {
+	c.log.Debugw("Getting account balance of Open Banking account", zap.String("account_id", accountID))
+
+	resp, err := c.client.GetAccountBalancesWithResponse(ctx, accountID, &openbanking.GetAccountBalancesParams{XFapiFinancialId: c.financialID})
+	if err != nil {
+		return budgit.Balance{}, fmt.Errorf("getting Balance of Account %q: %w", accountID, err)
+	}
+	if resp.JSON200 == nil {
+		return budgit.Balance{}, fmt.Errorf("getting Balance of Account %q: %w", accountID, openBankingResponseError(resp.HTTPResponse, resp.Body))
+	}
+
+	var cleared, effective *budgit.BalanceAmount
+	for _, balance := range resp.JSON200.Data.Balance {
+		amount, err := openBankingAmount(balance.Amount, balance.CreditDebitIndicator)
+		if err != nil {
+			return budgit.Balance{}, fmt.Errorf("getting Balance of Account %q: %w", accountID, err)
+		}
+		switch balance.Type {
+		case openbanking.InterimBooked, openbanking.ClosingBooked:
+			if cleared == nil || balance.Type == openbanking.InterimBooked {
+				cleared = &amount
+			}
+		case openbanking.InterimAvailable, openbanking.ClosingAvailable, openbanking.Expected:
+			if effective == nil || balance.Type == openbanking.InterimAvailable {
+				effective = &amount
+			}
+		}
+	}
+	if cleared == nil {
+		return budgit.Balance{}, fmt.Errorf("getting Balance of Account %q: no booked balance was returned", accountID)
+	}
+	if effective == nil {
+		effective = cleared
+	}
+	return budgit.Balance{ClearedBalance: *cleared, EffectiveBalance: *effective}, nil
+}
+
+// GetExternalTransactions returns the transactions of an account booked since the given time, oldest first,
+// following the pages linked from each response. Rejected transactions are skipped.
+func (c OpenBankingClient) GetExternalTransactions(ctx context.Context, externalAccountID string, since time.Time) ([]*budgit.ExternalTransaction, error) {
+	c.log.Debugw("Getting external Open Banking transactions", zap.String("account_id", externalAccountID), zap.Time("since", since))
+
+	resp, err := c.client.GetAccountTransactionsWithResponse(ctx, externalAccountID, &openbanking.GetAccountTransactionsParams{
+		FromBookingDateTime: &since,
+		XFapiFinancialId:    c.financialID,
+	})
+	transactions := []*budgit.ExternalTransaction{}
+	for {
+		if err != nil {
+			return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, err)
+		}
+		if resp.JSON200 == nil {
+			return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, openBankingResponseError(resp.HTTPResponse, resp.Body))
+		}
+
+		for _, transaction := range valueOrZero(resp.JSON200.Data.Transaction) {
+			if transaction.Status == openbanking.OBTransactionStatusRejected {
+				continue
+			}
+			amount, err := openBankingAmount(transaction.Amount, transaction.CreditDebitIndicator)
+			if err != nil {
+				return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, err)
+			}
+			transactions = append(transactions, &budgit.ExternalTransaction{
+				ID:                valueOrZero(transaction.TransactionId),
+				ExternalAccountID: externalAccountID,
+				IntegrationID:     c.id,
+				EffectiveDate:     transaction.BookingDateTime,
+				PayeeName:         openBankingPayeeName(transaction),
+				Reference:         valueOrZero(transaction.TransactionReference),
+				Memo:              valueOrZero(transaction.TransactionInformation),
+				Amount:            amount,
+				Cleared:           transaction.Status == openbanking.OBTransactionStatusBooked,
+			})
+		}
+
+		if resp.JSON200.Links == nil || valueOrZero(resp.JSON200.Links.Next) == "" {
+			break
+		}
+		resp, err = c.getTransactionsPage(ctx, *resp.JSON200.Links.Next)
+	}
+
+	slices.SortStableFunc(transactions, func(a, b *budgit.ExternalTransaction) int {
+		return a.EffectiveDate.Compare(b.EffectiveDate)
+	})
+	return transactions, nil
+}
+
+// getTransactionsPage gets a page of transactions by the absolute URL linked from the previous page.
+func (c OpenBankingClient) getTransactionsPage(ctx context.Context, url string) (*openbanking.GetAccountTransactionsResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.financialID != nil {
+		req.Header.Set("x-fapi-financial-id", *c.financialID)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	return openbanking.ParseGetAccountTransactionsResponse(resp)
+}
+
+// GetScheduledPayments returns the active standing orders and direct debits of an account. Direct debits have no
+// schedule, so their amount is that of the previous payment, and they have no next date.
+func (c OpenBankingClient) GetScheduledPayments(ctx context.Context, externalAccountID string) ([]*budgit.ScheduledPayment, error) {
+	c.log.Debugw("Getting Open Banking standing orders and direct debits", zap.String("account_id", externalAccountID))
+
+	ordersResp, err := c.client.GetAccountStandingOrdersWithResponse(ctx, externalAccountID, &openbanking.GetAccountStandingOrdersParams{XFapiFinancialId: c.financialID})
+	if err != nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+	}
+	if ordersResp.JSON200 == nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, openBankingResponseError(ordersResp.HTTPResponse, ordersResp.Body))
+	}
+	debitsResp, err := c.client.GetAccountDirectDebitsWithResponse(ctx, externalAccountID, &openbanking.GetAccountDirectDebitsParams{XFapiFinancialId: c.financialID})
+	if err != nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+	}
+	if debitsResp.JSON200 == nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, openBankingResponseError(debitsResp.HTTPResponse, debitsResp.Body))
+	}
+
+	orders, debits := valueOrZero(ordersResp.JSON200.Data.StandingOrder), valueOrZero(debitsResp.JSON200.Data.DirectDebit)
+	payments := make([]*budgit.ScheduledPayment, 0, len(orders)+len(debits))
+	for _, order := range orders {
+		if order.StandingOrderId == nil || valueOrZero(order.StandingOrderStatusCode) == openbanking.OBStandingOrderStandingOrderStatusCodeInactive {
+			continue
+		}
+		payment := &budgit.ScheduledPayment{
+			ID:                *order.StandingOrderId,
+			ExternalAccountID: externalAccountID,
+			IntegrationID:     c.id,
+			Reference:         valueOrZero(order.Reference),
+			Frequency:         order.Frequency,
+			NextDate:          valueOrZero(order.NextPaymentDateTime),
+		}
+		if order.CreditorAccount != nil {
+			payment.PayeeName = valueOrZero(order.CreditorAccount.Name)
+		}
+		if order.NextPaymentAmount != nil {
+			// Standing orders always send money out of the account.
+			amount, err := budgit.ParseBalanceAmount(order.NextPaymentAmount.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+			}
+			payment.Amount = -amount
+		}
+		payments = append(payments, payment)
+	}
+	for _, debit := range debits {
+		if debit.DirectDebitId == nil || valueOrZero(debit.DirectDebitStatusCode) == openbanking.OBDirectDebitDirectDebitStatusCodeInactive {
+			continue
+		}
+		payment := &budgit.ScheduledPayment{
+			ID:                *debit.DirectDebitId,
+			ExternalAccountID: externalAccountID,
+			IntegrationID:     c.id,
+			PayeeName:         debit.Name,
+			Reference:         debit.MandateIdentification,
+		}
+		if debit.PreviousPaymentAmount != nil {
+			amount, err := budgit.ParseBalanceAmount(debit.PreviousPaymentAmount.Amount)
+			if err != nil {
+				return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+			}
+			payment.Amount = -amount
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
+
+// openBankingAmount returns the signed BalanceAmount of an unsigned Open Banking amount.
+func openBankingAmount(amount openbanking.OBActiveOrHistoricCurrencyAndAmount, indicator openbanking.OBCreditDebitCode) (budgit.BalanceAmount, error) {
+	parsed, err := budgit.ParseBalanceAmount(amount.Amount)
+	if err != nil {
+		return 0, err
+	}
+	if indicator == openbanking.Debit {
+		return -parsed, nil
+	}
+	return parsed, nil
+}
+
+func openBankingAccountName(account openbanking.OBAccount) string {
+	if valueOrZero(account.Nickname) != "" {
+		return *account.Nickname
+	}
+	for _, cashAccount := range valueOrZero(account.Account) {
+		if valueOrZero(cashAccount.Name) != "" {
+			return *cashAccount.Name
+		}
+	}
+	return valueOrZero(account.Description)
+}
+
+// openBankingPayeeName returns the merchant or counterparty of a transaction, falling back to its information.
+func openBankingPayeeName(transaction openbanking.OBTransaction) string {
+	if transaction.MerchantDetails != nil && valueOrZero(transaction.MerchantDetails.MerchantName) != "" {
+		return *transaction.MerchantDetails.MerchantName
+	}
+	counterparty := transaction.CreditorAccount
+	if transaction.CreditDebitIndicator == openbanking.Credit {
+		counterparty = transaction.DebtorAccount
+	}
+	if counterparty != nil && valueOrZero(counterparty.Name) != "" {
+		return *counterparty.Name
+	}
+	return valueOrZero(transaction.TransactionInformation)
+}
+
+// openBankingResponseError returns the typed error for an Open Banking response without the expected body.
+func openBankingResponseError(resp *http.Response, body []byte) error {
+	errResp := openbanking.OBErrorResponse{}
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return responseError(resp, "")
+	}
+	if len(errResp.Errors) > 0 {
+		return responseError(resp, errResp.Errors[0].Message)
+	}
+	return responseError(resp, valueOrZero(errResp.Message))
+}
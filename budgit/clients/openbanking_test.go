@@ -0,0 +1,166 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"github.com/andrewthowell/budgit/integrations/openbanking"
+	"github.com/andrewthowell/budgit/integrations/openbanking/openbankingfake"
+	"go.uber.org/zap"
+)
+
+func (s *clientsSuite) newFakeOpenBankingClient(fake *openbankingfake.Server, financialID string) *clients.OpenBankingClient {
+	server := httptest.NewServer(fake)
+	s.T().Cleanup(server.Close)
+
+	httpClient := clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), clients.NewStaticTokenSource("token"))
+	client, err := clients.NewOpenBankingClient(zap.NewNop().Sugar(), "bank", server.URL, financialID, httpClient)
+	s.Require().NoError(err)
+	return client
+}
+
+func (s *clientsSuite) TestOpenBankingGetExternalAccounts() {
+	fake := openbankingfake.New()
+	bills := fake.AddAccount(openbankingfake.Account{Nickname: "Bills", BookedBalance: 12345, AvailableBalance: 10045})
+	overdrawn := fake.AddAccount(openbankingfake.Account{Nickname: "Overdrawn", BookedBalance: -2050, AvailableBalance: -2050})
+	fake.AddAccount(openbankingfake.Account{Nickname: "Closed", Status: openbanking.OBAccountStatusDisabled})
+	client := s.newFakeOpenBankingClient(fake, "")
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ExternalAccount{
+		{
+			ID:            bills.ID,
+			Name:          "Bills",
+			IntegrationID: "bank",
+			Balance:       budgit.Balance{ClearedBalance: 12345, EffectiveBalance: 10045},
+		},
+		{
+			ID:            overdrawn.ID,
+			Name:          "Overdrawn",
+			IntegrationID: "bank",
+			Balance:       budgit.Balance{ClearedBalance: -2050, EffectiveBalance: -2050},
+		},
+	}, accounts)
+
+	s.Run("NotFound", func() {
+		_, err := client.GetExternalAccount(context.Background(), "unknown")
+		s.ErrorIs(err, clients.ErrAccountNotFound)
+	})
+}
+
+func (s *clientsSuite) TestOpenBankingGetExternalTransactions() {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	fake := openbankingfake.New()
+	fake.SetPageSize(2)
+	account := fake.AddAccount(openbankingfake.Account{Nickname: "Bills"})
+	transactions := fake.AddTransactions(account.ID,
+		openbankingfake.Transaction{Amount: -320, MerchantName: "Pret", Information: "CARD PAYMENT PRET", BookingTime: now.AddDate(0, 0, -3)},
+		openbankingfake.Transaction{Amount: 1000, CounterpartyName: "Friend", Reference: "DINNER", Information: "FPS FRIEND", BookingTime: now.AddDate(0, 0, -2)},
+		openbankingfake.Transaction{Amount: -95000, CounterpartyName: "Landlord", Reference: "RENT", BookingTime: now.AddDate(0, 0, -1), Pending: true},
+		openbankingfake.Transaction{Amount: -100, Information: "TOO OLD", BookingTime: now.AddDate(0, -2, 0)},
+	)
+	client := s.newFakeOpenBankingClient(fake, "")
+
+	got, err := client.GetExternalTransactions(context.Background(), account.ID, now.AddDate(0, -1, 0))
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ExternalTransaction{
+		{
+			ID:                transactions[0].ID,
+			ExternalAccountID: account.ID,
+			IntegrationID:     "bank",
+			EffectiveDate:     now.AddDate(0, 0, -3),
+			PayeeName:         "Pret",
+			Memo:              "CARD PAYMENT PRET",
+			Amount:            -320,
+			Cleared:           true,
+		},
+		{
+			ID:                transactions[1].ID,
+			ExternalAccountID: account.ID,
+			IntegrationID:     "bank",
+			EffectiveDate:     now.AddDate(0, 0, -2),
+			PayeeName:         "Friend",
+			Reference:         "DINNER",
+			Memo:              "FPS FRIEND",
+			Amount:            1000,
+			Cleared:           true,
+		},
+		{
+			ID:                transactions[2].ID,
+			ExternalAccountID: account.ID,
+			IntegrationID:     "bank",
+			EffectiveDate:     now.AddDate(0, 0, -1),
+			PayeeName:         "Landlord",
+			Reference:         "RENT",
+			Amount:            -95000,
+		},
+	}, got)
+
+	s.Run("AccountNotFound", func() {
+		_, err := client.GetExternalTransactions(context.Background(), "unknown", now)
+		s.ErrorIs(err, clients.NotFoundError{Message: "account not found"})
+	})
+}
+
+func (s *clientsSuite) TestOpenBankingGetScheduledPayments() {
+	next := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	fake := openbankingfake.New()
+	account := fake.AddAccount(openbankingfake.Account{Nickname: "Bills"})
+	orders := fake.AddStandingOrders(account.ID,
+		openbankingfake.StandingOrder{Reference: "RENT", CreditorName: "Landlord", Amount: 95000, NextPayment: next, Frequency: "IntrvlMnthDay:01:01"},
+		openbankingfake.StandingOrder{Reference: "GYM", Amount: 3000, Inactive: true},
+	)
+	debits := fake.AddDirectDebits(account.ID,
+		openbankingfake.DirectDebit{Mandate: "ENERGY-123", Name: "Energy Co", PreviousAmount: 5400, PreviousPayment: next.AddDate(0, 0, -20)},
+	)
+	client := s.newFakeOpenBankingClient(fake, "")
+
+	got, err := client.GetScheduledPayments(context.Background(), account.ID)
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ScheduledPayment{
+		{
+			ID:                orders[0].ID,
+			ExternalAccountID: account.ID,
+			IntegrationID:     "bank",
+			PayeeName:         "Landlord",
+			Reference:         "RENT",
+			Amount:            -95000,
+			Frequency:         "IntrvlMnthDay:01:01",
+			NextDate:          next,
+		},
+		{
+			ID:                debits[0].ID,
+			ExternalAccountID: account.ID,
+			IntegrationID:     "bank",
+			PayeeName:         "Energy Co",
+			Reference:         "ENERGY-123",
+			Amount:            -5400,
+		},
+	}, got)
+}
+
+func (s *clientsSuite) TestOpenBankingErrors() {
+	s.Run("FinancialIDSent", func() {
+		fake := openbankingfake.New()
+		fake.SetFinancialID("0015800001041RHAAY")
+		fake.SetPageSize(1)
+		account := fake.AddAccount(openbankingfake.Account{})
+		fake.AddTransactions(account.ID, openbankingfake.Transaction{Amount: 1}, openbankingfake.Transaction{Amount: 2})
+
+		_, err := s.newFakeOpenBankingClient(fake, "").GetExternalAccounts(context.Background())
+		s.ErrorIs(err, clients.AuthError{StatusCode: http.StatusForbidden, Message: "x-fapi-financial-id is invalid"})
+
+		transactions, err := s.newFakeOpenBankingClient(fake, "0015800001041RHAAY").GetExternalTransactions(context.Background(), account.ID, time.Time{})
+		s.Require().NoError(err)
+		s.Len(transactions, 2)
+	})
+	s.Run("OAuthRequiresTokenURL", func() {
+		_, err := clients.NewRegistry(zap.NewNop().Sugar()).Build(clients.IntegrationConfig{ID: "bank", Type: clients.OpenBankingIntegrationType, TokenStorePath: "token"})
+		s.Error(err)
+	})
+}
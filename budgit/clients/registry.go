@@ -0,0 +1,165 @@
+package clients
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"go.uber.org/zap"
+	"golang.org/x/exp/maps"
+)
+
+// IntegrationConfig configures a single Integration. Several Integrations of the same Type may be configured,
+// e.g. for multiple Starling tokens, as long as each has its own ID.
+type IntegrationConfig struct {
+	ID   string
+	Type string
+	URL  string
+	// APIToken is a personal access token. It is used when TokenStorePath is not set.
+	APIToken string
+	// Fields concerning OAuth.
+	ClientID       string
+	ClientSecret   string
+	RefreshToken   string
+	TokenStorePath string
+	TokenStoreKey  []byte
+	// TokenURL overrides the OAuth token endpoint registered for the Type.
+	TokenURL string
+	// FinancialID is the x-fapi-financial-id of the bank of an Open Banking integration, sent if set.
+	FinancialID string
+	// Transport defaults to DefaultTransportConfig.
+	Transport *TransportConfig
+}
+
+// Factory builds an Integration from its config, sending requests with the given http.Client,
+// which authenticates them using the configured credentials.
+type Factory func(log *zap.SugaredLogger, config IntegrationConfig, httpClient *http.Client) (svc.Integration, error)
+
+// Registry builds Integrations from config, using the Factory registered for each type of Integration.
+type Registry struct {
+	log       *zap.SugaredLogger
+	factories map[string]registeredFactory
+}
+
+type registeredFactory struct {
+	factory Factory
+	// tokenURL returns the OAuth token endpoint of an integration at a URL, or nil if it does not support OAuth.
+	tokenURL func(url string) string
+}
+
+// NewRegistry returns a Registry with every integration in this package registered.
+func NewRegistry(log *zap.SugaredLogger) *Registry {
+	r := &Registry{
+		log:       log,
+		factories: map[string]registeredFactory{},
+	}
+	r.Register(StarlingIntegrationType, newStarlingIntegration, StarlingTokenURL)
+	r.Register(MonzoIntegrationType, newMonzoIntegration, MonzoTokenURL)
+	// Open Banking token endpoints differ between banks, so must be configured with IntegrationConfig.TokenURL.
+	r.Register(OpenBankingIntegrationType, newOpenBankingIntegration, nil)
+	return r
+}
+
+// Register registers the Factory for a type of Integration, replacing any already registered.
+// tokenURL returns the OAuth token endpoint of an integration at a URL, and may be nil if OAuth is not supported.
+func (r *Registry) Register(integrationType string, factory Factory, tokenURL func(url string) string) {
+	r.factories[integrationType] = registeredFactory{factory: factory, tokenURL: tokenURL}
+}
+
+// Types returns the registered types of Integration, sorted.
+func (r *Registry) Types() []string {
+	types := maps.Keys(r.factories)
+	slices.Sort(types)
+	return types
+}
+
+type UnknownIntegrationTypeError struct {
+	ID, Type string
+}
+
+func (e UnknownIntegrationTypeError) Error() string {
+	return fmt.Sprintf("integration %q has unknown type %q", e.ID, e.Type)
+}
+
+type DuplicateIntegrationIDError struct {
+	ID string
+}
+
+func (e DuplicateIntegrationIDError) Error() string {
+	return fmt.Sprintf("more than one integration is configured with ID %q", e.ID)
+}
+
+// Build returns an Integration for each config.
+func (r *Registry) Build(configs ...IntegrationConfig) ([]svc.Integration, error) {
+	integrations := make([]svc.Integration, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if config.ID == "" {
+			return nil, fmt.Errorf("building integrations: integration of type %q has no ID", config.Type)
+		}
+		if seen[config.ID] {
+			return nil, fmt.Errorf("building integrations: %w", DuplicateIntegrationIDError{ID: config.ID})
+		}
+		seen[config.ID] = true
+
+		registered, ok := r.factories[config.Type]
+		if !ok {
+			return nil, fmt.Errorf("building integrations: %w", UnknownIntegrationTypeError{ID: config.ID, Type: config.Type})
+		}
+
+		log := r.log.With(zap.String("integration_id", config.ID))
+		tokenSource, err := newTokenSource(log, config, registered.tokenURL)
+		if err != nil {
+			return nil, fmt.Errorf("building integration %q: %w", config.ID, err)
+		}
+		transport := DefaultTransportConfig()
+		if config.Transport != nil {
+			transport = *config.Transport
+		}
+
+		integration, err := registered.factory(log, config, NewHTTPClient(log, transport, tokenSource))
+		if err != nil {
+			return nil, fmt.Errorf("building integration %q: %w", config.ID, err)
+		}
+		integrations = append(integrations, integration)
+	}
+	return integrations, nil
+}
+
+// newTokenSource returns the TokenSource for an integration, using OAuth if a token store is configured.
+// The configured TokenURL takes precedence over the token endpoint registered for the integration's type.
+func newTokenSource(log *zap.SugaredLogger, config IntegrationConfig, tokenURL func(url string) string) (TokenSource, error) {
+	if config.TokenStorePath == "" {
+		return NewStaticTokenSource(config.APIToken), nil
+	}
+	url := config.TokenURL
+	if url == "" {
+		if tokenURL == nil {
+			return nil, fmt.Errorf("integration type %q does not support OAuth without a configured token URL", config.Type)
+		}
+		url = tokenURL(config.URL)
+	}
+
+	store, err := NewFileTokenStore(config.TokenStorePath, config.TokenStoreKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewOAuthTokenSource(log, OAuthConfig{
+		TokenURL:     url,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+	}, store, config.RefreshToken), nil
+}
+
+func newStarlingIntegration(log *zap.SugaredLogger, config IntegrationConfig, httpClient *http.Client) (svc.Integration, error) {
+	return NewStarlingClient(log, config.ID, config.URL, httpClient)
+}
+
+func newMonzoIntegration(log *zap.SugaredLogger, config IntegrationConfig, httpClient *http.Client) (svc.Integration, error) {
+	return NewMonzoClient(log, config.ID, config.URL, httpClient)
+}
+
+func newOpenBankingIntegration(log *zap.SugaredLogger, config IntegrationConfig, httpClient *http.Client) (svc.Integration, error) {
+	return NewOpenBankingClient(log, config.ID, config.URL, config.FinancialID, httpClient)
+}
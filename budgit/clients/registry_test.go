@@ -0,0 +1,73 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"go.uber.org/zap"
+)
+
+func (s *clientsSuite) TestRegistryBuild() {
+	personal, joint := starlingfake.New(), starlingfake.New()
+	personal.AddAccount(starlingfake.Account{Name: "Personal"})
+	joint.AddAccount(starlingfake.Account{Name: "Joint"})
+	joint.SetCredentials("joint-token", "")
+	personalServer, jointServer := httptest.NewServer(personal), httptest.NewServer(joint)
+	defer personalServer.Close()
+	defer jointServer.Close()
+
+	transport := testTransportConfig()
+	registry := clients.NewRegistry(zap.NewNop().Sugar())
+	s.Equal([]string{"monzo", "openbanking", "starling"}, registry.Types())
+
+	s.Run("SeveralOfOneType", func() {
+		integrations, err := registry.Build(
+			clients.IntegrationConfig{ID: "starling_personal", Type: "starling", URL: personalServer.URL, APIToken: "token", Transport: &transport},
+			clients.IntegrationConfig{ID: "starling_joint", Type: "starling", URL: jointServer.URL, APIToken: "joint-token", Transport: &transport},
+		)
+		s.Require().NoError(err)
+		s.Require().Len(integrations, 2)
+
+		for i, expected := range []struct{ id, name string }{{"starling_personal", "Personal"}, {"starling_joint", "Joint"}} {
+			s.Equal(expected.id, integrations[i].ID())
+			s.Implements((*svc.TransactionImporter)(nil), integrations[i])
+			s.Implements((*svc.ScheduledPaymentLister)(nil), integrations[i])
+			s.Implements((*svc.TransactionWriter)(nil), integrations[i])
+			s.Implements((*svc.AttachmentImporter)(nil), integrations[i])
+
+			accounts, err := integrations[i].GetExternalAccounts(context.Background())
+			s.Require().NoError(err)
+			s.Require().Len(accounts, 1)
+			s.Equal(expected.name, accounts[0].Name)
+			s.Equal(expected.id, accounts[0].IntegrationID)
+		}
+	})
+	s.Run("DuplicateID", func() {
+		_, err := registry.Build(
+			clients.IntegrationConfig{ID: "starling", Type: "starling", URL: personalServer.URL},
+			clients.IntegrationConfig{ID: "starling", Type: "starling", URL: jointServer.URL},
+		)
+		s.ErrorIs(err, clients.DuplicateIntegrationIDError{ID: "starling"})
+	})
+	s.Run("UnknownType", func() {
+		_, err := registry.Build(clients.IntegrationConfig{ID: "barclays", Type: "barclays"})
+		s.ErrorIs(err, clients.UnknownIntegrationTypeError{ID: "barclays", Type: "barclays"})
+	})
+	s.Run("RegisteredType", func() {
+		registry := clients.NewRegistry(zap.NewNop().Sugar())
+		registry.Register("custom", func(log *zap.SugaredLogger, config clients.IntegrationConfig, httpClient *http.Client) (svc.Integration, error) {
+			return clients.NewStarlingClient(log, config.ID, config.URL, httpClient)
+		}, nil)
+
+		integrations, err := registry.Build(clients.IntegrationConfig{ID: "mine", Type: "custom", URL: personalServer.URL, APIToken: "token"})
+		s.Require().NoError(err)
+		s.Equal("mine", integrations[0].ID())
+
+		_, err = registry.Build(clients.IntegrationConfig{ID: "mine", Type: "custom", URL: personalServer.URL, TokenStorePath: "token"})
+		s.Error(err, "expected OAuth to be rejected for a type without a token URL")
+	})
+}
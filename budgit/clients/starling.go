@@ -1,44 +1,55 @@
 package clients
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/andrewthowell/budgit/budgit"
 	"github.com/andrewthowell/budgit/integrations/starling"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-const starlingIntegrationID = "starling"
+// StarlingIntegrationType is the type of the Starling integration, see Registry.
+const StarlingIntegrationType = "starling"
 
 type Client struct {
 	log    *zap.SugaredLogger
+	id     string
 	client *starling.ClientWithResponses
 }
 
-func NewStarlingClient(log *zap.SugaredLogger, url, apiToken string) (*Client, error) {
-	log.Debugw("Starting Starling client", zap.String("url", url))
+// StarlingTokenURL returns the URL of the OAuth token endpoint of the Starling API at the given URL.
+func StarlingTokenURL(url string) string {
+	return strings.TrimSuffix(url, "/") + "/oauth/access-token"
+}
 
-	client, err := starling.NewClientWithResponses(
-		url,
-		starling.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
-			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", apiToken))
-			return nil
-		}),
-	)
+// NewStarlingClient returns a Client with the given integration ID for the Starling API at the given URL.
+// The given http.Client is expected to authenticate requests, see NewHTTPClient.
+func NewStarlingClient(log *zap.SugaredLogger, id, url string, httpClient *http.Client) (*Client, error) {
+	log.Debugw("Starting Starling client", zap.String("id", id), zap.String("url", url))
+
+	client, err := starling.NewClientWithResponses(url, starling.WithHTTPClient(httpClient))
 	if err != nil {
 		return nil, fmt.Errorf("initialising Starling client: %w", err)
 	}
 	return &Client{
 		log:    log,
+		id:     id,
 		client: client,
 	}, nil
 }
 
-func (c Client) ID() string { return starlingIntegrationID }
+func (c Client) ID() string { return c.id }
 
 func (c Client) GetExternalAccounts(ctx context.Context) ([]*budgit.ExternalAccount, error) {
 	c.log.Debug("Getting external Starling accounts")
@@ -47,28 +58,32 @@ func (c Client) GetExternalAccounts(ctx context.Context) ([]*budgit.ExternalAcco
 	if err != nil {
 		return nil, fmt.Errorf("getting Accounts: %w", err)
 	}
-	if resp.JSON4XX != nil {
-		return nil, fmt.Errorf("getting Accounts: %w", format4XXError(resp.JSON4XX))
+	if resp.JSON200 == nil || resp.JSON200.Accounts == nil {
+		return nil, fmt.Errorf("getting Accounts: %w", starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
 	}
 	c.log.Debugw("Retrieved external Starling accounts", zap.Int("number_of_accounts", len(*resp.JSON200.Accounts)))
 
 	accounts := make([]*budgit.ExternalAccount, 0, len(*resp.JSON200.Accounts))
 	for _, account := range *resp.JSON200.Accounts {
+		if account.AccountUid == nil {
+			continue
+		}
 		c.log.Debugw("Getting account balance of Starling account",
 			zap.String("account_id", account.AccountUid.String()),
-			zap.String("name", *account.Name),
+			zap.Stringp("name", account.Name),
 		)
 
 		resp, err := c.client.GetAccountBalanceWithResponse(ctx, *account.AccountUid)
 		if err != nil {
 			return nil, fmt.Errorf("getting Accounts: %w", err)
 		}
-		if resp.JSON4XX != nil {
-			return nil, fmt.Errorf("getting Accounts: %w", format4XXError(resp.JSON4XX))
+		if resp.JSON200 == nil || resp.JSON200.TotalClearedBalance == nil || resp.JSON200.TotalEffectiveBalance == nil {
+			return nil, fmt.Errorf("getting Accounts: %w", starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
 		}
 		accounts = append(accounts, &budgit.ExternalAccount{
 			ID:            account.AccountUid.String(),
-			IntegrationID: starlingIntegrationID,
+			Name:          valueOrZero(account.Name),
+			IntegrationID: c.id,
 			Balance: budgit.Balance{
 				ClearedBalance:   budgit.BalanceAmount(resp.JSON200.TotalClearedBalance.MinorUnits),
 				EffectiveBalance: budgit.BalanceAmount(resp.JSON200.TotalEffectiveBalance.MinorUnits),
@@ -94,11 +109,318 @@ func (c Client) GetExternalAccount(ctx context.Context, externalID string) (*bud
 	return accounts[idx], nil
 }
 
+// defaultCategory returns the UID of the account and its default category, which holds its main balance and feed.
+func (c Client) defaultCategory(ctx context.Context, externalAccountID string) (uuid.UUID, uuid.UUID, error) {
+	resp, err := c.client.GetAccountsWithResponse(ctx)
+	if err != nil {
+		return uuid.UUID{}, uuid.UUID{}, err
+	}
+	if resp.JSON200 == nil || resp.JSON200.Accounts == nil {
+		return uuid.UUID{}, uuid.UUID{}, starlingResponseError(resp.HTTPResponse, resp.JSON4XX)
+	}
+	for _, account := range *resp.JSON200.Accounts {
+		if account.AccountUid != nil && account.DefaultCategory != nil && account.AccountUid.String() == externalAccountID {
+			return *account.AccountUid, *account.DefaultCategory, nil
+		}
+	}
+	return uuid.UUID{}, uuid.UUID{}, ErrAccountNotFound
+}
+
+func (c Client) GetExternalTransactions(ctx context.Context, externalAccountID string, since time.Time) ([]*budgit.ExternalTransaction, error) {
+	c.log.Debugw("Getting external Starling transactions", zap.String("account_id", externalAccountID), zap.Time("since", since))
+
+	accountUID, categoryUID, err := c.defaultCategory(ctx, externalAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, err)
+	}
+	resp, err := c.client.QueryFeedItemsWithResponse(ctx, accountUID, categoryUID, &starling.QueryFeedItemsParams{ChangesSince: since})
+	if err != nil {
+		return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.FeedItems == nil {
+		return nil, fmt.Errorf("getting Transactions of Account %q: %w", externalAccountID, starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+	}
+
+	transactions := make([]*budgit.ExternalTransaction, 0, len(*resp.JSON200.FeedItems))
+	for _, item := range *resp.JSON200.FeedItems {
+		if item.FeedItemUid == nil || item.Amount == nil || item.TransactionTime == nil {
+			continue
+		}
+		switch valueOrZero(item.Status) {
+		case starling.FeedItemStatusDECLINED, starling.FeedItemStatusACCOUNTCHECK,
+			starling.FeedItemStatusUPCOMING, starling.FeedItemStatusUPCOMINGCANCELLED:
+			// These never moved money.
+			continue
+		}
+		amount := budgit.BalanceAmount(item.Amount.MinorUnits)
+		if valueOrZero(item.Direction) == starling.FeedItemDirectionOUT {
+			amount = -amount
+		}
+		transactions = append(transactions, &budgit.ExternalTransaction{
+			ID:                item.FeedItemUid.String(),
+			ExternalAccountID: externalAccountID,
+			IntegrationID:     c.id,
+			EffectiveDate:     *item.TransactionTime,
+			PayeeName:         valueOrZero(item.CounterPartyName),
+			Reference:         valueOrZero(item.Reference),
+			Memo:              valueOrZero(item.UserNote),
+			Amount:            amount,
+			Cleared:           valueOrZero(item.Status) == starling.FeedItemStatusSETTLED,
+		})
+	}
+	return transactions, nil
+}
+
+func (c Client) GetScheduledPayments(ctx context.Context, externalAccountID string) ([]*budgit.ScheduledPayment, error) {
+	c.log.Debugw("Getting Starling standing orders", zap.String("account_id", externalAccountID))
+
+	accountUID, categoryUID, err := c.defaultCategory(ctx, externalAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+	}
+	resp, err := c.client.ListStandingOrdersWithResponse(ctx, accountUID, categoryUID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, err)
+	}
+	if resp.JSON200 == nil || resp.JSON200.StandingOrders == nil {
+		return nil, fmt.Errorf("getting Scheduled Payments of Account %q: %w", externalAccountID, starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+	}
+
+	payments := make([]*budgit.ScheduledPayment, 0, len(*resp.JSON200.StandingOrders))
+	for _, order := range *resp.JSON200.StandingOrders {
+		if order.PaymentOrderUid == nil || order.Amount == nil || order.CancelledAt != nil {
+			continue
+		}
+		payment := &budgit.ScheduledPayment{
+			ID:                order.PaymentOrderUid.String(),
+			ExternalAccountID: externalAccountID,
+			IntegrationID:     c.id,
+			Reference:         valueOrZero(order.Reference),
+			// Standing orders always send money out of the account.
+			Amount: -budgit.BalanceAmount(order.Amount.MinorUnits),
+		}
+		if order.NextDate != nil {
+			payment.NextDate = order.NextDate.Time
+		}
+		if order.StandingOrderRecurrence != nil {
+			payment.Frequency = string(order.StandingOrderRecurrence.Frequency)
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
+
+// UpdateExternalTransaction writes the memo of a transaction to its Starling user note, and its category to its
+// Starling spending category. Categories are matched to spending categories by name, e.g. "Eating out" to EATING_OUT,
+// and categories which match none are not written back.
+func (c Client) UpdateExternalTransaction(ctx context.Context, externalAccountID, externalTransactionID string, update budgit.ExternalTransactionUpdate) error {
+	c.log.Debugw("Updating Starling transaction", zap.String("account_id", externalAccountID), zap.String("transaction_id", externalTransactionID))
+
+	feedItemUID, err := uuid.Parse(externalTransactionID)
+	if err != nil {
+		return fmt.Errorf("updating Transaction %q: %w", externalTransactionID, err)
+	}
+	accountUID, categoryUID, err := c.defaultCategory(ctx, externalAccountID)
+	if err != nil {
+		return fmt.Errorf("updating Transaction %q: %w", externalTransactionID, err)
+	}
+
+	if update.Memo != nil {
+		resp, err := c.client.UpdateUserNoteWithResponse(ctx, accountUID, categoryUID, feedItemUID, starling.UserNoteWrapper{UserNote: *update.Memo})
+		if err != nil {
+			return fmt.Errorf("updating user note of Transaction %q: %w", externalTransactionID, err)
+		}
+		if resp.StatusCode()/100 != 2 {
+			return fmt.Errorf("updating user note of Transaction %q: %w", externalTransactionID, starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+		}
+	}
+	if update.Category != nil {
+		spendingCategory, ok, err := starlingSpendingCategory(*update.Category)
+		if err != nil {
+			return fmt.Errorf("updating spending category of Transaction %q: %w", externalTransactionID, err)
+		}
+		if !ok {
+			// Starling only accepts its own spending categories, so categories without one are left unchanged.
+			c.log.Warnw("Not writing back category with no Starling spending category", zap.String("transaction_id", externalTransactionID), zap.String("category", *update.Category))
+			return nil
+		}
+		resp, err := c.client.ChangeTransactionCategoryWithResponse(ctx, accountUID, categoryUID, feedItemUID, starling.UpdateSpendingCategory{
+			SpendingCategory: spendingCategory,
+		})
+		if err != nil {
+			return fmt.Errorf("updating spending category of Transaction %q: %w", externalTransactionID, err)
+		}
+		if resp.StatusCode()/100 != 2 {
+			return fmt.Errorf("updating spending category of Transaction %q: %w", externalTransactionID, starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+		}
+	}
+	return nil
+}
+
+// starlingSpendingCategories are the spending categories Starling accepts, the enum of the spendingCategory of its
+// UpdateSpendingCategory schema. Starling rejects any other with 400 Bad Request.
+var starlingSpendingCategories = sync.OnceValues(func() ([]any, error) {
+	spec, err := starling.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("loading Starling API specification: %w", err)
+	}
+	return spec.Components.Schemas["UpdateSpendingCategory"].Value.Properties["spendingCategory"].Value.Enum, nil
+})
+
+// starlingSpendingCategory returns the Starling spending category named by a category, e.g. EATING_OUT for "Eating out",
+// or false if Starling has no spending category of that name, e.g. for "Groceries:Weekly".
+func starlingSpendingCategory(category string) (starling.UpdateSpendingCategorySpendingCategory, bool, error) {
+	spendingCategories, err := starlingSpendingCategories()
+	if err != nil {
+		return "", false, err
+	}
+	spendingCategory := strings.ToUpper(strings.Join(strings.Fields(category), "_"))
+	return starling.UpdateSpendingCategorySpendingCategory(spendingCategory), slices.Contains(spendingCategories, any(spendingCategory)), nil
+}
+
+// starlingReceiptPrefix prefixes the IDs of receipts listed as attachments, to distinguish them from files.
+const starlingReceiptPrefix = "receipt-"
+
+// GetExternalAttachments returns the files attached to a Starling transaction, followed by its itemised receipts,
+// which are downloaded as JSON.
+func (c Client) GetExternalAttachments(ctx context.Context, externalAccountID, externalTransactionID string) ([]*budgit.ExternalAttachment, error) {
+	c.log.Debugw("Getting Starling attachments", zap.String("account_id", externalAccountID), zap.String("transaction_id", externalTransactionID))
+
+	feedItemUID, err := uuid.Parse(externalTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Attachments of Transaction %q: %w", externalTransactionID, err)
+	}
+	accountUID, categoryUID, err := c.defaultCategory(ctx, externalAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Attachments of Transaction %q: %w", externalTransactionID, err)
+	}
+
+	attachmentsResp, err := c.client.GetFeedItemAttachmentsWithResponse(ctx, accountUID, categoryUID, feedItemUID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Attachments of Transaction %q: %w", externalTransactionID, err)
+	}
+	if attachmentsResp.JSON200 == nil {
+		return nil, fmt.Errorf("getting Attachments of Transaction %q: %w", externalTransactionID, starlingResponseError(attachmentsResp.HTTPResponse, attachmentsResp.JSON4XX))
+	}
+	receipts, err := c.getReceipts(ctx, accountUID, categoryUID, feedItemUID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Attachments of Transaction %q: %w", externalTransactionID, err)
+	}
+
+	attachments := []*budgit.ExternalAttachment{}
+	for _, attachment := range valueOrZero(attachmentsResp.JSON200.FeedItemAttachments) {
+		if attachment.FeedItemAttachmentUid == nil {
+			continue
+		}
+		externalAttachment := &budgit.ExternalAttachment{
+			ID:                    attachment.FeedItemAttachmentUid.String(),
+			ExternalTransactionID: externalTransactionID,
+			Name:                  "attachment-" + attachment.FeedItemAttachmentUid.String(),
+		}
+		if valueOrZero(attachment.FeedItemAttachmentType) == starling.PDF {
+			externalAttachment.Name += ".pdf"
+			externalAttachment.ContentType = "application/pdf"
+		}
+		attachments = append(attachments, externalAttachment)
+	}
+	for _, receipt := range receipts {
+		if receipt.ReceiptUid == nil {
+			continue
+		}
+		attachments = append(attachments, &budgit.ExternalAttachment{
+			ID:                    starlingReceiptPrefix + receipt.ReceiptUid.String(),
+			ExternalTransactionID: externalTransactionID,
+			Name:                  fmt.Sprintf("receipt-%s.json", receipt.ReceiptUid),
+			ContentType:           "application/json",
+		})
+	}
+	return attachments, nil
+}
+
+// DownloadExternalAttachment returns the content of a file attached to a Starling transaction, or of its receipt.
+func (c Client) DownloadExternalAttachment(ctx context.Context, externalAccountID string, attachment *budgit.ExternalAttachment) (io.ReadCloser, string, error) {
+	c.log.Debugw("Downloading Starling attachment", zap.String("account_id", externalAccountID), zap.String("attachment_id", attachment.ID))
+
+	feedItemUID, err := uuid.Parse(attachment.ExternalTransactionID)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+	}
+	accountUID, categoryUID, err := c.defaultCategory(ctx, externalAccountID)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+	}
+
+	if receiptUID, ok := strings.CutPrefix(attachment.ID, starlingReceiptPrefix); ok {
+		receipts, err := c.getReceipts(ctx, accountUID, categoryUID, feedItemUID)
+		if err != nil {
+			return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+		}
+		idx := slices.IndexFunc(receipts, func(r starling.Receipt) bool {
+			return r.ReceiptUid != nil && r.ReceiptUid.String() == receiptUID
+		})
+		if idx == -1 {
+			return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, ErrAttachmentNotFound)
+		}
+		content, err := json.MarshalIndent(receipts[idx], "", "  ")
+		if err != nil {
+			return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+		}
+		return io.NopCloser(bytes.NewReader(content)), "application/json", nil
+	}
+
+	attachmentUID, err := uuid.Parse(attachment.ID)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+	}
+	resp, err := c.client.DownloadFeedItemAttachmentWithResponse(ctx, accountUID, categoryUID, feedItemUID, attachmentUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return nil, "", fmt.Errorf("downloading Attachment %q: %w", attachment.ID, starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+	}
+	return io.NopCloser(bytes.NewReader(resp.Body)), resp.HTTPResponse.Header.Get("Content-Type"), nil
+}
+
+func (c Client) getReceipts(ctx context.Context, accountUID, categoryUID, feedItemUID uuid.UUID) ([]starling.Receipt, error) {
+	resp, err := c.client.GetReceiptsWithResponse(ctx, accountUID, categoryUID, feedItemUID)
+	if err != nil {
+		return nil, fmt.Errorf("getting Receipts: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("getting Receipts: %w", starlingResponseError(resp.HTTPResponse, resp.JSON4XX))
+	}
+	return *resp.JSON200, nil
+}
+
+// starlingResponseError returns the typed error for a Starling response without the expected body.
+func starlingResponseError(resp *http.Response, errResp *starling.ErrorResponse) error {
+	message := ""
+	if errResp != nil {
+		if err := format4XXError(errResp); err != nil {
+			message = err.Error()
+		}
+	}
+	return responseError(resp, message)
+}
+
 func format4XXError(errResp *starling.ErrorResponse) error {
+	if errResp.Errors == nil {
+		return nil
+	}
 	errs := make([]error, 0, len(*errResp.Errors))
 	for _, errDetail := range *errResp.Errors {
-		msg := *errDetail.Message
-		errs = append(errs, fmt.Errorf(msg))
+		if errDetail.Message != nil {
+			errs = append(errs, errors.New(*errDetail.Message))
+		}
 	}
 	return errors.Join(errs...)
 }
+
+func valueOrZero[T any](ptr *T) T {
+	var zero T
+	if ptr == nil {
+		return zero
+	}
+	return *ptr
+}
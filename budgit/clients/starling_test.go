@@ -0,0 +1,287 @@
+package clients_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+func (s *clientsSuite) newFakeStarlingClient(fake *starlingfake.Server, tokenSource clients.TokenSource) *clients.Client {
+	server := httptest.NewServer(fake)
+	s.T().Cleanup(server.Close)
+
+	client, err := clients.NewStarlingClient(zap.NewNop().Sugar(), "starling", server.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), tokenSource))
+	s.Require().NoError(err)
+	return client
+}
+
+func (s *clientsSuite) TestStarlingGetExternalAccounts() {
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal", ClearedBalance: 100, EffectiveBalance: 90})
+	joint := fake.AddAccount(starlingfake.Account{Name: "Joint", ClearedBalance: -5, EffectiveBalance: -5})
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ExternalAccount{
+		{
+			ID:            personal.UID.String(),
+			Name:          "Personal",
+			IntegrationID: "starling",
+			Balance:       budgit.Balance{ClearedBalance: 100, EffectiveBalance: 90},
+		},
+		{
+			ID:            joint.UID.String(),
+			Name:          "Joint",
+			IntegrationID: "starling",
+			Balance:       budgit.Balance{ClearedBalance: -5, EffectiveBalance: -5},
+		},
+	}, accounts)
+}
+
+func (s *clientsSuite) TestStarlingGetExternalAccount() {
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal", ClearedBalance: 100, EffectiveBalance: 90})
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	s.Run("Found", func() {
+		account, err := client.GetExternalAccount(context.Background(), personal.UID.String())
+		s.Require().NoError(err)
+		s.Equal("Personal", account.Name)
+	})
+	s.Run("NotFound", func() {
+		_, err := client.GetExternalAccount(context.Background(), uuid.NewString())
+		s.ErrorIs(err, clients.ErrAccountNotFound)
+	})
+}
+
+func (s *clientsSuite) TestStarlingInjectedFailures() {
+	s.Run("TransientFailureRetried", func() {
+		fake := starlingfake.New()
+		fake.AddAccount(starlingfake.Account{Name: "Personal"})
+		fake.InjectFailure(starlingfake.Failure{PathPrefix: "/api/v2/accounts", StatusCode: http.StatusServiceUnavailable, Times: 1})
+		client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+		accounts, err := client.GetExternalAccounts(context.Background())
+		s.Require().NoError(err)
+		s.Len(accounts, 1)
+		s.Equal([]string{"GET /api/v2/accounts", "GET /api/v2/accounts"}, fake.Requests()[:2])
+	})
+	s.Run("RateLimited", func() {
+		fake := starlingfake.New()
+		fake.InjectFailure(starlingfake.Failure{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"120"}}})
+		client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+		_, err := client.GetExternalAccounts(context.Background())
+		s.ErrorIs(err, clients.RateLimitError{RetryAfter: 2 * time.Minute})
+	})
+	s.Run("BalanceNotFound", func() {
+		fake := starlingfake.New()
+		fake.AddAccount(starlingfake.Account{Name: "Personal"})
+		fake.InjectFailure(starlingfake.Failure{Method: http.MethodGet, PathPrefix: "/api/v2/accounts/", StatusCode: http.StatusNotFound, Body: `{"errors": [{"message": "gone"}]}`})
+		client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+		_, err := client.GetExternalAccounts(context.Background())
+		s.ErrorIs(err, clients.NotFoundError{Message: "gone"})
+	})
+}
+
+func (s *clientsSuite) TestStarlingOAuth() {
+	fake := starlingfake.New()
+	fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	fake.SetCredentials("expired", "refresh-0")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := clients.NewFileTokenStore(filepath.Join(s.T().TempDir(), "token"), tokenStoreKey)
+	s.Require().NoError(err)
+	tokenSource := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: clients.StarlingTokenURL(server.URL)}, store, "refresh-0")
+
+	client, err := clients.NewStarlingClient(zap.NewNop().Sugar(), "starling", server.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), tokenSource))
+	s.Require().NoError(err)
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.Len(accounts, 1)
+
+	token, err := store.LoadToken(context.Background())
+	s.Require().NoError(err)
+	s.Equal("fake-access-token-1", token.AccessToken)
+	s.Equal("fake-refresh-token-1", token.RefreshToken)
+}
+
+func (s *clientsSuite) TestStarlingGetExternalTransactions() {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	items := fake.AddFeedItems(personal.UID,
+		starlingfake.FeedItem{Amount: 250000, CounterPartyName: "Employer Ltd", Reference: "SALARY", TransactionTime: now.AddDate(0, 0, -10), Settled: true},
+		starlingfake.FeedItem{Amount: -320, CounterPartyName: "Pret A Manger", UserNote: "coffee", TransactionTime: now.AddDate(0, 0, -1)},
+	)
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	s.Run("AllSince", func() {
+		transactions, err := client.GetExternalTransactions(context.Background(), personal.UID.String(), now.AddDate(0, -1, 0))
+		s.Require().NoError(err)
+		s.CMPEqual([]*budgit.ExternalTransaction{
+			{
+				ID:                items[0].UID.String(),
+				ExternalAccountID: personal.UID.String(),
+				IntegrationID:     "starling",
+				EffectiveDate:     now.AddDate(0, 0, -10),
+				PayeeName:         "Employer Ltd",
+				Reference:         "SALARY",
+				Amount:            250000,
+				Cleared:           true,
+			},
+			{
+				ID:                items[1].UID.String(),
+				ExternalAccountID: personal.UID.String(),
+				IntegrationID:     "starling",
+				EffectiveDate:     now.AddDate(0, 0, -1),
+				PayeeName:         "Pret A Manger",
+				Memo:              "coffee",
+				Amount:            -320,
+			},
+		}, transactions)
+	})
+	s.Run("OnlySince", func() {
+		transactions, err := client.GetExternalTransactions(context.Background(), personal.UID.String(), now.AddDate(0, 0, -2))
+		s.Require().NoError(err)
+		s.Require().Len(transactions, 1)
+		s.Equal(items[1].UID.String(), transactions[0].ID)
+	})
+	s.Run("AccountNotFound", func() {
+		_, err := client.GetExternalTransactions(context.Background(), uuid.NewString(), now)
+		s.ErrorIs(err, clients.ErrAccountNotFound)
+	})
+}
+
+func (s *clientsSuite) TestStarlingUpdateExternalTransaction() {
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	item := fake.AddFeedItems(personal.UID, starlingfake.FeedItem{Amount: -320, CounterPartyName: "Pret A Manger", UserNote: "old"})[0]
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	s.Run("MemoAndCategory", func() {
+		memo, category := "coffee with Sam", "Eating out"
+		err := client.UpdateExternalTransaction(context.Background(), personal.UID.String(), item.UID.String(), budgit.ExternalTransactionUpdate{Memo: &memo, Category: &category})
+		s.Require().NoError(err)
+
+		updated, ok := fake.FeedItem(personal.UID, item.UID)
+		s.Require().True(ok)
+		s.Equal("coffee with Sam", updated.UserNote)
+		s.Equal("EATING_OUT", updated.SpendingCategory)
+	})
+	s.Run("OnlyGivenFieldsUpdated", func() {
+		category := "groceries"
+		err := client.UpdateExternalTransaction(context.Background(), personal.UID.String(), item.UID.String(), budgit.ExternalTransactionUpdate{Category: &category})
+		s.Require().NoError(err)
+
+		updated, _ := fake.FeedItem(personal.UID, item.UID)
+		s.Equal("coffee with Sam", updated.UserNote)
+		s.Equal("GROCERIES", updated.SpendingCategory)
+	})
+	s.Run("CategoryWithoutSpendingCategorySkipped", func() {
+		for _, category := range []string{"Groceries:Weekly", "Rent & Bills"} {
+			memo := "weekly shop"
+			err := client.UpdateExternalTransaction(context.Background(), personal.UID.String(), item.UID.String(), budgit.ExternalTransactionUpdate{Memo: &memo, Category: &category})
+			s.Require().NoError(err, category)
+
+			updated, _ := fake.FeedItem(personal.UID, item.UID)
+			s.Equal("weekly shop", updated.UserNote, category)
+			s.Equal("GROCERIES", updated.SpendingCategory, category)
+		}
+	})
+	s.Run("TransactionNotFound", func() {
+		memo := "missing"
+		err := client.UpdateExternalTransaction(context.Background(), personal.UID.String(), uuid.New().String(), budgit.ExternalTransactionUpdate{Memo: &memo})
+		s.ErrorIs(err, clients.NotFoundError{Message: "NOT_FOUND"})
+	})
+}
+
+func (s *clientsSuite) TestStarlingExternalAttachments() {
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	item := fake.AddFeedItems(personal.UID, starlingfake.FeedItem{
+		Amount:           -320,
+		CounterPartyName: "Pret A Manger",
+		Attachments:      []starlingfake.Attachment{{Type: "PDF", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}},
+		Receipts:         []starlingfake.Receipt{{Identifier: "R-1", ProviderName: "Pret A Manger", Total: 3.2, Items: []starlingfake.ReceiptItem{{Description: "Coffee", Amount: 3.2, Quantity: 1}}}},
+	})[0]
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	attachments, err := client.GetExternalAttachments(context.Background(), personal.UID.String(), item.UID.String())
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ExternalAttachment{
+		{
+			ID:                    item.Attachments[0].UID.String(),
+			ExternalTransactionID: item.UID.String(),
+			Name:                  "attachment-" + item.Attachments[0].UID.String() + ".pdf",
+			ContentType:           "application/pdf",
+		},
+		{
+			ID:                    "receipt-" + item.Receipts[0].UID.String(),
+			ExternalTransactionID: item.UID.String(),
+			Name:                  "receipt-" + item.Receipts[0].UID.String() + ".json",
+			ContentType:           "application/json",
+		},
+	}, attachments)
+
+	s.Run("DownloadFile", func() {
+		content, contentType, err := client.DownloadExternalAttachment(context.Background(), personal.UID.String(), attachments[0])
+		s.Require().NoError(err)
+		defer content.Close()
+		s.Equal("application/pdf", contentType)
+		body, err := io.ReadAll(content)
+		s.Require().NoError(err)
+		s.Equal("%PDF-1.4", string(body))
+	})
+	s.Run("DownloadReceipt", func() {
+		content, contentType, err := client.DownloadExternalAttachment(context.Background(), personal.UID.String(), attachments[1])
+		s.Require().NoError(err)
+		defer content.Close()
+		s.Equal("application/json", contentType)
+		body, err := io.ReadAll(content)
+		s.Require().NoError(err)
+		s.Contains(string(body), "Coffee")
+	})
+	s.Run("ReceiptNotFound", func() {
+		_, _, err := client.DownloadExternalAttachment(context.Background(), personal.UID.String(), &budgit.ExternalAttachment{
+			ID:                    "receipt-" + uuid.NewString(),
+			ExternalTransactionID: item.UID.String(),
+		})
+		s.ErrorIs(err, clients.ErrAttachmentNotFound)
+	})
+}
+
+func (s *clientsSuite) TestStarlingGetScheduledPayments() {
+	nextDate := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	fake := starlingfake.New()
+	personal := fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	orders := fake.AddStandingOrders(personal.UID, starlingfake.StandingOrder{Amount: 100000, Reference: "JOINT", NextDate: nextDate})
+	client := s.newFakeStarlingClient(fake, clients.NewStaticTokenSource("token"))
+
+	payments, err := client.GetScheduledPayments(context.Background(), personal.UID.String())
+	s.Require().NoError(err)
+	s.CMPEqual([]*budgit.ScheduledPayment{
+		{
+			ID:                orders[0].UID.String(),
+			ExternalAccountID: personal.UID.String(),
+			IntegrationID:     "starling",
+			Reference:         "JOINT",
+			Amount:            -100000,
+			Frequency:         "MONTHLY",
+			NextDate:          nextDate,
+		},
+	}, payments)
+}
@@ -0,0 +1,367 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTokenNotFound       = fmt.Errorf("no token has been stored")
+	ErrTokenNotRefreshable = fmt.Errorf("the token cannot be refreshed")
+)
+
+// Token is an access token used to authenticate requests to an integration.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+}
+
+// expiresWithin returns true if the Token expires within the given duration of now.
+// A Token with no expiry never expires.
+func (t Token) expiresWithin(now time.Time, d time.Duration) bool {
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return !now.Add(d).Before(t.ExpiresAt)
+}
+
+// TokenSource supplies Tokens for authenticating requests to an integration.
+type TokenSource interface {
+	// Token returns a Token valid for use now, refreshing it if required.
+	Token(ctx context.Context) (*Token, error)
+	// RefreshToken unconditionally refreshes the Token, e.g. after it is rejected by the integration.
+	RefreshToken(ctx context.Context) (*Token, error)
+}
+
+// StaticTokenSource is a TokenSource for a fixed access token, such as a personal access token.
+type StaticTokenSource struct {
+	token *Token
+}
+
+func NewStaticTokenSource(accessToken string) StaticTokenSource {
+	return StaticTokenSource{token: &Token{AccessToken: accessToken}}
+}
+
+func (s StaticTokenSource) Token(ctx context.Context) (*Token, error) { return s.token, nil }
+
+func (s StaticTokenSource) RefreshToken(ctx context.Context) (*Token, error) {
+	return nil, ErrTokenNotRefreshable
+}
+
+// TokenStore persists Tokens between runs, so that rotated refresh tokens are not lost.
+type TokenStore interface {
+	LoadToken(ctx context.Context) (*Token, error)
+	SaveToken(ctx context.Context, token *Token) error
+}
+
+// FileTokenStore is a TokenStore that keeps a Token in a file, encrypted with AES-GCM.
+// The file is only readable by the current user.
+type FileTokenStore struct {
+	path string
+	aead cipher.AEAD
+}
+
+func NewFileTokenStore(path string, key []byte) (*FileTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("initialising token store: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("initialising token store: %w", err)
+	}
+	return &FileTokenStore{path: path, aead: aead}, nil
+}
+
+func (s FileTokenStore) LoadToken(ctx context.Context) (*Token, error) {
+	ciphertext, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading token: %w", err)
+	}
+	if len(ciphertext) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("loading token: token file %q is malformed", s.path)
+	}
+
+	nonce, ciphertext := ciphertext[:s.aead.NonceSize()], ciphertext[s.aead.NonceSize():]
+	plaintext, err := s.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("loading token: decrypting: %w", err)
+	}
+
+	token := &Token{}
+	if err := json.Unmarshal(plaintext, token); err != nil {
+		return nil, fmt.Errorf("loading token: %w", err)
+	}
+	return token, nil
+}
+
+func (s FileTokenStore) SaveToken(ctx context.Context, token *Token) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("saving token: generating nonce: %w", err)
+	}
+	ciphertext := s.aead.Seal(nonce, nonce, plaintext, nil)
+
+	// Write to a temporary file first, so that a failed write never loses the previous token.
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving token: %w", err)
+	}
+	if _, err := tmp.Write(ciphertext); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving token: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("saving token: %w", err)
+	}
+	return nil
+}
+
+// OAuthConfig configures the OAuth refresh token grant of an integration.
+type OAuthConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	// RefreshBefore is how long before expiry a Token is proactively refreshed.
+	RefreshBefore time.Duration
+}
+
+// OAuthTokenSource is a TokenSource which uses the OAuth refresh token grant to keep an access token valid.
+// Tokens are persisted to a TokenStore whenever they are refreshed, as refresh tokens are single use.
+type OAuthTokenSource struct {
+	log    *zap.SugaredLogger
+	config OAuthConfig
+	store  TokenStore
+	client *http.Client
+	now    func() time.Time
+
+	mu     sync.Mutex
+	loaded bool
+	token  *Token
+}
+
+const defaultRefreshBefore = time.Minute
+
+// NewOAuthTokenSource returns an OAuthTokenSource.
+// If the store holds no Token yet, the given refresh token is used to obtain the first one.
+func NewOAuthTokenSource(log *zap.SugaredLogger, config OAuthConfig, store TokenStore, refreshToken string) *OAuthTokenSource {
+	if config.RefreshBefore == 0 {
+		config.RefreshBefore = defaultRefreshBefore
+	}
+	source := &OAuthTokenSource{
+		log:    log,
+		config: config,
+		store:  store,
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}
+	if refreshToken != "" {
+		source.token = &Token{RefreshToken: refreshToken, ExpiresAt: time.Unix(0, 0)}
+	}
+	return source
+}
+
+func (s *OAuthTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadToken(ctx); err != nil {
+		return nil, err
+	}
+	if s.token.AccessToken != "" && !s.token.expiresWithin(s.now(), s.config.RefreshBefore) {
+		return s.token, nil
+	}
+	return s.refreshToken(ctx)
+}
+
+func (s *OAuthTokenSource) RefreshToken(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.loadToken(ctx); err != nil {
+		return nil, err
+	}
+	return s.refreshToken(ctx)
+}
+
+// loadToken loads the stored Token the first time it is called. It must be called with mu held.
+func (s *OAuthTokenSource) loadToken(ctx context.Context) error {
+	if s.loaded {
+		return nil
+	}
+
+	stored, err := s.store.LoadToken(ctx)
+	switch {
+	case errors.Is(err, ErrTokenNotFound):
+		if s.token == nil {
+			return fmt.Errorf("loading token: %w", ErrTokenNotFound)
+		}
+	case err != nil:
+		return err
+	default:
+		// A stored Token takes precedence over the configured refresh token, which may already have been used.
+		s.token = stored
+	}
+	s.loaded = true
+	return nil
+}
+
+type oauthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// refreshToken exchanges the current refresh token for a new Token. It must be called with mu held.
+func (s *OAuthTokenSource) refreshToken(ctx context.Context) (*Token, error) {
+	if s.token.RefreshToken == "" {
+		return nil, ErrTokenNotRefreshable
+	}
+	s.log.Debugw("Refreshing OAuth token", zap.String("token_url", s.config.TokenURL))
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.token.RefreshToken},
+		"client_id":     {s.config.ClientID},
+		"client_secret": {s.config.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("refreshing token: unexpected status %q: %s", resp.Status, bytes.TrimSpace(body))
+	}
+
+	tokenResp := &oauthTokenResponse{}
+	if err := json.Unmarshal(body, tokenResp); err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("refreshing token: response contained no access token")
+	}
+
+	token := &Token{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+	}
+	if token.RefreshToken == "" {
+		// Not all providers rotate refresh tokens, in which case the existing one remains valid.
+		token.RefreshToken = s.token.RefreshToken
+	}
+	if tokenResp.ExpiresIn > 0 {
+		token.ExpiresAt = s.now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+
+	if err := s.store.SaveToken(ctx, token); err != nil {
+		return nil, fmt.Errorf("refreshing token: %w", err)
+	}
+	s.token = token
+	s.log.Debugw("Refreshed OAuth token", zap.Time("expires_at", token.ExpiresAt))
+	return token, nil
+}
+
+// authTransport is a http.RoundTripper which authenticates requests using a TokenSource.
+// A request rejected with 401 Unauthorized is retried once with a refreshed Token.
+type authTransport struct {
+	base   http.RoundTripper
+	source TokenSource
+}
+
+func newAuthTransport(base http.RoundTripper, source TokenSource) *authTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &authTransport{base: base, source: source}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("authenticating request: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(withBearerToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		// The body has been consumed and cannot be replayed.
+		return resp, nil
+	}
+
+	token, err = t.source.RefreshToken(req.Context())
+	if errors.Is(err, ErrTokenNotRefreshable) {
+		return resp, nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return nil, fmt.Errorf("authenticating request: %w", err)
+	}
+	resp.Body.Close()
+
+	retry := withBearerToken(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("authenticating request: %w", err)
+		}
+		retry.Body = body
+	}
+	return t.base.RoundTrip(retry)
+}
+
+func withBearerToken(req *http.Request, token *Token) *http.Request {
+	// RoundTrippers must not modify the given request.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token.AccessToken))
+	return req
+}
@@ -0,0 +1,157 @@
+package clients_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"go.uber.org/zap"
+)
+
+var tokenStoreKey = []byte("0123456789abcdef0123456789abcdef")
+
+// newTokenServer returns a server which issues sequentially numbered tokens from the OAuth refresh token grant.
+func newTokenServer(expiresIn int64, refreshes *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "refresh_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := refreshes.Add(1)
+		json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  fmt.Sprintf("access-%d", n),
+			"refresh_token": fmt.Sprintf("refresh-%d", n),
+			"token_type":    "Bearer",
+			"expires_in":    expiresIn,
+		})
+	}))
+}
+
+func (s *clientsSuite) newTokenStore() *clients.FileTokenStore {
+	store, err := clients.NewFileTokenStore(filepath.Join(s.T().TempDir(), "token"), tokenStoreKey)
+	s.Require().NoError(err)
+	return store
+}
+
+func (s *clientsSuite) TestFileTokenStore() {
+	path := filepath.Join(s.T().TempDir(), "token")
+	store, err := clients.NewFileTokenStore(path, tokenStoreKey)
+	s.Require().NoError(err)
+
+	_, err = store.LoadToken(context.Background())
+	s.ErrorIs(err, clients.ErrTokenNotFound)
+
+	token := &clients.Token{AccessToken: "access-1", RefreshToken: "refresh-1"}
+	s.Require().NoError(store.SaveToken(context.Background(), token))
+
+	loaded, err := store.LoadToken(context.Background())
+	s.Require().NoError(err)
+	s.CMPEqual(token, loaded)
+
+	s.Run("TokenNotStoredInPlaintext", func() {
+		contents, err := os.ReadFile(path)
+		s.Require().NoError(err)
+		s.NotContains(string(contents), "access-1")
+	})
+	s.Run("FileOnlyReadableByUser", func() {
+		info, err := os.Stat(path)
+		s.Require().NoError(err)
+		s.Equal(os.FileMode(0o600), info.Mode().Perm())
+	})
+	s.Run("WrongKeyCannotLoad", func() {
+		wrongKey, err := clients.NewFileTokenStore(path, []byte("fedcba9876543210fedcba9876543210"))
+		s.Require().NoError(err)
+		_, err = wrongKey.LoadToken(context.Background())
+		s.Error(err)
+	})
+}
+
+func (s *clientsSuite) TestOAuthTokenSource() {
+	s.Run("RefreshesWhenNoAccessToken", func() {
+		refreshes := &atomic.Int64{}
+		server := newTokenServer(3600, refreshes)
+		defer server.Close()
+		store := s.newTokenStore()
+
+		source := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: server.URL}, store, "refresh-0")
+		token, err := source.Token(context.Background())
+		s.Require().NoError(err)
+		s.Equal("access-1", token.AccessToken)
+
+		token, err = source.Token(context.Background())
+		s.Require().NoError(err)
+		s.Equal("access-1", token.AccessToken, "expected token to be reused until it nears expiry")
+		s.Equal(int64(1), refreshes.Load())
+
+		stored, err := store.LoadToken(context.Background())
+		s.Require().NoError(err)
+		s.Equal("refresh-1", stored.RefreshToken, "expected rotated refresh token to be stored")
+	})
+	s.Run("RefreshesBeforeExpiry", func() {
+		refreshes := &atomic.Int64{}
+		server := newTokenServer(30, refreshes)
+		defer server.Close()
+
+		source := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: server.URL}, s.newTokenStore(), "refresh-0")
+		_, err := source.Token(context.Background())
+		s.Require().NoError(err)
+		token, err := source.Token(context.Background())
+		s.Require().NoError(err)
+		s.Equal("access-2", token.AccessToken)
+	})
+	s.Run("StoredTokenTakesPrecedence", func() {
+		refreshes := &atomic.Int64{}
+		server := newTokenServer(3600, refreshes)
+		defer server.Close()
+		store := s.newTokenStore()
+		s.Require().NoError(store.SaveToken(context.Background(), &clients.Token{AccessToken: "stored", RefreshToken: "stored-refresh"}))
+
+		source := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: server.URL}, store, "refresh-0")
+		token, err := source.Token(context.Background())
+		s.Require().NoError(err)
+		s.Equal("stored", token.AccessToken)
+		s.Equal(int64(0), refreshes.Load())
+	})
+	s.Run("NoTokenErrors", func() {
+		source := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{}, s.newTokenStore(), "")
+		_, err := source.Token(context.Background())
+		s.ErrorIs(err, clients.ErrTokenNotFound)
+	})
+}
+
+func (s *clientsSuite) TestStarlingClientRetriesUnauthorised() {
+	refreshes := &atomic.Int64{}
+	tokenServer := newTokenServer(3600, refreshes)
+	defer tokenServer.Close()
+
+	store := s.newTokenStore()
+	s.Require().NoError(store.SaveToken(context.Background(), &clients.Token{AccessToken: "revoked", RefreshToken: "refresh-0"}))
+
+	requests := &atomic.Int64{}
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accounts": []}`))
+	}))
+	defer apiServer.Close()
+
+	source := clients.NewOAuthTokenSource(zap.NewNop().Sugar(), clients.OAuthConfig{TokenURL: tokenServer.URL}, store, "")
+	client, err := clients.NewStarlingClient(zap.NewNop().Sugar(), "starling", apiServer.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), clients.DefaultTransportConfig(), source))
+	s.Require().NoError(err)
+
+	accounts, err := client.GetExternalAccounts(context.Background())
+	s.Require().NoError(err)
+	s.Empty(accounts)
+	s.Equal(int64(2), requests.Load(), "expected request to be retried once")
+	s.Equal(int64(1), refreshes.Load())
+}
@@ -0,0 +1,294 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TransportConfig configures the resilience of HTTP requests made to integrations.
+type TransportConfig struct {
+	// Timeout is the time limit of a single request, including retries.
+	Timeout time.Duration
+	// MaxRetries is the maximum number of times an idempotent request is retried.
+	MaxRetries int
+	// BaseBackoff and MaxBackoff bound the jittered exponential backoff between retries.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// MaxRetryAfter is the longest Retry-After the transport will wait for, rather than failing the request.
+	MaxRetryAfter time.Duration
+	// BreakerThreshold is the number of consecutive failed requests after which the circuit breaker opens. A request
+	// fails if its last attempt does, however many times it was retried.
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit breaker stays open before allowing a trial request.
+	BreakerCooldown time.Duration
+	// Base sends requests, defaulting to http.DefaultTransport. Tests may replace it, see the cassette package.
+	Base http.RoundTripper
+}
+
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		Timeout:          time.Minute,
+		MaxRetries:       3,
+		BaseBackoff:      200 * time.Millisecond,
+		MaxBackoff:       5 * time.Second,
+		MaxRetryAfter:    30 * time.Second,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// NewHTTPClient returns a http.Client for an integration, which authenticates requests using the given TokenSource,
+// retries failed idempotent requests and stops sending requests while the integration is failing.
+func NewHTTPClient(log *zap.SugaredLogger, config TransportConfig, tokenSource TokenSource) *http.Client {
+	transport := config.Base
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if tokenSource != nil {
+		transport = newAuthTransport(transport, tokenSource)
+	}
+	transport = &retryTransport{
+		log:     log,
+		config:  config,
+		base:    transport,
+		breaker: &circuitBreaker{threshold: config.BreakerThreshold, cooldown: config.BreakerCooldown, now: time.Now},
+	}
+	return &http.Client{
+		Timeout:   config.Timeout,
+		Transport: transport,
+	}
+}
+
+var ErrCircuitOpen = fmt.Errorf("the integration is failing, requests are paused")
+
+// retryTransport is a http.RoundTripper which retries idempotent requests that fail transiently,
+// backing off exponentially with jitter or as instructed by a Retry-After header.
+type retryTransport struct {
+	log     *zap.SugaredLogger
+	config  TransportConfig
+	base    http.RoundTripper
+	breaker *circuitBreaker
+}
+
+// RoundTrip sends a request, retrying it if it fails transiently. The circuit breaker counts the request as a whole,
+// once its last attempt is made, so that the retries of one request do not open it.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+	resp, err := t.roundTrip(req)
+	t.breaker.record(err != nil || isServerError(resp.StatusCode))
+	return resp, err
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	retryable := isIdempotent(req) && (req.Body == nil || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if !retryable || attempt >= t.config.MaxRetries || !isTransient(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > t.config.MaxRetryAfter {
+					return resp, nil
+				}
+				wait = retryAfter
+			}
+			resp.Body.Close()
+		}
+
+		t.log.Debugw("Retrying request",
+			zap.String("method", req.Method),
+			zap.String("url", req.URL.Redacted()),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("wait", wait),
+			zap.Error(err),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns a duration chosen uniformly from [0, min(MaxBackoff, BaseBackoff * 2^attempt)).
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.config.BaseBackoff << attempt
+	if ceiling <= 0 || ceiling > t.config.MaxBackoff {
+		ceiling = t.config.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling)
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || isServerError(resp.StatusCode)
+}
+
+func isServerError(statusCode int) bool {
+	switch statusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or a HTTP date.
+func parseRetryAfter(header string, now time.Time) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// circuitBreaker opens after a number of consecutive failures, rejecting requests until the cooldown has passed.
+// Once it has, a single trial request is allowed, closing the breaker again if it succeeds.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.consecutiveFailures < b.threshold {
+		return true
+	}
+	if b.trialInFlight || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trialInFlight = true
+	return true
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	if b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+	if !failed {
+		b.consecutiveFailures = 0
+		return
+	}
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+// AuthError is returned when an integration rejects the credentials used.
+type AuthError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e AuthError) Error() string {
+	return fmt.Sprintf("integration rejected credentials with status %d: %s", e.StatusCode, e.Message)
+}
+
+// RateLimitError is returned when an integration is rate limiting requests.
+type RateLimitError struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e RateLimitError) Error() string {
+	return fmt.Sprintf("integration is rate limiting requests, retry after %s: %s", e.RetryAfter, e.Message)
+}
+
+// NotFoundError is returned when an integration does not have the requested resource.
+type NotFoundError struct {
+	Message string
+}
+
+func (e NotFoundError) Error() string {
+	return fmt.Sprintf("integration could not find the requested resource: %s", e.Message)
+}
+
+// UnexpectedResponseError is returned when an integration responds with a status or body that cannot be handled.
+type UnexpectedResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("integration responded unexpectedly with status %d: %s", e.StatusCode, e.Message)
+}
+
+// responseError returns the typed error for a response that could not be handled.
+func responseError(resp *http.Response, message string) error {
+	if resp == nil {
+		return UnexpectedResponseError{Message: message}
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return AuthError{StatusCode: resp.StatusCode, Message: message}
+	case http.StatusTooManyRequests:
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		return RateLimitError{RetryAfter: retryAfter, Message: message}
+	case http.StatusNotFound:
+		return NotFoundError{Message: message}
+	}
+	if message == "" {
+		message = "response body could not be decoded"
+	}
+	return UnexpectedResponseError{StatusCode: resp.StatusCode, Message: message}
+}
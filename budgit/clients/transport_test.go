@@ -0,0 +1,275 @@
+package clients_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"go.uber.org/zap"
+)
+
+func testTransportConfig() clients.TransportConfig {
+	return clients.TransportConfig{
+		Timeout:          5 * time.Second,
+		MaxRetries:       2,
+		BaseBackoff:      time.Millisecond,
+		MaxBackoff:       5 * time.Millisecond,
+		MaxRetryAfter:    2 * time.Second,
+		BreakerThreshold: 0,
+	}
+}
+
+// newFlakyServer returns a server which responds with the given statuses in turn, then 200 OK.
+func newFlakyServer(requests *atomic.Int64, header http.Header, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(requests.Add(1))
+		if n <= len(statuses) {
+			for key, values := range header {
+				w.Header()[key] = values
+			}
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"accounts": []}`))
+	}))
+}
+
+func (s *clientsSuite) TestTransportRetries() {
+	testCases := []struct {
+		name             string
+		method           string
+		statuses         []int
+		expectedStatus   int
+		expectedRequests int64
+	}{
+		{
+			name:             "SuccessNotRetried",
+			method:           http.MethodGet,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 1,
+		},
+		{
+			name:             "ServerErrorRetried",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusServiceUnavailable, http.StatusBadGateway},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "RetriesExhausted",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			expectedStatus:   http.StatusInternalServerError,
+			expectedRequests: 3,
+		},
+		{
+			name:             "ClientErrorNotRetried",
+			method:           http.MethodGet,
+			statuses:         []int{http.StatusBadRequest},
+			expectedStatus:   http.StatusBadRequest,
+			expectedRequests: 1,
+		},
+		{
+			name:             "NonIdempotentNotRetried",
+			method:           http.MethodPost,
+			statuses:         []int{http.StatusServiceUnavailable},
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedRequests: 1,
+		},
+		{
+			name:             "IdempotentPutRetried",
+			method:           http.MethodPut,
+			statuses:         []int{http.StatusServiceUnavailable},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			requests := &atomic.Int64{}
+			server := newFlakyServer(requests, nil, tc.statuses...)
+			defer server.Close()
+
+			client := clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), nil)
+			req, err := http.NewRequest(tc.method, server.URL, strings.NewReader("body"))
+			s.Require().NoError(err)
+
+			resp, err := client.Do(req)
+			s.Require().NoError(err)
+			resp.Body.Close()
+
+			s.Equal(tc.expectedStatus, resp.StatusCode)
+			s.Equal(tc.expectedRequests, requests.Load())
+		})
+	}
+}
+
+func (s *clientsSuite) TestTransportRetryAfter() {
+	s.Run("RetryAfterHonoured", func() {
+		requests := &atomic.Int64{}
+		server := newFlakyServer(requests, http.Header{"Retry-After": {"1"}}, http.StatusTooManyRequests)
+		defer server.Close()
+
+		client := clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), nil)
+		start := time.Now()
+		resp, err := client.Get(server.URL)
+		s.Require().NoError(err)
+		resp.Body.Close()
+
+		s.Equal(http.StatusOK, resp.StatusCode)
+		s.Equal(int64(2), requests.Load())
+		s.GreaterOrEqual(time.Since(start), time.Second)
+	})
+	s.Run("LongRetryAfterNotWaited", func() {
+		requests := &atomic.Int64{}
+		server := newFlakyServer(requests, http.Header{"Retry-After": {"3600"}}, http.StatusTooManyRequests)
+		defer server.Close()
+
+		client := clients.NewHTTPClient(zap.NewNop().Sugar(), testTransportConfig(), nil)
+		resp, err := client.Get(server.URL)
+		s.Require().NoError(err)
+		resp.Body.Close()
+
+		s.Equal(http.StatusTooManyRequests, resp.StatusCode)
+		s.Equal(int64(1), requests.Load())
+	})
+}
+
+func (s *clientsSuite) TestTransportCircuitBreaker() {
+	requests := &atomic.Int64{}
+	server := newFlakyServer(requests, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	config := testTransportConfig()
+	config.MaxRetries = 0
+	config.BreakerThreshold = 2
+	config.BreakerCooldown = 50 * time.Millisecond
+	client := clients.NewHTTPClient(zap.NewNop().Sugar(), config, nil)
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		s.Require().NoError(err)
+		resp.Body.Close()
+	}
+
+	_, err := client.Get(server.URL)
+	s.ErrorIs(err, clients.ErrCircuitOpen)
+	s.Equal(int64(2), requests.Load(), "expected no request to be sent while the breaker is open")
+
+	time.Sleep(config.BreakerCooldown)
+	resp, err := client.Get(server.URL)
+	s.Require().NoError(err, "expected trial request after cooldown")
+	resp.Body.Close()
+	s.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+
+	_, err = client.Get(server.URL)
+	s.ErrorIs(err, clients.ErrCircuitOpen, "expected failed trial request to reopen the breaker")
+
+	time.Sleep(config.BreakerCooldown)
+	resp, err = client.Get(server.URL)
+	s.Require().NoError(err)
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+
+	resp, err = client.Get(server.URL)
+	s.Require().NoError(err, "expected successful trial request to close the breaker")
+	resp.Body.Close()
+}
+
+func (s *clientsSuite) TestStarlingClientTypedErrors() {
+	testCases := []struct {
+		name        string
+		status      int
+		header      http.Header
+		body        string
+		expectedErr error
+	}{
+		{
+			name:        "Unauthorised",
+			status:      http.StatusUnauthorized,
+			body:        `{"errors": [{"message": "invalid token"}], "success": false}`,
+			expectedErr: clients.AuthError{StatusCode: http.StatusUnauthorized, Message: "invalid token"},
+		},
+		{
+			name:        "Forbidden",
+			status:      http.StatusForbidden,
+			body:        `{"errors": [{"message": "insufficient scope"}], "success": false}`,
+			expectedErr: clients.AuthError{StatusCode: http.StatusForbidden, Message: "insufficient scope"},
+		},
+		{
+			name:        "RateLimited",
+			status:      http.StatusTooManyRequests,
+			header:      http.Header{"Retry-After": {"3600"}},
+			expectedErr: clients.RateLimitError{RetryAfter: time.Hour},
+		},
+		{
+			name:        "NotFound",
+			status:      http.StatusNotFound,
+			body:        `{"errors": [{"message": "no such account"}], "success": false}`,
+			expectedErr: clients.NotFoundError{Message: "no such account"},
+		},
+		{
+			name:        "NonJSONServerError",
+			status:      http.StatusInternalServerError,
+			body:        `<html>Internal Server Error</html>`,
+			expectedErr: clients.UnexpectedResponseError{StatusCode: http.StatusInternalServerError, Message: "response body could not be decoded"},
+		},
+		{
+			name:        "NonJSONSuccess",
+			status:      http.StatusOK,
+			body:        `<html>Maintenance</html>`,
+			expectedErr: clients.UnexpectedResponseError{StatusCode: http.StatusOK, Message: "response body could not be decoded"},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, values := range tc.header {
+					w.Header()[key] = values
+				}
+				if strings.HasPrefix(tc.body, "{") {
+					w.Header().Set("Content-Type", "application/json")
+				} else {
+					w.Header().Set("Content-Type", "text/html")
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			config := testTransportConfig()
+			config.MaxRetries = 0
+			client, err := clients.NewStarlingClient(zap.NewNop().Sugar(), "starling", server.URL, clients.NewHTTPClient(zap.NewNop().Sugar(), config, clients.NewStaticTokenSource("token")))
+			s.Require().NoError(err)
+
+			_, err = client.GetExternalAccounts(context.Background())
+			s.ErrorIs(err, tc.expectedErr)
+		})
+	}
+}
+
+func (s *clientsSuite) TestTransportCircuitBreakerCountsRequests() {
+	requests := &atomic.Int64{}
+	server := newFlakyServer(requests, nil, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	config := testTransportConfig()
+	config.BreakerThreshold = 2
+	config.BreakerCooldown = time.Hour
+	client := clients.NewHTTPClient(zap.NewNop().Sugar(), config, nil)
+
+	resp, err := client.Get(server.URL)
+	s.Require().NoError(err, "expected the retries of one request not to open the breaker")
+	resp.Body.Close()
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal(int64(3), requests.Load())
+
+	resp, err = client.Get(server.URL)
+	s.Require().NoError(err, "expected a request which succeeded after retries not to count as a failure")
+	resp.Body.Close()
+}
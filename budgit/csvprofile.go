@@ -0,0 +1,28 @@
+package budgit
+
+// CSVProfile maps the columns of the CSV statements of an Account to the fields of its transactions.
+//
+// Columns are referred to by their header when HasHeader is set, and otherwise by their 1-based position, e.g. "3".
+// Amounts are taken from AmountColumn when it is set, where positive amounts are money entering the account.
+// Otherwise, they are taken from DebitColumn, money leaving the account, and CreditColumn, money entering it.
+type CSVProfile struct {
+	AccountID string
+	// Delimiter separates fields, defaulting to a comma.
+	Delimiter rune
+	HasHeader bool
+	// SkipRows is the number of rows before the header, or before the first transaction if there is no header.
+	SkipRows int64
+	// DateFormat is the layout of dates as accepted by time.Parse, defaulting to "2006-01-02".
+	DateColumn   string
+	DateFormat   string
+	PayeeColumn  string
+	MemoColumn   string
+	AmountColumn string
+	DebitColumn  string
+	CreditColumn string
+	// NegateAmounts negates every amount, for statements where positive amounts are money leaving the account,
+	// such as those of credit cards.
+	NegateAmounts bool
+	// DecimalComma is whether amounts use a comma as their decimal separator, and full stops to group thousands.
+	DecimalComma bool
+}
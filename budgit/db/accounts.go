@@ -25,6 +25,8 @@ type Account struct {
 	ExternalLastSyncTimestamp pgtype.Timestamptz `db:"external_last_sync_timestamp"`
 	ExternalClearedBalance    pgtype.Int8        `db:"external_cleared_balance"`
 	ExternalEffectiveBalance  pgtype.Int8        `db:"external_effective_balance"`
+	ExternalWriteBack         pgtype.Bool        `db:"external_write_back"`
+	CreatedBy                 pgtype.Text        `db:"created_by"`
 }
 
 func (a Account) GetRequestID() string {
@@ -60,7 +62,9 @@ func (db DB) InsertAccounts(ctx context.Context, queryer Queryer, accounts ...*A
 				$10::TEXT[],
 				$11::TIMESTAMPTZ[],
 				$12::BIGINT[],
-				$13::BIGINT[]
+				$13::BIGINT[],
+				$14::BOOLEAN[],
+				$15::TEXT[]
 			)
 			AS u(%[1]s)
 		)
@@ -99,6 +103,7 @@ func (db DB) UpdateAccountValidToTimestamps(ctx context.Context, queryer Queryer
 			AS u(id, valid_to_timestamp)
 		) AS input
 		WHERE accounts.valid_to_timestamp = 'infinity'
+		AND accounts.budget_id = current_setting('budgit.budget_id')
 		AND accounts.id = input.id
 		RETURNING accounts.id;
 	`
@@ -132,6 +137,7 @@ func (db DB) SelectAccounts(ctx context.Context, queryer Queryer) ([]*Account, e
 		SELECT %[1]s
 		FROM accounts
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		ORDER BY id
 	`, accountColumnsStr)
 
@@ -157,6 +163,7 @@ func (db DB) SelectAccountsByRequestID(ctx context.Context, queryer Queryer, req
 		SELECT %[1]s
 		FROM accounts
 		WHERE request_id = ANY($1::TEXT[])
+		AND budget_id = current_setting('budgit.budget_id')
 	`, accountColumnsStr)
 
 	ids := make([]pgtype.Text, 0, len(requestIDs))
@@ -186,6 +193,7 @@ func (db DB) SelectAccountsByID(ctx context.Context, queryer Queryer, accountIDs
 		SELECT %[1]s
 		FROM accounts
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		AND id = ANY($1::TEXT[])
 	`, accountColumnsStr)
 
@@ -223,6 +231,8 @@ func accountsToArgs(accounts []*Account) []any {
 	external_last_sync_timestamp := make([]pgtype.Timestamptz, 0, len(accounts))
 	external_cleared_balance := make([]pgtype.Int8, 0, len(accounts))
 	external_effective_balance := make([]pgtype.Int8, 0, len(accounts))
+	external_write_back := make([]pgtype.Bool, 0, len(accounts))
+	createdBys := make([]pgtype.Text, 0, len(accounts))
 	for _, account := range accounts {
 		requestIDs = append(requestIDs, account.RequestID)
 		validFromTimestamps = append(validFromTimestamps, account.ValidFromTimestamp)
@@ -237,6 +247,8 @@ func accountsToArgs(accounts []*Account) []any {
 		external_last_sync_timestamp = append(external_last_sync_timestamp, account.ExternalLastSyncTimestamp)
 		external_cleared_balance = append(external_cleared_balance, account.ExternalClearedBalance)
 		external_effective_balance = append(external_effective_balance, account.ExternalEffectiveBalance)
+		external_write_back = append(external_write_back, account.ExternalWriteBack)
+		createdBys = append(createdBys, account.CreatedBy)
 	}
 	return []any{
 		requestIDs,
@@ -252,5 +264,7 @@ func accountsToArgs(accounts []*Account) []any {
 		external_last_sync_timestamp,
 		external_cleared_balance,
 		external_effective_balance,
+		external_write_back,
+		createdBys,
 	}
 }
@@ -24,6 +24,7 @@ func (s *dbSuite) TestInsertAccounts() {
 			ExternalLastSyncTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
 			ExternalClearedBalance:    pgtype.Int8{Int64: 3, Valid: true},
 			ExternalEffectiveBalance:  pgtype.Int8{Int64: 4, Valid: true},
+			ExternalWriteBack:         pgtype.Bool{Bool: true, Valid: true},
 		},
 		{
 			RequestID:                 pgtype.Text{String: "request_id-2", Valid: true},
@@ -0,0 +1,228 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// APIToken is a long-lived token a User authenticates with to the API. Only the hash of the token is stored.
+type APIToken struct {
+	ID        pgtype.Text `db:"id"`
+	TokenHash pgtype.Text `db:"token_hash"`
+	UserID    pgtype.Text `db:"user_id"`
+	Name      pgtype.Text `db:"name"`
+	// BudgetID is null for tokens which may be used for every budget of their User.
+	BudgetID pgtype.Text `db:"budget_id"`
+	// Scopes are separated by spaces.
+	Scopes            pgtype.Text        `db:"scopes"`
+	CreatedTimestamp  pgtype.Timestamptz `db:"created_timestamp"`
+	ExpiresTimestamp  pgtype.Timestamptz `db:"expires_timestamp"`
+	LastUsedTimestamp pgtype.Timestamptz `db:"last_used_timestamp"`
+}
+
+func (t APIToken) GetID() string {
+	return t.ID.String
+}
+
+func (t APIToken) GetTokenHash() string {
+	return t.TokenHash.String
+}
+
+var (
+	apiTokenColumns    = getAllDBColumns(APIToken{})
+	apiTokenColumnsStr = strings.Join(apiTokenColumns, ", ")
+)
+
+func (db DB) InsertAPITokens(ctx context.Context, queryer Queryer, tokens ...*APIToken) ([]string, error) {
+	db.log.Debugw("Inserting API tokens", zap.Int("number_of_api_tokens", len(tokens)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO api_tokens (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TEXT[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::TEXT[],
+				$7::TIMESTAMPTZ[],
+				$8::TIMESTAMPTZ[],
+				$9::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, apiTokenColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, apiTokensToArgs(tokens)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d API tokens: %w", len(tokens), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted API tokens", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d API tokens: %w", len(tokens), err)
+	}
+	db.log.Debugw("Inserted API tokens scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+// UpdateAPITokenLastUsedTimestamp sets when an API token was last used, and returns its ID if it exists.
+func (db DB) UpdateAPITokenLastUsedTimestamp(ctx context.Context, queryer Queryer, tokenID string, lastUsed pgtype.Timestamptz) ([]string, error) {
+	db.log.Debugw("Updating API token last used timestamp", zap.String("api_token_id", tokenID))
+
+	sql := `
+		UPDATE api_tokens
+		SET last_used_timestamp = $2
+		WHERE id = $1
+		RETURNING id;
+	`
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: tokenID, Valid: true}, lastUsed)
+	if err != nil {
+		return nil, fmt.Errorf("updating API token last used timestamp: %w", err)
+	}
+	defer rows.Close()
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating API token last used timestamp: %w", err)
+	}
+	return ids, nil
+}
+
+// SelectAPITokensByUser returns the API tokens of a User, oldest first.
+func (db DB) SelectAPITokensByUser(ctx context.Context, queryer Queryer, userID string) ([]*APIToken, error) {
+	db.log.Debugw("Selecting API tokens by user", zap.String("user_id", userID))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_timestamp, id
+	`, apiTokenColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: userID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("selecting API tokens by user: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected API tokens by user", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	tokens, err := pgx.CollectRows(rows, pgx.RowToStructByName[APIToken])
+	if err != nil {
+		return nil, fmt.Errorf("selecting API tokens by user: %w", err)
+	}
+	db.log.Debugw("Selected API tokens by user scanned", zap.Int("number_of_api_tokens", len(tokens)))
+	return structsToPointers(tokens), nil
+}
+
+// SelectAPITokensByTokenHash returns the API tokens with the given token hashes which have not expired, by token hash.
+func (db DB) SelectAPITokensByTokenHash(ctx context.Context, queryer Queryer, tokenHashes ...string) (map[string]*APIToken, error) {
+	db.log.Debugw("Selecting API tokens by token hash", zap.Int("number_of_token_hashes", len(tokenHashes)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM api_tokens
+		WHERE token_hash = ANY($1::TEXT[])
+		AND (expires_timestamp IS NULL OR expires_timestamp > NOW())
+	`, apiTokenColumnsStr)
+
+	hashes := make([]pgtype.Text, 0, len(tokenHashes))
+	for _, hash := range tokenHashes {
+		hashes = append(hashes, pgtype.Text{String: hash, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("selecting API tokens by token hash: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected API tokens by token hash", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	tokens, err := pgx.CollectRows(rows, pgx.RowToStructByName[APIToken])
+	if err != nil {
+		return nil, fmt.Errorf("selecting API tokens by token hash: %w", err)
+	}
+	db.log.Debugw("Selected API tokens by token hash scanned", zap.Int("number_of_api_tokens", len(tokens)))
+
+	tokensByTokenHash := make(map[string]*APIToken, len(tokens))
+	for _, token := range structsToPointers(tokens) {
+		tokensByTokenHash[token.GetTokenHash()] = token
+	}
+	return tokensByTokenHash, nil
+}
+
+// DeleteAPITokens deletes the API tokens of a User with the given IDs, and returns the IDs of those deleted.
+func (db DB) DeleteAPITokens(ctx context.Context, queryer Queryer, userID string, tokenIDs ...string) ([]string, error) {
+	db.log.Debugw("Deleting API tokens", zap.String("user_id", userID), zap.String("api_token_ids", fmt.Sprintf("%+v", tokenIDs)))
+
+	sql := `
+		DELETE FROM api_tokens
+		WHERE user_id = $1
+		AND id = ANY($2::TEXT[])
+		RETURNING id;
+	`
+
+	ids := make([]pgtype.Text, 0, len(tokenIDs))
+	for _, id := range tokenIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: userID, Valid: true}, ids)
+	if err != nil {
+		return nil, fmt.Errorf("deleting API tokens: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Deleted API tokens", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	deletedIDs, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("deleting API tokens: %w", err)
+	}
+	return deletedIDs, nil
+}
+
+func apiTokensToArgs(tokens []*APIToken) []any {
+	ids := make([]pgtype.Text, 0, len(tokens))
+	tokenHashes := make([]pgtype.Text, 0, len(tokens))
+	userIDs := make([]pgtype.Text, 0, len(tokens))
+	names := make([]pgtype.Text, 0, len(tokens))
+	budgetIDs := make([]pgtype.Text, 0, len(tokens))
+	scopes := make([]pgtype.Text, 0, len(tokens))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(tokens))
+	expiresTimestamps := make([]pgtype.Timestamptz, 0, len(tokens))
+	lastUsedTimestamps := make([]pgtype.Timestamptz, 0, len(tokens))
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+		tokenHashes = append(tokenHashes, token.TokenHash)
+		userIDs = append(userIDs, token.UserID)
+		names = append(names, token.Name)
+		budgetIDs = append(budgetIDs, token.BudgetID)
+		scopes = append(scopes, token.Scopes)
+		createdTimestamps = append(createdTimestamps, token.CreatedTimestamp)
+		expiresTimestamps = append(expiresTimestamps, token.ExpiresTimestamp)
+		lastUsedTimestamps = append(lastUsedTimestamps, token.LastUsedTimestamp)
+	}
+	return []any{
+		ids,
+		tokenHashes,
+		userIDs,
+		names,
+		budgetIDs,
+		scopes,
+		createdTimestamps,
+		expiresTimestamps,
+		lastUsedTimestamps,
+	}
+}
@@ -0,0 +1,99 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testAPITokens() []*db.APIToken {
+	return []*db.APIToken{
+		{
+			ID:               pgtype.Text{String: "id-1", Valid: true},
+			TokenHash:        pgtype.Text{String: "token_hash-1", Valid: true},
+			UserID:           pgtype.Text{String: "id-1", Valid: true},
+			Name:             pgtype.Text{String: "name-1", Valid: true},
+			BudgetID:         pgtype.Text{String: "default", Valid: true},
+			Scopes:           pgtype.Text{String: "accounts:read transactions:write", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond), Valid: true},
+		},
+		{
+			ID:                pgtype.Text{String: "id-2", Valid: true},
+			TokenHash:         pgtype.Text{String: "token_hash-2", Valid: true},
+			UserID:            pgtype.Text{String: "id-1", Valid: true},
+			Name:              pgtype.Text{String: "name-2", Valid: true},
+			Scopes:            pgtype.Text{String: "accounts:read", Valid: true},
+			CreatedTimestamp:  pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			LastUsedTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		},
+		{
+			ID:               pgtype.Text{String: "id-3", Valid: true},
+			TokenHash:        pgtype.Text{String: "token_hash-3", Valid: true},
+			UserID:           pgtype.Text{String: "id-2", Valid: true},
+			Name:             pgtype.Text{String: "name-3", Valid: true},
+			Scopes:           pgtype.Text{String: "accounts:sync", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		},
+		{
+			ID:               pgtype.Text{String: "id-4", Valid: true},
+			TokenHash:        pgtype.Text{String: "token_hash-4", Valid: true},
+			UserID:           pgtype.Text{String: "id-2", Valid: true},
+			Name:             pgtype.Text{String: "name-4", Valid: true},
+			Scopes:           pgtype.Text{String: "accounts:read", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: time.Unix(5, 0).UTC(), Valid: true},
+		},
+	}
+}
+
+func (s *dbSuite) TestAPITokens() {
+	_, err := s.db.InsertUsers(context.Background(), s.conn, testUsers()...)
+	s.Require().NoError(err)
+	tokens := testAPITokens()
+	ids, err := s.db.InsertAPITokens(context.Background(), s.conn, tokens...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-3", "id-4"}, ids)
+
+	s.Run("SelectByUser", func() {
+		actualTokens, err := s.db.SelectAPITokensByUser(context.Background(), s.conn, "id-1")
+		s.NoError(err)
+		s.CMPEqual(tokens[:2], actualTokens)
+	})
+
+	s.Run("SelectByTokenHash", func() {
+		actualTokens, err := s.db.SelectAPITokensByTokenHash(context.Background(), s.conn, "token_hash-3", "token_hash-5")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.APIToken{"token_hash-3": tokens[2]}, actualTokens)
+	})
+
+	s.Run("SelectByTokenHashNotExpired", func() {
+		actualTokens, err := s.db.SelectAPITokensByTokenHash(context.Background(), s.conn, "token_hash-1", "token_hash-4")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.APIToken{"token_hash-1": tokens[0]}, actualTokens)
+	})
+
+	s.Run("UpdateLastUsedTimestamp", func() {
+		lastUsed := pgtype.Timestamptz{Time: time.Unix(10, 0).UTC(), Valid: true}
+		ids, err := s.db.UpdateAPITokenLastUsedTimestamp(context.Background(), s.conn, "id-1", lastUsed)
+		s.NoError(err)
+		s.Equal([]string{"id-1"}, ids)
+		tokens[0].LastUsedTimestamp = lastUsed
+
+		actualTokens, err := s.db.SelectAPITokensByUser(context.Background(), s.conn, "id-1")
+		s.NoError(err)
+		s.CMPEqual(tokens[:2], actualTokens)
+	})
+
+	s.Run("DeleteOnlyOfUser", func() {
+		ids, err := s.db.DeleteAPITokens(context.Background(), s.conn, "id-1", "id-2", "id-3")
+		s.NoError(err)
+		s.Equal([]string{"id-2"}, ids)
+
+		actualTokens, err := s.db.SelectAPITokensByTokenHash(context.Background(), s.conn, "token_hash-1", "token_hash-2", "token_hash-3")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.APIToken{"token_hash-1": tokens[0], "token_hash-3": tokens[2]}, actualTokens)
+	})
+}
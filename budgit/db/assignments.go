@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type Assignment struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                 pgtype.Text        `db:"id"`
+	CategoryID         pgtype.Text        `db:"category_id"`
+	Month              pgtype.Date        `db:"month"`
+	Amount             pgtype.Int8        `db:"amount"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
+}
+
+func (a Assignment) GetID() string {
+	return a.ID.String
+}
+
+func (a Assignment) GetRequestID() string {
+	return a.RequestID.String
+}
+
+var (
+	assignmentColumns    = getAllDBColumns(Assignment{})
+	assignmentColumnsStr = strings.Join(assignmentColumns, ", ")
+)
+
+func (db DB) InsertAssignments(ctx context.Context, queryer Queryer, assignments ...*Assignment) ([]string, error) {
+	db.log.Debugw("Inserting assignments", zap.Int("number_of_assignments", len(assignments)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO assignments (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[],
+				$3::TIMESTAMPTZ[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::DATE[],
+				$7::BIGINT[],
+				$8::TEXT[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, assignmentColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, assignmentsToArgs(assignments)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d assignments: %w", len(assignments), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted assignments", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d assignments: %w", len(assignments), err)
+	}
+	db.log.Debugw("Inserted assignments scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) UpdateAssignmentValidToTimestamps(ctx context.Context, queryer Queryer, updates ...ValidToTimestampUpdate) ([]string, error) {
+	db.log.Debugw("Updating assignment valid to timestamps", zap.Int("number_of_assignments", len(updates)))
+
+	sql := `
+		UPDATE assignments
+		SET valid_to_timestamp = input.valid_to_timestamp
+		FROM 
+		(
+			SELECT id, valid_to_timestamp
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[]
+			)
+			AS u(id, valid_to_timestamp)
+		) AS input
+		WHERE assignments.valid_to_timestamp = 'infinity'
+		AND assignments.budget_id = current_setting('budgit.budget_id')
+		AND assignments.id = input.id
+		RETURNING assignments.id;
+	`
+
+	assignmentIDs := make([]pgtype.Text, 0, len(updates))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(updates))
+	for _, update := range updates {
+		assignmentIDs = append(assignmentIDs, update.ID)
+		validToTimestamps = append(validToTimestamps, update.ValidToTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, assignmentIDs, validToTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d assignment valid to timestamps: %w", len(updates), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated assignment valid to timestamps", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d assignment valid to timestamps: %w", len(updates), err)
+	}
+	db.log.Debugw("Updated assignment valid to timestamps scanned", zap.String("updated_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+// SelectAssignmentsByMonth returns the current Assignments for the given months, each given by its first day.
+func (db DB) SelectAssignmentsByMonth(ctx context.Context, queryer Queryer, months ...time.Time) ([]*Assignment, error) {
+	db.log.Debugw("Selecting assignments by month", zap.Int("number_of_months", len(months)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM assignments
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND month = ANY($1::DATE[])
+		ORDER BY month, category_id
+	`, assignmentColumnsStr)
+
+	dates := make([]pgtype.Date, 0, len(months))
+	for _, month := range months {
+		dates = append(dates, pgtype.Date{Time: month, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, dates)
+	if err != nil {
+		return nil, fmt.Errorf("selecting assignments by month: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected assignments by month", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	assignments, err := pgx.CollectRows(rows, pgx.RowToStructByName[Assignment])
+	if err != nil {
+		return nil, fmt.Errorf("selecting assignments by month: %w", err)
+	}
+	db.log.Debugw("Selected assignments by month scanned", zap.Int("number_of_assignments", len(assignments)))
+	return structsToPointers(assignments), nil
+}
+
+func assignmentsToArgs(assignments []*Assignment) []any {
+	requestIDs := make([]pgtype.Text, 0, len(assignments))
+	validFromTimestamps := make([]pgtype.Timestamptz, 0, len(assignments))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(assignments))
+	ids := make([]pgtype.Text, 0, len(assignments))
+	category_ids := make([]pgtype.Text, 0, len(assignments))
+	months := make([]pgtype.Date, 0, len(assignments))
+	amounts := make([]pgtype.Int8, 0, len(assignments))
+	createdBys := make([]pgtype.Text, 0, len(assignments))
+	for _, assignment := range assignments {
+		requestIDs = append(requestIDs, assignment.RequestID)
+		validFromTimestamps = append(validFromTimestamps, assignment.ValidFromTimestamp)
+		validToTimestamps = append(validToTimestamps, assignment.ValidToTimestamp)
+		ids = append(ids, assignment.ID)
+		category_ids = append(category_ids, assignment.CategoryID)
+		months = append(months, assignment.Month)
+		amounts = append(amounts, assignment.Amount)
+		createdBys = append(createdBys, assignment.CreatedBy)
+	}
+	return []any{
+		requestIDs,
+		validFromTimestamps,
+		validToTimestamps,
+		ids,
+		category_ids,
+		months,
+		amounts,
+		createdBys,
+	}
+}
@@ -0,0 +1,60 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testAssignments() []*db.Assignment {
+	assignments := make([]*db.Assignment, 0, 3)
+	for i := 1; i <= 3; i++ {
+		assignments = append(assignments, &db.Assignment{
+			RequestID:          pgtype.Text{String: fmt.Sprintf("request_id-%d", i), Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(int64(i), 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: fmt.Sprintf("id-%d", i), Valid: true},
+			CategoryID:         pgtype.Text{String: fmt.Sprintf("category_id-%d", i), Valid: true},
+			Month:              pgtype.Date{Time: time.Date(2000, time.Month(i), 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			Amount:             pgtype.Int8{Int64: int64(i), Valid: true},
+		})
+	}
+	return assignments
+}
+
+func (s *dbSuite) TestInsertAssignments() {
+	ids, err := s.db.InsertAssignments(context.Background(), s.conn, testAssignments()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-3"}, ids)
+}
+
+func (s *dbSuite) TestUpdateAssignmentValidToTimestamps() {
+	_, err := s.db.InsertAssignments(context.Background(), s.conn, testAssignments()...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateAssignmentValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.NoError(err)
+	s.Equal([]string{"id-2"}, ids)
+
+	assignments, err := s.db.SelectAssignmentsByMonth(context.Background(), s.conn,
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 2, 1, 0, 0, 0, 0, time.UTC))
+	s.NoError(err)
+	s.Len(assignments, 1)
+}
+
+func (s *dbSuite) TestSelectAssignmentsByMonth() {
+	assignments := testAssignments()
+	_, err := s.db.InsertAssignments(context.Background(), s.conn, assignments...)
+	s.Require().NoError(err)
+
+	actualAssignments, err := s.db.SelectAssignmentsByMonth(context.Background(), s.conn,
+		time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2000, 3, 1, 0, 0, 0, 0, time.UTC))
+	s.NoError(err)
+	s.CMPEqual([]*db.Assignment{assignments[0], assignments[2]}, actualAssignments)
+}
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type Attachment struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                 pgtype.Text        `db:"id"`
+	TransactionID      pgtype.Text        `db:"transaction_id"`
+	Name               pgtype.Text        `db:"name"`
+	ContentType        pgtype.Text        `db:"content_type"`
+	Size               pgtype.Int8        `db:"size"`
+	ExternalID         pgtype.Text        `db:"external_id"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
+}
+
+func (a Attachment) GetID() string {
+	return a.ID.String
+}
+
+func (a Attachment) GetRequestID() string {
+	return a.RequestID.String
+}
+
+var (
+	attachmentColumns    = getAllDBColumns(Attachment{})
+	attachmentColumnsStr = strings.Join(attachmentColumns, ", ")
+)
+
+func (db DB) InsertAttachments(ctx context.Context, queryer Queryer, attachments ...*Attachment) ([]string, error) {
+	db.log.Debugw("Inserting attachments", zap.Int("number_of_attachments", len(attachments)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO attachments (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[],
+				$3::TIMESTAMPTZ[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::TEXT[],
+				$7::TEXT[],
+				$8::BIGINT[],
+				$9::TEXT[],
+				$10::TEXT[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, attachmentColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, attachmentsToArgs(attachments)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d attachments: %w", len(attachments), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted attachments", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d attachments: %w", len(attachments), err)
+	}
+	db.log.Debugw("Inserted attachments scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) UpdateAttachmentValidToTimestamps(ctx context.Context, queryer Queryer, updates ...ValidToTimestampUpdate) ([]string, error) {
+	db.log.Debugw("Updating attachment valid to timestamps", zap.Int("number_of_attachments", len(updates)))
+
+	sql := `
+		UPDATE attachments
+		SET valid_to_timestamp = input.valid_to_timestamp
+		FROM 
+		(
+			SELECT id, valid_to_timestamp
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[]
+			)
+			AS u(id, valid_to_timestamp)
+		) AS input
+		WHERE attachments.valid_to_timestamp = 'infinity'
+		AND attachments.budget_id = current_setting('budgit.budget_id')
+		AND attachments.id = input.id
+		RETURNING attachments.id;
+	`
+
+	attachmentIDs := make([]pgtype.Text, 0, len(updates))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(updates))
+	for _, update := range updates {
+		attachmentIDs = append(attachmentIDs, update.ID)
+		validToTimestamps = append(validToTimestamps, update.ValidToTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, attachmentIDs, validToTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d attachment valid to timestamps: %w", len(updates), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated attachment valid to timestamps", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d attachment valid to timestamps: %w", len(updates), err)
+	}
+	db.log.Debugw("Updated attachment valid to timestamps scanned", zap.String("updated_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) SelectAttachmentsByTransaction(ctx context.Context, queryer Queryer, transactionID string) ([]*Attachment, error) {
+	db.log.Debug("Selecting attachments by transaction")
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM attachments
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND transaction_id = $1
+		ORDER BY name, id
+	`, attachmentColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: transactionID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("selecting attachments by transaction: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected attachments", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	attachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[Attachment])
+	if err != nil {
+		return nil, fmt.Errorf("selecting attachments by transaction: %w", err)
+	}
+	db.log.Debugw("Selected attachments scanned", zap.Int("number_of_attachments", len(attachments)))
+	return structsToPointers(attachments), nil
+}
+
+func (db DB) SelectAttachmentsByID(ctx context.Context, queryer Queryer, attachmentIDs ...string) (map[string]*Attachment, error) {
+	db.log.Debugw("Selecting attachments by ID", zap.String("attachment_ids", fmt.Sprintf("%+v", attachmentIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM attachments
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND id = ANY($1::TEXT[])
+	`, attachmentColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(attachmentIDs))
+	for _, id := range attachmentIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting attachments by ID: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected attachments by ID", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	attachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[Attachment])
+	if err != nil {
+		return nil, fmt.Errorf("selecting attachments by ID: %w", err)
+	}
+	db.log.Debugw("Selected attachments by ID scanned", zap.Int("number_of_attachments", len(attachments)))
+	return mapByID(structsToPointers(attachments)), nil
+}
+
+func attachmentsToArgs(attachments []*Attachment) []any {
+	requestIDs := make([]pgtype.Text, 0, len(attachments))
+	validFromTimestamps := make([]pgtype.Timestamptz, 0, len(attachments))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(attachments))
+	ids := make([]pgtype.Text, 0, len(attachments))
+	transaction_ids := make([]pgtype.Text, 0, len(attachments))
+	names := make([]pgtype.Text, 0, len(attachments))
+	content_types := make([]pgtype.Text, 0, len(attachments))
+	sizes := make([]pgtype.Int8, 0, len(attachments))
+	external_ids := make([]pgtype.Text, 0, len(attachments))
+	createdBys := make([]pgtype.Text, 0, len(attachments))
+	for _, attachment := range attachments {
+		requestIDs = append(requestIDs, attachment.RequestID)
+		validFromTimestamps = append(validFromTimestamps, attachment.ValidFromTimestamp)
+		validToTimestamps = append(validToTimestamps, attachment.ValidToTimestamp)
+		ids = append(ids, attachment.ID)
+		transaction_ids = append(transaction_ids, attachment.TransactionID)
+		names = append(names, attachment.Name)
+		content_types = append(content_types, attachment.ContentType)
+		sizes = append(sizes, attachment.Size)
+		external_ids = append(external_ids, attachment.ExternalID)
+		createdBys = append(createdBys, attachment.CreatedBy)
+	}
+	return []any{
+		requestIDs,
+		validFromTimestamps,
+		validToTimestamps,
+		ids,
+		transaction_ids,
+		names,
+		content_types,
+		sizes,
+		external_ids,
+		createdBys,
+	}
+}
@@ -0,0 +1,74 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testAttachments() []*db.Attachment {
+	attachments := make([]*db.Attachment, 0, 3)
+	for i := 1; i <= 3; i++ {
+		attachments = append(attachments, &db.Attachment{
+			RequestID:          pgtype.Text{String: fmt.Sprintf("request_id-%d", i), Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(int64(i), 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: fmt.Sprintf("id-%d", i), Valid: true},
+			TransactionID:      pgtype.Text{String: fmt.Sprintf("transaction_id-%d", (i+1)/2), Valid: true},
+			Name:               pgtype.Text{String: fmt.Sprintf("name-%d", i), Valid: true},
+			ContentType:        pgtype.Text{String: "application/pdf", Valid: true},
+			Size:               pgtype.Int8{Int64: int64(i), Valid: true},
+			ExternalID:         pgtype.Text{String: fmt.Sprintf("external_id-%d", i), Valid: true},
+		})
+	}
+	return attachments
+}
+
+func (s *dbSuite) TestInsertAttachments() {
+	ids, err := s.db.InsertAttachments(context.Background(), s.conn, testAttachments()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-3"}, ids)
+}
+
+func (s *dbSuite) TestUpdateAttachmentValidToTimestamps() {
+	_, err := s.db.InsertAttachments(context.Background(), s.conn, testAttachments()...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateAttachmentValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.NoError(err)
+	s.Equal([]string{"id-2"}, ids)
+
+	attachments, err := s.db.SelectAttachmentsByID(context.Background(), s.conn, "id-1", "id-2")
+	s.NoError(err)
+	s.Len(attachments, 1)
+}
+
+func (s *dbSuite) TestSelectAttachmentsByTransaction() {
+	attachments := testAttachments()
+	_, err := s.db.InsertAttachments(context.Background(), s.conn, attachments...)
+	s.Require().NoError(err)
+
+	actualAttachments, err := s.db.SelectAttachmentsByTransaction(context.Background(), s.conn, "transaction_id-1")
+	s.NoError(err)
+	s.CMPEqual(attachments[:2], actualAttachments)
+}
+
+func (s *dbSuite) TestSelectAttachmentsByID() {
+	attachments := testAttachments()
+	_, err := s.db.InsertAttachments(context.Background(), s.conn, attachments...)
+	s.Require().NoError(err)
+
+	expectedAttachments := map[string]*db.Attachment{
+		"id-1": attachments[0],
+		"id-3": attachments[2],
+	}
+	actualAttachments, err := s.db.SelectAttachmentsByID(context.Background(), s.conn, "id-1", "id-3")
+	s.NoError(err)
+	s.CMPEqual(expectedAttachments, actualAttachments)
+}
@@ -0,0 +1,457 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// SetBudget sets the budget the rows of the budget tables are read from and written to for the rest of a transaction.
+// Queries of those tables outside a transaction setting it return no rows, and inserts into them fail.
+func (db DB) SetBudget(ctx context.Context, queryer Queryer, budgetID string) error {
+	db.log.Debugw("Setting budget", zap.String("budget_id", budgetID))
+
+	var set string
+	if err := queryer.QueryRow(ctx, `SELECT set_config('budgit.budget_id', $1, TRUE)`, budgetID).Scan(&set); err != nil {
+		return fmt.Errorf("setting budget %q: %w", budgetID, err)
+	}
+	return nil
+}
+
+// Budget is a budget shared by its members. Budgets are not versioned.
+type Budget struct {
+	ID               pgtype.Text        `db:"id"`
+	Name             pgtype.Text        `db:"name"`
+	CreatedTimestamp pgtype.Timestamptz `db:"created_timestamp"`
+}
+
+func (b Budget) GetID() string {
+	return b.ID.String
+}
+
+var (
+	budgetColumns    = getAllDBColumns(Budget{})
+	budgetColumnsStr = strings.Join(budgetColumns, ", ")
+)
+
+func (db DB) InsertBudgets(ctx context.Context, queryer Queryer, budgets ...*Budget) ([]string, error) {
+	db.log.Debugw("Inserting budgets", zap.Int("number_of_budgets", len(budgets)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO budgets (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, budgetColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, budgetsToArgs(budgets)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budgets: %w", len(budgets), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted budgets", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budgets: %w", len(budgets), err)
+	}
+	db.log.Debugw("Inserted budgets scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) SelectBudgets(ctx context.Context, queryer Queryer) ([]*Budget, error) {
+	db.log.Debug("Selecting budgets")
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM budgets
+		ORDER BY name, id
+	`, budgetColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("selecting budgets: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected budgets", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	budgets, err := pgx.CollectRows(rows, pgx.RowToStructByName[Budget])
+	if err != nil {
+		return nil, fmt.Errorf("selecting budgets: %w", err)
+	}
+	db.log.Debugw("Selected budgets scanned", zap.Int("number_of_budgets", len(budgets)))
+	return structsToPointers(budgets), nil
+}
+
+func (db DB) SelectBudgetsByID(ctx context.Context, queryer Queryer, budgetIDs ...string) (map[string]*Budget, error) {
+	db.log.Debugw("Selecting budgets by ID", zap.String("budget_ids", fmt.Sprintf("%+v", budgetIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM budgets
+		WHERE id = ANY($1::TEXT[])
+	`, budgetColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(budgetIDs))
+	for _, id := range budgetIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting budgets by ID: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected budgets by ID", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	budgets, err := pgx.CollectRows(rows, pgx.RowToStructByName[Budget])
+	if err != nil {
+		return nil, fmt.Errorf("selecting budgets by ID: %w", err)
+	}
+	db.log.Debugw("Selected budgets by ID scanned", zap.Int("number_of_budgets", len(budgets)))
+	return mapByID(structsToPointers(budgets)), nil
+}
+
+func budgetsToArgs(budgets []*Budget) []any {
+	ids := make([]pgtype.Text, 0, len(budgets))
+	names := make([]pgtype.Text, 0, len(budgets))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(budgets))
+	for _, budget := range budgets {
+		ids = append(ids, budget.ID)
+		names = append(names, budget.Name)
+		createdTimestamps = append(createdTimestamps, budget.CreatedTimestamp)
+	}
+	return []any{
+		ids,
+		names,
+		createdTimestamps,
+	}
+}
+
+// BudgetMember is the membership of a User in a Budget, with the role they have in it.
+type BudgetMember struct {
+	BudgetID         pgtype.Text        `db:"budget_id"`
+	UserID           pgtype.Text        `db:"user_id"`
+	Role             pgtype.Text        `db:"role"`
+	CreatedTimestamp pgtype.Timestamptz `db:"created_timestamp"`
+}
+
+var (
+	budgetMemberColumns    = getAllDBColumns(BudgetMember{})
+	budgetMemberColumnsStr = strings.Join(budgetMemberColumns, ", ")
+)
+
+// InsertBudgetMembers inserts members of budgets, skipping Users who are already members, and returns the IDs of the
+// Users inserted.
+func (db DB) InsertBudgetMembers(ctx context.Context, queryer Queryer, members ...*BudgetMember) ([]string, error) {
+	db.log.Debugw("Inserting budget members", zap.Int("number_of_budget_members", len(members)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO budget_members (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TEXT[],
+				$4::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING user_id;
+	`, budgetMemberColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, budgetMembersToArgs(members)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budget members: %w", len(members), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted budget members", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budget members: %w", len(members), err)
+	}
+	return ids, nil
+}
+
+// UpdateBudgetMemberRole sets the role of a member of a budget, and returns the ID of the User if they are a member.
+func (db DB) UpdateBudgetMemberRole(ctx context.Context, queryer Queryer, budgetID, userID string, role pgtype.Text) ([]string, error) {
+	db.log.Debugw("Updating budget member role", zap.String("budget_id", budgetID), zap.String("user_id", userID))
+
+	sql := `
+		UPDATE budget_members
+		SET role = $3
+		WHERE budget_id = $1
+		AND user_id = $2
+		RETURNING user_id;
+	`
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: budgetID, Valid: true}, pgtype.Text{String: userID, Valid: true}, role)
+	if err != nil {
+		return nil, fmt.Errorf("updating budget member role: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated budget member role", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating budget member role: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteBudgetMembers removes the given Users from a budget, and returns the IDs of those removed.
+func (db DB) DeleteBudgetMembers(ctx context.Context, queryer Queryer, budgetID string, userIDs ...string) ([]string, error) {
+	db.log.Debugw("Deleting budget members", zap.String("budget_id", budgetID), zap.String("user_ids", fmt.Sprintf("%+v", userIDs)))
+
+	sql := `
+		DELETE FROM budget_members
+		WHERE budget_id = $1
+		AND user_id = ANY($2::TEXT[])
+		RETURNING user_id;
+	`
+
+	ids := make([]pgtype.Text, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: budgetID, Valid: true}, ids)
+	if err != nil {
+		return nil, fmt.Errorf("deleting budget members: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Deleted budget members", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	deletedIDs, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("deleting budget members: %w", err)
+	}
+	return deletedIDs, nil
+}
+
+// SelectBudgetMembers returns the members of a budget, oldest first.
+func (db DB) SelectBudgetMembers(ctx context.Context, queryer Queryer, budgetID string) ([]*BudgetMember, error) {
+	db.log.Debugw("Selecting budget members", zap.String("budget_id", budgetID))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM budget_members
+		WHERE budget_id = $1
+		ORDER BY created_timestamp, user_id
+	`, budgetMemberColumnsStr)
+
+	return db.selectBudgetMembers(ctx, queryer, sql, budgetID)
+}
+
+// SelectBudgetMembersByUser returns the memberships of a User, oldest first.
+func (db DB) SelectBudgetMembersByUser(ctx context.Context, queryer Queryer, userID string) ([]*BudgetMember, error) {
+	db.log.Debugw("Selecting budget members by user", zap.String("user_id", userID))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM budget_members
+		WHERE user_id = $1
+		ORDER BY created_timestamp, budget_id
+	`, budgetMemberColumnsStr)
+
+	return db.selectBudgetMembers(ctx, queryer, sql, userID)
+}
+
+func (db DB) selectBudgetMembers(ctx context.Context, queryer Queryer, sql, id string) ([]*BudgetMember, error) {
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: id, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("selecting budget members: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected budget members", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	members, err := pgx.CollectRows(rows, pgx.RowToStructByName[BudgetMember])
+	if err != nil {
+		return nil, fmt.Errorf("selecting budget members: %w", err)
+	}
+	db.log.Debugw("Selected budget members scanned", zap.Int("number_of_budget_members", len(members)))
+	return structsToPointers(members), nil
+}
+
+func budgetMembersToArgs(members []*BudgetMember) []any {
+	budgetIDs := make([]pgtype.Text, 0, len(members))
+	userIDs := make([]pgtype.Text, 0, len(members))
+	roles := make([]pgtype.Text, 0, len(members))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(members))
+	for _, member := range members {
+		budgetIDs = append(budgetIDs, member.BudgetID)
+		userIDs = append(userIDs, member.UserID)
+		roles = append(roles, member.Role)
+		createdTimestamps = append(createdTimestamps, member.CreatedTimestamp)
+	}
+	return []any{
+		budgetIDs,
+		userIDs,
+		roles,
+		createdTimestamps,
+	}
+}
+
+// BudgetInvitation invites whoever has its token to become a member of a budget. Only the hash of the token is stored.
+type BudgetInvitation struct {
+	ID               pgtype.Text        `db:"id"`
+	TokenHash        pgtype.Text        `db:"token_hash"`
+	BudgetID         pgtype.Text        `db:"budget_id"`
+	Role             pgtype.Text        `db:"role"`
+	CreatedBy        pgtype.Text        `db:"created_by"`
+	CreatedTimestamp pgtype.Timestamptz `db:"created_timestamp"`
+	ExpiresTimestamp pgtype.Timestamptz `db:"expires_timestamp"`
+}
+
+func (i BudgetInvitation) GetTokenHash() string {
+	return i.TokenHash.String
+}
+
+var (
+	budgetInvitationColumns    = getAllDBColumns(BudgetInvitation{})
+	budgetInvitationColumnsStr = strings.Join(budgetInvitationColumns, ", ")
+)
+
+func (db DB) InsertBudgetInvitations(ctx context.Context, queryer Queryer, invitations ...*BudgetInvitation) ([]string, error) {
+	db.log.Debugw("Inserting budget invitations", zap.Int("number_of_budget_invitations", len(invitations)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO budget_invitations (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TEXT[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::TIMESTAMPTZ[],
+				$7::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, budgetInvitationColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, budgetInvitationsToArgs(invitations)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budget invitations: %w", len(invitations), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted budget invitations", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d budget invitations: %w", len(invitations), err)
+	}
+	return ids, nil
+}
+
+// SelectBudgetInvitationsByTokenHash returns the invitations with the given token hashes which have not expired.
+func (db DB) SelectBudgetInvitationsByTokenHash(ctx context.Context, queryer Queryer, tokenHashes ...string) (map[string]*BudgetInvitation, error) {
+	db.log.Debugw("Selecting budget invitations by token hash", zap.Int("number_of_token_hashes", len(tokenHashes)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM budget_invitations
+		WHERE token_hash = ANY($1::TEXT[])
+		AND expires_timestamp > NOW()
+	`, budgetInvitationColumnsStr)
+
+	hashes := make([]pgtype.Text, 0, len(tokenHashes))
+	for _, hash := range tokenHashes {
+		hashes = append(hashes, pgtype.Text{String: hash, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("selecting budget invitations by token hash: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected budget invitations by token hash", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	invitations, err := pgx.CollectRows(rows, pgx.RowToStructByName[BudgetInvitation])
+	if err != nil {
+		return nil, fmt.Errorf("selecting budget invitations by token hash: %w", err)
+	}
+
+	invitationsByTokenHash := make(map[string]*BudgetInvitation, len(invitations))
+	for _, invitation := range structsToPointers(invitations) {
+		invitationsByTokenHash[invitation.GetTokenHash()] = invitation
+	}
+	return invitationsByTokenHash, nil
+}
+
+// DeleteBudgetInvitations deletes invitations to a budget with the given IDs, and returns the IDs of those deleted.
+func (db DB) DeleteBudgetInvitations(ctx context.Context, queryer Queryer, budgetID string, invitationIDs ...string) ([]string, error) {
+	db.log.Debugw("Deleting budget invitations", zap.String("budget_id", budgetID), zap.String("budget_invitation_ids", fmt.Sprintf("%+v", invitationIDs)))
+
+	sql := `
+		DELETE FROM budget_invitations
+		WHERE budget_id = $1
+		AND id = ANY($2::TEXT[])
+		RETURNING id;
+	`
+
+	ids := make([]pgtype.Text, 0, len(invitationIDs))
+	for _, id := range invitationIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: budgetID, Valid: true}, ids)
+	if err != nil {
+		return nil, fmt.Errorf("deleting budget invitations: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Deleted budget invitations", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	deletedIDs, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("deleting budget invitations: %w", err)
+	}
+	return deletedIDs, nil
+}
+
+func budgetInvitationsToArgs(invitations []*BudgetInvitation) []any {
+	ids := make([]pgtype.Text, 0, len(invitations))
+	tokenHashes := make([]pgtype.Text, 0, len(invitations))
+	budgetIDs := make([]pgtype.Text, 0, len(invitations))
+	roles := make([]pgtype.Text, 0, len(invitations))
+	createdBys := make([]pgtype.Text, 0, len(invitations))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(invitations))
+	expiresTimestamps := make([]pgtype.Timestamptz, 0, len(invitations))
+	for _, invitation := range invitations {
+		ids = append(ids, invitation.ID)
+		tokenHashes = append(tokenHashes, invitation.TokenHash)
+		budgetIDs = append(budgetIDs, invitation.BudgetID)
+		roles = append(roles, invitation.Role)
+		createdBys = append(createdBys, invitation.CreatedBy)
+		createdTimestamps = append(createdTimestamps, invitation.CreatedTimestamp)
+		expiresTimestamps = append(expiresTimestamps, invitation.ExpiresTimestamp)
+	}
+	return []any{
+		ids,
+		tokenHashes,
+		budgetIDs,
+		roles,
+		createdBys,
+		createdTimestamps,
+		expiresTimestamps,
+	}
+}
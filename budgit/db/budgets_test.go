@@ -0,0 +1,221 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testBudgets() []*db.Budget {
+	return []*db.Budget{
+		{
+			ID:               pgtype.Text{String: "id-1", Valid: true},
+			Name:             pgtype.Text{String: "name-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+		},
+		{
+			ID:               pgtype.Text{String: "id-2", Valid: true},
+			Name:             pgtype.Text{String: "name-2", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+		},
+	}
+}
+
+func (s *dbSuite) TestBudgets() {
+	budgets := testBudgets()
+	ids, err := s.db.InsertBudgets(context.Background(), s.conn, budgets...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2"}, ids)
+
+	s.Run("SelectByID", func() {
+		actualBudgets, err := s.db.SelectBudgetsByID(context.Background(), s.conn, "id-2", "id-3")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.Budget{"id-2": budgets[1]}, actualBudgets)
+	})
+
+	s.Run("SelectIncludesDefault", func() {
+		actualBudgets, err := s.db.SelectBudgets(context.Background(), s.conn)
+		s.NoError(err)
+		s.Len(actualBudgets, 3)
+	})
+}
+
+func (s *dbSuite) TestBudgetMembers() {
+	_, err := s.db.InsertUsers(context.Background(), s.conn, testUsers()...)
+	s.Require().NoError(err)
+	_, err = s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	members := []*db.BudgetMember{
+		{
+			BudgetID:         pgtype.Text{String: "id-1", Valid: true},
+			UserID:           pgtype.Text{String: "id-1", Valid: true},
+			Role:             pgtype.Text{String: "owner", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+		},
+		{
+			BudgetID:         pgtype.Text{String: "id-1", Valid: true},
+			UserID:           pgtype.Text{String: "id-2", Valid: true},
+			Role:             pgtype.Text{String: "viewer", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+		},
+		{
+			BudgetID:         pgtype.Text{String: "id-2", Valid: true},
+			UserID:           pgtype.Text{String: "id-2", Valid: true},
+			Role:             pgtype.Text{String: "owner", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		},
+	}
+	ids, err := s.db.InsertBudgetMembers(context.Background(), s.conn, members...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-2"}, ids)
+
+	s.Run("AlreadyMember", func() {
+		ids, err := s.db.InsertBudgetMembers(context.Background(), s.conn, members[0])
+		s.NoError(err)
+		s.Empty(ids)
+	})
+
+	s.Run("InvalidRole", func() {
+		_, err := s.db.InsertBudgetMembers(context.Background(), s.conn, &db.BudgetMember{
+			BudgetID:         pgtype.Text{String: "id-2", Valid: true},
+			UserID:           pgtype.Text{String: "id-1", Valid: true},
+			Role:             pgtype.Text{String: "admin", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+		})
+		s.Error(err)
+	})
+
+	s.Run("Select", func() {
+		actualMembers, err := s.db.SelectBudgetMembers(context.Background(), s.conn, "id-1")
+		s.NoError(err)
+		s.CMPEqual(members[:2], actualMembers)
+	})
+
+	s.Run("SelectByUser", func() {
+		actualMembers, err := s.db.SelectBudgetMembersByUser(context.Background(), s.conn, "id-2")
+		s.NoError(err)
+		s.CMPEqual(members[1:], actualMembers)
+	})
+
+	s.Run("UpdateRole", func() {
+		ids, err := s.db.UpdateBudgetMemberRole(context.Background(), s.conn, "id-1", "id-2", pgtype.Text{String: "editor", Valid: true})
+		s.NoError(err)
+		s.Equal([]string{"id-2"}, ids)
+		members[1].Role = pgtype.Text{String: "editor", Valid: true}
+
+		actualMembers, err := s.db.SelectBudgetMembers(context.Background(), s.conn, "id-1")
+		s.NoError(err)
+		s.CMPEqual(members[:2], actualMembers)
+	})
+
+	s.Run("DeleteOnlyOfBudget", func() {
+		ids, err := s.db.DeleteBudgetMembers(context.Background(), s.conn, "id-2", "id-1", "id-2")
+		s.NoError(err)
+		s.Equal([]string{"id-2"}, ids)
+
+		actualMembers, err := s.db.SelectBudgetMembersByUser(context.Background(), s.conn, "id-2")
+		s.NoError(err)
+		s.CMPEqual(members[1:2], actualMembers)
+	})
+}
+
+func (s *dbSuite) TestBudgetInvitations() {
+	_, err := s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	invitations := []*db.BudgetInvitation{
+		{
+			ID:               pgtype.Text{String: "id-1", Valid: true},
+			TokenHash:        pgtype.Text{String: "token_hash-1", Valid: true},
+			BudgetID:         pgtype.Text{String: "id-1", Valid: true},
+			Role:             pgtype.Text{String: "editor", Valid: true},
+			CreatedBy:        pgtype.Text{String: "created_by-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond), Valid: true},
+		},
+		{
+			ID:               pgtype.Text{String: "id-2", Valid: true},
+			TokenHash:        pgtype.Text{String: "token_hash-2", Valid: true},
+			BudgetID:         pgtype.Text{String: "id-1", Valid: true},
+			Role:             pgtype.Text{String: "viewer", Valid: true},
+			CreatedBy:        pgtype.Text{String: "created_by-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		},
+	}
+	ids, err := s.db.InsertBudgetInvitations(context.Background(), s.conn, invitations...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2"}, ids)
+
+	s.Run("SelectByTokenHashNotExpired", func() {
+		actualInvitations, err := s.db.SelectBudgetInvitationsByTokenHash(context.Background(), s.conn, "token_hash-1", "token_hash-2")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.BudgetInvitation{"token_hash-1": invitations[0]}, actualInvitations)
+	})
+
+	s.Run("DeleteOnlyOfBudget", func() {
+		ids, err := s.db.DeleteBudgetInvitations(context.Background(), s.conn, "id-2", "id-1")
+		s.NoError(err)
+		s.Empty(ids)
+
+		ids, err = s.db.DeleteBudgetInvitations(context.Background(), s.conn, "id-1", "id-1")
+		s.NoError(err)
+		s.Equal([]string{"id-1"}, ids)
+	})
+}
+
+func (s *dbSuite) TestSetBudget() {
+	_, err := s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	groups := testCategoryGroups()
+	_, err = s.db.InsertCategoryGroups(context.Background(), s.conn, groups[0])
+	s.Require().NoError(err)
+
+	tx, err := s.conn.Begin(context.Background())
+	s.Require().NoError(err)
+	defer tx.Rollback(context.Background())
+	s.Require().NoError(s.db.SetBudget(context.Background(), tx, "id-1"))
+
+	actualGroups, err := s.db.SelectCategoryGroups(context.Background(), tx)
+	s.NoError(err)
+	s.Empty(actualGroups, "expected category groups of another budget not to be selected")
+
+	_, err = s.db.InsertCategoryGroups(context.Background(), tx, groups[1])
+	s.Require().NoError(err)
+	actualGroups, err = s.db.SelectCategoryGroups(context.Background(), tx)
+	s.NoError(err)
+	s.CMPEqual([]*db.CategoryGroup{groups[1]}, actualGroups)
+}
+
+func (s *dbSuite) TestRowLevelSecurity() {
+	_, err := s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	_, err = s.db.InsertCategoryGroups(context.Background(), s.conn, testCategoryGroups()...)
+	s.Require().NoError(err)
+
+	// The test connects as a superuser, which policies do not apply to, so queries run as a role which is not.
+	tx, err := s.conn.Begin(context.Background())
+	s.Require().NoError(err)
+	defer tx.Rollback(context.Background())
+	for _, sql := range []string{
+		`CREATE ROLE budgit_rls_test NOSUPERUSER NOBYPASSRLS`,
+		`GRANT SELECT, INSERT ON category_groups TO budgit_rls_test`,
+		`SET LOCAL ROLE budgit_rls_test`,
+	} {
+		_, err := tx.Exec(context.Background(), sql)
+		s.Require().NoError(err, sql)
+	}
+	s.Require().NoError(s.db.SetBudget(context.Background(), tx, "id-1"))
+
+	var count int
+	s.Require().NoError(tx.QueryRow(context.Background(), `SELECT COUNT(*) FROM category_groups`).Scan(&count))
+	s.Zero(count, "expected the policy to hide category groups of another budget from a query which does not filter by budget")
+
+	_, err = tx.Exec(context.Background(), `SAVEPOINT insert_other_budget`)
+	s.Require().NoError(err)
+	_, err = tx.Exec(context.Background(), `INSERT INTO category_groups (request_id, id, name, budget_id) VALUES ('request_id-9', 'id-9', 'name-9', 'default')`)
+	s.Error(err, "expected the policy to refuse rows of another budget")
+	_, err = tx.Exec(context.Background(), `ROLLBACK TO SAVEPOINT insert_other_budget`)
+	s.Require().NoError(err)
+}
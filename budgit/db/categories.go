@@ -0,0 +1,382 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type CategoryGroup struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                 pgtype.Text        `db:"id"`
+	Name               pgtype.Text        `db:"name"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
+}
+
+func (c CategoryGroup) GetID() string {
+	return c.ID.String
+}
+
+func (c CategoryGroup) GetRequestID() string {
+	return c.RequestID.String
+}
+
+var (
+	categoryGroupColumns    = getAllDBColumns(CategoryGroup{})
+	categoryGroupColumnsStr = strings.Join(categoryGroupColumns, ", ")
+)
+
+func (db DB) InsertCategoryGroups(ctx context.Context, queryer Queryer, categoryGroups ...*CategoryGroup) ([]string, error) {
+	db.log.Debugw("Inserting category groups", zap.Int("number_of_category_groups", len(categoryGroups)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO category_groups (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[],
+				$3::TIMESTAMPTZ[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::TEXT[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, categoryGroupColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, categoryGroupsToArgs(categoryGroups)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d category groups: %w", len(categoryGroups), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted category groups", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d category groups: %w", len(categoryGroups), err)
+	}
+	db.log.Debugw("Inserted category groups scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) UpdateCategoryGroupValidToTimestamps(ctx context.Context, queryer Queryer, updates ...ValidToTimestampUpdate) ([]string, error) {
+	db.log.Debugw("Updating category group valid to timestamps", zap.Int("number_of_category_groups", len(updates)))
+
+	sql := `
+		UPDATE category_groups
+		SET valid_to_timestamp = input.valid_to_timestamp
+		FROM 
+		(
+			SELECT id, valid_to_timestamp
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[]
+			)
+			AS u(id, valid_to_timestamp)
+		) AS input
+		WHERE category_groups.valid_to_timestamp = 'infinity'
+		AND category_groups.budget_id = current_setting('budgit.budget_id')
+		AND category_groups.id = input.id
+		RETURNING category_groups.id;
+	`
+
+	categoryGroupIDs := make([]pgtype.Text, 0, len(updates))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(updates))
+	for _, update := range updates {
+		categoryGroupIDs = append(categoryGroupIDs, update.ID)
+		validToTimestamps = append(validToTimestamps, update.ValidToTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, categoryGroupIDs, validToTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d category group valid to timestamps: %w", len(updates), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated category group valid to timestamps", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d category group valid to timestamps: %w", len(updates), err)
+	}
+	db.log.Debugw("Updated category group valid to timestamps scanned", zap.String("updated_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) SelectCategoryGroups(ctx context.Context, queryer Queryer) ([]*CategoryGroup, error) {
+	db.log.Debug("Selecting category groups")
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM category_groups
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		ORDER BY name, id
+	`, categoryGroupColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("selecting category groups: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected category groups", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	categoryGroups, err := pgx.CollectRows(rows, pgx.RowToStructByName[CategoryGroup])
+	if err != nil {
+		return nil, fmt.Errorf("selecting category groups: %w", err)
+	}
+	db.log.Debugw("Selected category groups scanned", zap.Int("number_of_category_groups", len(categoryGroups)))
+	return structsToPointers(categoryGroups), nil
+}
+
+func (db DB) SelectCategoryGroupsByID(ctx context.Context, queryer Queryer, categoryGroupIDs ...string) (map[string]*CategoryGroup, error) {
+	db.log.Debugw("Selecting category groups by ID", zap.String("categoryGroup_ids", fmt.Sprintf("%+v", categoryGroupIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM category_groups
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND id = ANY($1::TEXT[])
+	`, categoryGroupColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(categoryGroupIDs))
+	for _, id := range categoryGroupIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting category groups by ID: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected category groups by ID", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	categoryGroups, err := pgx.CollectRows(rows, pgx.RowToStructByName[CategoryGroup])
+	if err != nil {
+		return nil, fmt.Errorf("selecting category groups by ID: %w", err)
+	}
+	db.log.Debugw("Selected category groups by ID scanned", zap.Int("number_of_category_groups", len(categoryGroups)))
+	return mapByID(structsToPointers(categoryGroups)), nil
+}
+
+func categoryGroupsToArgs(categoryGroups []*CategoryGroup) []any {
+	requestIDs := make([]pgtype.Text, 0, len(categoryGroups))
+	validFromTimestamps := make([]pgtype.Timestamptz, 0, len(categoryGroups))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(categoryGroups))
+	ids := make([]pgtype.Text, 0, len(categoryGroups))
+	names := make([]pgtype.Text, 0, len(categoryGroups))
+	createdBys := make([]pgtype.Text, 0, len(categoryGroups))
+	for _, categoryGroup := range categoryGroups {
+		requestIDs = append(requestIDs, categoryGroup.RequestID)
+		validFromTimestamps = append(validFromTimestamps, categoryGroup.ValidFromTimestamp)
+		validToTimestamps = append(validToTimestamps, categoryGroup.ValidToTimestamp)
+		ids = append(ids, categoryGroup.ID)
+		names = append(names, categoryGroup.Name)
+		createdBys = append(createdBys, categoryGroup.CreatedBy)
+	}
+	return []any{
+		requestIDs,
+		validFromTimestamps,
+		validToTimestamps,
+		ids,
+		names,
+		createdBys,
+	}
+}
+
+type Category struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                 pgtype.Text        `db:"id"`
+	GroupID            pgtype.Text        `db:"group_id"`
+	Name               pgtype.Text        `db:"name"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
+}
+
+func (c Category) GetID() string {
+	return c.ID.String
+}
+
+func (c Category) GetRequestID() string {
+	return c.RequestID.String
+}
+
+var (
+	categoryColumns    = getAllDBColumns(Category{})
+	categoryColumnsStr = strings.Join(categoryColumns, ", ")
+)
+
+func (db DB) InsertCategories(ctx context.Context, queryer Queryer, categories ...*Category) ([]string, error) {
+	db.log.Debugw("Inserting categories", zap.Int("number_of_categories", len(categories)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO categories (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[],
+				$3::TIMESTAMPTZ[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::TEXT[],
+				$7::TEXT[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, categoryColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, categoriesToArgs(categories)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d categories: %w", len(categories), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted categories", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d categories: %w", len(categories), err)
+	}
+	db.log.Debugw("Inserted categories scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) UpdateCategoryValidToTimestamps(ctx context.Context, queryer Queryer, updates ...ValidToTimestampUpdate) ([]string, error) {
+	db.log.Debugw("Updating category valid to timestamps", zap.Int("number_of_categories", len(updates)))
+
+	sql := `
+		UPDATE categories
+		SET valid_to_timestamp = input.valid_to_timestamp
+		FROM 
+		(
+			SELECT id, valid_to_timestamp
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[]
+			)
+			AS u(id, valid_to_timestamp)
+		) AS input
+		WHERE categories.valid_to_timestamp = 'infinity'
+		AND categories.budget_id = current_setting('budgit.budget_id')
+		AND categories.id = input.id
+		RETURNING categories.id;
+	`
+
+	categoryIDs := make([]pgtype.Text, 0, len(updates))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(updates))
+	for _, update := range updates {
+		categoryIDs = append(categoryIDs, update.ID)
+		validToTimestamps = append(validToTimestamps, update.ValidToTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, categoryIDs, validToTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d category valid to timestamps: %w", len(updates), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated category valid to timestamps", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d category valid to timestamps: %w", len(updates), err)
+	}
+	db.log.Debugw("Updated category valid to timestamps scanned", zap.String("updated_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+func (db DB) SelectCategories(ctx context.Context, queryer Queryer) ([]*Category, error) {
+	db.log.Debug("Selecting categories")
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM categories
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		ORDER BY group_id, name, id
+	`, categoryColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("selecting categories: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected categories", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[Category])
+	if err != nil {
+		return nil, fmt.Errorf("selecting categories: %w", err)
+	}
+	db.log.Debugw("Selected categories scanned", zap.Int("number_of_categories", len(categories)))
+	return structsToPointers(categories), nil
+}
+
+func (db DB) SelectCategoriesByID(ctx context.Context, queryer Queryer, categoryIDs ...string) (map[string]*Category, error) {
+	db.log.Debugw("Selecting categories by ID", zap.String("category_ids", fmt.Sprintf("%+v", categoryIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM categories
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND id = ANY($1::TEXT[])
+	`, categoryColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting categories by ID: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected categories by ID", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[Category])
+	if err != nil {
+		return nil, fmt.Errorf("selecting categories by ID: %w", err)
+	}
+	db.log.Debugw("Selected categories by ID scanned", zap.Int("number_of_categories", len(categories)))
+	return mapByID(structsToPointers(categories)), nil
+}
+
+func categoriesToArgs(categories []*Category) []any {
+	requestIDs := make([]pgtype.Text, 0, len(categories))
+	validFromTimestamps := make([]pgtype.Timestamptz, 0, len(categories))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(categories))
+	ids := make([]pgtype.Text, 0, len(categories))
+	group_ids := make([]pgtype.Text, 0, len(categories))
+	names := make([]pgtype.Text, 0, len(categories))
+	createdBys := make([]pgtype.Text, 0, len(categories))
+	for _, category := range categories {
+		requestIDs = append(requestIDs, category.RequestID)
+		validFromTimestamps = append(validFromTimestamps, category.ValidFromTimestamp)
+		validToTimestamps = append(validToTimestamps, category.ValidToTimestamp)
+		ids = append(ids, category.ID)
+		group_ids = append(group_ids, category.GroupID)
+		names = append(names, category.Name)
+		createdBys = append(createdBys, category.CreatedBy)
+	}
+	return []any{
+		requestIDs,
+		validFromTimestamps,
+		validToTimestamps,
+		ids,
+		group_ids,
+		names,
+		createdBys,
+	}
+}
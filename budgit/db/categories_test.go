@@ -0,0 +1,103 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testCategoryGroups() []*db.CategoryGroup {
+	groups := make([]*db.CategoryGroup, 0, 3)
+	for i := 1; i <= 3; i++ {
+		groups = append(groups, &db.CategoryGroup{
+			RequestID:          pgtype.Text{String: fmt.Sprintf("request_id-%d", i), Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(int64(i), 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: fmt.Sprintf("id-%d", i), Valid: true},
+			Name:               pgtype.Text{String: fmt.Sprintf("name-%d", i), Valid: true},
+		})
+	}
+	return groups
+}
+
+func testCategories() []*db.Category {
+	categories := make([]*db.Category, 0, 3)
+	for i := 1; i <= 3; i++ {
+		categories = append(categories, &db.Category{
+			RequestID:          pgtype.Text{String: fmt.Sprintf("request_id-%d", i), Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(int64(i), 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: fmt.Sprintf("id-%d", i), Valid: true},
+			GroupID:            pgtype.Text{String: fmt.Sprintf("group_id-%d", i), Valid: true},
+			Name:               pgtype.Text{String: fmt.Sprintf("name-%d", i), Valid: true},
+		})
+	}
+	return categories
+}
+
+func (s *dbSuite) TestInsertCategoryGroups() {
+	ids, err := s.db.InsertCategoryGroups(context.Background(), s.conn, testCategoryGroups()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-3"}, ids)
+}
+
+func (s *dbSuite) TestUpdateCategoryGroupValidToTimestamps() {
+	_, err := s.db.InsertCategoryGroups(context.Background(), s.conn, testCategoryGroups()...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateCategoryGroupValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.NoError(err)
+	s.Equal([]string{"id-2"}, ids)
+
+	groups, err := s.db.SelectCategoryGroups(context.Background(), s.conn)
+	s.NoError(err)
+	s.Len(groups, 2)
+}
+
+func (s *dbSuite) TestSelectCategoryGroupsByID() {
+	groups := testCategoryGroups()
+	_, err := s.db.InsertCategoryGroups(context.Background(), s.conn, groups...)
+	s.Require().NoError(err)
+
+	actualGroups, err := s.db.SelectCategoryGroupsByID(context.Background(), s.conn, "id-1", "id-3")
+	s.NoError(err)
+	s.CMPEqual(map[string]*db.CategoryGroup{"id-1": groups[0], "id-3": groups[2]}, actualGroups)
+}
+
+func (s *dbSuite) TestInsertCategories() {
+	ids, err := s.db.InsertCategories(context.Background(), s.conn, testCategories()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-3"}, ids)
+}
+
+func (s *dbSuite) TestUpdateCategoryValidToTimestamps() {
+	_, err := s.db.InsertCategories(context.Background(), s.conn, testCategories()...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateCategoryValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.NoError(err)
+	s.Equal([]string{"id-2"}, ids)
+
+	categories, err := s.db.SelectCategoriesByID(context.Background(), s.conn, "id-1", "id-2")
+	s.NoError(err)
+	s.Len(categories, 1)
+}
+
+func (s *dbSuite) TestSelectCategories() {
+	categories := testCategories()
+	_, err := s.db.InsertCategories(context.Background(), s.conn, categories...)
+	s.Require().NoError(err)
+
+	actualCategories, err := s.db.SelectCategories(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(categories, actualCategories)
+}
@@ -0,0 +1,232 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// CSVProfile is the column mapping used to import CSV files into an Account. Each Account has at most one.
+type CSVProfile struct {
+	RequestID          pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
+	AccountID          pgtype.Text        `db:"account_id"`
+	Delimiter          pgtype.Text        `db:"delimiter"`
+	HasHeader          pgtype.Bool        `db:"has_header"`
+	SkipRows           pgtype.Int8        `db:"skip_rows"`
+	DateColumn         pgtype.Text        `db:"date_column"`
+	DateFormat         pgtype.Text        `db:"date_format"`
+	PayeeColumn        pgtype.Text        `db:"payee_column"`
+	MemoColumn         pgtype.Text        `db:"memo_column"`
+	AmountColumn       pgtype.Text        `db:"amount_column"`
+	DebitColumn        pgtype.Text        `db:"debit_column"`
+	CreditColumn       pgtype.Text        `db:"credit_column"`
+	NegateAmounts      pgtype.Bool        `db:"negate_amounts"`
+	DecimalComma       pgtype.Bool        `db:"decimal_comma"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
+}
+
+func (p CSVProfile) GetRequestID() string {
+	return p.RequestID.String
+}
+
+func (p CSVProfile) GetAccountID() string {
+	return p.AccountID.String
+}
+
+var (
+	csvProfileColumns    = getAllDBColumns(CSVProfile{})
+	csvProfileColumnsStr = strings.Join(csvProfileColumns, ", ")
+)
+
+func (db DB) InsertCSVProfiles(ctx context.Context, queryer Queryer, profiles ...*CSVProfile) ([]string, error) {
+	db.log.Debugw("Inserting CSV profiles", zap.Int("number_of_csv_profiles", len(profiles)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO csv_profiles (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[],
+				$3::TIMESTAMPTZ[],
+				$4::TEXT[],
+				$5::TEXT[],
+				$6::BOOLEAN[],
+				$7::BIGINT[],
+				$8::TEXT[],
+				$9::TEXT[],
+				$10::TEXT[],
+				$11::TEXT[],
+				$12::TEXT[],
+				$13::TEXT[],
+				$14::TEXT[],
+				$15::BOOLEAN[],
+				$16::BOOLEAN[],
+				$17::TEXT[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING account_id;
+	`, csvProfileColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, csvProfilesToArgs(profiles)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d CSV profiles: %w", len(profiles), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted CSV profiles", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d CSV profiles: %w", len(profiles), err)
+	}
+	db.log.Debugw("Inserted CSV profiles scanned", zap.String("inserted_account_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+// UpdateCSVProfileValidToTimestamps updates the valid to timestamps of the current CSV profiles of the Accounts with the
+// IDs given as each update's ID.
+func (db DB) UpdateCSVProfileValidToTimestamps(ctx context.Context, queryer Queryer, updates ...ValidToTimestampUpdate) ([]string, error) {
+	db.log.Debugw("Updating CSV profile valid to timestamps", zap.Int("number_of_csv_profiles", len(updates)))
+
+	sql := `
+		UPDATE csv_profiles
+		SET valid_to_timestamp = input.valid_to_timestamp
+		FROM 
+		(
+			SELECT account_id, valid_to_timestamp
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TIMESTAMPTZ[]
+			)
+			AS u(account_id, valid_to_timestamp)
+		) AS input
+		WHERE csv_profiles.valid_to_timestamp = 'infinity'
+		AND csv_profiles.budget_id = current_setting('budgit.budget_id')
+		AND csv_profiles.account_id = input.account_id
+		RETURNING csv_profiles.account_id;
+	`
+
+	accountIDs := make([]pgtype.Text, 0, len(updates))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(updates))
+	for _, update := range updates {
+		accountIDs = append(accountIDs, update.ID)
+		validToTimestamps = append(validToTimestamps, update.ValidToTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, accountIDs, validToTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d CSV profile valid to timestamps: %w", len(updates), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated CSV profile valid to timestamps", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating %d CSV profile valid to timestamps: %w", len(updates), err)
+	}
+	db.log.Debugw("Updated CSV profile valid to timestamps scanned", zap.String("updated_account_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+// SelectCSVProfilesByAccount returns the current CSV profiles of the given Accounts, by Account ID.
+func (db DB) SelectCSVProfilesByAccount(ctx context.Context, queryer Queryer, accountIDs ...string) (map[string]*CSVProfile, error) {
+	db.log.Debugw("Selecting CSV profiles by account", zap.String("account_ids", fmt.Sprintf("%+v", accountIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM csv_profiles
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND account_id = ANY($1::TEXT[])
+	`, csvProfileColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting CSV profiles by account: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected CSV profiles by account", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	profiles, err := pgx.CollectRows(rows, pgx.RowToStructByName[CSVProfile])
+	if err != nil {
+		return nil, fmt.Errorf("selecting CSV profiles by account: %w", err)
+	}
+	db.log.Debugw("Selected CSV profiles by account scanned", zap.Int("number_of_csv_profiles", len(profiles)))
+
+	profilesByAccountID := make(map[string]*CSVProfile, len(profiles))
+	for _, profile := range structsToPointers(profiles) {
+		profilesByAccountID[profile.GetAccountID()] = profile
+	}
+	return profilesByAccountID, nil
+}
+
+func csvProfilesToArgs(profiles []*CSVProfile) []any {
+	requestIDs := make([]pgtype.Text, 0, len(profiles))
+	validFromTimestamps := make([]pgtype.Timestamptz, 0, len(profiles))
+	validToTimestamps := make([]pgtype.Timestamptz, 0, len(profiles))
+	account_ids := make([]pgtype.Text, 0, len(profiles))
+	delimiters := make([]pgtype.Text, 0, len(profiles))
+	has_headers := make([]pgtype.Bool, 0, len(profiles))
+	skip_rows := make([]pgtype.Int8, 0, len(profiles))
+	date_columns := make([]pgtype.Text, 0, len(profiles))
+	date_formats := make([]pgtype.Text, 0, len(profiles))
+	payee_columns := make([]pgtype.Text, 0, len(profiles))
+	memo_columns := make([]pgtype.Text, 0, len(profiles))
+	amount_columns := make([]pgtype.Text, 0, len(profiles))
+	debit_columns := make([]pgtype.Text, 0, len(profiles))
+	credit_columns := make([]pgtype.Text, 0, len(profiles))
+	negate_amounts := make([]pgtype.Bool, 0, len(profiles))
+	decimal_commas := make([]pgtype.Bool, 0, len(profiles))
+	createdBys := make([]pgtype.Text, 0, len(profiles))
+	for _, profile := range profiles {
+		requestIDs = append(requestIDs, profile.RequestID)
+		validFromTimestamps = append(validFromTimestamps, profile.ValidFromTimestamp)
+		validToTimestamps = append(validToTimestamps, profile.ValidToTimestamp)
+		account_ids = append(account_ids, profile.AccountID)
+		delimiters = append(delimiters, profile.Delimiter)
+		has_headers = append(has_headers, profile.HasHeader)
+		skip_rows = append(skip_rows, profile.SkipRows)
+		date_columns = append(date_columns, profile.DateColumn)
+		date_formats = append(date_formats, profile.DateFormat)
+		payee_columns = append(payee_columns, profile.PayeeColumn)
+		memo_columns = append(memo_columns, profile.MemoColumn)
+		amount_columns = append(amount_columns, profile.AmountColumn)
+		debit_columns = append(debit_columns, profile.DebitColumn)
+		credit_columns = append(credit_columns, profile.CreditColumn)
+		negate_amounts = append(negate_amounts, profile.NegateAmounts)
+		decimal_commas = append(decimal_commas, profile.DecimalComma)
+		createdBys = append(createdBys, profile.CreatedBy)
+	}
+	return []any{
+		requestIDs,
+		validFromTimestamps,
+		validToTimestamps,
+		account_ids,
+		delimiters,
+		has_headers,
+		skip_rows,
+		date_columns,
+		date_formats,
+		payee_columns,
+		memo_columns,
+		amount_columns,
+		debit_columns,
+		credit_columns,
+		negate_amounts,
+		decimal_commas,
+		createdBys,
+	}
+}
@@ -0,0 +1,70 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testCSVProfiles() []*db.CSVProfile {
+	profiles := make([]*db.CSVProfile, 0, 3)
+	for i := 1; i <= 3; i++ {
+		profiles = append(profiles, &db.CSVProfile{
+			RequestID:          pgtype.Text{String: fmt.Sprintf("request_id-%d", i), Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(int64(i), 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			AccountID:          pgtype.Text{String: fmt.Sprintf("account_id-%d", i), Valid: true},
+			Delimiter:          pgtype.Text{String: ";", Valid: true},
+			HasHeader:          pgtype.Bool{Bool: true, Valid: true},
+			SkipRows:           pgtype.Int8{Int64: int64(i), Valid: true},
+			DateColumn:         pgtype.Text{String: "Date", Valid: true},
+			DateFormat:         pgtype.Text{String: "02/01/2006", Valid: true},
+			PayeeColumn:        pgtype.Text{String: "Description", Valid: true},
+			MemoColumn:         pgtype.Text{String: "Reference", Valid: true},
+			DebitColumn:        pgtype.Text{String: "Paid out", Valid: true},
+			CreditColumn:       pgtype.Text{String: "Paid in", Valid: true},
+			NegateAmounts:      pgtype.Bool{Bool: true, Valid: true},
+			DecimalComma:       pgtype.Bool{Bool: true, Valid: true},
+		})
+	}
+	return profiles
+}
+
+func (s *dbSuite) TestInsertCSVProfiles() {
+	ids, err := s.db.InsertCSVProfiles(context.Background(), s.conn, testCSVProfiles()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"account_id-1", "account_id-2", "account_id-3"}, ids)
+}
+
+func (s *dbSuite) TestUpdateCSVProfileValidToTimestamps() {
+	_, err := s.db.InsertCSVProfiles(context.Background(), s.conn, testCSVProfiles()...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateCSVProfileValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "account_id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.NoError(err)
+	s.Equal([]string{"account_id-2"}, ids)
+
+	profiles, err := s.db.SelectCSVProfilesByAccount(context.Background(), s.conn, "account_id-1", "account_id-2")
+	s.NoError(err)
+	s.Len(profiles, 1)
+}
+
+func (s *dbSuite) TestSelectCSVProfilesByAccount() {
+	profiles := testCSVProfiles()
+	_, err := s.db.InsertCSVProfiles(context.Background(), s.conn, profiles...)
+	s.Require().NoError(err)
+
+	expectedProfiles := map[string]*db.CSVProfile{
+		"account_id-1": profiles[0],
+		"account_id-3": profiles[2],
+	}
+	actualProfiles, err := s.db.SelectCSVProfilesByAccount(context.Background(), s.conn, "account_id-1", "account_id-3")
+	s.NoError(err)
+	s.CMPEqual(expectedProfiles, actualProfiles)
+}
@@ -41,6 +41,19 @@ func (db DB) Now(ctx context.Context, queryer Queryer) (pgtype.Timestamptz, erro
 	return now, nil
 }
 
+// BypassesRowLevelSecurity returns whether the role queries run as is a superuser or has BYPASSRLS, so that the
+// row-level security policies of the budget tables do not apply to it.
+func (db DB) BypassesRowLevelSecurity(ctx context.Context, queryer Queryer) (bool, error) {
+	db.log.Debug("Selecting whether the current role bypasses row-level security")
+
+	row := queryer.QueryRow(ctx, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`)
+	var bypasses bool
+	if err := row.Scan(&bypasses); err != nil {
+		return false, fmt.Errorf("selecting whether the current role bypasses row-level security: %w", err)
+	}
+	return bypasses, nil
+}
+
 type ValidToTimestampUpdate struct {
 	ID               pgtype.Text        `db:"id"`
 	ValidToTimestamp pgtype.Timestamptz `db:"valid_to_timestamp"`
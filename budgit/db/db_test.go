@@ -2,20 +2,14 @@ package db_test
 
 import (
 	"context"
-	"fmt"
-	"io/fs"
-	"path/filepath"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/internal/dbtest"
 	"github.com/google/go-cmp/cmp"
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/suite"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
 	"go.uber.org/zap"
 )
 
@@ -26,38 +20,22 @@ func TestDB(t *testing.T) {
 type dbSuite struct {
 	suite.Suite
 
-	pgContainer testcontainers.Container
+	postgres *dbtest.Postgres
 
 	db   db.DB
 	conn *pgx.Conn
 }
 
 func (s *dbSuite) SetupSuite() {
-	migrationFilePaths := []string{}
-	migrationsPath := "../migrations"
-	err := filepath.WalkDir(migrationsPath, func(path string, d fs.DirEntry, _ error) error {
-		if strings.HasSuffix(path, ".up.sql") {
-			migrationFilePaths = append(migrationFilePaths, path)
-		}
-		return nil
-	})
-	s.Require().NoError(err, "unexpected error getting migration file paths")
-
-	pgContainer, err := postgres.Run(context.Background(), "postgres:16-alpine",
-		postgres.WithInitScripts(migrationFilePaths...),
-		postgres.WithUsername("postgres"),
-		postgres.WithPassword("postgres"),
-		postgres.WithDatabase("budgit"),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).WithStartupTimeout(5*time.Second)),
-	)
-	s.Require().NoError(err, "unexpected error running postgres container")
-	s.pgContainer = pgContainer
-
-	connString, err := pgContainer.ConnectionString(context.Background(), "sslmode=disable")
-	s.Require().NoError(err, "unexpected error getting postgres container URL")
-	conn, err := pgx.Connect(context.Background(), connString)
+	postgres, err := dbtest.Run(context.Background())
+	s.Require().NoError(err, "unexpected error running postgres")
+	s.postgres = postgres
+
+	connConfig, err := pgx.ParseConfig(postgres.ConnString)
+	s.Require().NoError(err, "unexpected error parsing postgres container URL")
+	// Tests of the budget tables read and write the default budget, as if it were set, see DB.SetBudget.
+	connConfig.RuntimeParams["budgit.budget_id"] = "default"
+	conn, err := pgx.ConnectConfig(context.Background(), connConfig)
 	s.Require().NoError(err, "unexpected error connecting to postgres container")
 	s.conn = conn
 
@@ -65,12 +43,12 @@ func (s *dbSuite) SetupSuite() {
 }
 
 func (s *dbSuite) TearDownTest() {
-	s.truncateTables("accounts", "payees", "transactions")
+	s.Require().NoError(dbtest.Truncate(context.Background(), s.conn))
 }
 
 func (s *dbSuite) TearDownSuite() {
 	s.Require().NoError(s.conn.Close(context.Background()), "unexpected error closing connection")
-	s.Require().NoError(s.pgContainer.Terminate(context.Background()), "unexpected error terminating postgres container")
+	s.Require().NoError(s.postgres.Terminate(context.Background()))
 }
 
 func (s *dbSuite) TestNow() {
@@ -83,9 +61,10 @@ func (s *dbSuite) TestNow() {
 	s.True(endTime.After(now.Time), "expected end time to be after Now")
 }
 
-func (s *dbSuite) truncateTables(tables ...string) {
-	_, err := s.conn.Exec(context.Background(), fmt.Sprintf(`TRUNCATE TABLE %s`, strings.Join(tables, ", ")))
-	s.Require().NoError(err, "unexpected error truncating tables %+v", tables)
+func (s *dbSuite) TestBypassesRowLevelSecurity() {
+	bypasses, err := s.db.BypassesRowLevelSecurity(context.Background(), s.conn)
+	s.Require().NoError(err)
+	s.True(bypasses, "expected the superuser the tests connect as to bypass row-level security")
 }
 
 func (s *dbSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
@@ -25,6 +25,7 @@ func toAccount(account *db.Account) *budgit.Account {
 				ClearedBalance:   budgit.BalanceAmount(account.ExternalClearedBalance.Int64),
 				EffectiveBalance: budgit.BalanceAmount(account.ExternalEffectiveBalance.Int64),
 			},
+			WriteBack: account.ExternalWriteBack.Bool,
 		}
 	}
 	return &budgit.Account{
@@ -60,6 +61,7 @@ func fromAccount(account *budgit.Account) *db.Account {
 		dbAccount.ExternalLastSyncTimestamp = toTimestamptz(account.ExternalAccount.LastSyncTimestamp)
 		dbAccount.ExternalClearedBalance = toInt8(int64(account.ExternalAccount.Balance.ClearedBalance))
 		dbAccount.ExternalEffectiveBalance = toInt8(int64(account.ExternalAccount.Balance.EffectiveBalance))
+		dbAccount.ExternalWriteBack = toBool(account.ExternalAccount.WriteBack)
 	}
 	return dbAccount
 }
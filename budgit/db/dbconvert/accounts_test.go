@@ -33,6 +33,7 @@ func (s *convertSuite) TestAccount() {
 				ExternalLastSyncTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
 				ExternalClearedBalance:    pgtype.Int8{Int64: 3, Valid: true},
 				ExternalEffectiveBalance:  pgtype.Int8{Int64: 4, Valid: true},
+				ExternalWriteBack:         pgtype.Bool{Bool: true, Valid: true},
 			},
 			budgitAccount: &budgit.Account{
 				ID:   "id-1",
@@ -50,6 +51,7 @@ func (s *convertSuite) TestAccount() {
 						ClearedBalance:   3,
 						EffectiveBalance: 4,
 					},
+					WriteBack: true,
 				},
 			},
 		},
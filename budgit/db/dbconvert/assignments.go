@@ -0,0 +1,40 @@
+package dbconvert
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+func ToAssignments(dbAssignments ...*db.Assignment) []*budgit.Assignment {
+	assignments := make([]*budgit.Assignment, 0, len(dbAssignments))
+	for _, dbAssignment := range dbAssignments {
+		assignments = append(assignments, toAssignment(dbAssignment))
+	}
+	return assignments
+}
+
+func toAssignment(assignment *db.Assignment) *budgit.Assignment {
+	return &budgit.Assignment{
+		ID:         assignment.ID.String,
+		CategoryID: assignment.CategoryID.String,
+		Month:      assignment.Month.Time,
+		Amount:     budgit.BalanceAmount(assignment.Amount.Int64),
+	}
+}
+
+func FromAssignments(assignments ...*budgit.Assignment) []*db.Assignment {
+	dbAssignments := make([]*db.Assignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		dbAssignments = append(dbAssignments, fromAssignment(assignment))
+	}
+	return dbAssignments
+}
+
+func fromAssignment(assignment *budgit.Assignment) *db.Assignment {
+	return &db.Assignment{
+		ID:         toText(assignment.ID),
+		CategoryID: toText(assignment.CategoryID),
+		Month:      toDate(assignment.Month),
+		Amount:     toInt8(int64(assignment.Amount)),
+	}
+}
@@ -0,0 +1,49 @@
+package dbconvert_test
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestAssignment() {
+	testCases := []struct {
+		name             string
+		dbAssignment     *db.Assignment
+		budgitAssignment *budgit.Assignment
+	}{
+		{
+			name:             "EmptyAssignment",
+			dbAssignment:     &db.Assignment{},
+			budgitAssignment: &budgit.Assignment{},
+		},
+		{
+			name: "PopulatedAssignment",
+			dbAssignment: &db.Assignment{
+				ID:         pgtype.Text{String: "id-1", Valid: true},
+				CategoryID: pgtype.Text{String: "category_id-1", Valid: true},
+				Month:      pgtype.Date{Time: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+				Amount:     pgtype.Int8{Int64: 1, Valid: true},
+			},
+			budgitAssignment: &budgit.Assignment{
+				ID:         "id-1",
+				CategoryID: "category_id-1",
+				Month:      time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+				Amount:     1,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Run("ToAssignment", func() {
+				s.CMPEqual(tc.budgitAssignment, dbconvert.ToAssignments(tc.dbAssignment)[0])
+			})
+			s.Run("FromAssignment", func() {
+				s.CMPEqual(tc.dbAssignment, dbconvert.FromAssignments(tc.budgitAssignment)[0])
+			})
+		})
+	}
+}
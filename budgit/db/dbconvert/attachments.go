@@ -0,0 +1,44 @@
+package dbconvert
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+func ToAttachments(dbAttachments ...*db.Attachment) []*budgit.Attachment {
+	attachments := make([]*budgit.Attachment, 0, len(dbAttachments))
+	for _, dbAttachment := range dbAttachments {
+		attachments = append(attachments, toAttachment(dbAttachment))
+	}
+	return attachments
+}
+
+func toAttachment(attachment *db.Attachment) *budgit.Attachment {
+	return &budgit.Attachment{
+		ID:            attachment.ID.String,
+		TransactionID: attachment.TransactionID.String,
+		Name:          attachment.Name.String,
+		ContentType:   attachment.ContentType.String,
+		Size:          attachment.Size.Int64,
+		ExternalID:    attachment.ExternalID.String,
+	}
+}
+
+func FromAttachments(attachments ...*budgit.Attachment) []*db.Attachment {
+	dbAttachments := make([]*db.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		dbAttachments = append(dbAttachments, fromAttachment(attachment))
+	}
+	return dbAttachments
+}
+
+func fromAttachment(attachment *budgit.Attachment) *db.Attachment {
+	return &db.Attachment{
+		ID:            toText(attachment.ID),
+		TransactionID: toText(attachment.TransactionID),
+		Name:          toText(attachment.Name),
+		ContentType:   toText(attachment.ContentType),
+		Size:          toInt8(attachment.Size),
+		ExternalID:    toText(attachment.ExternalID),
+	}
+}
@@ -0,0 +1,57 @@
+package dbconvert_test
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestAttachment() {
+	testCases := []struct {
+		name             string
+		dbAttachment     *db.Attachment
+		budgitAttachment *budgit.Attachment
+	}{
+		{
+			name:             "EmptyAttachment",
+			dbAttachment:     &db.Attachment{},
+			budgitAttachment: &budgit.Attachment{},
+		},
+		{
+			name: "PopulatedAttachment",
+			dbAttachment: &db.Attachment{
+				ID:            pgtype.Text{String: "id-1", Valid: true},
+				TransactionID: pgtype.Text{String: "transaction_id-1", Valid: true},
+				Name:          pgtype.Text{String: "receipt.pdf", Valid: true},
+				ContentType:   pgtype.Text{String: "application/pdf", Valid: true},
+				Size:          pgtype.Int8{Int64: 1024, Valid: true},
+				ExternalID:    pgtype.Text{String: "external_id-1", Valid: true},
+			},
+			budgitAttachment: &budgit.Attachment{
+				ID:            "id-1",
+				TransactionID: "transaction_id-1",
+				Name:          "receipt.pdf",
+				ContentType:   "application/pdf",
+				Size:          1024,
+				ExternalID:    "external_id-1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Run("ToAttachment", func() {
+				s.CMPEqual(tc.budgitAttachment, dbconvert.ToAttachments(tc.dbAttachment)[0])
+			})
+			s.Run("FromAttachment", func() {
+				s.CMPEqual(tc.dbAttachment, dbconvert.FromAttachments(tc.budgitAttachment)[0])
+			})
+			s.Run("FromAttachmentToAttachment", func() {
+				s.CMPEqual(tc.dbAttachment, dbconvert.FromAttachments(dbconvert.ToAttachments(tc.dbAttachment)...)[0])
+			})
+			s.Run("ToAttachmentFromAttachment", func() {
+				s.CMPEqual(tc.budgitAttachment, dbconvert.ToAttachments(dbconvert.FromAttachments(tc.budgitAttachment)...)[0])
+			})
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package dbconvert
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+func ToBudgets(dbBudgets ...*db.Budget) []*budgit.Budget {
+	budgets := make([]*budgit.Budget, 0, len(dbBudgets))
+	for _, dbBudget := range dbBudgets {
+		budgets = append(budgets, toBudget(dbBudget))
+	}
+	return budgets
+}
+
+func toBudget(budget *db.Budget) *budgit.Budget {
+	return &budgit.Budget{
+		ID:   budget.ID.String,
+		Name: budget.Name.String,
+	}
+}
+
+// ToBudgetMembers converts members of budgets, given the Users who are members, by ID.
+func ToBudgetMembers(dbUsers map[string]*db.User, dbMembers ...*db.BudgetMember) []*budgit.BudgetMember {
+	members := make([]*budgit.BudgetMember, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		members = append(members, toBudgetMember(dbUsers, dbMember))
+	}
+	return members
+}
+
+func toBudgetMember(dbUsers map[string]*db.User, member *db.BudgetMember) *budgit.BudgetMember {
+	budgitMember := &budgit.BudgetMember{
+		BudgetID:         member.BudgetID.String,
+		UserID:           member.UserID.String,
+		Role:             budgit.Role(member.Role.String),
+		CreatedTimestamp: member.CreatedTimestamp.Time,
+	}
+	if user, ok := dbUsers[member.UserID.String]; ok {
+		budgitMember.Username = user.Username.String
+	}
+	return budgitMember
+}
+
+// ToBudgetInvitations converts invitations to budgets, leaving out the hashes of their tokens.
+func ToBudgetInvitations(dbInvitations ...*db.BudgetInvitation) []*budgit.BudgetInvitation {
+	invitations := make([]*budgit.BudgetInvitation, 0, len(dbInvitations))
+	for _, dbInvitation := range dbInvitations {
+		invitations = append(invitations, toBudgetInvitation(dbInvitation))
+	}
+	return invitations
+}
+
+func toBudgetInvitation(invitation *db.BudgetInvitation) *budgit.BudgetInvitation {
+	return &budgit.BudgetInvitation{
+		ID:               invitation.ID.String,
+		BudgetID:         invitation.BudgetID.String,
+		Role:             budgit.Role(invitation.Role.String),
+		CreatedTimestamp: invitation.CreatedTimestamp.Time,
+		ExpiresTimestamp: invitation.ExpiresTimestamp.Time,
+	}
+}
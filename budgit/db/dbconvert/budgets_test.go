@@ -0,0 +1,76 @@
+package dbconvert_test
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestBudget() {
+	dbBudget := &db.Budget{
+		ID:               pgtype.Text{String: "id-1", Valid: true},
+		Name:             pgtype.Text{String: "name-1", Valid: true},
+		CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+	}
+	s.CMPEqual([]*budgit.Budget{{ID: "id-1", Name: "name-1"}}, dbconvert.ToBudgets(dbBudget))
+}
+
+func (s *convertSuite) TestBudgetMember() {
+	dbUsers := map[string]*db.User{
+		"user_id-1": {ID: pgtype.Text{String: "user_id-1", Valid: true}, Username: pgtype.Text{String: "username-1", Valid: true}},
+	}
+	testCases := []struct {
+		name         string
+		dbMember     *db.BudgetMember
+		budgitMember *budgit.BudgetMember
+	}{
+		{
+			name:         "EmptyBudgetMember",
+			dbMember:     &db.BudgetMember{},
+			budgitMember: &budgit.BudgetMember{},
+		},
+		{
+			name: "PopulatedBudgetMember",
+			dbMember: &db.BudgetMember{
+				BudgetID:         pgtype.Text{String: "budget_id-1", Valid: true},
+				UserID:           pgtype.Text{String: "user_id-1", Valid: true},
+				Role:             pgtype.Text{String: "editor", Valid: true},
+				CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			},
+			budgitMember: &budgit.BudgetMember{
+				BudgetID:         "budget_id-1",
+				UserID:           "user_id-1",
+				Username:         "username-1",
+				Role:             budgit.RoleEditor,
+				CreatedTimestamp: time.Unix(1, 0).UTC(),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.CMPEqual(tc.budgitMember, dbconvert.ToBudgetMembers(dbUsers, tc.dbMember)[0])
+		})
+	}
+}
+
+func (s *convertSuite) TestBudgetInvitation() {
+	dbInvitation := &db.BudgetInvitation{
+		ID:               pgtype.Text{String: "id-1", Valid: true},
+		TokenHash:        pgtype.Text{String: "token_hash-1", Valid: true},
+		BudgetID:         pgtype.Text{String: "budget_id-1", Valid: true},
+		Role:             pgtype.Text{String: "viewer", Valid: true},
+		CreatedBy:        pgtype.Text{String: "created_by-1", Valid: true},
+		CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+		ExpiresTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+	}
+	s.CMPEqual([]*budgit.BudgetInvitation{{
+		ID:               "id-1",
+		BudgetID:         "budget_id-1",
+		Role:             budgit.RoleViewer,
+		CreatedTimestamp: time.Unix(1, 0).UTC(),
+		ExpiresTimestamp: time.Unix(2, 0).UTC(),
+	}}, dbconvert.ToBudgetInvitations(dbInvitation))
+}
@@ -0,0 +1,68 @@
+package dbconvert
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+func ToCategoryGroups(dbGroups ...*db.CategoryGroup) []*budgit.CategoryGroup {
+	groups := make([]*budgit.CategoryGroup, 0, len(dbGroups))
+	for _, dbGroup := range dbGroups {
+		groups = append(groups, toCategoryGroup(dbGroup))
+	}
+	return groups
+}
+
+func toCategoryGroup(group *db.CategoryGroup) *budgit.CategoryGroup {
+	return &budgit.CategoryGroup{
+		ID:   group.ID.String,
+		Name: group.Name.String,
+	}
+}
+
+func FromCategoryGroups(groups ...*budgit.CategoryGroup) []*db.CategoryGroup {
+	dbGroups := make([]*db.CategoryGroup, 0, len(groups))
+	for _, group := range groups {
+		dbGroups = append(dbGroups, fromCategoryGroup(group))
+	}
+	return dbGroups
+}
+
+func fromCategoryGroup(group *budgit.CategoryGroup) *db.CategoryGroup {
+	return &db.CategoryGroup{
+		ID:   toText(group.ID),
+		Name: toText(group.Name),
+	}
+}
+
+func ToCategories(dbCategories ...*db.Category) []*budgit.Category {
+	categories := make([]*budgit.Category, 0, len(dbCategories))
+	for _, dbCategory := range dbCategories {
+		categories = append(categories, toCategory(dbCategory))
+	}
+	return categories
+}
+
+func toCategory(category *db.Category) *budgit.Category {
+	return &budgit.Category{
+		ID:      category.ID.String,
+		GroupID: category.GroupID.String,
+		Name:    category.Name.String,
+	}
+}
+
+func FromCategories(categories ...*budgit.Category) []*db.Category {
+	dbCategories := make([]*db.Category, 0, len(categories))
+	for _, category := range categories {
+		dbCategories = append(dbCategories, fromCategory(category))
+	}
+	return dbCategories
+}
+
+func fromCategory(category *budgit.Category) *db.Category {
+	return &db.Category{
+		ID:      toText(category.ID),
+		GroupID: toText(category.GroupID),
+		Name:    toText(category.Name),
+	}
+}
@@ -0,0 +1,80 @@
+package dbconvert_test
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestCategoryGroup() {
+	testCases := []struct {
+		name        string
+		dbGroup     *db.CategoryGroup
+		budgitGroup *budgit.CategoryGroup
+	}{
+		{
+			name:        "EmptyCategoryGroup",
+			dbGroup:     &db.CategoryGroup{},
+			budgitGroup: &budgit.CategoryGroup{},
+		},
+		{
+			name: "PopulatedCategoryGroup",
+			dbGroup: &db.CategoryGroup{
+				ID:   pgtype.Text{String: "id-1", Valid: true},
+				Name: pgtype.Text{String: "name-1", Valid: true},
+			},
+			budgitGroup: &budgit.CategoryGroup{
+				ID:   "id-1",
+				Name: "name-1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Run("ToCategoryGroup", func() {
+				s.CMPEqual(tc.budgitGroup, dbconvert.ToCategoryGroups(tc.dbGroup)[0])
+			})
+			s.Run("FromCategoryGroup", func() {
+				s.CMPEqual(tc.dbGroup, dbconvert.FromCategoryGroups(tc.budgitGroup)[0])
+			})
+		})
+	}
+}
+
+func (s *convertSuite) TestCategory() {
+	testCases := []struct {
+		name           string
+		dbCategory     *db.Category
+		budgitCategory *budgit.Category
+	}{
+		{
+			name:           "EmptyCategory",
+			dbCategory:     &db.Category{},
+			budgitCategory: &budgit.Category{},
+		},
+		{
+			name: "PopulatedCategory",
+			dbCategory: &db.Category{
+				ID:      pgtype.Text{String: "id-1", Valid: true},
+				GroupID: pgtype.Text{String: "group_id-1", Valid: true},
+				Name:    pgtype.Text{String: "name-1", Valid: true},
+			},
+			budgitCategory: &budgit.Category{
+				ID:      "id-1",
+				GroupID: "group_id-1",
+				Name:    "name-1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Run("ToCategory", func() {
+				s.CMPEqual(tc.budgitCategory, dbconvert.ToCategories(tc.dbCategory)[0])
+			})
+			s.Run("FromCategory", func() {
+				s.CMPEqual(tc.dbCategory, dbconvert.FromCategories(tc.budgitCategory)[0])
+			})
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package dbconvert
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+func ToCSVProfiles(dbProfiles ...*db.CSVProfile) []*budgit.CSVProfile {
+	profiles := make([]*budgit.CSVProfile, 0, len(dbProfiles))
+	for _, dbProfile := range dbProfiles {
+		profiles = append(profiles, toCSVProfile(dbProfile))
+	}
+	return profiles
+}
+
+func toCSVProfile(profile *db.CSVProfile) *budgit.CSVProfile {
+	var delimiter rune
+	for _, r := range profile.Delimiter.String {
+		delimiter = r
+		break
+	}
+	return &budgit.CSVProfile{
+		AccountID:     profile.AccountID.String,
+		Delimiter:     delimiter,
+		HasHeader:     profile.HasHeader.Bool,
+		SkipRows:      profile.SkipRows.Int64,
+		DateColumn:    profile.DateColumn.String,
+		DateFormat:    profile.DateFormat.String,
+		PayeeColumn:   profile.PayeeColumn.String,
+		MemoColumn:    profile.MemoColumn.String,
+		AmountColumn:  profile.AmountColumn.String,
+		DebitColumn:   profile.DebitColumn.String,
+		CreditColumn:  profile.CreditColumn.String,
+		NegateAmounts: profile.NegateAmounts.Bool,
+		DecimalComma:  profile.DecimalComma.Bool,
+	}
+}
+
+func FromCSVProfiles(profiles ...*budgit.CSVProfile) []*db.CSVProfile {
+	dbProfiles := make([]*db.CSVProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		dbProfiles = append(dbProfiles, fromCSVProfile(profile))
+	}
+	return dbProfiles
+}
+
+func fromCSVProfile(profile *budgit.CSVProfile) *db.CSVProfile {
+	delimiter := ""
+	if profile.Delimiter != 0 {
+		delimiter = string(profile.Delimiter)
+	}
+	return &db.CSVProfile{
+		AccountID:     toText(profile.AccountID),
+		Delimiter:     toText(delimiter),
+		HasHeader:     toBool(profile.HasHeader),
+		SkipRows:      toInt8(profile.SkipRows),
+		DateColumn:    toText(profile.DateColumn),
+		DateFormat:    toText(profile.DateFormat),
+		PayeeColumn:   toText(profile.PayeeColumn),
+		MemoColumn:    toText(profile.MemoColumn),
+		AmountColumn:  toText(profile.AmountColumn),
+		DebitColumn:   toText(profile.DebitColumn),
+		CreditColumn:  toText(profile.CreditColumn),
+		NegateAmounts: toBool(profile.NegateAmounts),
+		DecimalComma:  toBool(profile.DecimalComma),
+	}
+}
@@ -0,0 +1,71 @@
+package dbconvert_test
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestCSVProfile() {
+	testCases := []struct {
+		name          string
+		dbProfile     *db.CSVProfile
+		budgitProfile *budgit.CSVProfile
+	}{
+		{
+			name:          "EmptyCSVProfile",
+			dbProfile:     &db.CSVProfile{},
+			budgitProfile: &budgit.CSVProfile{},
+		},
+		{
+			name: "PopulatedCSVProfile",
+			dbProfile: &db.CSVProfile{
+				AccountID:     pgtype.Text{String: "account_id-1", Valid: true},
+				Delimiter:     pgtype.Text{String: ";", Valid: true},
+				HasHeader:     pgtype.Bool{Bool: true, Valid: true},
+				SkipRows:      pgtype.Int8{Int64: 2, Valid: true},
+				DateColumn:    pgtype.Text{String: "date_column-1", Valid: true},
+				DateFormat:    pgtype.Text{String: "02/01/2006", Valid: true},
+				PayeeColumn:   pgtype.Text{String: "payee_column-1", Valid: true},
+				MemoColumn:    pgtype.Text{String: "memo_column-1", Valid: true},
+				AmountColumn:  pgtype.Text{String: "amount_column-1", Valid: true},
+				DebitColumn:   pgtype.Text{String: "debit_column-1", Valid: true},
+				CreditColumn:  pgtype.Text{String: "credit_column-1", Valid: true},
+				NegateAmounts: pgtype.Bool{Bool: true, Valid: true},
+				DecimalComma:  pgtype.Bool{Bool: true, Valid: true},
+			},
+			budgitProfile: &budgit.CSVProfile{
+				AccountID:     "account_id-1",
+				Delimiter:     ';',
+				HasHeader:     true,
+				SkipRows:      2,
+				DateColumn:    "date_column-1",
+				DateFormat:    "02/01/2006",
+				PayeeColumn:   "payee_column-1",
+				MemoColumn:    "memo_column-1",
+				AmountColumn:  "amount_column-1",
+				DebitColumn:   "debit_column-1",
+				CreditColumn:  "credit_column-1",
+				NegateAmounts: true,
+				DecimalComma:  true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Run("ToCSVProfile", func() {
+				s.CMPEqual(tc.budgitProfile, dbconvert.ToCSVProfiles(tc.dbProfile)[0])
+			})
+			s.Run("FromCSVProfile", func() {
+				s.CMPEqual(tc.dbProfile, dbconvert.FromCSVProfiles(tc.budgitProfile)[0])
+			})
+			s.Run("FromCSVProfileToCSVProfile", func() {
+				s.CMPEqual(tc.dbProfile, dbconvert.FromCSVProfiles(dbconvert.ToCSVProfiles(tc.dbProfile)...)[0])
+			})
+			s.Run("ToCSVProfileFromCSVProfile", func() {
+				s.CMPEqual(tc.budgitProfile, dbconvert.ToCSVProfiles(dbconvert.FromCSVProfiles(tc.budgitProfile)...)[0])
+			})
+		})
+	}
+}
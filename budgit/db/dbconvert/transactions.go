@@ -15,13 +15,18 @@ func ToTransactions(dbTransactions ...*db.Transaction) []*budgit.Transaction {
 
 func toTransaction(transaction *db.Transaction) *budgit.Transaction {
 	return &budgit.Transaction{
-		ID:              transaction.ID.String,
-		EffectiveDate:   transaction.EffectiveDate.Time,
-		AccountID:       transaction.AccountID.String,
-		PayeeID:         transaction.PayeeID.String,
-		IsPayeeInternal: transaction.IsPayeeInternal.Bool,
-		Amount:          budgit.BalanceAmount(transaction.Amount.Int64),
-		Cleared:         transaction.Cleared.Bool,
+		ID:                  transaction.ID.String,
+		EffectiveDate:       transaction.EffectiveDate.Time,
+		AccountID:           transaction.AccountID.String,
+		PayeeID:             transaction.PayeeID.String,
+		IsPayeeInternal:     transaction.IsPayeeInternal.Bool,
+		Amount:              budgit.BalanceAmount(transaction.Amount.Int64),
+		Cleared:             transaction.Cleared.Bool,
+		Memo:                transaction.Memo.String,
+		ImportID:            transaction.ImportID.String,
+		ImportIntegrationID: transaction.ImportIntegrationID.String,
+		CategoryID:          transaction.CategoryID.String,
+		SplitID:             transaction.SplitID.String,
 	}
 }
 
@@ -35,12 +40,17 @@ func FromTransactions(transactions ...*budgit.Transaction) []*db.Transaction {
 
 func fromTransaction(transaction *budgit.Transaction) *db.Transaction {
 	return &db.Transaction{
-		ID:              toText(transaction.ID),
-		EffectiveDate:   toDate(transaction.EffectiveDate),
-		AccountID:       toText(transaction.AccountID),
-		PayeeID:         toText(transaction.PayeeID),
-		IsPayeeInternal: toBool(transaction.IsPayeeInternal),
-		Amount:          toInt8(int64(transaction.Amount)),
-		Cleared:         toBool(transaction.Cleared),
+		ID:                  toText(transaction.ID),
+		EffectiveDate:       toDate(transaction.EffectiveDate),
+		AccountID:           toText(transaction.AccountID),
+		PayeeID:             toText(transaction.PayeeID),
+		IsPayeeInternal:     toBool(transaction.IsPayeeInternal),
+		Amount:              toInt8(int64(transaction.Amount)),
+		Cleared:             toBool(transaction.Cleared),
+		Memo:                toText(transaction.Memo),
+		ImportID:            toText(transaction.ImportID),
+		ImportIntegrationID: toText(transaction.ImportIntegrationID),
+		CategoryID:          toText(transaction.CategoryID),
+		SplitID:             toText(transaction.SplitID),
 	}
 }
@@ -23,22 +23,32 @@ func (s *convertSuite) TestTransaction() {
 		{
 			name: "PopulatedTransaction",
 			dbTransaction: &db.Transaction{
-				ID:              pgtype.Text{String: "id-1", Valid: true},
-				EffectiveDate:   pgtype.Date{Time: time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC), Valid: true},
-				AccountID:       pgtype.Text{String: "account_id-1", Valid: true},
-				PayeeID:         pgtype.Text{String: "payee_id-1", Valid: true},
-				IsPayeeInternal: pgtype.Bool{Bool: true, Valid: true},
-				Amount:          pgtype.Int8{Int64: 1, Valid: true},
-				Cleared:         pgtype.Bool{Bool: true, Valid: true},
+				ID:                  pgtype.Text{String: "id-1", Valid: true},
+				EffectiveDate:       pgtype.Date{Time: time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+				AccountID:           pgtype.Text{String: "account_id-1", Valid: true},
+				PayeeID:             pgtype.Text{String: "payee_id-1", Valid: true},
+				IsPayeeInternal:     pgtype.Bool{Bool: true, Valid: true},
+				Amount:              pgtype.Int8{Int64: 1, Valid: true},
+				Cleared:             pgtype.Bool{Bool: true, Valid: true},
+				Memo:                pgtype.Text{String: "memo-1", Valid: true},
+				ImportID:            pgtype.Text{String: "import_id-1", Valid: true},
+				ImportIntegrationID: pgtype.Text{String: "import_integration_id-1", Valid: true},
+				CategoryID:          pgtype.Text{String: "category_id-1", Valid: true},
+				SplitID:             pgtype.Text{String: "split_id-1", Valid: true},
 			},
 			budgitTransaction: &budgit.Transaction{
-				ID:              "id-1",
-				EffectiveDate:   time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC),
-				AccountID:       "account_id-1",
-				PayeeID:         "payee_id-1",
-				IsPayeeInternal: true,
-				Amount:          1,
-				Cleared:         true,
+				ID:                  "id-1",
+				EffectiveDate:       time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC),
+				AccountID:           "account_id-1",
+				PayeeID:             "payee_id-1",
+				IsPayeeInternal:     true,
+				Amount:              1,
+				Cleared:             true,
+				Memo:                "memo-1",
+				ImportID:            "import_id-1",
+				ImportIntegrationID: "import_integration_id-1",
+				CategoryID:          "category_id-1",
+				SplitID:             "split_id-1",
 			},
 		},
 	}
@@ -0,0 +1,79 @@
+package dbconvert
+
+import (
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+)
+
+// ToUsers converts users, leaving out their password hashes and TOTP secrets. There is no FromUsers, as Users do not
+// hold them.
+func ToUsers(dbUsers ...*db.User) []*budgit.User {
+	users := make([]*budgit.User, 0, len(dbUsers))
+	for _, dbUser := range dbUsers {
+		users = append(users, toUser(dbUser))
+	}
+	return users
+}
+
+func toUser(user *db.User) *budgit.User {
+	return &budgit.User{
+		ID:               user.ID.String,
+		Username:         user.Username.String,
+		TOTPEnabled:      user.TOTPSecret.Valid,
+		CreatedTimestamp: user.CreatedTimestamp.Time,
+	}
+}
+
+// ToAPITokens converts API tokens, leaving out the hashes of the tokens.
+func ToAPITokens(dbTokens ...*db.APIToken) []*budgit.APIToken {
+	tokens := make([]*budgit.APIToken, 0, len(dbTokens))
+	for _, dbToken := range dbTokens {
+		tokens = append(tokens, toAPIToken(dbToken))
+	}
+	return tokens
+}
+
+func toAPIToken(token *db.APIToken) *budgit.APIToken {
+	var scopes []budgit.Scope
+	for _, scope := range strings.Fields(token.Scopes.String) {
+		scopes = append(scopes, budgit.Scope(scope))
+	}
+	return &budgit.APIToken{
+		ID:                token.ID.String,
+		UserID:            token.UserID.String,
+		Name:              token.Name.String,
+		BudgetID:          token.BudgetID.String,
+		Scopes:            scopes,
+		CreatedTimestamp:  token.CreatedTimestamp.Time,
+		ExpiresTimestamp:  token.ExpiresTimestamp.Time,
+		LastUsedTimestamp: token.LastUsedTimestamp.Time,
+	}
+}
+
+// FromAPITokens converts API tokens, leaving the hashes of the tokens to be set, as APITokens do not hold them.
+func FromAPITokens(tokens ...*budgit.APIToken) []*db.APIToken {
+	dbTokens := make([]*db.APIToken, 0, len(tokens))
+	for _, token := range tokens {
+		dbTokens = append(dbTokens, fromAPIToken(token))
+	}
+	return dbTokens
+}
+
+func fromAPIToken(token *budgit.APIToken) *db.APIToken {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return &db.APIToken{
+		ID:                toText(token.ID),
+		UserID:            toText(token.UserID),
+		Name:              toText(token.Name),
+		BudgetID:          toText(token.BudgetID),
+		Scopes:            toText(strings.Join(scopes, " ")),
+		CreatedTimestamp:  toTimestamptz(token.CreatedTimestamp),
+		ExpiresTimestamp:  toTimestamptz(token.ExpiresTimestamp),
+		LastUsedTimestamp: toTimestamptz(token.LastUsedTimestamp),
+	}
+}
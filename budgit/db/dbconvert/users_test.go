@@ -0,0 +1,102 @@
+package dbconvert_test
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *convertSuite) TestUser() {
+	testCases := []struct {
+		name       string
+		dbUser     *db.User
+		budgitUser *budgit.User
+	}{
+		{
+			name:       "EmptyUser",
+			dbUser:     &db.User{},
+			budgitUser: &budgit.User{},
+		},
+		{
+			name: "PopulatedUser",
+			dbUser: &db.User{
+				ID:               pgtype.Text{String: "id-1", Valid: true},
+				Username:         pgtype.Text{String: "username-1", Valid: true},
+				PasswordHash:     pgtype.Text{String: "password_hash-1", Valid: true},
+				TOTPSecret:       pgtype.Text{String: "totp_secret-1", Valid: true},
+				CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			},
+			budgitUser: &budgit.User{
+				ID:               "id-1",
+				Username:         "username-1",
+				TOTPEnabled:      true,
+				CreatedTimestamp: time.Unix(1, 0).UTC(),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.CMPEqual(tc.budgitUser, dbconvert.ToUsers(tc.dbUser)[0])
+		})
+	}
+}
+
+func (s *convertSuite) TestAPIToken() {
+	testCases := []struct {
+		name           string
+		dbToken        *db.APIToken
+		budgitAPIToken *budgit.APIToken
+	}{
+		{
+			name:           "EmptyAPIToken",
+			dbToken:        &db.APIToken{},
+			budgitAPIToken: &budgit.APIToken{},
+		},
+		{
+			name: "PopulatedAPIToken",
+			dbToken: &db.APIToken{
+				ID:                pgtype.Text{String: "id-1", Valid: true},
+				TokenHash:         pgtype.Text{String: "token_hash-1", Valid: true},
+				UserID:            pgtype.Text{String: "user_id-1", Valid: true},
+				Name:              pgtype.Text{String: "name-1", Valid: true},
+				BudgetID:          pgtype.Text{String: "budget_id-1", Valid: true},
+				Scopes:            pgtype.Text{String: "accounts:read transactions:write", Valid: true},
+				CreatedTimestamp:  pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+				ExpiresTimestamp:  pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+				LastUsedTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			},
+			budgitAPIToken: &budgit.APIToken{
+				ID:                "id-1",
+				UserID:            "user_id-1",
+				Name:              "name-1",
+				BudgetID:          "budget_id-1",
+				Scopes:            []budgit.Scope{budgit.ScopeAccountsRead, budgit.ScopeTransactionsWrite},
+				CreatedTimestamp:  time.Unix(1, 0).UTC(),
+				ExpiresTimestamp:  time.Unix(3, 0).UTC(),
+				LastUsedTimestamp: time.Unix(2, 0).UTC(),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			// The hash of the token is left out of APITokens, so is not expected back.
+			withoutHash := *tc.dbToken
+			withoutHash.TokenHash = pgtype.Text{}
+			s.Run("ToAPIToken", func() {
+				s.CMPEqual(tc.budgitAPIToken, dbconvert.ToAPITokens(tc.dbToken)[0])
+			})
+			s.Run("FromAPIToken", func() {
+				s.CMPEqual(&withoutHash, dbconvert.FromAPITokens(tc.budgitAPIToken)[0])
+			})
+			s.Run("FromAPITokenToAPIToken", func() {
+				s.CMPEqual(&withoutHash, dbconvert.FromAPITokens(dbconvert.ToAPITokens(tc.dbToken)...)[0])
+			})
+			s.Run("ToAPITokenFromAPIToken", func() {
+				s.CMPEqual(tc.budgitAPIToken, dbconvert.ToAPITokens(dbconvert.FromAPITokens(tc.budgitAPIToken)...)[0])
+			})
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// ExternalAccountLink records that an external account of an Integration is linked to an Account of a budget. Links
+// are not versioned, and are kept once the Account is no longer linked.
+type ExternalAccountLink struct {
+	IntegrationID     pgtype.Text        `db:"integration_id"`
+	ExternalAccountID pgtype.Text        `db:"external_account_id"`
+	BudgetID          pgtype.Text        `db:"budget_id"`
+	CreatedBy         pgtype.Text        `db:"created_by"`
+	CreatedTimestamp  pgtype.Timestamptz `db:"created_timestamp"`
+}
+
+var (
+	externalAccountLinkColumns    = getAllDBColumns(ExternalAccountLink{})
+	externalAccountLinkColumnsStr = strings.Join(externalAccountLinkColumns, ", ")
+)
+
+// InsertExternalAccountLinks inserts links of external accounts, skipping those already linked in the same budget, and
+// returns the IDs of the budgets of the links inserted.
+func (db DB) InsertExternalAccountLinks(ctx context.Context, queryer Queryer, links ...*ExternalAccountLink) ([]string, error) {
+	db.log.Debugw("Inserting external account links", zap.Int("number_of_external_account_links", len(links)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO external_account_links (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TEXT[],
+				$4::TEXT[],
+				$5::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING budget_id;
+	`, externalAccountLinkColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, externalAccountLinksToArgs(links)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d external account links: %w", len(links), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted external account links", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d external account links: %w", len(links), err)
+	}
+	return ids, nil
+}
+
+// SelectExternalAccountLinks returns the links of an external account in every budget, oldest first.
+func (db DB) SelectExternalAccountLinks(ctx context.Context, queryer Queryer, integrationID, externalAccountID string) ([]*ExternalAccountLink, error) {
+	db.log.Debugw("Selecting external account links", zap.String("integration_id", integrationID), zap.String("external_account_id", externalAccountID))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM external_account_links
+		WHERE integration_id = $1
+		AND external_account_id = $2
+		ORDER BY created_timestamp, budget_id
+	`, externalAccountLinkColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: integrationID, Valid: true}, pgtype.Text{String: externalAccountID, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("selecting external account links: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected external account links", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	links, err := pgx.CollectRows(rows, pgx.RowToStructByName[ExternalAccountLink])
+	if err != nil {
+		return nil, fmt.Errorf("selecting external account links: %w", err)
+	}
+	db.log.Debugw("Selected external account links scanned", zap.Int("number_of_external_account_links", len(links)))
+	return structsToPointers(links), nil
+}
+
+func externalAccountLinksToArgs(links []*ExternalAccountLink) []any {
+	integrationIDs := make([]pgtype.Text, 0, len(links))
+	externalAccountIDs := make([]pgtype.Text, 0, len(links))
+	budgetIDs := make([]pgtype.Text, 0, len(links))
+	createdBys := make([]pgtype.Text, 0, len(links))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(links))
+	for _, link := range links {
+		integrationIDs = append(integrationIDs, link.IntegrationID)
+		externalAccountIDs = append(externalAccountIDs, link.ExternalAccountID)
+		budgetIDs = append(budgetIDs, link.BudgetID)
+		createdBys = append(createdBys, link.CreatedBy)
+		createdTimestamps = append(createdTimestamps, link.CreatedTimestamp)
+	}
+	return []any{
+		integrationIDs,
+		externalAccountIDs,
+		budgetIDs,
+		createdBys,
+		createdTimestamps,
+	}
+}
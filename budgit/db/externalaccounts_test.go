@@ -0,0 +1,100 @@
+package db_test
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *dbSuite) TestExternalAccountLinks() {
+	_, err := s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	links := []*db.ExternalAccountLink{
+		{
+			IntegrationID:     pgtype.Text{String: "integration-1", Valid: true},
+			ExternalAccountID: pgtype.Text{String: "external-1", Valid: true},
+			BudgetID:          pgtype.Text{String: "id-1", Valid: true},
+			CreatedBy:         pgtype.Text{String: "user-1", Valid: true},
+			CreatedTimestamp:  pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+		},
+		{
+			IntegrationID:     pgtype.Text{String: "integration-1", Valid: true},
+			ExternalAccountID: pgtype.Text{String: "external-1", Valid: true},
+			BudgetID:          pgtype.Text{String: "id-2", Valid: true},
+			CreatedBy:         pgtype.Text{String: "user-2", Valid: true},
+			CreatedTimestamp:  pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+		},
+		{
+			IntegrationID:     pgtype.Text{String: "integration-1", Valid: true},
+			ExternalAccountID: pgtype.Text{String: "external-2", Valid: true},
+			BudgetID:          pgtype.Text{String: "id-1", Valid: true},
+			CreatedBy:         pgtype.Text{String: "user-1", Valid: true},
+			CreatedTimestamp:  pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		},
+	}
+	budgetIDs, err := s.db.InsertExternalAccountLinks(context.Background(), s.conn, links...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2", "id-1"}, budgetIDs)
+
+	s.Run("InsertSkipsExisting", func() {
+		budgetIDs, err := s.db.InsertExternalAccountLinks(context.Background(), s.conn, links[0])
+		s.NoError(err)
+		s.Empty(budgetIDs)
+	})
+
+	s.Run("Select", func() {
+		actualLinks, err := s.db.SelectExternalAccountLinks(context.Background(), s.conn, "integration-1", "external-1")
+		s.NoError(err)
+		s.CMPEqual(links[:2], actualLinks)
+	})
+
+	s.Run("SelectOtherIntegration", func() {
+		actualLinks, err := s.db.SelectExternalAccountLinks(context.Background(), s.conn, "integration-2", "external-1")
+		s.NoError(err)
+		s.Empty(actualLinks)
+	})
+}
+
+func (s *dbSuite) TestExternalAccountLinksMigration() {
+	_, err := s.db.InsertBudgets(context.Background(), s.conn, testBudgets()...)
+	s.Require().NoError(err)
+	migration, err := os.ReadFile("../migrations/010_external_account_links.up.sql")
+	s.Require().NoError(err)
+
+	// Migrations run as the role which owns the tables, which row-level security is forced on, so the migration is
+	// rerun as such a role rather than the superuser the test connects as.
+	tx, err := s.conn.Begin(context.Background())
+	s.Require().NoError(err)
+	defer tx.Rollback(context.Background())
+	for _, sql := range []string{
+		`INSERT INTO accounts (request_id, valid_from_timestamp, valid_to_timestamp, id, name, cleared_balance, effective_balance, external_id, external_integration_id, budget_id)
+		VALUES ('request_id-1', 'epoch', 'infinity', 'id-1', 'name-1', 0, 0, 'external-1', 'integration-1', 'id-1'),
+		('request_id-2', 'epoch', 'infinity', 'id-2', 'name-2', 0, 0, 'external-2', 'integration-1', 'id-2')`,
+		`DROP TABLE external_account_links`,
+		`CREATE ROLE budgit_migrate_test NOSUPERUSER NOBYPASSRLS`,
+		`ALTER TABLE accounts OWNER TO budgit_migrate_test`,
+		`GRANT CREATE ON SCHEMA public TO budgit_migrate_test`,
+		`GRANT REFERENCES ON budgets TO budgit_migrate_test`,
+		`SET LOCAL ROLE budgit_migrate_test`,
+		`SET LOCAL budgit.budget_id TO ''`,
+		string(migration),
+		`RESET ROLE`,
+	} {
+		_, err := tx.Exec(context.Background(), sql)
+		s.Require().NoError(err, sql)
+	}
+
+	rows, err := tx.Query(context.Background(), `SELECT budget_id FROM external_account_links`)
+	s.Require().NoError(err)
+	budgetIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2"}, budgetIDs, "expected the links of every budget to be copied")
+
+	var forced bool
+	s.Require().NoError(tx.QueryRow(context.Background(), `SELECT relforcerowsecurity FROM pg_class WHERE relname = 'accounts'`).Scan(&forced))
+	s.True(forced, "expected row-level security to be forced on accounts again")
+}
@@ -16,6 +16,7 @@ type Payee struct {
 	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
 	ID                 pgtype.Text        `db:"id"`
 	Name               pgtype.Text        `db:"name"`
+	CreatedBy          pgtype.Text        `db:"created_by"`
 }
 
 func (p Payee) GetID() string {
@@ -47,7 +48,8 @@ func (db DB) InsertPayees(ctx context.Context, queryer Queryer, payees ...*Payee
 				$2::TIMESTAMPTZ[],
 				$3::TIMESTAMPTZ[],
 				$4::TEXT[],
-				$5::TEXT[]
+				$5::TEXT[],
+				$6::TEXT[]
 			)
 			AS u(%[1]s)
 		)
@@ -86,6 +88,7 @@ func (db DB) UpdatePayeeValidToTimestamps(ctx context.Context, queryer Queryer,
 			AS u(id, valid_to_timestamp)
 		) AS input
 		WHERE payees.valid_to_timestamp = 'infinity'
+		AND payees.budget_id = current_setting('budgit.budget_id')
 		AND payees.id = input.id
 		RETURNING payees.id;
 	`
@@ -119,6 +122,7 @@ func (db DB) SelectPayees(ctx context.Context, queryer Queryer) ([]*Payee, error
 		SELECT %[1]s
 		FROM payees
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		ORDER BY id
 	`, payeeColumnsStr)
 
@@ -144,6 +148,7 @@ func (db DB) SelectPayeesByRequestID(ctx context.Context, queryer Queryer, reque
 		SELECT %[1]s
 		FROM payees
 		WHERE request_id = ANY($1::TEXT[])
+		AND budget_id = current_setting('budgit.budget_id')
 	`, payeeColumnsStr)
 
 	ids := make([]pgtype.Text, 0, len(requestIDs))
@@ -173,6 +178,7 @@ func (db DB) SelectPayeesByID(ctx context.Context, queryer Queryer, payeeIDs ...
 		SELECT %[1]s
 		FROM payees
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		AND id = ANY($1::TEXT[])
 	`, payeeColumnsStr)
 
@@ -203,6 +209,7 @@ func (db DB) SelectPayeesByName(ctx context.Context, queryer Queryer, payeeNames
 		SELECT %[1]s
 		FROM payees
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		AND name = ANY($1::TEXT[])
 	`, payeeColumnsStr)
 
@@ -232,12 +239,14 @@ func payeesToArgs(payees []*Payee) []any {
 	validToTimestamps := make([]pgtype.Timestamptz, 0, len(payees))
 	ids := make([]pgtype.Text, 0, len(payees))
 	names := make([]pgtype.Text, 0, len(payees))
+	createdBys := make([]pgtype.Text, 0, len(payees))
 	for _, payee := range payees {
 		requestIDs = append(requestIDs, payee.RequestID)
 		validFromTimestamps = append(validFromTimestamps, payee.ValidFromTimestamp)
 		validToTimestamps = append(validToTimestamps, payee.ValidToTimestamp)
 		ids = append(ids, payee.ID)
 		names = append(names, payee.Name)
+		createdBys = append(createdBys, payee.CreatedBy)
 	}
 	return []any{
 		requestIDs,
@@ -245,5 +254,6 @@ func payeesToArgs(payees []*Payee) []any {
 		validToTimestamps,
 		ids,
 		names,
+		createdBys,
 	}
 }
@@ -11,16 +11,22 @@ import (
 )
 
 type Transaction struct {
-	RequestID          pgtype.Text        `db:"request_id"`
-	ValidFromTimestamp pgtype.Timestamptz `db:"valid_from_timestamp"`
-	ValidToTimestamp   pgtype.Timestamptz `db:"valid_to_timestamp"`
-	ID                 pgtype.Text        `db:"id"`
-	EffectiveDate      pgtype.Date        `db:"effective_date"`
-	AccountID          pgtype.Text        `db:"account_id"`
-	PayeeID            pgtype.Text        `db:"payee_id"`
-	IsPayeeInternal    pgtype.Bool        `db:"is_payee_internal"`
-	Amount             pgtype.Int8        `db:"amount"`
-	Cleared            pgtype.Bool        `db:"cleared"`
+	RequestID           pgtype.Text        `db:"request_id"`
+	ValidFromTimestamp  pgtype.Timestamptz `db:"valid_from_timestamp"`
+	ValidToTimestamp    pgtype.Timestamptz `db:"valid_to_timestamp"`
+	ID                  pgtype.Text        `db:"id"`
+	EffectiveDate       pgtype.Date        `db:"effective_date"`
+	AccountID           pgtype.Text        `db:"account_id"`
+	PayeeID             pgtype.Text        `db:"payee_id"`
+	IsPayeeInternal     pgtype.Bool        `db:"is_payee_internal"`
+	Amount              pgtype.Int8        `db:"amount"`
+	Cleared             pgtype.Bool        `db:"cleared"`
+	Memo                pgtype.Text        `db:"memo"`
+	ImportID            pgtype.Text        `db:"import_id"`
+	ImportIntegrationID pgtype.Text        `db:"import_integration_id"`
+	CategoryID          pgtype.Text        `db:"category_id"`
+	SplitID             pgtype.Text        `db:"split_id"`
+	CreatedBy           pgtype.Text        `db:"created_by"`
 }
 
 func (p Transaction) GetID() string {
@@ -57,7 +63,13 @@ func (db DB) InsertTransactions(ctx context.Context, queryer Queryer, transactio
 				$7::TEXT[],
 				$8::BOOL[],
 				$9::BIGINT[],
-				$10::BOOL[]
+				$10::BOOL[],
+				$11::TEXT[],
+				$12::TEXT[],
+				$13::TEXT[],
+				$14::TEXT[],
+				$15::TEXT[],
+				$16::TEXT[]
 			)
 			AS u(%[1]s)
 		)
@@ -96,6 +108,7 @@ func (db DB) UpdateTransactionValidToTimestamps(ctx context.Context, queryer Que
 			AS u(id, valid_to_timestamp)
 		) AS input
 		WHERE transactions.valid_to_timestamp = 'infinity'
+		AND transactions.budget_id = current_setting('budgit.budget_id')
 		AND transactions.id = input.id
 		RETURNING transactions.id;
 	`
@@ -129,6 +142,7 @@ func (db DB) SelectTransactions(ctx context.Context, queryer Queryer) ([]*Transa
 		SELECT %[1]s
 		FROM transactions
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		ORDER BY effective_date, amount
 	`, transactionColumnsStr)
 
@@ -154,6 +168,7 @@ func (db DB) SelectTransactionsByAccount(ctx context.Context, queryer Queryer, a
 		SELECT %[1]s
 		FROM transactions
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		AND account_id = $1
 		ORDER BY effective_date, amount
 	`, transactionColumnsStr)
@@ -173,6 +188,39 @@ func (db DB) SelectTransactionsByAccount(ctx context.Context, queryer Queryer, a
 	return structsToPointers(transactions), nil
 }
 
+func (db DB) SelectTransactionsByPayee(ctx context.Context, queryer Queryer, payeeIDs ...string) ([]*Transaction, error) {
+	db.log.Debugw("Selecting transactions by payee", zap.String("payee_ids", fmt.Sprintf("%+v", payeeIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM transactions
+		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
+		AND NOT is_payee_internal
+		AND payee_id = ANY($1::TEXT[])
+		ORDER BY effective_date, amount
+	`, transactionColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(payeeIDs))
+	for _, id := range payeeIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting transactions by payee: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected transactions by payee", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	transactions, err := pgx.CollectRows(rows, pgx.RowToStructByName[Transaction])
+	if err != nil {
+		return nil, fmt.Errorf("selecting transactions by payee: %w", err)
+	}
+	db.log.Debugw("Selected transactions by payee scanned", zap.Int("number_of_transactions", len(transactions)))
+	return structsToPointers(transactions), nil
+}
+
 func (db DB) SelectTransactionsByRequestID(ctx context.Context, queryer Queryer, requestIDs ...string) (map[string]*Transaction, error) {
 	db.log.Debugw("Selecting transactions by request ID", zap.String("request_ids", fmt.Sprintf("%+v", requestIDs)))
 
@@ -180,6 +228,7 @@ func (db DB) SelectTransactionsByRequestID(ctx context.Context, queryer Queryer,
 		SELECT %[1]s
 		FROM transactions
 		WHERE request_id = ANY($1::TEXT[])
+		AND budget_id = current_setting('budgit.budget_id')
 	`, transactionColumnsStr)
 
 	ids := make([]pgtype.Text, 0, len(requestIDs))
@@ -209,6 +258,7 @@ func (db DB) SelectTransactionsByID(ctx context.Context, queryer Queryer, transa
 		SELECT %[1]s
 		FROM transactions
 		WHERE valid_to_timestamp = 'infinity'
+		AND budget_id = current_setting('budgit.budget_id')
 		AND id = ANY($1::TEXT[])
 	`, transactionColumnsStr)
 
@@ -243,6 +293,12 @@ func transactionsToArgs(transactions []*Transaction) []any {
 	is_payee_internals := make([]pgtype.Bool, 0, len(transactions))
 	amounts := make([]pgtype.Int8, 0, len(transactions))
 	cleareds := make([]pgtype.Bool, 0, len(transactions))
+	memos := make([]pgtype.Text, 0, len(transactions))
+	importIDs := make([]pgtype.Text, 0, len(transactions))
+	importIntegrationIDs := make([]pgtype.Text, 0, len(transactions))
+	categoryIDs := make([]pgtype.Text, 0, len(transactions))
+	splitIDs := make([]pgtype.Text, 0, len(transactions))
+	createdBys := make([]pgtype.Text, 0, len(transactions))
 	for _, transaction := range transactions {
 		requestIDs = append(requestIDs, transaction.RequestID)
 		validFromTimestamps = append(validFromTimestamps, transaction.ValidFromTimestamp)
@@ -254,6 +310,12 @@ func transactionsToArgs(transactions []*Transaction) []any {
 		is_payee_internals = append(is_payee_internals, transaction.IsPayeeInternal)
 		amounts = append(amounts, transaction.Amount)
 		cleareds = append(cleareds, transaction.Cleared)
+		memos = append(memos, transaction.Memo)
+		importIDs = append(importIDs, transaction.ImportID)
+		importIntegrationIDs = append(importIntegrationIDs, transaction.ImportIntegrationID)
+		categoryIDs = append(categoryIDs, transaction.CategoryID)
+		splitIDs = append(splitIDs, transaction.SplitID)
+		createdBys = append(createdBys, transaction.CreatedBy)
 	}
 	return []any{
 		requestIDs,
@@ -266,5 +328,11 @@ func transactionsToArgs(transactions []*Transaction) []any {
 		is_payee_internals,
 		amounts,
 		cleareds,
+		memos,
+		importIDs,
+		importIntegrationIDs,
+		categoryIDs,
+		splitIDs,
+		createdBys,
 	}
 }
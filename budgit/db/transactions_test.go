@@ -348,3 +348,55 @@ func (s *dbSuite) TestSelectTransactionsByAccount() {
 	s.NoError(err)
 	s.CMPEqual(expectedTransactions, actualTransactions)
 }
+
+func (s *dbSuite) TestSelectTransactionsByPayee() {
+	transactions := []*db.Transaction{
+		{
+			RequestID:          pgtype.Text{String: "request_id-1", Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: "id-1", Valid: true},
+			EffectiveDate:      pgtype.Date{Time: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+			AccountID:          pgtype.Text{String: "account-id-1", Valid: true},
+			PayeeID:            pgtype.Text{String: "payee-id-1", Valid: true},
+			IsPayeeInternal:    pgtype.Bool{Bool: true, Valid: true},
+			Amount:             pgtype.Int8{Int64: 1, Valid: true},
+			Cleared:            pgtype.Bool{Bool: true, Valid: true},
+		},
+		{
+			RequestID:          pgtype.Text{String: "request_id-2", Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: "id-2", Valid: true},
+			EffectiveDate:      pgtype.Date{Time: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true},
+			AccountID:          pgtype.Text{String: "account-id-2", Valid: true},
+			PayeeID:            pgtype.Text{String: "payee-id-1", Valid: true},
+			IsPayeeInternal:    pgtype.Bool{Bool: false, Valid: true},
+			Amount:             pgtype.Int8{Int64: 2, Valid: true},
+			Cleared:            pgtype.Bool{Bool: true, Valid: true},
+		},
+		{
+			RequestID:          pgtype.Text{String: "request_id-3", Valid: true},
+			ValidFromTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+			ValidToTimestamp:   pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+			ID:                 pgtype.Text{String: "id-3", Valid: true},
+			EffectiveDate:      pgtype.Date{Time: time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+			AccountID:          pgtype.Text{String: "account-id-1", Valid: true},
+			PayeeID:            pgtype.Text{String: "payee-id-3", Valid: true},
+			IsPayeeInternal:    pgtype.Bool{Bool: false, Valid: true},
+			Amount:             pgtype.Int8{Int64: 3, Valid: true},
+			Cleared:            pgtype.Bool{Bool: true, Valid: true},
+		},
+	}
+	_, err := s.db.InsertTransactions(context.Background(), s.conn, transactions...)
+	s.Require().NoError(err)
+
+	// The first Transaction is a transfer to the Account "payee-id-1", not to the Payee.
+	expectedTransactions := []*db.Transaction{
+		transactions[1],
+		transactions[2],
+	}
+	actualTransactions, err := s.db.SelectTransactionsByPayee(context.Background(), s.conn, "payee-id-1", "payee-id-3")
+	s.NoError(err)
+	s.CMPEqual(expectedTransactions, actualTransactions)
+}
@@ -0,0 +1,379 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+// User is a user of budgit. Users are not versioned, so that replaced password hashes and TOTP secrets are not kept.
+type User struct {
+	ID               pgtype.Text        `db:"id"`
+	Username         pgtype.Text        `db:"username"`
+	PasswordHash     pgtype.Text        `db:"password_hash"`
+	TOTPSecret       pgtype.Text        `db:"totp_secret"`
+	CreatedTimestamp pgtype.Timestamptz `db:"created_timestamp"`
+}
+
+func (u User) GetID() string {
+	return u.ID.String
+}
+
+func (u User) GetUsername() string {
+	return u.Username.String
+}
+
+var (
+	userColumns    = getAllDBColumns(User{})
+	userColumnsStr = strings.Join(userColumns, ", ")
+)
+
+// InsertUsers inserts users, skipping those whose username is taken, and returns the IDs of those inserted.
+func (db DB) InsertUsers(ctx context.Context, queryer Queryer, users ...*User) ([]string, error) {
+	db.log.Debugw("Inserting users", zap.Int("number_of_users", len(users)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO users (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TEXT[],
+				$4::TEXT[],
+				$5::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING id;
+	`, userColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql, usersToArgs(users)...)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d users: %w", len(users), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted users", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d users: %w", len(users), err)
+	}
+	db.log.Debugw("Inserted users scanned", zap.String("inserted_ids", fmt.Sprintf("%v", ids)))
+	return ids, nil
+}
+
+// UpdateUserPasswordHash sets the password hash of a user, and returns the ID of the user if it exists.
+func (db DB) UpdateUserPasswordHash(ctx context.Context, queryer Queryer, userID string, passwordHash pgtype.Text) ([]string, error) {
+	db.log.Debugw("Updating user password hash", zap.String("user_id", userID))
+
+	sql := `
+		UPDATE users
+		SET password_hash = $2
+		WHERE id = $1
+		RETURNING id;
+	`
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: userID, Valid: true}, passwordHash)
+	if err != nil {
+		return nil, fmt.Errorf("updating user password hash: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated user password hash", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating user password hash: %w", err)
+	}
+	return ids, nil
+}
+
+// UpdateUserTOTPSecret sets the TOTP secret of a user, clearing it if the secret is null, and returns the ID of the
+// user if it exists.
+func (db DB) UpdateUserTOTPSecret(ctx context.Context, queryer Queryer, userID string, secret pgtype.Text) ([]string, error) {
+	db.log.Debugw("Updating user TOTP secret", zap.String("user_id", userID))
+
+	sql := `
+		UPDATE users
+		SET totp_secret = $2
+		WHERE id = $1
+		RETURNING id;
+	`
+
+	rows, err := queryer.Query(ctx, sql, pgtype.Text{String: userID, Valid: true}, secret)
+	if err != nil {
+		return nil, fmt.Errorf("updating user TOTP secret: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Updated user TOTP secret", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("updating user TOTP secret: %w", err)
+	}
+	return ids, nil
+}
+
+func (db DB) SelectUsers(ctx context.Context, queryer Queryer) ([]*User, error) {
+	db.log.Debug("Selecting users")
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM users
+		ORDER BY username
+	`, userColumnsStr)
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("selecting users: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected users", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, fmt.Errorf("selecting users: %w", err)
+	}
+	db.log.Debugw("Selected users scanned", zap.Int("number_of_users", len(users)))
+	return structsToPointers(users), nil
+}
+
+func (db DB) SelectUsersByID(ctx context.Context, queryer Queryer, userIDs ...string) (map[string]*User, error) {
+	db.log.Debugw("Selecting users by ID", zap.String("user_ids", fmt.Sprintf("%+v", userIDs)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM users
+		WHERE id = ANY($1::TEXT[])
+	`, userColumnsStr)
+
+	ids := make([]pgtype.Text, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, pgtype.Text{String: id, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("selecting users by ID: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected users by ID", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, fmt.Errorf("selecting users by ID: %w", err)
+	}
+	db.log.Debugw("Selected users by ID scanned", zap.Int("number_of_users", len(users)))
+	return mapByID(structsToPointers(users)), nil
+}
+
+func (db DB) SelectUsersByUsername(ctx context.Context, queryer Queryer, usernames ...string) (map[string]*User, error) {
+	db.log.Debugw("Selecting users by username", zap.String("usernames", fmt.Sprintf("%+v", usernames)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM users
+		WHERE username = ANY($1::TEXT[])
+	`, userColumnsStr)
+
+	names := make([]pgtype.Text, 0, len(usernames))
+	for _, name := range usernames {
+		names = append(names, pgtype.Text{String: name, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, names)
+	if err != nil {
+		return nil, fmt.Errorf("selecting users by username: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected users by username", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	users, err := pgx.CollectRows(rows, pgx.RowToStructByName[User])
+	if err != nil {
+		return nil, fmt.Errorf("selecting users by username: %w", err)
+	}
+	db.log.Debugw("Selected users by username scanned", zap.Int("number_of_users", len(users)))
+
+	usersByUsername := make(map[string]*User, len(users))
+	for _, user := range structsToPointers(users) {
+		usersByUsername[user.GetUsername()] = user
+	}
+	return usersByUsername, nil
+}
+
+func usersToArgs(users []*User) []any {
+	ids := make([]pgtype.Text, 0, len(users))
+	usernames := make([]pgtype.Text, 0, len(users))
+	passwordHashes := make([]pgtype.Text, 0, len(users))
+	totpSecrets := make([]pgtype.Text, 0, len(users))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+		usernames = append(usernames, user.Username)
+		passwordHashes = append(passwordHashes, user.PasswordHash)
+		totpSecrets = append(totpSecrets, user.TOTPSecret)
+		createdTimestamps = append(createdTimestamps, user.CreatedTimestamp)
+	}
+	return []any{
+		ids,
+		usernames,
+		passwordHashes,
+		totpSecrets,
+		createdTimestamps,
+	}
+}
+
+// Session is a session of a User, logged in with their password. Only the hash of its token is stored.
+type Session struct {
+	TokenHash        pgtype.Text        `db:"token_hash"`
+	UserID           pgtype.Text        `db:"user_id"`
+	CreatedTimestamp pgtype.Timestamptz `db:"created_timestamp"`
+	ExpiresTimestamp pgtype.Timestamptz `db:"expires_timestamp"`
+}
+
+func (s Session) GetTokenHash() string {
+	return s.TokenHash.String
+}
+
+var (
+	sessionColumns    = getAllDBColumns(Session{})
+	sessionColumnsStr = strings.Join(sessionColumns, ", ")
+)
+
+func (db DB) InsertSessions(ctx context.Context, queryer Queryer, sessions ...*Session) ([]string, error) {
+	db.log.Debugw("Inserting sessions", zap.Int("number_of_sessions", len(sessions)))
+
+	sql := fmt.Sprintf(`
+		INSERT INTO sessions (%[1]s)
+		(
+			SELECT %[1]s
+			FROM UNNEST(
+				$1::TEXT[],
+				$2::TEXT[],
+				$3::TIMESTAMPTZ[],
+				$4::TIMESTAMPTZ[]
+			)
+			AS u(%[1]s)
+		)
+		ON CONFLICT DO NOTHING
+		RETURNING user_id;
+	`, sessionColumnsStr)
+
+	tokenHashes := make([]pgtype.Text, 0, len(sessions))
+	userIDs := make([]pgtype.Text, 0, len(sessions))
+	createdTimestamps := make([]pgtype.Timestamptz, 0, len(sessions))
+	expiresTimestamps := make([]pgtype.Timestamptz, 0, len(sessions))
+	for _, session := range sessions {
+		tokenHashes = append(tokenHashes, session.TokenHash)
+		userIDs = append(userIDs, session.UserID)
+		createdTimestamps = append(createdTimestamps, session.CreatedTimestamp)
+		expiresTimestamps = append(expiresTimestamps, session.ExpiresTimestamp)
+	}
+
+	rows, err := queryer.Query(ctx, sql, tokenHashes, userIDs, createdTimestamps, expiresTimestamps)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d sessions: %w", len(sessions), err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Inserted sessions", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("inserting %d sessions: %w", len(sessions), err)
+	}
+	return ids, nil
+}
+
+// SelectSessionsByTokenHash returns the sessions with the given token hashes which have not expired, by token hash.
+func (db DB) SelectSessionsByTokenHash(ctx context.Context, queryer Queryer, tokenHashes ...string) (map[string]*Session, error) {
+	db.log.Debugw("Selecting sessions by token hash", zap.Int("number_of_token_hashes", len(tokenHashes)))
+
+	sql := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM sessions
+		WHERE expires_timestamp > NOW()
+		AND token_hash = ANY($1::TEXT[])
+	`, sessionColumnsStr)
+
+	hashes := make([]pgtype.Text, 0, len(tokenHashes))
+	for _, hash := range tokenHashes {
+		hashes = append(hashes, pgtype.Text{String: hash, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("selecting sessions by token hash: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected sessions by token hash", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	sessions, err := pgx.CollectRows(rows, pgx.RowToStructByName[Session])
+	if err != nil {
+		return nil, fmt.Errorf("selecting sessions by token hash: %w", err)
+	}
+	db.log.Debugw("Selected sessions by token hash scanned", zap.Int("number_of_sessions", len(sessions)))
+
+	sessionsByTokenHash := make(map[string]*Session, len(sessions))
+	for _, session := range structsToPointers(sessions) {
+		sessionsByTokenHash[session.GetTokenHash()] = session
+	}
+	return sessionsByTokenHash, nil
+}
+
+// DeleteSessions deletes the sessions with the given token hashes, and returns the IDs of the users they were of.
+func (db DB) DeleteSessions(ctx context.Context, queryer Queryer, tokenHashes ...string) ([]string, error) {
+	db.log.Debugw("Deleting sessions", zap.Int("number_of_token_hashes", len(tokenHashes)))
+
+	sql := `
+		DELETE FROM sessions
+		WHERE token_hash = ANY($1::TEXT[])
+		RETURNING user_id;
+	`
+
+	hashes := make([]pgtype.Text, 0, len(tokenHashes))
+	for _, hash := range tokenHashes {
+		hashes = append(hashes, pgtype.Text{String: hash, Valid: true})
+	}
+
+	rows, err := queryer.Query(ctx, sql, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("deleting sessions: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Deleted sessions", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("deleting sessions: %w", err)
+	}
+	return ids, nil
+}
+
+// DeleteExpiredSessions deletes the sessions which have expired, and returns the IDs of the users they were of.
+func (db DB) DeleteExpiredSessions(ctx context.Context, queryer Queryer) ([]string, error) {
+	db.log.Debug("Deleting expired sessions")
+
+	sql := `
+		DELETE FROM sessions
+		WHERE expires_timestamp <= NOW()
+		RETURNING user_id;
+	`
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("deleting expired sessions: %w", err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Deleted expired sessions", zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	ids, err := rowsToIDs(rows)
+	if err != nil {
+		return nil, fmt.Errorf("deleting expired sessions: %w", err)
+	}
+	return ids, nil
+}
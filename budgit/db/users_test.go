@@ -0,0 +1,150 @@
+package db_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testUsers() []*db.User {
+	return []*db.User{
+		{
+			ID:               pgtype.Text{String: "id-1", Valid: true},
+			Username:         pgtype.Text{String: "username-1", Valid: true},
+			PasswordHash:     pgtype.Text{String: "password_hash-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(1, 0).UTC(), Valid: true},
+		},
+		{
+			ID:               pgtype.Text{String: "id-2", Valid: true},
+			Username:         pgtype.Text{String: "username-2", Valid: true},
+			PasswordHash:     pgtype.Text{String: "password_hash-2", Valid: true},
+			TOTPSecret:       pgtype.Text{String: "totp_secret-2", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(2, 0).UTC(), Valid: true},
+		},
+	}
+}
+
+func (s *dbSuite) TestInsertUsers() {
+	ids, err := s.db.InsertUsers(context.Background(), s.conn, testUsers()...)
+	s.NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-2"}, ids)
+
+	s.Run("UsernameTaken", func() {
+		ids, err := s.db.InsertUsers(context.Background(), s.conn, &db.User{
+			ID:               pgtype.Text{String: "id-3", Valid: true},
+			Username:         pgtype.Text{String: "username-1", Valid: true},
+			PasswordHash:     pgtype.Text{String: "password_hash-3", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: time.Unix(3, 0).UTC(), Valid: true},
+		})
+		s.NoError(err)
+		s.Empty(ids)
+	})
+}
+
+func (s *dbSuite) TestSelectUsers() {
+	users := testUsers()
+	_, err := s.db.InsertUsers(context.Background(), s.conn, users...)
+	s.Require().NoError(err)
+
+	actualUsers, err := s.db.SelectUsers(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(users, actualUsers)
+
+	usersByID, err := s.db.SelectUsersByID(context.Background(), s.conn, "id-2", "id-3")
+	s.NoError(err)
+	s.CMPEqual(map[string]*db.User{"id-2": users[1]}, usersByID)
+
+	usersByUsername, err := s.db.SelectUsersByUsername(context.Background(), s.conn, "username-1")
+	s.NoError(err)
+	s.CMPEqual(map[string]*db.User{"username-1": users[0]}, usersByUsername)
+}
+
+func (s *dbSuite) TestUpdateUserPasswordHash() {
+	users := testUsers()
+	_, err := s.db.InsertUsers(context.Background(), s.conn, users...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateUserPasswordHash(context.Background(), s.conn, "id-1", pgtype.Text{String: "password_hash-3", Valid: true})
+	s.NoError(err)
+	s.Equal([]string{"id-1"}, ids)
+	ids, err = s.db.UpdateUserPasswordHash(context.Background(), s.conn, "id-3", pgtype.Text{String: "password_hash-3", Valid: true})
+	s.NoError(err)
+	s.Empty(ids)
+
+	users[0].PasswordHash = pgtype.Text{String: "password_hash-3", Valid: true}
+	actualUsers, err := s.db.SelectUsers(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(users, actualUsers)
+}
+
+func (s *dbSuite) TestUpdateUserTOTPSecret() {
+	users := testUsers()
+	_, err := s.db.InsertUsers(context.Background(), s.conn, users...)
+	s.Require().NoError(err)
+
+	ids, err := s.db.UpdateUserTOTPSecret(context.Background(), s.conn, "id-1", pgtype.Text{String: "totp_secret-1", Valid: true})
+	s.NoError(err)
+	s.Equal([]string{"id-1"}, ids)
+	ids, err = s.db.UpdateUserTOTPSecret(context.Background(), s.conn, "id-2", pgtype.Text{})
+	s.NoError(err)
+	s.Equal([]string{"id-2"}, ids)
+
+	users[0].TOTPSecret = pgtype.Text{String: "totp_secret-1", Valid: true}
+	users[1].TOTPSecret = pgtype.Text{}
+	actualUsers, err := s.db.SelectUsers(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(users, actualUsers)
+}
+
+func (s *dbSuite) TestSessions() {
+	_, err := s.db.InsertUsers(context.Background(), s.conn, testUsers()...)
+	s.Require().NoError(err)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	sessions := []*db.Session{
+		{
+			TokenHash:        pgtype.Text{String: "token_hash-1", Valid: true},
+			UserID:           pgtype.Text{String: "id-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+		},
+		{
+			TokenHash:        pgtype.Text{String: "token_hash-2", Valid: true},
+			UserID:           pgtype.Text{String: "id-1", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: now.Add(-2 * time.Hour), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+		},
+		{
+			TokenHash:        pgtype.Text{String: "token_hash-3", Valid: true},
+			UserID:           pgtype.Text{String: "id-2", Valid: true},
+			CreatedTimestamp: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true},
+			ExpiresTimestamp: pgtype.Timestamptz{Time: now.Add(time.Hour), Valid: true},
+		},
+	}
+	userIDs, err := s.db.InsertSessions(context.Background(), s.conn, sessions...)
+	s.Require().NoError(err)
+	s.ElementsMatch([]string{"id-1", "id-1", "id-2"}, userIDs)
+
+	s.Run("SelectSkipsExpired", func() {
+		actualSessions, err := s.db.SelectSessionsByTokenHash(context.Background(), s.conn, "token_hash-1", "token_hash-2")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.Session{"token_hash-1": sessions[0]}, actualSessions)
+	})
+
+	s.Run("DeleteExpired", func() {
+		userIDs, err := s.db.DeleteExpiredSessions(context.Background(), s.conn)
+		s.NoError(err)
+		s.Equal([]string{"id-1"}, userIDs)
+	})
+
+	s.Run("Delete", func() {
+		userIDs, err := s.db.DeleteSessions(context.Background(), s.conn, "token_hash-3")
+		s.NoError(err)
+		s.Equal([]string{"id-2"}, userIDs)
+
+		actualSessions, err := s.db.SelectSessionsByTokenHash(context.Background(), s.conn, "token_hash-1", "token_hash-3")
+		s.NoError(err)
+		s.CMPEqual(map[string]*db.Session{"token_hash-1": sessions[0]}, actualSessions)
+	})
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// SchemaVersion is the number of the latest migration, which the row types of this package match.
+// It must be increased with each new migration.
+const SchemaVersion = 12
+
+// budgetTables are the tables holding a budget. Their rows belong to the budget of their budget_id, see SetBudget.
+var budgetTables = []string{
+	"accounts",
+	"payees",
+	"category_groups",
+	"categories",
+	"transactions",
+	"attachments",
+	"csv_profiles",
+	"assignments",
+}
+
+// SelectAccountVersions returns every version of every Account, including those no longer valid.
+func (db DB) SelectAccountVersions(ctx context.Context, queryer Queryer) ([]*Account, error) {
+	return selectVersions[Account](ctx, db, queryer, "accounts", accountColumnsStr)
+}
+
+// SelectPayeeVersions returns every version of every Payee, including those no longer valid.
+func (db DB) SelectPayeeVersions(ctx context.Context, queryer Queryer) ([]*Payee, error) {
+	return selectVersions[Payee](ctx, db, queryer, "payees", payeeColumnsStr)
+}
+
+// SelectCategoryGroupVersions returns every version of every CategoryGroup, including those no longer valid.
+func (db DB) SelectCategoryGroupVersions(ctx context.Context, queryer Queryer) ([]*CategoryGroup, error) {
+	return selectVersions[CategoryGroup](ctx, db, queryer, "category_groups", categoryGroupColumnsStr)
+}
+
+// SelectCategoryVersions returns every version of every Category, including those no longer valid.
+func (db DB) SelectCategoryVersions(ctx context.Context, queryer Queryer) ([]*Category, error) {
+	return selectVersions[Category](ctx, db, queryer, "categories", categoryColumnsStr)
+}
+
+// SelectTransactionVersions returns every version of every Transaction, including those no longer valid.
+func (db DB) SelectTransactionVersions(ctx context.Context, queryer Queryer) ([]*Transaction, error) {
+	return selectVersions[Transaction](ctx, db, queryer, "transactions", transactionColumnsStr)
+}
+
+// SelectAttachmentVersions returns every version of every Attachment, including those no longer valid.
+func (db DB) SelectAttachmentVersions(ctx context.Context, queryer Queryer) ([]*Attachment, error) {
+	return selectVersions[Attachment](ctx, db, queryer, "attachments", attachmentColumnsStr)
+}
+
+// SelectCSVProfileVersions returns every version of every CSVProfile, including those no longer valid.
+func (db DB) SelectCSVProfileVersions(ctx context.Context, queryer Queryer) ([]*CSVProfile, error) {
+	return selectVersions[CSVProfile](ctx, db, queryer, "csv_profiles", csvProfileColumnsStr)
+}
+
+// SelectAssignmentVersions returns every version of every Assignment, including those no longer valid.
+func (db DB) SelectAssignmentVersions(ctx context.Context, queryer Queryer) ([]*Assignment, error) {
+	return selectVersions[Assignment](ctx, db, queryer, "assignments", assignmentColumnsStr)
+}
+
+func selectVersions[E any](ctx context.Context, db DB, queryer Queryer, table, columns string) ([]*E, error) {
+	db.log.Debugw("Selecting versions", zap.String("table", table))
+
+	sql := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE budget_id = current_setting('budgit.budget_id')
+		ORDER BY valid_from_timestamp, request_id
+	`, columns, table)
+
+	rows, err := queryer.Query(ctx, sql)
+	if err != nil {
+		return nil, fmt.Errorf("selecting %s versions: %w", table, err)
+	}
+	defer rows.Close()
+	db.log.Debugw("Selected versions", zap.String("table", table), zap.Int64("rows_affected", rows.CommandTag().RowsAffected()))
+
+	versions, err := pgx.CollectRows(rows, pgx.RowToStructByName[E])
+	if err != nil {
+		return nil, fmt.Errorf("selecting %s versions: %w", table, err)
+	}
+	db.log.Debugw("Selected versions scanned", zap.String("table", table), zap.Int("number_of_versions", len(versions)))
+	return structsToPointers(versions), nil
+}
+
+// SelectIsEmpty returns whether no table of the budget has any rows, current or not.
+func (db DB) SelectIsEmpty(ctx context.Context, queryer Queryer) (bool, error) {
+	db.log.Debug("Selecting is empty")
+
+	exists := make([]string, 0, len(budgetTables))
+	for _, table := range budgetTables {
+		exists = append(exists, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s WHERE budget_id = current_setting('budgit.budget_id'))", table))
+	}
+	sql := fmt.Sprintf(`SELECT %s`, strings.Join(exists, " AND "))
+
+	var empty bool
+	if err := queryer.QueryRow(ctx, sql).Scan(&empty); err != nil {
+		return false, fmt.Errorf("selecting is empty: %w", err)
+	}
+	db.log.Debugw("Selected is empty", zap.Bool("is_empty", empty))
+	return empty, nil
+}
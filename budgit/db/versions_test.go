@@ -0,0 +1,69 @@
+package db_test
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *dbSuite) TestSchemaVersion() {
+	migrations := 0
+	err := filepath.WalkDir("../migrations", func(path string, d fs.DirEntry, _ error) error {
+		if strings.HasSuffix(path, ".up.sql") {
+			migrations++
+		}
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(migrations, db.SchemaVersion, "expected SchemaVersion to be the number of the latest migration")
+}
+
+func (s *dbSuite) TestSelectAssignmentVersions() {
+	assignments := testAssignments()
+	_, err := s.db.InsertAssignments(context.Background(), s.conn, assignments...)
+	s.Require().NoError(err)
+	_, err = s.db.UpdateAssignmentValidToTimestamps(context.Background(), s.conn, db.ValidToTimestampUpdate{
+		ID:               pgtype.Text{String: "id-2", Valid: true},
+		ValidToTimestamp: pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true},
+	})
+	s.Require().NoError(err)
+	assignments[1].ValidToTimestamp = pgtype.Timestamptz{Time: time.Unix(4, 0).UTC(), Valid: true}
+
+	actualAssignments, err := s.db.SelectAssignmentVersions(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(assignments, actualAssignments)
+}
+
+func (s *dbSuite) TestSelectCategoryVersions() {
+	categoryGroups, categories := testCategoryGroups(), testCategories()
+	_, err := s.db.InsertCategoryGroups(context.Background(), s.conn, categoryGroups...)
+	s.Require().NoError(err)
+	_, err = s.db.InsertCategories(context.Background(), s.conn, categories...)
+	s.Require().NoError(err)
+
+	actualCategoryGroups, err := s.db.SelectCategoryGroupVersions(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(categoryGroups, actualCategoryGroups)
+
+	actualCategories, err := s.db.SelectCategoryVersions(context.Background(), s.conn)
+	s.NoError(err)
+	s.CMPEqual(categories, actualCategories)
+}
+
+func (s *dbSuite) TestSelectIsEmpty() {
+	empty, err := s.db.SelectIsEmpty(context.Background(), s.conn)
+	s.NoError(err)
+	s.True(empty)
+
+	_, err = s.db.InsertAttachments(context.Background(), s.conn, testAttachments()...)
+	s.Require().NoError(err)
+
+	empty, err = s.db.SelectIsEmpty(context.Background(), s.conn)
+	s.NoError(err)
+	s.False(empty)
+}
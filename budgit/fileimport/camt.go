@@ -0,0 +1,226 @@
+package fileimport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+var ErrCAMTInvalid = fmt.Errorf("the file is not a camt.053 document")
+
+// camtDocument is the part of an ISO 20022 camt.053 bank to customer statement document read by ParseCAMT.
+// Elements are matched regardless of namespace, so that any version of the message can be read.
+type camtDocument struct {
+	XMLName    xml.Name        `xml:"Document"`
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	Account struct {
+		IBAN     string `xml:"Id>IBAN"`
+		Other    string `xml:"Id>Othr>Id"`
+		Currency string `xml:"Ccy"`
+	} `xml:"Acct"`
+	Balances []struct {
+		Type   string     `xml:"Tp>CdOrPrtry>Cd"`
+		Amount camtAmount `xml:"Amt"`
+		Credit string     `xml:"CdtDbtInd"`
+		Date   camtDate   `xml:"Dt"`
+	} `xml:"Bal"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+type camtEntry struct {
+	Reference           string     `xml:"NtryRef"`
+	Amount              camtAmount `xml:"Amt"`
+	Credit              string     `xml:"CdtDbtInd"`
+	Reversal            bool       `xml:"RvslInd"`
+	Status              camtStatus `xml:"Sts"`
+	BookingDate         camtDate   `xml:"BookgDt"`
+	ValueDate           camtDate   `xml:"ValDt"`
+	ServicerReference   string     `xml:"AcctSvcrRef"`
+	AdditionalEntryInfo string     `xml:"AddtlNtryInf"`
+	Details             []struct {
+		ServicerReference string    `xml:"Refs>AcctSvcrRef"`
+		EndToEndID        string    `xml:"Refs>EndToEndId"`
+		Debtor            camtParty `xml:"RltdPties>Dbtr"`
+		Creditor          camtParty `xml:"RltdPties>Cdtr"`
+		Unstructured      []string  `xml:"RmtInf>Ustrd"`
+	} `xml:"NtryDtls>TxDtls"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate is a date, given either as a date or as a datetime depending on the version of the message.
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtStatus is the status of an entry, given either as text or as a code depending on the version of the message.
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+// camtParty is a party to a transaction, whose name is nested in Pty in later versions of the message.
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+// ParseCAMT parses the statements of an ISO 20022 camt.053 document. Transactions are identified by the bank's
+// reference, and take the name of their counterparty as their payee. Pending entries are parsed as uncleared
+// transactions, and information-only entries are skipped.
+//
+// An UnbalancedStatementError is returned if the booked entries of a statement do not account for its opening and
+// closing booked balances.
+func ParseCAMT(r io.Reader) ([]*BankStatement, error) {
+	var document camtDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("parsing camt.053: %w: %w", ErrCAMTInvalid, err)
+	}
+
+	statements := make([]*BankStatement, 0, len(document.Statements))
+	ids := newImportIDs("camt")
+	for _, camtStatement := range document.Statements {
+		statement, err := toCAMTStatement(camtStatement, ids)
+		if err != nil {
+			return nil, fmt.Errorf("parsing camt.053: %w", err)
+		}
+		if err := statement.checkBalances(); err != nil {
+			return nil, fmt.Errorf("parsing camt.053: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+func toCAMTStatement(camtStatement camtStatement, ids *importIDs) (*BankStatement, error) {
+	statement := &BankStatement{
+		AccountID:    camtStatement.Account.IBAN,
+		Currency:     camtStatement.Account.Currency,
+		Transactions: []*budgit.ExternalTransaction{},
+	}
+	if statement.AccountID == "" {
+		statement.AccountID = camtStatement.Account.Other
+	}
+
+	for _, balance := range camtStatement.Balances {
+		amount, err := toCAMTAmount(balance.Amount, balance.Credit, false)
+		if err != nil {
+			return nil, fmt.Errorf("%s balance: %w", balance.Type, err)
+		}
+		date, err := balance.Date.parse()
+		if err != nil {
+			return nil, fmt.Errorf("%s balance: %w", balance.Type, err)
+		}
+		switch balance.Type {
+		case "OPBD":
+			statement.OpeningBalance, statement.OpeningBalanceDate, statement.HasOpeningBalance = amount, date, true
+		case "PRCD":
+			// A previous closing balance stands in for the opening balance if the statement has none.
+			if !statement.HasOpeningBalance {
+				statement.OpeningBalance, statement.OpeningBalanceDate, statement.HasOpeningBalance = amount, date, true
+			}
+		case "CLBD":
+			statement.ClosingBalance, statement.ClosingBalanceDate, statement.HasClosingBalance = amount, date, true
+		}
+	}
+
+	for _, entry := range camtStatement.Entries {
+		status := strings.TrimSpace(entry.Status.Value)
+		if entry.Status.Code != "" {
+			status = entry.Status.Code
+		}
+		if status == "INFO" {
+			continue
+		}
+		transaction, err := toCAMTTransaction(entry, status == "BOOK")
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", entry.Reference, err)
+		}
+		if transaction.ID == "" {
+			transaction.ID = ids.next(transaction)
+		}
+		statement.Transactions = append(statement.Transactions, transaction)
+	}
+	return statement, nil
+}
+
+func toCAMTTransaction(entry camtEntry, booked bool) (*budgit.ExternalTransaction, error) {
+	amount, err := toCAMTAmount(entry.Amount, entry.Credit, entry.Reversal)
+	if err != nil {
+		return nil, err
+	}
+	date, err := entry.BookingDate.parse()
+	if entry.BookingDate == (camtDate{}) {
+		date, err = entry.ValueDate.parse()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &budgit.ExternalTransaction{
+		ID:            entry.ServicerReference,
+		EffectiveDate: date,
+		Memo:          strings.TrimSpace(entry.AdditionalEntryInfo),
+		Amount:        amount,
+		Cleared:       booked,
+	}
+	// Counterparties and remittance information are only taken from entries of a single transaction, as batch
+	// bookings have many.
+	if len(entry.Details) == 1 {
+		details := entry.Details[0]
+		if transaction.ID == "" {
+			transaction.ID = details.ServicerReference
+		}
+		counterparty := details.Creditor
+		if amount > 0 {
+			counterparty = details.Debtor
+		}
+		transaction.PayeeName = strings.TrimSpace(counterparty.Name + counterparty.PartyName)
+		if details.EndToEndID != "NOTPROVIDED" {
+			transaction.Reference = details.EndToEndID
+		}
+		if remittance := strings.TrimSpace(strings.Join(details.Unstructured, " ")); remittance != "" {
+			transaction.Memo = remittance
+		}
+	}
+	return transaction, nil
+}
+
+// toCAMTAmount returns an amount, negated if it is a debit. Reversals flip the direction given by credit.
+func toCAMTAmount(amount camtAmount, credit string, reversal bool) (budgit.BalanceAmount, error) {
+	parsed, err := budgit.ParseBalanceAmount(amount.Value)
+	if err != nil {
+		return 0, err
+	}
+	if (credit == "DBIT") != reversal {
+		parsed = -parsed
+	}
+	return parsed, nil
+}
+
+// parse parses a date such as "2024-06-01", or the date of a datetime such as "2024-06-01T09:30:00+01:00".
+func (d camtDate) parse() (time.Time, error) {
+	str := strings.TrimSpace(d.Date)
+	if str == "" {
+		str = strings.TrimSpace(d.DateTime)
+	}
+	if len(str) < 10 {
+		return time.Time{}, fmt.Errorf("parsing date %q: too short", str)
+	}
+	date, err := time.Parse(time.DateOnly, str[:10])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing date %q: %w", str, err)
+	}
+	return date, nil
+}
@@ -0,0 +1,117 @@
+package fileimport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+const camt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>MSG-1</MsgId><CreDtTm>2024-06-04T06:00:00+01:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <Acct><Id><IBAN>GB33BUKB20201555555555</IBAN></Id><Ccy>GBP</Ccy></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="GBP">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-06-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="GBP">1200.50</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-06-03</Dt></Dt>
+      </Bal>
+      <Ntry>
+        <NtryRef>1</NtryRef>
+        <Amt Ccy="GBP">250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><Dt>2024-06-01</Dt></BookgDt>
+        <ValDt><Dt>2024-06-01</Dt></ValDt>
+        <AcctSvcrRef>BANKREF-1</AcctSvcrRef>
+        <NtryDtls><TxDtls>
+          <Refs><EndToEndId>INV-42</EndToEndId></Refs>
+          <RltdPties>
+            <Dbtr><Pty><Nm>Acme Ltd</Nm></Pty></Dbtr>
+            <Cdtr><Pty><Nm>Our Business</Nm></Pty></Cdtr>
+          </RltdPties>
+          <RmtInf><Ustrd>Invoice 42</Ustrd><Ustrd>June</Ustrd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="GBP">49.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-06-02T09:30:00+01:00</DtTm></BookgDt>
+        <AddtlNtryInf>Bank charges</AddtlNtryInf>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="GBP">20.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-06-03</Dt></BookgDt>
+        <NtryDtls><TxDtls>
+          <Refs><AcctSvcrRef>BANKREF-3</AcctSvcrRef><EndToEndId>NOTPROVIDED</EndToEndId></Refs>
+          <RltdPties><Cdtr><Nm>Stationers</Nm></Cdtr></RltdPties>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="GBP">5.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>INFO</Cd></Sts>
+        <BookgDt><Dt>2024-06-03</Dt></BookgDt>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func (s *fileImportSuite) TestParseCAMT() {
+	statements, err := fileimport.ParseCAMT(strings.NewReader(camt053))
+	s.Require().NoError(err)
+	s.Require().Len(statements, 1)
+	s.Require().Len(statements[0].Transactions, 3)
+	// Transactions without a bank reference are given one derived from their fields.
+	s.True(strings.HasPrefix(statements[0].Transactions[1].ID, "camt-"))
+	statements[0].Transactions[1].ID = ""
+
+	expected := []*fileimport.BankStatement{
+		{
+			AccountID: "GB33BUKB20201555555555",
+			Currency:  "GBP",
+			Transactions: []*budgit.ExternalTransaction{
+				{ID: "BANKREF-1", EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "Acme Ltd", Reference: "INV-42", Memo: "Invoice 42 June", Amount: 25000, Cleared: true},
+				{EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Memo: "Bank charges", Amount: -4950, Cleared: true},
+				{ID: "BANKREF-3", EffectiveDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), PayeeName: "Stationers", Amount: -2000},
+			},
+			OpeningBalance:     100000,
+			OpeningBalanceDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			HasOpeningBalance:  true,
+			ClosingBalance:     120050,
+			ClosingBalanceDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			HasClosingBalance:  true,
+		},
+	}
+	s.CMPEqual(expected, statements)
+}
+
+func (s *fileImportSuite) TestParseCAMTErrors() {
+	s.Run("NotCAMT", func() {
+		_, err := fileimport.ParseCAMT(strings.NewReader("<OFX></OFX>"))
+		s.ErrorIs(err, fileimport.ErrCAMTInvalid)
+	})
+	s.Run("Unbalanced", func() {
+		_, err := fileimport.ParseCAMT(strings.NewReader(strings.Replace(camt053, "1200.50", "1300.50", 1)))
+		s.ErrorAs(err, &fileimport.UnbalancedStatementError{})
+	})
+	s.Run("InvalidAmount", func() {
+		_, err := fileimport.ParseCAMT(strings.NewReader(strings.Replace(camt053, "49.50", "lots", 1)))
+		s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+	})
+}
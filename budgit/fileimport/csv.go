@@ -0,0 +1,239 @@
+package fileimport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+const defaultCSVDateFormat = "2006-01-02"
+
+var (
+	ErrCSVDateColumnMissing   = fmt.Errorf("the CSV profile has no date column")
+	ErrCSVAmountColumnMissing = fmt.Errorf("the CSV profile has neither an amount column nor debit and credit columns")
+)
+
+// CSVColumnNotFoundError is returned when a column of a CSV profile is not in the CSV statement.
+type CSVColumnNotFoundError struct {
+	Column string
+}
+
+func (e CSVColumnNotFoundError) Error() string {
+	return fmt.Sprintf("column %q is not in the CSV statement", e.Column)
+}
+
+// ValidateCSVProfile returns an error if the profile cannot map a statement's rows to transactions.
+func ValidateCSVProfile(profile *budgit.CSVProfile) error {
+	errs := []error{}
+	if profile.DateColumn == "" {
+		errs = append(errs, ErrCSVDateColumnMissing)
+	}
+	if profile.AmountColumn == "" && profile.DebitColumn == "" && profile.CreditColumn == "" {
+		errs = append(errs, ErrCSVAmountColumnMissing)
+	}
+	if !profile.HasHeader {
+		for _, column := range []string{profile.DateColumn, profile.PayeeColumn, profile.MemoColumn, profile.AmountColumn, profile.DebitColumn, profile.CreditColumn} {
+			if position, err := strconv.Atoi(column); column != "" && (err != nil || position < 1) {
+				errs = append(errs, CSVColumnNotFoundError{Column: column})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ParseCSV parses the transactions of a CSV statement, mapping its columns to transactions with the given profile.
+// Each transaction is given an ID derived from its fields, as CSV statements do not identify their rows.
+func ParseCSV(r io.Reader, profile *budgit.CSVProfile) ([]*budgit.ExternalTransaction, error) {
+	if err := ValidateCSVProfile(profile); err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+
+	reader := csv.NewReader(skipByteOrderMark(r))
+	if profile.Delimiter != 0 {
+		reader.Comma = profile.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for range profile.SkipRows {
+		if _, err := reader.Read(); err != nil {
+			return nil, fmt.Errorf("parsing CSV: skipping rows: %w", err)
+		}
+	}
+
+	var header []string
+	if profile.HasHeader {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("parsing CSV: reading header: %w", err)
+		}
+		header = record
+	}
+	columns, err := newCSVColumns(profile, header)
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+
+	ids := newImportIDs("csv")
+	transactions := []*budgit.ExternalTransaction{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing CSV: %w", err)
+		}
+		if isBlank(record) {
+			continue
+		}
+
+		transaction, err := columns.transaction(record)
+		if err != nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("parsing CSV: %w", RowError{Line: line, Err: err})
+		}
+		transaction.ID = ids.next(transaction)
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+// csvColumns holds the positions of a profile's columns in a CSV statement, or -1 for columns not in the profile.
+type csvColumns struct {
+	profile                                  *budgit.CSVProfile
+	date, payee, memo, amount, debit, credit int
+}
+
+func newCSVColumns(profile *budgit.CSVProfile, header []string) (*csvColumns, error) {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	position := func(column string) (int, error) {
+		if column == "" {
+			return -1, nil
+		}
+		if header == nil {
+			index, _ := strconv.Atoi(column)
+			return index - 1, nil
+		}
+		index, ok := positions[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return -1, CSVColumnNotFoundError{Column: column}
+		}
+		return index, nil
+	}
+
+	columns := &csvColumns{profile: profile}
+	errs := []error{}
+	for _, c := range []struct {
+		column   string
+		position *int
+	}{
+		{profile.DateColumn, &columns.date},
+		{profile.PayeeColumn, &columns.payee},
+		{profile.MemoColumn, &columns.memo},
+		{profile.AmountColumn, &columns.amount},
+		{profile.DebitColumn, &columns.debit},
+		{profile.CreditColumn, &columns.credit},
+	} {
+		index, err := position(c.column)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		*c.position = index
+	}
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+	return columns, nil
+}
+
+func (c csvColumns) transaction(record []string) (*budgit.ExternalTransaction, error) {
+	dateFormat := c.profile.DateFormat
+	if dateFormat == "" {
+		dateFormat = defaultCSVDateFormat
+	}
+	date, err := time.Parse(dateFormat, field(record, c.date))
+	if err != nil {
+		return nil, fmt.Errorf("parsing date: %w", err)
+	}
+
+	var amount budgit.BalanceAmount
+	if c.amount != -1 {
+		amount, err = parseAmount(field(record, c.amount), c.profile.DecimalComma)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		debit, err := parseOptionalAmount(field(record, c.debit), c.profile.DecimalComma)
+		if err != nil {
+			return nil, err
+		}
+		credit, err := parseOptionalAmount(field(record, c.credit), c.profile.DecimalComma)
+		if err != nil {
+			return nil, err
+		}
+		amount = abs(credit) - abs(debit)
+	}
+	if c.profile.NegateAmounts {
+		amount = -amount
+	}
+
+	return &budgit.ExternalTransaction{
+		EffectiveDate: date,
+		PayeeName:     field(record, c.payee),
+		Memo:          field(record, c.memo),
+		Amount:        amount,
+		Cleared:       true,
+	}, nil
+}
+
+// field returns the trimmed field at the given position of a record, or an empty string if there is none.
+func field(record []string, position int) string {
+	if position < 0 || position >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[position])
+}
+
+func parseOptionalAmount(str string, decimalComma bool) (budgit.BalanceAmount, error) {
+	if str == "" {
+		return 0, nil
+	}
+	return parseAmount(str, decimalComma)
+}
+
+func abs(amount budgit.BalanceAmount) budgit.BalanceAmount {
+	if amount < 0 {
+		return -amount
+	}
+	return amount
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// skipByteOrderMark skips the UTF-8 byte order mark some spreadsheet programs begin CSV files with.
+func skipByteOrderMark(r io.Reader) io.Reader {
+	buffered := bufio.NewReader(r)
+	if bom, err := buffered.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		buffered.Discard(3)
+	}
+	return buffered
+}
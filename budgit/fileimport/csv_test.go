@@ -0,0 +1,115 @@
+package fileimport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+func (s *fileImportSuite) TestParseCSV() {
+	testCases := []struct {
+		name                 string
+		profile              *budgit.CSVProfile
+		csv                  string
+		expectedTransactions []*budgit.ExternalTransaction
+	}{
+		{
+			name: "SignedAmountColumn",
+			profile: &budgit.CSVProfile{
+				HasHeader:    true,
+				DateColumn:   "Date",
+				DateFormat:   "02/01/2006",
+				PayeeColumn:  "Description",
+				MemoColumn:   "Reference",
+				AmountColumn: "Amount",
+			},
+			csv: "\xef\xbb\xbfDate,Description,Reference,Amount\n" +
+				"01/06/2024,Pret A Manger,coffee,-3.20\n" +
+				"\n" +
+				"02/06/2024,Employer,\"June, salary\",\"£2,000.00\"\n",
+			expectedTransactions: []*budgit.ExternalTransaction{
+				{EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "Pret A Manger", Memo: "coffee", Amount: -320, Cleared: true},
+				{EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), PayeeName: "Employer", Memo: "June, salary", Amount: 200000, Cleared: true},
+			},
+		},
+		{
+			name: "DebitAndCreditColumns",
+			profile: &budgit.CSVProfile{
+				Delimiter:    ';',
+				HasHeader:    true,
+				SkipRows:     1,
+				DateColumn:   "date",
+				PayeeColumn:  "payee",
+				DebitColumn:  "paid out",
+				CreditColumn: "paid in",
+				DecimalComma: true,
+			},
+			csv: "Account statement;;;\n" +
+				"Date;Payee;Paid out;Paid in\n" +
+				"2024-06-01;Bakery;1.234,50;\n" +
+				"2024-06-02;Refund;;(12,00)\n",
+			expectedTransactions: []*budgit.ExternalTransaction{
+				{EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "Bakery", Amount: -123450, Cleared: true},
+				{EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), PayeeName: "Refund", Amount: 1200, Cleared: true},
+			},
+		},
+		{
+			name: "NegatedAmountsWithoutHeader",
+			profile: &budgit.CSVProfile{
+				DateColumn:    "1",
+				PayeeColumn:   "3",
+				AmountColumn:  "2",
+				NegateAmounts: true,
+			},
+			csv: "2024-06-01,25.00,Card purchase\n" +
+				"2024-06-03,-100.00,Payment received\n",
+			expectedTransactions: []*budgit.ExternalTransaction{
+				{EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "Card purchase", Amount: -2500, Cleared: true},
+				{EffectiveDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), PayeeName: "Payment received", Amount: 10000, Cleared: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			transactions, err := fileimport.ParseCSV(strings.NewReader(tc.csv), tc.profile)
+			s.Require().NoError(err)
+			s.CMPEqual(tc.expectedTransactions, transactions, ignoreIDs)
+		})
+	}
+}
+
+func (s *fileImportSuite) TestParseCSVImportIDs() {
+	profile := &budgit.CSVProfile{DateColumn: "1", PayeeColumn: "2", AmountColumn: "3"}
+	statement := "2024-06-01,Pret,-3.20\n2024-06-01,Pret,-3.20\n2024-06-02,Pret,-3.20\n"
+
+	transactions, err := fileimport.ParseCSV(strings.NewReader(statement), profile)
+	s.Require().NoError(err)
+	s.Require().Len(transactions, 3)
+	s.NotEqual(transactions[0].ID, transactions[1].ID, "identical rows are given distinct IDs")
+	s.NotEqual(transactions[0].ID, transactions[2].ID)
+
+	overlapping, err := fileimport.ParseCSV(strings.NewReader("2024-05-31,Tesco,-10.00\n"+statement), profile)
+	s.Require().NoError(err)
+	s.Equal(transactions[0].ID, overlapping[1].ID, "IDs are stable across overlapping statements")
+	s.Equal(transactions[1].ID, overlapping[2].ID)
+}
+
+func (s *fileImportSuite) TestParseCSVErrors() {
+	s.Run("InvalidProfile", func() {
+		_, err := fileimport.ParseCSV(strings.NewReader(""), &budgit.CSVProfile{})
+		s.ErrorIs(err, fileimport.ErrCSVDateColumnMissing)
+		s.ErrorIs(err, fileimport.ErrCSVAmountColumnMissing)
+	})
+	s.Run("ColumnNotFound", func() {
+		_, err := fileimport.ParseCSV(strings.NewReader("Date,Amount\n"), &budgit.CSVProfile{HasHeader: true, DateColumn: "Date", AmountColumn: "Value"})
+		s.ErrorIs(err, fileimport.CSVColumnNotFoundError{Column: "Value"})
+	})
+	s.Run("InvalidRow", func() {
+		_, err := fileimport.ParseCSV(strings.NewReader("2024-06-01,1.00\n2024-06-02,one\n"), &budgit.CSVProfile{DateColumn: "1", AmountColumn: "2"})
+		s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+		s.ErrorAs(err, &fileimport.RowError{})
+		s.ErrorContains(err, "line 2")
+	})
+}
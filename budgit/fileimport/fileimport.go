@@ -0,0 +1,96 @@
+// Package fileimport parses the transactions of statements downloaded from banks, for accounts without an integration,
+// and reads and writes the QIF files of other finance programs.
+package fileimport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// RowError is returned when a row of a statement cannot be parsed.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// importIDs generates the IDs of parsed transactions which have no ID of their own in a statement, derived from their
+// fields so that the same transaction is given the same ID when it appears in overlapping statements.
+// Identical transactions within a statement are numbered in order, so that each is still imported.
+type importIDs struct {
+	prefix string
+	seen   map[string]int
+}
+
+// AssignImportIDs sets the IDs of transactions parsed from a statement which does not identify them, see importIDs.
+func AssignImportIDs(prefix string, transactions []*budgit.ExternalTransaction) []*budgit.ExternalTransaction {
+	ids := newImportIDs(prefix)
+	for _, transaction := range transactions {
+		transaction.ID = ids.next(transaction)
+	}
+	return transactions
+}
+
+func newImportIDs(prefix string) *importIDs {
+	return &importIDs{prefix: prefix, seen: map[string]int{}}
+}
+
+func (ids *importIDs) next(transaction *budgit.ExternalTransaction) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{
+		transaction.EffectiveDate.Format("2006-01-02"),
+		strconv.FormatInt(int64(transaction.Amount), 10),
+		transaction.PayeeName,
+		transaction.Memo,
+	}, "\x00")))
+	key := hex.EncodeToString(hash[:8])
+	ids.seen[key]++
+	return fmt.Sprintf("%s-%s-%d", ids.prefix, key, ids.seen[key])
+}
+
+// parseAmount parses an amount as formatted in statements, which may include currency symbols, thousands separators and
+// negative amounts in parentheses, e.g. "(£1,234.50)".
+func parseAmount(str string, decimalComma bool) (budgit.BalanceAmount, error) {
+	amount := strings.Map(func(r rune) rune {
+		switch r {
+		case '£', '$', '€', ' ', '\u00a0':
+			return -1
+		}
+		return r
+	}, str)
+
+	negative := false
+	if strings.HasPrefix(amount, "(") && strings.HasSuffix(amount, ")") {
+		negative, amount = true, amount[1:len(amount)-1]
+	}
+	if strings.HasSuffix(amount, "-") {
+		negative, amount = true, amount[:len(amount)-1]
+	}
+
+	if decimalComma {
+		amount = strings.ReplaceAll(amount, ".", "")
+		amount = strings.ReplaceAll(amount, ",", ".")
+	} else {
+		amount = strings.ReplaceAll(amount, ",", "")
+	}
+
+	parsed, err := budgit.ParseBalanceAmount(amount)
+	if err != nil {
+		return 0, fmt.Errorf("parsing amount %q: %w", str, budgit.ErrInvalidBalanceAmount)
+	}
+	if negative {
+		parsed = -parsed
+	}
+	return parsed, nil
+}
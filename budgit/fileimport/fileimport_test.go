@@ -0,0 +1,27 @@
+package fileimport_test
+
+import (
+	"testing"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFileImport(t *testing.T) {
+	suite.Run(t, new(fileImportSuite))
+}
+
+type fileImportSuite struct {
+	suite.Suite
+}
+
+func (s *fileImportSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
+
+// ignoreIDs ignores the generated IDs of parsed transactions, which are asserted separately.
+var ignoreIDs = cmpopts.IgnoreFields(budgit.ExternalTransaction{}, "ID")
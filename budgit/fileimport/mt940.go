@@ -0,0 +1,282 @@
+package fileimport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+var ErrMT940Invalid = fmt.Errorf("the file is not an MT940 statement")
+
+var (
+	// mt940Tag matches the tag starting a field, e.g. ":61:" or ":60F:".
+	mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+	// mt940StatementLine matches the first line of a :61: field: the value date, optional entry date, debit or credit
+	// mark, optional funds code, amount and transaction type, followed by the references.
+	mt940StatementLine = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)[A-Z]?(\d+,\d*)[NFS][A-Z0-9]{3}(.*)$`)
+	// mt940Balance matches a balance field: the debit or credit mark, date, currency and amount.
+	mt940Balance = regexp.MustCompile(`^([CD])(\d{6})([A-Z]{3})(\d+,\d*)`)
+	// mt940GVCSubfield and mt940SWIFTCode match the subfields of structured :86: information, e.g. "?20" or "/NAME/".
+	mt940GVCSubfield = regexp.MustCompile(`\?(\d{2})`)
+	mt940SWIFTCode   = regexp.MustCompile(`/([A-Z]{2,4})/`)
+)
+
+// mt940Field is a tagged field of an MT940 message, with its continuation lines.
+type mt940Field struct {
+	line  int
+	tag   string
+	value string
+}
+
+// ParseMT940 parses the statements of an MT940 file, one for each message. Transactions are identified by the bank's
+// reference, and take the name of their counterparty as their payee when the :86: information gives one in either
+// the GVC "?32" or the SWIFT "/NAME/" structure. Otherwise all of the information is used as the memo.
+//
+// An UnbalancedStatementError is returned if the transactions of a statement do not account for its opening and
+// closing balances.
+func ParseMT940(r io.Reader) ([]*BankStatement, error) {
+	messages, err := readMT940Messages(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing MT940: %w", err)
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("parsing MT940: %w", ErrMT940Invalid)
+	}
+
+	statements := make([]*BankStatement, 0, len(messages))
+	ids := newImportIDs("mt940")
+	for _, fields := range messages {
+		statement, err := toMT940Statement(fields, ids)
+		if err != nil {
+			return nil, fmt.Errorf("parsing MT940: %w", err)
+		}
+		if err := statement.checkBalances(); err != nil {
+			return nil, fmt.Errorf("parsing MT940: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+// readMT940Messages reads the fields of each message of an MT940 file. Messages are ended by a line of "-", and may be
+// wrapped in the "{1:...}{2:...}{4:" blocks of a SWIFT envelope.
+func readMT940Messages(r io.Reader) ([][]*mt940Field, error) {
+	messages := [][]*mt940Field{}
+	fields := []*mt940Field{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if _, body, ok := strings.Cut(text, "{4:"); ok {
+			text = body
+		}
+
+		switch trimmed := strings.TrimSpace(text); {
+		case trimmed == "-" || trimmed == "-}":
+			if len(fields) != 0 {
+				messages = append(messages, fields)
+			}
+			fields = []*mt940Field{}
+		case mt940Tag.MatchString(text):
+			tag := mt940Tag.FindStringSubmatch(text)
+			fields = append(fields, &mt940Field{line: line, tag: tag[1], value: text[len(tag[0]):]})
+		case len(fields) != 0:
+			fields[len(fields)-1].value += "\n" + text
+		case trimmed != "" && !strings.HasPrefix(trimmed, "{"):
+			return nil, RowError{Line: line, Err: ErrMT940Invalid}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	// The final message of a file need not be ended.
+	if len(fields) != 0 {
+		messages = append(messages, fields)
+	}
+	return messages, nil
+}
+
+func toMT940Statement(fields []*mt940Field, ids *importIDs) (*BankStatement, error) {
+	statement := &BankStatement{Transactions: []*budgit.ExternalTransaction{}}
+	var lastTransaction *budgit.ExternalTransaction
+	for _, field := range fields {
+		switch field.tag {
+		case "25":
+			statement.AccountID = strings.TrimSpace(field.value)
+		case "60F", "60M":
+			amount, date, currency, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, RowError{Line: field.line, Err: err}
+			}
+			statement.OpeningBalance, statement.OpeningBalanceDate, statement.HasOpeningBalance = amount, date, true
+			statement.Currency = currency
+		case "62F", "62M":
+			amount, date, _, err := parseMT940Balance(field.value)
+			if err != nil {
+				return nil, RowError{Line: field.line, Err: err}
+			}
+			statement.ClosingBalance, statement.ClosingBalanceDate, statement.HasClosingBalance = amount, date, true
+		case "61":
+			transaction, err := parseMT940StatementLine(field.value)
+			if err != nil {
+				return nil, RowError{Line: field.line, Err: err}
+			}
+			statement.Transactions = append(statement.Transactions, transaction)
+			lastTransaction = transaction
+		case "86":
+			// Information following a statement line describes its transaction, otherwise the statement as a whole.
+			if lastTransaction != nil {
+				lastTransaction.PayeeName, lastTransaction.Memo = parseMT940Information(field.value)
+				lastTransaction = nil
+			}
+		}
+	}
+
+	for _, transaction := range statement.Transactions {
+		if transaction.ID == "" {
+			transaction.ID = ids.next(transaction)
+		}
+	}
+	return statement, nil
+}
+
+// parseMT940StatementLine parses a :61: field, e.g. "2406030603DR12,50NTRFNONREF//B4C03XYZ\nCard payment".
+// The bank's reference follows "//", and is used as the transaction's ID. The account owner's reference precedes it.
+func parseMT940StatementLine(value string) (*budgit.ExternalTransaction, error) {
+	firstLine, _, _ := strings.Cut(value, "\n")
+	match := mt940StatementLine.FindStringSubmatch(strings.TrimSpace(firstLine))
+	if match == nil {
+		return nil, fmt.Errorf("%w: malformed statement line %q", ErrMT940Invalid, firstLine)
+	}
+
+	valueDate, err := parseMT940Date(match[1])
+	if err != nil {
+		return nil, err
+	}
+	date := valueDate
+	if match[2] != "" {
+		if date, err = parseMT940EntryDate(match[2], valueDate); err != nil {
+			return nil, err
+		}
+	}
+	amount, err := parseAmount(match[4], true)
+	if err != nil {
+		return nil, err
+	}
+	if match[3] == "D" || match[3] == "RC" {
+		amount = -amount
+	}
+
+	ownerReference, bankReference, _ := strings.Cut(match[5], "//")
+	ownerReference, bankReference = strings.TrimSpace(ownerReference), strings.TrimSpace(bankReference)
+	if ownerReference == "NONREF" {
+		ownerReference = ""
+	}
+	return &budgit.ExternalTransaction{
+		ID:            bankReference,
+		EffectiveDate: date,
+		Reference:     ownerReference,
+		Amount:        amount,
+		Cleared:       true,
+	}, nil
+}
+
+// parseMT940Balance parses a balance field, e.g. "C240601GBP1234,56".
+func parseMT940Balance(value string) (budgit.BalanceAmount, time.Time, string, error) {
+	match := mt940Balance.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, time.Time{}, "", fmt.Errorf("%w: malformed balance %q", ErrMT940Invalid, value)
+	}
+	date, err := parseMT940Date(match[2])
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+	amount, err := parseAmount(match[4], true)
+	if err != nil {
+		return 0, time.Time{}, "", err
+	}
+	if match[1] == "D" {
+		amount = -amount
+	}
+	return amount, date, match[3], nil
+}
+
+// parseMT940Date parses a YYMMDD date.
+func parseMT940Date(str string) (time.Time, error) {
+	date, err := time.Parse("060102", str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing date %q: %w", str, err)
+	}
+	return date, nil
+}
+
+// parseMT940EntryDate parses the MMDD entry date of a statement line, taking its year from the value date. Entries
+// booked either side of a new year from their value date are given the adjacent year.
+func parseMT940EntryDate(str string, valueDate time.Time) (time.Time, error) {
+	date, err := time.Parse("0102", str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing entry date %q: %w", str, err)
+	}
+	year := valueDate.Year()
+	switch {
+	case date.Month() == time.January && valueDate.Month() == time.December:
+		year++
+	case date.Month() == time.December && valueDate.Month() == time.January:
+		year--
+	}
+	return time.Date(year, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// parseMT940Information returns the counterparty name and memo of the :86: information of a transaction.
+//
+// In the GVC structure, e.g. "166?00SEPA-UEBERWEISUNG?20Invoice 1?32ACME LTD", the memo is given by subfields ?20 to
+// ?29 and the name by ?32 and ?33. In the SWIFT structure, e.g. "/NAME/ACME LTD/REMI/Invoice 1/", they are given by
+// NAME and REMI. Unstructured information is used as the memo.
+func parseMT940Information(value string) (string, string) {
+	information := strings.ReplaceAll(value, "\n", "")
+
+	if subfields := mt940GVCSubfield.FindAllStringSubmatchIndex(information, -1); len(subfields) != 0 {
+		var name, memo strings.Builder
+		for i, subfield := range subfields {
+			end := len(information)
+			if i+1 < len(subfields) {
+				end = subfields[i+1][0]
+			}
+			code, text := information[subfield[2]:subfield[3]], information[subfield[1]:end]
+			switch {
+			case code >= "20" && code <= "29":
+				memo.WriteString(text)
+			case code == "32" || code == "33":
+				name.WriteString(text)
+			}
+		}
+		return strings.TrimSpace(name.String()), strings.TrimSpace(memo.String())
+	}
+
+	if codes := mt940SWIFTCode.FindAllStringSubmatchIndex(information, -1); len(codes) != 0 && codes[0][0] == 0 {
+		name, memo := "", ""
+		for i := range codes {
+			// A code's value runs until the next code, and the final value may end with a "/".
+			end := len(information)
+			if i+1 < len(codes) {
+				end = codes[i+1][0]
+			}
+			text := strings.TrimSuffix(information[codes[i][1]:end], "/")
+			switch information[codes[i][2]:codes[i][3]] {
+			case "NAME":
+				name = text
+			case "REMI":
+				memo = text
+			}
+		}
+		return strings.TrimSpace(name), strings.TrimSpace(memo)
+	}
+	return "", strings.TrimSpace(value)
+}
@@ -0,0 +1,94 @@
+package fileimport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+const mt940 = `{1:F01BANKGB2LAXXX0000000000}{2:I940BANKGB2LXXXXN}{4:
+:20:STMT240603
+:25:GB33BUKB20201555555555
+:28C:00123/001
+:60F:C240531GBP1000,00
+:61:2406010601C250,00NTRFINV42//B4F01
+:86:/TRTP/SEPA CREDIT/NAME/ACME LTD/REMI/Invoice 42/
+:61:2406020602D49,5NCHGNONREF//B4F02
+:86:Bank charges
+ for May
+:61:2406030603D20,00NMSCNONREF
+:86:166?00SEPA-UEBERWEISUNG?20Paper and?21 pens?32STATION?33ERS
+:62F:C240603GBP1180,50
+-}
+:20:STMT240604
+:25:GB33BUKB20201555555555
+:60F:C240603GBP1180,50
+:61:2501021231RD10,00NTRFNONREF//B4F04
+:62F:C240604GBP1190,50
+-
+`
+
+func (s *fileImportSuite) TestParseMT940() {
+	statements, err := fileimport.ParseMT940(strings.NewReader(mt940))
+	s.Require().NoError(err)
+	s.Require().Len(statements, 2)
+	s.Require().Len(statements[0].Transactions, 3)
+	// Transactions without a bank reference are given one derived from their fields.
+	s.True(strings.HasPrefix(statements[0].Transactions[2].ID, "mt940-"))
+	statements[0].Transactions[2].ID = ""
+
+	expected := []*fileimport.BankStatement{
+		{
+			AccountID: "GB33BUKB20201555555555",
+			Currency:  "GBP",
+			Transactions: []*budgit.ExternalTransaction{
+				{ID: "B4F01", EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "ACME LTD", Reference: "INV42", Memo: "Invoice 42", Amount: 25000, Cleared: true},
+				{ID: "B4F02", EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Memo: "Bank charges\n for May", Amount: -4950, Cleared: true},
+				{EffectiveDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), PayeeName: "STATIONERS", Memo: "Paper and pens", Amount: -2000, Cleared: true},
+			},
+			OpeningBalance:     100000,
+			OpeningBalanceDate: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+			HasOpeningBalance:  true,
+			ClosingBalance:     118050,
+			ClosingBalanceDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			HasClosingBalance:  true,
+		},
+		{
+			AccountID: "GB33BUKB20201555555555",
+			Currency:  "GBP",
+			Transactions: []*budgit.ExternalTransaction{
+				// A reversed debit, booked in the year before its value date.
+				{ID: "B4F04", EffectiveDate: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), Amount: 1000, Cleared: true},
+			},
+			OpeningBalance:     118050,
+			OpeningBalanceDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			HasOpeningBalance:  true,
+			ClosingBalance:     119050,
+			ClosingBalanceDate: time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC),
+			HasClosingBalance:  true,
+		},
+	}
+	s.CMPEqual(expected, statements)
+}
+
+func (s *fileImportSuite) TestParseMT940Errors() {
+	s.Run("NotMT940", func() {
+		_, err := fileimport.ParseMT940(strings.NewReader("Date,Amount\n"))
+		s.ErrorIs(err, fileimport.ErrMT940Invalid)
+	})
+	s.Run("Empty", func() {
+		_, err := fileimport.ParseMT940(strings.NewReader(""))
+		s.ErrorIs(err, fileimport.ErrMT940Invalid)
+	})
+	s.Run("MalformedStatementLine", func() {
+		_, err := fileimport.ParseMT940(strings.NewReader(":20:1\n:61:240601X1,00\n-\n"))
+		s.ErrorAs(err, &fileimport.RowError{})
+		s.ErrorIs(err, fileimport.ErrMT940Invalid)
+	})
+	s.Run("Unbalanced", func() {
+		_, err := fileimport.ParseMT940(strings.NewReader(strings.Replace(mt940, ":62F:C240603GBP1180,50", ":62F:C240603GBP1181,50", 1)))
+		s.ErrorAs(err, &fileimport.UnbalancedStatementError{})
+	})
+}
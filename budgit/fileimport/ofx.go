@@ -0,0 +1,225 @@
+package fileimport
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+var ErrOFXInvalid = fmt.Errorf("the file is not an OFX document")
+
+// OFXStatement is a bank or credit card statement parsed from an OFX document.
+type OFXStatement struct {
+	// AccountID is the ID of the statement's account at its bank.
+	AccountID    string
+	Currency     string
+	Transactions []*budgit.ExternalTransaction
+	// LedgerBalance is the balance of the account at LedgerBalanceDate. It is only set if HasLedgerBalance is.
+	LedgerBalance     budgit.BalanceAmount
+	LedgerBalanceDate time.Time
+	HasLedgerBalance  bool
+}
+
+// ParseOFX parses the bank and credit card statements of an OFX document, in either the SGML format of OFX 1.x, as
+// used by QFX files, or the XML format of OFX 2.x. Transactions are identified by their FITID.
+func ParseOFX(r io.Reader) ([]*OFXStatement, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing OFX: %w", err)
+	}
+	root, err := parseOFXElements(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parsing OFX: %w", err)
+	}
+
+	statements := []*OFXStatement{}
+	for _, statementResponse := range root.findAll("STMTRS", "CCSTMTRS") {
+		statement, err := toOFXStatement(statementResponse)
+		if err != nil {
+			return nil, fmt.Errorf("parsing OFX: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
+
+func toOFXStatement(statementResponse *ofxElement) (*OFXStatement, error) {
+	statement := &OFXStatement{
+		AccountID:    statementResponse.find("ACCTID").value,
+		Currency:     statementResponse.child("CURDEF").value,
+		Transactions: []*budgit.ExternalTransaction{},
+	}
+
+	for _, entry := range statementResponse.findAll("STMTTRN") {
+		transaction, err := toOFXTransaction(entry)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %q: %w", entry.child("FITID").value, err)
+		}
+		statement.Transactions = append(statement.Transactions, transaction)
+	}
+
+	if ledgerBalance := statementResponse.child("LEDGERBAL"); ledgerBalance != nil {
+		amount, err := parseOFXAmount(ledgerBalance.child("BALAMT").value)
+		if err != nil {
+			return nil, fmt.Errorf("ledger balance: %w", err)
+		}
+		date, err := parseOFXDate(ledgerBalance.child("DTASOF").value)
+		if err != nil {
+			return nil, fmt.Errorf("ledger balance: %w", err)
+		}
+		statement.LedgerBalance, statement.LedgerBalanceDate, statement.HasLedgerBalance = amount, date, true
+	}
+	return statement, nil
+}
+
+func toOFXTransaction(entry *ofxElement) (*budgit.ExternalTransaction, error) {
+	id := entry.child("FITID").value
+	if id == "" {
+		return nil, fmt.Errorf("missing FITID")
+	}
+	date, err := parseOFXDate(entry.child("DTPOSTED").value)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := parseOFXAmount(entry.child("TRNAMT").value)
+	if err != nil {
+		return nil, err
+	}
+
+	payeeName := entry.child("NAME").value
+	if payee := entry.child("PAYEE"); payeeName == "" && payee != nil {
+		payeeName = payee.child("NAME").value
+	}
+	reference := entry.child("CHECKNUM").value
+	if reference == "" {
+		reference = entry.child("REFNUM").value
+	}
+	return &budgit.ExternalTransaction{
+		ID:            id,
+		EffectiveDate: date,
+		PayeeName:     payeeName,
+		Reference:     reference,
+		Memo:          entry.child("MEMO").value,
+		Amount:        amount,
+		Cleared:       true,
+	}, nil
+}
+
+// parseOFXDate parses the date of an OFX datetime, e.g. "20240601" or "20240601120000.000[+1:BST]", ignoring its time.
+func parseOFXDate(str string) (time.Time, error) {
+	if len(str) < 8 {
+		return time.Time{}, fmt.Errorf("parsing date %q: too short", str)
+	}
+	date, err := time.Parse("20060102", str[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing date %q: %w", str, err)
+	}
+	return date, nil
+}
+
+// parseOFXAmount parses an OFX amount, which uses either a full stop or a comma as its decimal separator.
+func parseOFXAmount(str string) (budgit.BalanceAmount, error) {
+	return parseAmount(str, strings.Contains(str, ",") && !strings.Contains(str, "."))
+}
+
+// ofxElement is an element of an OFX document. Elements either have a value or children.
+type ofxElement struct {
+	name     string
+	value    string
+	children []*ofxElement
+}
+
+// child returns the first direct child element with the given name, or an empty element if there is none.
+func (e *ofxElement) child(name string) *ofxElement {
+	for _, child := range e.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return &ofxElement{}
+}
+
+// find returns the first descendant element with the given name, or an empty element if there is none.
+func (e *ofxElement) find(name string) *ofxElement {
+	if found := e.findAll(name); len(found) != 0 {
+		return found[0]
+	}
+	return &ofxElement{}
+}
+
+// findAll returns the descendant elements with any of the given names, in document order, without descending into them.
+func (e *ofxElement) findAll(names ...string) []*ofxElement {
+	found := []*ofxElement{}
+	for _, child := range e.children {
+		if slices.Contains(names, child.name) {
+			found = append(found, child)
+		} else {
+			found = append(found, child.findAll(names...)...)
+		}
+	}
+	return found
+}
+
+// parseOFXElements parses the elements of an OFX document from its <OFX> element onwards, skipping its headers.
+//
+// OFX 1.x documents are SGML, in which elements with values need not be closed, e.g. "<TRNAMT>-3.20<FITID>1".
+// An element with a value is therefore closed by the next tag, unless that tag closes it explicitly, as in OFX 2.x.
+func parseOFXElements(document string) (*ofxElement, error) {
+	start := strings.Index(document, "<OFX>")
+	if start == -1 {
+		return nil, ErrOFXInvalid
+	}
+
+	root := &ofxElement{}
+	stack := []*ofxElement{root}
+	closeValued := func() {
+		if top := stack[len(stack)-1]; top.value != "" && len(stack) > 1 {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	rest := document[start:]
+	for {
+		open := strings.IndexByte(rest, '<')
+		if open == -1 {
+			break
+		}
+		if text := strings.TrimSpace(rest[:open]); text != "" {
+			stack[len(stack)-1].value = html.UnescapeString(text)
+		}
+		end := strings.IndexByte(rest[open:], '>')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unterminated tag", ErrOFXInvalid)
+		}
+		tag := strings.TrimSpace(rest[open+1 : open+end])
+		rest = rest[open+end+1:]
+
+		switch {
+		case tag == "" || strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!") || strings.HasSuffix(tag, "/"):
+			continue
+		case strings.HasPrefix(tag, "/"):
+			name := strings.ToUpper(strings.TrimSpace(tag[1:]))
+			if stack[len(stack)-1].name != name {
+				closeValued()
+			}
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		default:
+			closeValued()
+			element := &ofxElement{name: strings.ToUpper(strings.Fields(tag)[0])}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, element)
+			stack = append(stack, element)
+		}
+	}
+	return root, nil
+}
@@ -0,0 +1,148 @@
+package fileimport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:USASCII
+CHARSET:1252
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240603120000<LANGUAGE>ENG</SONRS></SIGNONMSGSRSV1>
+<CREDITCARDMSGSRSV1>
+<CCSTMTTRNRS>
+<TRNUID>1
+<CCSTMTRS>
+<CURDEF>GBP
+<CCACCTFROM><ACCTID>4000123412341234</CCACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20240601<DTEND>20240603
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20240601120000.000[+1:BST]
+<TRNAMT>-3.20
+<FITID>2024060101
+<NAME>PRET A MANGER
+<MEMO>Coffee &amp; croissant
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20240602
+<TRNAMT>100,00
+<FITID>2024060201
+<PAYEE><NAME>Card payment</PAYEE>
+<REFNUM>REF-1
+</STMTTRN>
+</BANKTRANLIST>
+<LEDGERBAL><BALAMT>-250.75<DTASOF>20240603</LEDGERBAL>
+</CCSTMTRS>
+</CCSTMTTRNRS>
+</CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <TRNUID>1</TRNUID>
+      <STMTRS>
+        <CURDEF>GBP</CURDEF>
+        <BANKACCTFROM>
+          <BANKID>608371</BANKID>
+          <ACCTID>12345678</ACCTID>
+          <ACCTTYPE>CHECKING</ACCTTYPE>
+        </BANKACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>20240601</DTSTART>
+          <DTEND>20240603</DTEND>
+          <STMTTRN>
+            <TRNTYPE>CHECK</TRNTYPE>
+            <DTPOSTED>20240601</DTPOSTED>
+            <TRNAMT>-45.00</TRNAMT>
+            <FITID>A1</FITID>
+            <CHECKNUM>1001</CHECKNUM>
+            <NAME>Window cleaner</NAME>
+          </STMTTRN>
+        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>1000.00</BALAMT>
+          <DTASOF>20240603000000</DTASOF>
+        </LEDGERBAL>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+</OFX>
+`
+
+func (s *fileImportSuite) TestParseOFX() {
+	testCases := []struct {
+		name               string
+		ofx                string
+		expectedStatements []*fileimport.OFXStatement
+	}{
+		{
+			name: "SGML",
+			ofx:  sgmlOFX,
+			expectedStatements: []*fileimport.OFXStatement{
+				{
+					AccountID: "4000123412341234",
+					Currency:  "GBP",
+					Transactions: []*budgit.ExternalTransaction{
+						{ID: "2024060101", EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "PRET A MANGER", Memo: "Coffee & croissant", Amount: -320, Cleared: true},
+						{ID: "2024060201", EffectiveDate: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), PayeeName: "Card payment", Reference: "REF-1", Amount: 10000, Cleared: true},
+					},
+					LedgerBalance:     -25075,
+					LedgerBalanceDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+					HasLedgerBalance:  true,
+				},
+			},
+		},
+		{
+			name: "XML",
+			ofx:  xmlOFX,
+			expectedStatements: []*fileimport.OFXStatement{
+				{
+					AccountID: "12345678",
+					Currency:  "GBP",
+					Transactions: []*budgit.ExternalTransaction{
+						{ID: "A1", EffectiveDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), PayeeName: "Window cleaner", Reference: "1001", Amount: -4500, Cleared: true},
+					},
+					LedgerBalance:     100000,
+					LedgerBalanceDate: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+					HasLedgerBalance:  true,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			statements, err := fileimport.ParseOFX(strings.NewReader(tc.ofx))
+			s.Require().NoError(err)
+			s.CMPEqual(tc.expectedStatements, statements)
+		})
+	}
+}
+
+func (s *fileImportSuite) TestParseOFXErrors() {
+	s.Run("NotOFX", func() {
+		_, err := fileimport.ParseOFX(strings.NewReader("Date,Amount\n"))
+		s.ErrorIs(err, fileimport.ErrOFXInvalid)
+	})
+	s.Run("InvalidAmount", func() {
+		_, err := fileimport.ParseOFX(strings.NewReader("<OFX><STMTRS><STMTTRN><DTPOSTED>20240601<TRNAMT>lots<FITID>1</STMTTRN></STMTRS></OFX>"))
+		s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+	})
+}
@@ -0,0 +1,352 @@
+package fileimport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// QIFFile is the content of a QIF file: the categories and account registers exported from a finance program.
+type QIFFile struct {
+	Categories []*QIFCategory
+	Accounts   []*QIFAccount
+}
+
+type QIFCategory struct {
+	// Path is the category's path, e.g. "Bills:Energy".
+	Path        string
+	Description string
+	Income      bool
+}
+
+type QIFAccount struct {
+	// Name is empty for files holding a single register without an !Account header.
+	Name string
+	// Type is the QIF type of the register, e.g. "Bank" or "CCard".
+	Type         string
+	Transactions []*QIFTransaction
+}
+
+type QIFTransaction struct {
+	Date   time.Time
+	Amount budgit.BalanceAmount
+	Payee  string
+	Memo   string
+	Number string
+	// Category is the category path of the transaction. It is empty for transfers and split transactions.
+	Category string
+	// TransferAccount is the name of the account money was transferred to or from, written "[Name]" in QIF files.
+	TransferAccount string
+	Cleared         bool
+	Splits          []*QIFSplit
+}
+
+type QIFSplit struct {
+	Category        string
+	TransferAccount string
+	Memo            string
+	Amount          budgit.BalanceAmount
+}
+
+// QIFOptions configures how QIF files are read and written.
+type QIFOptions struct {
+	// DayFirst is whether dates are written day first, e.g. "31/12/2024", as by UK editions of Quicken, rather than
+	// month first.
+	DayFirst bool
+}
+
+// qifTransactionTypes are the types of the registers of transactions read from QIF files. Investment registers,
+// memorised transactions and classes are skipped.
+var qifTransactionTypes = map[string]bool{"Bank": true, "Cash": true, "CCard": true, "Oth A": true, "Oth L": true}
+
+// ParseQIF parses the categories and account registers of a QIF file.
+func ParseQIF(r io.Reader, options QIFOptions) (*QIFFile, error) {
+	file := &QIFFile{Categories: []*QIFCategory{}, Accounts: []*QIFAccount{}}
+	accountsByName := map[string]*QIFAccount{}
+
+	var (
+		section     string
+		account     *QIFAccount
+		record      = map[byte][]string{}
+		splits      []*QIFSplit
+		recordStart int
+	)
+	flush := func() error {
+		defer func() {
+			record, splits = map[byte][]string{}, nil
+		}()
+		if len(record) == 0 && len(splits) == 0 {
+			return nil
+		}
+		switch {
+		case section == "Account":
+			name := first(record['N'])
+			existing, ok := accountsByName[name]
+			if !ok {
+				existing = &QIFAccount{Name: name, Transactions: []*QIFTransaction{}}
+				accountsByName[name] = existing
+				file.Accounts = append(file.Accounts, existing)
+			}
+			if accountType := first(record['T']); accountType != "" {
+				existing.Type = accountType
+			}
+			account = existing
+		case section == "Cat":
+			_, income := record['I']
+			file.Categories = append(file.Categories, &QIFCategory{
+				Path:        first(record['N']),
+				Description: first(record['D']),
+				Income:      income,
+			})
+		case qifTransactionTypes[section]:
+			transaction, err := toQIFTransaction(record, splits, options)
+			if err != nil {
+				return RowError{Line: recordStart, Err: err}
+			}
+			if account == nil {
+				account = &QIFAccount{Transactions: []*QIFTransaction{}}
+				accountsByName[""] = account
+				file.Accounts = append(file.Accounts, account)
+			}
+			if account.Type == "" {
+				account.Type = section
+			}
+			account.Transactions = append(account.Transactions, transaction)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(skipByteOrderMark(r))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if len(record) == 0 && len(splits) == 0 {
+			recordStart = line
+		}
+
+		code, value := text[0], strings.TrimSpace(text[1:])
+		isSplitField := (code == 'S' || code == 'E' || code == '$') && qifTransactionTypes[section]
+		switch {
+		case code == '!':
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("parsing QIF: %w", err)
+			}
+			header, _ := strings.CutPrefix(value, "Type:")
+			if header == "Option:AutoSwitch" || header == "Clear:AutoSwitch" {
+				continue
+			}
+			section = header
+		case code == '^':
+			if err := flush(); err != nil {
+				return nil, fmt.Errorf("parsing QIF: %w", err)
+			}
+		case isSplitField && code == 'S':
+			splits = append(splits, &QIFSplit{})
+			splits[len(splits)-1].Category, splits[len(splits)-1].TransferAccount = parseQIFCategory(value)
+		case isSplitField:
+			if len(splits) == 0 {
+				return nil, fmt.Errorf("parsing QIF: %w", RowError{Line: line, Err: fmt.Errorf("split field %q before split category", text)})
+			}
+			split := splits[len(splits)-1]
+			if code == 'E' {
+				split.Memo = value
+				continue
+			}
+			amount, err := parseAmount(value, false)
+			if err != nil {
+				return nil, fmt.Errorf("parsing QIF: %w", RowError{Line: line, Err: err})
+			}
+			split.Amount = amount
+		default:
+			record[code] = append(record[code], value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing QIF: %w", err)
+	}
+	if err := flush(); err != nil {
+		return nil, fmt.Errorf("parsing QIF: %w", err)
+	}
+	return file, nil
+}
+
+func toQIFTransaction(record map[byte][]string, splits []*QIFSplit, options QIFOptions) (*QIFTransaction, error) {
+	date, err := parseQIFDate(first(record['D']), options)
+	if err != nil {
+		return nil, err
+	}
+	amountField := first(record['T'])
+	if amountField == "" {
+		amountField = first(record['U'])
+	}
+	amount, err := parseAmount(amountField, false)
+	if err != nil {
+		return nil, err
+	}
+
+	category, transferAccount := parseQIFCategory(first(record['L']))
+	cleared := strings.ContainsAny(first(record['C']), "*cXR")
+	return &QIFTransaction{
+		Date:            date,
+		Amount:          amount,
+		Payee:           first(record['P']),
+		Memo:            first(record['M']),
+		Number:          first(record['N']),
+		Category:        category,
+		TransferAccount: transferAccount,
+		Cleared:         cleared,
+		Splits:          splits,
+	}, nil
+}
+
+// parseQIFCategory parses the category field of a QIF transaction, which is either a category path or the name of an
+// account in brackets for transfers, optionally followed by a class after a slash, e.g. "Bills:Energy/Home".
+func parseQIFCategory(field string) (category, transferAccount string) {
+	field, _, _ = strings.Cut(field, "/")
+	if strings.HasPrefix(field, "[") && strings.HasSuffix(field, "]") {
+		return "", field[1 : len(field)-1]
+	}
+	return field, ""
+}
+
+// parseQIFDate parses the dates of QIF files, e.g. "6/1/24", "06/01'2024" or "2024-06-01".
+// Two digit years are in the 1900s from 70 onwards, and otherwise in the 2000s.
+func parseQIFDate(field string, options QIFOptions) (time.Time, error) {
+	if date, err := time.Parse(time.DateOnly, field); err == nil {
+		return date, nil
+	}
+
+	parts := strings.FieldsFunc(field, func(r rune) bool {
+		return r == '/' || r == '\'' || r == '-' || r == '.'
+	})
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("parsing date %q: expected day, month and year", field)
+	}
+	numbers := make([]int, 0, 3)
+	for _, part := range parts {
+		number, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parsing date %q: %w", field, err)
+		}
+		numbers = append(numbers, number)
+	}
+
+	month, day, year := numbers[0], numbers[1], numbers[2]
+	if options.DayFirst {
+		month, day = day, month
+	}
+	switch {
+	case year < 70:
+		year += 2000
+	case year < 100:
+		year += 1900
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, fmt.Errorf("parsing date %q: day or month out of range", field)
+	}
+	return date, nil
+}
+
+// WriteQIF writes the categories and account registers of a QIF file.
+func WriteQIF(w io.Writer, file *QIFFile, options QIFOptions) error {
+	writer := &qifWriter{w: bufio.NewWriter(w), options: options}
+	if len(file.Categories) != 0 {
+		writer.line("!Type:Cat")
+		for _, category := range file.Categories {
+			writer.field('N', category.Path)
+			writer.field('D', category.Description)
+			if category.Income {
+				writer.line("I")
+			} else {
+				writer.line("E")
+			}
+			writer.line("^")
+		}
+	}
+	for _, account := range file.Accounts {
+		accountType := account.Type
+		if accountType == "" {
+			accountType = "Bank"
+		}
+		if account.Name != "" {
+			writer.line("!Account")
+			writer.field('N', account.Name)
+			writer.field('T', accountType)
+			writer.line("^")
+		}
+		writer.line("!Type:" + accountType)
+		for _, transaction := range account.Transactions {
+			writer.transaction(transaction)
+		}
+	}
+	if writer.err != nil {
+		return fmt.Errorf("writing QIF: %w", writer.err)
+	}
+	if err := writer.w.Flush(); err != nil {
+		return fmt.Errorf("writing QIF: %w", err)
+	}
+	return nil
+}
+
+type qifWriter struct {
+	w       *bufio.Writer
+	options QIFOptions
+	err     error
+}
+
+func (w *qifWriter) transaction(transaction *QIFTransaction) {
+	dateFormat := "01/02/2006"
+	if w.options.DayFirst {
+		dateFormat = "02/01/2006"
+	}
+	w.field('D', transaction.Date.Format(dateFormat))
+	w.field('T', transaction.Amount.String())
+	if transaction.Cleared {
+		w.field('C', "X")
+	}
+	w.field('N', transaction.Number)
+	w.field('P', transaction.Payee)
+	w.field('M', transaction.Memo)
+	w.field('L', qifCategory(transaction.Category, transaction.TransferAccount))
+	for _, split := range transaction.Splits {
+		w.line("S" + qifCategory(split.Category, split.TransferAccount))
+		w.field('E', split.Memo)
+		w.field('$', split.Amount.String())
+	}
+	w.line("^")
+}
+
+// field writes a field of a record, unless its value is empty.
+func (w *qifWriter) field(code byte, value string) {
+	if value != "" {
+		w.line(string(code) + value)
+	}
+}
+
+func (w *qifWriter) line(line string) {
+	if w.err == nil {
+		_, w.err = w.w.WriteString(line + "\n")
+	}
+}
+
+func qifCategory(category, transferAccount string) string {
+	if transferAccount != "" {
+		return "[" + transferAccount + "]"
+	}
+	return category
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,168 @@
+package fileimport_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+const testQIF = `!Type:Cat
+NBills:Energy
+DGas and electricity
+E
+^
+NSalary
+I
+^
+!Option:AutoSwitch
+!Account
+NCurrent
+TBank
+^
+NSavings
+TBank
+^
+!Clear:AutoSwitch
+!Account
+NCurrent
+TBank
+^
+!Type:Bank
+D6/01/2024
+T-1,250.00
+C*
+PLandlord
+MJune rent
+LBills:Rent/Home
+^
+D6/ 2'24
+T-80.00
+PEnergy Co
+SBills:Energy
+EGas
+$-30.00
+SBills:Energy
+EElectricity
+$-50.00
+^
+D6/3'24
+T-100.00
+L[Savings]
+^
+!Type:Invst
+D6/4'24
+NBuy
+$1,000.00
+^
+`
+
+func (s *fileImportSuite) TestParseQIF() {
+	file, err := fileimport.ParseQIF(strings.NewReader(testQIF), fileimport.QIFOptions{})
+	s.Require().NoError(err)
+	s.CMPEqual(&fileimport.QIFFile{
+		Categories: []*fileimport.QIFCategory{
+			{Path: "Bills:Energy", Description: "Gas and electricity"},
+			{Path: "Salary", Income: true},
+		},
+		Accounts: []*fileimport.QIFAccount{
+			{
+				Name: "Current",
+				Type: "Bank",
+				Transactions: []*fileimport.QIFTransaction{
+					{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Amount: -125000, Payee: "Landlord", Memo: "June rent", Category: "Bills:Rent", Cleared: true},
+					{Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Amount: -8000, Payee: "Energy Co", Splits: []*fileimport.QIFSplit{
+						{Category: "Bills:Energy", Memo: "Gas", Amount: -3000},
+						{Category: "Bills:Energy", Memo: "Electricity", Amount: -5000},
+					}},
+					{Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), Amount: -10000, TransferAccount: "Savings"},
+				},
+			},
+			{Name: "Savings", Type: "Bank", Transactions: []*fileimport.QIFTransaction{}},
+		},
+	}, file)
+}
+
+func (s *fileImportSuite) TestParseQIFWithoutAccount() {
+	file, err := fileimport.ParseQIF(strings.NewReader("!Type:CCard\nD01/06/2024\nT-3.20\nPPret\n^\n"), fileimport.QIFOptions{DayFirst: true})
+	s.Require().NoError(err)
+	s.CMPEqual(&fileimport.QIFFile{
+		Categories: []*fileimport.QIFCategory{},
+		Accounts: []*fileimport.QIFAccount{
+			{Type: "CCard", Transactions: []*fileimport.QIFTransaction{
+				{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Amount: -320, Payee: "Pret"},
+			}},
+		},
+	}, file)
+}
+
+func (s *fileImportSuite) TestWriteQIF() {
+	file := &fileimport.QIFFile{
+		Categories: []*fileimport.QIFCategory{{Path: "Bills:Energy"}, {Path: "Salary", Income: true}},
+		Accounts: []*fileimport.QIFAccount{
+			{
+				Name: "Current",
+				Type: "Bank",
+				Transactions: []*fileimport.QIFTransaction{
+					{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Amount: 200005, Payee: "Employer", Category: "Salary", Cleared: true},
+					{Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Amount: -8000, Payee: "Energy Co", Memo: "June", Splits: []*fileimport.QIFSplit{
+						{Category: "Bills:Energy", Memo: "Gas", Amount: -3000},
+						{TransferAccount: "Savings", Amount: -5000},
+					}},
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	s.Require().NoError(fileimport.WriteQIF(buf, file, fileimport.QIFOptions{DayFirst: true}))
+	s.Equal(`!Type:Cat
+NBills:Energy
+E
+^
+NSalary
+I
+^
+!Account
+NCurrent
+TBank
+^
+!Type:Bank
+D01/06/2024
+T2000.05
+CX
+PEmployer
+LSalary
+^
+D02/06/2024
+T-80.00
+PEnergy Co
+MJune
+SBills:Energy
+EGas
+$-30.00
+S[Savings]
+$-50.00
+^
+`, buf.String())
+
+	s.Run("RoundTrip", func() {
+		parsed, err := fileimport.ParseQIF(buf, fileimport.QIFOptions{DayFirst: true})
+		s.Require().NoError(err)
+		s.CMPEqual(file, parsed)
+	})
+}
+
+func (s *fileImportSuite) TestParseQIFErrors() {
+	s.Run("InvalidDate", func() {
+		_, err := fileimport.ParseQIF(strings.NewReader("!Type:Bank\nD13/45/2024\nT1.00\n^\n"), fileimport.QIFOptions{})
+		s.ErrorAs(err, &fileimport.RowError{})
+		s.ErrorContains(err, "line 2")
+	})
+	s.Run("InvalidAmount", func() {
+		_, err := fileimport.ParseQIF(strings.NewReader("!Type:Bank\nD1/2/2024\nTlots\n^\n"), fileimport.QIFOptions{})
+		s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+	})
+}
@@ -0,0 +1,65 @@
+package fileimport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// BankStatement is a statement of an account parsed from a bank's camt.053 or MT940 file.
+type BankStatement struct {
+	// AccountID identifies the statement's account at its bank, e.g. by its IBAN.
+	AccountID    string
+	Currency     string
+	Transactions []*budgit.ExternalTransaction
+	// OpeningBalance is the booked balance of the account at OpeningBalanceDate, before the statement's transactions.
+	// It is only set if HasOpeningBalance is.
+	OpeningBalance     budgit.BalanceAmount
+	OpeningBalanceDate time.Time
+	HasOpeningBalance  bool
+	// ClosingBalance is the booked balance of the account at ClosingBalanceDate, after the statement's transactions.
+	// It is only set if HasClosingBalance is.
+	ClosingBalance     budgit.BalanceAmount
+	ClosingBalanceDate time.Time
+	HasClosingBalance  bool
+}
+
+// UnbalancedStatementError is returned when the booked transactions of a statement do not account for the difference
+// between its opening and closing balances, as happens when a statement has been cut short.
+type UnbalancedStatementError struct {
+	AccountID         string
+	OpeningBalance    budgit.BalanceAmount
+	ClosingBalance    budgit.BalanceAmount
+	TransactionsTotal budgit.BalanceAmount
+}
+
+func (e UnbalancedStatementError) Error() string {
+	return fmt.Sprintf(
+		"statement of account %q has an opening balance of %s and a closing balance of %s, but its transactions total %s",
+		e.AccountID, e.OpeningBalance, e.ClosingBalance, e.TransactionsTotal,
+	)
+}
+
+// checkBalances returns an UnbalancedStatementError if a statement has opening and closing balances which its booked
+// transactions do not account for.
+func (s *BankStatement) checkBalances() error {
+	if !s.HasOpeningBalance || !s.HasClosingBalance {
+		return nil
+	}
+	var total budgit.BalanceAmount
+	for _, transaction := range s.Transactions {
+		if transaction.Cleared {
+			total += transaction.Amount
+		}
+	}
+	if s.OpeningBalance+total != s.ClosingBalance {
+		return UnbalancedStatementError{
+			AccountID:         s.AccountID,
+			OpeningBalance:    s.OpeningBalance,
+			ClosingBalance:    s.ClosingBalance,
+			TransactionsTotal: total,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,586 @@
+package fileimport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"golang.org/x/exp/maps"
+)
+
+var ErrYNABInvalid = fmt.Errorf("the file is not a YNAB export")
+
+// YNABBudget is a budget exported from YNAB, read by ParseYNABCSV or ParseYNABAPIExport.
+type YNABBudget struct {
+	Accounts []string
+	Payees   []string
+	// Categories are the paths of the budget's categories, see budgit.CategoryPath. YNAB's internal categories, such as
+	// "Inflow: Ready to Assign", are left out, and transactions in them are uncategorised.
+	Categories   []string
+	Assignments  []*YNABAssignment
+	Transactions []*YNABTransaction
+	// Unmapped describes the parts of the export which budgit has no equivalent of, so were imported only in part or
+	// not at all, e.g. "3 flagged transactions, whose flags were not imported".
+	Unmapped []string
+}
+
+// YNABAssignment is an amount assigned to a category for a month.
+type YNABAssignment struct {
+	// Month is the first day of the month.
+	Month    time.Time
+	Category string
+	Amount   budgit.BalanceAmount
+}
+
+type YNABTransaction struct {
+	// ID is YNAB's ID of the transaction, or one derived from its fields for register CSV exports, which do not have them.
+	ID      string
+	Account string
+	Date    time.Time
+	Payee   string
+	// Category is the category path of the transaction. It is empty for transfers and split transactions.
+	Category string
+	// TransferAccount is the name of the account money was transferred to or from.
+	TransferAccount string
+	Memo            string
+	Amount          budgit.BalanceAmount
+	Cleared         bool
+	Splits          []*YNABSplit
+}
+
+// YNABSplit is a part of a split transaction, which in YNAB may have its own payee.
+type YNABSplit struct {
+	Payee           string
+	Category        string
+	TransferAccount string
+	Memo            string
+	Amount          budgit.BalanceAmount
+}
+
+// YNABOptions configures how YNAB's CSV exports are read, which are formatted with the budget's date and number
+// formats.
+type YNABOptions struct {
+	// DayFirst is whether dates are written day first, e.g. "31/12/2024", rather than month first.
+	DayFirst bool
+	// DecimalComma is whether amounts use a comma as their decimal separator, e.g. "1.234,50".
+	DecimalComma bool
+}
+
+const ynabTransferPayeePrefix = "Transfer : "
+
+// ynabSplitMemo matches the memos YNAB's register CSV exports give the rows of split transactions, e.g. "Split (1/2)".
+var ynabSplitMemo = regexp.MustCompile(`^Split \((\d+)/(\d+)\)\s*(.*)$`)
+
+// ynabReport counts the parts of an export which could not be mapped, by description.
+type ynabReport map[string]int
+
+func (r ynabReport) add(description string) {
+	r[description]++
+}
+
+func (r ynabReport) list() []string {
+	descriptions := maps.Keys(r)
+	slices.Sort(descriptions)
+	unmapped := make([]string, 0, len(r))
+	for _, description := range descriptions {
+		unmapped = append(unmapped, fmt.Sprintf("%d %s", r[description], description))
+	}
+	return unmapped
+}
+
+// isYNABInternalGroup returns whether a category group holds YNAB's internal categories, which budgit has no
+// equivalent of, as money not yet assigned is not held in a Category.
+func isYNABInternalGroup(group string) bool {
+	return group == "Internal Master Category" || group == "Inflow"
+}
+
+// ParseYNABCSV parses the register CSV export of a YNAB budget, and optionally its budget CSV export of the amounts
+// assigned to categories each month, which may be nil. Both the exports of YNAB and of YNAB 4 are read.
+//
+// Transfers are recognised by their "Transfer : Account" payees, and split transactions by their "Split (1/2)" memos.
+func ParseYNABCSV(register, budget io.Reader, options YNABOptions) (*YNABBudget, error) {
+	report := ynabReport{}
+	parsed, err := parseYNABRegister(register, options, report)
+	if err != nil {
+		return nil, fmt.Errorf("parsing YNAB register: %w", err)
+	}
+	if budget != nil {
+		assignments, categories, err := parseYNABBudget(budget, options)
+		if err != nil {
+			return nil, fmt.Errorf("parsing YNAB budget: %w", err)
+		}
+		parsed.Assignments = assignments
+		parsed.Categories = deduplicateStrings(append(parsed.Categories, categories...))
+	}
+	parsed.Unmapped = report.list()
+	return parsed, nil
+}
+
+// ynabCSV reads a CSV export of YNAB, whose columns are found by name.
+type ynabCSV struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    int
+}
+
+func newYNABCSV(r io.Reader, required ...string) (*ynabCSV, error) {
+	reader := csv.NewReader(skipByteOrderMark(r))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %w", ErrYNABInvalid, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: %w", ErrYNABInvalid, CSVColumnNotFoundError{Column: name})
+		}
+	}
+	return &ynabCSV{reader: reader, columns: columns, line: 1}, nil
+}
+
+// read returns the next non-blank record, or io.EOF.
+func (c *ynabCSV) read() ([]string, error) {
+	for {
+		record, err := c.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		c.line++
+		if !isBlank(record) {
+			return record, nil
+		}
+	}
+}
+
+// field returns the field of a record in the first of the named columns in the export.
+func (c *ynabCSV) field(record []string, names ...string) string {
+	for _, name := range names {
+		if position, ok := c.columns[name]; ok {
+			return field(record, position)
+		}
+	}
+	return ""
+}
+
+// category returns the category group and name of a record, from the columns of either YNAB or YNAB 4.
+func (c *ynabCSV) category(record []string) (group, name string) {
+	if _, ok := c.columns["Sub Category"]; ok {
+		return c.field(record, "Master Category"), c.field(record, "Sub Category")
+	}
+	return c.field(record, "Category Group"), c.field(record, "Category")
+}
+
+func parseYNABRegister(r io.Reader, options YNABOptions, report ynabReport) (*YNABBudget, error) {
+	register, err := newYNABCSV(r, "Account", "Date", "Payee", "Outflow", "Inflow")
+	if err != nil {
+		return nil, err
+	}
+
+	budget := &YNABBudget{Transactions: []*YNABTransaction{}}
+	ids := map[string]*importIDs{}
+	var split *YNABTransaction
+	splitsRemaining := 0
+	for {
+		record, err := register.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row, err := toYNABRegisterRow(register, record, options)
+		if err != nil {
+			return nil, RowError{Line: register.line, Err: err}
+		}
+		if register.field(record, "Flag") != "" {
+			report.add("flagged transactions, whose flags were not imported")
+		}
+		budget.Accounts = append(budget.Accounts, row.Account)
+		if row.Payee != "" && row.TransferAccount == "" {
+			budget.Payees = append(budget.Payees, row.Payee)
+		}
+		if row.Category != "" {
+			budget.Categories = append(budget.Categories, row.Category)
+		}
+
+		match := ynabSplitMemo.FindStringSubmatch(row.Memo)
+		if match == nil {
+			if splitsRemaining != 0 {
+				report.add("split transactions missing some of their splits, whose splits were imported as they were")
+			}
+			split, splitsRemaining = nil, 0
+			budget.Transactions = append(budget.Transactions, row)
+			continue
+		}
+
+		row.Memo = match[3]
+		if match[1] == "1" || split == nil || split.Account != row.Account || !split.Date.Equal(row.Date) {
+			if splitsRemaining != 0 {
+				report.add("split transactions missing some of their splits, whose splits were imported as they were")
+			}
+			split = &YNABTransaction{Account: row.Account, Date: row.Date, Payee: row.Payee, Cleared: row.Cleared}
+			splitsRemaining, _ = strconv.Atoi(match[2])
+			budget.Transactions = append(budget.Transactions, split)
+		}
+		split.Amount += row.Amount
+		split.Splits = append(split.Splits, &YNABSplit{
+			Payee:           row.Payee,
+			Category:        row.Category,
+			TransferAccount: row.TransferAccount,
+			Memo:            row.Memo,
+			Amount:          row.Amount,
+		})
+		splitsRemaining--
+	}
+	if splitsRemaining > 0 {
+		report.add("split transactions missing some of their splits, whose splits were imported as they were")
+	}
+
+	for _, transaction := range budget.Transactions {
+		accountIDs, ok := ids[transaction.Account]
+		if !ok {
+			accountIDs = newImportIDs("ynab")
+			ids[transaction.Account] = accountIDs
+		}
+		transaction.ID = accountIDs.next(&budgit.ExternalTransaction{
+			EffectiveDate: transaction.Date,
+			PayeeName:     transaction.Payee,
+			Memo:          transaction.Memo,
+			Amount:        transaction.Amount,
+		})
+	}
+	budget.Accounts = deduplicateStrings(budget.Accounts)
+	budget.Payees = deduplicateStrings(budget.Payees)
+	budget.Categories = deduplicateStrings(budget.Categories)
+	return budget, nil
+}
+
+// toYNABRegisterRow parses a row of a register CSV export as a transaction, which may be a split of a split transaction.
+func toYNABRegisterRow(register *ynabCSV, record []string, options YNABOptions) (*YNABTransaction, error) {
+	date, err := parseQIFDate(register.field(record, "Date"), QIFOptions{DayFirst: options.DayFirst})
+	if err != nil {
+		return nil, err
+	}
+	outflow, err := parseOptionalAmount(register.field(record, "Outflow"), options.DecimalComma)
+	if err != nil {
+		return nil, err
+	}
+	inflow, err := parseOptionalAmount(register.field(record, "Inflow"), options.DecimalComma)
+	if err != nil {
+		return nil, err
+	}
+
+	row := &YNABTransaction{
+		Account: register.field(record, "Account"),
+		Date:    date,
+		Memo:    register.field(record, "Memo"),
+		Amount:  abs(inflow) - abs(outflow),
+		Cleared: register.field(record, "Cleared") != "Uncleared",
+	}
+	payee := register.field(record, "Payee")
+	if transferAccount, ok := strings.CutPrefix(payee, ynabTransferPayeePrefix); ok {
+		row.TransferAccount = strings.TrimSpace(transferAccount)
+	} else {
+		row.Payee = payee
+	}
+	if group, name := register.category(record); name != "" && !isYNABInternalGroup(group) {
+		row.Category = budgit.CategoryPath(group, name)
+	}
+	return row, nil
+}
+
+// parseYNABBudget parses a budget CSV export, returning the non-zero amounts assigned to categories and the paths of
+// every category.
+func parseYNABBudget(r io.Reader, options YNABOptions) ([]*YNABAssignment, []string, error) {
+	budget, err := newYNABCSV(r, "Month")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assignments, categories := []*YNABAssignment{}, []string{}
+	for {
+		record, err := budget.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		group, name := budget.category(record)
+		if name == "" || isYNABInternalGroup(group) {
+			continue
+		}
+		month, err := time.Parse("Jan 2006", budget.field(record, "Month"))
+		if err != nil {
+			return nil, nil, RowError{Line: budget.line, Err: fmt.Errorf("parsing month: %w", err)}
+		}
+		amount, err := parseOptionalAmount(budget.field(record, "Assigned", "Budgeted"), options.DecimalComma)
+		if err != nil {
+			return nil, nil, RowError{Line: budget.line, Err: err}
+		}
+
+		path := budgit.CategoryPath(group, name)
+		categories = append(categories, path)
+		if amount != 0 {
+			assignments = append(assignments, &YNABAssignment{Month: month, Category: path, Amount: amount})
+		}
+	}
+	return assignments, deduplicateStrings(categories), nil
+}
+
+// ynabAPIBudget is the part of a budget exported from YNAB's API, by GET /budgets/{budget_id}, read by
+// ParseYNABAPIExport. Amounts are in milliunits, thousandths of the budget's currency.
+type ynabAPIBudget struct {
+	Accounts []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		OnBudget bool   `json:"on_budget"`
+		Closed   bool   `json:"closed"`
+		Deleted  bool   `json:"deleted"`
+	} `json:"accounts"`
+	Payees []struct {
+		ID                string `json:"id"`
+		Name              string `json:"name"`
+		TransferAccountID string `json:"transfer_account_id"`
+		Deleted           bool   `json:"deleted"`
+	} `json:"payees"`
+	CategoryGroups []struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Deleted bool   `json:"deleted"`
+	} `json:"category_groups"`
+	Categories []struct {
+		ID              string `json:"id"`
+		CategoryGroupID string `json:"category_group_id"`
+		Name            string `json:"name"`
+		Hidden          bool   `json:"hidden"`
+		GoalType        string `json:"goal_type"`
+		Deleted         bool   `json:"deleted"`
+	} `json:"categories"`
+	Months []struct {
+		Month      string `json:"month"`
+		Categories []struct {
+			ID       string `json:"id"`
+			Budgeted int64  `json:"budgeted"`
+			Deleted  bool   `json:"deleted"`
+		} `json:"categories"`
+	} `json:"months"`
+	Transactions []struct {
+		ID                string `json:"id"`
+		Date              string `json:"date"`
+		Amount            int64  `json:"amount"`
+		Memo              string `json:"memo"`
+		Cleared           string `json:"cleared"`
+		Approved          bool   `json:"approved"`
+		FlagColor         string `json:"flag_color"`
+		AccountID         string `json:"account_id"`
+		PayeeID           string `json:"payee_id"`
+		CategoryID        string `json:"category_id"`
+		TransferAccountID string `json:"transfer_account_id"`
+		Deleted           bool   `json:"deleted"`
+	} `json:"transactions"`
+	Subtransactions []struct {
+		TransactionID     string `json:"transaction_id"`
+		Amount            int64  `json:"amount"`
+		Memo              string `json:"memo"`
+		PayeeID           string `json:"payee_id"`
+		CategoryID        string `json:"category_id"`
+		TransferAccountID string `json:"transfer_account_id"`
+		Deleted           bool   `json:"deleted"`
+	} `json:"subtransactions"`
+	ScheduledTransactions []struct {
+		Deleted bool `json:"deleted"`
+	} `json:"scheduled_transactions"`
+}
+
+// ParseYNABAPIExport parses a budget exported from YNAB's API, either the response of GET /budgets/{budget_id} or
+// the budget it holds. Deleted entities are skipped.
+func ParseYNABAPIExport(r io.Reader) (*YNABBudget, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing YNAB API export: %w", err)
+	}
+	var response struct {
+		Data struct {
+			Budget *ynabAPIBudget `json:"budget"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil {
+		return nil, fmt.Errorf("parsing YNAB API export: %w: %w", ErrYNABInvalid, err)
+	}
+	apiBudget := response.Data.Budget
+	if apiBudget == nil {
+		apiBudget = &ynabAPIBudget{}
+		if err := json.Unmarshal(content, apiBudget); err != nil {
+			return nil, fmt.Errorf("parsing YNAB API export: %w: %w", ErrYNABInvalid, err)
+		}
+	}
+	if apiBudget.Accounts == nil {
+		return nil, fmt.Errorf("parsing YNAB API export: %w: no accounts", ErrYNABInvalid)
+	}
+
+	budget, err := toYNABBudget(apiBudget)
+	if err != nil {
+		return nil, fmt.Errorf("parsing YNAB API export: %w", err)
+	}
+	return budget, nil
+}
+
+func toYNABBudget(apiBudget *ynabAPIBudget) (*YNABBudget, error) {
+	report := ynabReport{}
+	budget := &YNABBudget{
+		Accounts:     []string{},
+		Payees:       []string{},
+		Categories:   []string{},
+		Assignments:  []*YNABAssignment{},
+		Transactions: []*YNABTransaction{},
+	}
+	amount := func(milliunits int64) budgit.BalanceAmount {
+		if milliunits%10 != 0 {
+			report.add("amounts in fractions of a penny, which were rounded towards zero")
+		}
+		return budgit.BalanceAmount(milliunits / 10)
+	}
+
+	accountNames := map[string]string{}
+	for _, account := range apiBudget.Accounts {
+		if account.Deleted {
+			continue
+		}
+		accountNames[account.ID] = account.Name
+		budget.Accounts = append(budget.Accounts, account.Name)
+		if !account.OnBudget {
+			report.add("tracking accounts, which were imported as budget accounts")
+		}
+		if account.Closed {
+			report.add("closed accounts, which were imported as open accounts")
+		}
+	}
+
+	payeeNames := map[string]string{}
+	for _, payee := range apiBudget.Payees {
+		if payee.Deleted || payee.TransferAccountID != "" {
+			continue
+		}
+		payeeNames[payee.ID] = payee.Name
+		budget.Payees = append(budget.Payees, payee.Name)
+	}
+
+	groupNames := map[string]string{}
+	for _, group := range apiBudget.CategoryGroups {
+		if !group.Deleted {
+			groupNames[group.ID] = group.Name
+		}
+	}
+	categoryPaths := map[string]string{}
+	for _, category := range apiBudget.Categories {
+		group, ok := groupNames[category.CategoryGroupID]
+		if category.Deleted || !ok || isYNABInternalGroup(group) {
+			continue
+		}
+		categoryPaths[category.ID] = budgit.CategoryPath(group, category.Name)
+		budget.Categories = append(budget.Categories, categoryPaths[category.ID])
+		if category.Hidden {
+			report.add("hidden categories, which were imported as visible categories")
+		}
+		if category.GoalType != "" {
+			report.add("category targets, which were not imported")
+		}
+	}
+
+	for _, month := range apiBudget.Months {
+		date, err := time.Parse(time.DateOnly, month.Month)
+		if err != nil {
+			return nil, fmt.Errorf("month %q: %w", month.Month, err)
+		}
+		for _, category := range month.Categories {
+			path, ok := categoryPaths[category.ID]
+			if category.Deleted || !ok || category.Budgeted == 0 {
+				continue
+			}
+			budget.Assignments = append(budget.Assignments, &YNABAssignment{Month: date, Category: path, Amount: amount(category.Budgeted)})
+		}
+	}
+
+	transactions := map[string]*YNABTransaction{}
+	for _, apiTransaction := range apiBudget.Transactions {
+		if apiTransaction.Deleted {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, apiTransaction.Date)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %q: %w", apiTransaction.ID, err)
+		}
+		account, ok := accountNames[apiTransaction.AccountID]
+		if !ok {
+			return nil, fmt.Errorf("transaction %q: %w: unknown account %q", apiTransaction.ID, ErrYNABInvalid, apiTransaction.AccountID)
+		}
+		if apiTransaction.FlagColor != "" {
+			report.add("flagged transactions, whose flags were not imported")
+		}
+		if !apiTransaction.Approved {
+			report.add("unapproved transactions, which were imported as approved")
+		}
+
+		transaction := &YNABTransaction{
+			ID:              apiTransaction.ID,
+			Account:         account,
+			Date:            date,
+			Payee:           payeeNames[apiTransaction.PayeeID],
+			Category:        categoryPaths[apiTransaction.CategoryID],
+			TransferAccount: accountNames[apiTransaction.TransferAccountID],
+			Memo:            apiTransaction.Memo,
+			Amount:          amount(apiTransaction.Amount),
+			Cleared:         apiTransaction.Cleared != "uncleared",
+		}
+		transactions[transaction.ID] = transaction
+		budget.Transactions = append(budget.Transactions, transaction)
+	}
+	for _, subtransaction := range apiBudget.Subtransactions {
+		transaction, ok := transactions[subtransaction.TransactionID]
+		if subtransaction.Deleted || !ok {
+			continue
+		}
+		transaction.Category = ""
+		transaction.Splits = append(transaction.Splits, &YNABSplit{
+			Payee:           payeeNames[subtransaction.PayeeID],
+			Category:        categoryPaths[subtransaction.CategoryID],
+			TransferAccount: accountNames[subtransaction.TransferAccountID],
+			Memo:            subtransaction.Memo,
+			Amount:          amount(subtransaction.Amount),
+		})
+	}
+
+	for _, scheduled := range apiBudget.ScheduledTransactions {
+		if !scheduled.Deleted {
+			report.add("scheduled transactions, which were not imported")
+		}
+	}
+	budget.Unmapped = report.list()
+	return budget, nil
+}
+
+// deduplicateStrings returns the distinct strings, in the order they first appear.
+func deduplicateStrings(strs []string) []string {
+	seen := make(map[string]bool, len(strs))
+	return slices.DeleteFunc(strs, func(str string) bool {
+		if seen[str] {
+			return true
+		}
+		seen[str] = true
+		return false
+	})
+}
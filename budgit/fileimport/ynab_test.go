@@ -0,0 +1,161 @@
+package fileimport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+const ynabRegister = `"Account","Flag","Date","Payee","Category Group/Category","Category Group","Category","Memo","Outflow","Inflow","Cleared"
+"Current","","01/06/2024","Employer","Inflow: Ready to Assign","Inflow","Ready to Assign","June pay",£0.00,£2000.00,"Reconciled"
+"Current","Red","02/06/2024","Tesco","","","","Split (1/2) Food",£40.00,£0.00,"Cleared"
+"Current","Red","02/06/2024","Tesco","Bills: Household","Bills","Household","Split (2/2) Bleach",£2.50,£0.00,"Cleared"
+"Current","","03/06/2024","Transfer : Savings","","","","",£100.00,£0.00,"Uncleared"
+"Savings","","03/06/2024","Transfer : Current","","","","",£0.00,£100.00,"Uncleared"
+`
+
+const ynabBudget = `"Month","Category Group/Category","Category Group","Category","Assigned","Activity","Available"
+"Jun 2024","Inflow: Ready to Assign","Inflow","Ready to Assign",£0.00,£2000.00,£1857.50
+"Jun 2024","Bills: Household","Bills","Household",£50.00,-£2.50,£47.50
+"Jun 2024","Everyday: Groceries","Everyday","Groceries",£0.00,£0.00,£0.00
+`
+
+const ynabAPIExport = `{
+  "data": {
+    "budget": {
+      "id": "budget-1",
+      "name": "Household",
+      "accounts": [
+        {"id": "account-1", "name": "Current", "on_budget": true, "closed": false, "deleted": false},
+        {"id": "account-2", "name": "Pension", "on_budget": false, "closed": false, "deleted": false},
+        {"id": "account-3", "name": "Old", "on_budget": true, "closed": true, "deleted": true}
+      ],
+      "payees": [
+        {"id": "payee-1", "name": "Tesco", "transfer_account_id": null, "deleted": false},
+        {"id": "payee-2", "name": "Transfer : Pension", "transfer_account_id": "account-2", "deleted": false}
+      ],
+      "category_groups": [
+        {"id": "group-1", "name": "Internal Master Category", "hidden": false, "deleted": false},
+        {"id": "group-2", "name": "Bills", "hidden": false, "deleted": false}
+      ],
+      "categories": [
+        {"id": "category-1", "category_group_id": "group-1", "name": "Inflow: Ready to Assign", "hidden": false, "deleted": false},
+        {"id": "category-2", "category_group_id": "group-2", "name": "Household", "hidden": true, "goal_type": "MF", "deleted": false}
+      ],
+      "months": [
+        {"month": "2024-06-01", "categories": [{"id": "category-2", "budgeted": 50000, "deleted": false}]}
+      ],
+      "transactions": [
+        {"id": "transaction-1", "date": "2024-06-02", "amount": -42500, "memo": null, "cleared": "cleared", "approved": true, "flag_color": "red",
+         "account_id": "account-1", "payee_id": "payee-1", "category_id": null, "transfer_account_id": null, "deleted": false},
+        {"id": "transaction-2", "date": "2024-06-03", "amount": -100005, "memo": "Top up", "cleared": "uncleared", "approved": false, "flag_color": null,
+         "account_id": "account-1", "payee_id": "payee-2", "category_id": null, "transfer_account_id": "account-2", "deleted": false},
+        {"id": "transaction-3", "date": "2024-06-04", "amount": -1000, "memo": null, "cleared": "cleared", "approved": true,
+         "account_id": "account-1", "payee_id": "payee-1", "category_id": "category-2", "transfer_account_id": null, "deleted": true}
+      ],
+      "subtransactions": [
+        {"id": "sub-1", "transaction_id": "transaction-1", "amount": -40000, "memo": "Food", "payee_id": null, "category_id": null, "transfer_account_id": null, "deleted": false},
+        {"id": "sub-2", "transaction_id": "transaction-1", "amount": -2500, "memo": "Bleach", "payee_id": null, "category_id": "category-2", "transfer_account_id": null, "deleted": false}
+      ],
+      "scheduled_transactions": [{"id": "scheduled-1", "deleted": false}]
+    }
+  }
+}`
+
+func (s *fileImportSuite) TestParseYNABCSV() {
+	budget, err := fileimport.ParseYNABCSV(strings.NewReader(ynabRegister), strings.NewReader(ynabBudget), fileimport.YNABOptions{DayFirst: true})
+	s.Require().NoError(err)
+	for _, transaction := range budget.Transactions {
+		// Register exports do not identify transactions, so they are given IDs derived from their fields.
+		s.True(strings.HasPrefix(transaction.ID, "ynab-"))
+		transaction.ID = ""
+	}
+
+	expected := &fileimport.YNABBudget{
+		Accounts:   []string{"Current", "Savings"},
+		Payees:     []string{"Employer", "Tesco"},
+		Categories: []string{"Bills:Household", "Everyday:Groceries"},
+		Assignments: []*fileimport.YNABAssignment{
+			{Month: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Category: "Bills:Household", Amount: 5000},
+		},
+		Transactions: []*fileimport.YNABTransaction{
+			{Account: "Current", Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Payee: "Employer", Memo: "June pay", Amount: 200000, Cleared: true},
+			{
+				Account: "Current", Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Payee: "Tesco", Amount: -4250, Cleared: true,
+				Splits: []*fileimport.YNABSplit{
+					{Payee: "Tesco", Memo: "Food", Amount: -4000},
+					{Payee: "Tesco", Category: "Bills:Household", Memo: "Bleach", Amount: -250},
+				},
+			},
+			{Account: "Current", Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), TransferAccount: "Savings", Amount: -10000},
+			{Account: "Savings", Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), TransferAccount: "Current", Amount: 10000},
+		},
+		Unmapped: []string{"2 flagged transactions, whose flags were not imported"},
+	}
+	s.CMPEqual(expected, budget)
+}
+
+func (s *fileImportSuite) TestParseYNABCSVWithoutBudget() {
+	budget, err := fileimport.ParseYNABCSV(strings.NewReader(ynabRegister), nil, fileimport.YNABOptions{DayFirst: true})
+	s.Require().NoError(err)
+	s.Equal([]string{"Bills:Household"}, budget.Categories)
+	s.Empty(budget.Assignments)
+	s.Len(budget.Transactions, 4)
+}
+
+func (s *fileImportSuite) TestParseYNABAPIExport() {
+	budget, err := fileimport.ParseYNABAPIExport(strings.NewReader(ynabAPIExport))
+	s.Require().NoError(err)
+
+	expected := &fileimport.YNABBudget{
+		Accounts:   []string{"Current", "Pension"},
+		Payees:     []string{"Tesco"},
+		Categories: []string{"Bills:Household"},
+		Assignments: []*fileimport.YNABAssignment{
+			{Month: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Category: "Bills:Household", Amount: 5000},
+		},
+		Transactions: []*fileimport.YNABTransaction{
+			{
+				ID: "transaction-1", Account: "Current", Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Payee: "Tesco", Amount: -4250, Cleared: true,
+				Splits: []*fileimport.YNABSplit{
+					{Memo: "Food", Amount: -4000},
+					{Category: "Bills:Household", Memo: "Bleach", Amount: -250},
+				},
+			},
+			{ID: "transaction-2", Account: "Current", Date: time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC), TransferAccount: "Pension", Memo: "Top up", Amount: -10000},
+		},
+		Unmapped: []string{
+			"1 amounts in fractions of a penny, which were rounded towards zero",
+			"1 category targets, which were not imported",
+			"1 flagged transactions, whose flags were not imported",
+			"1 hidden categories, which were imported as visible categories",
+			"1 scheduled transactions, which were not imported",
+			"1 tracking accounts, which were imported as budget accounts",
+			"1 unapproved transactions, which were imported as approved",
+		},
+	}
+	s.CMPEqual(expected, budget)
+}
+
+func (s *fileImportSuite) TestParseYNABErrors() {
+	s.Run("RegisterMissingColumn", func() {
+		_, err := fileimport.ParseYNABCSV(strings.NewReader("Account,Date,Payee\n"), nil, fileimport.YNABOptions{})
+		s.ErrorIs(err, fileimport.ErrYNABInvalid)
+		s.ErrorAs(err, &fileimport.CSVColumnNotFoundError{})
+	})
+	s.Run("RegisterInvalidAmount", func() {
+		_, err := fileimport.ParseYNABCSV(strings.NewReader("Account,Date,Payee,Outflow,Inflow\nCurrent,2024-06-01,Tesco,lots,\n"), nil, fileimport.YNABOptions{})
+		s.ErrorAs(err, &fileimport.RowError{})
+		s.ErrorIs(err, budgit.ErrInvalidBalanceAmount)
+	})
+	s.Run("APIExportNotJSON", func() {
+		_, err := fileimport.ParseYNABAPIExport(strings.NewReader("Account,Date\n"))
+		s.ErrorIs(err, fileimport.ErrYNABInvalid)
+	})
+	s.Run("APIExportNotBudget", func() {
+		_, err := fileimport.ParseYNABAPIExport(strings.NewReader(`{"data": {}}`))
+		s.ErrorIs(err, fileimport.ErrYNABInvalid)
+	})
+}
@@ -0,0 +1,119 @@
+// Package dbtest runs Postgres in a container with the budgit schema migrated, for the tests of the packages which
+// query it.
+package dbtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// AppPassword is the password budgit_app, the role budgit runs as, logs in with.
+const AppPassword = "budgit_app"
+
+// Tables are the tables the tests write to, which Truncate empties.
+var Tables = []string{
+	"accounts", "assignments", "attachments", "categories", "category_groups", "csv_profiles", "payees", "transactions",
+	"users", "sessions", "api_tokens", "budget_members", "budget_invitations", "external_account_links",
+}
+
+// Postgres is a Postgres container with every migration applied.
+type Postgres struct {
+	container *postgres.PostgresContainer
+
+	// ConnString connects as the superuser, which owns the tables and bypasses row-level security.
+	ConnString string
+	// AppConnString connects as budgit_app, which row-level security applies to.
+	AppConnString string
+}
+
+// Run starts a Postgres container, applies every migration as the superuser, as they are applied in production by
+// the role which owns the tables, and lets budgit_app log in.
+func Run(ctx context.Context) (*Postgres, error) {
+	container, err := postgres.Run(ctx, "postgres:16-alpine",
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("postgres"),
+		postgres.WithDatabase("budgit"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).WithStartupTimeout(5*time.Second)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("running postgres container: %w", err)
+	}
+	p := &Postgres{container: container}
+
+	if err := p.setup(ctx); err != nil {
+		return nil, errors.Join(err, p.Terminate(ctx))
+	}
+	return p, nil
+}
+
+func (p *Postgres) setup(ctx context.Context) error {
+	connString, err := p.container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return fmt.Errorf("getting postgres container URL: %w", err)
+	}
+	p.ConnString = connString
+
+	migrator, err := migrations.New(connString)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+	if _, err := migrator.Up(); err != nil {
+		return err
+	}
+
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return fmt.Errorf("connecting to postgres container: %w", err)
+	}
+	defer conn.Close(ctx)
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`ALTER ROLE budgit_app LOGIN PASSWORD '%s'`, AppPassword)); err != nil {
+		return fmt.Errorf("letting budgit_app log in: %w", err)
+	}
+
+	appURL, err := url.Parse(connString)
+	if err != nil {
+		return fmt.Errorf("parsing postgres container URL: %w", err)
+	}
+	appURL.User = url.UserPassword("budgit_app", AppPassword)
+	p.AppConnString = appURL.String()
+	return nil
+}
+
+// Terminate stops and removes the container.
+func (p *Postgres) Terminate(ctx context.Context) error {
+	if err := p.container.Terminate(ctx); err != nil {
+		return fmt.Errorf("terminating postgres container: %w", err)
+	}
+	return nil
+}
+
+// Execer is a connection or pool, which Truncate runs its statements on.
+type Execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// Truncate empties the Tables and deletes every budget except the default one, which the migrations create, as a
+// superuser between tests.
+func Truncate(ctx context.Context, conn Execer) error {
+	if _, err := conn.Exec(ctx, fmt.Sprintf(`TRUNCATE TABLE %s`, strings.Join(Tables, ", "))); err != nil {
+		return fmt.Errorf("truncating tables: %w", err)
+	}
+	if _, err := conn.Exec(ctx, `DELETE FROM budgets WHERE id <> 'default'`); err != nil {
+		return fmt.Errorf("deleting budgets: %w", err)
+	}
+	return nil
+}
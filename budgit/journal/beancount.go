@@ -0,0 +1,65 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// beancountWriter writes beancount journals, whose accounts are opened before use.
+type beancountWriter struct{}
+
+func (w *beancountWriter) header(b *strings.Builder, currency string, openDate time.Time, accounts [][]string) {
+	fmt.Fprintf(b, "option \"operating_currency\" %s\n\n", beancountString(currency))
+	for _, account := range accounts {
+		fmt.Fprintf(b, "%s open %s %s\n", openDate.Format(time.DateOnly), beancountAccount(account), currency)
+	}
+}
+
+func (w *beancountWriter) entry(b *strings.Builder, currency string, e *entry) {
+	flag := "!"
+	if e.cleared {
+		flag = "*"
+	}
+	fmt.Fprintf(b, "%s %s %s %s\n", e.date.Format(time.DateOnly), flag, beancountString(e.payee), beancountString(e.memo))
+	for _, posting := range e.postings {
+		fmt.Fprintf(b, "  %s  %s %s", beancountAccount(posting.account), posting.amount, currency)
+		if posting.memo != "" {
+			fmt.Fprintf(b, " ; %s", strings.Join(strings.Fields(posting.memo), " "))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// beancountAccount returns the name of an account in beancount, whose components are made of letters, digits and
+// dashes, and begin with a capital letter or digit. Words are capitalised and joined by dashes, e.g. "Day to day"
+// becomes "Day-To-Day", and other characters are dropped.
+func beancountAccount(components []string) string {
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		words := []string{}
+		for _, word := range strings.FieldsFunc(component, func(r rune) bool { return unicode.IsSpace(r) || r == ':' || r == '-' }) {
+			word = strings.Map(func(r rune) rune {
+				if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+					return r
+				}
+				return -1
+			}, word)
+			if word != "" {
+				words = append(words, strings.ToUpper(word[:1])+word[1:])
+			}
+		}
+		name := strings.Join(words, "-")
+		if name == "" {
+			name = "Unnamed"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ":")
+}
+
+// beancountString returns text as a quoted beancount string.
+func beancountString(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(strings.Join(strings.Fields(text), " ")) + `"`
+}
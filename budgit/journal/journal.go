@@ -0,0 +1,267 @@
+// Package journal writes budgets as the journals of plain-text accounting programs: ledger, hledger and beancount.
+//
+// Accounts are written as assets, and the Categories of Transactions as expenses, e.g. "Expenses:Bills:Energy".
+// Uncategorised money in is written as income, and uncategorised money out as an expense. Transfers between Accounts,
+// which budgit records as a Transaction and its mirror, are written as single entries with a posting to each Account,
+// as are split transactions, with a posting for each split.
+package journal
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// Format is the format of a journal.
+type Format string
+
+const (
+	FormatLedger    Format = "ledger"
+	FormatHledger   Format = "hledger"
+	FormatBeancount Format = "beancount"
+)
+
+var ErrUnknownFormat = fmt.Errorf("the journal format is not known")
+
+// Budget is the content of a budget to write as a journal.
+type Budget struct {
+	// Currency is the commodity amounts are written in, e.g. "GBP".
+	Currency string
+	Accounts []*budgit.Account
+	Payees   []*budgit.Payee
+	// CategoryPaths are the paths of Categories by ID, see budgit.CategoryPath.
+	CategoryPaths map[string]string
+	Transactions  []*budgit.Transaction
+}
+
+var (
+	openingBalancesAccount = []string{"Equity", "Opening Balances"}
+	uncategorisedIncome    = []string{"Income", "Uncategorised"}
+	uncategorisedExpenses  = []string{"Expenses", "Uncategorised"}
+)
+
+// entry is a journal entry, whose postings sum to zero.
+type entry struct {
+	date     time.Time
+	cleared  bool
+	payee    string
+	memo     string
+	postings []*posting
+}
+
+type posting struct {
+	// account is the components of the account's name, e.g. {"Expenses", "Bills", "Energy"}.
+	account []string
+	amount  budgit.BalanceAmount
+	memo    string
+}
+
+// Write writes a budget as a journal of the given format.
+func Write(w io.Writer, format Format, budget *Budget) error {
+	var writer journalWriter
+	switch format {
+	case FormatLedger:
+		writer = &ledgerWriter{dateFormat: "2006/01/02"}
+	case FormatHledger:
+		writer = &ledgerWriter{dateFormat: time.DateOnly, inlineNotes: true}
+	case FormatBeancount:
+		writer = &beancountWriter{}
+	default:
+		return fmt.Errorf("writing %q journal: %w", format, ErrUnknownFormat)
+	}
+
+	entries := newEntries(budget)
+	accounts := [][]string{}
+	for _, entry := range entries {
+		for _, posting := range entry.postings {
+			accounts = append(accounts, posting.account)
+		}
+	}
+	slices.SortFunc(accounts, compareAccounts)
+	accounts = slices.CompactFunc(accounts, slices.Equal)
+
+	openDate := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	if len(entries) != 0 {
+		openDate = entries[0].date
+	}
+
+	b := &strings.Builder{}
+	writer.header(b, budget.Currency, openDate, accounts)
+	for _, entry := range entries {
+		b.WriteString("\n")
+		writer.entry(b, budget.Currency, entry)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing %q journal: %w", format, err)
+	}
+	return nil
+}
+
+// newEntries returns the entries of a budget's Transactions, sorted by date, preceded by an opening balance entry for
+// each Account whose balance is not accounted for by its Transactions.
+func newEntries(budget *Budget) []*entry {
+	accountNames := make(map[string]string, len(budget.Accounts))
+	for _, account := range budget.Accounts {
+		accountNames[account.ID] = account.Name
+	}
+	payeeNames := make(map[string]string, len(budget.Payees))
+	for _, payee := range budget.Payees {
+		payeeNames[payee.ID] = payee.Name
+	}
+	accountOf := func(id string) []string {
+		return []string{"Assets", accountNames[id]}
+	}
+
+	units := splitUnits(budget.Transactions)
+	skipped := skippedMirrors(units)
+
+	entries := []*entry{}
+	for _, unit := range units {
+		e := &entry{date: unit[0].EffectiveDate, cleared: true}
+		var total budgit.BalanceAmount
+		counterPostings := []*posting{}
+		for _, transaction := range unit {
+			if skipped[transaction] {
+				continue
+			}
+			total += transaction.Amount
+			e.cleared = e.cleared && transaction.Cleared
+			if e.memo == "" {
+				e.memo = transaction.Memo
+			}
+
+			counter := &posting{amount: -transaction.Amount, memo: transaction.Memo}
+			switch {
+			case transaction.IsPayeeInternal:
+				counter.account = accountOf(transaction.PayeeID)
+			case budget.CategoryPaths[transaction.CategoryID] != "":
+				group, category := budgit.SplitCategoryPath(budget.CategoryPaths[transaction.CategoryID])
+				counter.account = []string{"Expenses", group, category}
+				if group == category {
+					counter.account = []string{"Expenses", category}
+				}
+			case transaction.Amount > 0:
+				counter.account = uncategorisedIncome
+			default:
+				counter.account = uncategorisedExpenses
+			}
+			if !transaction.IsPayeeInternal && e.payee == "" {
+				e.payee = payeeNames[transaction.PayeeID]
+			}
+			counterPostings = append(counterPostings, counter)
+		}
+		if len(counterPostings) == 0 {
+			continue
+		}
+
+		// The memos of splits are kept with their postings, and otherwise with the entry.
+		if len(counterPostings) == 1 {
+			counterPostings[0].memo = ""
+		} else {
+			e.memo = ""
+		}
+		if e.payee == "" {
+			e.payee = "Transfer"
+		}
+		e.postings = append([]*posting{{account: accountOf(unit[0].AccountID), amount: total}}, counterPostings...)
+		entries = append(entries, e)
+	}
+	slices.SortStableFunc(entries, func(a, b *entry) int { return a.date.Compare(b.date) })
+
+	openingDate := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	if len(entries) != 0 {
+		openingDate = entries[0].date
+	}
+	transactionTotals := map[string]budgit.BalanceAmount{}
+	for _, transaction := range budget.Transactions {
+		transactionTotals[transaction.AccountID] += transaction.Amount
+	}
+	openingEntries := []*entry{}
+	for _, account := range budget.Accounts {
+		if opening := account.Balance.EffectiveBalance - transactionTotals[account.ID]; opening != 0 {
+			openingEntries = append(openingEntries, &entry{
+				date:    openingDate,
+				cleared: true,
+				payee:   "Opening Balance",
+				postings: []*posting{
+					{account: accountOf(account.ID), amount: opening},
+					{account: openingBalancesAccount, amount: -opening},
+				},
+			})
+		}
+	}
+	return append(openingEntries, entries...)
+}
+
+// splitUnits groups Transactions into those written as an entry: the Transactions of a split transaction, sharing a
+// SplitID in an Account, and otherwise single Transactions.
+func splitUnits(transactions []*budgit.Transaction) [][]*budgit.Transaction {
+	type accountSplit struct{ accountID, splitID string }
+	units := [][]*budgit.Transaction{}
+	splitUnitIndexes := map[accountSplit]int{}
+	for _, transaction := range transactions {
+		if transaction.SplitID == "" {
+			units = append(units, []*budgit.Transaction{transaction})
+			continue
+		}
+		key := accountSplit{transaction.AccountID, transaction.SplitID}
+		if i, ok := splitUnitIndexes[key]; ok {
+			units[i] = append(units[i], transaction)
+			continue
+		}
+		splitUnitIndexes[key] = len(units)
+		units = append(units, []*budgit.Transaction{transaction})
+	}
+	return units
+}
+
+// skippedMirrors returns the Transactions to skip as the other side of a transfer written with its pair. Of a pair,
+// the side in the split transaction with the most splits is kept, so that a split's transfer is written with the rest
+// of the split, and otherwise the first side.
+func skippedMirrors(units [][]*budgit.Transaction) map[*budgit.Transaction]bool {
+	type side struct {
+		accountID, otherAccountID, splitID string
+		date                               time.Time
+		amount                             budgit.BalanceAmount
+	}
+	unitSizes := map[*budgit.Transaction]int{}
+	unmatched := map[side][]*budgit.Transaction{}
+	skipped := map[*budgit.Transaction]bool{}
+	for _, unit := range units {
+		for _, transaction := range unit {
+			unitSizes[transaction] = len(unit)
+			if !transaction.IsPayeeInternal {
+				continue
+			}
+			mirrorSide := side{transaction.PayeeID, transaction.AccountID, transaction.SplitID, transaction.EffectiveDate, -transaction.Amount}
+			if mirrors := unmatched[mirrorSide]; len(mirrors) != 0 {
+				mirror := mirrors[0]
+				unmatched[mirrorSide] = mirrors[1:]
+				if unitSizes[mirror] < unitSizes[transaction] {
+					skipped[mirror] = true
+				} else {
+					skipped[transaction] = true
+				}
+				continue
+			}
+			ownSide := side{transaction.AccountID, transaction.PayeeID, transaction.SplitID, transaction.EffectiveDate, transaction.Amount}
+			unmatched[ownSide] = append(unmatched[ownSide], transaction)
+		}
+	}
+	return skipped
+}
+
+func compareAccounts(a, b []string) int {
+	return cmp.Compare(strings.Join(a, ":"), strings.Join(b, ":"))
+}
+
+// journalWriter writes the directives and entries of a journal format.
+type journalWriter interface {
+	header(b *strings.Builder, currency string, openDate time.Time, accounts [][]string)
+	entry(b *strings.Builder, currency string, e *entry)
+}
@@ -0,0 +1,165 @@
+package journal_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/journal"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestJournal(t *testing.T) {
+	suite.Run(t, new(journalSuite))
+}
+
+type journalSuite struct {
+	suite.Suite
+}
+
+// testBudget returns a budget with an opening balance, income, a split transaction with a transfer, and a transfer,
+// whose mirrors are recorded as CreateTransactions records them.
+func testBudget() *journal.Budget {
+	june := func(day int) time.Time { return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC) }
+	return &journal.Budget{
+		Currency: "GBP",
+		Accounts: []*budgit.Account{
+			{ID: "current", Name: "Current", Balance: budgit.Balance{ClearedBalance: 295000, EffectiveBalance: 300000}},
+			{ID: "savings", Name: "Rainy day", Balance: budgit.Balance{ClearedBalance: 1000, EffectiveBalance: -4000}},
+		},
+		Payees: []*budgit.Payee{
+			{ID: "employer", Name: "Employer"},
+			{ID: "tesco", Name: "Tesco"},
+		},
+		CategoryPaths: map[string]string{"groceries": "Everyday:Groceries"},
+		Transactions: []*budgit.Transaction{
+			{ID: "1", EffectiveDate: june(1), AccountID: "current", PayeeID: "employer", Amount: 200000, Cleared: true, Memo: "June pay"},
+			{ID: "2", EffectiveDate: june(2), AccountID: "current", PayeeID: "tesco", CategoryID: "groceries", Amount: -4000, Cleared: true, Memo: "Food", SplitID: "split"},
+			{ID: "3", EffectiveDate: june(2), AccountID: "current", PayeeID: "savings", IsPayeeInternal: true, Amount: -1000, Cleared: true, Memo: "Pot", SplitID: "split"},
+			{ID: "4", EffectiveDate: june(3), AccountID: "current", PayeeID: "savings", IsPayeeInternal: true, Amount: 5000},
+			{ID: "3-mirror", EffectiveDate: june(2), AccountID: "savings", PayeeID: "current", IsPayeeInternal: true, Amount: 1000, Cleared: true, Memo: "Pot", SplitID: "split"},
+			{ID: "4-mirror", EffectiveDate: june(3), AccountID: "savings", PayeeID: "current", IsPayeeInternal: true, Amount: -5000},
+		},
+	}
+}
+
+func (s *journalSuite) TestWrite() {
+	testCases := []struct {
+		format   journal.Format
+		expected string
+	}{
+		{
+			format: journal.FormatLedger,
+			expected: `commodity GBP
+account Assets:Current
+account Assets:Rainy day
+account Equity:Opening Balances
+account Expenses:Everyday:Groceries
+account Income:Uncategorised
+
+2024/06/01 * Opening Balance
+    Assets:Current  1000.00 GBP
+    Equity:Opening Balances  -1000.00 GBP
+
+2024/06/01 * Employer
+    ; June pay
+    Assets:Current  2000.00 GBP
+    Income:Uncategorised  -2000.00 GBP
+
+2024/06/02 * Tesco
+    Assets:Current  -50.00 GBP
+    Expenses:Everyday:Groceries  40.00 GBP  ; Food
+    Assets:Rainy day  10.00 GBP  ; Pot
+
+2024/06/03 ! Transfer
+    Assets:Current  50.00 GBP
+    Assets:Rainy day  -50.00 GBP
+`,
+		},
+		{
+			format: journal.FormatHledger,
+			expected: `commodity GBP
+account Assets:Current
+account Assets:Rainy day
+account Equity:Opening Balances
+account Expenses:Everyday:Groceries
+account Income:Uncategorised
+
+2024-06-01 * Opening Balance
+    Assets:Current  1000.00 GBP
+    Equity:Opening Balances  -1000.00 GBP
+
+2024-06-01 * Employer | June pay
+    Assets:Current  2000.00 GBP
+    Income:Uncategorised  -2000.00 GBP
+
+2024-06-02 * Tesco
+    Assets:Current  -50.00 GBP
+    Expenses:Everyday:Groceries  40.00 GBP  ; Food
+    Assets:Rainy day  10.00 GBP  ; Pot
+
+2024-06-03 ! Transfer
+    Assets:Current  50.00 GBP
+    Assets:Rainy day  -50.00 GBP
+`,
+		},
+		{
+			format: journal.FormatBeancount,
+			expected: `option "operating_currency" "GBP"
+
+2024-06-01 open Assets:Current GBP
+2024-06-01 open Assets:Rainy-Day GBP
+2024-06-01 open Equity:Opening-Balances GBP
+2024-06-01 open Expenses:Everyday:Groceries GBP
+2024-06-01 open Income:Uncategorised GBP
+
+2024-06-01 * "Opening Balance" ""
+  Assets:Current  1000.00 GBP
+  Equity:Opening-Balances  -1000.00 GBP
+
+2024-06-01 * "Employer" "June pay"
+  Assets:Current  2000.00 GBP
+  Income:Uncategorised  -2000.00 GBP
+
+2024-06-02 * "Tesco" ""
+  Assets:Current  -50.00 GBP
+  Expenses:Everyday:Groceries  40.00 GBP ; Food
+  Assets:Rainy-Day  10.00 GBP ; Pot
+
+2024-06-03 ! "Transfer" ""
+  Assets:Current  50.00 GBP
+  Assets:Rainy-Day  -50.00 GBP
+`,
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(string(tc.format), func() {
+			b := &strings.Builder{}
+			s.Require().NoError(journal.Write(b, tc.format, testBudget()))
+			s.Equal(tc.expected, b.String())
+		})
+	}
+}
+
+func (s *journalSuite) TestWriteAccountNames() {
+	budget := &journal.Budget{
+		Currency: "GBP",
+		Accounts: []*budgit.Account{{ID: "joint", Name: "day-to-day: joint (£)", Balance: budgit.Balance{EffectiveBalance: 100}}},
+	}
+	s.Run("Ledger", func() {
+		b := &strings.Builder{}
+		s.Require().NoError(journal.Write(b, journal.FormatLedger, budget))
+		s.Contains(b.String(), "account Assets:day-to-day- joint (£)\n")
+	})
+	s.Run("Beancount", func() {
+		b := &strings.Builder{}
+		s.Require().NoError(journal.Write(b, journal.FormatBeancount, budget))
+		s.Contains(b.String(), "1970-01-01 open Assets:Day-To-Day-Joint GBP\n")
+	})
+}
+
+func (s *journalSuite) TestWriteUnknownFormat() {
+	err := journal.Write(&strings.Builder{}, "gnucash", testBudget())
+	s.ErrorIs(err, journal.ErrUnknownFormat)
+}
@@ -0,0 +1,63 @@
+package journal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ledgerWriter writes ledger-cli journals, or hledger journals, which differ only in their dates and notes.
+type ledgerWriter struct {
+	dateFormat string
+	// inlineNotes is whether memos are written after the payee, separated by " | ", as hledger reads them as notes.
+	// Otherwise they are written as comments.
+	inlineNotes bool
+}
+
+func (w *ledgerWriter) header(b *strings.Builder, currency string, _ time.Time, accounts [][]string) {
+	fmt.Fprintf(b, "commodity %s\n", currency)
+	for _, account := range accounts {
+		fmt.Fprintf(b, "account %s\n", ledgerAccount(account))
+	}
+}
+
+func (w *ledgerWriter) entry(b *strings.Builder, currency string, e *entry) {
+	status := "!"
+	if e.cleared {
+		status = "*"
+	}
+	fmt.Fprintf(b, "%s %s %s", e.date.Format(w.dateFormat), status, ledgerText(e.payee))
+	if e.memo != "" && w.inlineNotes {
+		fmt.Fprintf(b, " | %s", ledgerText(e.memo))
+	}
+	b.WriteString("\n")
+	if e.memo != "" && !w.inlineNotes {
+		fmt.Fprintf(b, "    ; %s\n", ledgerText(e.memo))
+	}
+	for _, posting := range e.postings {
+		fmt.Fprintf(b, "    %s  %s %s", ledgerAccount(posting.account), posting.amount, currency)
+		if posting.memo != "" {
+			fmt.Fprintf(b, "  ; %s", ledgerText(posting.memo))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// ledgerAccount returns the name of an account in ledger and hledger. Colons separate components, and two spaces end
+// the name, so neither may appear within a component.
+func ledgerAccount(components []string) string {
+	names := make([]string, 0, len(components))
+	for _, component := range components {
+		name := strings.Join(strings.Fields(strings.NewReplacer(":", "-", ";", "").Replace(component)), " ")
+		if name == "" {
+			name = "Unnamed"
+		}
+		names = append(names, name)
+	}
+	return strings.Join(names, ":")
+}
+
+// ledgerText returns text on a single line, with any " | " which hledger would read as the start of a note replaced.
+func ledgerText(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), " | ", " / ")
+}
@@ -0,0 +1,50 @@
+package budgit
+
+import "time"
+
+// Role is what a member of a Budget may do with it. Each role may do everything the roles below it may.
+type Role string
+
+const (
+	// RoleOwner may manage the members of the Budget, as well as write to it.
+	RoleOwner Role = "owner"
+	// RoleEditor may write to the Budget, as well as read it.
+	RoleEditor Role = "editor"
+	// RoleViewer may only read the Budget.
+	RoleViewer Role = "viewer"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// IsValid returns whether the Role is one of the roles members may have.
+func (r Role) IsValid() bool {
+	return roleRanks[r] != 0
+}
+
+// Includes returns whether a member with the Role may do everything a member with another role may.
+func (r Role) Includes(other Role) bool {
+	return r.IsValid() && roleRanks[r] >= roleRanks[other]
+}
+
+// BudgetMember is a User who is a member of a Budget.
+type BudgetMember struct {
+	BudgetID         string
+	UserID           string
+	Username         string
+	Role             Role
+	CreatedTimestamp time.Time
+}
+
+// BudgetInvitation invites whoever has its token to become a member of a Budget with a role. The token itself is only
+// known when the invitation is created, and may only be used once.
+type BudgetInvitation struct {
+	ID               string
+	BudgetID         string
+	Role             Role
+	CreatedTimestamp time.Time
+	ExpiresTimestamp time.Time
+}
@@ -0,0 +1,25 @@
+package budgit_test
+
+import (
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+func (s *budgitSuite) TestRoleIncludes() {
+	testCases := []struct {
+		role, other budgit.Role
+		includes    bool
+	}{
+		{role: budgit.RoleOwner, other: budgit.RoleOwner, includes: true},
+		{role: budgit.RoleOwner, other: budgit.RoleViewer, includes: true},
+		{role: budgit.RoleEditor, other: budgit.RoleEditor, includes: true},
+		{role: budgit.RoleEditor, other: budgit.RoleOwner, includes: false},
+		{role: budgit.RoleViewer, other: budgit.RoleEditor, includes: false},
+		{role: "admin", other: budgit.RoleViewer, includes: false},
+		{role: "", other: "", includes: false},
+	}
+	for _, tc := range testCases {
+		s.Run(string(tc.role)+"/"+string(tc.other), func() {
+			s.Equal(tc.includes, tc.role.Includes(tc.other))
+		})
+	}
+}
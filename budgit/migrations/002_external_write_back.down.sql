@@ -0,0 +1 @@
+ALTER TABLE accounts DROP COLUMN external_write_back;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Whether changes to imported transactions are written back to the linked external account. Optional.
+ALTER TABLE accounts ADD COLUMN external_write_back BOOLEAN;
@@ -0,0 +1,18 @@
+CREATE TABLE
+  attachments (
+    request_id TEXT PRIMARY KEY,
+    valid_from_timestamp TIMESTAMPTZ,
+    valid_to_timestamp TIMESTAMPTZ,
+
+    id TEXT NOT NULL,
+    transaction_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    -- The ID of the attachment in the integration it was imported from. Optional.
+    external_id TEXT
+  );
+
+CREATE INDEX attachments_request_id_idx ON attachments (request_id);
+CREATE INDEX attachments_id_idx ON attachments (id) WHERE valid_to_timestamp = 'infinity';
+CREATE INDEX attachments_transaction_id_idx ON attachments (transaction_id) WHERE valid_to_timestamp = 'infinity';
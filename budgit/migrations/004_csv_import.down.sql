@@ -0,0 +1,6 @@
+DROP TABLE csv_profiles;
+
+DROP INDEX transactions_account_import_id_idx;
+
+ALTER TABLE transactions DROP COLUMN import_id;
+ALTER TABLE transactions DROP COLUMN memo;
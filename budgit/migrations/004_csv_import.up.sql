@@ -0,0 +1,30 @@
+-- The memo of a transaction, and the ID of the row it was imported from, used to skip rows already imported. Optional.
+ALTER TABLE transactions ADD COLUMN memo TEXT;
+ALTER TABLE transactions ADD COLUMN import_id TEXT;
+
+CREATE INDEX transactions_account_import_id_idx ON transactions (account_id, import_id) WHERE valid_to_timestamp = 'infinity';
+
+CREATE TABLE
+  csv_profiles (
+    request_id TEXT PRIMARY KEY,
+    valid_from_timestamp TIMESTAMPTZ,
+    valid_to_timestamp TIMESTAMPTZ,
+
+    account_id TEXT NOT NULL,
+    delimiter TEXT,
+    has_header BOOLEAN,
+    skip_rows BIGINT,
+    date_column TEXT NOT NULL,
+    date_format TEXT,
+    -- Columns are optional, but either amount_column or at least one of debit_column and credit_column is set.
+    payee_column TEXT,
+    memo_column TEXT,
+    amount_column TEXT,
+    debit_column TEXT,
+    credit_column TEXT,
+    negate_amounts BOOLEAN,
+    decimal_comma BOOLEAN
+  );
+
+CREATE INDEX csv_profiles_request_id_idx ON csv_profiles (request_id);
+CREATE INDEX csv_profiles_account_id_idx ON csv_profiles (account_id) WHERE valid_to_timestamp = 'infinity';
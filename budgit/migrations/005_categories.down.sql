@@ -0,0 +1,6 @@
+ALTER TABLE transactions DROP COLUMN split_id;
+ALTER TABLE transactions DROP COLUMN category_id;
+
+DROP TABLE categories;
+
+DROP TABLE category_groups;
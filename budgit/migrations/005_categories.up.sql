@@ -0,0 +1,30 @@
+CREATE TABLE
+  category_groups (
+    request_id TEXT PRIMARY KEY,
+    valid_from_timestamp TIMESTAMPTZ,
+    valid_to_timestamp TIMESTAMPTZ,
+
+    id TEXT NOT NULL,
+    name TEXT NOT NULL
+  );
+
+CREATE INDEX category_groups_request_id_idx ON category_groups (request_id);
+CREATE INDEX category_groups_id_idx ON category_groups (id) WHERE valid_to_timestamp = 'infinity';
+
+CREATE TABLE
+  categories (
+    request_id TEXT PRIMARY KEY,
+    valid_from_timestamp TIMESTAMPTZ,
+    valid_to_timestamp TIMESTAMPTZ,
+
+    id TEXT NOT NULL,
+    group_id TEXT NOT NULL,
+    name TEXT NOT NULL
+  );
+
+CREATE INDEX categories_request_id_idx ON categories (request_id);
+CREATE INDEX categories_id_idx ON categories (id) WHERE valid_to_timestamp = 'infinity';
+
+-- The category of a transaction, and the ID shared by the transactions a split transaction is divided into. Optional.
+ALTER TABLE transactions ADD COLUMN category_id TEXT;
+ALTER TABLE transactions ADD COLUMN split_id TEXT;
@@ -0,0 +1,15 @@
+CREATE TABLE
+  assignments (
+    request_id TEXT PRIMARY KEY,
+    valid_from_timestamp TIMESTAMPTZ,
+    valid_to_timestamp TIMESTAMPTZ,
+
+    id TEXT NOT NULL,
+    category_id TEXT NOT NULL,
+    month DATE NOT NULL,
+    amount BIGINT NOT NULL
+  );
+
+CREATE INDEX assignments_request_id_idx ON assignments (request_id);
+CREATE INDEX assignments_id_idx ON assignments (id) WHERE valid_to_timestamp = 'infinity';
+CREATE INDEX assignments_month_idx ON assignments (month) WHERE valid_to_timestamp = 'infinity';
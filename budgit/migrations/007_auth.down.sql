@@ -0,0 +1,12 @@
+ALTER TABLE assignments DROP COLUMN created_by;
+ALTER TABLE categories DROP COLUMN created_by;
+ALTER TABLE category_groups DROP COLUMN created_by;
+ALTER TABLE csv_profiles DROP COLUMN created_by;
+ALTER TABLE attachments DROP COLUMN created_by;
+ALTER TABLE transactions DROP COLUMN created_by;
+ALTER TABLE payees DROP COLUMN created_by;
+ALTER TABLE accounts DROP COLUMN created_by;
+
+DROP TABLE api_tokens;
+DROP TABLE sessions;
+DROP TABLE users;
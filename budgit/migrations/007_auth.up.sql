@@ -0,0 +1,47 @@
+-- Users, and the sessions and API tokens they authenticate with. Unlike the budget, these are not versioned, so that
+-- password hashes and TOTP secrets are not kept once replaced, and tokens are forgotten once they expire or are revoked.
+CREATE TABLE
+  users (
+    id TEXT PRIMARY KEY,
+    username TEXT NOT NULL UNIQUE,
+    -- An argon2id hash, in the PHC string format.
+    password_hash TEXT NOT NULL,
+    -- The base32 secret of the TOTP second factor. Optional.
+    totp_secret TEXT,
+    created_timestamp TIMESTAMPTZ NOT NULL
+  );
+
+CREATE TABLE
+  sessions (
+    -- The SHA-256 hash of the session token, which itself is not stored.
+    token_hash TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_timestamp TIMESTAMPTZ NOT NULL,
+    expires_timestamp TIMESTAMPTZ NOT NULL
+  );
+
+CREATE INDEX sessions_user_id_idx ON sessions (user_id);
+
+CREATE TABLE
+  api_tokens (
+    id TEXT PRIMARY KEY,
+    -- The SHA-256 hash of the token, which itself is not stored.
+    token_hash TEXT NOT NULL UNIQUE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_timestamp TIMESTAMPTZ NOT NULL,
+    last_used_timestamp TIMESTAMPTZ
+  );
+
+CREATE INDEX api_tokens_user_id_idx ON api_tokens (user_id);
+
+-- The principal which wrote each version of the budget, the ID of a User or "local" for the command line run with
+-- direct access to the database. Null for versions written before authentication.
+ALTER TABLE accounts ADD COLUMN created_by TEXT;
+ALTER TABLE payees ADD COLUMN created_by TEXT;
+ALTER TABLE transactions ADD COLUMN created_by TEXT;
+ALTER TABLE attachments ADD COLUMN created_by TEXT;
+ALTER TABLE csv_profiles ADD COLUMN created_by TEXT;
+ALTER TABLE category_groups ADD COLUMN created_by TEXT;
+ALTER TABLE categories ADD COLUMN created_by TEXT;
+ALTER TABLE assignments ADD COLUMN created_by TEXT;
@@ -0,0 +1,43 @@
+DROP POLICY assignments_budget_policy ON assignments;
+ALTER TABLE assignments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE assignments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE assignments DROP COLUMN budget_id;
+
+DROP POLICY csv_profiles_budget_policy ON csv_profiles;
+ALTER TABLE csv_profiles NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE csv_profiles DISABLE ROW LEVEL SECURITY;
+ALTER TABLE csv_profiles DROP COLUMN budget_id;
+
+DROP POLICY attachments_budget_policy ON attachments;
+ALTER TABLE attachments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE attachments DISABLE ROW LEVEL SECURITY;
+ALTER TABLE attachments DROP COLUMN budget_id;
+
+DROP POLICY transactions_budget_policy ON transactions;
+ALTER TABLE transactions NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE transactions DISABLE ROW LEVEL SECURITY;
+ALTER TABLE transactions DROP COLUMN budget_id;
+
+DROP POLICY categories_budget_policy ON categories;
+ALTER TABLE categories NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE categories DISABLE ROW LEVEL SECURITY;
+ALTER TABLE categories DROP COLUMN budget_id;
+
+DROP POLICY category_groups_budget_policy ON category_groups;
+ALTER TABLE category_groups NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE category_groups DISABLE ROW LEVEL SECURITY;
+ALTER TABLE category_groups DROP COLUMN budget_id;
+
+DROP POLICY payees_budget_policy ON payees;
+ALTER TABLE payees NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE payees DISABLE ROW LEVEL SECURITY;
+ALTER TABLE payees DROP COLUMN budget_id;
+
+DROP POLICY accounts_budget_policy ON accounts;
+ALTER TABLE accounts NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE accounts DISABLE ROW LEVEL SECURITY;
+ALTER TABLE accounts DROP COLUMN budget_id;
+
+DROP TABLE budget_invitations;
+DROP TABLE budget_members;
+DROP TABLE budgets;
@@ -0,0 +1,140 @@
+-- Budgets, and the Users who are members of them. Each member has a role: owners manage the budget and its members,
+-- editors write to the budget, and viewers only read it.
+CREATE TABLE
+  budgets (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_timestamp TIMESTAMPTZ NOT NULL
+  );
+
+CREATE TABLE
+  budget_members (
+    budget_id TEXT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_timestamp TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (budget_id, user_id)
+  );
+
+CREATE INDEX budget_members_user_id_idx ON budget_members (user_id);
+
+CREATE TABLE
+  budget_invitations (
+    id TEXT PRIMARY KEY,
+    -- The SHA-256 hash of the invitation token, which itself is not stored.
+    token_hash TEXT NOT NULL UNIQUE,
+    budget_id TEXT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    -- The role the User accepting the invitation becomes a member with.
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_by TEXT NOT NULL,
+    created_timestamp TIMESTAMPTZ NOT NULL,
+    expires_timestamp TIMESTAMPTZ NOT NULL
+  );
+
+CREATE INDEX budget_invitations_budget_id_idx ON budget_invitations (budget_id);
+
+-- The budget written before budgets were shared, which every existing User owns.
+INSERT INTO budgets (id, name, created_timestamp) VALUES ('default', 'Budget', NOW());
+INSERT INTO budget_members (budget_id, user_id, role, created_timestamp)
+SELECT 'default', id, 'owner', NOW() FROM users;
+
+-- Every row of a budget table belongs to a budget. Queries run with the budget they may access set as the
+-- budgit.budget_id setting of their transaction, which rows are inserted with by default.
+ALTER TABLE accounts ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE accounts SET budget_id = 'default';
+ALTER TABLE accounts ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE accounts ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX accounts_budget_id_idx ON accounts (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE payees ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE payees SET budget_id = 'default';
+ALTER TABLE payees ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE payees ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX payees_budget_id_idx ON payees (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE category_groups ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE category_groups SET budget_id = 'default';
+ALTER TABLE category_groups ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE category_groups ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX category_groups_budget_id_idx ON category_groups (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE categories ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE categories SET budget_id = 'default';
+ALTER TABLE categories ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE categories ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX categories_budget_id_idx ON categories (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE transactions ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE transactions SET budget_id = 'default';
+ALTER TABLE transactions ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE transactions ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX transactions_budget_id_idx ON transactions (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE attachments ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE attachments SET budget_id = 'default';
+ALTER TABLE attachments ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE attachments ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX attachments_budget_id_idx ON attachments (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE csv_profiles ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE csv_profiles SET budget_id = 'default';
+ALTER TABLE csv_profiles ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE csv_profiles ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX csv_profiles_budget_id_idx ON csv_profiles (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+ALTER TABLE assignments ADD COLUMN budget_id TEXT REFERENCES budgets (id);
+UPDATE assignments SET budget_id = 'default';
+ALTER TABLE assignments ALTER COLUMN budget_id SET NOT NULL;
+ALTER TABLE assignments ALTER COLUMN budget_id SET DEFAULT current_setting('budgit.budget_id');
+CREATE INDEX assignments_budget_id_idx ON assignments (budget_id) WHERE valid_to_timestamp = 'infinity';
+
+-- Row-level security is defence in depth: queries filter by budget themselves, but a query which does not still cannot
+-- read or write rows of another budget. Policies do not apply to superusers or roles with BYPASSRLS, so budgit must
+-- connect as a role which is neither for them to take effect. Without the setting, no rows are visible.
+ALTER TABLE accounts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE accounts FORCE ROW LEVEL SECURITY;
+CREATE POLICY accounts_budget_policy ON accounts
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE payees ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payees FORCE ROW LEVEL SECURITY;
+CREATE POLICY payees_budget_policy ON payees
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE category_groups ENABLE ROW LEVEL SECURITY;
+ALTER TABLE category_groups FORCE ROW LEVEL SECURITY;
+CREATE POLICY category_groups_budget_policy ON category_groups
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE categories FORCE ROW LEVEL SECURITY;
+CREATE POLICY categories_budget_policy ON categories
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE transactions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE transactions FORCE ROW LEVEL SECURITY;
+CREATE POLICY transactions_budget_policy ON transactions
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE attachments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attachments FORCE ROW LEVEL SECURITY;
+CREATE POLICY attachments_budget_policy ON attachments
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE csv_profiles ENABLE ROW LEVEL SECURITY;
+ALTER TABLE csv_profiles FORCE ROW LEVEL SECURITY;
+CREATE POLICY csv_profiles_budget_policy ON csv_profiles
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
+
+ALTER TABLE assignments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE assignments FORCE ROW LEVEL SECURITY;
+CREATE POLICY assignments_budget_policy ON assignments
+  USING (budget_id = current_setting('budgit.budget_id', TRUE))
+  WITH CHECK (budget_id = current_setting('budgit.budget_id', TRUE));
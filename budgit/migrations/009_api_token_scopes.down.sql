@@ -0,0 +1,5 @@
+DROP INDEX api_tokens_budget_id_idx;
+
+ALTER TABLE api_tokens DROP COLUMN expires_timestamp;
+ALTER TABLE api_tokens DROP COLUMN scopes;
+ALTER TABLE api_tokens DROP COLUMN budget_id;
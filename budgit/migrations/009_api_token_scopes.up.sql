@@ -0,0 +1,16 @@
+-- API tokens are scoped to a budget and to the capabilities of their scopes, and may expire.
+--
+-- The budget is null for tokens created before tokens were scoped, which may be used for every budget of their User.
+ALTER TABLE api_tokens ADD COLUMN budget_id TEXT REFERENCES budgets (id) ON DELETE CASCADE;
+
+-- The scopes are separated by spaces, as the scope of an OAuth 2.0 token is. Tokens created before tokens were scoped
+-- keep every scope there was, so that they work as they did.
+ALTER TABLE api_tokens ADD COLUMN scopes TEXT;
+UPDATE api_tokens
+SET scopes = 'accounts:read accounts:write accounts:sync payees:read payees:write transactions:read transactions:write categories:read categories:write budgets:read budgets:manage tokens:manage';
+ALTER TABLE api_tokens ALTER COLUMN scopes SET NOT NULL;
+
+-- Null if the token does not expire.
+ALTER TABLE api_tokens ADD COLUMN expires_timestamp TIMESTAMPTZ;
+
+CREATE INDEX api_tokens_budget_id_idx ON api_tokens (budget_id);
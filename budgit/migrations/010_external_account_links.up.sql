@@ -0,0 +1,26 @@
+-- The external accounts linked to Accounts of each budget. An external account belongs to the budgets it is linked in,
+-- so it may only be linked or read in another budget by Users who are members of all of them. Links outlive the Accounts
+-- linked, so that an external account stays claimed once an Account linked to it is closed.
+CREATE TABLE
+  external_account_links (
+    integration_id TEXT NOT NULL,
+    external_account_id TEXT NOT NULL,
+    budget_id TEXT NOT NULL REFERENCES budgets (id) ON DELETE CASCADE,
+    created_by TEXT,
+    created_timestamp TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (integration_id, external_account_id, budget_id)
+  );
+
+-- Row-level security is forced on accounts, so even their owner sees none of them without a budget set. It is lifted
+-- for the owner, which applies the migrations, while the links of every budget are copied.
+ALTER TABLE accounts NO FORCE ROW LEVEL SECURITY;
+
+INSERT INTO external_account_links (integration_id, external_account_id, budget_id, created_by, created_timestamp)
+SELECT external_integration_id, external_id, budget_id, created_by, valid_from_timestamp
+FROM accounts
+WHERE valid_to_timestamp = 'infinity'
+AND external_id IS NOT NULL
+AND external_integration_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE accounts FORCE ROW LEVEL SECURITY;
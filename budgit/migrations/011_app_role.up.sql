@@ -0,0 +1,22 @@
+-- budgit_app is the role budgit runs as. Row-level security does not apply to superusers or roles with BYPASSRLS, so
+-- budgit must not run as the role which owns the tables and applies the migrations, see docker/README.md.
+--
+-- The role is created without a password if it does not exist yet. Deployments either create it beforehand, as the
+-- Docker Compose setup does, or give it one with ALTER ROLE budgit_app LOGIN PASSWORD '...'.
+DO $$
+BEGIN
+  IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'budgit_app') THEN
+    CREATE ROLE budgit_app NOLOGIN;
+  END IF;
+END
+$$;
+
+ALTER ROLE budgit_app NOSUPERUSER NOBYPASSRLS NOCREATEDB NOCREATEROLE;
+
+GRANT USAGE ON SCHEMA public TO budgit_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO budgit_app;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO budgit_app;
+
+-- Tables of later migrations, which run as the same role, are granted too.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO budgit_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO budgit_app;
@@ -0,0 +1 @@
+ALTER TABLE transactions DROP COLUMN import_integration_id;
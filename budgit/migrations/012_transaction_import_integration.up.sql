@@ -0,0 +1,3 @@
+-- The integration a transaction was imported from, when it was imported from the external account linked to its
+-- account rather than from a file. Only those are written back to their external account. Optional.
+ALTER TABLE transactions ADD COLUMN import_integration_id TEXT;
@@ -0,0 +1,94 @@
+package quickadd
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Match returns the indexes of the candidate names best matching a name, which is matched fuzzily so that names may
+// be abbreviated, written in any case, without punctuation or with small typos. No indexes are returned if no
+// candidate matches, and several if more than one matches equally well.
+//
+// From best to worst, a candidate matches if it is:
+//   - the name, ignoring case and any characters other than letters and digits;
+//   - the name followed by more, such as "Pret A Manger" for "pret";
+//   - made of words starting with each word of the name, such as "Everyday:Eating Out" for "eating-out";
+//   - within a few typos of the name, fewer typos matching better.
+func Match(name string, candidates []string) []int {
+	query, queryWords := normalize(name)
+	if query == "" {
+		return nil
+	}
+	maxDistance := len([]rune(query)) / 4
+
+	var best []int
+	bestScore := -1
+	for i, candidate := range candidates {
+		normalized, words := normalize(candidate)
+		score := -1
+		switch {
+		case normalized == query:
+			score = 0
+		case strings.HasPrefix(normalized, query):
+			score = 1
+		case hasWordPrefixes(words, queryWords):
+			score = 2
+		default:
+			if distance := levenshtein(query, normalized); distance <= maxDistance {
+				score = 3 + distance
+			}
+		}
+		switch {
+		case score < 0:
+		case bestScore < 0 || score < bestScore:
+			best, bestScore = []int{i}, score
+		case score == bestScore:
+			best = append(best, i)
+		}
+	}
+	return best
+}
+
+// normalize returns a name in lower case without characters other than letters and digits, and its words.
+func normalize(name string) (string, []string) {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, ""), words
+}
+
+// hasWordPrefixes returns whether each of the prefixes starts a different word, in order.
+func hasWordPrefixes(words, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return false
+	}
+	next := 0
+	for _, word := range words {
+		if next < len(prefixes) && strings.HasPrefix(word, prefixes[next]) {
+			next++
+		}
+	}
+	return next == len(prefixes)
+}
+
+// levenshtein returns the number of single character insertions, deletions and substitutions between two strings.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
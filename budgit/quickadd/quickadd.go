@@ -0,0 +1,232 @@
+// Package quickadd parses transactions written in a short, natural form for quick entry, such as
+// "coffee 3.20 @Pret yesterday from Starling personal #eating-out", and fuzzily matches the names they give against
+// those of the budget.
+//
+// An entry is made of words in any order:
+//
+//   - an amount, such as "3.20" or "£3.20", spent unless written with a leading "+", such as "+1500" for income;
+//   - "@" and a payee, such as "@Pret", quoted if it is several words, such as `@"Pret A Manger"`;
+//   - "#" and a category, such as "#eating-out";
+//   - "from" and the Account, such as "from Starling personal", running until the next of these words;
+//   - a date: "today", "yesterday", a weekday meaning the last one on or before today, or a date written YYYY-MM-DD.
+//
+// Every other word is the memo.
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// Entry is a transaction parsed from a quick-add input, with the names it gives yet to be matched against the budget.
+type Entry struct {
+	Date   time.Time
+	Amount budgit.BalanceAmount
+	// Payee is the name of the payee, empty if none is given.
+	Payee string
+	// Account is the name of the Account, empty if none is given.
+	Account string
+	// Category is the name or category path of the Category, empty if none is given.
+	Category string
+	Memo     string
+}
+
+var (
+	ErrNoAmount       = errors.New("an amount is required, such as 3.20")
+	ErrSeveralAmounts = errors.New("only one amount may be given")
+	ErrUnclosedQuote  = errors.New("a quote is not closed")
+)
+
+// MissingNameError is returned when a marker, such as "@" or "from", is not followed by a name.
+type MissingNameError struct {
+	Marker string
+}
+
+func (e MissingNameError) Error() string {
+	return fmt.Sprintf("%q must be followed by a name", e.Marker)
+}
+
+// Parse parses a quick-add input, resolving relative dates against today. The entry is dated today if no date is
+// given.
+func Parse(input string, today time.Time) (*Entry, error) {
+	words, err := split(input)
+	if err != nil {
+		return nil, err
+	}
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	entry := &Entry{Date: today}
+	var memo []string
+	amountGiven := false
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		lower := strings.ToLower(word.text)
+		switch {
+		case word.quoted:
+			memo = append(memo, word.text)
+		case strings.HasPrefix(word.text, "@"):
+			name, next := markedName(words, i, "@")
+			if name == "" {
+				return nil, MissingNameError{Marker: "@"}
+			}
+			entry.Payee, i = name, next
+		case strings.HasPrefix(word.text, "#"):
+			name, next := markedName(words, i, "#")
+			if name == "" {
+				return nil, MissingNameError{Marker: "#"}
+			}
+			entry.Category, i = name, next
+		case lower == "from":
+			name, next := accountName(words, i+1)
+			if name == "" {
+				return nil, MissingNameError{Marker: word.text}
+			}
+			entry.Account, i = name, next
+		default:
+			if amount, ok := parseAmount(word.text); ok {
+				if amountGiven {
+					return nil, ErrSeveralAmounts
+				}
+				entry.Amount, amountGiven = amount, true
+				continue
+			}
+			if date, ok := parseDate(lower, today); ok {
+				entry.Date = date
+				continue
+			}
+			memo = append(memo, word.text)
+		}
+	}
+	if !amountGiven {
+		return nil, ErrNoAmount
+	}
+	entry.Memo = strings.Join(memo, " ")
+	return entry, nil
+}
+
+// word is a word of an input, or a quoted phrase.
+type word struct {
+	text   string
+	quoted bool
+}
+
+// split splits an input into words, keeping phrases in double quotes together. A marker directly before a quote, such
+// as `@"Pret A Manger"`, is kept as a word of its own followed by the quoted phrase.
+func split(input string) ([]word, error) {
+	var words []word
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			words = append(words, word{text: current.String()})
+			current.Reset()
+		}
+	}
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '"':
+			flush()
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, ErrUnclosedQuote
+			}
+			words = append(words, word{text: string(runes[i+1 : end]), quoted: true})
+			i = end
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return words, nil
+}
+
+// markedName returns the name marked by a marker at words[i], either the rest of the word or, for a lone marker, the
+// quoted phrase after it, and the index of the last word of the name.
+func markedName(words []word, i int, marker string) (string, int) {
+	if name := strings.TrimPrefix(words[i].text, marker); name != "" {
+		return name, i
+	}
+	if i+1 < len(words) && words[i+1].quoted {
+		return words[i+1].text, i + 1
+	}
+	return "", i
+}
+
+// accountName returns the name of an Account starting at words[i], running until the next word which is not part of a
+// name, and the index of its last word.
+func accountName(words []word, i int) (string, int) {
+	if i < len(words) && words[i].quoted {
+		return words[i].text, i
+	}
+	var name []string
+	for ; i < len(words); i++ {
+		if words[i].quoted || isReserved(words[i].text) {
+			break
+		}
+		name = append(name, words[i].text)
+	}
+	return strings.Join(name, " "), i - 1
+}
+
+// isReserved returns whether a word has a meaning of its own, so ends the name of an Account.
+func isReserved(text string) bool {
+	lower := strings.ToLower(text)
+	if strings.HasPrefix(text, "@") || strings.HasPrefix(text, "#") || lower == "from" {
+		return true
+	}
+	if _, ok := parseAmount(text); ok {
+		return true
+	}
+	_, ok := parseDate(lower, time.Time{})
+	return ok
+}
+
+// currencySymbols are stripped from amounts.
+const currencySymbols = "£$€"
+
+// parseAmount parses an amount, spent unless written with a leading "+".
+func parseAmount(text string) (budgit.BalanceAmount, bool) {
+	income := strings.HasPrefix(text, "+")
+	digits := strings.TrimLeft(strings.TrimPrefix(text, "+"), currencySymbols)
+	if digits == "" || !unicode.IsDigit([]rune(digits)[0]) {
+		return 0, false
+	}
+	amount, err := budgit.ParseBalanceAmount(strings.ReplaceAll(digits, ",", ""))
+	if err != nil {
+		return 0, false
+	}
+	if income {
+		return amount, true
+	}
+	return -amount, true
+}
+
+// parseDate parses a date, resolving relative dates against today.
+func parseDate(lower string, today time.Time) (time.Time, bool) {
+	switch lower {
+	case "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if lower == name {
+			return today.AddDate(0, 0, -((int(today.Weekday()) - int(day) + 7) % 7)), true
+		}
+	}
+	if date, err := time.Parse(time.DateOnly, lower); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,131 @@
+package quickadd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit/quickadd"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestQuickAdd(t *testing.T) {
+	suite.Run(t, new(quickAddSuite))
+}
+
+type quickAddSuite struct {
+	suite.Suite
+}
+
+// today is a Monday.
+var today = time.Date(2024, 6, 10, 18, 30, 0, 0, time.UTC)
+
+func june(day int) time.Time {
+	return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *quickAddSuite) TestParse() {
+	testCases := []struct {
+		name     string
+		input    string
+		expected *quickadd.Entry
+	}{
+		{
+			name:  "Everything",
+			input: "coffee 3.20 @Pret yesterday from Starling personal #eating-out",
+			expected: &quickadd.Entry{
+				Date: june(9), Amount: -320, Payee: "Pret", Account: "Starling personal", Category: "eating-out", Memo: "coffee",
+			},
+		},
+		{
+			name:     "OnlyAmount",
+			input:    "12",
+			expected: &quickadd.Entry{Date: june(10), Amount: -1200},
+		},
+		{
+			name:     "Income",
+			input:    "+1,500.00 @Employer June pay",
+			expected: &quickadd.Entry{Date: june(10), Amount: 150000, Payee: "Employer", Memo: "June pay"},
+		},
+		{
+			name:     "CurrencySymbol",
+			input:    "£4.50 lunch",
+			expected: &quickadd.Entry{Date: june(10), Amount: -450, Memo: "lunch"},
+		},
+		{
+			name:     "QuotedNames",
+			input:    `@"Pret A Manger" from "Joint account" 3.20 "at the station"`,
+			expected: &quickadd.Entry{Date: june(10), Amount: -320, Payee: "Pret A Manger", Account: "Joint account", Memo: "at the station"},
+		},
+		{
+			name:     "AccountEndsAtDate",
+			input:    "from Current account friday 20 #groceries",
+			expected: &quickadd.Entry{Date: june(7), Amount: -2000, Account: "Current account", Category: "groceries"},
+		},
+		{
+			name:     "WeekdayIsTodayOrBefore",
+			input:    "monday 1 and tuesday",
+			expected: &quickadd.Entry{Date: june(4), Amount: -100, Memo: "and"},
+		},
+		{
+			name:     "Date",
+			input:    "2024-05-31 @Landlord 1250 rent",
+			expected: &quickadd.Entry{Date: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), Amount: -125000, Payee: "Landlord", Memo: "rent"},
+		},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			entry, err := quickadd.Parse(tc.input, today)
+			s.Require().NoError(err)
+			s.CMPEqual(tc.expected, entry)
+		})
+	}
+}
+
+func (s *quickAddSuite) TestParseErrors() {
+	testCases := []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{name: "NoAmount", input: "coffee @Pret", expected: quickadd.ErrNoAmount},
+		{name: "SeveralAmounts", input: "coffee 3.20 4.10", expected: quickadd.ErrSeveralAmounts},
+		{name: "UnclosedQuote", input: `3.20 @"Pret`, expected: quickadd.ErrUnclosedQuote},
+		{name: "NoPayee", input: "3.20 @", expected: quickadd.MissingNameError{Marker: "@"}},
+		{name: "NoAccount", input: "3.20 from #eating-out", expected: quickadd.MissingNameError{Marker: "from"}},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			_, err := quickadd.Parse(tc.input, today)
+			s.ErrorIs(err, tc.expected)
+		})
+	}
+}
+
+func (s *quickAddSuite) TestMatch() {
+	candidates := []string{"Pret A Manger", "Starling - Personal", "Starling - Joint", "Everyday:Eating Out", "Tesco", "Tesco Express"}
+	testCases := []struct {
+		name     string
+		expected []int
+	}{
+		{name: "tesco", expected: []int{4}},
+		{name: "pret", expected: []int{0}},
+		{name: "Starling personal", expected: []int{1}},
+		{name: "starling", expected: []int{1, 2}},
+		{name: "eating-out", expected: []int{3}},
+		{name: "Tescoo Express", expected: []int{5}},
+		{name: "Sainsbury's", expected: nil},
+		{name: "--", expected: nil},
+	}
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.Equal(tc.expected, quickadd.Match(tc.name, candidates))
+		})
+	}
+}
+
+func (s *quickAddSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
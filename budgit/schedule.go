@@ -0,0 +1,16 @@
+package budgit
+
+import "time"
+
+// ScheduledPayment is a recurring payment from an ExternalAccount, such as a standing order or direct debit.
+type ScheduledPayment struct {
+	ID                string
+	ExternalAccountID string
+	IntegrationID     string
+	PayeeName         string
+	Reference         string
+	Amount            BalanceAmount
+	Frequency         string
+	// NextDate is zero if the integration does not know when the payment is next due.
+	NextDate time.Time
+}
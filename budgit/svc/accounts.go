@@ -21,23 +21,9 @@ type AccountDB interface {
 func (s Service) CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error) {
 	var createdAccounts []*budgit.Account
 	err := s.inTx(ctx, func(conn Conn) error {
-		now, err := s.db.Now(ctx, conn)
-		if err != nil {
-			return err
-		}
-
-		dbAccounts := dbconvert.FromAccounts(accounts...)
-		for _, dbAccount := range dbAccounts {
-			dbAccount.ValidFromTimestamp = now
-			dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
-		}
-
-		// TODO: check for accounts not being inserted
-		if _, err := s.db.InsertAccounts(ctx, conn, dbAccounts...); err != nil {
-			return err
-		}
-		createdAccounts = accounts
-		return nil
+		var err error
+		createdAccounts, err = s.createAccounts(ctx, conn, accounts...)
+		return err
 	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
 	if err != nil {
 		return nil, fmt.Errorf("creating accounts: %w", err)
@@ -45,8 +31,32 @@ func (s Service) CreateAccounts(ctx context.Context, accounts ...*budgit.Account
 	return createdAccounts, nil
 }
 
+// createAccounts creates Accounts within a transaction, see CreateAccounts.
+func (s Service) createAccounts(ctx context.Context, conn Conn, accounts ...*budgit.Account) ([]*budgit.Account, error) {
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	dbAccounts := dbconvert.FromAccounts(accounts...)
+	for _, dbAccount := range dbAccounts {
+		dbAccount.RequestID = newRequestID()
+		dbAccount.CreatedBy = createdBy(ctx)
+		dbAccount.ValidFromTimestamp = now
+		dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	// TODO: check for accounts not being inserted
+	if _, err := s.db.InsertAccounts(ctx, conn, dbAccounts...); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 func (s Service) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
-	accounts, err := s.db.SelectAccounts(ctx, s.conn)
+	accounts, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Account, error) {
+		return s.db.SelectAccounts(ctx, conn)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("listing accounts: %w", err)
 	}
@@ -0,0 +1,123 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/exp/maps"
+)
+
+type AssignmentDB interface {
+	InsertAssignments(ctx context.Context, queryer db.Queryer, assignments ...*db.Assignment) ([]string, error)
+	UpdateAssignmentValidToTimestamps(ctx context.Context, queryer db.Queryer, updates ...db.ValidToTimestampUpdate) ([]string, error)
+	SelectAssignmentsByMonth(ctx context.Context, queryer db.Queryer, months ...time.Time) ([]*db.Assignment, error)
+}
+
+type MissingCategoriesError struct {
+	CategoryIDs []string
+}
+
+func (e MissingCategoriesError) Error() string {
+	return fmt.Sprintf("assignments reference Categories that do not exist: %+v", e.CategoryIDs)
+}
+
+// Assign sets the amounts assigned to Categories for months, replacing any amount already assigned to a Category for
+// the same month, whose ID is kept. Months are truncated to their first day.
+func (s Service) Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	var assigned []*budgit.Assignment
+	err := s.inTx(ctx, func(conn Conn) error {
+		var err error
+		assigned, err = s.assign(ctx, conn, assignments...)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("assigning to categories: %w", err)
+	}
+	return assigned, nil
+}
+
+// assign sets the amounts assigned to Categories within a transaction, see Assign.
+func (s Service) assign(ctx context.Context, conn Conn, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	categoryIDs, months := make([]string, 0, len(assignments)), make([]time.Time, 0, len(assignments))
+	for _, assignment := range assignments {
+		assignment.Month = firstOfMonth(assignment.Month)
+		categoryIDs = append(categoryIDs, assignment.CategoryID)
+		months = append(months, assignment.Month)
+	}
+	uniqueCategoryIDs := deduplicate(categoryIDs)
+	foundCategories, err := s.db.SelectCategoriesByID(ctx, conn, uniqueCategoryIDs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(foundCategories) < len(uniqueCategoryIDs) {
+		return nil, MissingCategoriesError{CategoryIDs: symmetricDifference(uniqueCategoryIDs, maps.Keys(foundCategories))}
+	}
+
+	type categoryMonth struct {
+		categoryID string
+		month      time.Time
+	}
+	existing, err := s.db.SelectAssignmentsByMonth(ctx, conn, deduplicate(months)...)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs := make(map[categoryMonth]string, len(existing))
+	for _, assignment := range dbconvert.ToAssignments(existing...) {
+		existingIDs[categoryMonth{assignment.CategoryID, assignment.Month}] = assignment.ID
+	}
+
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := []db.ValidToTimestampUpdate{}
+	for _, assignment := range assignments {
+		if id, ok := existingIDs[categoryMonth{assignment.CategoryID, assignment.Month}]; ok {
+			assignment.ID = id
+			updates = append(updates, db.ValidToTimestampUpdate{ID: pgtype.Text{String: id, Valid: true}, ValidToTimestamp: now})
+		} else if assignment.ID == "" {
+			assignment.ID = uuid.New().String()
+		}
+	}
+	if len(updates) != 0 {
+		if _, err := s.db.UpdateAssignmentValidToTimestamps(ctx, conn, updates...); err != nil {
+			return nil, err
+		}
+	}
+
+	dbAssignments := dbconvert.FromAssignments(assignments...)
+	for _, dbAssignment := range dbAssignments {
+		dbAssignment.RequestID = newRequestID()
+		dbAssignment.CreatedBy = createdBy(ctx)
+		dbAssignment.ValidFromTimestamp = now
+		dbAssignment.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	if _, err := s.db.InsertAssignments(ctx, conn, dbAssignments...); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// ListAssignments returns the amounts assigned to Categories for a month.
+func (s Service) ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error) {
+	assignments, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Assignment, error) {
+		return s.db.SelectAssignmentsByMonth(ctx, conn, firstOfMonth(month))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing assignments for %s: %w", month.Format("January 2006"), err)
+	}
+	return dbconvert.ToAssignments(assignments...), nil
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
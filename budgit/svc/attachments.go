@@ -0,0 +1,182 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type AttachmentDB interface {
+	InsertAttachments(ctx context.Context, queryer db.Queryer, attachments ...*db.Attachment) ([]string, error)
+	SelectAttachmentsByTransaction(ctx context.Context, queryer db.Queryer, transactionID string) ([]*db.Attachment, error)
+	SelectAttachmentsByID(ctx context.Context, queryer db.Queryer, attachmentIDs ...string) (map[string]*db.Attachment, error)
+}
+
+// AttachmentStore holds the content of Attachments, by Attachment ID. Open returns an error wrapping fs.ErrNotExist
+// if no content is held for an ID.
+type AttachmentStore interface {
+	Put(ctx context.Context, id string, content io.Reader) (int64, error)
+	Open(ctx context.Context, id string) (io.ReadCloser, error)
+	Delete(ctx context.Context, id string) error
+}
+
+var (
+	ErrTransactionNotFound = fmt.Errorf("the requested Transaction does not exist")
+	ErrAttachmentNotFound  = fmt.Errorf("the requested Attachment does not exist")
+)
+
+// UploadAttachment stores a file and attaches it to a Transaction.
+func (s Service) UploadAttachment(ctx context.Context, transactionID, name, contentType string, content io.Reader) (*budgit.Attachment, error) {
+	attachment := &budgit.Attachment{
+		ID:            uuid.New().String(),
+		TransactionID: transactionID,
+		Name:          name,
+		ContentType:   contentType,
+	}
+	if err := s.createAttachment(ctx, attachment, content); err != nil {
+		return nil, fmt.Errorf("uploading attachment to transaction %q: %w", transactionID, err)
+	}
+	return attachment, nil
+}
+
+// DownloadAttachment returns an Attachment and its content, which must be closed.
+func (s Service) DownloadAttachment(ctx context.Context, attachmentID string) (*budgit.Attachment, io.ReadCloser, error) {
+	dbAttachments, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Attachment, error) {
+		return s.db.SelectAttachmentsByID(ctx, conn, attachmentID)
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, err)
+	}
+	dbAttachment, ok := dbAttachments[attachmentID]
+	if !ok {
+		return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, ErrAttachmentNotFound)
+	}
+
+	content, err := s.attachments.Open(ctx, attachmentID)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, ErrAttachmentNotFound)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, err)
+	}
+	return dbconvert.ToAttachments(dbAttachment)[0], content, nil
+}
+
+// ListAttachments returns the Attachments of a Transaction, sorted by name.
+func (s Service) ListAttachments(ctx context.Context, transactionID string) ([]*budgit.Attachment, error) {
+	dbAttachments, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Attachment, error) {
+		return s.db.SelectAttachmentsByTransaction(ctx, conn, transactionID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing attachments of transaction %q: %w", transactionID, err)
+	}
+	return dbconvert.ToAttachments(dbAttachments...), nil
+}
+
+// ImportExternalAttachments attaches the files attached to an imported transaction, in the external account linked
+// to an Account, to the Transaction it was imported as. Files already imported are skipped, so it may be called again
+// to import files attached since.
+func (s Service) ImportExternalAttachments(ctx context.Context, accountID, transactionID, externalTransactionID string) ([]*budgit.Attachment, error) {
+	externalAccount, err := s.linkedAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+	}
+	importer, err := integrationAs[AttachmentImporter](s, externalAccount.IntegrationID, CapabilityAttachmentImport)
+	if err != nil {
+		return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+	}
+	externalAttachments, err := importer.GetExternalAttachments(ctx, externalAccount.ID, externalTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+	}
+
+	existing, err := s.ListAttachments(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+	}
+	imported := make(map[string]bool, len(existing))
+	for _, attachment := range existing {
+		imported[attachment.ExternalID] = true
+	}
+
+	attachments := []*budgit.Attachment{}
+	for _, externalAttachment := range externalAttachments {
+		if imported[externalAttachment.ID] {
+			continue
+		}
+		content, contentType, err := importer.DownloadExternalAttachment(ctx, externalAccount.ID, externalAttachment)
+		if err != nil {
+			return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+		}
+		if contentType == "" {
+			contentType = externalAttachment.ContentType
+		}
+		attachment := &budgit.Attachment{
+			ID:            uuid.New().String(),
+			TransactionID: transactionID,
+			Name:          externalAttachment.Name,
+			ContentType:   contentType,
+			ExternalID:    externalAttachment.ID,
+		}
+		err = s.createAttachment(ctx, attachment, content)
+		content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("importing attachments of transaction %q: %w", transactionID, err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, nil
+}
+
+// createAttachment stores the content of an Attachment, setting its Size, then records it against its Transaction.
+// The content is removed again if the Attachment cannot be recorded.
+func (s Service) createAttachment(ctx context.Context, attachment *budgit.Attachment, content io.Reader) error {
+	transactions, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Transaction, error) {
+		return s.db.SelectTransactionsByID(ctx, conn, attachment.TransactionID)
+	})
+	if err != nil {
+		return err
+	}
+	if _, ok := transactions[attachment.TransactionID]; !ok {
+		return ErrTransactionNotFound
+	}
+
+	size, err := s.attachments.Put(ctx, attachment.ID, content)
+	if err != nil {
+		return err
+	}
+	attachment.Size = size
+
+	err = s.inTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		dbAttachment := dbconvert.FromAttachments(attachment)[0]
+		dbAttachment.RequestID = newRequestID()
+		dbAttachment.CreatedBy = createdBy(ctx)
+		dbAttachment.ValidFromTimestamp = now
+		dbAttachment.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+
+		_, err = s.db.InsertAttachments(ctx, conn, dbAttachment)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		if deleteErr := s.attachments.Delete(ctx, attachment.ID); deleteErr != nil {
+			s.log.Warnw("Deleting content of unrecorded attachment", zap.String("attachment_id", attachment.ID), zap.Error(deleteErr))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package svc_test
+
+import (
+	"io"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func (s *svcSuite) TestImportExternalTransactionsImportsAttachments() {
+	ctx := s.localContext()
+	account, external := s.createLinkedAccount(ctx, "Current", false)
+	s.starling.AddFeedItems(external.UID,
+		starlingfake.FeedItem{
+			Amount:           -320,
+			CounterPartyName: "Pret",
+			TransactionTime:  time.Now().Add(-time.Hour),
+			Attachments:      []starlingfake.Attachment{{Type: "PDF", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}},
+		},
+		starlingfake.FeedItem{
+			Amount:           -1250,
+			CounterPartyName: "Tesco",
+			TransactionTime:  time.Now().Add(-2 * time.Hour),
+		},
+	)
+
+	transactions, err := s.service.ImportExternalTransactions(ctx, account.ID, time.Now().Add(-24*time.Hour))
+	s.Require().NoError(err)
+	s.Require().Len(transactions, 2)
+
+	for _, transaction := range transactions {
+		attachments, err := s.service.ListAttachments(ctx, transaction.ID)
+		s.Require().NoError(err)
+		item := s.mustFeedItem(external, transaction.ImportID)
+		if len(item.Attachments) == 0 {
+			s.Empty(attachments, "transaction of feed item without attachments")
+			continue
+		}
+		s.CMPEqual([]*budgit.Attachment{{
+			TransactionID: transaction.ID,
+			Name:          "attachment-" + item.Attachments[0].UID.String() + ".pdf",
+			ContentType:   "application/pdf",
+			Size:          int64(len("%PDF-1.4")),
+			ExternalID:    item.Attachments[0].UID.String(),
+		}}, attachments, cmpopts.IgnoreFields(budgit.Attachment{}, "ID"))
+
+		_, content, err := s.service.DownloadAttachment(ctx, attachments[0].ID)
+		s.Require().NoError(err)
+		contents, err := io.ReadAll(content)
+		content.Close()
+		s.Require().NoError(err)
+		s.Equal("%PDF-1.4", string(contents))
+	}
+
+	s.Run("ReimportSkipsImported", func() {
+		transactions, err := s.service.ImportExternalTransactions(ctx, account.ID, time.Now().Add(-24*time.Hour))
+		s.Require().NoError(err)
+		s.Empty(transactions)
+	})
+}
@@ -0,0 +1,545 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/auth"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type AuthDB interface {
+	InsertUsers(ctx context.Context, queryer db.Queryer, users ...*db.User) ([]string, error)
+	UpdateUserPasswordHash(ctx context.Context, queryer db.Queryer, userID string, passwordHash pgtype.Text) ([]string, error)
+	UpdateUserTOTPSecret(ctx context.Context, queryer db.Queryer, userID string, secret pgtype.Text) ([]string, error)
+	SelectUsers(ctx context.Context, queryer db.Queryer) ([]*db.User, error)
+	SelectUsersByID(ctx context.Context, queryer db.Queryer, userIDs ...string) (map[string]*db.User, error)
+	SelectUsersByUsername(ctx context.Context, queryer db.Queryer, usernames ...string) (map[string]*db.User, error)
+	InsertSessions(ctx context.Context, queryer db.Queryer, sessions ...*db.Session) ([]string, error)
+	SelectSessionsByTokenHash(ctx context.Context, queryer db.Queryer, tokenHashes ...string) (map[string]*db.Session, error)
+	DeleteSessions(ctx context.Context, queryer db.Queryer, tokenHashes ...string) ([]string, error)
+	DeleteExpiredSessions(ctx context.Context, queryer db.Queryer) ([]string, error)
+	InsertAPITokens(ctx context.Context, queryer db.Queryer, tokens ...*db.APIToken) ([]string, error)
+	UpdateAPITokenLastUsedTimestamp(ctx context.Context, queryer db.Queryer, tokenID string, lastUsed pgtype.Timestamptz) ([]string, error)
+	SelectAPITokensByUser(ctx context.Context, queryer db.Queryer, userID string) ([]*db.APIToken, error)
+	SelectAPITokensByTokenHash(ctx context.Context, queryer db.Queryer, tokenHashes ...string) (map[string]*db.APIToken, error)
+	DeleteAPITokens(ctx context.Context, queryer db.Queryer, userID string, tokenIDs ...string) ([]string, error)
+}
+
+var (
+	ErrUnauthenticated    = errors.New("the operation requires authentication")
+	ErrForbidden          = errors.New("the operation is not permitted")
+	ErrInvalidCredentials = errors.New("the username, password or TOTP code is incorrect")
+	ErrTOTPRequired       = errors.New("a TOTP code is required")
+	ErrInvalidTOTPCode    = errors.New("the TOTP code is incorrect")
+	ErrUserNotFound       = errors.New("the requested User does not exist")
+	ErrUsernameTaken      = errors.New("a User with the username already exists")
+	ErrInvalidUsername    = errors.New("a username is required, without spaces at its start or end")
+	ErrPasswordTooShort   = fmt.Errorf("a password must be at least %d characters", minPasswordLength)
+	ErrAPITokenNotFound   = errors.New("the requested API token does not exist")
+	ErrInvalidScopes      = errors.New("an API token requires at least one scope, each a known scope such as accounts:read")
+	ErrInvalidExpiry      = errors.New("an API token must expire in the future")
+)
+
+const (
+	// minPasswordLength is the length of the shortest password accepted, in characters.
+	minPasswordLength = 8
+	// sessionDuration is how long a session lasts from logging in.
+	sessionDuration = 30 * 24 * time.Hour
+	// SessionTokenPrefix and APITokenPrefix prefix tokens, so that those who come across them know what they are.
+	SessionTokenPrefix = "budgit_s_"
+	APITokenPrefix     = "budgit_t_"
+	// totpIssuer names budgit in authenticator apps.
+	totpIssuer = "Budg-it"
+	// localCreatedBy records versions written by the local principal.
+	localCreatedBy = "local"
+)
+
+// Principal is who an operation of the Service runs as. Every operation runs under a Principal given by WithPrincipal,
+// which is recorded as the creator of the versions of the budget it writes.
+type Principal struct {
+	// UserID is the ID of the authenticated User, empty for the local principal.
+	UserID   string
+	Username string
+	// APITokenID is the ID of the API token the User authenticated with, empty if they logged in with a password.
+	APITokenID string
+	// APITokenBudgetID is the Budget the API token may be used for, empty if it may be used for every Budget of the
+	// User. Operations on other Budgets fail with ErrBudgetNotFound.
+	APITokenBudgetID string
+	// Scopes are the scopes of the API token, which the API checks before each operation, see HasScope.
+	Scopes []budgit.Scope
+}
+
+// LocalPrincipal is the principal of the command line, which has direct access to the database so needs no login. It
+// may manage every User.
+var LocalPrincipal = Principal{}
+
+// IsLocal returns whether the Principal is the LocalPrincipal.
+func (p Principal) IsLocal() bool {
+	return p.UserID == ""
+}
+
+// HasScope returns whether the Principal may use operations needing a scope. Only Principals authenticated by an API
+// token are limited by scopes.
+func (p Principal) HasScope(scope budgit.Scope) bool {
+	return p.APITokenID == "" || slices.Contains(p.Scopes, scope)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context running operations of the Service under a Principal.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the Principal operations run under in a context, if it has one.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// createdBy returns what versions of the budget written under the Principal of a context record as their creator.
+func createdBy(ctx context.Context) pgtype.Text {
+	principal, _ := PrincipalFrom(ctx)
+	if principal.IsLocal() {
+		return pgtype.Text{String: localCreatedBy, Valid: true}
+	}
+	return pgtype.Text{String: principal.UserID, Valid: true}
+}
+
+// authenticatedConn refuses to query the database in contexts without a Principal, so that every operation of the
+// Service runs under one. Transactions are begun through it, so are refused too.
+type authenticatedConn struct {
+	TxConn
+}
+
+func (c authenticatedConn) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if _, ok := PrincipalFrom(ctx); !ok {
+		return nil, ErrUnauthenticated
+	}
+	return c.TxConn.Query(ctx, sql, args...)
+}
+
+func (c authenticatedConn) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if _, ok := PrincipalFrom(ctx); !ok {
+		return errRow{ErrUnauthenticated}
+	}
+	return c.TxConn.QueryRow(ctx, sql, args...)
+}
+
+func (c authenticatedConn) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if _, ok := PrincipalFrom(ctx); !ok {
+		return pgconn.CommandTag{}, ErrUnauthenticated
+	}
+	return c.TxConn.Exec(ctx, sql, args...)
+}
+
+func (c authenticatedConn) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	if _, ok := PrincipalFrom(ctx); !ok {
+		return nil, ErrUnauthenticated
+	}
+	return c.TxConn.BeginTx(ctx, txOptions)
+}
+
+// errRow is a pgx.Row which failed before it was queried.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
+
+// authorizeUser returns ErrForbidden unless the Principal of a context may manage a User, which only the User and the
+// local principal may.
+func authorizeUser(ctx context.Context, userID string) error {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !principal.IsLocal() && principal.UserID != userID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// CreateUser creates a User who logs in with a password. Only the local principal may create Users.
+func (s Service) CreateUser(ctx context.Context, username, password string) (*budgit.User, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("creating user %q: %w", username, ErrUnauthenticated)
+	}
+	if !principal.IsLocal() {
+		return nil, fmt.Errorf("creating user %q: %w", username, ErrForbidden)
+	}
+	if username == "" || strings.TrimSpace(username) != username {
+		return nil, fmt.Errorf("creating user %q: %w", username, ErrInvalidUsername)
+	}
+	passwordHash, err := s.hashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("creating user %q: %w", username, err)
+	}
+
+	now, err := s.db.Now(ctx, s.userConn)
+	if err != nil {
+		return nil, fmt.Errorf("creating user %q: %w", username, err)
+	}
+	dbUser := &db.User{
+		ID:               pgtype.Text{String: uuid.New().String(), Valid: true},
+		Username:         pgtype.Text{String: username, Valid: true},
+		PasswordHash:     pgtype.Text{String: passwordHash, Valid: true},
+		CreatedTimestamp: now,
+	}
+	ids, err := s.db.InsertUsers(ctx, s.userConn, dbUser)
+	if err != nil {
+		return nil, fmt.Errorf("creating user %q: %w", username, err)
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("creating user %q: %w", username, ErrUsernameTaken)
+	}
+	return dbconvert.ToUsers(dbUser)[0], nil
+}
+
+func (s Service) ListUsers(ctx context.Context) ([]*budgit.User, error) {
+	users, err := s.db.SelectUsers(ctx, s.userConn)
+	if err != nil {
+		return nil, fmt.Errorf("listing users: %w", err)
+	}
+	return dbconvert.ToUsers(users...), nil
+}
+
+// CurrentUser returns the User operations run as, or ErrUserNotFound under the local principal, which is no User.
+func (s Service) CurrentUser(ctx context.Context) (*budgit.User, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("getting current user: %w", ErrUnauthenticated)
+	}
+	if principal.IsLocal() {
+		return nil, fmt.Errorf("getting current user: %w", ErrUserNotFound)
+	}
+	dbUsers, err := s.db.SelectUsersByID(ctx, s.userConn, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("getting current user: %w", err)
+	}
+	dbUser, ok := dbUsers[principal.UserID]
+	if !ok {
+		return nil, fmt.Errorf("getting current user: %w", ErrUserNotFound)
+	}
+	return dbconvert.ToUsers(dbUser)[0], nil
+}
+
+// SetPassword replaces the password of a User. Their sessions and API tokens remain valid.
+func (s Service) SetPassword(ctx context.Context, username, password string) error {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("setting password of user %q: %w", username, err)
+	}
+	passwordHash, err := s.hashPassword(password)
+	if err != nil {
+		return fmt.Errorf("setting password of user %q: %w", username, err)
+	}
+	if _, err := s.db.UpdateUserPasswordHash(ctx, s.userConn, dbUser.ID.String, pgtype.Text{String: passwordHash, Valid: true}); err != nil {
+		return fmt.Errorf("setting password of user %q: %w", username, err)
+	}
+	return nil
+}
+
+func (s Service) hashPassword(password string) (string, error) {
+	if len([]rune(password)) < minPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+	return auth.HashPassword(password, s.passwordParams)
+}
+
+// TOTPEnrolment is a new TOTP secret of a User, to be added to their authenticator app before it is enabled.
+type TOTPEnrolment struct {
+	Secret string
+	// URI is the otpauth URI of the secret, which authenticator apps read from a QR code.
+	URI string
+}
+
+// EnrolTOTP returns a new TOTP secret for a User, which is not enabled until EnableTOTP is given a code of it.
+func (s Service) EnrolTOTP(ctx context.Context, username string) (*TOTPEnrolment, error) {
+	if _, err := s.authorizedUser(ctx, username); err != nil {
+		return nil, fmt.Errorf("enrolling TOTP of user %q: %w", username, err)
+	}
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return nil, fmt.Errorf("enrolling TOTP of user %q: %w", username, err)
+	}
+	return &TOTPEnrolment{Secret: secret, URI: auth.TOTPURI(totpIssuer, username, secret)}, nil
+}
+
+// EnableTOTP enables a TOTP second factor of a User, given a current code of its secret to show that their
+// authenticator app has it. They must then give a code along with their password to log in.
+func (s Service) EnableTOTP(ctx context.Context, username, secret, code string) error {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("enabling TOTP of user %q: %w", username, err)
+	}
+	if !auth.ValidateTOTP(secret, code, time.Now()) {
+		return fmt.Errorf("enabling TOTP of user %q: %w", username, ErrInvalidTOTPCode)
+	}
+	if _, err := s.db.UpdateUserTOTPSecret(ctx, s.userConn, dbUser.ID.String, pgtype.Text{String: secret, Valid: true}); err != nil {
+		return fmt.Errorf("enabling TOTP of user %q: %w", username, err)
+	}
+	return nil
+}
+
+// DisableTOTP disables the TOTP second factor of a User, if they have one.
+func (s Service) DisableTOTP(ctx context.Context, username string) error {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("disabling TOTP of user %q: %w", username, err)
+	}
+	if _, err := s.db.UpdateUserTOTPSecret(ctx, s.userConn, dbUser.ID.String, pgtype.Text{}); err != nil {
+		return fmt.Errorf("disabling TOTP of user %q: %w", username, err)
+	}
+	return nil
+}
+
+// authorizedUser returns the User with a username, if the Principal of a context may manage them.
+func (s Service) authorizedUser(ctx context.Context, username string) (*db.User, error) {
+	dbUsers, err := s.db.SelectUsersByUsername(ctx, s.userConn, username)
+	if err != nil {
+		return nil, err
+	}
+	dbUser, ok := dbUsers[username]
+	if !ok {
+		// The User is not found before authorizing, so whether a User exists is not revealed to those who may not
+		// manage them.
+		if err := authorizeUser(ctx, ""); err != nil {
+			return nil, err
+		}
+		return nil, ErrUserNotFound
+	}
+	if err := authorizeUser(ctx, dbUser.ID.String); err != nil {
+		return nil, err
+	}
+	return dbUser, nil
+}
+
+// Session is a session of a User, who logged in with their password.
+type Session struct {
+	// Token authenticates requests of the session, see Authenticate. It is only known when logging in.
+	Token            string
+	User             *budgit.User
+	ExpiresTimestamp time.Time
+}
+
+// Login starts a session of a User, given their password and, if they have enabled a TOTP second factor, a current code
+// of it. It returns ErrTOTPRequired if the password is correct but a code is required and not given, so that it can be
+// asked for, and ErrInvalidCredentials otherwise. Login needs no Principal.
+func (s Service) Login(ctx context.Context, username, password, totpCode string) (*Session, error) {
+	dbUsers, err := s.db.SelectUsersByUsername(ctx, s.unauthenticatedConn, username)
+	if err != nil {
+		return nil, fmt.Errorf("logging in user %q: %w", username, err)
+	}
+	dbUser, ok := dbUsers[username]
+	if !ok {
+		// A password is still verified, so that how long logging in takes does not reveal whether the User exists.
+		auth.VerifyPassword(password, s.dummyPasswordHash())
+		return nil, fmt.Errorf("logging in user %q: %w", username, ErrInvalidCredentials)
+	}
+	if err := auth.VerifyPassword(password, dbUser.PasswordHash.String); err != nil {
+		if errors.Is(err, auth.ErrPasswordMismatch) {
+			err = ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("logging in user %q: %w", username, err)
+	}
+	if dbUser.TOTPSecret.Valid {
+		if totpCode == "" {
+			return nil, fmt.Errorf("logging in user %q: %w", username, ErrTOTPRequired)
+		}
+		if !auth.ValidateTOTP(dbUser.TOTPSecret.String, totpCode, time.Now()) {
+			return nil, fmt.Errorf("logging in user %q: %w", username, ErrInvalidCredentials)
+		}
+	}
+
+	token, err := auth.NewToken(SessionTokenPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("logging in user %q: %w", username, err)
+	}
+	now, err := s.db.Now(ctx, s.unauthenticatedConn)
+	if err != nil {
+		return nil, fmt.Errorf("logging in user %q: %w", username, err)
+	}
+	dbSession := &db.Session{
+		TokenHash:        pgtype.Text{String: auth.HashToken(token), Valid: true},
+		UserID:           dbUser.ID,
+		CreatedTimestamp: now,
+		ExpiresTimestamp: pgtype.Timestamptz{Time: now.Time.Add(sessionDuration), Valid: true},
+	}
+	if _, err := s.db.InsertSessions(ctx, s.unauthenticatedConn, dbSession); err != nil {
+		return nil, fmt.Errorf("logging in user %q: %w", username, err)
+	}
+	if _, err := s.db.DeleteExpiredSessions(ctx, s.unauthenticatedConn); err != nil {
+		s.log.Warnw("Failed to delete expired sessions", zap.Error(err))
+	}
+	return &Session{Token: token, User: dbconvert.ToUsers(dbUser)[0], ExpiresTimestamp: dbSession.ExpiresTimestamp.Time}, nil
+}
+
+// Logout ends the session of a session token. Ending a session which has already ended is not an error.
+func (s Service) Logout(ctx context.Context, token string) error {
+	if _, err := s.db.DeleteSessions(ctx, s.unauthenticatedConn, auth.HashToken(token)); err != nil {
+		return fmt.Errorf("logging out: %w", err)
+	}
+	return nil
+}
+
+// Authenticate returns the Principal of a session token or API token, or ErrUnauthenticated if it is not the token of a
+// current session or API token. Authenticate needs no Principal.
+func (s Service) Authenticate(ctx context.Context, token string) (Principal, error) {
+	var principal Principal
+	var userID string
+	switch {
+	case strings.HasPrefix(token, SessionTokenPrefix):
+		dbSessions, err := s.db.SelectSessionsByTokenHash(ctx, s.unauthenticatedConn, auth.HashToken(token))
+		if err != nil {
+			return Principal{}, fmt.Errorf("authenticating: %w", err)
+		}
+		dbSession, ok := dbSessions[auth.HashToken(token)]
+		if !ok {
+			return Principal{}, ErrUnauthenticated
+		}
+		userID = dbSession.UserID.String
+	case strings.HasPrefix(token, APITokenPrefix):
+		dbTokens, err := s.db.SelectAPITokensByTokenHash(ctx, s.unauthenticatedConn, auth.HashToken(token))
+		if err != nil {
+			return Principal{}, fmt.Errorf("authenticating: %w", err)
+		}
+		dbToken, ok := dbTokens[auth.HashToken(token)]
+		if !ok {
+			return Principal{}, ErrUnauthenticated
+		}
+		apiToken := dbconvert.ToAPITokens(dbToken)[0]
+		userID, principal.APITokenID = apiToken.UserID, apiToken.ID
+		principal.APITokenBudgetID, principal.Scopes = apiToken.BudgetID, apiToken.Scopes
+		now, err := s.db.Now(ctx, s.unauthenticatedConn)
+		if err != nil {
+			return Principal{}, fmt.Errorf("authenticating: %w", err)
+		}
+		if _, err := s.db.UpdateAPITokenLastUsedTimestamp(ctx, s.unauthenticatedConn, dbToken.ID.String, now); err != nil {
+			return Principal{}, fmt.Errorf("authenticating: %w", err)
+		}
+	default:
+		return Principal{}, ErrUnauthenticated
+	}
+
+	dbUsers, err := s.db.SelectUsersByID(ctx, s.unauthenticatedConn, userID)
+	if err != nil {
+		return Principal{}, fmt.Errorf("authenticating: %w", err)
+	}
+	dbUser, ok := dbUsers[userID]
+	if !ok {
+		return Principal{}, ErrUnauthenticated
+	}
+	principal.UserID, principal.Username = dbUser.ID.String, dbUser.Username.String
+	return principal, nil
+}
+
+// CreateAPIToken creates an API token of a User, named for what uses it, returning it along with the token itself,
+// which is not kept so cannot be returned again. The token may only be used for the budget of the context, which the
+// User must be a member of, and for the operations its scopes cover. It expires at expires, unless it is zero.
+//
+// A Principal authenticated by an API token may only create tokens with scopes it has itself.
+func (s Service) CreateAPIToken(ctx context.Context, username, name string, scopes []budgit.Scope, expires time.Time) (*budgit.APIToken, string, error) {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, ErrInvalidScopes)
+	}
+	principal, _ := PrincipalFrom(ctx)
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("creating API token of user %q with scope %q: %w", username, scope, ErrInvalidScopes)
+		}
+		if !principal.HasScope(scope) {
+			return nil, "", fmt.Errorf("creating API token of user %q with scope %q: %w", username, scope, ErrForbidden)
+		}
+	}
+
+	budgetID, ok := BudgetFrom(ctx)
+	if !ok {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, ErrBudgetRequired)
+	}
+	if _, err := budgetRole(ctx, s.db, s.userConn, budgetID); err != nil {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+	}
+	if principal.IsLocal() {
+		// The local principal may create tokens of any User, who may not be a member of the budget.
+		if _, err := memberRole(ctx, s.db, s.userConn, dbUser.ID.String, budgetID); err != nil {
+			return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+		}
+	}
+
+	token, err := auth.NewToken(APITokenPrefix)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+	}
+	now, err := s.db.Now(ctx, s.userConn)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+	}
+	if !expires.IsZero() && !expires.After(now.Time) {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, ErrInvalidExpiry)
+	}
+	scopes = slices.Clone(scopes)
+	slices.Sort(scopes)
+	apiToken := &budgit.APIToken{
+		ID:               uuid.New().String(),
+		UserID:           dbUser.ID.String,
+		Name:             name,
+		BudgetID:         budgetID,
+		Scopes:           slices.Compact(scopes),
+		CreatedTimestamp: now.Time,
+		ExpiresTimestamp: expires,
+	}
+	dbToken := dbconvert.FromAPITokens(apiToken)[0]
+	dbToken.TokenHash = pgtype.Text{String: auth.HashToken(token), Valid: true}
+	if _, err := s.db.InsertAPITokens(ctx, s.userConn, dbToken); err != nil {
+		return nil, "", fmt.Errorf("creating API token of user %q: %w", username, err)
+	}
+	return dbconvert.ToAPITokens(dbToken)[0], token, nil
+}
+
+func (s Service) ListAPITokens(ctx context.Context, username string) ([]*budgit.APIToken, error) {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("listing API tokens of user %q: %w", username, err)
+	}
+	dbTokens, err := s.db.SelectAPITokensByUser(ctx, s.userConn, dbUser.ID.String)
+	if err != nil {
+		return nil, fmt.Errorf("listing API tokens of user %q: %w", username, err)
+	}
+	return dbconvert.ToAPITokens(dbTokens...), nil
+}
+
+// DeleteAPIToken revokes an API token of a User.
+func (s Service) DeleteAPIToken(ctx context.Context, username, tokenID string) error {
+	dbUser, err := s.authorizedUser(ctx, username)
+	if err != nil {
+		return fmt.Errorf("deleting API token %q of user %q: %w", tokenID, username, err)
+	}
+	ids, err := s.db.DeleteAPITokens(ctx, s.userConn, dbUser.ID.String, tokenID)
+	if err != nil {
+		return fmt.Errorf("deleting API token %q of user %q: %w", tokenID, username, err)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("deleting API token %q of user %q: %w", tokenID, username, ErrAPITokenNotFound)
+	}
+	return nil
+}
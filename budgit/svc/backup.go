@@ -0,0 +1,336 @@
+package svc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/backup"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
+)
+
+type BackupDB interface {
+	SelectAccountVersions(ctx context.Context, queryer db.Queryer) ([]*db.Account, error)
+	SelectPayeeVersions(ctx context.Context, queryer db.Queryer) ([]*db.Payee, error)
+	SelectCategoryGroupVersions(ctx context.Context, queryer db.Queryer) ([]*db.CategoryGroup, error)
+	SelectCategoryVersions(ctx context.Context, queryer db.Queryer) ([]*db.Category, error)
+	SelectTransactionVersions(ctx context.Context, queryer db.Queryer) ([]*db.Transaction, error)
+	SelectAttachmentVersions(ctx context.Context, queryer db.Queryer) ([]*db.Attachment, error)
+	SelectCSVProfileVersions(ctx context.Context, queryer db.Queryer) ([]*db.CSVProfile, error)
+	SelectAssignmentVersions(ctx context.Context, queryer db.Queryer) ([]*db.Assignment, error)
+	SelectIsEmpty(ctx context.Context, queryer db.Queryer) (bool, error)
+}
+
+var (
+	ErrRestoreTargetNotEmpty   = fmt.Errorf("a backup can only be restored into an empty Budget")
+	ErrBackupSchemaUnsupported = fmt.Errorf("the backup was written by a later schema version than the database")
+	ErrBackupTableUnknown      = fmt.Errorf("the backup holds rows of an unknown table")
+)
+
+const (
+	// restoreBatchSize is the number of rows of a table inserted at once when restoring a backup.
+	restoreBatchSize = 500
+
+	attachmentContentsTableName = "attachment_contents"
+)
+
+// attachmentContent is the content of an Attachment, which is held in the AttachmentStore rather than the database, but
+// is written to backups as rows of its own table.
+type attachmentContent struct {
+	ID      pgtype.Text `db:"id"`
+	Content []byte      `db:"content"`
+}
+
+// restoredIDs are the IDs given to the rows of a backup as it is restored, by the ID they were backed up with. Each ID
+// is replaced by the same new ID wherever it appears, so the versions of a row keep forming one chain and references
+// between rows, such as the Account of a Transaction, keep pointing at the same row.
+type restoredIDs struct {
+	ids        map[string]string
+	requestIDs map[string]string
+}
+
+func newRestoredIDs() *restoredIDs {
+	return &restoredIDs{ids: map[string]string{}, requestIDs: map[string]string{}}
+}
+
+// id returns the ID restored in place of an ID of a backup. Null and empty IDs are kept.
+func (r *restoredIDs) id(id pgtype.Text) pgtype.Text {
+	if !id.Valid || id.String == "" {
+		return id
+	}
+	if _, ok := r.ids[id.String]; !ok {
+		r.ids[id.String] = uuid.New().String()
+	}
+	return pgtype.Text{String: r.ids[id.String], Valid: true}
+}
+
+// requestID returns the request ID restored in place of a request ID of a backup.
+func (r *restoredIDs) requestID(requestID pgtype.Text) pgtype.Text {
+	if _, ok := r.requestIDs[requestID.String]; !ok {
+		r.requestIDs[requestID.String] = newRequestID().String
+	}
+	return pgtype.Text{String: r.requestIDs[requestID.String], Valid: true}
+}
+
+// backupTable writes every version of the rows of a table to a backup, and inserts rows read from one with new IDs.
+type backupTable struct {
+	name    string
+	write   func(ctx context.Context, conn Conn, writer *backup.Writer) error
+	restore func(ctx context.Context, conn Conn, ids *restoredIDs, rows []*backup.Row) error
+}
+
+// newBackupTable returns the backupTable of a table whose rows are read by selectVersions and written by insert.
+// restoreIDs replaces the request ID, ID and references to other rows of a row being restored.
+func newBackupTable[E any](
+	name string,
+	selectVersions func(ctx context.Context, queryer db.Queryer) ([]*E, error),
+	insert func(ctx context.Context, queryer db.Queryer, rows ...*E) ([]string, error),
+	restoreIDs func(row *E, ids *restoredIDs),
+) backupTable {
+	return backupTable{
+		name: name,
+		write: func(ctx context.Context, conn Conn, writer *backup.Writer) error {
+			versions, err := selectVersions(ctx, conn)
+			if err != nil {
+				return err
+			}
+			for _, version := range versions {
+				if err := writer.Write(name, version); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		restore: func(ctx context.Context, conn Conn, ids *restoredIDs, rows []*backup.Row) error {
+			versions := make([]*E, 0, len(rows))
+			for _, row := range rows {
+				version := new(E)
+				if err := row.Decode(version); err != nil {
+					return err
+				}
+				restoreIDs(version, ids)
+				versions = append(versions, version)
+			}
+			_, err := insert(ctx, conn, versions...)
+			return err
+		},
+	}
+}
+
+func (s Service) backupTables() []backupTable {
+	return []backupTable{
+		newBackupTable("accounts", s.db.SelectAccountVersions, s.db.InsertAccounts, func(account *db.Account, ids *restoredIDs) {
+			account.RequestID = ids.requestID(account.RequestID)
+			account.ID = ids.id(account.ID)
+		}),
+		newBackupTable("payees", s.db.SelectPayeeVersions, s.db.InsertPayees, func(payee *db.Payee, ids *restoredIDs) {
+			payee.RequestID = ids.requestID(payee.RequestID)
+			payee.ID = ids.id(payee.ID)
+		}),
+		newBackupTable("category_groups", s.db.SelectCategoryGroupVersions, s.db.InsertCategoryGroups, func(group *db.CategoryGroup, ids *restoredIDs) {
+			group.RequestID = ids.requestID(group.RequestID)
+			group.ID = ids.id(group.ID)
+		}),
+		newBackupTable("categories", s.db.SelectCategoryVersions, s.db.InsertCategories, func(category *db.Category, ids *restoredIDs) {
+			category.RequestID = ids.requestID(category.RequestID)
+			category.ID = ids.id(category.ID)
+			category.GroupID = ids.id(category.GroupID)
+		}),
+		newBackupTable("transactions", s.db.SelectTransactionVersions, s.db.InsertTransactions, func(transaction *db.Transaction, ids *restoredIDs) {
+			transaction.RequestID = ids.requestID(transaction.RequestID)
+			transaction.ID = ids.id(transaction.ID)
+			transaction.AccountID = ids.id(transaction.AccountID)
+			// The payee of a transfer is an Account, which is restored with a new ID all the same.
+			transaction.PayeeID = ids.id(transaction.PayeeID)
+			transaction.CategoryID = ids.id(transaction.CategoryID)
+			transaction.SplitID = ids.id(transaction.SplitID)
+		}),
+		newBackupTable("attachments", s.db.SelectAttachmentVersions, s.db.InsertAttachments, func(attachment *db.Attachment, ids *restoredIDs) {
+			attachment.RequestID = ids.requestID(attachment.RequestID)
+			attachment.ID = ids.id(attachment.ID)
+			attachment.TransactionID = ids.id(attachment.TransactionID)
+		}),
+		newBackupTable("csv_profiles", s.db.SelectCSVProfileVersions, s.db.InsertCSVProfiles, func(profile *db.CSVProfile, ids *restoredIDs) {
+			profile.RequestID = ids.requestID(profile.RequestID)
+			profile.AccountID = ids.id(profile.AccountID)
+		}),
+		newBackupTable("assignments", s.db.SelectAssignmentVersions, s.db.InsertAssignments, func(assignment *db.Assignment, ids *restoredIDs) {
+			assignment.RequestID = ids.requestID(assignment.RequestID)
+			assignment.ID = ids.id(assignment.ID)
+			assignment.CategoryID = ids.id(assignment.CategoryID)
+		}),
+	}
+}
+
+// Backup writes an archive of the budget, holding every version of its rows and the content of its Attachments, as
+// documented by package backup. The rows are read in a single transaction, so that the archive is consistent.
+func (s Service) Backup(ctx context.Context, w io.Writer) error {
+	err := s.inTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+		writer, err := backup.NewWriter(w, db.SchemaVersion, now.Time)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range s.backupTables() {
+			if err := table.write(ctx, conn, writer); err != nil {
+				return err
+			}
+		}
+		if err := s.backupAttachmentContents(ctx, conn, writer); err != nil {
+			return err
+		}
+		return writer.Close()
+	}, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("backing up budget: %w", err)
+	}
+	return nil
+}
+
+func (s Service) backupAttachmentContents(ctx context.Context, conn Conn, writer *backup.Writer) error {
+	attachments, err := s.db.SelectAttachmentVersions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	attachmentIDs := make([]string, 0, len(attachments))
+	for _, attachment := range attachments {
+		attachmentIDs = append(attachmentIDs, attachment.ID.String)
+	}
+
+	for _, attachmentID := range deduplicate(attachmentIDs) {
+		content, err := s.readAttachmentContent(ctx, attachmentID)
+		if errors.Is(err, fs.ErrNotExist) {
+			s.log.Warnw("Skipping backup of missing attachment content", zap.String("attachment_id", attachmentID))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("reading content of attachment %q: %w", attachmentID, err)
+		}
+		row := &attachmentContent{ID: pgtype.Text{String: attachmentID, Valid: true}, Content: content}
+		if err := writer.Write(attachmentContentsTableName, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Service) readAttachmentContent(ctx context.Context, attachmentID string) ([]byte, error) {
+	content, err := s.attachments.Open(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	return io.ReadAll(content)
+}
+
+// Restore reads an archive written by Backup into a Budget, which must be empty and owned by the Principal of the
+// context, returning the number of rows restored by table. Every row is given a new ID, so that a backup may be restored
+// alongside the Budget it was written from. The archive is read in a single transaction, so nothing is restored unless
+// the whole archive is valid and its checksum matches.
+func (s Service) Restore(ctx context.Context, r io.Reader, budgetID string) (map[string]int, error) {
+	ctx = WithBudget(ctx, budgetID)
+	if _, err := s.authorizeBudget(ctx, budgit.RoleOwner); err != nil {
+		return nil, fmt.Errorf("restoring budget %q: %w", budgetID, err)
+	}
+	reader, err := backup.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("restoring budget %q: %w", budgetID, err)
+	}
+	if schemaVersion := reader.Header().SchemaVersion; schemaVersion > db.SchemaVersion {
+		return nil, fmt.Errorf("restoring budget %q: schema version %d: %w", budgetID, schemaVersion, ErrBackupSchemaUnsupported)
+	}
+
+	tablesByName := map[string]backupTable{}
+	for _, table := range s.backupTables() {
+		tablesByName[table.name] = table
+	}
+
+	ids := newRestoredIDs()
+	restored := map[string]int{}
+	restoredAttachmentIDs := []string{}
+	err = s.inTx(ctx, func(conn Conn) error {
+		empty, err := s.db.SelectIsEmpty(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return ErrRestoreTargetNotEmpty
+		}
+
+		pending := map[string][]*backup.Row{}
+		flush := func(table backupTable) error {
+			if len(pending[table.name]) == 0 {
+				return nil
+			}
+			if err := table.restore(ctx, conn, ids, pending[table.name]); err != nil {
+				return fmt.Errorf("restoring %s: %w", table.name, err)
+			}
+			restored[table.name] += len(pending[table.name])
+			pending[table.name] = nil
+			return nil
+		}
+
+		for {
+			row, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			if row.Table == attachmentContentsTableName {
+				content := &attachmentContent{}
+				if err := row.Decode(content); err != nil {
+					return err
+				}
+				attachmentID := ids.id(content.ID).String
+				if _, err := s.attachments.Put(ctx, attachmentID, bytes.NewReader(content.Content)); err != nil {
+					return fmt.Errorf("restoring content of attachment %q: %w", content.ID.String, err)
+				}
+				restoredAttachmentIDs = append(restoredAttachmentIDs, attachmentID)
+				restored[attachmentContentsTableName]++
+				continue
+			}
+
+			table, ok := tablesByName[row.Table]
+			if !ok {
+				return fmt.Errorf("table %q: %w", row.Table, ErrBackupTableUnknown)
+			}
+			pending[table.name] = append(pending[table.name], row)
+			if len(pending[table.name]) >= restoreBatchSize {
+				if err := flush(table); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, table := range s.backupTables() {
+			if err := flush(table); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		for _, attachmentID := range restoredAttachmentIDs {
+			if deleteErr := s.attachments.Delete(ctx, attachmentID); deleteErr != nil {
+				s.log.Warnw("Deleting content of unrestored attachment", zap.String("attachment_id", attachmentID), zap.Error(deleteErr))
+			}
+		}
+		return nil, fmt.Errorf("restoring budget %q: %w", budgetID, err)
+	}
+	return restored, nil
+}
@@ -0,0 +1,102 @@
+package svc_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+)
+
+func (s *svcSuite) TestRestoreIntoBudget() {
+	ctx := s.localContext()
+	accounts, err := s.service.CreateAccounts(ctx,
+		&budgit.Account{ID: uuid.New().String(), Name: "Current"},
+		&budgit.Account{ID: uuid.New().String(), Name: "Savings"},
+	)
+	s.Require().NoError(err)
+	current, savings := accounts[0], accounts[1]
+	payees, err := s.service.CreatePayees(ctx, &budgit.Payee{ID: uuid.New().String(), Name: "Tesco"})
+	s.Require().NoError(err)
+	transactions, err := s.service.CreateTransactions(ctx,
+		&budgit.Transaction{ID: uuid.New().String(), EffectiveDate: june(1), AccountID: current.ID, PayeeID: payees[0].ID, Amount: -1000},
+		&budgit.Transaction{ID: uuid.New().String(), EffectiveDate: june(2), AccountID: current.ID, PayeeID: savings.ID, IsPayeeInternal: true, Amount: -5000},
+	)
+	s.Require().NoError(err)
+	shop := transactions[0]
+	shop.Amount = -1250
+	shop.Memo = "big shop"
+	_, err = s.service.UpdateTransactions(ctx, shop)
+	s.Require().NoError(err)
+	_, err = s.service.UploadAttachment(ctx, shop.ID, "receipt.txt", "text/plain", strings.NewReader("a receipt"))
+	s.Require().NoError(err)
+
+	var archive bytes.Buffer
+	s.Require().NoError(s.service.Backup(ctx, &archive))
+
+	copied, err := s.service.CreateBudget(ctx, "Copy")
+	s.Require().NoError(err)
+	restored, err := s.service.Restore(ctx, bytes.NewReader(archive.Bytes()), copied.ID)
+	s.Require().NoError(err)
+	s.Equal(1, restored["payees"])
+	s.Equal(1, restored["attachments"])
+	s.Equal(1, restored["attachment_contents"])
+
+	copyCtx := svc.WithBudget(ctx, copied.ID)
+	copiedAccounts, err := s.service.ListAccounts(copyCtx)
+	s.Require().NoError(err)
+	s.Require().Len(copiedAccounts, 2)
+	copiedAccountIDs := map[string]string{}
+	for _, account := range copiedAccounts {
+		s.NotEqual(current.ID, account.ID)
+		s.NotEqual(savings.ID, account.ID)
+		copiedAccountIDs[account.Name] = account.ID
+	}
+
+	copiedTransactions, err := s.service.ListTransactions(copyCtx, copiedAccountIDs["Current"])
+	s.Require().NoError(err)
+	s.Require().Len(copiedTransactions, 2, "only the current version of each Transaction")
+	var copiedShop, copiedTransfer *budgit.Transaction
+	for _, transaction := range copiedTransactions {
+		s.NotEqual(shop.ID, transaction.ID)
+		if transaction.IsPayeeInternal {
+			copiedTransfer = transaction
+		} else {
+			copiedShop = transaction
+		}
+	}
+	s.Require().NotNil(copiedShop)
+	s.Require().NotNil(copiedTransfer)
+	s.Equal(budgit.BalanceAmount(-1250), copiedShop.Amount)
+	s.Equal("big shop", copiedShop.Memo)
+	s.Equal(copiedAccountIDs["Savings"], copiedTransfer.PayeeID)
+
+	attachments, err := s.service.ListAttachments(copyCtx, copiedShop.ID)
+	s.Require().NoError(err)
+	s.Require().Len(attachments, 1)
+	_, content, err := s.service.DownloadAttachment(copyCtx, attachments[0].ID)
+	s.Require().NoError(err)
+	contents, err := io.ReadAll(content)
+	content.Close()
+	s.Require().NoError(err)
+	s.Equal("a receipt", string(contents))
+
+	s.Run("OriginalUnchanged", func() {
+		originalTransactions, err := s.service.ListTransactions(ctx, current.ID)
+		s.Require().NoError(err)
+		s.Len(originalTransactions, 2)
+	})
+	s.Run("NotEmpty", func() {
+		_, err := s.service.Restore(ctx, bytes.NewReader(archive.Bytes()), copied.ID)
+		s.ErrorIs(err, svc.ErrRestoreTargetNotEmpty)
+	})
+	s.Run("NotOwner", func() {
+		other, err := s.service.CreateBudget(ctx, "Other")
+		s.Require().NoError(err)
+		editorCtx := s.memberContext(svc.WithBudget(ctx, other.ID), "eve", budgit.RoleEditor)
+		_, err = s.service.Restore(editorCtx, bytes.NewReader(archive.Bytes()), other.ID)
+		s.ErrorIs(err, svc.ErrForbidden)
+	})
+}
@@ -0,0 +1,474 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/auth"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type BudgetDB interface {
+	SetBudget(ctx context.Context, queryer db.Queryer, budgetID string) error
+	InsertBudgets(ctx context.Context, queryer db.Queryer, budgets ...*db.Budget) ([]string, error)
+	SelectBudgets(ctx context.Context, queryer db.Queryer) ([]*db.Budget, error)
+	SelectBudgetsByID(ctx context.Context, queryer db.Queryer, budgetIDs ...string) (map[string]*db.Budget, error)
+	InsertBudgetMembers(ctx context.Context, queryer db.Queryer, members ...*db.BudgetMember) ([]string, error)
+	UpdateBudgetMemberRole(ctx context.Context, queryer db.Queryer, budgetID, userID string, role pgtype.Text) ([]string, error)
+	DeleteBudgetMembers(ctx context.Context, queryer db.Queryer, budgetID string, userIDs ...string) ([]string, error)
+	SelectBudgetMembers(ctx context.Context, queryer db.Queryer, budgetID string) ([]*db.BudgetMember, error)
+	SelectBudgetMembersByUser(ctx context.Context, queryer db.Queryer, userID string) ([]*db.BudgetMember, error)
+	InsertBudgetInvitations(ctx context.Context, queryer db.Queryer, invitations ...*db.BudgetInvitation) ([]string, error)
+	SelectBudgetInvitationsByTokenHash(ctx context.Context, queryer db.Queryer, tokenHashes ...string) (map[string]*db.BudgetInvitation, error)
+	DeleteBudgetInvitations(ctx context.Context, queryer db.Queryer, budgetID string, invitationIDs ...string) ([]string, error)
+	InsertExternalAccountLinks(ctx context.Context, queryer db.Queryer, links ...*db.ExternalAccountLink) ([]string, error)
+	SelectExternalAccountLinks(ctx context.Context, queryer db.Queryer, integrationID, externalAccountID string) ([]*db.ExternalAccountLink, error)
+}
+
+var (
+	ErrBudgetRequired     = errors.New("the operation requires a budget")
+	ErrBudgetNotFound     = errors.New("the requested Budget does not exist")
+	ErrInvalidBudgetName  = errors.New("a budget name is required")
+	ErrInvalidRole        = errors.New("the role must be owner, editor or viewer")
+	ErrMemberNotFound     = errors.New("the User is not a member of the Budget")
+	ErrLastOwner          = errors.New("a Budget must keep at least one owner")
+	ErrInvitationNotFound = errors.New("the invitation does not exist or has expired")
+)
+
+const (
+	// DefaultBudgetID is the ID of the budget written before budgets were shared, which every User who existed then owns.
+	DefaultBudgetID = "default"
+	// InvitationTokenPrefix prefixes invitation tokens, as SessionTokenPrefix and APITokenPrefix do.
+	InvitationTokenPrefix = "budgit_i_"
+	// invitationDuration is how long an invitation may be accepted for.
+	invitationDuration = 7 * 24 * time.Hour
+)
+
+type budgetKey struct{}
+
+// WithBudget returns a context running operations of the Service on a budget. Every operation reading or writing a
+// budget runs on one, which the Principal of the context must be a member of; ErrBudgetNotFound is returned otherwise.
+func WithBudget(ctx context.Context, budgetID string) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budgetID)
+}
+
+// BudgetFrom returns the ID of the budget operations run on in a context, if it has one.
+func BudgetFrom(ctx context.Context) (string, bool) {
+	budgetID, ok := ctx.Value(budgetKey{}).(string)
+	return budgetID, ok
+}
+
+// budgetConn begins transactions on the budget of their context, see WithBudget, once the Principal of the context is
+// found to have a role in the budget. Transactions which write need at least the editor role. It has no queries outside
+// a transaction, so that the role is looked up once for all the queries of an operation, in the transaction which runs
+// them.
+type budgetConn struct {
+	conn TxConn
+	db   DB
+}
+
+func (c budgetConn) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	budgetID, ok := BudgetFrom(ctx)
+	if !ok {
+		return nil, ErrBudgetRequired
+	}
+
+	tx, err := c.conn.BeginTx(ctx, txOptions)
+	if err != nil {
+		return nil, err
+	}
+	role, err := budgetRole(ctx, c.db, tx, budgetID)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	if txOptions.AccessMode != pgx.ReadOnly && !role.Includes(budgit.RoleEditor) {
+		tx.Rollback(ctx)
+		return nil, ErrForbidden
+	}
+	if err := c.db.SetBudget(ctx, tx, budgetID); err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
+	return tx, nil
+}
+
+// budgetRole returns the role of the Principal of a context in a budget, or ErrBudgetNotFound if they are not a member,
+// so that whether a budget exists is not revealed to those who are not. The local principal owns every budget. A
+// Principal authenticated by an API token of another budget is not a member.
+func budgetRole(ctx context.Context, dbs DB, conn Conn, budgetID string) (budgit.Role, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return "", ErrUnauthenticated
+	}
+	if principal.IsLocal() {
+		dbBudgets, err := dbs.SelectBudgetsByID(ctx, conn, budgetID)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := dbBudgets[budgetID]; !ok {
+			return "", ErrBudgetNotFound
+		}
+		return budgit.RoleOwner, nil
+	}
+	if principal.APITokenBudgetID != "" && principal.APITokenBudgetID != budgetID {
+		return "", ErrBudgetNotFound
+	}
+	return memberRole(ctx, dbs, conn, principal.UserID, budgetID)
+}
+
+// memberRole returns the role of a User in a budget, or ErrBudgetNotFound if they are not a member.
+func memberRole(ctx context.Context, dbs DB, conn Conn, userID, budgetID string) (budgit.Role, error) {
+	dbMembers, err := dbs.SelectBudgetMembersByUser(ctx, conn, userID)
+	if err != nil {
+		return "", err
+	}
+	for _, dbMember := range dbMembers {
+		if dbMember.BudgetID.String == budgetID {
+			return budgit.Role(dbMember.Role.String), nil
+		}
+	}
+	return "", ErrBudgetNotFound
+}
+
+// authorizeBudget returns the ID of the budget of a context, if the Principal of the context has at least a role in it.
+func (s Service) authorizeBudget(ctx context.Context, role budgit.Role) (string, error) {
+	budgetID, ok := BudgetFrom(ctx)
+	if !ok {
+		return "", ErrBudgetRequired
+	}
+	principalRole, err := budgetRole(ctx, s.db, s.userConn, budgetID)
+	if err != nil {
+		return "", err
+	}
+	if !principalRole.Includes(role) {
+		return "", ErrForbidden
+	}
+	return budgetID, nil
+}
+
+// CreateBudget creates an empty Budget, which the User creating it owns.
+func (s Service) CreateBudget(ctx context.Context, name string) (*budgit.Budget, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("creating budget %q: %w", name, ErrUnauthenticated)
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("creating budget %q: %w", name, ErrInvalidBudgetName)
+	}
+
+	dbBudget := &db.Budget{
+		ID:   pgtype.Text{String: uuid.New().String(), Valid: true},
+		Name: pgtype.Text{String: name, Valid: true},
+	}
+	err := s.inUserTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+		dbBudget.CreatedTimestamp = now
+		if _, err := s.db.InsertBudgets(ctx, conn, dbBudget); err != nil {
+			return err
+		}
+		if principal.IsLocal() {
+			return nil
+		}
+		_, err = s.db.InsertBudgetMembers(ctx, conn, &db.BudgetMember{
+			BudgetID:         dbBudget.ID,
+			UserID:           pgtype.Text{String: principal.UserID, Valid: true},
+			Role:             pgtype.Text{String: string(budgit.RoleOwner), Valid: true},
+			CreatedTimestamp: now,
+		})
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("creating budget %q: %w", name, err)
+	}
+	budget := dbconvert.ToBudgets(dbBudget)[0]
+	budget.Role = budgit.RoleOwner
+	return budget, nil
+}
+
+// ListBudgets returns the Budgets the Principal is a member of, with their role in each. The local principal owns every
+// Budget, and a Principal authenticated by an API token only lists the Budget of the token.
+func (s Service) ListBudgets(ctx context.Context) ([]*budgit.Budget, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("listing budgets: %w", ErrUnauthenticated)
+	}
+	if principal.IsLocal() {
+		dbBudgets, err := s.db.SelectBudgets(ctx, s.userConn)
+		if err != nil {
+			return nil, fmt.Errorf("listing budgets: %w", err)
+		}
+		budgets := dbconvert.ToBudgets(dbBudgets...)
+		for _, budget := range budgets {
+			budget.Role = budgit.RoleOwner
+		}
+		return budgets, nil
+	}
+
+	dbMembers, err := s.db.SelectBudgetMembersByUser(ctx, s.userConn, principal.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("listing budgets: %w", err)
+	}
+	if principal.APITokenBudgetID != "" {
+		dbMembers = slices.DeleteFunc(dbMembers, func(dbMember *db.BudgetMember) bool {
+			return dbMember.BudgetID.String != principal.APITokenBudgetID
+		})
+	}
+	budgetIDs := make([]string, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		budgetIDs = append(budgetIDs, dbMember.BudgetID.String)
+	}
+	dbBudgets, err := s.db.SelectBudgetsByID(ctx, s.userConn, budgetIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("listing budgets: %w", err)
+	}
+	budgets := make([]*budgit.Budget, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		if dbBudget, ok := dbBudgets[dbMember.BudgetID.String]; ok {
+			budget := dbconvert.ToBudgets(dbBudget)[0]
+			budget.Role = budgit.Role(dbMember.Role.String)
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets, nil
+}
+
+// ListMembers returns the members of the budget of a context, oldest first. Every member may list them.
+func (s Service) ListMembers(ctx context.Context) ([]*budgit.BudgetMember, error) {
+	budgetID, err := s.authorizeBudget(ctx, budgit.RoleViewer)
+	if err != nil {
+		return nil, fmt.Errorf("listing members: %w", err)
+	}
+	dbMembers, err := s.db.SelectBudgetMembers(ctx, s.userConn, budgetID)
+	if err != nil {
+		return nil, fmt.Errorf("listing members of budget %q: %w", budgetID, err)
+	}
+	userIDs := make([]string, 0, len(dbMembers))
+	for _, dbMember := range dbMembers {
+		userIDs = append(userIDs, dbMember.UserID.String)
+	}
+	dbUsers, err := s.db.SelectUsersByID(ctx, s.userConn, userIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("listing members of budget %q: %w", budgetID, err)
+	}
+	return dbconvert.ToBudgetMembers(dbUsers, dbMembers...), nil
+}
+
+// SetMember makes a User a member of the budget of a context with a role, or changes their role if they already are
+// one. Only owners may set members, and the last owner may not be made an editor or viewer.
+func (s Service) SetMember(ctx context.Context, username string, role budgit.Role) error {
+	if !role.IsValid() {
+		return fmt.Errorf("setting member %q: %w", username, ErrInvalidRole)
+	}
+	budgetID, err := s.authorizeBudget(ctx, budgit.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("setting member %q: %w", username, err)
+	}
+	dbUsers, err := s.db.SelectUsersByUsername(ctx, s.userConn, username)
+	if err != nil {
+		return fmt.Errorf("setting member %q of budget %q: %w", username, budgetID, err)
+	}
+	dbUser, ok := dbUsers[username]
+	if !ok {
+		return fmt.Errorf("setting member %q of budget %q: %w", username, budgetID, ErrUserNotFound)
+	}
+
+	err = s.inUserTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+		ids, err := s.db.InsertBudgetMembers(ctx, conn, &db.BudgetMember{
+			BudgetID:         pgtype.Text{String: budgetID, Valid: true},
+			UserID:           dbUser.ID,
+			Role:             pgtype.Text{String: string(role), Valid: true},
+			CreatedTimestamp: now,
+		})
+		if err != nil || len(ids) != 0 {
+			return err
+		}
+		if _, err := s.db.UpdateBudgetMemberRole(ctx, conn, budgetID, dbUser.ID.String, pgtype.Text{String: string(role), Valid: true}); err != nil {
+			return err
+		}
+		return s.checkOwners(ctx, conn, budgetID)
+	}, pgx.TxOptions{IsoLevel: pgx.Serializable, AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return fmt.Errorf("setting member %q of budget %q: %w", username, budgetID, err)
+	}
+	return nil
+}
+
+// RemoveMember removes a User from the budget of a context. Owners may remove any member, and every member may remove
+// themselves to leave the budget, unless they are its last owner.
+func (s Service) RemoveMember(ctx context.Context, username string) error {
+	budgetID, err := s.authorizeBudget(ctx, budgit.RoleViewer)
+	if err != nil {
+		return fmt.Errorf("removing member %q: %w", username, err)
+	}
+	dbUsers, err := s.db.SelectUsersByUsername(ctx, s.userConn, username)
+	if err != nil {
+		return fmt.Errorf("removing member %q of budget %q: %w", username, budgetID, err)
+	}
+	dbUser, ok := dbUsers[username]
+	if !ok {
+		return fmt.Errorf("removing member %q of budget %q: %w", username, budgetID, ErrMemberNotFound)
+	}
+	if principal, _ := PrincipalFrom(ctx); principal.UserID != dbUser.ID.String {
+		if _, err := s.authorizeBudget(ctx, budgit.RoleOwner); err != nil {
+			return fmt.Errorf("removing member %q of budget %q: %w", username, budgetID, err)
+		}
+	}
+
+	err = s.inUserTx(ctx, func(conn Conn) error {
+		ids, err := s.db.DeleteBudgetMembers(ctx, conn, budgetID, dbUser.ID.String)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return ErrMemberNotFound
+		}
+		return s.checkOwners(ctx, conn, budgetID)
+	}, pgx.TxOptions{IsoLevel: pgx.Serializable, AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return fmt.Errorf("removing member %q of budget %q: %w", username, budgetID, err)
+	}
+	return nil
+}
+
+// checkOwners returns ErrLastOwner if a budget has no owner left, so that it is not left without anyone to manage it.
+func (s Service) checkOwners(ctx context.Context, conn Conn, budgetID string) error {
+	dbMembers, err := s.db.SelectBudgetMembers(ctx, conn, budgetID)
+	if err != nil {
+		return err
+	}
+	for _, dbMember := range dbMembers {
+		if budgit.Role(dbMember.Role.String) == budgit.RoleOwner {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
+
+// CreateInvitation creates an invitation to the budget of a context, returning it along with its token, which is not
+// kept so cannot be returned again. The User who accepts it, see AcceptInvitation, becomes a member with the role. Only
+// owners may invite.
+func (s Service) CreateInvitation(ctx context.Context, role budgit.Role) (*budgit.BudgetInvitation, string, error) {
+	if !role.IsValid() {
+		return nil, "", fmt.Errorf("creating invitation: %w", ErrInvalidRole)
+	}
+	budgetID, err := s.authorizeBudget(ctx, budgit.RoleOwner)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating invitation: %w", err)
+	}
+	token, err := auth.NewToken(InvitationTokenPrefix)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating invitation to budget %q: %w", budgetID, err)
+	}
+	now, err := s.db.Now(ctx, s.userConn)
+	if err != nil {
+		return nil, "", fmt.Errorf("creating invitation to budget %q: %w", budgetID, err)
+	}
+	dbInvitation := &db.BudgetInvitation{
+		ID:               pgtype.Text{String: uuid.New().String(), Valid: true},
+		TokenHash:        pgtype.Text{String: auth.HashToken(token), Valid: true},
+		BudgetID:         pgtype.Text{String: budgetID, Valid: true},
+		Role:             pgtype.Text{String: string(role), Valid: true},
+		CreatedBy:        createdBy(ctx),
+		CreatedTimestamp: now,
+		ExpiresTimestamp: pgtype.Timestamptz{Time: now.Time.Add(invitationDuration), Valid: true},
+	}
+	if _, err := s.db.InsertBudgetInvitations(ctx, s.userConn, dbInvitation); err != nil {
+		return nil, "", fmt.Errorf("creating invitation to budget %q: %w", budgetID, err)
+	}
+	return dbconvert.ToBudgetInvitations(dbInvitation)[0], token, nil
+}
+
+// DeleteInvitation revokes an invitation to the budget of a context which has not been accepted. Only owners may revoke
+// invitations.
+func (s Service) DeleteInvitation(ctx context.Context, invitationID string) error {
+	budgetID, err := s.authorizeBudget(ctx, budgit.RoleOwner)
+	if err != nil {
+		return fmt.Errorf("deleting invitation %q: %w", invitationID, err)
+	}
+	ids, err := s.db.DeleteBudgetInvitations(ctx, s.userConn, budgetID, invitationID)
+	if err != nil {
+		return fmt.Errorf("deleting invitation %q to budget %q: %w", invitationID, budgetID, err)
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("deleting invitation %q to budget %q: %w", invitationID, budgetID, ErrInvitationNotFound)
+	}
+	return nil
+}
+
+// AcceptInvitation makes the User of a context a member of the Budget of an invitation token, with the role of the
+// invitation, and returns the Budget. Each invitation may only be accepted once. Accepting an invitation to a Budget the
+// User is already a member of uses it up without changing their role.
+func (s Service) AcceptInvitation(ctx context.Context, token string) (*budgit.Budget, error) {
+	principal, ok := PrincipalFrom(ctx)
+	if !ok {
+		return nil, fmt.Errorf("accepting invitation: %w", ErrUnauthenticated)
+	}
+	if principal.IsLocal() {
+		// The local principal is not a User, so cannot be a member.
+		return nil, fmt.Errorf("accepting invitation: %w", ErrForbidden)
+	}
+
+	var dbBudget *db.Budget
+	var role budgit.Role
+	err := s.inUserTx(ctx, func(conn Conn) error {
+		dbInvitations, err := s.db.SelectBudgetInvitationsByTokenHash(ctx, conn, auth.HashToken(token))
+		if err != nil {
+			return err
+		}
+		dbInvitation, ok := dbInvitations[auth.HashToken(token)]
+		if !ok {
+			return ErrInvitationNotFound
+		}
+		ids, err := s.db.DeleteBudgetInvitations(ctx, conn, dbInvitation.BudgetID.String, dbInvitation.ID.String)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			// Accepted by someone else meanwhile.
+			return ErrInvitationNotFound
+		}
+
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.InsertBudgetMembers(ctx, conn, &db.BudgetMember{
+			BudgetID:         dbInvitation.BudgetID,
+			UserID:           pgtype.Text{String: principal.UserID, Valid: true},
+			Role:             dbInvitation.Role,
+			CreatedTimestamp: now,
+		}); err != nil {
+			return err
+		}
+		dbBudgets, err := s.db.SelectBudgetsByID(ctx, conn, dbInvitation.BudgetID.String)
+		if err != nil {
+			return err
+		}
+		dbBudget = dbBudgets[dbInvitation.BudgetID.String]
+		role, err = budgetRole(ctx, s.db, conn, dbInvitation.BudgetID.String)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("accepting invitation: %w", err)
+	}
+	budget := dbconvert.ToBudgets(dbBudget)[0]
+	budget.Role = role
+	return budget, nil
+}
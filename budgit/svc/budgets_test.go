@@ -0,0 +1,118 @@
+package svc_test
+
+import (
+	"context"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+)
+
+func (s *svcSuite) TestBudgetRoles() {
+	ctx := s.localContext()
+	ownerCtx := s.memberContext(ctx, "alice", budgit.RoleOwner)
+	editorCtx := s.memberContext(ctx, "eve", budgit.RoleEditor)
+	viewerCtx := s.memberContext(ctx, "victor", budgit.RoleViewer)
+	nonMemberCtx := s.userContext(s.createUser("nora"), svc.DefaultBudgetID)
+
+	s.Run("ViewerWrite", func() {
+		_, err := s.service.CreateAccounts(viewerCtx, &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+		s.ErrorIs(err, svc.ErrForbidden)
+		_, err = s.service.ListAccounts(viewerCtx)
+		s.NoError(err)
+	})
+	s.Run("EditorWrite", func() {
+		_, err := s.service.CreateAccounts(editorCtx, &budgit.Account{ID: uuid.New().String(), Name: "Savings"})
+		s.NoError(err)
+	})
+	s.Run("NonMember", func() {
+		_, err := s.service.ListAccounts(nonMemberCtx)
+		s.ErrorIs(err, svc.ErrBudgetNotFound)
+		_, err = s.service.CreateAccounts(nonMemberCtx, &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+		s.ErrorIs(err, svc.ErrBudgetNotFound)
+		_, err = s.service.ListMembers(nonMemberCtx)
+		s.ErrorIs(err, svc.ErrBudgetNotFound)
+	})
+	s.Run("NonOwnerSetMember", func() {
+		s.ErrorIs(s.service.SetMember(editorCtx, "nora", budgit.RoleViewer), svc.ErrForbidden)
+		s.ErrorIs(s.service.SetMember(viewerCtx, "victor", budgit.RoleOwner), svc.ErrForbidden)
+		s.ErrorIs(s.service.RemoveMember(editorCtx, "victor"), svc.ErrForbidden)
+	})
+	s.Run("OwnerSetMember", func() {
+		s.Require().NoError(s.service.SetMember(ownerCtx, "victor", budgit.RoleEditor))
+		members, err := s.service.ListMembers(ownerCtx)
+		s.Require().NoError(err)
+		for _, member := range members {
+			if member.Username == "victor" {
+				s.Equal(budgit.RoleEditor, member.Role)
+			}
+		}
+	})
+}
+
+func (s *svcSuite) TestLastOwner() {
+	ctx := s.budgetContext(s.createUser("alice"), "Alice's")
+	s.memberContext(ctx, "bob", budgit.RoleEditor)
+
+	s.Run("Demote", func() {
+		s.ErrorIs(s.service.SetMember(ctx, "alice", budgit.RoleEditor), svc.ErrLastOwner)
+	})
+	s.Run("Remove", func() {
+		s.ErrorIs(s.service.RemoveMember(ctx, "alice"), svc.ErrLastOwner)
+	})
+	s.Run("StillOwner", func() {
+		members, err := s.service.ListMembers(ctx)
+		s.Require().NoError(err)
+		s.Len(members, 2)
+		for _, member := range members {
+			if member.Username == "alice" {
+				s.Equal(budgit.RoleOwner, member.Role)
+			}
+		}
+	})
+	s.Run("DemoteWithAnotherOwner", func() {
+		s.Require().NoError(s.service.SetMember(ctx, "bob", budgit.RoleOwner))
+		s.NoError(s.service.SetMember(ctx, "alice", budgit.RoleEditor))
+	})
+}
+
+func (s *svcSuite) TestAcceptInvitation() {
+	ctx := s.localContext()
+	newUserCtx := func(username string) context.Context {
+		return s.userContext(s.createUser(username), svc.DefaultBudgetID)
+	}
+
+	s.Run("Accepted", func() {
+		_, token, err := s.service.CreateInvitation(ctx, budgit.RoleEditor)
+		s.Require().NoError(err)
+		userCtx := newUserCtx("alice")
+		budget, err := s.service.AcceptInvitation(userCtx, token)
+		s.Require().NoError(err)
+		s.Equal(svc.DefaultBudgetID, budget.ID)
+		s.Equal(budgit.RoleEditor, budget.Role)
+	})
+	s.Run("Used", func() {
+		_, token, err := s.service.CreateInvitation(ctx, budgit.RoleOwner)
+		s.Require().NoError(err)
+		_, err = s.service.AcceptInvitation(newUserCtx("bob"), token)
+		s.Require().NoError(err)
+
+		userCtx := newUserCtx("carol")
+		_, err = s.service.AcceptInvitation(userCtx, token)
+		s.ErrorIs(err, svc.ErrInvitationNotFound)
+		_, err = s.service.ListAccounts(userCtx)
+		s.ErrorIs(err, svc.ErrBudgetNotFound)
+	})
+	s.Run("Expired", func() {
+		invitation, token, err := s.service.CreateInvitation(ctx, budgit.RoleOwner)
+		s.Require().NoError(err)
+		_, err = s.pool.Exec(context.Background(), `UPDATE budget_invitations SET expires_timestamp = NOW() - INTERVAL '1 minute' WHERE id = $1`, invitation.ID)
+		s.Require().NoError(err)
+
+		userCtx := newUserCtx("dave")
+		_, err = s.service.AcceptInvitation(userCtx, token)
+		s.ErrorIs(err, svc.ErrInvitationNotFound)
+		_, err = s.service.ListAccounts(userCtx)
+		s.ErrorIs(err, svc.ErrBudgetNotFound)
+	})
+}
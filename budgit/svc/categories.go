@@ -0,0 +1,200 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CategoryDB interface {
+	InsertCategoryGroups(ctx context.Context, queryer db.Queryer, categoryGroups ...*db.CategoryGroup) ([]string, error)
+	SelectCategoryGroups(ctx context.Context, queryer db.Queryer) ([]*db.CategoryGroup, error)
+	InsertCategories(ctx context.Context, queryer db.Queryer, categories ...*db.Category) ([]string, error)
+	SelectCategories(ctx context.Context, queryer db.Queryer) ([]*db.Category, error)
+	SelectCategoriesByID(ctx context.Context, queryer db.Queryer, categoryIDs ...string) (map[string]*db.Category, error)
+}
+
+func (s Service) CreateCategoryGroups(ctx context.Context, groups ...*budgit.CategoryGroup) ([]*budgit.CategoryGroup, error) {
+	err := s.inTx(ctx, func(conn Conn) error {
+		return s.createCategoryGroups(ctx, conn, groups...)
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("creating category groups: %w", err)
+	}
+	return groups, nil
+}
+
+// createCategoryGroups creates CategoryGroups within a transaction, see CreateCategoryGroups.
+func (s Service) createCategoryGroups(ctx context.Context, conn Conn, groups ...*budgit.CategoryGroup) error {
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	dbGroups := dbconvert.FromCategoryGroups(groups...)
+	for _, dbGroup := range dbGroups {
+		dbGroup.RequestID = newRequestID()
+		dbGroup.CreatedBy = createdBy(ctx)
+		dbGroup.ValidFromTimestamp = now
+		dbGroup.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	_, err = s.db.InsertCategoryGroups(ctx, conn, dbGroups...)
+	return err
+}
+
+func (s Service) ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error) {
+	groups, err := inReadTx(ctx, s, func(conn Conn) ([]*db.CategoryGroup, error) {
+		return s.db.SelectCategoryGroups(ctx, conn)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing category groups: %w", err)
+	}
+	return dbconvert.ToCategoryGroups(groups...), nil
+}
+
+type MissingCategoryGroupsError struct {
+	CategoryGroupIDs []string
+}
+
+func (e MissingCategoryGroupsError) Error() string {
+	return fmt.Sprintf("categories reference Category Groups that do not exist: %+v", e.CategoryGroupIDs)
+}
+
+func (s Service) CreateCategories(ctx context.Context, categories ...*budgit.Category) ([]*budgit.Category, error) {
+	err := s.inTx(ctx, func(conn Conn) error {
+		return s.createCategories(ctx, conn, categories...)
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("creating categories: %w", err)
+	}
+	return categories, nil
+}
+
+// createCategories creates Categories within a transaction, see CreateCategories.
+func (s Service) createCategories(ctx context.Context, conn Conn, categories ...*budgit.Category) error {
+	dbGroups, err := s.db.SelectCategoryGroups(ctx, conn)
+	if err != nil {
+		return err
+	}
+	groupIDs := make([]string, 0, len(dbGroups))
+	for _, dbGroup := range dbGroups {
+		groupIDs = append(groupIDs, dbGroup.ID.String)
+	}
+	referencedGroupIDs := make([]string, 0, len(categories))
+	for _, category := range categories {
+		referencedGroupIDs = append(referencedGroupIDs, category.GroupID)
+	}
+	if missingIDs := symmetricDifference(groupIDs, deduplicate(referencedGroupIDs)); len(missingIDs) != 0 {
+		return MissingCategoryGroupsError{CategoryGroupIDs: missingIDs}
+	}
+
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	dbCategories := dbconvert.FromCategories(categories...)
+	for _, dbCategory := range dbCategories {
+		dbCategory.RequestID = newRequestID()
+		dbCategory.CreatedBy = createdBy(ctx)
+		dbCategory.ValidFromTimestamp = now
+		dbCategory.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	_, err = s.db.InsertCategories(ctx, conn, dbCategories...)
+	return err
+}
+
+func (s Service) ListCategories(ctx context.Context) ([]*budgit.Category, error) {
+	categories, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Category, error) {
+		return s.db.SelectCategories(ctx, conn)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %w", err)
+	}
+	return dbconvert.ToCategories(categories...), nil
+}
+
+// categoryPaths returns the category path of every Category, see budgit.CategoryPath, by Category ID.
+func (s Service) categoryPaths(ctx context.Context) (map[string]string, error) {
+	groups, err := s.ListCategoryGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+	paths := make(map[string]string, len(categories))
+	for _, category := range categories {
+		paths[category.ID] = budgit.CategoryPath(groupNames[category.GroupID], category.Name)
+	}
+	return paths, nil
+}
+
+// categoryIDsByPath returns the IDs of the Categories with the given category paths, see budgit.SplitCategoryPath,
+// creating the Categories and CategoryGroups which do not exist within a transaction.
+func (s Service) categoryIDsByPath(ctx context.Context, conn Conn, paths ...string) (map[string]string, error) {
+	dbGroups, err := s.db.SelectCategoryGroups(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	dbCategories, err := s.db.SelectCategories(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	groups, categories := dbconvert.ToCategoryGroups(dbGroups...), dbconvert.ToCategories(dbCategories...)
+
+	groupIDsByName := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupIDsByName[group.Name] = group.ID
+	}
+	type groupCategory struct{ groupID, name string }
+	categoryIDs := make(map[groupCategory]string, len(categories))
+	for _, category := range categories {
+		categoryIDs[groupCategory{category.GroupID, category.Name}] = category.ID
+	}
+
+	missingGroups, missingCategories := []*budgit.CategoryGroup{}, []*budgit.Category{}
+	idsByPath := make(map[string]string, len(paths))
+	for _, path := range deduplicate(paths) {
+		groupName, categoryName := budgit.SplitCategoryPath(path)
+		groupID, ok := groupIDsByName[groupName]
+		if !ok {
+			group := &budgit.CategoryGroup{ID: uuid.New().String(), Name: groupName}
+			missingGroups = append(missingGroups, group)
+			groupID, groupIDsByName[groupName] = group.ID, group.ID
+		}
+		categoryID, ok := categoryIDs[groupCategory{groupID, categoryName}]
+		if !ok {
+			category := &budgit.Category{ID: uuid.New().String(), GroupID: groupID, Name: categoryName}
+			missingCategories = append(missingCategories, category)
+			categoryID, categoryIDs[groupCategory{groupID, categoryName}] = category.ID, category.ID
+		}
+		idsByPath[path] = categoryID
+	}
+
+	if len(missingGroups) != 0 {
+		if err := s.createCategoryGroups(ctx, conn, missingGroups...); err != nil {
+			return nil, err
+		}
+	}
+	if len(missingCategories) != 0 {
+		if err := s.createCategories(ctx, conn, missingCategories...); err != nil {
+			return nil, err
+		}
+	}
+	return idsByPath, nil
+}
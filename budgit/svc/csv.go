@@ -0,0 +1,102 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CSVProfileDB interface {
+	InsertCSVProfiles(ctx context.Context, queryer db.Queryer, profiles ...*db.CSVProfile) ([]string, error)
+	UpdateCSVProfileValidToTimestamps(ctx context.Context, queryer db.Queryer, updates ...db.ValidToTimestampUpdate) ([]string, error)
+	SelectCSVProfilesByAccount(ctx context.Context, queryer db.Queryer, accountIDs ...string) (map[string]*db.CSVProfile, error)
+}
+
+var ErrCSVProfileNotFound = fmt.Errorf("the requested Account has no CSV profile")
+
+// SaveCSVProfile saves the profile used to import CSV statements into its Account, replacing any previous profile.
+func (s Service) SaveCSVProfile(ctx context.Context, profile *budgit.CSVProfile) error {
+	if err := fileimport.ValidateCSVProfile(profile); err != nil {
+		return fmt.Errorf("saving CSV profile of account %q: %w", profile.AccountID, err)
+	}
+
+	err := s.inTx(ctx, func(conn Conn) error {
+		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, profile.AccountID)
+		if err != nil {
+			return err
+		}
+		if _, ok := dbAccounts[profile.AccountID]; !ok {
+			return ErrAccountNotFound
+		}
+
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.db.UpdateCSVProfileValidToTimestamps(ctx, conn, db.ValidToTimestampUpdate{
+			ID:               pgtype.Text{String: profile.AccountID, Valid: true},
+			ValidToTimestamp: now,
+		}); err != nil {
+			return err
+		}
+
+		dbProfile := dbconvert.FromCSVProfiles(profile)[0]
+		dbProfile.RequestID = newRequestID()
+		dbProfile.CreatedBy = createdBy(ctx)
+		dbProfile.ValidFromTimestamp = now
+		dbProfile.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+
+		_, err = s.db.InsertCSVProfiles(ctx, conn, dbProfile)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return fmt.Errorf("saving CSV profile of account %q: %w", profile.AccountID, err)
+	}
+	return nil
+}
+
+// GetCSVProfile returns the profile used to import CSV statements into an Account.
+func (s Service) GetCSVProfile(ctx context.Context, accountID string) (*budgit.CSVProfile, error) {
+	dbProfiles, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.CSVProfile, error) {
+		return s.db.SelectCSVProfilesByAccount(ctx, conn, accountID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting CSV profile of account %q: %w", accountID, err)
+	}
+	dbProfile, ok := dbProfiles[accountID]
+	if !ok {
+		return nil, fmt.Errorf("getting CSV profile of account %q: %w", accountID, ErrCSVProfileNotFound)
+	}
+	return dbconvert.ToCSVProfiles(dbProfile)[0], nil
+}
+
+// PreviewCSVImport parses a CSV statement with a profile, which need not be saved, and returns its transactions,
+// marking those already imported into the profile's Account.
+func (s Service) PreviewCSVImport(ctx context.Context, profile *budgit.CSVProfile, content io.Reader) ([]*ImportCandidate, error) {
+	transactions, err := fileimport.ParseCSV(content, profile)
+	if err != nil {
+		return nil, fmt.Errorf("previewing CSV import into account %q: %w", profile.AccountID, err)
+	}
+	return s.PreviewImport(ctx, profile.AccountID, transactions)
+}
+
+// ImportCSV imports the transactions of a CSV statement into an Account, using its saved profile.
+func (s Service) ImportCSV(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error) {
+	profile, err := s.GetCSVProfile(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("importing CSV into account %q: %w", accountID, err)
+	}
+	transactions, err := fileimport.ParseCSV(content, profile)
+	if err != nil {
+		return nil, fmt.Errorf("importing CSV into account %q: %w", accountID, err)
+	}
+	return s.ImportTransactions(ctx, accountID, transactions)
+}
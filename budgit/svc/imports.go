@@ -0,0 +1,324 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// unknownPayeeName is the name of the Payee of imported transactions which do not name one.
+const unknownPayeeName = "Unknown"
+
+// ImportCandidate is a transaction parsed from a statement, to be imported into an Account.
+type ImportCandidate struct {
+	Transaction *budgit.ExternalTransaction
+	// Duplicate is whether the transaction matches an existing Transaction of the Account, so will not be imported.
+	Duplicate bool
+}
+
+// PreviewImport returns the transactions parsed from a statement, marking those already imported into an Account.
+//
+// A transaction is a duplicate of an existing Transaction imported with the same ID or, for Transactions that were not
+// imported, of one with the same date and amount. Each existing Transaction is matched by at most one transaction.
+func (s Service) PreviewImport(ctx context.Context, accountID string, transactions []*budgit.ExternalTransaction) ([]*ImportCandidate, error) {
+	candidates, err := inReadTx(ctx, s, func(conn Conn) ([]*ImportCandidate, error) {
+		return s.previewImport(ctx, conn, accountID, transactions)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("previewing import into account %q: %w", accountID, err)
+	}
+	return candidates, nil
+}
+
+// previewImport marks the transactions already imported into an Account, see PreviewImport.
+func (s Service) previewImport(ctx context.Context, conn Conn, accountID string, transactions []*budgit.ExternalTransaction) ([]*ImportCandidate, error) {
+	dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := dbAccounts[accountID]; !ok {
+		return nil, ErrAccountNotFound
+	}
+
+	dbTransactions, err := s.db.SelectTransactionsByAccount(ctx, conn, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return markDuplicates(dbconvert.ToTransactions(dbTransactions...), transactions), nil
+}
+
+// ImportTransactions creates Transactions in an Account from the transactions parsed from a statement, skipping those
+// already imported, see PreviewImport. Payees are matched by name, and created if they do not exist, in the same
+// database transaction as the Transactions, so a failed import leaves no Payees behind. The files attached to
+// transactions of the external account linked to the Account are then imported too, see ImportExternalAttachments.
+// Only Transactions imported from the linked external account record its Integration, so that only their changes are
+// written back to it, see UpdateTransactions.
+func (s Service) ImportTransactions(ctx context.Context, accountID string, transactions []*budgit.ExternalTransaction) ([]*budgit.Transaction, error) {
+	var (
+		toImport []*budgit.ExternalTransaction
+		imported []*budgit.Transaction
+	)
+	created := []*budgit.Transaction{}
+	err := s.inTx(ctx, func(conn Conn) error {
+		candidates, err := s.previewImport(ctx, conn, accountID, transactions)
+		if err != nil {
+			return err
+		}
+
+		toImport = make([]*budgit.ExternalTransaction, 0, len(candidates))
+		payeeNames := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			if !candidate.Duplicate {
+				toImport = append(toImport, candidate.Transaction)
+				payeeNames = append(payeeNames, importedPayeeName(candidate.Transaction))
+			}
+		}
+		if len(toImport) == 0 {
+			return nil
+		}
+
+		payeeIDsByName, err := s.payeeIDsByName(ctx, conn, payeeNames...)
+		if err != nil {
+			return err
+		}
+		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, accountID)
+		if err != nil {
+			return err
+		}
+		externalAccount := dbconvert.ToAccounts(dbAccounts[accountID])[0].ExternalAccount
+
+		imported = make([]*budgit.Transaction, 0, len(toImport))
+		for _, transaction := range toImport {
+			memo := transaction.Memo
+			if memo == "" {
+				memo = transaction.Reference
+			}
+			importIntegrationID := ""
+			if isFromExternalAccount(transaction, externalAccount) {
+				importIntegrationID = transaction.IntegrationID
+			}
+			imported = append(imported, &budgit.Transaction{
+				ID:                  uuid.New().String(),
+				EffectiveDate:       transaction.EffectiveDate,
+				AccountID:           accountID,
+				PayeeID:             payeeIDsByName[importedPayeeName(transaction)],
+				Amount:              transaction.Amount,
+				Cleared:             transaction.Cleared,
+				Memo:                memo,
+				ImportID:            transaction.ID,
+				ImportIntegrationID: importIntegrationID,
+			})
+		}
+		created, err = s.createTransactions(ctx, conn, imported...)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("importing transactions into account %q: %w", accountID, err)
+	}
+	if len(imported) != 0 {
+		s.importLinkedAttachments(ctx, accountID, toImport, imported)
+	}
+	return created, nil
+}
+
+// ImportExternalTransactions imports the transactions since the given time of the external account linked to an
+// Account, along with the files attached to them, skipping those already imported, see ImportTransactions.
+func (s Service) ImportExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.Transaction, error) {
+	transactions, err := s.ListExternalTransactions(ctx, accountID, since)
+	if err != nil {
+		return nil, fmt.Errorf("importing external transactions into account %q: %w", accountID, err)
+	}
+	return s.ImportTransactions(ctx, accountID, transactions)
+}
+
+// importLinkedAttachments imports the files attached to the imported transactions which came from the external account
+// linked to an Account, if its integration is an AttachmentImporter. The Transactions are imported whether or not their
+// files can be, and ImportExternalAttachments skips those already imported, so failures are only logged.
+func (s Service) importLinkedAttachments(ctx context.Context, accountID string, externalTransactions []*budgit.ExternalTransaction, transactions []*budgit.Transaction) {
+	externalAccount, err := s.linkedAccount(ctx, accountID)
+	if err != nil {
+		return
+	}
+	if _, ok := s.integrations[externalAccount.IntegrationID].(AttachmentImporter); !ok {
+		return
+	}
+	for i, externalTransaction := range externalTransactions {
+		if !isFromExternalAccount(externalTransaction, externalAccount) {
+			continue
+		}
+		transaction := transactions[i]
+		if _, err := s.ImportExternalAttachments(ctx, accountID, transaction.ID, transaction.ImportID); err != nil {
+			s.log.Warnw("Importing attachments of imported transaction", zap.String("transaction_id", transaction.ID), zap.Error(err))
+		}
+	}
+}
+
+// StatementReconciliationError is returned alongside imported transactions when the closing balance of the statement
+// they were imported from does not match the cleared balance of the Account, e.g. because earlier statements have not
+// been imported.
+type StatementReconciliationError struct {
+	AccountName                      string
+	StatementDate                    time.Time
+	StatementBalance, AccountBalance budgit.BalanceAmount
+}
+
+func (e StatementReconciliationError) Error() string {
+	return fmt.Sprintf("reconciling Account %q failed, balance %s of statement on %s does not match cleared balance of internal account %s", e.AccountName, e.StatementBalance, e.StatementDate.Format(time.DateOnly), e.AccountBalance)
+}
+
+// reconcileStatement returns a StatementReconciliationError if the cleared balance of an Account does not match the
+// closing balance of a statement.
+func (s Service) reconcileStatement(ctx context.Context, accountID string, statementBalance budgit.BalanceAmount, statementDate time.Time) error {
+	dbAccounts, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Account, error) {
+		return s.db.SelectAccountsByID(ctx, conn, accountID)
+	})
+	if err != nil {
+		return fmt.Errorf("reconciling account %q: %w", accountID, err)
+	}
+	dbAccount, ok := dbAccounts[accountID]
+	if !ok {
+		return fmt.Errorf("reconciling account %q: %w", accountID, ErrAccountNotFound)
+	}
+	account := dbconvert.ToAccounts(dbAccount)[0]
+	if account.Balance.ClearedBalance != statementBalance {
+		return StatementReconciliationError{
+			AccountName:      account.Name,
+			StatementDate:    statementDate,
+			StatementBalance: statementBalance,
+			AccountBalance:   account.Balance.ClearedBalance,
+		}
+	}
+	return nil
+}
+
+// payeeIDsByName returns the IDs of the Payees with the given names, creating those which do not exist within a
+// transaction.
+func (s Service) payeeIDsByName(ctx context.Context, conn Conn, names ...string) (map[string]string, error) {
+	uniqueNames := deduplicate(names)
+	dbPayees, err := s.db.SelectPayeesByName(ctx, conn, uniqueNames...)
+	if err != nil {
+		return nil, err
+	}
+
+	idsByName := make(map[string]string, len(uniqueNames))
+	for name, dbPayee := range dbPayees {
+		idsByName[name] = dbPayee.ID.String
+	}
+	missingPayees := []*budgit.Payee{}
+	for _, name := range uniqueNames {
+		if _, ok := idsByName[name]; !ok {
+			payee := &budgit.Payee{ID: uuid.New().String(), Name: name}
+			missingPayees = append(missingPayees, payee)
+			idsByName[name] = payee.ID
+		}
+	}
+	if len(missingPayees) != 0 {
+		if _, err := s.createPayees(ctx, conn, missingPayees...); err != nil {
+			return nil, err
+		}
+	}
+	return idsByName, nil
+}
+
+// accountIDsByName returns the IDs of the Accounts with the given names, creating those which do not exist within a
+// transaction, which are also returned. Empty names are ignored.
+func (s Service) accountIDsByName(ctx context.Context, conn Conn, names ...string) (map[string]string, []*budgit.Account, error) {
+	dbAccounts, err := s.db.SelectAccounts(ctx, conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	accounts := dbconvert.ToAccounts(dbAccounts...)
+	idsByName := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		idsByName[account.Name] = account.ID
+	}
+
+	missingAccounts := []*budgit.Account{}
+	for _, name := range deduplicate(names) {
+		if _, ok := idsByName[name]; !ok && name != "" {
+			account := &budgit.Account{ID: uuid.New().String(), Name: name}
+			missingAccounts = append(missingAccounts, account)
+			idsByName[name] = account.ID
+		}
+	}
+	if len(missingAccounts) != 0 {
+		if _, err := s.createAccounts(ctx, conn, missingAccounts...); err != nil {
+			return nil, nil, err
+		}
+	}
+	return idsByName, missingAccounts, nil
+}
+
+// transferSide identifies a side of a transfer between Accounts.
+type transferSide struct {
+	accountID, otherAccountID string
+	date                      time.Time
+	amount                    budgit.BalanceAmount
+}
+
+// transferMatcher finds the other sides of transfers in files holding the registers of both Accounts of a transfer.
+// Only one side is to be imported, as CreateTransactions creates the other as its mirror.
+type transferMatcher map[transferSide]int
+
+// isMirror returns whether a transfer from an Account is the other side of a transfer already seen, and otherwise
+// records it, so that its own other side is found.
+func (m transferMatcher) isMirror(accountID, otherAccountID string, date time.Time, amount budgit.BalanceAmount) bool {
+	side := transferSide{accountID: accountID, otherAccountID: otherAccountID, date: date, amount: amount}
+	if m[side] > 0 {
+		m[side]--
+		return true
+	}
+	m[transferSide{accountID: otherAccountID, otherAccountID: accountID, date: date, amount: -amount}]++
+	return false
+}
+
+func importedPayeeName(transaction *budgit.ExternalTransaction) string {
+	if transaction.PayeeName == "" {
+		return unknownPayeeName
+	}
+	return transaction.PayeeName
+}
+
+func markDuplicates(existing []*budgit.Transaction, transactions []*budgit.ExternalTransaction) []*ImportCandidate {
+	type dateAmount struct {
+		date   string
+		amount budgit.BalanceAmount
+	}
+	importIDs := make(map[string]bool, len(existing))
+	unimported := make(map[dateAmount]int, len(existing))
+	for _, transaction := range existing {
+		if transaction.ImportID != "" {
+			importIDs[transaction.ImportID] = true
+		} else {
+			unimported[dateAmount{transaction.EffectiveDate.Format("2006-01-02"), transaction.Amount}]++
+		}
+	}
+
+	candidates := make([]*ImportCandidate, 0, len(transactions))
+	for _, transaction := range transactions {
+		candidate := &ImportCandidate{Transaction: transaction}
+		key := dateAmount{transaction.EffectiveDate.Format("2006-01-02"), transaction.Amount}
+		switch {
+		case importIDs[transaction.ID]:
+			candidate.Duplicate = true
+		case unimported[key] > 0:
+			candidate.Duplicate = true
+			unimported[key]--
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// isFromExternalAccount returns whether a transaction came from an external account, which may be nil, rather than
+// from a file.
+func isFromExternalAccount(transaction *budgit.ExternalTransaction, externalAccount *budgit.ExternalAccount) bool {
+	return externalAccount != nil && transaction.IntegrationID == externalAccount.IntegrationID && transaction.ExternalAccountID == externalAccount.ID
+}
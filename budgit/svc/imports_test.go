@@ -0,0 +1,156 @@
+package svc_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+)
+
+func june(day int) time.Time {
+	return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *svcSuite) TestPreviewImportMarksDuplicates() {
+	ctx := s.localContext()
+	accounts, err := s.service.CreateAccounts(ctx, &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+	s.Require().NoError(err)
+	accountID := accounts[0].ID
+	payees, err := s.service.CreatePayees(ctx, &budgit.Payee{ID: uuid.New().String(), Name: "Tesco"})
+	s.Require().NoError(err)
+
+	_, err = s.service.ImportTransactions(ctx, accountID, []*budgit.ExternalTransaction{
+		{ID: "statement-1", EffectiveDate: june(1), PayeeName: "Tesco", Amount: -1000, Cleared: true},
+	})
+	s.Require().NoError(err)
+	_, err = s.service.CreateTransactions(ctx,
+		&budgit.Transaction{ID: uuid.New().String(), EffectiveDate: june(3), AccountID: accountID, PayeeID: payees[0].ID, Amount: -500},
+		&budgit.Transaction{ID: uuid.New().String(), EffectiveDate: june(5), AccountID: accountID, PayeeID: payees[0].ID, Amount: -700},
+	)
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name        string
+		transaction *budgit.ExternalTransaction
+		duplicate   bool
+	}{
+		{
+			name:        "SameImportID",
+			transaction: &budgit.ExternalTransaction{ID: "statement-1", EffectiveDate: june(2), PayeeName: "Tesco", Amount: -999},
+			duplicate:   true,
+		},
+		{
+			name:        "SameDateAndAmountAsImported",
+			transaction: &budgit.ExternalTransaction{ID: "statement-2", EffectiveDate: june(1), PayeeName: "Tesco", Amount: -1000},
+			duplicate:   false,
+		},
+		{
+			name:        "SameDateAndAmountAsNotImported",
+			transaction: &budgit.ExternalTransaction{ID: "statement-3", EffectiveDate: june(3), PayeeName: "Aldi", Amount: -500},
+			duplicate:   true,
+		},
+		{
+			name:        "SecondMatchOfNotImported",
+			transaction: &budgit.ExternalTransaction{ID: "statement-4", EffectiveDate: june(3), PayeeName: "Aldi", Amount: -500},
+			duplicate:   false,
+		},
+		{
+			name:        "NextDay",
+			transaction: &budgit.ExternalTransaction{ID: "statement-5", EffectiveDate: june(6), PayeeName: "Tesco", Amount: -700},
+			duplicate:   false,
+		},
+		{
+			name:        "DifferentAmount",
+			transaction: &budgit.ExternalTransaction{ID: "statement-6", EffectiveDate: june(5), PayeeName: "Tesco", Amount: -701},
+			duplicate:   false,
+		},
+	}
+	transactions := make([]*budgit.ExternalTransaction, 0, len(testCases))
+	for _, testCase := range testCases {
+		transactions = append(transactions, testCase.transaction)
+	}
+
+	candidates, err := s.service.PreviewImport(ctx, accountID, transactions)
+	s.Require().NoError(err)
+	s.Require().Len(candidates, len(testCases))
+	for i, testCase := range testCases {
+		s.Run(testCase.name, func() {
+			s.Equal(testCase.transaction, candidates[i].Transaction)
+			s.Equal(testCase.duplicate, candidates[i].Duplicate)
+		})
+	}
+
+	s.Run("ImportSkipsDuplicates", func() {
+		imported, err := s.service.ImportTransactions(ctx, accountID, transactions)
+		s.Require().NoError(err)
+		importIDs := []string{}
+		for _, transaction := range imported {
+			importIDs = append(importIDs, transaction.ImportID)
+		}
+		s.Equal([]string{"statement-2", "statement-4", "statement-5", "statement-6"}, importIDs)
+
+		imported, err = s.service.ImportTransactions(ctx, accountID, transactions)
+		s.Require().NoError(err)
+		s.Empty(imported)
+	})
+}
+
+func (s *svcSuite) TestImportTransactionsCreatesPayeesWithTransactions() {
+	ctx := s.localContext()
+	accounts, err := s.service.CreateAccounts(ctx, &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+	s.Require().NoError(err)
+
+	viewerCtx := s.memberContext(ctx, "vic", budgit.RoleViewer)
+	_, err = s.service.ImportTransactions(viewerCtx, accounts[0].ID, []*budgit.ExternalTransaction{
+		{ID: "statement-1", EffectiveDate: june(1), PayeeName: "Aldi", Amount: -1000},
+	})
+	s.Require().ErrorIs(err, svc.ErrForbidden)
+
+	imported, err := s.service.ImportTransactions(ctx, accounts[0].ID, []*budgit.ExternalTransaction{
+		{ID: "statement-1", EffectiveDate: june(1), PayeeName: "Aldi", Amount: -1000},
+		{ID: "statement-2", EffectiveDate: june(2), Amount: -250},
+	})
+	s.Require().NoError(err)
+	payees, err := s.service.ListPayees(ctx)
+	s.Require().NoError(err)
+	payeeNames := map[string]string{}
+	for _, payee := range payees {
+		payeeNames[payee.ID] = payee.Name
+	}
+	s.Require().Len(imported, 2)
+	s.Equal("Aldi", payeeNames[imported[0].PayeeID])
+	s.Equal("Unknown", payeeNames[imported[1].PayeeID])
+	s.Len(payees, 2)
+}
+
+func (s *svcSuite) TestFailedQIFImportCreatesNothing() {
+	ctx := s.localContext()
+	// The register without an account name needs an Account to import into, which is only found missing once the
+	// Account of the named register has been created.
+	qif := "!Type:Bank\nD6/01/2024\nT-10.00\nPTesco\nLGroceries\n^\n!Account\nNSavings\nTBank\n^\n"
+
+	_, err := s.service.ImportQIF(ctx, strings.NewReader(qif), "", fileimport.QIFOptions{})
+	s.Require().ErrorIs(err, svc.ErrQIFAccountRequired)
+	accounts, err := s.service.ListAccounts(ctx)
+	s.Require().NoError(err)
+	s.Empty(accounts, "expected the Accounts created by the failed import to be rolled back")
+
+	s.Run("Retried", func() {
+		current, err := s.service.CreateAccounts(ctx, &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+		s.Require().NoError(err)
+		imported, err := s.service.ImportQIF(ctx, strings.NewReader(qif), current[0].ID, fileimport.QIFOptions{})
+		s.Require().NoError(err)
+		s.Len(imported, 1)
+
+		accounts, err := s.service.ListAccounts(ctx)
+		s.Require().NoError(err)
+		names := []string{}
+		for _, account := range accounts {
+			names = append(names, account.Name)
+		}
+		s.ElementsMatch([]string{"Current", "Savings"}, names)
+	})
+}
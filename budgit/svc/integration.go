@@ -2,7 +2,12 @@ package svc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/andrewthowell/budgit/budgit"
 	"github.com/andrewthowell/budgit/budgit/db"
@@ -12,20 +17,140 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Integration is an external source of accounts, such as a bank. Every Integration can list its accounts,
+// and may implement further capability interfaces, such as TransactionImporter, which are discovered at runtime.
 type Integration interface {
 	ID() string
 	GetExternalAccounts(ctx context.Context) ([]*budgit.ExternalAccount, error)
 	GetExternalAccount(ctx context.Context, externalID string) (*budgit.ExternalAccount, error)
 }
 
+// TransactionImporter is an Integration which can list the transactions of its external accounts.
+type TransactionImporter interface {
+	Integration
+	GetExternalTransactions(ctx context.Context, externalAccountID string, since time.Time) ([]*budgit.ExternalTransaction, error)
+}
+
+// ScheduledPaymentLister is an Integration which can list the scheduled payments of its external accounts.
+type ScheduledPaymentLister interface {
+	Integration
+	GetScheduledPayments(ctx context.Context, externalAccountID string) ([]*budgit.ScheduledPayment, error)
+}
+
+// TransactionWriter is an Integration which can write changes to the transactions of its external accounts,
+// such as memos and categories, back to them.
+type TransactionWriter interface {
+	Integration
+	UpdateExternalTransaction(ctx context.Context, externalAccountID, externalTransactionID string, update budgit.ExternalTransactionUpdate) error
+}
+
+// AttachmentImporter is an Integration which can list and download the files, such as receipts, attached to the
+// transactions of its external accounts. DownloadExternalAttachment returns the content type of the file if known,
+// which takes precedence over the one listed.
+type AttachmentImporter interface {
+	Integration
+	GetExternalAttachments(ctx context.Context, externalAccountID, externalTransactionID string) ([]*budgit.ExternalAttachment, error)
+	DownloadExternalAttachment(ctx context.Context, externalAccountID string, attachment *budgit.ExternalAttachment) (io.ReadCloser, string, error)
+}
+
+// Capability names an optional interface an Integration may implement.
+type Capability string
+
+const (
+	CapabilityTransactionImport Capability = "transaction_import"
+	CapabilityScheduledPayments Capability = "scheduled_payments"
+	CapabilityTransactionWrite  Capability = "transaction_write"
+	CapabilityAttachmentImport  Capability = "attachment_import"
+)
+
+// capabilitiesOf returns the Capabilities implemented by an Integration.
+func capabilitiesOf(integration Integration) []Capability {
+	capabilities := []Capability{}
+	if _, ok := integration.(TransactionImporter); ok {
+		capabilities = append(capabilities, CapabilityTransactionImport)
+	}
+	if _, ok := integration.(ScheduledPaymentLister); ok {
+		capabilities = append(capabilities, CapabilityScheduledPayments)
+	}
+	if _, ok := integration.(TransactionWriter); ok {
+		capabilities = append(capabilities, CapabilityTransactionWrite)
+	}
+	if _, ok := integration.(AttachmentImporter); ok {
+		capabilities = append(capabilities, CapabilityAttachmentImport)
+	}
+	return capabilities
+}
+
+// IntegrationInfo describes a configured Integration.
+type IntegrationInfo struct {
+	ID           string
+	Capabilities []Capability
+}
+
+var ErrIntegrationNotFound = fmt.Errorf("the requested Integration is not configured")
+
+type UnsupportedCapabilityError struct {
+	IntegrationID string
+	Capability    Capability
+}
+
+func (e UnsupportedCapabilityError) Error() string {
+	return fmt.Sprintf("integration %q does not support %s", e.IntegrationID, e.Capability)
+}
+
+// ListIntegrations returns the configured Integrations, sorted by ID, with the Capabilities each supports.
+func (s Service) ListIntegrations() []IntegrationInfo {
+	infos := make([]IntegrationInfo, 0, len(s.integrations))
+	for id, integration := range s.integrations {
+		infos = append(infos, IntegrationInfo{ID: id, Capabilities: capabilitiesOf(integration)})
+	}
+	slices.SortFunc(infos, func(a, b IntegrationInfo) int { return strings.Compare(a.ID, b.ID) })
+	return infos
+}
+
+func (s Service) integration(integrationID string) (Integration, error) {
+	integration, ok := s.integrations[integrationID]
+	if !ok {
+		return nil, fmt.Errorf("integration %q: %w", integrationID, ErrIntegrationNotFound)
+	}
+	return integration, nil
+}
+
+// integrationAs returns the Integration with the given ID as the capability interface C,
+// or an UnsupportedCapabilityError if it does not implement it.
+func integrationAs[C Integration](s Service, integrationID string, capability Capability) (C, error) {
+	var zero C
+	integration, err := s.integration(integrationID)
+	if err != nil {
+		return zero, err
+	}
+	capable, ok := integration.(C)
+	if !ok {
+		return zero, UnsupportedCapabilityError{IntegrationID: integrationID, Capability: capability}
+	}
+	return capable, nil
+}
+
+// LoadAccountsFromIntegration creates an Account for each account of an Integration. External accounts already linked in
+// a budget the Principal of the context may not access are skipped, see authorizeExternalAccount.
 func (s Service) LoadAccountsFromIntegration(ctx context.Context, integrationID string) ([]*budgit.Account, error) {
-	externalAccounts, err := s.integrations[integrationID].GetExternalAccounts(ctx)
+	integration, err := s.integration(integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("loading accounts: %w", err)
+	}
+	externalAccounts, err := integration.GetExternalAccounts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("loading accounts from %q: %w", integrationID, err)
 	}
 
 	accounts := make([]*budgit.Account, 0, len(externalAccounts))
 	for _, externalAccount := range externalAccounts {
+		if err := s.authorizeExternalAccount(ctx, externalAccount); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				continue
+			}
+			return nil, fmt.Errorf("loading accounts from %q: %w", integrationID, err)
+		}
 		accounts = append(accounts, &budgit.Account{
 			ID:              uuid.New().String(),
 			Name:            fmt.Sprintf("%s - %s", externalAccount.IntegrationID, externalAccount.Name),
@@ -43,6 +168,8 @@ func (s Service) LoadAccountsFromIntegration(ctx context.Context, integrationID
 
 		dbAccounts := dbconvert.FromAccounts(accounts...)
 		for _, dbAccount := range dbAccounts {
+			dbAccount.RequestID = newRequestID()
+			dbAccount.CreatedBy = createdBy(ctx)
 			dbAccount.ValidFromTimestamp = now
 			dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
 			dbAccount.ExternalLastSyncTimestamp = now
@@ -52,6 +179,9 @@ func (s Service) LoadAccountsFromIntegration(ctx context.Context, integrationID
 		if _, err := s.db.InsertAccounts(ctx, conn, dbAccounts...); err != nil {
 			return err
 		}
+		if err := s.insertExternalAccountLinks(ctx, conn, now, accounts...); err != nil {
+			return err
+		}
 		createdAccounts = accounts
 		return nil
 	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
@@ -71,12 +201,15 @@ func (e AccountSyncError) Error() string {
 }
 
 var (
-	ErrAccountNotFound  = fmt.Errorf("the requested Account does not exist")
-	ErrAccountNotLinked = fmt.Errorf("the requested Account is not linked to an external account and cannot be synced")
+	ErrAccountNotFound      = fmt.Errorf("the requested Account does not exist")
+	ErrAccountNotLinked     = fmt.Errorf("the requested Account is not linked to an external account and cannot be synced")
+	ErrAccountAlreadyLinked = fmt.Errorf("the requested Account is already linked to an external account")
 )
 
 func (s Service) SyncAccount(ctx context.Context, accountID string) error {
-	dbAccounts, err := s.db.SelectAccountsByID(ctx, s.conn, accountID)
+	dbAccounts, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Account, error) {
+		return s.db.SelectAccountsByID(ctx, conn, accountID)
+	})
 	if err != nil {
 		return fmt.Errorf("syncing account %q: %w", accountID, err)
 	}
@@ -90,8 +223,15 @@ func (s Service) SyncAccount(ctx context.Context, accountID string) error {
 		// Account is not linked, no need to sync
 		return ErrAccountNotLinked
 	}
+	if err := s.authorizeExternalAccount(ctx, account.ExternalAccount); err != nil {
+		return fmt.Errorf("syncing account %q: %w", accountID, err)
+	}
 
-	externalAccount, err := s.integrations[account.ExternalAccount.IntegrationID].GetExternalAccount(ctx, account.ExternalAccount.ID)
+	integration, err := s.integration(account.ExternalAccount.IntegrationID)
+	if err != nil {
+		return fmt.Errorf("syncing account %q: %w", accountID, err)
+	}
+	externalAccount, err := integration.GetExternalAccount(ctx, account.ExternalAccount.ID)
 	if err != nil {
 		return fmt.Errorf("syncing account %q: %w", accountID, err)
 	}
@@ -102,6 +242,7 @@ func (s Service) SyncAccount(ctx context.Context, accountID string) error {
 			InternalBalance: account.Balance,
 		}
 	}
+	externalAccount.WriteBack = account.ExternalAccount.WriteBack
 	account.ExternalAccount = externalAccount
 
 	err = s.inTx(ctx, func(conn Conn) error {
@@ -110,7 +251,7 @@ func (s Service) SyncAccount(ctx context.Context, accountID string) error {
 			return err
 		}
 
-		if _, err := s.db.UpdateAccountValidToTimestamps(ctx, s.conn, db.ValidToTimestampUpdate{
+		if _, err := s.db.UpdateAccountValidToTimestamps(ctx, conn, db.ValidToTimestampUpdate{
 			ID:               dbAccount.ID,
 			ValidToTimestamp: now,
 		}); err != nil {
@@ -118,12 +259,14 @@ func (s Service) SyncAccount(ctx context.Context, accountID string) error {
 		}
 
 		dbAccount := dbconvert.FromAccounts(account)[0]
+		dbAccount.RequestID = newRequestID()
+		dbAccount.CreatedBy = createdBy(ctx)
 		dbAccount.ValidFromTimestamp = now
 		dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
 		dbAccount.ExternalLastSyncTimestamp = now
 
 		// TODO: check for accounts not being inserted
-		if _, err := s.db.InsertAccounts(ctx, s.conn, dbAccount); err != nil {
+		if _, err := s.db.InsertAccounts(ctx, conn, dbAccount); err != nil {
 			return fmt.Errorf("syncing account %q: %w", accountID, err)
 		}
 		return nil
@@ -133,3 +276,254 @@ func (s Service) SyncAccount(ctx context.Context, accountID string) error {
 	}
 	return nil
 }
+
+// LinkAccount links an existing Account to an account of an Integration, so that it can be synced. The balance of the
+// Account is kept, so a SyncAccount which follows fails until any difference from the external account is reconciled.
+func (s Service) LinkAccount(ctx context.Context, accountID, integrationID, externalAccountID string) (*budgit.Account, error) {
+	dbAccounts, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Account, error) {
+		return s.db.SelectAccountsByID(ctx, conn, accountID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, err)
+	}
+	dbAccount, ok := dbAccounts[accountID]
+	if !ok {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, ErrAccountNotFound)
+	}
+	account := dbconvert.ToAccounts(dbAccount)[0]
+	if account.ExternalAccount != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, ErrAccountAlreadyLinked)
+	}
+
+	integration, err := s.integration(integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, err)
+	}
+	externalAccount, err := integration.GetExternalAccount(ctx, externalAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, err)
+	}
+	if err := s.authorizeExternalAccount(ctx, externalAccount); err != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, err)
+	}
+	account.ExternalAccount = externalAccount
+
+	err = s.inTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.db.UpdateAccountValidToTimestamps(ctx, conn, db.ValidToTimestampUpdate{
+			ID:               dbAccount.ID,
+			ValidToTimestamp: now,
+		}); err != nil {
+			return err
+		}
+
+		dbAccount := dbconvert.FromAccounts(account)[0]
+		dbAccount.RequestID = newRequestID()
+		dbAccount.CreatedBy = createdBy(ctx)
+		dbAccount.ValidFromTimestamp = now
+		dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+		dbAccount.ExternalLastSyncTimestamp = now
+
+		// TODO: check for accounts not being inserted
+		if _, err := s.db.InsertAccounts(ctx, conn, dbAccount); err != nil {
+			return err
+		}
+		if err := s.insertExternalAccountLinks(ctx, conn, now, account); err != nil {
+			return err
+		}
+		account.ExternalAccount.LastSyncTimestamp = now.Time
+		return nil
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("linking account %q: %w", accountID, err)
+	}
+	return account, nil
+}
+
+// linkedAccount returns the external account linked to the Account with the given ID.
+func (s Service) linkedAccount(ctx context.Context, accountID string) (*budgit.ExternalAccount, error) {
+	dbAccounts, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Account, error) {
+		return s.db.SelectAccountsByID(ctx, conn, accountID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	dbAccount, ok := dbAccounts[accountID]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	account := dbconvert.ToAccounts(dbAccount)[0]
+	if account.ExternalAccount == nil {
+		return nil, ErrAccountNotLinked
+	}
+	if err := s.authorizeExternalAccount(ctx, account.ExternalAccount); err != nil {
+		return nil, err
+	}
+	return account.ExternalAccount, nil
+}
+
+// authorizeExternalAccount returns ErrForbidden if an external account is linked in a budget, other than that of the
+// context, which the Principal of the context has no role in. Integrations are configured for the whole server, so
+// this stops a User reading the accounts of an Integration which others have linked to their own budgets.
+func (s Service) authorizeExternalAccount(ctx context.Context, externalAccount *budgit.ExternalAccount) error {
+	budgetID, ok := BudgetFrom(ctx)
+	if !ok {
+		return ErrBudgetRequired
+	}
+	dbLinks, err := s.db.SelectExternalAccountLinks(ctx, s.userConn, externalAccount.IntegrationID, externalAccount.ID)
+	if err != nil {
+		return err
+	}
+	for _, dbLink := range dbLinks {
+		if dbLink.BudgetID.String == budgetID {
+			continue
+		}
+		if _, err := budgetRole(ctx, s.db, s.userConn, dbLink.BudgetID.String); err != nil {
+			if errors.Is(err, ErrBudgetNotFound) {
+				return fmt.Errorf("external account %q of integration %q is linked in another budget: %w", externalAccount.ID, externalAccount.IntegrationID, ErrForbidden)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// insertExternalAccountLinks records the external accounts linked to Accounts in the budget of the context.
+func (s Service) insertExternalAccountLinks(ctx context.Context, conn Conn, now pgtype.Timestamptz, accounts ...*budgit.Account) error {
+	budgetID, ok := BudgetFrom(ctx)
+	if !ok {
+		return ErrBudgetRequired
+	}
+	dbLinks := make([]*db.ExternalAccountLink, 0, len(accounts))
+	for _, account := range accounts {
+		dbLinks = append(dbLinks, &db.ExternalAccountLink{
+			IntegrationID:     pgtype.Text{String: account.ExternalAccount.IntegrationID, Valid: true},
+			ExternalAccountID: pgtype.Text{String: account.ExternalAccount.ID, Valid: true},
+			BudgetID:          pgtype.Text{String: budgetID, Valid: true},
+			CreatedBy:         createdBy(ctx),
+			CreatedTimestamp:  now,
+		})
+	}
+	_, err := s.db.InsertExternalAccountLinks(ctx, conn, dbLinks...)
+	return err
+}
+
+// ListExternalTransactions returns the transactions since the given time of the external account linked to an Account.
+func (s Service) ListExternalTransactions(ctx context.Context, accountID string, since time.Time) ([]*budgit.ExternalTransaction, error) {
+	externalAccount, err := s.linkedAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("listing external transactions of account %q: %w", accountID, err)
+	}
+	importer, err := integrationAs[TransactionImporter](s, externalAccount.IntegrationID, CapabilityTransactionImport)
+	if err != nil {
+		return nil, fmt.Errorf("listing external transactions of account %q: %w", accountID, err)
+	}
+	transactions, err := importer.GetExternalTransactions(ctx, externalAccount.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("listing external transactions of account %q: %w", accountID, err)
+	}
+	return transactions, nil
+}
+
+// ListScheduledPayments returns the scheduled payments of the external account linked to an Account.
+func (s Service) ListScheduledPayments(ctx context.Context, accountID string) ([]*budgit.ScheduledPayment, error) {
+	externalAccount, err := s.linkedAccount(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled payments of account %q: %w", accountID, err)
+	}
+	lister, err := integrationAs[ScheduledPaymentLister](s, externalAccount.IntegrationID, CapabilityScheduledPayments)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled payments of account %q: %w", accountID, err)
+	}
+	payments, err := lister.GetScheduledPayments(ctx, externalAccount.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled payments of account %q: %w", accountID, err)
+	}
+	return payments, nil
+}
+
+// SetAccountWriteBack opts an Account in or out of writing changes to its imported transactions back to its linked
+// external account. Opting in requires the integration of the external account to be a TransactionWriter.
+func (s Service) SetAccountWriteBack(ctx context.Context, accountID string, writeBack bool) error {
+	dbAccounts, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Account, error) {
+		return s.db.SelectAccountsByID(ctx, conn, accountID)
+	})
+	if err != nil {
+		return fmt.Errorf("setting write back of account %q: %w", accountID, err)
+	}
+	dbAccount, ok := dbAccounts[accountID]
+	if !ok {
+		return fmt.Errorf("setting write back of account %q: %w", accountID, ErrAccountNotFound)
+	}
+	account := dbconvert.ToAccounts(dbAccount)[0]
+	if account.ExternalAccount == nil {
+		return fmt.Errorf("setting write back of account %q: %w", accountID, ErrAccountNotLinked)
+	}
+	if writeBack {
+		if _, err := integrationAs[TransactionWriter](s, account.ExternalAccount.IntegrationID, CapabilityTransactionWrite); err != nil {
+			return fmt.Errorf("setting write back of account %q: %w", accountID, err)
+		}
+	}
+	if account.ExternalAccount.WriteBack == writeBack {
+		return nil
+	}
+	account.ExternalAccount.WriteBack = writeBack
+
+	err = s.inTx(ctx, func(conn Conn) error {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.db.UpdateAccountValidToTimestamps(ctx, conn, db.ValidToTimestampUpdate{
+			ID:               dbAccount.ID,
+			ValidToTimestamp: now,
+		}); err != nil {
+			return err
+		}
+
+		dbAccount := dbconvert.FromAccounts(account)[0]
+		dbAccount.RequestID = newRequestID()
+		dbAccount.CreatedBy = createdBy(ctx)
+		dbAccount.ValidFromTimestamp = now
+		dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+
+		// TODO: check for accounts not being inserted
+		if _, err := s.db.InsertAccounts(ctx, conn, dbAccount); err != nil {
+			return err
+		}
+		return nil
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return fmt.Errorf("setting write back of account %q: %w", accountID, err)
+	}
+	return nil
+}
+
+// WriteBackExternalTransaction writes a change to an imported transaction back to the external account linked to
+// an Account. It does nothing unless the Account has opted in, see SetAccountWriteBack, so UpdateTransactions calls it
+// whenever an imported transaction is categorised or annotated. Writing back needs at least the editor role.
+func (s Service) WriteBackExternalTransaction(ctx context.Context, accountID, externalTransactionID string, update budgit.ExternalTransactionUpdate) error {
+	if _, err := s.authorizeBudget(ctx, budgit.RoleEditor); err != nil {
+		return fmt.Errorf("writing back external transaction %q of account %q: %w", externalTransactionID, accountID, err)
+	}
+	externalAccount, err := s.linkedAccount(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("writing back external transaction %q of account %q: %w", externalTransactionID, accountID, err)
+	}
+	if !externalAccount.WriteBack {
+		return nil
+	}
+	writer, err := integrationAs[TransactionWriter](s, externalAccount.IntegrationID, CapabilityTransactionWrite)
+	if err != nil {
+		return fmt.Errorf("writing back external transaction %q of account %q: %w", externalTransactionID, accountID, err)
+	}
+	if err := writer.UpdateExternalTransaction(ctx, externalAccount.ID, externalTransactionID, update); err != nil {
+		return fmt.Errorf("writing back external transaction %q of account %q: %w", externalTransactionID, accountID, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/andrewthowell/budgit/budgit/journal"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/exp/maps"
+)
+
+// ExportJournal writes the current Accounts, Payees and Transactions as a ledger, hledger or beancount journal, with
+// amounts in the given currency. They are read in a single transaction, so that the journal balances.
+func (s Service) ExportJournal(ctx context.Context, w io.Writer, format journal.Format, currency string) error {
+	budget := &journal.Budget{Currency: currency}
+	err := s.inTx(ctx, func(conn Conn) error {
+		dbAccounts, err := s.db.SelectAccounts(ctx, conn)
+		if err != nil {
+			return err
+		}
+		budget.Accounts = dbconvert.ToAccounts(dbAccounts...)
+
+		payeeIDs := []string{}
+		for _, account := range budget.Accounts {
+			dbTransactions, err := s.db.SelectTransactionsByAccount(ctx, conn, account.ID)
+			if err != nil {
+				return err
+			}
+			for _, transaction := range dbconvert.ToTransactions(dbTransactions...) {
+				if !transaction.IsPayeeInternal {
+					payeeIDs = append(payeeIDs, transaction.PayeeID)
+				}
+				budget.Transactions = append(budget.Transactions, transaction)
+			}
+		}
+
+		dbPayees, err := s.db.SelectPayeesByID(ctx, conn, deduplicate(payeeIDs)...)
+		if err != nil {
+			return err
+		}
+		budget.Payees = dbconvert.ToPayees(maps.Values(dbPayees)...)
+
+		dbGroups, err := s.db.SelectCategoryGroups(ctx, conn)
+		if err != nil {
+			return err
+		}
+		groupNames := make(map[string]string, len(dbGroups))
+		for _, group := range dbconvert.ToCategoryGroups(dbGroups...) {
+			groupNames[group.ID] = group.Name
+		}
+		dbCategories, err := s.db.SelectCategories(ctx, conn)
+		if err != nil {
+			return err
+		}
+		budget.CategoryPaths = make(map[string]string, len(dbCategories))
+		for _, category := range dbconvert.ToCategories(dbCategories...) {
+			budget.CategoryPaths[category.ID] = budgit.CategoryPath(groupNames[category.GroupID], category.Name)
+		}
+		return nil
+	}, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("exporting %s journal: %w", format, err)
+	}
+
+	if err := journal.Write(w, format, budget); err != nil {
+		return fmt.Errorf("exporting %s journal: %w", format, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+// OFXStatementCountError is returned when importing an OFX document which does not hold exactly one statement.
+type OFXStatementCountError struct {
+	AccountIDs []string
+}
+
+func (e OFXStatementCountError) Error() string {
+	return fmt.Sprintf("OFX document must hold a single statement, but holds statements of accounts %+v", e.AccountIDs)
+}
+
+// PreviewOFXImport parses an OFX or QFX statement and returns its transactions, marking those already imported into an
+// Account by their FITID.
+func (s Service) PreviewOFXImport(ctx context.Context, accountID string, content io.Reader) ([]*ImportCandidate, error) {
+	statement, err := parseOFXStatement(content)
+	if err != nil {
+		return nil, fmt.Errorf("previewing OFX import into account %q: %w", accountID, err)
+	}
+	return s.PreviewImport(ctx, accountID, statement.Transactions)
+}
+
+// ImportOFX imports the transactions of an OFX or QFX statement into an Account, skipping those already imported by
+// their FITID. If the statement has a ledger balance which does not match the Account's cleared balance once imported,
+// the imported transactions are returned with a StatementReconciliationError.
+func (s Service) ImportOFX(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error) {
+	statement, err := parseOFXStatement(content)
+	if err != nil {
+		return nil, fmt.Errorf("importing OFX into account %q: %w", accountID, err)
+	}
+	imported, err := s.ImportTransactions(ctx, accountID, statement.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	if statement.HasLedgerBalance {
+		if err := s.reconcileStatement(ctx, accountID, statement.LedgerBalance, statement.LedgerBalanceDate); err != nil {
+			return imported, err
+		}
+	}
+	return imported, nil
+}
+
+func parseOFXStatement(content io.Reader) (*fileimport.OFXStatement, error) {
+	statements, err := fileimport.ParseOFX(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(statements) != 1 {
+		accountIDs := make([]string, 0, len(statements))
+		for _, statement := range statements {
+			accountIDs = append(accountIDs, statement.AccountID)
+		}
+		return nil, OFXStatementCountError{AccountIDs: accountIDs}
+	}
+	return statements[0], nil
+}
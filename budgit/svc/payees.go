@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/andrewthowell/budgit/budgit"
 	"github.com/andrewthowell/budgit/budgit/db"
@@ -15,6 +16,8 @@ import (
 
 type PayeeDB interface {
 	InsertPayees(ctx context.Context, queryer db.Queryer, payee ...*db.Payee) ([]string, error)
+	UpdatePayeeValidToTimestamps(ctx context.Context, queryer db.Queryer, updates ...db.ValidToTimestampUpdate) ([]string, error)
+	SelectPayees(ctx context.Context, queryer db.Queryer) ([]*db.Payee, error)
 	SelectPayeesByID(ctx context.Context, queryer db.Queryer, payeeIDs ...string) (map[string]*db.Payee, error)
 	SelectPayeesByName(ctx context.Context, queryer db.Queryer, payeeNames ...string) (map[string]*db.Payee, error)
 }
@@ -22,32 +25,104 @@ type PayeeDB interface {
 func (s Service) CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
 	var createdPayees []*budgit.Payee
 	err := s.inTx(ctx, func(conn Conn) error {
-		if err := s.validatePayees(ctx, conn, payees...); err != nil {
+		var err error
+		createdPayees, err = s.createPayees(ctx, conn, payees...)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("creating payees: %w", err)
+	}
+	return createdPayees, nil
+}
+
+// createPayees creates Payees within a transaction, see CreatePayees.
+func (s Service) createPayees(ctx context.Context, conn Conn, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
+	if err := s.validatePayees(ctx, conn, payees...); err != nil {
+		return nil, err
+	}
+
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPayees := dbconvert.FromPayees(payees...)
+	for _, dbPayee := range dbPayees {
+		dbPayee.RequestID = newRequestID()
+		dbPayee.CreatedBy = createdBy(ctx)
+		dbPayee.ValidFromTimestamp = now
+		dbPayee.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	// TODO: check for payees not being inserted
+	if _, err := s.db.InsertPayees(ctx, conn, dbPayees...); err != nil {
+		return nil, err
+	}
+	return payees, nil
+}
+
+func (s Service) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	payees, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Payee, error) {
+		return s.db.SelectPayees(ctx, conn)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing payees: %w", err)
+	}
+	return dbconvert.ToPayees(payees...), nil
+}
+
+// MergePayees merges Payees into another, typically duplicates of it under different names. The Transactions of the
+// merged Payees are moved to the Payee, and the merged Payees are ended. It returns the number of Transactions moved.
+func (s Service) MergePayees(ctx context.Context, payeeID string, mergedPayeeIDs ...string) (int, error) {
+	mergedPayeeIDs = slices.DeleteFunc(deduplicate(mergedPayeeIDs), func(id string) bool { return id == payeeID })
+
+	var moved int
+	err := s.inTx(ctx, func(conn Conn) error {
+		payeeIDs := append([]string{payeeID}, mergedPayeeIDs...)
+		foundPayees, err := s.db.SelectPayeesByID(ctx, conn, payeeIDs...)
+		if err != nil {
 			return err
 		}
+		if len(foundPayees) < len(payeeIDs) {
+			return MissingPayeesError{PayeeIDs: symmetricDifference(payeeIDs, maps.Keys(foundPayees))}
+		}
+		if len(mergedPayeeIDs) == 0 {
+			return nil
+		}
 
-		now, err := s.db.Now(ctx, conn)
+		dbTransactions, err := s.db.SelectTransactionsByPayee(ctx, conn, mergedPayeeIDs...)
 		if err != nil {
 			return err
 		}
-
-		dbPayees := dbconvert.FromPayees(payees...)
-		for _, dbPayee := range dbPayees {
-			dbPayee.ValidFromTimestamp = now
-			dbPayee.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+		oldTransactions := dbconvert.ToTransactions(dbTransactions...)
+		newTransactions := make([]*budgit.Transaction, 0, len(oldTransactions))
+		for _, transaction := range oldTransactions {
+			moved := *transaction
+			moved.PayeeID = payeeID
+			newTransactions = append(newTransactions, &moved)
+		}
+		if err := s.replaceTransactions(ctx, conn, oldTransactions, newTransactions); err != nil {
+			return err
 		}
 
-		// TODO: check for payees not being inserted
-		if _, err := s.db.InsertPayees(ctx, conn, dbPayees...); err != nil {
+		now, err := s.db.Now(ctx, conn)
+		if err != nil {
 			return err
 		}
-		createdPayees = payees
+		updates := make([]db.ValidToTimestampUpdate, 0, len(mergedPayeeIDs))
+		for _, id := range mergedPayeeIDs {
+			updates = append(updates, db.ValidToTimestampUpdate{ID: pgtype.Text{String: id, Valid: true}, ValidToTimestamp: now})
+		}
+		if _, err := s.db.UpdatePayeeValidToTimestamps(ctx, conn, updates...); err != nil {
+			return err
+		}
+		moved = len(newTransactions)
 		return nil
 	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
 	if err != nil {
-		return nil, fmt.Errorf("creating payees: %w", err)
+		return 0, fmt.Errorf("merging payees into %q: %w", payeeID, err)
 	}
-	return createdPayees, nil
+	return moved, nil
 }
 
 type DuplicatePayeesError struct {
@@ -71,11 +146,7 @@ func (s Service) validatePayees(ctx context.Context, conn Conn, payees ...*budgi
 	if err != nil {
 		return fmt.Errorf("validating payees: %w", err)
 	}
-	if len(foundDBPayees) < len(uniquePayeeNames) {
-		foundPayeeNames := make([]string, 0, len(foundDBPayees))
-		for _, dbPayee := range foundDBPayees {
-			foundPayeeNames = append(foundPayeeNames, dbconvert.ToPayees(dbPayee)[0].Name)
-		}
+	if len(foundDBPayees) != 0 {
 		duplicateNames := intersection(uniquePayeeNames, maps.Keys(foundDBPayees))
 		errs = append(errs, DuplicatePayeesError{PayeeNames: duplicateNames})
 	}
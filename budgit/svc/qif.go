@@ -0,0 +1,315 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/db/dbconvert"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/exp/maps"
+)
+
+var ErrQIFAccountRequired = fmt.Errorf("the QIF file holds a register without an account name, so an Account to import it into is required")
+
+// ImportQIF imports the categories and account registers of a QIF file. Accounts are matched by name, and created if
+// they do not exist, except for a register without an account name, which is imported into the Account with the given
+// ID. Category paths such as "Bills:Energy" are mapped onto CategoryGroups and Categories, see budgit.SplitCategoryPath,
+// which are created if they do not exist, as are Payees.
+//
+// Split transactions become a Transaction per split, sharing a SplitID. Transfers become Transactions with an internal
+// Payee, and the other side of a transfer is skipped when the file also holds the register of the other Account.
+// Transactions already imported are skipped, see PreviewImport. Everything is created in one database transaction, so an
+// import which fails creates nothing.
+func (s Service) ImportQIF(ctx context.Context, content io.Reader, accountID string, options fileimport.QIFOptions) ([]*budgit.Transaction, error) {
+	file, err := fileimport.ParseQIF(content, options)
+	if err != nil {
+		return nil, fmt.Errorf("importing QIF: %w", err)
+	}
+
+	var created []*budgit.Transaction
+	err = s.inTx(ctx, func(conn Conn) error {
+		accountIDsByName, err := s.qifAccountIDs(ctx, conn, file, accountID)
+		if err != nil {
+			return err
+		}
+		categoryIDsByPath, err := s.categoryIDsByPath(ctx, conn, qifCategoryPaths(file)...)
+		if err != nil {
+			return err
+		}
+
+		importer := &qifImporter{
+			accountIDsByName:  accountIDsByName,
+			categoryIDsByPath: categoryIDsByPath,
+			transfers:         transferMatcher{},
+		}
+		for _, account := range file.Accounts {
+			registerAccountID := accountIDsByName[account.Name]
+			candidates, err := s.previewImport(ctx, conn, registerAccountID, qifExternalTransactions(account))
+			if err != nil {
+				return fmt.Errorf("previewing import into account %q: %w", registerAccountID, err)
+			}
+			for i, candidate := range candidates {
+				if !candidate.Duplicate {
+					importer.add(registerAccountID, candidate.Transaction.ID, account.Transactions[i])
+				}
+			}
+		}
+		if len(importer.transactions) == 0 {
+			created = []*budgit.Transaction{}
+			return nil
+		}
+
+		payeeIDsByName, err := s.payeeIDsByName(ctx, conn, maps.Values(importer.payeeNames)...)
+		if err != nil {
+			return err
+		}
+		for _, transaction := range importer.transactions {
+			if !transaction.IsPayeeInternal {
+				transaction.PayeeID = payeeIDsByName[importer.payeeNames[transaction]]
+			}
+		}
+		created, err = s.createTransactions(ctx, conn, importer.transactions...)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("importing QIF: %w", err)
+	}
+	return created, nil
+}
+
+// qifAccountIDs returns the IDs of the Accounts of the registers and transfers of a QIF file by name, creating those
+// which do not exist within a transaction. The register without a name, if any, is imported into the Account with the
+// given ID.
+func (s Service) qifAccountIDs(ctx context.Context, conn Conn, file *fileimport.QIFFile, accountID string) (map[string]string, error) {
+	names := []string{}
+	hasUnnamedRegister := false
+	for _, account := range file.Accounts {
+		if account.Name == "" {
+			hasUnnamedRegister = true
+			continue
+		}
+		names = append(names, account.Name)
+		for _, transaction := range account.Transactions {
+			names = append(names, transaction.TransferAccount)
+			for _, split := range transaction.Splits {
+				names = append(names, split.TransferAccount)
+			}
+		}
+	}
+
+	idsByName, _, err := s.accountIDsByName(ctx, conn, names...)
+	if err != nil {
+		return nil, err
+	}
+	if hasUnnamedRegister {
+		if accountID == "" {
+			return nil, ErrQIFAccountRequired
+		}
+		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := dbAccounts[accountID]; !ok {
+			return nil, fmt.Errorf("account %q: %w", accountID, ErrAccountNotFound)
+		}
+		idsByName[""] = accountID
+	}
+	return idsByName, nil
+}
+
+func qifCategoryPaths(file *fileimport.QIFFile) []string {
+	paths := []string{}
+	for _, category := range file.Categories {
+		paths = append(paths, category.Path)
+	}
+	for _, account := range file.Accounts {
+		for _, transaction := range account.Transactions {
+			paths = append(paths, transaction.Category)
+			for _, split := range transaction.Splits {
+				paths = append(paths, split.Category)
+			}
+		}
+	}
+	return slices.DeleteFunc(deduplicate(paths), func(path string) bool { return path == "" })
+}
+
+// qifExternalTransactions returns the transactions of a QIF register as ExternalTransactions, to find those already
+// imported. They are given IDs derived from their fields, as QIF files do not identify transactions.
+func qifExternalTransactions(account *fileimport.QIFAccount) []*budgit.ExternalTransaction {
+	transactions := make([]*budgit.ExternalTransaction, 0, len(account.Transactions))
+	for _, transaction := range account.Transactions {
+		transactions = append(transactions, &budgit.ExternalTransaction{
+			EffectiveDate: transaction.Date,
+			PayeeName:     transaction.Payee,
+			Memo:          transaction.Memo,
+			Reference:     transaction.Number,
+			Amount:        transaction.Amount,
+			Cleared:       transaction.Cleared,
+		})
+	}
+	return fileimport.AssignImportIDs("qif", transactions)
+}
+
+type qifImporter struct {
+	accountIDsByName  map[string]string
+	categoryIDsByPath map[string]string
+	transfers         transferMatcher
+	transactions      []*budgit.Transaction
+	// payeeNames holds the name of the Payee of each transaction with an external Payee.
+	payeeNames map[*budgit.Transaction]string
+}
+
+// add adds the Transactions of a QIF transaction of the Account with the given ID, one for each of its splits.
+func (i *qifImporter) add(accountID, importID string, transaction *fileimport.QIFTransaction) {
+	splits := transaction.Splits
+	splitID := ""
+	if len(splits) == 0 {
+		splits = []*fileimport.QIFSplit{{
+			Category:        transaction.Category,
+			TransferAccount: transaction.TransferAccount,
+			Memo:            transaction.Memo,
+			Amount:          transaction.Amount,
+		}}
+	} else {
+		splitID = uuid.New().String()
+	}
+
+	for _, split := range splits {
+		memo := split.Memo
+		if memo == "" {
+			memo = transaction.Memo
+		}
+		added := &budgit.Transaction{
+			ID:            uuid.New().String(),
+			EffectiveDate: transaction.Date,
+			AccountID:     accountID,
+			CategoryID:    i.categoryIDsByPath[split.Category],
+			Amount:        split.Amount,
+			Cleared:       transaction.Cleared,
+			Memo:          memo,
+			ImportID:      importID,
+			SplitID:       splitID,
+		}
+
+		otherAccountID := i.accountIDsByName[split.TransferAccount]
+		if split.TransferAccount != "" && otherAccountID != accountID {
+			if i.transfers.isMirror(accountID, otherAccountID, transaction.Date, split.Amount) {
+				continue
+			}
+			added.PayeeID, added.IsPayeeInternal = otherAccountID, true
+		} else {
+			if i.payeeNames == nil {
+				i.payeeNames = map[*budgit.Transaction]string{}
+			}
+			i.payeeNames[added] = importedPayeeName(&budgit.ExternalTransaction{PayeeName: transaction.Payee})
+		}
+		i.transactions = append(i.transactions, added)
+	}
+}
+
+// ExportQIF writes the register of an Account to a QIF file, with the paths of the categories it uses.
+func (s Service) ExportQIF(ctx context.Context, accountID string, w io.Writer, options fileimport.QIFOptions) error {
+	var (
+		dbAccount       *db.Account
+		transactions    []*budgit.Transaction
+		dbPayees        map[string]*db.Payee
+		dbOtherAccounts map[string]*db.Account
+	)
+	err := s.inTx(ctx, func(conn Conn) error {
+		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, accountID)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if dbAccount, ok = dbAccounts[accountID]; !ok {
+			return ErrAccountNotFound
+		}
+		dbTransactions, err := s.db.SelectTransactionsByAccount(ctx, conn, accountID)
+		if err != nil {
+			return err
+		}
+		transactions = dbconvert.ToTransactions(dbTransactions...)
+
+		payeeIDs, otherAccountIDs := []string{}, []string{}
+		for _, transaction := range transactions {
+			if transaction.IsPayeeInternal {
+				otherAccountIDs = append(otherAccountIDs, transaction.PayeeID)
+			} else {
+				payeeIDs = append(payeeIDs, transaction.PayeeID)
+			}
+		}
+		if dbPayees, err = s.db.SelectPayeesByID(ctx, conn, deduplicate(payeeIDs)...); err != nil {
+			return err
+		}
+		dbOtherAccounts, err = s.db.SelectAccountsByID(ctx, conn, deduplicate(otherAccountIDs)...)
+		return err
+	}, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return fmt.Errorf("exporting account %q to QIF: %w", accountID, err)
+	}
+	categoryPaths, err := s.categoryPaths(ctx)
+	if err != nil {
+		return fmt.Errorf("exporting account %q to QIF: %w", accountID, err)
+	}
+
+	register := &fileimport.QIFAccount{Name: dbAccount.Name.String, Type: "Bank", Transactions: []*fileimport.QIFTransaction{}}
+	usedPaths := []string{}
+	splitTransactions := map[string]*fileimport.QIFTransaction{}
+	for _, transaction := range transactions {
+		category, transferAccount, payee := categoryPaths[transaction.CategoryID], "", ""
+		if transaction.IsPayeeInternal {
+			transferAccount = dbOtherAccounts[transaction.PayeeID].Name.String
+		} else if dbPayee, ok := dbPayees[transaction.PayeeID]; ok {
+			payee = dbPayee.Name.String
+		}
+		if category != "" {
+			usedPaths = append(usedPaths, category)
+		}
+
+		if transaction.SplitID == "" {
+			register.Transactions = append(register.Transactions, &fileimport.QIFTransaction{
+				Date:            transaction.EffectiveDate,
+				Amount:          transaction.Amount,
+				Payee:           payee,
+				Memo:            transaction.Memo,
+				Category:        category,
+				TransferAccount: transferAccount,
+				Cleared:         transaction.Cleared,
+			})
+			continue
+		}
+		split, ok := splitTransactions[transaction.SplitID]
+		if !ok {
+			split = &fileimport.QIFTransaction{Date: transaction.EffectiveDate, Cleared: transaction.Cleared}
+			splitTransactions[transaction.SplitID] = split
+			register.Transactions = append(register.Transactions, split)
+		}
+		if split.Payee == "" {
+			split.Payee = payee
+		}
+		split.Amount += transaction.Amount
+		split.Splits = append(split.Splits, &fileimport.QIFSplit{
+			Category:        category,
+			TransferAccount: transferAccount,
+			Memo:            transaction.Memo,
+			Amount:          transaction.Amount,
+		})
+	}
+
+	file := &fileimport.QIFFile{Accounts: []*fileimport.QIFAccount{register}}
+	usedPaths = deduplicate(usedPaths)
+	slices.Sort(usedPaths)
+	for _, path := range usedPaths {
+		file.Categories = append(file.Categories, &fileimport.QIFCategory{Path: path})
+	}
+	if err := fileimport.WriteQIF(w, file, options); err != nil {
+		return fmt.Errorf("exporting account %q to QIF: %w", accountID, err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/quickadd"
+	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+)
+
+// QuickAdd is a Transaction parsed from a quick-add input, see package quickadd, to be confirmed before it is added.
+type QuickAdd struct {
+	Transaction *budgit.Transaction
+	// NewPayee is the Payee created along with the Transaction, when no existing Payee matches the payee given.
+	NewPayee *budgit.Payee
+	// Matches are the names of the budget matched by the names the input gives.
+	Matches []QuickAddMatch
+}
+
+// QuickAddMatch is the name of an Account, Payee or Category matched by a name given in a quick-add input.
+type QuickAddMatch struct {
+	// Field is what was matched, "account", "payee" or "category".
+	Field string
+	// Given is the name given, empty for the only Account of the budget when the input gives none.
+	Given   string
+	Matched string
+}
+
+// QuickAddError is returned when a quick-add input cannot be parsed, or the names it gives cannot be matched.
+type QuickAddError struct {
+	Input string
+	Err   error
+}
+
+func (e QuickAddError) Error() string {
+	return fmt.Sprintf("quick-adding %q: %s", e.Input, e.Err)
+}
+
+func (e QuickAddError) Unwrap() error {
+	return e.Err
+}
+
+// UnmatchedNameError is returned when no Account or Category matches a name given in a quick-add input.
+type UnmatchedNameError struct {
+	Field string
+	Name  string
+}
+
+func (e UnmatchedNameError) Error() string {
+	return fmt.Sprintf("no %s matches %q", e.Field, e.Name)
+}
+
+// AmbiguousNameError is returned when several Accounts, Payees or Categories match a name given in a quick-add input
+// equally well.
+type AmbiguousNameError struct {
+	Field      string
+	Name       string
+	Candidates []string
+}
+
+func (e AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%q matches more than one %s: %s", e.Name, e.Field, strings.Join(e.Candidates, ", "))
+}
+
+var (
+	errQuickAddNoAccount = errors.New("an Account is required, give one with from and its name")
+	errQuickAddNoPayee   = errors.New("a payee is required, give one with @ and its name")
+)
+
+// PreviewQuickAdd parses a quick-add input into a Transaction, without adding it, resolving relative dates against
+// today. The names it gives are matched fuzzily against those of the budget, see quickadd.Match.
+//
+// The Account may be left out of the input if the budget has only one. A Payee is created for a payee which matches
+// no existing Payee, when the QuickAdd is confirmed.
+func (s Service) PreviewQuickAdd(ctx context.Context, input string, today time.Time) (*QuickAdd, error) {
+	entry, err := quickadd.Parse(input, today)
+	if err != nil {
+		return nil, QuickAddError{Input: input, Err: err}
+	}
+	preview := &QuickAdd{Transaction: &budgit.Transaction{
+		ID:            uuid.New().String(),
+		EffectiveDate: entry.Date,
+		Amount:        entry.Amount,
+		Memo:          entry.Memo,
+	}}
+
+	accounts, err := s.ListAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewing quick add %q: %w", input, err)
+	}
+	accountNames := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		accountNames = append(accountNames, account.Name)
+	}
+	switch {
+	case entry.Account != "":
+		i, err := preview.match("account", entry.Account, accountNames)
+		if err != nil {
+			return nil, QuickAddError{Input: input, Err: err}
+		}
+		preview.Transaction.AccountID = accounts[i].ID
+	case len(accounts) == 1:
+		preview.Transaction.AccountID = accounts[0].ID
+		preview.Matches = append(preview.Matches, QuickAddMatch{Field: "account", Matched: accounts[0].Name})
+	default:
+		return nil, QuickAddError{Input: input, Err: errQuickAddNoAccount}
+	}
+
+	if entry.Payee == "" {
+		return nil, QuickAddError{Input: input, Err: errQuickAddNoPayee}
+	}
+	payeeID, newPayee, err := s.quickAddPayee(ctx, preview, entry.Payee)
+	if err != nil {
+		var ambiguous AmbiguousNameError
+		if errors.As(err, &ambiguous) {
+			return nil, QuickAddError{Input: input, Err: err}
+		}
+		return nil, fmt.Errorf("previewing quick add %q: %w", input, err)
+	}
+	preview.Transaction.PayeeID, preview.NewPayee = payeeID, newPayee
+
+	if entry.Category != "" {
+		paths, err := s.categoryPaths(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("previewing quick add %q: %w", input, err)
+		}
+		categoryIDs := maps.Keys(paths)
+		slices.SortFunc(categoryIDs, func(a, b string) int { return strings.Compare(paths[a], paths[b]) })
+		categoryPaths := make([]string, 0, len(categoryIDs))
+		for _, id := range categoryIDs {
+			categoryPaths = append(categoryPaths, paths[id])
+		}
+		i, err := preview.match("category", entry.Category, categoryPaths)
+		if err != nil {
+			return nil, QuickAddError{Input: input, Err: err}
+		}
+		preview.Transaction.CategoryID = categoryIDs[i]
+	}
+	return preview, nil
+}
+
+// quickAddPayee returns the ID of the Payee named exactly as a payee given, or else matching it, or else of a new
+// Payee of the name, which is returned to be created.
+func (s Service) quickAddPayee(ctx context.Context, preview *QuickAdd, name string) (string, *budgit.Payee, error) {
+	dbPayees, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Payee, error) {
+		return s.db.SelectPayeesByName(ctx, conn, name)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	if dbPayee, ok := dbPayees[name]; ok {
+		preview.Matches = append(preview.Matches, QuickAddMatch{Field: "payee", Given: name, Matched: name})
+		return dbPayee.ID.String, nil, nil
+	}
+
+	payees, err := s.ListPayees(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	payeeNames := make([]string, 0, len(payees))
+	for _, payee := range payees {
+		payeeNames = append(payeeNames, payee.Name)
+	}
+	i, err := preview.match("payee", name, payeeNames)
+	var unmatched UnmatchedNameError
+	switch {
+	case errors.As(err, &unmatched):
+		newPayee := &budgit.Payee{ID: uuid.New().String(), Name: name}
+		return newPayee.ID, newPayee, nil
+	case err != nil:
+		return "", nil, err
+	}
+	return payees[i].ID, nil, nil
+}
+
+// match returns the index of the name best matching a name given, recording the match.
+func (q *QuickAdd) match(field, name string, candidates []string) (int, error) {
+	matches := quickadd.Match(name, candidates)
+	switch len(matches) {
+	case 0:
+		return 0, UnmatchedNameError{Field: field, Name: name}
+	case 1:
+		q.Matches = append(q.Matches, QuickAddMatch{Field: field, Given: name, Matched: candidates[matches[0]]})
+		return matches[0], nil
+	}
+	names := make([]string, 0, len(matches))
+	for _, i := range matches {
+		names = append(names, candidates[i])
+	}
+	return 0, AmbiguousNameError{Field: field, Name: name, Candidates: names}
+}
+
+// ConfirmQuickAdd adds the Transaction of a previewed QuickAdd, creating its new Payee first if it has one.
+func (s Service) ConfirmQuickAdd(ctx context.Context, quickAdd *QuickAdd) (*budgit.Transaction, error) {
+	if quickAdd.NewPayee != nil {
+		if _, err := s.CreatePayees(ctx, quickAdd.NewPayee); err != nil {
+			return nil, fmt.Errorf("confirming quick add: %w", err)
+		}
+	}
+	created, err := s.CreateTransactions(ctx, quickAdd.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("confirming quick add: %w", err)
+	}
+	return created[0], nil
+}
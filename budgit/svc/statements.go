@@ -0,0 +1,120 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+)
+
+// BankStatementAccountsError is returned when importing a camt.053 or MT940 file holding statements of more than one
+// account.
+type BankStatementAccountsError struct {
+	AccountIDs []string
+}
+
+func (e BankStatementAccountsError) Error() string {
+	return fmt.Sprintf("statements must all be of a single account, but are of accounts %+v", e.AccountIDs)
+}
+
+// PreviewCAMTImport parses the statements of an ISO 20022 camt.053 document and returns their transactions, marking
+// those already imported into an Account by their bank reference.
+func (s Service) PreviewCAMTImport(ctx context.Context, accountID string, content io.Reader) ([]*ImportCandidate, error) {
+	statements, err := fileimport.ParseCAMT(content)
+	if err != nil {
+		return nil, fmt.Errorf("previewing camt.053 import into account %q: %w", accountID, err)
+	}
+	candidates, err := s.previewBankStatementsImport(ctx, accountID, statements)
+	if err != nil {
+		return nil, fmt.Errorf("previewing camt.053 import into account %q: %w", accountID, err)
+	}
+	return candidates, nil
+}
+
+// ImportCAMT imports the transactions of the statements of an ISO 20022 camt.053 document into an Account, see
+// importBankStatements.
+func (s Service) ImportCAMT(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error) {
+	statements, err := fileimport.ParseCAMT(content)
+	if err != nil {
+		return nil, fmt.Errorf("importing camt.053 into account %q: %w", accountID, err)
+	}
+	return s.importBankStatements(ctx, accountID, statements)
+}
+
+// PreviewMT940Import parses the statements of an MT940 file and returns their transactions, marking those already
+// imported into an Account by their bank reference.
+func (s Service) PreviewMT940Import(ctx context.Context, accountID string, content io.Reader) ([]*ImportCandidate, error) {
+	statements, err := fileimport.ParseMT940(content)
+	if err != nil {
+		return nil, fmt.Errorf("previewing MT940 import into account %q: %w", accountID, err)
+	}
+	candidates, err := s.previewBankStatementsImport(ctx, accountID, statements)
+	if err != nil {
+		return nil, fmt.Errorf("previewing MT940 import into account %q: %w", accountID, err)
+	}
+	return candidates, nil
+}
+
+// ImportMT940 imports the transactions of the statements of an MT940 file into an Account, see importBankStatements.
+func (s Service) ImportMT940(ctx context.Context, accountID string, content io.Reader) ([]*budgit.Transaction, error) {
+	statements, err := fileimport.ParseMT940(content)
+	if err != nil {
+		return nil, fmt.Errorf("importing MT940 into account %q: %w", accountID, err)
+	}
+	return s.importBankStatements(ctx, accountID, statements)
+}
+
+func (s Service) previewBankStatementsImport(ctx context.Context, accountID string, statements []*fileimport.BankStatement) ([]*ImportCandidate, error) {
+	transactions, err := bankStatementTransactions(statements)
+	if err != nil {
+		return nil, err
+	}
+	return s.PreviewImport(ctx, accountID, transactions)
+}
+
+// importBankStatements imports the transactions of statements of a single account into an Account, skipping those
+// already imported by their bank reference. Each statement's opening and closing balances have already been checked
+// against its transactions when parsed. If the latest closing balance does not match the Account's cleared balance once
+// imported, the imported transactions are returned with a StatementReconciliationError.
+func (s Service) importBankStatements(ctx context.Context, accountID string, statements []*fileimport.BankStatement) ([]*budgit.Transaction, error) {
+	transactions, err := bankStatementTransactions(statements)
+	if err != nil {
+		return nil, fmt.Errorf("importing statements into account %q: %w", accountID, err)
+	}
+	imported, err := s.ImportTransactions(ctx, accountID, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *fileimport.BankStatement
+	for _, statement := range statements {
+		if statement.HasClosingBalance && (latest == nil || !statement.ClosingBalanceDate.Before(latest.ClosingBalanceDate)) {
+			latest = statement
+		}
+	}
+	if latest != nil {
+		if err := s.reconcileStatement(ctx, accountID, latest.ClosingBalance, latest.ClosingBalanceDate); err != nil {
+			return imported, err
+		}
+	}
+	return imported, nil
+}
+
+// bankStatementTransactions returns the transactions of statements, which must all be of a single account.
+func bankStatementTransactions(statements []*fileimport.BankStatement) ([]*budgit.ExternalTransaction, error) {
+	accountIDs := []string{}
+	transactions := []*budgit.ExternalTransaction{}
+	for _, statement := range statements {
+		if !slices.Contains(accountIDs, statement.AccountID) {
+			accountIDs = append(accountIDs, statement.AccountID)
+		}
+		transactions = append(transactions, statement.Transactions...)
+	}
+	if len(accountIDs) > 1 {
+		return nil, BankStatementAccountsError{AccountIDs: accountIDs}
+	}
+	return transactions, nil
+}
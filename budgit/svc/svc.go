@@ -2,9 +2,13 @@ package svc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
+	"github.com/andrewthowell/budgit/budgit/auth"
 	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"go.uber.org/zap"
@@ -17,6 +21,11 @@ type Conn interface {
 
 type TxConn interface {
 	Conn
+	txBeginner
+}
+
+// txBeginner begins transactions, in which every query of a budget runs, see budgetConn.
+type txBeginner interface {
 	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
 }
 
@@ -25,33 +34,80 @@ type DB interface {
 	AccountDB
 	PayeeDB
 	TransactionDB
+	AttachmentDB
+	CSVProfileDB
+	CategoryDB
+	AssignmentDB
+	BackupDB
+	AuthDB
+	BudgetDB
 }
 
 type Service struct {
-	log          *zap.SugaredLogger
-	conn         TxConn
-	db           DB
-	integrations map[string]Integration
+	log *zap.SugaredLogger
+	// conn begins transactions reading and writing the budget of the context, see WithBudget, as the Principal of the
+	// context. userConn reads and writes Users and budget memberships, and refuses queries in contexts without a
+	// Principal, see WithPrincipal. unauthenticatedConn does not, and is used only to authenticate.
+	conn                txBeginner
+	userConn            TxConn
+	unauthenticatedConn TxConn
+	db                  DB
+	integrations        map[string]Integration
+	attachments         AttachmentStore
+	passwordParams      auth.PasswordParams
+	// dummyPasswordHash is verified against when logging in as a User who does not exist. It is hashed on first use,
+	// as hashing is slow.
+	dummyPasswordHash func() string
 }
 
-func New(log *zap.SugaredLogger, conn TxConn, db DB, integrations []Integration) *Service {
+func New(log *zap.SugaredLogger, conn TxConn, db DB, integrations []Integration, attachments AttachmentStore) *Service {
 	return &Service{
-		log:          log,
-		conn:         conn,
-		db:           db,
-		integrations: mapByID(integrations),
+		log:                 log,
+		conn:                budgetConn{conn: authenticatedConn{conn}, db: db},
+		userConn:            authenticatedConn{conn},
+		unauthenticatedConn: conn,
+		db:                  db,
+		integrations:        mapByID(integrations),
+		attachments:         attachments,
+		passwordParams:      auth.DefaultPasswordParams,
+		dummyPasswordHash: sync.OnceValue(func() string {
+			hash, _ := auth.HashPassword(uuid.New().String(), auth.DefaultPasswordParams)
+			return hash
+		}),
 	}
 }
 
-func (s Service) inTx(ctx context.Context, txFunc func(conn Conn) error, txOptions pgx.TxOptions) (rollbackErr error) {
+// inTx runs a function in a transaction on the budget of the context.
+func (s Service) inTx(ctx context.Context, txFunc func(conn Conn) error, txOptions pgx.TxOptions) error {
+	return runInTx(ctx, s.conn, txFunc, txOptions)
+}
+
+// inUserTx runs a function in a transaction on Users and budget memberships.
+func (s Service) inUserTx(ctx context.Context, txFunc func(conn Conn) error, txOptions pgx.TxOptions) error {
+	return runInTx(ctx, s.userConn, txFunc, txOptions)
+}
+
+// inReadTx runs a function returning a value in a transaction which only reads the budget of the context.
+func inReadTx[T any](ctx context.Context, s Service, txFunc func(conn Conn) (T, error)) (T, error) {
+	var value T
+	err := s.inTx(ctx, func(conn Conn) error {
+		var err error
+		value, err = txFunc(conn)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	return value, err
+}
+
+func runInTx(ctx context.Context, conn txBeginner, txFunc func(conn Conn) error, txOptions pgx.TxOptions) (rollbackErr error) {
 	// rollbackErr is a named return so that it can be modified in a deferred call.
 
-	tx, err := s.conn.BeginTx(ctx, txOptions)
+	tx, err := conn.BeginTx(ctx, txOptions)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
-		if err := tx.Rollback(ctx); err != nil {
+		// Rolling back a committed transaction returns ErrTxClosed, which is expected.
+		if err := tx.Rollback(ctx); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
 			rollbackErr = fmt.Errorf("failed to rollback transaction: %w", err)
 		}
 	}()
@@ -67,6 +123,11 @@ func (s Service) inTx(ctx context.Context, txFunc func(conn Conn) error, txOptio
 	return rollbackErr
 }
 
+// newRequestID returns the ID of a new version of a row, which each inserted row must have.
+func newRequestID() pgtype.Text {
+	return pgtype.Text{String: uuid.New().String(), Valid: true}
+}
+
 type idGetter interface {
 	ID() string
 }
@@ -0,0 +1,161 @@
+package svc_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/attachmentstore"
+	"github.com/andrewthowell/budgit/budgit/clients"
+	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/internal/dbtest"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestService(t *testing.T) {
+	suite.Run(t, new(svcSuite))
+}
+
+// svcSuite runs the Service against Postgres as the budgit_app role, so that row-level security applies, with a fake
+// Starling API as its only integration, "starling". pool connects as the superuser, which truncates the tables.
+type svcSuite struct {
+	suite.Suite
+
+	postgres       *dbtest.Postgres
+	starlingServer *httptest.Server
+
+	pool     *pgxpool.Pool
+	appPool  *pgxpool.Pool
+	starling *starlingfake.Server
+	service  *svc.Service
+}
+
+func (s *svcSuite) SetupSuite() {
+	postgres, err := dbtest.Run(context.Background())
+	s.Require().NoError(err, "unexpected error running postgres")
+	s.postgres = postgres
+
+	pool, err := pgxpool.New(context.Background(), postgres.ConnString)
+	s.Require().NoError(err, "unexpected error connecting to postgres container")
+	s.pool = pool
+	appPool, err := pgxpool.New(context.Background(), postgres.AppConnString)
+	s.Require().NoError(err, "unexpected error connecting to postgres container as budgit_app")
+	s.appPool = appPool
+
+	log := zap.NewNop().Sugar()
+	s.starling = starlingfake.New()
+	s.starlingServer = httptest.NewServer(s.starling)
+	starling, err := clients.NewStarlingClient(log, "starling", s.starlingServer.URL, clients.NewHTTPClient(log, clients.DefaultTransportConfig(), clients.NewStaticTokenSource("token")))
+	s.Require().NoError(err, "unexpected error creating Starling client")
+
+	attachments, err := attachmentstore.NewFileStore(s.T().TempDir())
+	s.Require().NoError(err, "unexpected error creating attachment store")
+
+	s.service = svc.New(log, appPool, db.New(log), []svc.Integration{starling}, attachments)
+}
+
+func (s *svcSuite) TearDownTest() {
+	s.Require().NoError(dbtest.Truncate(context.Background(), s.pool))
+}
+
+func (s *svcSuite) TearDownSuite() {
+	s.starlingServer.Close()
+	s.appPool.Close()
+	s.pool.Close()
+	s.Require().NoError(s.postgres.Terminate(context.Background()))
+}
+
+func (s *svcSuite) TestRowLevelSecurity() {
+	_, err := s.service.CreateAccounts(s.localContext(), &budgit.Account{ID: uuid.New().String(), Name: "Current"})
+	s.Require().NoError(err)
+
+	bypasses, err := db.New(zap.NewNop().Sugar()).BypassesRowLevelSecurity(context.Background(), s.appPool)
+	s.Require().NoError(err)
+	s.False(bypasses, "expected budgit_app to be subject to row-level security")
+
+	var count int
+	s.Require().NoError(s.appPool.QueryRow(context.Background(), `SELECT COUNT(*) FROM accounts`).Scan(&count))
+	s.Zero(count, "expected no accounts to be visible to budgit_app without a budget set")
+	s.Require().NoError(s.pool.QueryRow(context.Background(), `SELECT COUNT(*) FROM accounts`).Scan(&count))
+	s.Equal(1, count, "expected the superuser to see every account")
+}
+
+// localContext returns a context running operations on the default budget as the local principal, which owns it.
+func (s *svcSuite) localContext() context.Context {
+	return svc.WithBudget(svc.WithPrincipal(context.Background(), svc.LocalPrincipal), svc.DefaultBudgetID)
+}
+
+// memberContext creates a User who is a member of the budget of a context with a role, returning a context running
+// operations on the budget as them.
+func (s *svcSuite) memberContext(ctx context.Context, username string, role budgit.Role) context.Context {
+	user := s.createUser(username)
+	s.Require().NoError(s.service.SetMember(ctx, username, role), "unexpected error setting member %q", username)
+	budgetID, _ := svc.BudgetFrom(ctx)
+	return s.userContext(user, budgetID)
+}
+
+// createUser creates a User who logs in with a password.
+func (s *svcSuite) createUser(username string) *budgit.User {
+	user, err := s.service.CreateUser(svc.WithPrincipal(context.Background(), svc.LocalPrincipal), username, "password123")
+	s.Require().NoError(err, "unexpected error creating user %q", username)
+	return user
+}
+
+// budgetContext creates a budget which a User owns, returning a context running operations on it as them.
+func (s *svcSuite) budgetContext(user *budgit.User, name string) context.Context {
+	budget, err := s.service.CreateBudget(svc.WithPrincipal(context.Background(), svc.Principal{UserID: user.ID, Username: user.Username}), name)
+	s.Require().NoError(err, "unexpected error creating budget %q", name)
+	return s.userContext(user, budget.ID)
+}
+
+// userContext returns a context running operations on a budget as a User who logged in with their password.
+func (s *svcSuite) userContext(user *budgit.User, budgetID string) context.Context {
+	ctx := svc.WithPrincipal(context.Background(), svc.Principal{UserID: user.ID, Username: user.Username})
+	return svc.WithBudget(ctx, budgetID)
+}
+
+// createLinkedAccount creates an Account linked to a new account of the fake Starling API, opted in to writing back
+// changes to its transactions or not, returning both.
+func (s *svcSuite) createLinkedAccount(ctx context.Context, name string, writeBack bool) (*budgit.Account, starlingfake.Account) {
+	external := s.starling.AddAccount(starlingfake.Account{Name: name})
+	accounts, err := s.service.CreateAccounts(ctx, &budgit.Account{ID: uuid.New().String(), Name: name})
+	s.Require().NoError(err, "unexpected error creating account %q", name)
+	account, err := s.service.LinkAccount(ctx, accounts[0].ID, "starling", external.UID.String())
+	s.Require().NoError(err, "unexpected error linking account %q", name)
+	s.Require().NoError(s.service.SetAccountWriteBack(ctx, account.ID, writeBack), "unexpected error setting write back of account %q", name)
+	return account, external
+}
+
+// importFeedItems adds feed items to an account of the fake Starling API, then imports them into the Account linked
+// to it, returning the Transactions created.
+func (s *svcSuite) importFeedItems(ctx context.Context, accountID string, external starlingfake.Account, items ...starlingfake.FeedItem) []*budgit.Transaction {
+	for i := range items {
+		items[i].TransactionTime = time.Now().Add(-time.Duration(i+1) * time.Hour)
+	}
+	s.starling.AddFeedItems(external.UID, items...)
+	externalTransactions, err := s.service.ListExternalTransactions(ctx, accountID, time.Now().Add(-24*time.Hour))
+	s.Require().NoError(err, "unexpected error listing external transactions")
+	transactions, err := s.service.ImportTransactions(ctx, accountID, externalTransactions)
+	s.Require().NoError(err, "unexpected error importing transactions")
+	return transactions
+}
+
+func (s *svcSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
+
+func (s *svcSuite) mustFeedItem(external starlingfake.Account, feedItemID string) starlingfake.FeedItem {
+	item, ok := s.starling.FeedItem(external.UID, uuid.MustParse(feedItemID))
+	s.Require().True(ok, "feed item %q not found", feedItemID)
+	return item
+}
@@ -12,64 +12,364 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 )
 
 type TransactionDB interface {
 	InsertTransactions(ctx context.Context, queryer db.Queryer, transactions ...*db.Transaction) ([]string, error)
+	UpdateTransactionValidToTimestamps(ctx context.Context, queryer db.Queryer, updates ...db.ValidToTimestampUpdate) ([]string, error)
+	SelectTransactionsByAccount(ctx context.Context, queryer db.Queryer, accountID string) ([]*db.Transaction, error)
+	SelectTransactionsByPayee(ctx context.Context, queryer db.Queryer, payeeIDs ...string) ([]*db.Transaction, error)
+	SelectTransactionsByID(ctx context.Context, queryer db.Queryer, transactionIDs ...string) (map[string]*db.Transaction, error)
 }
 
 func (s Service) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
 	var createdTransactions []*budgit.Transaction
+	err := s.inTx(ctx, func(conn Conn) error {
+		var err error
+		createdTransactions, err = s.createTransactions(ctx, conn, transactions...)
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("creating transactions: %w", err)
+	}
+	return createdTransactions, nil
+}
+
+// createTransactions creates Transactions and the mirrors of transfers within a transaction, see CreateTransactions.
+func (s Service) createTransactions(ctx context.Context, conn Conn, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if err := s.validateTransactions(ctx, conn, transactions...); err != nil {
+		return nil, err
+	}
+
+	transactions, err := appendMirrorTransactions(transactions...)
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := s.db.Now(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	dbTransactions := dbconvert.FromTransactions(transactions...)
+	for _, dbTransaction := range dbTransactions {
+		dbTransaction.RequestID = newRequestID()
+		dbTransaction.CreatedBy = createdBy(ctx)
+		dbTransaction.ValidFromTimestamp = now
+		dbTransaction.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+
+	// TODO: check for transactions not being inserted
+	if _, err := s.db.InsertTransactions(ctx, conn, dbTransactions...); err != nil {
+		return nil, err
+	}
+
+	if err := s.adjustAccountBalances(ctx, conn, now, balanceChangesByAccount(transactions)); err != nil {
+		return nil, err
+	}
+
+	// TODO: Update Category Balances
+
+	return transactions, nil
+}
+
+// ListTransactions returns the current Transactions of an Account.
+func (s Service) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	transactions, err := inReadTx(ctx, s, func(conn Conn) ([]*db.Transaction, error) {
+		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, accountID)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := dbAccounts[accountID]; !ok {
+			return nil, ErrAccountNotFound
+		}
+		return s.db.SelectTransactionsByAccount(ctx, conn, accountID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing transactions of account %q: %w", accountID, err)
+	}
+	return dbconvert.ToTransactions(transactions...), nil
+}
+
+// GetTransaction returns the current version of a Transaction.
+func (s Service) GetTransaction(ctx context.Context, transactionID string) (*budgit.Transaction, error) {
+	dbTransactions, err := inReadTx(ctx, s, func(conn Conn) (map[string]*db.Transaction, error) {
+		return s.db.SelectTransactionsByID(ctx, conn, transactionID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction %q: %w", transactionID, err)
+	}
+	dbTransaction, ok := dbTransactions[transactionID]
+	if !ok {
+		return nil, fmt.Errorf("getting transaction %q: %w", transactionID, ErrTransactionNotFound)
+	}
+	return dbconvert.ToTransactions(dbTransaction)[0], nil
+}
+
+// UpdateTransactions replaces the current versions of Transactions with the given ones, matched by ID, and adjusts
+// the balances of the Accounts affected. The mirror of a transfer between Accounts is replaced along with it, keeping
+// its ID if the Transaction is still a transfer. Changes to the memos and categories of imported Transactions are then
+// written back to the external accounts they were imported from, see WriteBackExternalTransaction.
+func (s Service) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	var updatedTransactions []*budgit.Transaction
+	var writeBacks []externalTransactionWriteBack
 	err := s.inTx(ctx, func(conn Conn) error {
 		if err := s.validateTransactions(ctx, conn, transactions...); err != nil {
 			return err
 		}
-
-		transactions, err := appendMirrorTransactions(transactions...)
+		currentTransactions, err := s.currentTransactions(ctx, conn, transactionIDs(transactions)...)
 		if err != nil {
 			return err
 		}
 
-		now, err := s.db.Now(ctx, conn)
+		replacedTransactions := make([]*budgit.Transaction, 0, 2*len(transactions))
+		newTransactions := make([]*budgit.Transaction, 0, 2*len(transactions))
+		for i, transaction := range transactions {
+			current := currentTransactions[i]
+			replacedTransactions = append(replacedTransactions, current)
+			newTransactions = append(newTransactions, transaction)
+
+			mirror, err := s.findMirror(ctx, conn, current)
+			if err != nil {
+				return err
+			}
+			mirrorID := uuid.New().String()
+			if mirror != nil {
+				replacedTransactions = append(replacedTransactions, mirror)
+				mirrorID = mirror.ID
+			}
+			if transaction.IsPayeeInternal {
+				newTransactions = append(newTransactions, transaction.Mirror(mirrorID))
+			}
+		}
+
+		if err := s.replaceTransactions(ctx, conn, replacedTransactions, newTransactions); err != nil {
+			return err
+		}
+		writeBacks, err = s.externalTransactionWriteBacks(ctx, conn, currentTransactions, transactions)
 		if err != nil {
 			return err
 		}
+		updatedTransactions = transactions
+		return nil
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("updating transactions: %w", err)
+	}
 
-		dbTransactions := dbconvert.FromTransactions(transactions...)
-		for _, dbTransaction := range dbTransactions {
-			dbTransaction.ValidFromTimestamp = now
-			dbTransaction.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	// The Transactions are updated whether or not their external accounts accept the changes, so failures to write back
+	// are only logged.
+	for _, writeBack := range writeBacks {
+		if err := s.WriteBackExternalTransaction(ctx, writeBack.accountID, writeBack.externalTransactionID, writeBack.update); err != nil {
+			s.log.Warnw("Writing back updated transaction", zap.String("transaction_id", writeBack.transactionID), zap.Error(err))
 		}
+	}
+	return updatedTransactions, nil
+}
 
-		// TODO: check for transactions not being inserted
-		if _, err := s.db.InsertTransactions(ctx, conn, dbTransactions...); err != nil {
-			return err
+// externalTransactionWriteBack is a change to an imported Transaction, to write back to its external account.
+type externalTransactionWriteBack struct {
+	transactionID, accountID, externalTransactionID string
+	update                                          budgit.ExternalTransactionUpdate
+}
+
+// externalTransactionWriteBacks returns the changes to the memos and categories of imported Transactions made by
+// updating them, to write back to the external transactions they were imported from. Only Transactions imported from
+// the external account still linked to their Account are written back, not those imported from files. Categories are
+// written back by name, and removing a category is not written back.
+func (s Service) externalTransactionWriteBacks(ctx context.Context, conn Conn, currentTransactions, updatedTransactions []*budgit.Transaction) ([]externalTransactionWriteBack, error) {
+	accountIDs, categoryIDs := []string{}, []string{}
+	for i, transaction := range updatedTransactions {
+		if currentTransactions[i].ImportIntegrationID == "" {
+			continue
+		}
+		accountIDs = append(accountIDs, currentTransactions[i].AccountID)
+		if transaction.CategoryID != "" && transaction.CategoryID != currentTransactions[i].CategoryID {
+			categoryIDs = append(categoryIDs, transaction.CategoryID)
 		}
+	}
+	dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, deduplicate(accountIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	dbCategories, err := s.db.SelectCategoriesByID(ctx, conn, deduplicate(categoryIDs)...)
+	if err != nil {
+		return nil, err
+	}
 
-		balanceChangeByAccountID := balanceChangesByAccount(transactions)
-		dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, maps.Keys(balanceChangeByAccountID)...)
+	writeBacks := []externalTransactionWriteBack{}
+	for i, transaction := range updatedTransactions {
+		current := currentTransactions[i]
+		dbAccount, ok := dbAccounts[current.AccountID]
+		if current.ImportIntegrationID == "" || !ok || dbAccount.ExternalIntegrationID.String != current.ImportIntegrationID {
+			continue
+		}
+		update := budgit.ExternalTransactionUpdate{}
+		if transaction.Memo != current.Memo {
+			update.Memo = &transaction.Memo
+		}
+		if dbCategory, ok := dbCategories[transaction.CategoryID]; ok && transaction.CategoryID != current.CategoryID {
+			update.Category = &dbCategory.Name.String
+		}
+		if update.Memo == nil && update.Category == nil {
+			continue
+		}
+		writeBacks = append(writeBacks, externalTransactionWriteBack{
+			transactionID:         current.ID,
+			accountID:             current.AccountID,
+			externalTransactionID: current.ImportID,
+			update:                update,
+		})
+	}
+	return writeBacks, nil
+}
+
+// DeleteTransactions ends the current versions of Transactions, along with the mirrors of transfers between Accounts,
+// and adjusts the balances of the Accounts affected.
+func (s Service) DeleteTransactions(ctx context.Context, transactionIDs ...string) error {
+	err := s.inTx(ctx, func(conn Conn) error {
+		currentTransactions, err := s.currentTransactions(ctx, conn, transactionIDs...)
 		if err != nil {
-			return fmt.Errorf("updating affected account balances: %w", err)
+			return err
 		}
-		accounts := dbconvert.ToAccounts(maps.Values(dbAccounts)...)
 
-		for _, account := range accounts {
-			account.Balance.Add(balanceChangeByAccountID[account.ID])
+		deletedTransactions := make([]*budgit.Transaction, 0, 2*len(currentTransactions))
+		for _, current := range currentTransactions {
+			deletedTransactions = append(deletedTransactions, current)
+			mirror, err := s.findMirror(ctx, conn, current)
+			if err != nil {
+				return err
+			}
+			if mirror != nil {
+				deletedTransactions = append(deletedTransactions, mirror)
+			}
 		}
-		if _, err := s.db.InsertAccounts(ctx, s.conn, dbconvert.FromAccounts(accounts...)...); err != nil {
-			return fmt.Errorf("updating affected account balances: %w", err)
+		return s.replaceTransactions(ctx, conn, deletedTransactions, nil)
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return fmt.Errorf("deleting transactions: %w", err)
+	}
+	return nil
+}
+
+// currentTransactions returns the current versions of the Transactions with the given IDs, in the same order, or
+// ErrTransactionNotFound if any do not exist.
+func (s Service) currentTransactions(ctx context.Context, conn Conn, transactionIDs ...string) ([]*budgit.Transaction, error) {
+	dbTransactions, err := s.db.SelectTransactionsByID(ctx, conn, transactionIDs...)
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*budgit.Transaction, 0, len(transactionIDs))
+	for _, id := range transactionIDs {
+		dbTransaction, ok := dbTransactions[id]
+		if !ok {
+			return nil, fmt.Errorf("transaction %q: %w", id, ErrTransactionNotFound)
 		}
+		transactions = append(transactions, dbconvert.ToTransactions(dbTransaction)[0])
+	}
+	return transactions, nil
+}
 
-		// TODO: Update Category Balances
+// findMirror returns the mirror created with a transfer between Accounts, see appendMirrorTransactions, or nil if the
+// Transaction is not a transfer or its mirror no longer exists. Mirrors are not linked to their Transaction, so the
+// first Transaction of the other Account mirroring it is returned.
+func (s Service) findMirror(ctx context.Context, conn Conn, transaction *budgit.Transaction) (*budgit.Transaction, error) {
+	if !transaction.IsPayeeInternal {
+		return nil, nil
+	}
+	dbTransactions, err := s.db.SelectTransactionsByAccount(ctx, conn, transaction.PayeeID)
+	if err != nil {
+		return nil, fmt.Errorf("finding mirror of transaction %q: %w", transaction.ID, err)
+	}
+	for _, candidate := range dbconvert.ToTransactions(dbTransactions...) {
+		if candidate.ID != transaction.ID &&
+			candidate.IsPayeeInternal &&
+			candidate.PayeeID == transaction.AccountID &&
+			candidate.EffectiveDate.Equal(transaction.EffectiveDate) &&
+			candidate.Amount == -transaction.Amount {
+			return candidate, nil
+		}
+	}
+	return nil, nil
+}
 
-		createdTransactions = transactions
-		return nil
-	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+// replaceTransactions ends the current versions of the old Transactions and inserts the new, adjusting the balances
+// of the Accounts affected by the difference between them.
+func (s Service) replaceTransactions(ctx context.Context, conn Conn, oldTransactions, newTransactions []*budgit.Transaction) error {
+	now, err := s.db.Now(ctx, conn)
 	if err != nil {
-		return nil, fmt.Errorf("creating transactions: %w", err)
+		return err
 	}
-	return createdTransactions, nil
+
+	updates := make([]db.ValidToTimestampUpdate, 0, len(oldTransactions))
+	for _, transaction := range oldTransactions {
+		updates = append(updates, db.ValidToTimestampUpdate{ID: pgtype.Text{String: transaction.ID, Valid: true}, ValidToTimestamp: now})
+	}
+	if _, err := s.db.UpdateTransactionValidToTimestamps(ctx, conn, updates...); err != nil {
+		return err
+	}
+
+	if len(newTransactions) != 0 {
+		dbTransactions := dbconvert.FromTransactions(newTransactions...)
+		for _, dbTransaction := range dbTransactions {
+			dbTransaction.RequestID = newRequestID()
+			dbTransaction.CreatedBy = createdBy(ctx)
+			dbTransaction.ValidFromTimestamp = now
+			dbTransaction.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+		}
+		if _, err := s.db.InsertTransactions(ctx, conn, dbTransactions...); err != nil {
+			return err
+		}
+	}
+
+	balanceChangeByAccountID := balanceChangesByAccount(newTransactions)
+	for accountID, change := range balanceChangesByAccount(oldTransactions) {
+		balanceChangeByAccountID[accountID] = balanceChangeByAccountID[accountID].Add(budgit.Balance{
+			ClearedBalance:   -change.ClearedBalance,
+			EffectiveBalance: -change.EffectiveBalance,
+		})
+	}
+	return s.adjustAccountBalances(ctx, conn, now, balanceChangeByAccountID)
+}
+
+// adjustAccountBalances replaces the current versions of Accounts with versions whose balances are changed by the
+// given amounts.
+func (s Service) adjustAccountBalances(ctx context.Context, conn Conn, now pgtype.Timestamptz, balanceChangeByAccountID map[string]budgit.Balance) error {
+	dbAccounts, err := s.db.SelectAccountsByID(ctx, conn, maps.Keys(balanceChangeByAccountID)...)
+	if err != nil {
+		return fmt.Errorf("updating affected account balances: %w", err)
+	}
+	accounts := dbconvert.ToAccounts(maps.Values(dbAccounts)...)
+
+	updates := make([]db.ValidToTimestampUpdate, 0, len(accounts))
+	for _, account := range accounts {
+		account.Balance = account.Balance.Add(balanceChangeByAccountID[account.ID])
+		updates = append(updates, db.ValidToTimestampUpdate{ID: pgtype.Text{String: account.ID, Valid: true}, ValidToTimestamp: now})
+	}
+	if _, err := s.db.UpdateAccountValidToTimestamps(ctx, conn, updates...); err != nil {
+		return fmt.Errorf("updating affected account balances: %w", err)
+	}
+	updatedDBAccounts := dbconvert.FromAccounts(accounts...)
+	for _, dbAccount := range updatedDBAccounts {
+		dbAccount.RequestID = newRequestID()
+		dbAccount.CreatedBy = createdBy(ctx)
+		dbAccount.ValidFromTimestamp = now
+		dbAccount.ValidToTimestamp = pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	}
+	if _, err := s.db.InsertAccounts(ctx, conn, updatedDBAccounts...); err != nil {
+		return fmt.Errorf("updating affected account balances: %w", err)
+	}
+	return nil
+}
+
+func transactionIDs(transactions []*budgit.Transaction) []string {
+	ids := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		ids = append(ids, transaction.ID)
+	}
+	return ids
 }
 
 type MissingAccountsError struct {
@@ -0,0 +1,107 @@
+package svc_test
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"github.com/google/uuid"
+)
+
+func (s *svcSuite) TestUpdateTransactionsWritesBack() {
+	ctx := s.localContext()
+	groups, err := s.service.CreateCategoryGroups(ctx, &budgit.CategoryGroup{ID: uuid.New().String(), Name: "Food"})
+	s.Require().NoError(err)
+	categories, err := s.service.CreateCategories(ctx,
+		&budgit.Category{ID: uuid.New().String(), GroupID: groups[0].ID, Name: "Eating out"},
+		&budgit.Category{ID: uuid.New().String(), GroupID: groups[0].ID, Name: "Weekly shop"},
+	)
+	s.Require().NoError(err)
+	eatingOut, weeklyShop := categories[0], categories[1]
+
+	s.Run("OptedIn", func() {
+		account, external := s.createLinkedAccount(ctx, "Personal", true)
+		transaction := s.importFeedItems(ctx, account.ID, external, starlingfake.FeedItem{Amount: -320, CounterPartyName: "Pret A Manger", Settled: true})[0]
+		s.Equal("starling", transaction.ImportIntegrationID)
+
+		transaction.Memo = "coffee with Sam"
+		transaction.CategoryID = eatingOut.ID
+		_, err := s.service.UpdateTransactions(ctx, transaction)
+		s.Require().NoError(err)
+
+		item := s.mustFeedItem(external, transaction.ImportID)
+		s.Equal("coffee with Sam", item.UserNote)
+		s.Equal("EATING_OUT", item.SpendingCategory)
+	})
+	s.Run("OnlyChangesWrittenBack", func() {
+		account, external := s.createLinkedAccount(ctx, "Bills", true)
+		transaction := s.importFeedItems(ctx, account.ID, external, starlingfake.FeedItem{Amount: -6523, CounterPartyName: "Supermarket", UserNote: "from the app", SpendingCategory: "GROCERIES", Settled: true})[0]
+
+		transaction.Cleared = !transaction.Cleared
+		_, err := s.service.UpdateTransactions(ctx, transaction)
+		s.Require().NoError(err)
+
+		item := s.mustFeedItem(external, transaction.ImportID)
+		s.Equal("from the app", item.UserNote)
+		s.Equal("GROCERIES", item.SpendingCategory)
+	})
+	s.Run("CategoryWithoutSpendingCategory", func() {
+		account, external := s.createLinkedAccount(ctx, "Groceries", true)
+		transaction := s.importFeedItems(ctx, account.ID, external, starlingfake.FeedItem{Amount: -6523, CounterPartyName: "Supermarket", SpendingCategory: "GROCERIES", Settled: true})[0]
+
+		transaction.Memo = "big shop"
+		transaction.CategoryID = weeklyShop.ID
+		_, err := s.service.UpdateTransactions(ctx, transaction)
+		s.Require().NoError(err)
+
+		item := s.mustFeedItem(external, transaction.ImportID)
+		s.Equal("big shop", item.UserNote)
+		s.Equal("GROCERIES", item.SpendingCategory)
+	})
+	s.Run("NotOptedIn", func() {
+		account, external := s.createLinkedAccount(ctx, "Joint", false)
+		transaction := s.importFeedItems(ctx, account.ID, external, starlingfake.FeedItem{Amount: -12000, CounterPartyName: "Energy Co", Settled: true})[0]
+
+		transaction.Memo = "quarterly bill"
+		_, err := s.service.UpdateTransactions(ctx, transaction)
+		s.Require().NoError(err)
+
+		updated, err := s.service.GetTransaction(ctx, transaction.ID)
+		s.Require().NoError(err)
+		s.Equal("quarterly bill", updated.Memo)
+		s.Empty(s.mustFeedItem(external, transaction.ImportID).UserNote)
+	})
+	s.Run("ImportedFromFile", func() {
+		account, external := s.createLinkedAccount(ctx, "Current", true)
+		s.starling.AddFeedItems(external.UID, starlingfake.FeedItem{Amount: -250, CounterPartyName: "Bakery", Settled: true, TransactionTime: time.Now().Add(-time.Hour)})
+		externalTransactions, err := s.service.ListExternalTransactions(ctx, account.ID, time.Now().Add(-24*time.Hour))
+		s.Require().NoError(err)
+		// A statement of the account imported from a file has the IDs of its transactions, but no Integration.
+		for _, externalTransaction := range externalTransactions {
+			externalTransaction.IntegrationID, externalTransaction.ExternalAccountID = "", ""
+		}
+		transactions, err := s.service.ImportTransactions(ctx, account.ID, externalTransactions)
+		s.Require().NoError(err)
+		transaction := transactions[0]
+		s.Empty(transaction.ImportIntegrationID)
+
+		transaction.Memo = "from a statement"
+		_, err = s.service.UpdateTransactions(ctx, transaction)
+		s.Require().NoError(err)
+
+		s.Empty(s.mustFeedItem(external, transaction.ImportID).UserNote)
+	})
+	s.Run("ViewerForbidden", func() {
+		account, external := s.createLinkedAccount(ctx, "Savings", true)
+		transaction := s.importFeedItems(ctx, account.ID, external, starlingfake.FeedItem{Amount: -500, CounterPartyName: "Cafe", Settled: true})[0]
+		viewerCtx := s.memberContext(ctx, "viewer", budgit.RoleViewer)
+
+		transaction.Memo = "written by a viewer"
+		_, err := s.service.UpdateTransactions(viewerCtx, transaction)
+		s.ErrorIs(err, svc.ErrForbidden)
+		err = s.service.WriteBackExternalTransaction(viewerCtx, account.ID, transaction.ImportID, budgit.ExternalTransactionUpdate{Memo: &transaction.Memo})
+		s.ErrorIs(err, svc.ErrForbidden)
+		s.Empty(s.mustFeedItem(external, transaction.ImportID).UserNote)
+	})
+}
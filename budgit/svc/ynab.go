@@ -0,0 +1,211 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/fileimport"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// YNABImport is the result of importing a YNAB budget.
+type YNABImport struct {
+	// Accounts are the Accounts created, which did not already exist by name.
+	Accounts     []*budgit.Account
+	Assignments  []*budgit.Assignment
+	Transactions []*budgit.Transaction
+	// Unmapped describes the parts of the budget which budgit has no equivalent of, so were imported only in part or not
+	// at all, see fileimport.YNABBudget.
+	Unmapped []string
+}
+
+// ImportYNABCSV imports a YNAB budget from its register CSV export and, optionally, its budget CSV export of the
+// amounts assigned each month, which may be nil. See importYNAB.
+func (s Service) ImportYNABCSV(ctx context.Context, register, budget io.Reader, options fileimport.YNABOptions) (*YNABImport, error) {
+	parsed, err := fileimport.ParseYNABCSV(register, budget, options)
+	if err != nil {
+		return nil, fmt.Errorf("importing YNAB budget: %w", err)
+	}
+	return s.importYNAB(ctx, parsed)
+}
+
+// ImportYNABAPIExport imports a YNAB budget from a file holding its export from YNAB's API. See importYNAB.
+func (s Service) ImportYNABAPIExport(ctx context.Context, content io.Reader) (*YNABImport, error) {
+	parsed, err := fileimport.ParseYNABAPIExport(content)
+	if err != nil {
+		return nil, fmt.Errorf("importing YNAB budget: %w", err)
+	}
+	return s.importYNAB(ctx, parsed)
+}
+
+// importYNAB recreates a YNAB budget's accounts, payees, category groups and categories, monthly assignments and
+// transactions. It is meant for a new budget, but Accounts, Payees and Categories which already exist are matched by
+// name, and transactions already imported are skipped. Everything is created in one database transaction, so an import
+// which fails creates nothing and may be retried.
+//
+// Split transactions become a Transaction per split, sharing a SplitID. Transfers become Transactions with an internal
+// Payee, and the other side of a transfer is skipped when the export holds it too.
+func (s Service) importYNAB(ctx context.Context, budget *fileimport.YNABBudget) (*YNABImport, error) {
+	accountNames, categoryPaths, payeeNames := slices.Clone(budget.Accounts), slices.Clone(budget.Categories), slices.Clone(budget.Payees)
+	for _, transaction := range budget.Transactions {
+		accountNames = append(accountNames, transaction.Account, transaction.TransferAccount)
+		categoryPaths = append(categoryPaths, transaction.Category)
+		for _, split := range transaction.Splits {
+			accountNames = append(accountNames, split.TransferAccount)
+			categoryPaths = append(categoryPaths, split.Category)
+		}
+	}
+
+	var createdAccounts []*budgit.Account
+	var assignments []*budgit.Assignment
+	var transactions []*budgit.Transaction
+	err := s.inTx(ctx, func(conn Conn) error {
+		var accountIDsByName map[string]string
+		var err error
+		accountIDsByName, createdAccounts, err = s.accountIDsByName(ctx, conn, accountNames...)
+		if err != nil {
+			return err
+		}
+		categoryIDsByPath, err := s.categoryIDsByPath(ctx, conn, slices.DeleteFunc(categoryPaths, func(path string) bool { return path == "" })...)
+		if err != nil {
+			return err
+		}
+
+		assignments = make([]*budgit.Assignment, 0, len(budget.Assignments))
+		for _, assignment := range budget.Assignments {
+			assignments = append(assignments, &budgit.Assignment{
+				CategoryID: categoryIDsByPath[assignment.Category],
+				Month:      assignment.Month,
+				Amount:     assignment.Amount,
+			})
+		}
+		if len(assignments) != 0 {
+			if assignments, err = s.assign(ctx, conn, assignments...); err != nil {
+				return err
+			}
+		}
+
+		var transactionPayeeNames []string
+		transactions, transactionPayeeNames, err = s.ynabTransactions(ctx, conn, budget.Transactions, accountIDsByName, categoryIDsByPath)
+		if err != nil {
+			return err
+		}
+		payeeNames = slices.DeleteFunc(append(payeeNames, transactionPayeeNames...), func(name string) bool { return name == "" })
+		payeeIDsByName, err := s.payeeIDsByName(ctx, conn, payeeNames...)
+		if err != nil {
+			return err
+		}
+		for i, transaction := range transactions {
+			if !transaction.IsPayeeInternal {
+				transaction.PayeeID = payeeIDsByName[transactionPayeeNames[i]]
+			}
+		}
+		if len(transactions) != 0 {
+			transactions, err = s.createTransactions(ctx, conn, transactions...)
+		}
+		return err
+	}, pgx.TxOptions{AccessMode: pgx.ReadWrite})
+	if err != nil {
+		return nil, fmt.Errorf("importing YNAB budget: %w", err)
+	}
+
+	return &YNABImport{
+		Accounts:     createdAccounts,
+		Assignments:  assignments,
+		Transactions: transactions,
+		Unmapped:     budget.Unmapped,
+	}, nil
+}
+
+// ynabTransactions returns the Transactions to create for YNAB transactions not already imported, with the name of
+// the Payee of each, by index, which is empty for transfers.
+func (s Service) ynabTransactions(ctx context.Context, conn Conn, ynabTransactions []*fileimport.YNABTransaction, accountIDsByName, categoryIDsByPath map[string]string) ([]*budgit.Transaction, []string, error) {
+	byAccount := map[string][]*fileimport.YNABTransaction{}
+	accounts := []string{}
+	for _, transaction := range ynabTransactions {
+		if _, ok := byAccount[transaction.Account]; !ok {
+			accounts = append(accounts, transaction.Account)
+		}
+		byAccount[transaction.Account] = append(byAccount[transaction.Account], transaction)
+	}
+
+	transfers := transferMatcher{}
+	transactions, payeeNames := []*budgit.Transaction{}, []string{}
+	for _, account := range accounts {
+		accountID := accountIDsByName[account]
+		external := make([]*budgit.ExternalTransaction, 0, len(byAccount[account]))
+		for _, transaction := range byAccount[account] {
+			external = append(external, &budgit.ExternalTransaction{
+				ID:            transaction.ID,
+				EffectiveDate: transaction.Date,
+				PayeeName:     transaction.Payee,
+				Memo:          transaction.Memo,
+				Amount:        transaction.Amount,
+				Cleared:       transaction.Cleared,
+			})
+		}
+		candidates, err := s.previewImport(ctx, conn, accountID, external)
+		if err != nil {
+			return nil, nil, fmt.Errorf("previewing import into account %q: %w", accountID, err)
+		}
+
+		for i, candidate := range candidates {
+			if candidate.Duplicate {
+				continue
+			}
+			transaction := byAccount[account][i]
+			splits, splitID := transaction.Splits, ""
+			if len(splits) == 0 {
+				splits = []*fileimport.YNABSplit{{
+					Payee:           transaction.Payee,
+					Category:        transaction.Category,
+					TransferAccount: transaction.TransferAccount,
+					Memo:            transaction.Memo,
+					Amount:          transaction.Amount,
+				}}
+			} else {
+				splitID = uuid.New().String()
+			}
+
+			for _, split := range splits {
+				memo := split.Memo
+				if memo == "" {
+					memo = transaction.Memo
+				}
+				added := &budgit.Transaction{
+					ID:            uuid.New().String(),
+					EffectiveDate: transaction.Date,
+					AccountID:     accountID,
+					CategoryID:    categoryIDsByPath[split.Category],
+					Amount:        split.Amount,
+					Cleared:       transaction.Cleared,
+					Memo:          memo,
+					ImportID:      transaction.ID,
+					SplitID:       splitID,
+				}
+
+				payeeName := ""
+				otherAccountID := accountIDsByName[split.TransferAccount]
+				if split.TransferAccount != "" && otherAccountID != accountID {
+					if transfers.isMirror(accountID, otherAccountID, transaction.Date, split.Amount) {
+						continue
+					}
+					added.PayeeID, added.IsPayeeInternal = otherAccountID, true
+				} else {
+					payee := split.Payee
+					if payee == "" {
+						payee = transaction.Payee
+					}
+					payeeName = importedPayeeName(&budgit.ExternalTransaction{PayeeName: payee})
+				}
+				transactions = append(transactions, added)
+				payeeNames = append(payeeNames, payeeName)
+			}
+		}
+	}
+	return transactions, payeeNames, nil
+}
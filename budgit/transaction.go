@@ -14,6 +14,14 @@ type Transaction struct {
 	CategoryID      string
 	Amount          BalanceAmount
 	Cleared         bool
+	Memo            string
+	// ImportID identifies the row of a statement the transaction was imported from, if any.
+	ImportID string
+	// ImportIntegrationID is the ID of the Integration the transaction was imported from, if it was imported from the
+	// external account linked to its Account rather than from a file. Only such transactions are written back.
+	ImportIntegrationID string
+	// SplitID groups the Transactions a split transaction is divided into, each with its own Category and Amount.
+	SplitID string
 }
 
 // Mirror mirrors the transaction, by returning another with the same fields but:
@@ -30,5 +38,28 @@ func (t Transaction) Mirror(id string) *Transaction {
 		CategoryID:      t.CategoryID,
 		Amount:          -t.Amount,
 		Cleared:         t.Cleared,
+		Memo:            t.Memo,
+		SplitID:         t.SplitID,
 	}
 }
+
+// ExternalTransaction is a transaction of an ExternalAccount, as reported by its integration.
+// Amount is signed, negative for money leaving the account.
+type ExternalTransaction struct {
+	ID                string
+	ExternalAccountID string
+	IntegrationID     string
+	EffectiveDate     time.Time
+	PayeeName         string
+	Reference         string
+	Memo              string
+	Amount            BalanceAmount
+	Cleared           bool
+}
+
+// ExternalTransactionUpdate is a change to a transaction of an ExternalAccount, to write back to its integration.
+// Nil fields are left unchanged.
+type ExternalTransactionUpdate struct {
+	Memo     *string
+	Category *string
+}
@@ -29,6 +29,8 @@ func (s *budgitSuite) TestTransaction() {
 				IsPayeeInternal: true,
 				Amount:          1,
 				Cleared:         true,
+				Memo:            "memo-1",
+				SplitID:         "split_id-1",
 			},
 			mirrorTransaction: &budgit.Transaction{
 				ID:              "mirror_id-1",
@@ -38,6 +40,8 @@ func (s *budgitSuite) TestTransaction() {
 				IsPayeeInternal: true,
 				Amount:          -1,
 				Cleared:         true,
+				Memo:            "memo-1",
+				SplitID:         "split_id-1",
 			},
 		},
 	}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// monthState is the state of the budget screen, listing for each Category the amount assigned to it for a month, its
+// activity, the sum of its Transactions that month, and what is available of the amount assigned after its activity.
+type monthState struct {
+	month  time.Time
+	cursor int
+}
+
+// budgetRow is a row of the budget, either the name of a CategoryGroup or a Category within it.
+type budgetRow struct {
+	group    *budgit.CategoryGroup
+	category *budgit.Category
+}
+
+// rows returns the CategoryGroups and their Categories, sorted by name.
+func (s *monthState) rows(m *Model) []budgetRow {
+	groups := slices.Clone(m.budget.groups)
+	slices.SortFunc(groups, func(a, b *budgit.CategoryGroup) int { return strings.Compare(a.Name, b.Name) })
+	categories := slices.Clone(m.budget.categories)
+	slices.SortFunc(categories, func(a, b *budgit.Category) int { return strings.Compare(a.Name, b.Name) })
+
+	rows := []budgetRow{}
+	for _, group := range groups {
+		rows = append(rows, budgetRow{group: group})
+		for _, category := range categories {
+			if category.GroupID == group.ID {
+				rows = append(rows, budgetRow{group: group, category: category})
+			}
+		}
+	}
+	return rows
+}
+
+// categories returns the Categories of the budget in the order they are listed, which the cursor moves between.
+func (s *monthState) categories(m *Model) []*budgit.Category {
+	categories := []*budgit.Category{}
+	for _, row := range s.rows(m) {
+		if row.category != nil {
+			categories = append(categories, row.category)
+		}
+	}
+	return categories
+}
+
+func (s *monthState) clamp(m *Model) {
+	s.cursor = min(max(s.cursor, 0), max(len(s.categories(m))-1, 0))
+}
+
+func (s *monthState) assigned(m *Model) map[string]budgit.BalanceAmount {
+	assigned := map[string]budgit.BalanceAmount{}
+	for _, assignment := range m.budget.assignments {
+		assigned[assignment.CategoryID] += assignment.Amount
+	}
+	return assigned
+}
+
+func (s *monthState) activity(m *Model) map[string]budgit.BalanceAmount {
+	transactions := []*budgit.Transaction{}
+	for _, accountTransactions := range m.budget.transactions {
+		transactions = append(transactions, accountTransactions...)
+	}
+	return budgit.CategoryActivity(s.month, transactions...)
+}
+
+func (s *monthState) handleKey(m *Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "left", "h":
+		s.month = s.month.AddDate(0, -1, 0)
+		return m.load()
+	case "right", "l":
+		s.month = s.month.AddDate(0, 1, 0)
+		return m.load()
+	case "up", "k":
+		s.cursor--
+		s.clamp(m)
+	case "down", "j":
+		s.cursor++
+		s.clamp(m)
+	case "e", "enter":
+		categories := s.categories(m)
+		if len(categories) == 0 {
+			return nil
+		}
+		category := categories[s.cursor]
+		m.prompt = &prompt{
+			label:  fmt.Sprintf("Assign to %s for %s", category.Name, s.month.Format("January 2006")),
+			value:  s.assigned(m)[category.ID].String(),
+			submit: s.assign(category),
+		}
+	}
+	return nil
+}
+
+func (s *monthState) assign(category *budgit.Category) func(m *Model, value string) (tea.Cmd, error) {
+	return func(m *Model, value string) (tea.Cmd, error) {
+		amount, err := budgit.ParseBalanceAmount(value)
+		if err != nil {
+			return nil, err
+		}
+		assignment := &budgit.Assignment{CategoryID: category.ID, Month: s.month, Amount: amount}
+		return change(func() (string, error) {
+			if _, err := m.backend.Assign(m.ctx, assignment); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Assigned %s to %s", amount, category.Name), nil
+		}), nil
+	}
+}
+
+const budgetColumns = "  %s  %s  %s  %s"
+
+// budgetOtherLines is the number of lines of the screen other than the rows of the budget.
+const budgetOtherLines = 9
+
+func (s *monthState) view(m *Model) []string {
+	lines := []string{titleStyle.Render(s.month.Format("January 2006")), ""}
+	rows := s.rows(m)
+	if len(rows) == 0 {
+		return append(lines, "No Categories yet.")
+	}
+
+	assigned, activity := s.assigned(m), s.activity(m)
+	lines = append(lines, headerStyle.Render(fmt.Sprintf(budgetColumns,
+		cell("CATEGORY", 30), cell("ASSIGNED", -10), cell("ACTIVITY", -10), cell("AVAILABLE", -10))))
+
+	var selected *budgit.Category
+	if categories := s.categories(m); len(categories) != 0 {
+		selected = categories[s.cursor]
+	}
+	cursorRow := slices.IndexFunc(rows, func(row budgetRow) bool { return row.category != nil && row.category == selected })
+	start, end := m.visibleRows(len(rows), cursorRow, budgetOtherLines)
+	for i := start; i < end; i++ {
+		row := rows[i]
+		if row.category == nil {
+			lines = append(lines, fmt.Sprintf("  %s", row.group.Name))
+			continue
+		}
+		id := row.category.ID
+		line := fmt.Sprintf(budgetColumns,
+			cell("  "+row.category.Name, 30),
+			cell(assigned[id].String(), -10),
+			cell(activity[id].String(), -10),
+			cell((assigned[id]+activity[id]).String(), -10))
+		if i == cursorRow {
+			line = selectedStyle.Render(">" + line[1:])
+		}
+		lines = append(lines, line)
+	}
+
+	var totalAssigned, totalActivity budgit.BalanceAmount
+	for _, category := range s.categories(m) {
+		totalAssigned += assigned[category.ID]
+		totalActivity += activity[category.ID]
+	}
+	return append(lines, headerStyle.Render(fmt.Sprintf(budgetColumns,
+		cell("TOTAL", 30),
+		cell(totalAssigned.String(), -10),
+		cell(totalActivity.String(), -10),
+		cell((totalAssigned+totalActivity).String(), -10))))
+}
@@ -0,0 +1,276 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andrewthowell/budgit/budgit"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+)
+
+// transferPrefix prefixes the name of an Account given as the payee of a Transaction, making it a transfer.
+const transferPrefix = "Transfer: "
+
+// editText applies a key to a value being typed, returning whether the key edited it.
+func editText(value string, msg tea.KeyMsg) (string, bool) {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		return value + string(msg.Runes), true
+	case tea.KeyBackspace:
+		if value == "" {
+			return value, true
+		}
+		_, size := utf8.DecodeLastRuneInString(value)
+		return value[:len(value)-size], true
+	}
+	return value, false
+}
+
+// prompt asks for a single value, such as the amount to assign to a Category.
+type prompt struct {
+	label, value string
+	// submit is called with the value entered, keeping the prompt open if it returns an error.
+	submit func(m *Model, value string) (tea.Cmd, error)
+	err    error
+}
+
+func (p *prompt) handleKey(m *Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.prompt = nil
+		return nil
+	case "enter":
+		cmd, err := p.submit(m, p.value)
+		if err != nil {
+			p.err = err
+			return nil
+		}
+		m.prompt = nil
+		return cmd
+	}
+	p.value, _ = editText(p.value, msg)
+	p.err = nil
+	return nil
+}
+
+func (p *prompt) view() string {
+	line := fmt.Sprintf("%s: %s_", p.label, p.value)
+	if p.err != nil {
+		line += "  " + errorStyle.Render(p.err.Error())
+	}
+	return line
+}
+
+const (
+	fieldDate = iota
+	fieldPayee
+	fieldCategory
+	fieldAmount
+	fieldMemo
+	fieldCleared
+	fieldCount
+)
+
+var fieldLabels = [fieldCount]string{"Date", "Payee", "Category", "Amount", "Memo", "Cleared"}
+
+// transactionForm adds a Transaction to an Account, or edits one. The payee is given by name, or as "Transfer: " and
+// the name of an Account for transfers, and is created if no Payee has the name. The category is given by its path,
+// see budgit.CategoryPath.
+type transactionForm struct {
+	account *budgit.Account
+	// editing is the Transaction being edited, or nil if adding one.
+	editing *budgit.Transaction
+	values  [fieldCount]string
+	cleared bool
+	focus   int
+	err     error
+}
+
+func newTransactionForm(m *Model, account *budgit.Account, editing *budgit.Transaction) *transactionForm {
+	form := &transactionForm{account: account, editing: editing, focus: fieldPayee}
+	if editing == nil {
+		form.values[fieldDate] = m.now().Format(time.DateOnly)
+		return form
+	}
+	form.values[fieldDate] = editing.EffectiveDate.Format(time.DateOnly)
+	form.values[fieldPayee] = payeeName(m.budget, editing)
+	form.values[fieldCategory] = categoryPath(m.budget, editing.CategoryID)
+	form.values[fieldAmount] = editing.Amount.String()
+	form.values[fieldMemo] = editing.Memo
+	form.cleared = editing.Cleared
+	form.focus = fieldAmount
+	return form
+}
+
+func (f *transactionForm) handleKey(m *Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.form = nil
+		return nil
+	case "enter":
+		cmd, err := f.submit(m)
+		f.err = err
+		return cmd
+	case "tab", "down":
+		f.focus = (f.focus + 1) % fieldCount
+		return nil
+	case "shift+tab", "up":
+		f.focus = (f.focus + fieldCount - 1) % fieldCount
+		return nil
+	}
+	if f.focus == fieldCleared {
+		if msg.Type == tea.KeySpace {
+			f.cleared = !f.cleared
+		}
+		return nil
+	}
+	f.values[f.focus], _ = editText(f.values[f.focus], msg)
+	return nil
+}
+
+// submit validates the form, returning a command saving the Transaction if it is valid.
+func (f *transactionForm) submit(m *Model) (tea.Cmd, error) {
+	transaction := &budgit.Transaction{ID: uuid.New().String(), AccountID: f.account.ID}
+	if f.editing != nil {
+		copied := *f.editing
+		transaction = &copied
+	}
+
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(f.values[fieldDate]))
+	if err != nil {
+		return nil, fmt.Errorf("date %q is not written YYYY-MM-DD", f.values[fieldDate])
+	}
+	categoryID, err := findCategory(m.budget, f.values[fieldCategory])
+	if err != nil {
+		return nil, err
+	}
+	amount, err := budgit.ParseBalanceAmount(f.values[fieldAmount])
+	if err != nil {
+		return nil, err
+	}
+	transaction.EffectiveDate, transaction.Amount, transaction.CategoryID = date, amount, categoryID
+	transaction.Memo, transaction.Cleared = strings.TrimSpace(f.values[fieldMemo]), f.cleared
+
+	payee := strings.TrimSpace(f.values[fieldPayee])
+	var newPayee *budgit.Payee
+	switch {
+	case payee == "":
+		return nil, errors.New("a payee is required")
+	case strings.HasPrefix(payee, transferPrefix):
+		accountName := strings.TrimSpace(strings.TrimPrefix(payee, transferPrefix))
+		account := findAccount(m.budget, accountName)
+		if account == nil {
+			return nil, fmt.Errorf("no Account is named %q", accountName)
+		}
+		if account.ID == transaction.AccountID {
+			return nil, errors.New("a transfer must be to another Account")
+		}
+		transaction.PayeeID, transaction.IsPayeeInternal = account.ID, true
+	default:
+		transaction.IsPayeeInternal = false
+		if existing := findPayee(m.budget, payee); existing != nil {
+			transaction.PayeeID = existing.ID
+		} else {
+			newPayee = &budgit.Payee{ID: uuid.New().String(), Name: payee}
+			transaction.PayeeID = newPayee.ID
+		}
+	}
+
+	return change(func() (string, error) {
+		if newPayee != nil {
+			if _, err := m.backend.CreatePayees(m.ctx, newPayee); err != nil {
+				return "", err
+			}
+		}
+		if f.editing != nil {
+			if _, err := m.backend.UpdateTransactions(m.ctx, transaction); err != nil {
+				return "", err
+			}
+			return "Saved Transaction", nil
+		}
+		if _, err := m.backend.CreateTransactions(m.ctx, transaction); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Added Transaction of %s to %s", transaction.Amount, f.account.Name), nil
+	}), nil
+}
+
+func (f *transactionForm) view() []string {
+	title := "Add Transaction to " + f.account.Name
+	if f.editing != nil {
+		title = "Edit Transaction of " + f.account.Name
+	}
+	lines := []string{titleStyle.Render(title), ""}
+	for i, label := range fieldLabels {
+		value := f.values[i]
+		if i == fieldCleared {
+			value = "[ ]"
+			if f.cleared {
+				value = "[x]"
+			}
+		}
+		prefix := "  "
+		if i == f.focus {
+			prefix = "> "
+			if i != fieldCleared {
+				value += "_"
+			}
+		}
+		lines = append(lines, prefix+cell(label, 10)+value)
+	}
+	lines = append(lines, "")
+	if f.err != nil {
+		lines = append(lines, errorStyle.Render(f.err.Error()))
+	} else {
+		lines = append(lines, helpStyle.Render(`Payees are created if new. Give "Transfer: " and an Account's name for transfers.`))
+	}
+	return lines
+}
+
+func findAccount(data *budgetData, name string) *budgit.Account {
+	for _, account := range data.accounts {
+		if strings.EqualFold(account.Name, name) {
+			return account
+		}
+	}
+	return nil
+}
+
+func findPayee(data *budgetData, name string) *budgit.Payee {
+	for _, payee := range data.payees {
+		if strings.EqualFold(payee.Name, name) {
+			return payee
+		}
+	}
+	return nil
+}
+
+// findCategory returns the ID of the Category with a category path, or with a name unique among Categories, or no ID
+// if no path is given.
+func findCategory(data *budgetData, path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", nil
+	}
+	var byName []string
+	for _, category := range data.categories {
+		if strings.EqualFold(categoryPath(data, category.ID), path) {
+			return category.ID, nil
+		}
+		if strings.EqualFold(category.Name, path) {
+			byName = append(byName, category.ID)
+		}
+	}
+	switch len(byName) {
+	case 0:
+		return "", fmt.Errorf("no Category is named %q", path)
+	case 1:
+		return byName[0], nil
+	default:
+		return "", fmt.Errorf("several Categories are named %q, give its group too, e.g. \"Group:%s\"", path, path)
+	}
+}
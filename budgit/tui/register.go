@@ -0,0 +1,256 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// registerState is the state of the register screen, listing the Transactions of an Account, newest first.
+type registerState struct {
+	accountIndex int
+	cursor       int
+	// reconciling is the reconciliation of the Account against a statement in progress, if any.
+	reconciling *reconciliation
+}
+
+// reconciliation is the reconciliation of an Account's cleared balance against the closing balance of a statement.
+// Transactions are marked cleared until the difference between the two is zero.
+type reconciliation struct {
+	statementBalance budgit.BalanceAmount
+}
+
+// registerRow is a Transaction of the register with the effective balance of its Account after it.
+type registerRow struct {
+	transaction *budgit.Transaction
+	balance     budgit.BalanceAmount
+}
+
+func (r *registerState) account(m *Model) *budgit.Account {
+	if len(m.budget.accounts) == 0 {
+		return nil
+	}
+	return m.budget.accounts[r.accountIndex]
+}
+
+// rows returns the Transactions of the Account, newest first, with running balances. As the Account may have been
+// created with an opening balance, the balances run back from its current effective balance.
+func (r *registerState) rows(m *Model) []registerRow {
+	account := r.account(m)
+	if account == nil {
+		return nil
+	}
+	transactions := slices.Clone(m.budget.transactions[account.ID])
+	slices.SortStableFunc(transactions, func(a, b *budgit.Transaction) int {
+		if c := b.EffectiveDate.Compare(a.EffectiveDate); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	rows := make([]registerRow, 0, len(transactions))
+	balance := account.Balance.EffectiveBalance
+	for _, transaction := range transactions {
+		rows = append(rows, registerRow{transaction: transaction, balance: balance})
+		balance -= transaction.Amount
+	}
+	return rows
+}
+
+func (r *registerState) selected(m *Model) *budgit.Transaction {
+	rows := r.rows(m)
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[r.cursor].transaction
+}
+
+// clamp keeps the selected Account and Transaction within those loaded.
+func (r *registerState) clamp(m *Model) {
+	r.accountIndex = min(max(r.accountIndex, 0), max(len(m.budget.accounts)-1, 0))
+	r.cursor = min(max(r.cursor, 0), max(len(r.rows(m))-1, 0))
+}
+
+func (r *registerState) handleKey(m *Model, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "left", "h":
+		r.selectAccount(m, r.accountIndex-1)
+	case "right", "l":
+		r.selectAccount(m, r.accountIndex+1)
+	case "up", "k":
+		r.cursor--
+		r.clamp(m)
+	case "down", "j":
+		r.cursor++
+		r.clamp(m)
+	case "a":
+		if account := r.account(m); account != nil {
+			m.form = newTransactionForm(m, account, nil)
+		}
+	case "e", "enter":
+		if transaction := r.selected(m); transaction != nil {
+			m.form = newTransactionForm(m, r.account(m), transaction)
+		}
+	case "c":
+		return r.toggleCleared(m)
+	case "r":
+		if r.account(m) != nil && r.reconciling == nil {
+			m.prompt = &prompt{label: "Statement balance", submit: r.startReconciling}
+		}
+	case "esc":
+		if r.reconciling != nil {
+			r.finishReconciling(m)
+		}
+	}
+	return nil
+}
+
+func (r *registerState) selectAccount(m *Model, index int) {
+	if r.reconciling != nil {
+		m.status = "Finish reconciling before changing Account"
+		return
+	}
+	r.accountIndex, r.cursor = index, 0
+	r.clamp(m)
+}
+
+func (r *registerState) toggleCleared(m *Model) tea.Cmd {
+	selected := r.selected(m)
+	if selected == nil {
+		return nil
+	}
+	transaction := *selected
+	transaction.Cleared = !transaction.Cleared
+	return change(func() (string, error) {
+		if _, err := m.backend.UpdateTransactions(m.ctx, &transaction); err != nil {
+			return "", err
+		}
+		if transaction.Cleared {
+			return "Marked Transaction cleared", nil
+		}
+		return "Marked Transaction uncleared", nil
+	})
+}
+
+func (r *registerState) startReconciling(m *Model, value string) (tea.Cmd, error) {
+	balance, err := budgit.ParseBalanceAmount(value)
+	if err != nil {
+		return nil, err
+	}
+	r.reconciling = &reconciliation{statementBalance: balance}
+	return nil, nil
+}
+
+func (r *registerState) finishReconciling(m *Model) {
+	difference := r.difference(m)
+	if difference == 0 {
+		m.status = fmt.Sprintf("Reconciled %s to the statement balance of %s", r.account(m).Name, r.reconciling.statementBalance)
+	} else {
+		m.status = fmt.Sprintf("Stopped reconciling %s with a difference of %s", r.account(m).Name, difference)
+	}
+	r.reconciling = nil
+}
+
+// difference returns how much the statement balance being reconciled to exceeds the Account's cleared balance.
+func (r *registerState) difference(m *Model) budgit.BalanceAmount {
+	return r.reconciling.statementBalance - r.account(m).Balance.ClearedBalance
+}
+
+const registerColumns = "  %s  %s  %s  %s  %s  %s  %s"
+
+// registerOtherLines is the number of lines of the screen other than the rows of the register.
+const registerOtherLines = 8
+
+func (r *registerState) view(m *Model) []string {
+	account := r.account(m)
+	if account == nil {
+		return []string{"No Accounts yet, create one with `budgit accounts create`."}
+	}
+
+	lines := []string{fmt.Sprintf("%s (%d/%d)   Cleared %s   Effective %s",
+		titleStyle.Render(account.Name), r.accountIndex+1, len(m.budget.accounts),
+		account.Balance.ClearedBalance, account.Balance.EffectiveBalance)}
+	if r.reconciling != nil {
+		difference := r.difference(m)
+		state := fmt.Sprintf("difference %s", difference)
+		if difference == 0 {
+			state = "balanced, esc to finish"
+		}
+		lines = append(lines, fmt.Sprintf("Reconciling to statement balance %s: cleared %s, %s",
+			r.reconciling.statementBalance, account.Balance.ClearedBalance, state))
+	} else {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, headerStyle.Render(fmt.Sprintf(registerColumns,
+		cell("DATE", 10), cell("PAYEE", 20), cell("CATEGORY", 20), cell("MEMO", 16),
+		cell("AMOUNT", -10), cell("BALANCE", -10), "C")))
+	rows := r.rows(m)
+	if len(rows) == 0 {
+		return append(lines, "  No Transactions yet, press a to add one.")
+	}
+	start, end := m.visibleRows(len(rows), r.cursor, registerOtherLines)
+	for i := start; i < end; i++ {
+		transaction := rows[i].transaction
+		cleared := " "
+		if transaction.Cleared {
+			cleared = "*"
+		}
+		line := fmt.Sprintf(registerColumns,
+			cell(transaction.EffectiveDate.Format("2006-01-02"), 10),
+			cell(payeeName(m.budget, transaction), 20),
+			cell(categoryPath(m.budget, transaction.CategoryID), 20),
+			cell(transaction.Memo, 16),
+			cell(transaction.Amount.String(), -10),
+			cell(rows[i].balance.String(), -10),
+			cleared)
+		if i == r.cursor {
+			line = selectedStyle.Render(">" + line[1:])
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// payeeName returns the name of a Transaction's Payee, or of the Account transferred to or from.
+func payeeName(data *budgetData, transaction *budgit.Transaction) string {
+	if transaction.IsPayeeInternal {
+		for _, account := range data.accounts {
+			if account.ID == transaction.PayeeID {
+				return transferPrefix + account.Name
+			}
+		}
+		return transferPrefix + transaction.PayeeID
+	}
+	for _, payee := range data.payees {
+		if payee.ID == transaction.PayeeID {
+			return payee.Name
+		}
+	}
+	return transaction.PayeeID
+}
+
+// categoryPath returns the category path of a Category, see budgit.CategoryPath.
+func categoryPath(data *budgetData, categoryID string) string {
+	if categoryID == "" {
+		return ""
+	}
+	for _, category := range data.categories {
+		if category.ID == categoryID {
+			return budgit.CategoryPath(groupName(data, category.GroupID), category.Name)
+		}
+	}
+	return categoryID
+}
+
+func groupName(data *budgetData, groupID string) string {
+	for _, group := range data.groups {
+		if group.ID == groupID {
+			return group.Name
+		}
+	}
+	return groupID
+}
@@ -0,0 +1,321 @@
+// Package tui is a keyboard-driven terminal UI for day-to-day budgeting, built on Bubble Tea.
+//
+// It has two screens, switched between with tab: the register of an Account, listing its Transactions with running
+// balances, where Transactions are quickly added, edited and reconciled against a statement; and the budget of a month,
+// listing the amount assigned to and spent from each Category.
+//
+// The UI runs against a Backend, which is either the budgit service itself or, through api.ServiceClient, a budgit
+// server's API.
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/api"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Backend is what the UI reads and changes the budget through, implemented by svc.Service and api.ServiceClient.
+type Backend interface {
+	ListAccounts(ctx context.Context) ([]*budgit.Account, error)
+	CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error)
+	ListPayees(ctx context.Context) ([]*budgit.Payee, error)
+	CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error)
+	UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error)
+	ListCategories(ctx context.Context) ([]*budgit.Category, error)
+	ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error)
+	Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error)
+}
+
+var (
+	_ Backend = (*svc.Service)(nil)
+	_ Backend = (*api.ServiceClient)(nil)
+)
+
+// Run runs the UI until it is quit or the context is done.
+func Run(ctx context.Context, backend Backend, in io.Reader, out io.Writer) error {
+	program := tea.NewProgram(New(ctx, backend, time.Now),
+		tea.WithContext(ctx),
+		tea.WithInput(in),
+		tea.WithOutput(out),
+		tea.WithAltScreen(),
+	)
+	if _, err := program.Run(); err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("running terminal UI: %w", err)
+	}
+	return nil
+}
+
+type screen int
+
+const (
+	screenRegister screen = iota
+	screenBudget
+)
+
+// Model is the Bubble Tea model of the UI.
+type Model struct {
+	ctx     context.Context
+	backend Backend
+	now     func() time.Time
+
+	width, height int
+	screen        screen
+
+	budget   *budgetData
+	register registerState
+	month    monthState
+
+	// form is the Transaction being added or edited, if any.
+	form *transactionForm
+	// prompt is the single value being entered, if any, such as a statement balance.
+	prompt *prompt
+
+	status string
+	err    error
+}
+
+// New returns the Model of the UI, loading the budget from the backend when started. now gives the current time,
+// dating new Transactions and choosing the month first budgeted.
+func New(ctx context.Context, backend Backend, now func() time.Time) *Model {
+	return &Model{
+		ctx:     ctx,
+		backend: backend,
+		now:     now,
+		month:   monthState{month: firstOfMonth(now())},
+	}
+}
+
+// budgetData is everything loaded from the backend, reloaded after every change.
+type budgetData struct {
+	accounts   []*budgit.Account
+	payees     []*budgit.Payee
+	groups     []*budgit.CategoryGroup
+	categories []*budgit.Category
+	// transactions are the Transactions of each Account, by Account ID.
+	transactions map[string][]*budgit.Transaction
+	assignments  []*budgit.Assignment
+}
+
+type loadedMsg struct {
+	data *budgetData
+}
+
+type errMsg struct {
+	err error
+}
+
+// changedMsg is sent when a change to the budget succeeded, describing it.
+type changedMsg struct {
+	status string
+}
+
+func (m *Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// load loads the budget, as of the month being budgeted.
+func (m *Model) load() tea.Cmd {
+	ctx, backend, month := m.ctx, m.backend, m.month.month
+	return func() tea.Msg {
+		data := &budgetData{transactions: map[string][]*budgit.Transaction{}}
+		var err error
+		if data.accounts, err = backend.ListAccounts(ctx); err != nil {
+			return errMsg{err}
+		}
+		if data.payees, err = backend.ListPayees(ctx); err != nil {
+			return errMsg{err}
+		}
+		if data.groups, err = backend.ListCategoryGroups(ctx); err != nil {
+			return errMsg{err}
+		}
+		if data.categories, err = backend.ListCategories(ctx); err != nil {
+			return errMsg{err}
+		}
+		for _, account := range data.accounts {
+			if data.transactions[account.ID], err = backend.ListTransactions(ctx, account.ID); err != nil {
+				return errMsg{err}
+			}
+		}
+		if data.assignments, err = backend.ListAssignments(ctx, month); err != nil {
+			return errMsg{err}
+		}
+		return loadedMsg{data}
+	}
+}
+
+// change runs a change to the budget, after which it is reloaded.
+func change(change func() (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		status, err := change()
+		if err != nil {
+			return errMsg{err}
+		}
+		return changedMsg{status}
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case loadedMsg:
+		m.budget = msg.data
+		m.register.clamp(m)
+		m.month.clamp(m)
+		return m, nil
+	case changedMsg:
+		m.form, m.status, m.err = nil, msg.status, nil
+		return m, m.load()
+	case errMsg:
+		m.err = msg.err
+		return m, nil
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case m.form != nil:
+		return m.form.handleKey(m, msg)
+	case m.prompt != nil:
+		return m.prompt.handleKey(m, msg)
+	case m.budget == nil:
+		if msg.String() == "q" {
+			return tea.Quit
+		}
+		return nil
+	}
+
+	m.status, m.err = "", nil
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "tab":
+		if m.screen == screenRegister {
+			m.screen = screenBudget
+		} else {
+			m.screen = screenRegister
+		}
+		return nil
+	}
+	if m.screen == screenBudget {
+		return m.month.handleKey(m, msg)
+	}
+	return m.register.handleKey(m, msg)
+}
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	headerStyle   = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+)
+
+func (m *Model) View() string {
+	lines := []string{m.tabs(), ""}
+	switch {
+	case m.budget == nil && m.err == nil:
+		lines = append(lines, "Loading...")
+	case m.budget == nil:
+	case m.form != nil:
+		lines = append(lines, m.form.view()...)
+	case m.screen == screenBudget:
+		lines = append(lines, m.month.view(m)...)
+	default:
+		lines = append(lines, m.register.view(m)...)
+	}
+
+	lines = append(lines, "")
+	switch {
+	case m.err != nil:
+		lines = append(lines, errorStyle.Render("Error: "+m.err.Error()))
+	case m.prompt != nil:
+		lines = append(lines, m.prompt.view())
+	case m.status != "":
+		lines = append(lines, m.status)
+	default:
+		lines = append(lines, "")
+	}
+	lines = append(lines, helpStyle.Render(m.help()))
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) tabs() string {
+	names := []string{"Register", "Budget"}
+	for i, name := range names {
+		if screen(i) == m.screen {
+			names[i] = titleStyle.Render("[" + name + "]")
+		} else {
+			names[i] = " " + name + " "
+		}
+	}
+	return "Budg-it  " + strings.Join(names, " ")
+}
+
+func (m *Model) help() string {
+	switch {
+	case m.form != nil:
+		return "tab/↑/↓ field • space toggle cleared • enter save • esc cancel"
+	case m.prompt != nil:
+		return "enter confirm • esc cancel"
+	case m.screen == screenBudget:
+		return "←/→ month • ↑/↓ category • enter assign • tab register • q quit"
+	case m.register.reconciling != nil:
+		return "↑/↓ transaction • c toggle cleared • esc finish reconciling • q quit"
+	default:
+		return "←/→ account • ↑/↓ transaction • a add • e edit • c toggle cleared • r reconcile • tab budget • q quit"
+	}
+}
+
+// visibleRows returns the range of rows to show of a list of n rows, scrolled to keep the cursor visible, given the
+// number of lines the rest of the screen takes. Every row is shown until the size of the terminal is known.
+func (m *Model) visibleRows(n, cursor, otherLines int) (int, int) {
+	if m.height == 0 {
+		return 0, n
+	}
+	rows := max(m.height-otherLines, 1)
+	if n <= rows {
+		return 0, n
+	}
+	start := min(max(cursor-rows/2, 0), n-rows)
+	return start, start + rows
+}
+
+// cell pads or truncates a value to the width of a column, right-aligning it if width is negative.
+func cell(value string, width int) string {
+	right := width < 0
+	if right {
+		width = -width
+	}
+	runes := []rune(value)
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	padding := strings.Repeat(" ", width-len(runes))
+	if right {
+		return padding + value
+	}
+	return value + padding
+}
+
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
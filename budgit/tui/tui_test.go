@@ -0,0 +1,328 @@
+package tui_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/suite"
+)
+
+var update = flag.Bool("update", false, "update the golden files of screens")
+
+func TestTUI(t *testing.T) {
+	suite.Run(t, new(tuiSuite))
+}
+
+type tuiSuite struct {
+	suite.Suite
+
+	backend *fakeBackend
+	model   tea.Model
+}
+
+func (s *tuiSuite) SetupSuite() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+func (s *tuiSuite) SetupTest() {
+	june := func(day int) time.Time { return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC) }
+	s.backend = &fakeBackend{
+		accounts: []*budgit.Account{
+			{ID: "account-1", Name: "Current", Balance: budgit.Balance{ClearedBalance: 185000, EffectiveBalance: 184230}},
+			{ID: "account-2", Name: "Savings", Balance: budgit.Balance{ClearedBalance: 10000, EffectiveBalance: 10000}},
+		},
+		payees: []*budgit.Payee{{ID: "payee-1", Name: "Tesco"}, {ID: "payee-2", Name: "Employer"}},
+		groups: []*budgit.CategoryGroup{{ID: "group-1", Name: "Bills"}, {ID: "group-2", Name: "Everyday"}},
+		categories: []*budgit.Category{
+			{ID: "category-1", GroupID: "group-2", Name: "Groceries"},
+			{ID: "category-2", GroupID: "group-1", Name: "Energy"},
+		},
+		transactions: []*budgit.Transaction{
+			{ID: "transaction-1", EffectiveDate: june(1), AccountID: "account-1", PayeeID: "payee-2", Amount: 200000, Cleared: true, Memo: "June pay"},
+			{ID: "transaction-2", EffectiveDate: june(3), AccountID: "account-1", PayeeID: "payee-1", CategoryID: "category-1", Amount: -5000, Cleared: true, Memo: "Weekly shop"},
+			{ID: "transaction-3", EffectiveDate: june(5), AccountID: "account-1", PayeeID: "account-2", IsPayeeInternal: true, Amount: -10000, Cleared: true},
+			{ID: "transaction-4", EffectiveDate: june(7), AccountID: "account-1", PayeeID: "payee-1", CategoryID: "category-1", Amount: -770},
+			{ID: "transaction-5", EffectiveDate: june(5), AccountID: "account-2", PayeeID: "account-1", IsPayeeInternal: true, Amount: 10000, Cleared: true},
+		},
+		assignments: []*budgit.Assignment{
+			{ID: "assignment-1", CategoryID: "category-1", Month: june(1), Amount: 30000},
+		},
+	}
+	now := func() time.Time { return time.Date(2024, 6, 10, 18, 30, 0, 0, time.UTC) }
+	s.model = tui.New(context.Background(), s.backend, now)
+	s.run(s.model.Init())
+}
+
+// fakeBackend is a tui.Backend holding a budget, changing the balances of Accounts with their Transactions as the
+// service does.
+type fakeBackend struct {
+	accounts     []*budgit.Account
+	payees       []*budgit.Payee
+	groups       []*budgit.CategoryGroup
+	categories   []*budgit.Category
+	transactions []*budgit.Transaction
+	assignments  []*budgit.Assignment
+	err          error
+}
+
+func (f *fakeBackend) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
+	return f.accounts, f.err
+}
+
+func (f *fakeBackend) CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
+	f.payees = append(f.payees, payees...)
+	return payees, f.err
+}
+
+func (f *fakeBackend) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	return f.payees, f.err
+}
+
+func (f *fakeBackend) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, transaction := range transactions {
+		f.adjustBalance(transaction, 1)
+	}
+	f.transactions = append(f.transactions, transactions...)
+	return transactions, nil
+}
+
+func (f *fakeBackend) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	transactions := []*budgit.Transaction{}
+	for _, transaction := range f.transactions {
+		if transaction.AccountID == accountID {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, f.err
+}
+
+func (f *fakeBackend) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, transaction := range transactions {
+		for i, existing := range f.transactions {
+			if existing.ID == transaction.ID {
+				f.adjustBalance(existing, -1)
+				f.adjustBalance(transaction, 1)
+				f.transactions[i] = transaction
+			}
+		}
+	}
+	return transactions, nil
+}
+
+func (f *fakeBackend) adjustBalance(transaction *budgit.Transaction, sign budgit.BalanceAmount) {
+	for _, account := range f.accounts {
+		if account.ID == transaction.AccountID {
+			account.Balance = account.Balance.AddAmount(sign*transaction.Amount, transaction.Cleared)
+		}
+	}
+}
+
+func (f *fakeBackend) ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error) {
+	return f.groups, f.err
+}
+
+func (f *fakeBackend) ListCategories(ctx context.Context) ([]*budgit.Category, error) {
+	return f.categories, f.err
+}
+
+func (f *fakeBackend) ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error) {
+	assignments := []*budgit.Assignment{}
+	for _, assignment := range f.assignments {
+		if assignment.Month.Equal(month) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, f.err
+}
+
+func (f *fakeBackend) Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	f.assignments = append(f.assignments, assignments...)
+	return assignments, f.err
+}
+
+// send sends messages to the model, running the commands it returns to completion.
+func (s *tuiSuite) send(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		model, cmd := s.model.Update(msg)
+		s.model = model
+		s.run(cmd)
+	}
+}
+
+func (s *tuiSuite) run(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			s.run(cmd)
+		}
+	case tea.QuitMsg, nil:
+	default:
+		s.send(msg)
+	}
+}
+
+// press sends key presses, named as tea.KeyMsg.String names them, e.g. "enter" or "a".
+func (s *tuiSuite) press(keys ...string) {
+	for _, key := range keys {
+		s.send(keyMsg(key))
+	}
+}
+
+// typeText sends the key presses typing text.
+func (s *tuiSuite) typeText(text string) {
+	for _, r := range text {
+		if r == ' ' {
+			s.send(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{r}})
+		} else {
+			s.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+}
+
+func keyMsg(key string) tea.KeyMsg {
+	types := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab, "backspace": tea.KeyBackspace,
+		"up": tea.KeyUp, "down": tea.KeyDown, "left": tea.KeyLeft, "right": tea.KeyRight, "space": tea.KeySpace,
+	}
+	if keyType, ok := types[key]; ok {
+		return tea.KeyMsg{Type: keyType}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// assertScreen asserts the screen matches its golden file, testdata/<name>.golden, which is written instead when
+// testing with -update.
+func (s *tuiSuite) assertScreen(name string) {
+	path := filepath.Join("testdata", name+".golden")
+	screen := s.model.View() + "\n"
+	if *update {
+		s.Require().NoError(os.MkdirAll("testdata", 0o755))
+		s.Require().NoError(os.WriteFile(path, []byte(screen), 0o644))
+		return
+	}
+	expected, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(string(expected), screen)
+}
+
+func (s *tuiSuite) TestRegister() {
+	s.assertScreen("register")
+
+	s.press("right")
+	s.assertScreen("register_next_account")
+}
+
+func (s *tuiSuite) TestRegisterScrollsToCursor() {
+	s.send(tea.WindowSizeMsg{Width: 100, Height: 10})
+	s.press("down", "down", "down")
+	s.assertScreen("register_scrolled")
+}
+
+func (s *tuiSuite) TestQuickAdd() {
+	s.press("a")
+	s.typeText("Corner Shop")
+	s.press("tab")
+	s.typeText("groceries")
+	s.press("tab")
+	s.typeText("-3.20")
+	s.press("tab")
+	s.typeText("Milk")
+	s.assertScreen("quick_add_form")
+
+	s.press("enter")
+	s.assertScreen("quick_add_saved")
+	s.Require().Len(s.backend.payees, 3)
+	s.Equal("Corner Shop", s.backend.payees[2].Name)
+	s.CMPEqual(&budgit.Transaction{
+		EffectiveDate: time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC),
+		AccountID:     "account-1",
+		PayeeID:       s.backend.payees[2].ID,
+		CategoryID:    "category-1",
+		Amount:        -320,
+		Memo:          "Milk",
+	}, s.backend.transactions[5], cmpopts.IgnoreFields(budgit.Transaction{}, "ID"))
+}
+
+func (s *tuiSuite) TestQuickAddInvalid() {
+	s.press("a")
+	s.typeText("Tesco")
+	s.press("tab")
+	s.typeText("Holidays")
+	s.press("enter")
+	s.assertScreen("quick_add_invalid")
+	s.Len(s.backend.transactions, 5)
+}
+
+func (s *tuiSuite) TestEdit() {
+	s.press("e", "backspace", "backspace")
+	s.typeText("50")
+	s.assertScreen("edit_form")
+
+	s.press("enter")
+	s.Equal(budgit.BalanceAmount(-750), s.backend.transactions[3].Amount)
+	s.assertScreen("edit_saved")
+}
+
+func (s *tuiSuite) TestReconcile() {
+	s.press("r")
+	s.typeText("1842.30")
+	s.press("enter")
+	s.assertScreen("reconcile_difference")
+
+	s.press("c")
+	s.assertScreen("reconcile_balanced")
+
+	s.press("esc")
+	s.assertScreen("reconcile_finished")
+}
+
+func (s *tuiSuite) TestBudget() {
+	s.press("tab")
+	s.assertScreen("budget")
+
+	s.press("down", "enter")
+	for range 6 {
+		s.press("backspace")
+	}
+	s.typeText("200")
+	s.assertScreen("budget_assign_prompt")
+
+	s.press("enter")
+	s.assertScreen("budget_assigned")
+
+	s.press("left")
+	s.assertScreen("budget_previous_month")
+}
+
+func (s *tuiSuite) TestError() {
+	s.backend.err = errors.New("connection refused")
+	s.press("c")
+	s.assertScreen("error")
+}
+
+func (s *tuiSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
@@ -0,0 +1,78 @@
+package budgit
+
+import (
+	"slices"
+	"time"
+)
+
+// User is a person who logs in to budgit.
+type User struct {
+	ID       string
+	Username string
+	// TOTPEnabled is whether the User has a TOTP second factor, a code of which they must give to log in.
+	TOTPEnabled      bool
+	CreatedTimestamp time.Time
+}
+
+// APIToken is a long-lived token a User authenticates to the API with, such as from scripts. The token itself is only
+// known when it is created.
+type APIToken struct {
+	ID     string
+	UserID string
+	// Name describes what the token is used by.
+	Name string
+	// BudgetID is the Budget the token may be used for. It is empty for tokens created before tokens were scoped, which
+	// may be used for every Budget of their User.
+	BudgetID string
+	// Scopes are the capabilities the token is granted, limiting the operations of the API it may be used for.
+	Scopes           []Scope
+	CreatedTimestamp time.Time
+	// ExpiresTimestamp is zero if the token does not expire.
+	ExpiresTimestamp time.Time
+	// LastUsedTimestamp is zero if the token has not been used.
+	LastUsedTimestamp time.Time
+}
+
+// Scope is a capability an APIToken may be granted, such as reading Accounts or triggering their sync.
+type Scope string
+
+const (
+	ScopeAccountsRead      Scope = "accounts:read"
+	ScopeAccountsWrite     Scope = "accounts:write"
+	ScopeAccountsSync      Scope = "accounts:sync"
+	ScopePayeesRead        Scope = "payees:read"
+	ScopePayeesWrite       Scope = "payees:write"
+	ScopeTransactionsRead  Scope = "transactions:read"
+	ScopeTransactionsWrite Scope = "transactions:write"
+	// ScopeCategoriesRead and ScopeCategoriesWrite cover Category groups and Categories, and the amounts assigned to
+	// them each month.
+	ScopeCategoriesRead  Scope = "categories:read"
+	ScopeCategoriesWrite Scope = "categories:write"
+	// ScopeBudgetsRead covers listing Budgets and their members, and ScopeBudgetsManage creating them, managing their
+	// members and invitations, and accepting invitations.
+	ScopeBudgetsRead   Scope = "budgets:read"
+	ScopeBudgetsManage Scope = "budgets:manage"
+	// ScopeTokensManage covers listing, creating and revoking the API tokens of the User.
+	ScopeTokensManage Scope = "tokens:manage"
+)
+
+// Scopes are every Scope, in the order they are listed.
+var Scopes = []Scope{
+	ScopeAccountsRead,
+	ScopeAccountsWrite,
+	ScopeAccountsSync,
+	ScopePayeesRead,
+	ScopePayeesWrite,
+	ScopeTransactionsRead,
+	ScopeTransactionsWrite,
+	ScopeCategoriesRead,
+	ScopeCategoriesWrite,
+	ScopeBudgetsRead,
+	ScopeBudgetsManage,
+	ScopeTokensManage,
+}
+
+// IsValid returns whether the Scope is one an APIToken may be granted.
+func (s Scope) IsValid() bool {
+	return slices.Contains(Scopes, s)
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+)
+
+type accountsPage struct {
+	page
+	Accounts     []*budgit.Account
+	Total        budgit.Balance
+	Integrations []svc.IntegrationInfo
+	// Form holds the values of the form creating an Account, kept when creating it failed.
+	Form struct{ Name, OpeningBalance string }
+}
+
+func (h *Handler) accountsPage(r *http.Request) (*accountsPage, error) {
+	data := &accountsPage{page: newPage(r, "Accounts", "accounts")}
+	accounts, err := h.service.ListAccounts(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	data.Accounts = accounts
+	for _, account := range accounts {
+		data.Total = data.Total.Add(account.Balance)
+	}
+	data.Integrations = h.service.ListIntegrations()
+	return data, nil
+}
+
+func (h *Handler) accounts(w http.ResponseWriter, r *http.Request) {
+	data, err := h.accountsPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "accounts", data)
+}
+
+func (h *Handler) createAccount(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	err := func() error {
+		if name == "" {
+			return formError{errors.New("name is required")}
+		}
+		account := &budgit.Account{ID: uuid.New().String(), Name: name}
+		if strings.TrimSpace(r.PostFormValue("opening_balance")) != "" {
+			amount, err := parseAmount(r, "opening_balance")
+			if err != nil {
+				return err
+			}
+			account.Balance = budgit.Balance{ClearedBalance: amount, EffectiveBalance: amount}
+		}
+		_, err := h.service.CreateAccounts(r.Context(), account)
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "accounts", func() (pageData, error) {
+			data, loadErr := h.accountsPage(r)
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			data.Form.Name, data.Form.OpeningBalance = name, r.PostFormValue("opening_balance")
+			return data, nil
+		})
+		return
+	}
+	redirect(w, r, "/accounts", fmt.Sprintf("Created Account %s", name))
+}
+
+func (h *Handler) syncAccount(w http.ResponseWriter, r *http.Request) {
+	accountID := r.PathValue("accountID")
+	if err := h.service.SyncAccount(r.Context(), accountID); err != nil {
+		h.failForm(w, r, err, "accounts", func() (pageData, error) { return h.accountsPage(r) })
+		return
+	}
+	redirect(w, r, "/accounts", "Synced Account")
+}
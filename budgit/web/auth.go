@@ -0,0 +1,127 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit/svc"
+)
+
+// sessionCookie is the cookie holding the token of the session of the User logged in.
+const sessionCookie = "budgit_session"
+
+// authenticate runs a request under the Principal of its session cookie, on the Budget of its budget cookie,
+// redirecting to the login page if it has no current session. API tokens are refused in the cookie, as their scopes
+// and Budget are not enforced by the pages, which act with every permission of the User.
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (*http.Request, bool) {
+	cookie, err := r.Cookie(sessionCookie)
+	if err != nil {
+		h.redirectToLogin(w, r)
+		return nil, false
+	}
+	principal, err := h.service.Authenticate(r.Context(), cookie.Value)
+	if errors.Is(err, svc.ErrUnauthenticated) {
+		h.redirectToLogin(w, r)
+		return nil, false
+	}
+	if err != nil {
+		h.fail(w, r, err)
+		return nil, false
+	}
+	if principal.APITokenID != "" {
+		h.redirectToLogin(w, r)
+		return nil, false
+	}
+	return r.WithContext(svc.WithBudget(svc.WithPrincipal(r.Context(), principal), requestBudget(r))), true
+}
+
+// redirectToLogin redirects to the login page, which returns to the page requested once logged in. Forms submitted
+// without a session return to the page they were submitted from.
+func (h *Handler) redirectToLogin(w http.ResponseWriter, r *http.Request) {
+	next := r.URL.RequestURI()
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		next = "/"
+		if referer, err := url.Parse(r.Referer()); err == nil && referer.Host == r.Host {
+			next = referer.RequestURI()
+		}
+	}
+	http.Redirect(w, r, "/login?"+url.Values{"next": {next}}.Encode(), http.StatusSeeOther)
+}
+
+// isPublic returns whether a request needs no session, as the login page and static files do.
+func isPublic(r *http.Request) bool {
+	return r.URL.Path == "/login" || strings.HasPrefix(r.URL.Path, "/static/")
+}
+
+type loginPage struct {
+	page
+	Username string
+	Next     string
+}
+
+func (h *Handler) loginPage(w http.ResponseWriter, r *http.Request) {
+	h.render(w, r, http.StatusOK, "login", &loginPage{
+		page: newPage(r, "Log in", ""),
+		Next: r.URL.Query().Get("next"),
+	})
+}
+
+// login starts a session of a User given their password and any TOTP code, see svc.Service.Login, setting the session
+// cookie.
+func (h *Handler) login(w http.ResponseWriter, r *http.Request) {
+	username, next := r.PostFormValue("username"), r.PostFormValue("next")
+	session, err := h.service.Login(r.Context(), username, r.PostFormValue("password"), strings.TrimSpace(r.PostFormValue("totp_code")))
+	if err != nil {
+		status, message := http.StatusUnauthorized, "The username, password or TOTP code is incorrect."
+		switch {
+		case errors.Is(err, svc.ErrTOTPRequired):
+			message = "A TOTP code from your authenticator app is required."
+		case !errors.Is(err, svc.ErrInvalidCredentials):
+			status, message = h.describeError(r, err)
+		}
+		data := &loginPage{page: newPage(r, "Log in", ""), Username: username, Next: next}
+		data.setError(message)
+		h.render(w, r, status, "login", data)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    session.Token,
+		Path:     "/",
+		Expires:  session.ExpiresTimestamp,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, localPath(next), http.StatusSeeOther)
+}
+
+// logout ends the session of the session cookie and removes it.
+func (h *Handler) logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookie); err == nil {
+		if err := h.service.Logout(r.Context(), cookie.Value); err != nil {
+			h.fail(w, r, err)
+			return
+		}
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	redirect(w, r, "/login", "Logged out")
+}
+
+// localPath returns a path to redirect to after logging in, if it is a path of the UI, so that the login page cannot
+// redirect to another site.
+func localPath(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
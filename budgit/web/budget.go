@@ -0,0 +1,131 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+)
+
+// monthLayout is the layout of months in the URL of the budget, e.g. "2024-06".
+const monthLayout = "2006-01"
+
+type budgetPage struct {
+	page
+	Month, Previous, Next string
+	MonthName             string
+	Groups                []budgetGroup
+	Total                 budgetRow
+}
+
+type budgetGroup struct {
+	Name string
+	Rows []budgetRow
+}
+
+// budgetRow is the amount assigned to a Category in a month, the activity of its Transactions within the month and the
+// amount available to it, that left of the amount assigned after its activity.
+type budgetRow struct {
+	Category                      *budgit.Category
+	Assigned, Activity, Available budgit.BalanceAmount
+}
+
+func (h *Handler) budgetPage(r *http.Request, month time.Time) (*budgetPage, error) {
+	groups, err := h.service.ListCategoryGroups(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	categories, err := h.service.ListCategories(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := h.service.ListAssignments(r.Context(), month)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := h.service.ListAccounts(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	var transactions []*budgit.Transaction
+	for _, account := range accounts {
+		accountTransactions, err := h.service.ListTransactions(r.Context(), account.ID)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, accountTransactions...)
+	}
+
+	assigned := map[string]budgit.BalanceAmount{}
+	for _, assignment := range assignments {
+		assigned[assignment.CategoryID] += assignment.Amount
+	}
+	activity := budgit.CategoryActivity(month, transactions...)
+
+	data := &budgetPage{
+		page:      newPage(r, "Budget", "budget"),
+		Month:     month.Format(monthLayout),
+		Previous:  month.AddDate(0, -1, 0).Format(monthLayout),
+		Next:      month.AddDate(0, 1, 0).Format(monthLayout),
+		MonthName: month.Format("January 2006"),
+	}
+	for _, group := range groupCategories(groups, categories) {
+		budgetGroup := budgetGroup{Name: group.Name}
+		for _, category := range group.Categories {
+			row := budgetRow{Category: category, Assigned: assigned[category.ID], Activity: activity[category.ID]}
+			row.Available = row.Assigned + row.Activity
+			budgetGroup.Rows = append(budgetGroup.Rows, row)
+			data.Total.Assigned += row.Assigned
+			data.Total.Activity += row.Activity
+			data.Total.Available += row.Available
+		}
+		data.Groups = append(data.Groups, budgetGroup)
+	}
+	return data, nil
+}
+
+// parseMonth parses the month of the budget shown, the current month if none is given.
+func (h *Handler) parseMonth(value string) (time.Time, error) {
+	if value == "" {
+		now := h.now()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	month, err := time.Parse(monthLayout, value)
+	if err != nil {
+		return time.Time{}, formError{fmt.Errorf("month %q is not written YYYY-MM", value)}
+	}
+	return month, nil
+}
+
+func (h *Handler) budget(w http.ResponseWriter, r *http.Request) {
+	month, err := h.parseMonth(r.URL.Query().Get("month"))
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	data, err := h.budgetPage(r, month)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "budget", data)
+}
+
+func (h *Handler) assign(w http.ResponseWriter, r *http.Request) {
+	month, err := h.parseMonth(r.PostFormValue("month"))
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	amount, err := parseAmount(r, "amount")
+	if err == nil {
+		assignment := &budgit.Assignment{CategoryID: r.PostFormValue("category_id"), Month: month, Amount: amount}
+		_, err = h.service.Assign(r.Context(), assignment)
+	}
+	if err != nil {
+		h.failForm(w, r, err, "budget", func() (pageData, error) { return h.budgetPage(r, month) })
+		return
+	}
+	redirect(w, r, "/budget?month="+month.Format(monthLayout), fmt.Sprintf("Assigned %s", amount))
+}
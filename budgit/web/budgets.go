@@ -0,0 +1,124 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+)
+
+// budgetCookie is the cookie holding the ID of the Budget pages are shown for, see svc.WithBudget.
+const budgetCookie = "budgit_budget"
+
+// requestBudget returns the ID of the Budget of the budget cookie of a request, or the default Budget without one.
+func requestBudget(r *http.Request) string {
+	if cookie, err := r.Cookie(budgetCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	return svc.DefaultBudgetID
+}
+
+type budgetsPage struct {
+	page
+	Budgets []*budgit.Budget
+	// Current is the ID of the Budget pages are shown for.
+	Current string
+	// Name is the name of the Budget being created, kept when creating it failed.
+	Name string
+}
+
+func (h *Handler) budgetsPage(r *http.Request) (*budgetsPage, error) {
+	budgets, err := h.service.ListBudgets(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	return &budgetsPage{page: newPage(r, "Budgets", "budgets"), Budgets: budgets, Current: requestBudget(r)}, nil
+}
+
+func (h *Handler) budgets(w http.ResponseWriter, r *http.Request) {
+	data, err := h.budgetsPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "budgets", data)
+}
+
+// createBudget creates a Budget, see svc.Service.CreateBudget, and switches to it.
+func (h *Handler) createBudget(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	budget, err := func() (*budgit.Budget, error) {
+		if name == "" {
+			return nil, formError{errors.New("name is required")}
+		}
+		return h.service.CreateBudget(r.Context(), name)
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "budgets", func() (pageData, error) {
+			data, loadErr := h.budgetsPage(r)
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			data.Name = name
+			return data, nil
+		})
+		return
+	}
+	setBudgetCookie(w, r, budget.ID)
+	redirect(w, r, "/accounts", fmt.Sprintf("Created Budget %s", budget.Name))
+}
+
+// selectBudget switches the Budget pages are shown for to one the User is a member of.
+func (h *Handler) selectBudget(w http.ResponseWriter, r *http.Request) {
+	budgetID := r.PostFormValue("budget_id")
+	budget, err := func() (*budgit.Budget, error) {
+		budgets, err := h.service.ListBudgets(r.Context())
+		if err != nil {
+			return nil, err
+		}
+		for _, budget := range budgets {
+			if budget.ID == budgetID {
+				return budget, nil
+			}
+		}
+		return nil, fmt.Errorf("selecting budget %q: %w", budgetID, svc.ErrBudgetNotFound)
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "budgets", func() (pageData, error) { return h.budgetsPage(r) })
+		return
+	}
+	setBudgetCookie(w, r, budget.ID)
+	redirect(w, r, "/accounts", fmt.Sprintf("Switched to Budget %s", budget.Name))
+}
+
+// acceptInvitation makes the User a member of the Budget of an invitation token, see svc.Service.AcceptInvitation,
+// and switches to it.
+func (h *Handler) acceptInvitation(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSpace(r.PostFormValue("token"))
+	budget, err := func() (*budgit.Budget, error) {
+		if token == "" {
+			return nil, formError{errors.New("token is required")}
+		}
+		return h.service.AcceptInvitation(r.Context(), token)
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "budgets", func() (pageData, error) { return h.budgetsPage(r) })
+		return
+	}
+	setBudgetCookie(w, r, budget.ID)
+	redirect(w, r, "/accounts", fmt.Sprintf("Joined Budget %s as %s", budget.Name, budget.Role))
+}
+
+func setBudgetCookie(w http.ResponseWriter, r *http.Request, budgetID string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     budgetCookie,
+		Value:    budgetID,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
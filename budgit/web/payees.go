@@ -0,0 +1,83 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/google/uuid"
+)
+
+type payeesPage struct {
+	page
+	Payees []*budgit.Payee
+	// Name is the name of the Payee being created, kept when creating it failed.
+	Name string
+}
+
+func (h *Handler) payeesPage(r *http.Request) (*payeesPage, error) {
+	payees, err := h.service.ListPayees(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(payees, func(a, b *budgit.Payee) int { return strings.Compare(a.Name, b.Name) })
+	return &payeesPage{page: newPage(r, "Payees", "payees"), Payees: payees}, nil
+}
+
+func (h *Handler) payees(w http.ResponseWriter, r *http.Request) {
+	data, err := h.payeesPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "payees", data)
+}
+
+func (h *Handler) createPayee(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	err := func() error {
+		if name == "" {
+			return formError{errors.New("name is required")}
+		}
+		_, err := h.service.CreatePayees(r.Context(), &budgit.Payee{ID: uuid.New().String(), Name: name})
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "payees", func() (pageData, error) {
+			data, loadErr := h.payeesPage(r)
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			data.Name = name
+			return data, nil
+		})
+		return
+	}
+	redirect(w, r, "/payees", fmt.Sprintf("Created Payee %s", name))
+}
+
+// mergePayees merges the Payees chosen into another, see svc.Service.MergePayees.
+func (h *Handler) mergePayees(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		h.fail(w, r, formError{err})
+		return
+	}
+	payeeID, mergedPayeeIDs := r.PostForm.Get("payee_id"), r.PostForm["merged_payee_id"]
+	var moved int
+	err := func() error {
+		if payeeID == "" || len(mergedPayeeIDs) == 0 {
+			return formError{errors.New("choose the Payees to merge and the Payee to merge them into")}
+		}
+		var err error
+		moved, err = h.service.MergePayees(r.Context(), payeeID, mergedPayeeIDs...)
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "payees", func() (pageData, error) { return h.payeesPage(r) })
+		return
+	}
+	redirect(w, r, "/payees", fmt.Sprintf("Merged Payees, moving %d Transactions", moved))
+}
@@ -0,0 +1,438 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// transferPrefix prefixes the name of an Account given as the payee of a Transaction, making it a transfer.
+const transferPrefix = "Transfer: "
+
+// names are the Accounts, Payees and Categories of the budget, naming those a Transaction refers to.
+type names struct {
+	Accounts []*budgit.Account
+	Payees   []*budgit.Payee
+	// CategoryGroups are the CategoryGroups with their Categories, sorted by name, offered when choosing a Category.
+	CategoryGroups []categoryGroup
+	categories     map[string]string
+}
+
+type categoryGroup struct {
+	Name       string
+	Categories []*budgit.Category
+}
+
+func (h *Handler) loadNames(r *http.Request) (*names, error) {
+	n := &names{categories: map[string]string{}}
+	var err error
+	if n.Accounts, err = h.service.ListAccounts(r.Context()); err != nil {
+		return nil, err
+	}
+	if n.Payees, err = h.service.ListPayees(r.Context()); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(n.Payees, func(a, b *budgit.Payee) int { return strings.Compare(a.Name, b.Name) })
+	groups, err := h.service.ListCategoryGroups(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	categories, err := h.service.ListCategories(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	n.CategoryGroups = groupCategories(groups, categories)
+	for _, group := range n.CategoryGroups {
+		for _, category := range group.Categories {
+			n.categories[category.ID] = budgit.CategoryPath(group.Name, category.Name)
+		}
+	}
+	return n, nil
+}
+
+// groupCategories returns the Categories of each CategoryGroup, both sorted by name.
+func groupCategories(groups []*budgit.CategoryGroup, categories []*budgit.Category) []categoryGroup {
+	byGroupID := map[string][]*budgit.Category{}
+	for _, category := range categories {
+		byGroupID[category.GroupID] = append(byGroupID[category.GroupID], category)
+	}
+	grouped := make([]categoryGroup, 0, len(groups))
+	for _, group := range groups {
+		groupCategories := byGroupID[group.ID]
+		slices.SortFunc(groupCategories, func(a, b *budgit.Category) int { return strings.Compare(a.Name, b.Name) })
+		grouped = append(grouped, categoryGroup{Name: group.Name, Categories: groupCategories})
+	}
+	slices.SortFunc(grouped, func(a, b categoryGroup) int { return strings.Compare(a.Name, b.Name) })
+	return grouped
+}
+
+func (n *names) account(accountID string) *budgit.Account {
+	for _, account := range n.Accounts {
+		if account.ID == accountID {
+			return account
+		}
+	}
+	return nil
+}
+
+// PayeeName returns the name of a Transaction's Payee, or of the Account transferred to or from.
+func (n *names) PayeeName(transaction *budgit.Transaction) string {
+	if transaction.IsPayeeInternal {
+		if account := n.account(transaction.PayeeID); account != nil {
+			return transferPrefix + account.Name
+		}
+		return transferPrefix + transaction.PayeeID
+	}
+	for _, payee := range n.Payees {
+		if payee.ID == transaction.PayeeID {
+			return payee.Name
+		}
+	}
+	return transaction.PayeeID
+}
+
+// CategoryPath returns the category path of a Category, see budgit.CategoryPath.
+func (n *names) CategoryPath(categoryID string) string {
+	if path, ok := n.categories[categoryID]; ok {
+		return path
+	}
+	return categoryID
+}
+
+// transactionForm holds the values of the form adding or editing a Transaction.
+type transactionForm struct {
+	Date, Payee, CategoryID, Amount, Memo string
+	Cleared                               bool
+}
+
+func newTransactionForm(r *http.Request) transactionForm {
+	return transactionForm{
+		Date:       r.PostFormValue("date"),
+		Payee:      r.PostFormValue("payee"),
+		CategoryID: r.PostFormValue("category_id"),
+		Amount:     r.PostFormValue("amount"),
+		Memo:       r.PostFormValue("memo"),
+		Cleared:    r.PostFormValue("cleared") != "",
+	}
+}
+
+// apply sets the values of the form on a Transaction, returning the Payee to create first if the payee is not an
+// existing one. The payee is given by name, or as "Transfer: " and the name of an Account for transfers.
+func (f transactionForm) apply(n *names, transaction *budgit.Transaction) (*budgit.Payee, error) {
+	date, err := time.Parse(time.DateOnly, strings.TrimSpace(f.Date))
+	if err != nil {
+		return nil, formError{fmt.Errorf("date %q is not written YYYY-MM-DD", f.Date)}
+	}
+	if f.CategoryID != "" {
+		if _, ok := n.categories[f.CategoryID]; !ok {
+			return nil, formError{errors.New("the Category chosen does not exist")}
+		}
+	}
+	amount, err := budgit.ParseBalanceAmount(f.Amount)
+	if err != nil {
+		return nil, formError{err}
+	}
+	transaction.EffectiveDate, transaction.Amount, transaction.CategoryID = date, amount, f.CategoryID
+	transaction.Memo, transaction.Cleared = strings.TrimSpace(f.Memo), f.Cleared
+
+	payee := strings.TrimSpace(f.Payee)
+	switch {
+	case payee == "":
+		return nil, formError{errors.New("a payee is required")}
+	case strings.HasPrefix(payee, transferPrefix):
+		accountName := strings.TrimSpace(strings.TrimPrefix(payee, transferPrefix))
+		i := slices.IndexFunc(n.Accounts, func(account *budgit.Account) bool {
+			return strings.EqualFold(account.Name, accountName)
+		})
+		if i < 0 {
+			return nil, formError{fmt.Errorf("no Account is named %q", accountName)}
+		}
+		if n.Accounts[i].ID == transaction.AccountID {
+			return nil, formError{errors.New("a transfer must be to another Account")}
+		}
+		transaction.PayeeID, transaction.IsPayeeInternal = n.Accounts[i].ID, true
+		return nil, nil
+	}
+	transaction.IsPayeeInternal = false
+	for _, existing := range n.Payees {
+		if strings.EqualFold(existing.Name, payee) {
+			transaction.PayeeID = existing.ID
+			return nil, nil
+		}
+	}
+	newPayee := &budgit.Payee{ID: uuid.New().String(), Name: payee}
+	transaction.PayeeID = newPayee.ID
+	return newPayee, nil
+}
+
+// createNewPayee creates the new Payee of a Transaction, if one is to be created.
+func (h *Handler) createNewPayee(r *http.Request, newPayee *budgit.Payee) error {
+	if newPayee == nil {
+		return nil
+	}
+	_, err := h.service.CreatePayees(r.Context(), newPayee)
+	return err
+}
+
+type registerPage struct {
+	page
+	*names
+	Account *budgit.Account
+	Rows    []registerRow
+	Form    transactionForm
+}
+
+// registerRow is a Transaction of the register with the effective balance of its Account after it.
+type registerRow struct {
+	Transaction *budgit.Transaction
+	Balance     budgit.BalanceAmount
+}
+
+func (h *Handler) registerPage(r *http.Request) (*registerPage, error) {
+	n, err := h.loadNames(r)
+	if err != nil {
+		return nil, err
+	}
+	accountID := r.PathValue("accountID")
+	account := n.account(accountID)
+	if account == nil {
+		return nil, fmt.Errorf("showing register of account %q: %w", accountID, svc.ErrAccountNotFound)
+	}
+	transactions, err := h.service.ListTransactions(r.Context(), account.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &registerPage{
+		page:    newPage(r, account.Name, "accounts"),
+		names:   n,
+		Account: account,
+		Rows:    registerRows(account, transactions),
+		Form:    transactionForm{Date: h.now().Format(time.DateOnly)},
+	}, nil
+}
+
+// registerRows returns the Transactions of an Account, newest first, with running balances. As the Account may have
+// been created with an opening balance, the balances run back from its current effective balance.
+func registerRows(account *budgit.Account, transactions []*budgit.Transaction) []registerRow {
+	transactions = slices.Clone(transactions)
+	slices.SortStableFunc(transactions, func(a, b *budgit.Transaction) int {
+		if c := b.EffectiveDate.Compare(a.EffectiveDate); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	rows := make([]registerRow, 0, len(transactions))
+	balance := account.Balance.EffectiveBalance
+	for _, transaction := range transactions {
+		rows = append(rows, registerRow{Transaction: transaction, Balance: balance})
+		balance -= transaction.Amount
+	}
+	return rows
+}
+
+func (h *Handler) register(w http.ResponseWriter, r *http.Request) {
+	data, err := h.registerPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "register", data)
+}
+
+func (h *Handler) addTransaction(w http.ResponseWriter, r *http.Request) {
+	form := newTransactionForm(r)
+	var transaction *budgit.Transaction
+	err := func() error {
+		n, err := h.loadNames(r)
+		if err != nil {
+			return err
+		}
+		transaction = &budgit.Transaction{ID: uuid.New().String(), AccountID: r.PathValue("accountID")}
+		newPayee, err := form.apply(n, transaction)
+		if err != nil {
+			return err
+		}
+		if err := h.createNewPayee(r, newPayee); err != nil {
+			return err
+		}
+		_, err = h.service.CreateTransactions(r.Context(), transaction)
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "register", func() (pageData, error) {
+			data, loadErr := h.registerPage(r)
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			data.Form = form
+			return data, nil
+		})
+		return
+	}
+	redirect(w, r, "/accounts/"+transaction.AccountID, fmt.Sprintf("Added Transaction of %s", transaction.Amount))
+}
+
+// toggleCleared marks a Transaction cleared, or uncleared if it is cleared, returning to the page it was toggled on.
+func (h *Handler) toggleCleared(w http.ResponseWriter, r *http.Request) {
+	transaction, err := h.service.GetTransaction(r.Context(), r.PathValue("transactionID"))
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	transaction.Cleared = !transaction.Cleared
+	if _, err := h.service.UpdateTransactions(r.Context(), transaction); err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	notice := "Marked Transaction uncleared"
+	if transaction.Cleared {
+		notice = "Marked Transaction cleared"
+	}
+	redirect(w, r, "/accounts/"+transaction.AccountID, notice)
+}
+
+type transactionPage struct {
+	page
+	*names
+	Account     *budgit.Account
+	Transaction *budgit.Transaction
+	Attachments []*budgit.Attachment
+	Form        transactionForm
+}
+
+func (h *Handler) transactionPage(r *http.Request) (*transactionPage, error) {
+	transaction, err := h.service.GetTransaction(r.Context(), r.PathValue("transactionID"))
+	if err != nil {
+		return nil, err
+	}
+	n, err := h.loadNames(r)
+	if err != nil {
+		return nil, err
+	}
+	account := n.account(transaction.AccountID)
+	if account == nil {
+		return nil, fmt.Errorf("showing transaction %q: %w", transaction.ID, svc.ErrAccountNotFound)
+	}
+	attachments, err := h.service.ListAttachments(r.Context(), transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionPage{
+		page:        newPage(r, "Edit Transaction", "accounts"),
+		names:       n,
+		Account:     account,
+		Transaction: transaction,
+		Attachments: attachments,
+		Form: transactionForm{
+			Date:       transaction.EffectiveDate.Format(time.DateOnly),
+			Payee:      n.PayeeName(transaction),
+			CategoryID: transaction.CategoryID,
+			Amount:     transaction.Amount.String(),
+			Memo:       transaction.Memo,
+			Cleared:    transaction.Cleared,
+		},
+	}, nil
+}
+
+func (h *Handler) transaction(w http.ResponseWriter, r *http.Request) {
+	data, err := h.transactionPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	h.render(w, r, http.StatusOK, "transaction", data)
+}
+
+func (h *Handler) updateTransaction(w http.ResponseWriter, r *http.Request) {
+	form := newTransactionForm(r)
+	data, err := h.transactionPage(r)
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	transaction := *data.Transaction
+	err = func() error {
+		newPayee, err := form.apply(data.names, &transaction)
+		if err != nil {
+			return err
+		}
+		if err := h.createNewPayee(r, newPayee); err != nil {
+			return err
+		}
+		_, err = h.service.UpdateTransactions(r.Context(), &transaction)
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "transaction", func() (pageData, error) {
+			data.Form = form
+			return data, nil
+		})
+		return
+	}
+	redirect(w, r, "/accounts/"+transaction.AccountID, "Saved Transaction")
+}
+
+// uploadAttachment attaches the file of an upload form to a Transaction.
+func (h *Handler) uploadAttachment(w http.ResponseWriter, r *http.Request) {
+	transactionID := r.PathValue("transactionID")
+	err := func() error {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			return formError{fmt.Errorf("a file is required: %w", err)}
+		}
+		defer file.Close()
+		contentType := header.Header.Get("Content-Type")
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		_, err = h.service.UploadAttachment(r.Context(), transactionID, header.Filename, contentType, file)
+		return err
+	}()
+	if err != nil {
+		h.failForm(w, r, err, "transaction", func() (pageData, error) {
+			return h.transactionPage(r)
+		})
+		return
+	}
+	redirect(w, r, "/transactions/"+transactionID, "Attached file")
+}
+
+// downloadAttachment serves the content of an Attachment as a download.
+func (h *Handler) downloadAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, content, err := h.service.DownloadAttachment(r.Context(), r.PathValue("attachmentID"))
+	if err != nil {
+		h.fail(w, r, err)
+		return
+	}
+	defer content.Close()
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	if _, err := io.Copy(w, content); err != nil {
+		h.log.Warnw("Writing attachment content", zap.String("attachment_id", attachment.ID), zap.Error(err))
+	}
+}
+
+// PayeeSuggestions returns the names offered as the payee of a Transaction: those of Payees, then transfers to each
+// Account.
+func (n *names) PayeeSuggestions() []string {
+	suggestions := make([]string, 0, len(n.Payees)+len(n.Accounts))
+	for _, payee := range n.Payees {
+		suggestions = append(suggestions, payee.Name)
+	}
+	for _, account := range n.Accounts {
+		suggestions = append(suggestions, transferPrefix+account.Name)
+	}
+	return suggestions
+}
@@ -0,0 +1,57 @@
+// Progressive enhancement of the pages of Budg-it, which work as plain HTML forms without it.
+//
+// Forms marked data-enhance are submitted in the background, and the <main> of the page returned, after following
+// its redirect, replaces the current one, keeping the scroll position. Inputs marked data-autosubmit submit their form
+// when changed.
+(function () {
+  "use strict";
+
+  document.documentElement.classList.add("js");
+
+  async function submit(form) {
+    const main = document.querySelector("main");
+    main.setAttribute("aria-busy", "true");
+    let response;
+    try {
+      response = await fetch(form.action, {
+        method: "POST",
+        body: new URLSearchParams(new FormData(form)),
+        credentials: "same-origin",
+      });
+    } catch (error) {
+      // The form was not submitted, so submit it normally instead.
+      main.removeAttribute("aria-busy");
+      form.submit();
+      return;
+    }
+    const page = new DOMParser().parseFromString(await response.text(), "text/html");
+    const newMain = page.querySelector("main");
+    if (!newMain) {
+      location.assign(response.url);
+      return;
+    }
+    main.replaceWith(newMain);
+    document.title = page.title;
+    if (response.redirected) {
+      history.pushState(null, "", response.url);
+    }
+  }
+
+  document.addEventListener("submit", (event) => {
+    const form = event.target;
+    if (!form.matches("form[data-enhance]") || form.method.toLowerCase() !== "post") {
+      return;
+    }
+    event.preventDefault();
+    submit(form);
+  });
+
+  document.addEventListener("change", (event) => {
+    const input = event.target;
+    if (input.matches("[data-autosubmit]") && input.form) {
+      input.form.requestSubmit();
+    }
+  });
+
+  window.addEventListener("popstate", () => location.reload());
+})();
@@ -0,0 +1,320 @@
+// Package web serves a server-rendered web UI over the budgit service, covering Accounts and their sync status, the
+// register of each Account, Payees and the budget of each month.
+//
+// Pages other than the login page require the session cookie set by logging in, and run under the Principal of the
+// session. They are shown for the Budget chosen on the budgets page, held in a cookie, or the default Budget until one
+// is chosen.
+//
+// Pages are rendered from html/template templates and work as plain HTML forms, each change posting a form and
+// redirecting back to the page. Where JavaScript is available, static/app.js enhances forms to submit in the
+// background and swap in the page returned, so the page is not reloaded. Templates and static files are embedded in
+// the binary.
+package web
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"go.uber.org/zap"
+)
+
+// Service is the budgit service the UI is built on, implemented by svc.Service.
+type Service interface {
+	CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error)
+	ListAccounts(ctx context.Context) ([]*budgit.Account, error)
+	SyncAccount(ctx context.Context, accountID string) error
+	ListIntegrations() []svc.IntegrationInfo
+	CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error)
+	ListPayees(ctx context.Context) ([]*budgit.Payee, error)
+	MergePayees(ctx context.Context, payeeID string, mergedPayeeIDs ...string) (int, error)
+	CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error)
+	GetTransaction(ctx context.Context, transactionID string) (*budgit.Transaction, error)
+	UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error)
+	ListAttachments(ctx context.Context, transactionID string) ([]*budgit.Attachment, error)
+	UploadAttachment(ctx context.Context, transactionID, name, contentType string, content io.Reader) (*budgit.Attachment, error)
+	DownloadAttachment(ctx context.Context, attachmentID string) (*budgit.Attachment, io.ReadCloser, error)
+	ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error)
+	ListCategories(ctx context.Context) ([]*budgit.Category, error)
+	ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error)
+	Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error)
+	Login(ctx context.Context, username, password, totpCode string) (*svc.Session, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (svc.Principal, error)
+	CreateBudget(ctx context.Context, name string) (*budgit.Budget, error)
+	ListBudgets(ctx context.Context) ([]*budgit.Budget, error)
+	AcceptInvitation(ctx context.Context, token string) (*budgit.Budget, error)
+}
+
+//go:embed templates static
+var content embed.FS
+
+// pages are the templates of pages, each rendered within templates/layout.html.
+var pages = []string{"login", "accounts", "register", "transaction", "payees", "budget", "budgets", "error"}
+
+// Handler is an http.Handler serving the web UI.
+type Handler struct {
+	log       *zap.SugaredLogger
+	service   Service
+	now       func() time.Time
+	templates map[string]*template.Template
+	mux       *http.ServeMux
+}
+
+// New returns a Handler serving the web UI. now gives the current time, dating new Transactions and choosing the month
+// first budgeted.
+func New(log *zap.SugaredLogger, service Service, now func() time.Time) (*Handler, error) {
+	h := &Handler{
+		log:       log,
+		service:   service,
+		now:       now,
+		templates: make(map[string]*template.Template, len(pages)),
+		mux:       http.NewServeMux(),
+	}
+	for _, page := range pages {
+		tmpl, err := template.New("").Funcs(templateFuncs).ParseFS(content, "templates/layout.html", "templates/"+page+".html")
+		if err != nil {
+			return nil, fmt.Errorf("parsing template of page %q: %w", page, err)
+		}
+		h.templates[page] = tmpl
+	}
+	static, err := fs.Sub(content, "static")
+	if err != nil {
+		return nil, fmt.Errorf("loading static files: %w", err)
+	}
+
+	h.mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	h.mux.HandleFunc("GET /login", h.loginPage)
+	h.mux.HandleFunc("POST /login", h.login)
+	h.mux.HandleFunc("POST /logout", h.logout)
+	h.mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/accounts", http.StatusSeeOther)
+	})
+	h.mux.HandleFunc("GET /accounts", h.accounts)
+	h.mux.HandleFunc("POST /accounts", h.createAccount)
+	h.mux.HandleFunc("POST /accounts/{accountID}/sync", h.syncAccount)
+	h.mux.HandleFunc("GET /accounts/{accountID}", h.register)
+	h.mux.HandleFunc("POST /accounts/{accountID}/transactions", h.addTransaction)
+	h.mux.HandleFunc("GET /transactions/{transactionID}", h.transaction)
+	h.mux.HandleFunc("POST /transactions/{transactionID}", h.updateTransaction)
+	h.mux.HandleFunc("POST /transactions/{transactionID}/cleared", h.toggleCleared)
+	h.mux.HandleFunc("POST /transactions/{transactionID}/attachments", h.uploadAttachment)
+	h.mux.HandleFunc("GET /attachments/{attachmentID}", h.downloadAttachment)
+	h.mux.HandleFunc("GET /payees", h.payees)
+	h.mux.HandleFunc("POST /payees", h.createPayee)
+	h.mux.HandleFunc("POST /payees/merge", h.mergePayees)
+	h.mux.HandleFunc("GET /budget", h.budget)
+	h.mux.HandleFunc("POST /budget", h.assign)
+	h.mux.HandleFunc("GET /budgets", h.budgets)
+	h.mux.HandleFunc("POST /budgets", h.createBudget)
+	h.mux.HandleFunc("POST /budgets/select", h.selectBudget)
+	h.mux.HandleFunc("POST /budgets/accept", h.acceptInvitation)
+	h.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		h.renderError(w, r, http.StatusNotFound, "The page does not exist.")
+	})
+	return h, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && !isSameOrigin(r) {
+		h.renderError(w, r, http.StatusForbidden, "Forms may only be submitted from Budg-it itself.")
+		return
+	}
+	if !isPublic(r) {
+		var ok bool
+		if r, ok = h.authenticate(w, r); !ok {
+			return
+		}
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// isSameOrigin returns whether a request was made by a page of the UI, rather than submitted by another site, using
+// the Sec-Fetch-Site header sent by browsers, or the Origin header sent by older ones.
+func isSameOrigin(r *http.Request) bool {
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" {
+		return site == "same-origin" || site == "none"
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+// page is the data every page is rendered with.
+type page struct {
+	Title string
+	// User is the username of the User logged in, empty on the login page.
+	User string
+	// Nav is the section of the navigation the page is in.
+	Nav string
+	// Notice describes the change made by the form submitted before being redirected to the page, if any.
+	Notice string
+	// Error describes why the form submitted failed, if it did.
+	Error string
+}
+
+func (p *page) setError(message string) {
+	p.Error = message
+}
+
+// pageData is the data of a page, embedding page.
+type pageData interface {
+	setError(message string)
+}
+
+// newPage returns the page data of a request, with any notice it was redirected with.
+func newPage(r *http.Request, title, nav string) page {
+	principal, _ := svc.PrincipalFrom(r.Context())
+	return page{Title: title, User: principal.Username, Nav: nav, Notice: r.URL.Query().Get("notice")}
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	},
+	"datetime": func(t time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return t.Format("2006-01-02 15:04")
+	},
+	"negative": func(amount budgit.BalanceAmount) bool {
+		return amount < 0
+	},
+}
+
+// render renders a page, which is rendered fully before being written so that failing to render it is an error page.
+func (h *Handler) render(w http.ResponseWriter, r *http.Request, status int, name string, data any) {
+	buf := &bytes.Buffer{}
+	if err := h.templates[name].ExecuteTemplate(buf, "layout", data); err != nil {
+		h.log.Errorw("Rendering page", zap.String("page", name), zap.String("path", r.URL.Path), zap.Error(err))
+		http.Error(w, "An unexpected error occurred.", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func (h *Handler) renderError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	principal, _ := svc.PrincipalFrom(r.Context())
+	data := struct {
+		page
+		Status int
+	}{
+		page:   page{Title: http.StatusText(status), User: principal.Username, Error: message},
+		Status: status,
+	}
+	h.render(w, r, status, "error", data)
+}
+
+// fail renders the error page for an error which prevented a page being shown.
+func (h *Handler) fail(w http.ResponseWriter, r *http.Request, err error) {
+	status, message := h.describeError(r, err)
+	h.renderError(w, r, status, message)
+}
+
+// failForm renders a page again for a form which failed to be submitted, describing why, with the data load returns.
+func (h *Handler) failForm(w http.ResponseWriter, r *http.Request, err error, name string, load func() (pageData, error)) {
+	status, message := h.describeError(r, err)
+	data, loadErr := load()
+	if loadErr != nil {
+		h.fail(w, r, loadErr)
+		return
+	}
+	data.setError(message)
+	h.render(w, r, status, name, data)
+}
+
+// describeError returns the status and message a failed request is responded with. The details of unexpected errors
+// are logged rather than shown, as they may reveal internals.
+func (h *Handler) describeError(r *http.Request, err error) (int, string) {
+	var (
+		invalid               formError
+		missingAccounts       svc.MissingAccountsError
+		missingPayees         svc.MissingPayeesError
+		missingCategories     svc.MissingCategoriesError
+		duplicatePayees       svc.DuplicatePayeesError
+		accountSync           svc.AccountSyncError
+		unsupportedCapability svc.UnsupportedCapabilityError
+	)
+	switch {
+	case errors.As(err, &invalid):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, svc.ErrForbidden):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, svc.ErrAccountNotFound),
+		errors.Is(err, svc.ErrTransactionNotFound),
+		errors.Is(err, svc.ErrAttachmentNotFound),
+		errors.Is(err, svc.ErrIntegrationNotFound),
+		errors.Is(err, svc.ErrBudgetNotFound),
+		errors.Is(err, svc.ErrInvitationNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.As(err, &missingAccounts),
+		errors.As(err, &missingPayees),
+		errors.As(err, &missingCategories),
+		errors.As(err, &unsupportedCapability),
+		errors.Is(err, svc.ErrInvalidBudgetName):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.As(err, &duplicatePayees),
+		errors.As(err, &accountSync),
+		errors.Is(err, svc.ErrAccountNotLinked):
+		return http.StatusConflict, err.Error()
+	default:
+		h.log.Errorw("Serving page", zap.String("method", r.Method), zap.String("path", r.URL.Path), zap.Error(err))
+		return http.StatusInternalServerError, "An unexpected error occurred."
+	}
+}
+
+// formError is an error in the values of a form submitted.
+type formError struct {
+	err error
+}
+
+func (e formError) Error() string {
+	return e.err.Error()
+}
+
+func (e formError) Unwrap() error {
+	return e.err
+}
+
+// redirect redirects to a page after a form is submitted, with a notice describing the change made.
+func redirect(w http.ResponseWriter, r *http.Request, path, notice string) {
+	if notice != "" {
+		separator := "?"
+		if strings.Contains(path, "?") {
+			separator = "&"
+		}
+		path += separator + url.Values{"notice": {notice}}.Encode()
+	}
+	http.Redirect(w, r, path, http.StatusSeeOther)
+}
+
+// parseAmount parses the decimal amount of a form field, such as "-12.30".
+func parseAmount(r *http.Request, field string) (budgit.BalanceAmount, error) {
+	value := strings.TrimSpace(r.PostFormValue(field))
+	if value == "" {
+		return 0, formError{fmt.Errorf("%s is required", field)}
+	}
+	amount, err := budgit.ParseBalanceAmount(value)
+	if err != nil {
+		return 0, formError{err}
+	}
+	return amount, nil
+}
@@ -0,0 +1,806 @@
+package web_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/budgit"
+	"github.com/andrewthowell/budgit/budgit/svc"
+	"github.com/andrewthowell/budgit/budgit/web"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+func TestWeb(t *testing.T) {
+	suite.Run(t, new(webSuite))
+}
+
+type webSuite struct {
+	suite.Suite
+
+	service *fakeService
+	handler *web.Handler
+	// session is the session cookie get and post send, if set.
+	session string
+	// budget is the budget cookie get and post send, if set.
+	budget string
+}
+
+// testSession is the session token fakeService authenticates, as the User alice.
+const testSession = "budgit_s_test"
+
+// testAPIToken is an API token of alice, which fakeService also authenticates.
+const testAPIToken = "budgit_t_test"
+
+func june(day int) time.Time {
+	return time.Date(2024, 6, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *webSuite) SetupTest() {
+	s.service = &fakeService{
+		accounts: []*budgit.Account{
+			{ID: "account-1", Name: "Current", Balance: budgit.Balance{ClearedBalance: 185000, EffectiveBalance: 184230}},
+			{
+				ID:      "account-2",
+				Name:    "Savings",
+				Balance: budgit.Balance{ClearedBalance: 10000, EffectiveBalance: 10000},
+				ExternalAccount: &budgit.ExternalAccount{
+					ID:                "external-2",
+					Name:              "Easy Saver",
+					IntegrationID:     "starling",
+					LastSyncTimestamp: time.Date(2024, 6, 9, 8, 15, 0, 0, time.UTC),
+					Balance:           budgit.Balance{ClearedBalance: 10000, EffectiveBalance: 10000},
+				},
+			},
+		},
+		payees: []*budgit.Payee{{ID: "payee-1", Name: "Tesco"}, {ID: "payee-2", Name: "Employer"}},
+		groups: []*budgit.CategoryGroup{{ID: "group-1", Name: "Bills"}, {ID: "group-2", Name: "Everyday"}},
+		categories: []*budgit.Category{
+			{ID: "category-1", GroupID: "group-2", Name: "Groceries"},
+			{ID: "category-2", GroupID: "group-1", Name: "Energy"},
+		},
+		transactions: []*budgit.Transaction{
+			{ID: "transaction-1", EffectiveDate: june(1), AccountID: "account-1", PayeeID: "payee-2", Amount: 200000, Cleared: true, Memo: "June pay"},
+			{ID: "transaction-2", EffectiveDate: june(3), AccountID: "account-1", PayeeID: "payee-1", CategoryID: "category-1", Amount: -5000, Cleared: true, Memo: "Weekly shop"},
+			{ID: "transaction-3", EffectiveDate: june(5), AccountID: "account-1", PayeeID: "account-2", IsPayeeInternal: true, Amount: -10000, Cleared: true},
+			{ID: "transaction-4", EffectiveDate: june(7), AccountID: "account-1", PayeeID: "payee-1", CategoryID: "category-1", Amount: -770},
+			{ID: "transaction-5", EffectiveDate: june(5), AccountID: "account-2", PayeeID: "account-1", IsPayeeInternal: true, Amount: 10000, Cleared: true},
+		},
+		assignments: []*budgit.Assignment{
+			{ID: "assignment-1", CategoryID: "category-1", Month: june(1), Amount: 30000},
+		},
+		integrations: []svc.IntegrationInfo{{ID: "starling", Capabilities: []svc.Capability{"transaction_import"}}},
+		budgets: []*budgit.Budget{
+			{ID: "default", Name: "Household", Role: budgit.RoleOwner},
+			{ID: "budget-2", Name: "Holiday", Role: budgit.RoleViewer},
+		},
+	}
+	now := func() time.Time { return time.Date(2024, 6, 10, 18, 30, 0, 0, time.UTC) }
+	handler, err := web.New(zap.NewNop().Sugar(), s.service, now)
+	s.Require().NoError(err)
+	s.handler = handler
+	s.session = testSession
+	s.budget = ""
+}
+
+// fakeService is a web.Service holding a budget, returning err if set.
+type fakeService struct {
+	accounts     []*budgit.Account
+	payees       []*budgit.Payee
+	groups       []*budgit.CategoryGroup
+	categories   []*budgit.Category
+	transactions []*budgit.Transaction
+	assignments  []*budgit.Assignment
+	integrations []svc.IntegrationInfo
+	budgets      []*budgit.Budget
+	attachments  []*budgit.Attachment
+	err          error
+
+	syncedAccountIDs []string
+	merged           []string
+	loggedOut        string
+	budgetID         string
+	// attachmentContents are the contents of attachments, by Attachment ID.
+	attachmentContents map[string]string
+}
+
+func (f *fakeService) CreateAccounts(ctx context.Context, accounts ...*budgit.Account) ([]*budgit.Account, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.accounts = append(f.accounts, accounts...)
+	return accounts, nil
+}
+
+func (f *fakeService) ListAccounts(ctx context.Context) ([]*budgit.Account, error) {
+	f.budgetID, _ = svc.BudgetFrom(ctx)
+	return f.accounts, f.err
+}
+
+func (f *fakeService) SyncAccount(ctx context.Context, accountID string) error {
+	f.syncedAccountIDs = append(f.syncedAccountIDs, accountID)
+	return f.err
+}
+
+func (f *fakeService) ListIntegrations() []svc.IntegrationInfo {
+	return f.integrations
+}
+
+func (f *fakeService) CreatePayees(ctx context.Context, payees ...*budgit.Payee) ([]*budgit.Payee, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, payee := range payees {
+		for _, existing := range f.payees {
+			if existing.Name == payee.Name {
+				return nil, svc.DuplicatePayeesError{PayeeNames: []string{payee.Name}}
+			}
+		}
+	}
+	f.payees = append(f.payees, payees...)
+	return payees, nil
+}
+
+func (f *fakeService) ListPayees(ctx context.Context) ([]*budgit.Payee, error) {
+	return f.payees, f.err
+}
+
+func (f *fakeService) MergePayees(ctx context.Context, payeeID string, mergedPayeeIDs ...string) (int, error) {
+	f.merged = append([]string{payeeID}, mergedPayeeIDs...)
+	return 2, f.err
+}
+
+func (f *fakeService) CreateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.transactions = append(f.transactions, transactions...)
+	return transactions, nil
+}
+
+func (f *fakeService) ListTransactions(ctx context.Context, accountID string) ([]*budgit.Transaction, error) {
+	transactions := []*budgit.Transaction{}
+	for _, transaction := range f.transactions {
+		if transaction.AccountID == accountID {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, f.err
+}
+
+func (f *fakeService) GetTransaction(ctx context.Context, transactionID string) (*budgit.Transaction, error) {
+	for _, transaction := range f.transactions {
+		if transaction.ID == transactionID {
+			copied := *transaction
+			return &copied, f.err
+		}
+	}
+	return nil, fmt.Errorf("getting transaction %q: %w", transactionID, svc.ErrTransactionNotFound)
+}
+
+func (f *fakeService) UpdateTransactions(ctx context.Context, transactions ...*budgit.Transaction) ([]*budgit.Transaction, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, transaction := range transactions {
+		for i, existing := range f.transactions {
+			if existing.ID == transaction.ID {
+				f.transactions[i] = transaction
+			}
+		}
+	}
+	return transactions, nil
+}
+
+func (f *fakeService) ListAttachments(ctx context.Context, transactionID string) ([]*budgit.Attachment, error) {
+	attachments := []*budgit.Attachment{}
+	for _, attachment := range f.attachments {
+		if attachment.TransactionID == transactionID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, f.err
+}
+
+func (f *fakeService) UploadAttachment(ctx context.Context, transactionID, name, contentType string, content io.Reader) (*budgit.Attachment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	contents, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &budgit.Attachment{
+		ID:            fmt.Sprintf("attachment-%d", len(f.attachments)+1),
+		TransactionID: transactionID,
+		Name:          name,
+		ContentType:   contentType,
+		Size:          int64(len(contents)),
+	}
+	f.attachments = append(f.attachments, attachment)
+	if f.attachmentContents == nil {
+		f.attachmentContents = map[string]string{}
+	}
+	f.attachmentContents[attachment.ID] = string(contents)
+	return attachment, nil
+}
+
+func (f *fakeService) DownloadAttachment(ctx context.Context, attachmentID string) (*budgit.Attachment, io.ReadCloser, error) {
+	for _, attachment := range f.attachments {
+		if attachment.ID == attachmentID {
+			return attachment, io.NopCloser(strings.NewReader(f.attachmentContents[attachmentID])), f.err
+		}
+	}
+	return nil, nil, fmt.Errorf("downloading attachment %q: %w", attachmentID, svc.ErrAttachmentNotFound)
+}
+
+func (f *fakeService) ListCategoryGroups(ctx context.Context) ([]*budgit.CategoryGroup, error) {
+	return f.groups, f.err
+}
+
+func (f *fakeService) ListCategories(ctx context.Context) ([]*budgit.Category, error) {
+	return f.categories, f.err
+}
+
+func (f *fakeService) ListAssignments(ctx context.Context, month time.Time) ([]*budgit.Assignment, error) {
+	assignments := []*budgit.Assignment{}
+	for _, assignment := range f.assignments {
+		if assignment.Month.Equal(month) {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, f.err
+}
+
+func (f *fakeService) Assign(ctx context.Context, assignments ...*budgit.Assignment) ([]*budgit.Assignment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, assignment := range assignments {
+		if !slices.ContainsFunc(f.categories, func(category *budgit.Category) bool { return category.ID == assignment.CategoryID }) {
+			return nil, svc.MissingCategoriesError{CategoryIDs: []string{assignment.CategoryID}}
+		}
+	}
+	f.assignments = append(f.assignments, assignments...)
+	return assignments, nil
+}
+
+// Login logs alice in with the password "correct horse" and the TOTP code "123456".
+func (f *fakeService) Login(ctx context.Context, username, password, totpCode string) (*svc.Session, error) {
+	switch {
+	case username != "alice" || password != "correct horse":
+		return nil, fmt.Errorf("logging in user %q: %w", username, svc.ErrInvalidCredentials)
+	case totpCode == "":
+		return nil, fmt.Errorf("logging in user %q: %w", username, svc.ErrTOTPRequired)
+	case totpCode != "123456":
+		return nil, fmt.Errorf("logging in user %q: %w", username, svc.ErrInvalidCredentials)
+	}
+	return &svc.Session{
+		Token:            testSession,
+		User:             &budgit.User{ID: "user-1", Username: "alice", TOTPEnabled: true},
+		ExpiresTimestamp: time.Date(2024, 7, 10, 18, 30, 0, 0, time.UTC),
+	}, nil
+}
+
+func (f *fakeService) Logout(ctx context.Context, token string) error {
+	f.loggedOut = token
+	return nil
+}
+
+// Authenticate authenticates testSession and testAPIToken as alice. It ignores err, so that errors of other methods can
+// be tested.
+func (f *fakeService) Authenticate(ctx context.Context, token string) (svc.Principal, error) {
+	switch token {
+	case testSession:
+		return svc.Principal{UserID: "user-1", Username: "alice"}, nil
+	case testAPIToken:
+		return svc.Principal{UserID: "user-1", Username: "alice", APITokenID: "token-1", Scopes: []budgit.Scope{budgit.ScopeAccountsRead}}, nil
+	}
+	return svc.Principal{}, svc.ErrUnauthenticated
+}
+
+func (f *fakeService) CreateBudget(ctx context.Context, name string) (*budgit.Budget, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	budget := &budgit.Budget{ID: fmt.Sprintf("budget-%d", len(f.budgets)+1), Name: name, Role: budgit.RoleOwner}
+	f.budgets = append(f.budgets, budget)
+	return budget, nil
+}
+
+func (f *fakeService) ListBudgets(ctx context.Context) ([]*budgit.Budget, error) {
+	return f.budgets, f.err
+}
+
+// AcceptInvitation accepts the token "budgit_i_secret", inviting to a Budget as an editor.
+func (f *fakeService) AcceptInvitation(ctx context.Context, token string) (*budgit.Budget, error) {
+	if token != "budgit_i_secret" {
+		return nil, fmt.Errorf("accepting invitation: %w", svc.ErrInvitationNotFound)
+	}
+	budget := &budgit.Budget{ID: "budget-9", Name: "Shared", Role: budgit.RoleEditor}
+	f.budgets = append(f.budgets, budget)
+	return budget, nil
+}
+
+func (s *webSuite) get(path string) (*http.Response, string) {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	s.addSession(req)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), recorder.Body.String()
+}
+
+// post submits a form as a browser would from a page of the UI.
+func (s *webSuite) post(path string, form url.Values) (*http.Response, string) {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Sec-Fetch-Site", "same-origin")
+	s.addSession(req)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), recorder.Body.String()
+}
+
+func (s *webSuite) addSession(req *http.Request) {
+	if s.session != "" {
+		req.AddCookie(&http.Cookie{Name: "budgit_session", Value: s.session})
+	}
+	if s.budget != "" {
+		req.AddCookie(&http.Cookie{Name: "budgit_budget", Value: s.budget})
+	}
+}
+
+// assertRedirect asserts a form was submitted, redirecting back to a page.
+func (s *webSuite) assertRedirect(resp *http.Response, location string) {
+	s.Equal(http.StatusSeeOther, resp.StatusCode)
+	s.Equal(location, resp.Header.Get("Location"))
+}
+
+func (s *webSuite) TestRootRedirectsToAccounts() {
+	resp, _ := s.get("/")
+	s.assertRedirect(resp, "/accounts")
+}
+
+func (s *webSuite) TestPagesRequireSession() {
+	s.session = ""
+	resp, _ := s.get("/budget?month=2024-06")
+	s.assertRedirect(resp, "/login?next=%2Fbudget%3Fmonth%3D2024-06")
+
+	s.session = "budgit_s_expired"
+	resp, _ = s.post("/payees", url.Values{"name": {"Aldi"}})
+	s.assertRedirect(resp, "/login?next=%2F")
+	s.Len(s.service.payees, 2)
+
+	s.session = testAPIToken
+	resp, _ = s.get("/accounts")
+	s.assertRedirect(resp, "/login?next=%2Faccounts")
+	resp, _ = s.post("/payees", url.Values{"name": {"Aldi"}})
+	s.assertRedirect(resp, "/login?next=%2F")
+	s.Len(s.service.payees, 2)
+
+	resp, body := s.get("/login?next=%2Fbudget")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<input type="hidden" name="next" value="/budget">`)
+	s.NotContains(body, `action="/logout"`)
+}
+
+func (s *webSuite) TestLogin() {
+	s.session = ""
+	resp, body := s.post("/login", url.Values{"username": {"alice"}, "password": {"wrong"}, "next": {"/budget"}})
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">The username, password or TOTP code is incorrect.</p>`)
+	s.Contains(body, `value="alice"`)
+	s.Empty(resp.Cookies())
+
+	resp, body = s.post("/login", url.Values{"username": {"alice"}, "password": {"correct horse"}, "next": {"/budget"}})
+	s.Equal(http.StatusUnauthorized, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">A TOTP code from your authenticator app is required.</p>`)
+
+	resp, _ = s.post("/login", url.Values{"username": {"alice"}, "password": {"correct horse"}, "totp_code": {"123456"}, "next": {"/budget"}})
+	s.assertRedirect(resp, "/budget")
+	s.Require().Len(resp.Cookies(), 1)
+	cookie := resp.Cookies()[0]
+	s.Equal("budgit_session", cookie.Name)
+	s.Equal(testSession, cookie.Value)
+	s.True(cookie.HttpOnly)
+	s.Equal(http.SameSiteLaxMode, cookie.SameSite)
+
+	resp, _ = s.post("/login", url.Values{"username": {"alice"}, "password": {"correct horse"}, "totp_code": {"123456"}, "next": {"//evil.example"}})
+	s.assertRedirect(resp, "/")
+}
+
+func (s *webSuite) TestLogout() {
+	resp, body := s.get("/payees")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<span class="muted">alice</span>`)
+
+	resp, _ = s.post("/logout", nil)
+	s.assertRedirect(resp, "/login?notice=Logged+out")
+	s.Equal(testSession, s.service.loggedOut)
+	s.Require().Len(resp.Cookies(), 1)
+	s.Equal(-1, resp.Cookies()[0].MaxAge)
+}
+
+func (s *webSuite) TestAccounts() {
+	resp, body := s.get("/accounts?notice=Synced+Account")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	s.Contains(body, `<p class="notice" role="status">Synced Account</p>`)
+	s.Contains(body, `<a href="/accounts/account-1">Current</a>`)
+	s.Contains(body, `<td>Easy Saver (starling)</td>`)
+	s.Contains(body, `<td>2024-06-09 08:15</td>`)
+	s.Contains(body, `<form method="post" action="/accounts/account-2/sync" data-enhance>`)
+	s.NotContains(body, `action="/accounts/account-1/sync"`)
+	s.Contains(body, `<span class="amount">1942.30</span>`)
+	s.Contains(body, `<li>starling: transaction_import</li>`)
+}
+
+func (s *webSuite) TestCreateAccount() {
+	resp, _ := s.post("/accounts", url.Values{"name": {"Credit Card"}, "opening_balance": {"-120.50"}})
+	s.assertRedirect(resp, "/accounts?notice=Created+Account+Credit+Card")
+	s.Require().Len(s.service.accounts, 3)
+	s.CMPEqual(&budgit.Account{
+		Name:    "Credit Card",
+		Balance: budgit.Balance{ClearedBalance: -12050, EffectiveBalance: -12050},
+	}, s.service.accounts[2], cmpopts.IgnoreFields(budgit.Account{}, "ID"))
+}
+
+func (s *webSuite) TestCreateAccountInvalid() {
+	resp, body := s.post("/accounts", url.Values{"name": {"Credit Card"}, "opening_balance": {"12.345"}})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">`)
+	s.Contains(body, `value="Credit Card"`)
+	s.Contains(body, `value="12.345"`)
+	s.Len(s.service.accounts, 2)
+}
+
+func (s *webSuite) TestSyncAccount() {
+	resp, _ := s.post("/accounts/account-2/sync", nil)
+	s.assertRedirect(resp, "/accounts?notice=Synced+Account")
+	s.Equal([]string{"account-2"}, s.service.syncedAccountIDs)
+}
+
+func (s *webSuite) TestRegister() {
+	resp, body := s.get("/accounts/account-1")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<h1>Current</h1>`)
+	s.Contains(body, `<a href="/accounts/account-1" aria-current="page">Current</a>`)
+	s.Contains(body, `<input type="date" name="date" value="2024-06-10" required>`)
+	s.Contains(body, `<option value="Transfer: Savings">`)
+	s.Contains(body, `<optgroup label="Bills">`)
+
+	// Rows are newest first, with running balances back from the effective balance.
+	rows := []string{
+		`<td>2024-06-07</td>
+      <td>Tesco</td>
+      <td>Everyday:Groceries</td>
+      <td></td>
+      <td class="number"><span class="amount negative">-7.70</span></td>
+      <td class="number"><span class="amount">1842.30</span></td>`,
+		`<td>2024-06-05</td>
+      <td>Transfer: Savings</td>`,
+		`<td class="number"><span class="amount">1850.00</span></td>`,
+		`<td>2024-06-01</td>
+      <td>Employer</td>
+      <td></td>
+      <td>June pay</td>
+      <td class="number"><span class="amount">2000.00</span></td>
+      <td class="number"><span class="amount">2000.00</span></td>`,
+	}
+	previous := 0
+	for _, row := range rows {
+		i := strings.Index(body, row)
+		s.Require().Greater(i, previous, row)
+		previous = i
+	}
+}
+
+func (s *webSuite) TestRegisterOfUnknownAccount() {
+	resp, body := s.get("/accounts/account-9")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	s.Contains(body, "the requested Account does not exist")
+}
+
+func (s *webSuite) TestAddTransaction() {
+	resp, _ := s.post("/accounts/account-1/transactions", url.Values{
+		"date":        {"2024-06-10"},
+		"payee":       {"Corner Shop"},
+		"category_id": {"category-1"},
+		"amount":      {"-3.20"},
+		"memo":        {"Milk"},
+	})
+	s.assertRedirect(resp, "/accounts/account-1?notice=Added+Transaction+of+-3.20")
+	s.Require().Len(s.service.payees, 3)
+	s.Equal("Corner Shop", s.service.payees[2].Name)
+	s.CMPEqual(&budgit.Transaction{
+		EffectiveDate: june(10),
+		AccountID:     "account-1",
+		PayeeID:       s.service.payees[2].ID,
+		CategoryID:    "category-1",
+		Amount:        -320,
+		Memo:          "Milk",
+	}, s.service.transactions[5], cmpopts.IgnoreFields(budgit.Transaction{}, "ID"))
+}
+
+func (s *webSuite) TestAddTransfer() {
+	resp, _ := s.post("/accounts/account-1/transactions", url.Values{
+		"date":    {"2024-06-10"},
+		"payee":   {"Transfer: savings"},
+		"amount":  {"-50"},
+		"cleared": {"true"},
+	})
+	s.assertRedirect(resp, "/accounts/account-1?notice=Added+Transaction+of+-50.00")
+	s.Len(s.service.payees, 2)
+	s.CMPEqual(&budgit.Transaction{
+		EffectiveDate:   june(10),
+		AccountID:       "account-1",
+		PayeeID:         "account-2",
+		IsPayeeInternal: true,
+		Amount:          -5000,
+		Cleared:         true,
+	}, s.service.transactions[5], cmpopts.IgnoreFields(budgit.Transaction{}, "ID"))
+}
+
+func (s *webSuite) TestAddTransactionInvalid() {
+	resp, body := s.post("/accounts/account-1/transactions", url.Values{
+		"date":   {"2024-06-10"},
+		"payee":  {"Transfer: Current"},
+		"amount": {"-50"},
+		"memo":   {"Kept"},
+	})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">a transfer must be to another Account</p>`)
+	s.Contains(body, `<input name="memo" value="Kept">`)
+	s.Len(s.service.transactions, 5)
+}
+
+func (s *webSuite) TestToggleCleared() {
+	resp, _ := s.post("/transactions/transaction-4/cleared", nil)
+	s.assertRedirect(resp, "/accounts/account-1?notice=Marked+Transaction+cleared")
+	s.True(s.service.transactions[3].Cleared)
+
+	resp, _ = s.post("/transactions/transaction-4/cleared", nil)
+	s.assertRedirect(resp, "/accounts/account-1?notice=Marked+Transaction+uncleared")
+	s.False(s.service.transactions[3].Cleared)
+}
+
+func (s *webSuite) TestEditTransaction() {
+	resp, body := s.get("/transactions/transaction-2")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<h1>Edit Transaction of Current</h1>`)
+	s.Contains(body, `<input name="payee" value="Tesco"`)
+	s.Contains(body, `<option value="category-1" selected>Groceries</option>`)
+	s.Contains(body, `<input name="amount" value="-50.00"`)
+	s.Contains(body, `<input type="checkbox" name="cleared" value="true" checked>`)
+
+	resp, _ = s.post("/transactions/transaction-2", url.Values{
+		"date":        {"2024-06-04"},
+		"payee":       {"Tesco"},
+		"category_id": {"category-1"},
+		"amount":      {"-55.00"},
+		"memo":        {"Weekly shop"},
+	})
+	s.assertRedirect(resp, "/accounts/account-1?notice=Saved+Transaction")
+	s.CMPEqual(&budgit.Transaction{
+		ID:            "transaction-2",
+		EffectiveDate: june(4),
+		AccountID:     "account-1",
+		PayeeID:       "payee-1",
+		CategoryID:    "category-1",
+		Amount:        -5500,
+		Memo:          "Weekly shop",
+	}, s.service.transactions[1])
+}
+
+func (s *webSuite) TestAttachments() {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="receipt.pdf"`)
+	header.Set("Content-Type", "application/pdf")
+	part, err := writer.CreatePart(header)
+	s.Require().NoError(err)
+	_, err = part.Write([]byte("%PDF-1.7"))
+	s.Require().NoError(err)
+	s.Require().NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/transactions/transaction-2/attachments", &form)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Sec-Fetch-Site", "same-origin")
+	s.addSession(req)
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, req)
+	s.assertRedirect(recorder.Result(), "/transactions/transaction-2?notice=Attached+file")
+
+	resp, body := s.get("/transactions/transaction-2")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<a href="/attachments/attachment-1">receipt.pdf</a>`)
+	s.Contains(body, `enctype="multipart/form-data"`)
+
+	resp, body = s.get("/attachments/attachment-1")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("application/pdf", resp.Header.Get("Content-Type"))
+	s.Equal("attachment; filename=receipt.pdf", resp.Header.Get("Content-Disposition"))
+	s.Equal("%PDF-1.7", body)
+
+	resp, _ = s.get("/attachments/attachment-2")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, body = s.post("/transactions/transaction-2/attachments", url.Values{})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(body, "a file is required")
+	s.Len(s.service.attachments, 1)
+}
+
+func (s *webSuite) TestPayees() {
+	resp, body := s.get("/payees")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, "<li>Employer</li><li>Tesco</li>")
+
+	resp, _ = s.post("/payees", url.Values{"name": {"Corner Shop"}})
+	s.assertRedirect(resp, "/payees?notice=Created+Payee+Corner+Shop")
+	s.Equal("Corner Shop", s.service.payees[2].Name)
+
+	resp, _ = s.post("/payees/merge", url.Values{"payee_id": {"payee-1"}, "merged_payee_id": {"payee-2", "payee-3"}})
+	s.assertRedirect(resp, "/payees?notice=Merged+Payees%2C+moving+2+Transactions")
+	s.Equal([]string{"payee-1", "payee-2", "payee-3"}, s.service.merged)
+}
+
+func (s *webSuite) TestCreateDuplicatePayee() {
+	resp, body := s.post("/payees", url.Values{"name": {"Tesco"}})
+	s.Equal(http.StatusConflict, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">`)
+	s.Contains(body, `<input name="name" value="Tesco" required>`)
+}
+
+func (s *webSuite) TestBudget() {
+	resp, body := s.get("/budget")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<strong>June 2024</strong>`)
+	s.Contains(body, `<a href="/budget?month=2024-05" rel="prev">`)
+	s.Contains(body, `<input name="amount" value="300.00" inputmode="decimal" aria-label="Assigned to Groceries" data-autosubmit>`)
+	// Groceries has 300.00 assigned with activity of -57.70, leaving 242.30 available.
+	s.Contains(body, `<td class="number"><span class="amount negative">-57.70</span></td>
+      <td class="number"><span class="amount">242.30</span></td>`)
+
+	resp, body = s.get("/budget?month=2024-05")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<strong>May 2024</strong>`)
+	s.Contains(body, `aria-label="Assigned to Groceries"`)
+	s.NotContains(body, `value="300.00"`)
+}
+
+func (s *webSuite) TestAssign() {
+	resp, _ := s.post("/budget", url.Values{"month": {"2024-07"}, "category_id": {"category-2"}, "amount": {"85"}})
+	s.assertRedirect(resp, "/budget?month=2024-07&notice=Assigned+85.00")
+	s.CMPEqual(&budgit.Assignment{CategoryID: "category-2", Month: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), Amount: 8500}, s.service.assignments[1])
+}
+
+func (s *webSuite) TestAssignMissingCategory() {
+	resp, body := s.post("/budget", url.Values{"month": {"2024-06"}, "category_id": {"category-9"}, "amount": {"85"}})
+	s.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">assignments reference Categories that do not exist: [category-9]</p>`)
+	s.Contains(body, `<strong>June 2024</strong>`)
+}
+
+func (s *webSuite) TestBudgets() {
+	resp, body := s.get("/budgets")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(body, `<td>Household</td>
+      <td>owner</td>`)
+	s.Contains(body, `<span class="muted">Current</span>`)
+	s.Contains(body, `<input type="hidden" name="budget_id" value="budget-2">`)
+	s.NotContains(body, `value="default"`)
+
+	s.budget = "budget-2"
+	_, body = s.get("/budgets")
+	s.Contains(body, `<input type="hidden" name="budget_id" value="default">`)
+}
+
+func (s *webSuite) TestBudgetCookie() {
+	s.get("/accounts")
+	s.Equal("default", s.service.budgetID)
+
+	s.budget = "budget-2"
+	s.get("/accounts")
+	s.Equal("budget-2", s.service.budgetID)
+}
+
+func (s *webSuite) TestSelectBudget() {
+	resp, _ := s.post("/budgets/select", url.Values{"budget_id": {"budget-2"}})
+	s.assertRedirect(resp, "/accounts?notice=Switched+to+Budget+Holiday")
+	s.Require().Len(resp.Cookies(), 1)
+	s.Equal("budgit_budget", resp.Cookies()[0].Name)
+	s.Equal("budget-2", resp.Cookies()[0].Value)
+
+	s.Run("NotMember", func() {
+		resp, body := s.post("/budgets/select", url.Values{"budget_id": {"budget-9"}})
+		s.Equal(http.StatusNotFound, resp.StatusCode)
+		s.Contains(body, `<p class="error" role="alert">`)
+		s.Empty(resp.Cookies())
+	})
+}
+
+func (s *webSuite) TestCreateBudget() {
+	resp, _ := s.post("/budgets", url.Values{"name": {"Wedding"}})
+	s.assertRedirect(resp, "/accounts?notice=Created+Budget+Wedding")
+	s.Require().Len(resp.Cookies(), 1)
+	s.Equal("budget-3", resp.Cookies()[0].Value)
+
+	resp, body := s.post("/budgets", url.Values{"name": {" "}})
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+	s.Contains(body, `<p class="error" role="alert">name is required</p>`)
+}
+
+func (s *webSuite) TestAcceptInvitation() {
+	resp, _ := s.post("/budgets/accept", url.Values{"token": {"budgit_i_secret"}})
+	s.assertRedirect(resp, "/accounts?notice=Joined+Budget+Shared+as+editor")
+	s.Require().Len(resp.Cookies(), 1)
+	s.Equal("budget-9", resp.Cookies()[0].Value)
+
+	resp, body := s.post("/budgets/accept", url.Values{"token": {"budgit_i_wrong"}})
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	s.Contains(body, "the invitation does not exist or has expired")
+}
+
+func (s *webSuite) TestUnexpectedErrorsAreHidden() {
+	s.service.err = errors.New("connection refused")
+	resp, body := s.get("/accounts")
+	s.Equal(http.StatusInternalServerError, resp.StatusCode)
+	s.Contains(body, "An unexpected error occurred.")
+	s.NotContains(body, "connection refused")
+}
+
+func (s *webSuite) TestCrossSiteFormsAreForbidden() {
+	for name, header := range map[string][2]string{
+		"SecFetchSite": {"Sec-Fetch-Site", "cross-site"},
+		"Origin":       {"Origin", "https://evil.example"},
+	} {
+		s.Run(name, func() {
+			req := httptest.NewRequest(http.MethodPost, "/accounts", strings.NewReader("name=Stolen"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.Header.Set(header[0], header[1])
+			recorder := httptest.NewRecorder()
+			s.handler.ServeHTTP(recorder, req)
+			s.Equal(http.StatusForbidden, recorder.Code)
+			s.Len(s.service.accounts, 2)
+		})
+	}
+}
+
+func (s *webSuite) TestStaticFiles() {
+	s.session = ""
+	resp, body := s.get("/static/app.js")
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(resp.Header.Get("Content-Type"), "javascript")
+	s.Contains(body, "data-enhance")
+
+	resp, _ = s.get("/static/app.css")
+	s.Equal(http.StatusOK, resp.StatusCode)
+}
+
+func (s *webSuite) TestUnknownPage() {
+	resp, body := s.get("/nowhere")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	s.Contains(body, "The page does not exist.")
+}
+
+func (s *webSuite) CMPEqual(expected, actual any, opts ...cmp.Option) {
+	if !cmp.Equal(expected, actual, opts...) {
+		s.Fail(cmp.Diff(expected, actual, opts...))
+	}
+}
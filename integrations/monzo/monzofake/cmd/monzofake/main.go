@@ -0,0 +1,27 @@
+// Command monzofake serves a fake Monzo API seeded with demo data, so Budg-it can be demoed without credentials.
+//
+// Point a Monzo integration at it, with any API token:
+//
+//	go run ./integrations/monzo/monzofake/cmd/monzofake -addr localhost:8082
+//	INTEGRATIONS=monzo MONZO_URL=http://localhost:8082 MONZO_API_TOKEN=demo go run .
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/monzo/monzofake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8082", "address to listen on")
+	flag.Parse()
+
+	server := monzofake.New()
+	server.SeedDemo(time.Now())
+
+	log.Printf("Serving fake Monzo API on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -0,0 +1,51 @@
+package monzofake
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/monzo"
+)
+
+// SeedDemo seeds the Server with a personal and a joint account, each with a month of transactions before now,
+// and pots.
+func (s *Server) SeedDemo(now time.Time) {
+	personal := s.AddAccount(Account{
+		ID:            "acc_00000000000000000000000001",
+		Description:   "user_00000000000000000000000001",
+		Type:          monzo.UkRetail,
+		Created:       now.AddDate(-1, 0, 0),
+		OwnerNames:    []string{"Alex Smith"},
+		AccountNumber: "12345678",
+		SortCode:      "040004",
+		Balance:       84210,
+	})
+	joint := s.AddAccount(Account{
+		ID:            "acc_00000000000000000000000002",
+		Description:   "joint_00000000000000000000000002",
+		Type:          monzo.UkRetailJoint,
+		Created:       now.AddDate(-1, 0, 0),
+		OwnerNames:    []string{"Alex Smith", "Sam Jones"},
+		AccountNumber: "87654321",
+		SortCode:      "040004",
+		Balance:       61500,
+	})
+
+	day := func(daysAgo int) time.Time {
+		return now.AddDate(0, 0, -daysAgo).Truncate(time.Hour)
+	}
+	s.AddTransactions(personal.ID,
+		Transaction{Amount: 210000, CounterpartyName: "Employer Ltd", Description: "SALARY", Category: "income", Created: day(28), Settled: true},
+		Transaction{Amount: -4599, MerchantName: "Supermarket", Description: "SUPERMARKET LONDON", Category: "groceries", Created: day(12), Settled: true},
+		Transaction{Amount: -1250, MerchantName: "Cinema", Description: "CINEMA", Category: "entertainment", Notes: "with friends", Created: day(5), Settled: true},
+		Transaction{Amount: -999, MerchantName: "Streaming Service", Description: "STREAMING", Category: "entertainment", Created: day(3), DeclineReason: "INSUFFICIENT_FUNDS"},
+		Transaction{Amount: -450, MerchantName: "Coffee Shop", Description: "COFFEE SHOP", Category: "eating_out", Created: day(0)},
+	)
+	s.AddTransactions(joint.ID,
+		Transaction{Amount: 100000, CounterpartyName: "Personal", Description: "JOINT", Category: "transfers", Created: day(26), Settled: true},
+		Transaction{Amount: -38500, CounterpartyName: "Council", Description: "COUNCIL TAX", Category: "bills", Created: day(18), Settled: true},
+	)
+	s.AddPots(personal.ID,
+		Pot{ID: "pot_00000000000000000000000001", Name: "Rainy Day", Balance: 150000, Goal: 300000},
+		Pot{ID: "pot_00000000000000000000000002", Name: "Old Holiday", Balance: 0, Deleted: true},
+	)
+}
@@ -0,0 +1,489 @@
+// Package monzofake is an in-process fake of the Monzo API, for tests and demos without a live bank.
+// It serves the generated monzo types, seeded with accounts, balances, pots and transactions,
+// and can be made to fail requests to exercise error handling.
+package monzofake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/monzo"
+)
+
+// Account is a Monzo current account to seed the Server with.
+type Account struct {
+	ID          string
+	Description string
+	Type        monzo.AccountType
+	Currency    string
+	Created     time.Time
+	Closed      bool
+	// OwnerNames are the preferred names of the owners of the account.
+	OwnerNames    []string
+	AccountNumber string
+	SortCode      string
+	// Balance is the available balance, excluding pots.
+	Balance int64
+}
+
+// Pot is a pot of a Monzo account, to seed the Server with.
+type Pot struct {
+	ID      string
+	Name    string
+	Balance int64
+	Goal    int64
+	Deleted bool
+}
+
+// Transaction is a transaction of a Monzo account, to seed the Server with.
+// Amount is signed, negative amounts being debits.
+type Transaction struct {
+	ID               string
+	Amount           int64
+	Description      string
+	MerchantName     string
+	CounterpartyName string
+	Notes            string
+	Category         string
+	Created          time.Time
+	Settled          bool
+	DeclineReason    string
+}
+
+// Failure is a failure injected into the Server, returned instead of the normal response.
+type Failure struct {
+	// Method and PathPrefix restrict the requests that fail. Empty values match all requests.
+	Method     string
+	PathPrefix string
+	StatusCode int
+	Header     http.Header
+	Body       string
+	// Times is the number of matching requests that fail. Zero fails every matching request.
+	Times int
+}
+
+type account struct {
+	Account
+	pots         []Pot
+	transactions []Transaction
+}
+
+// Server is a fake Monzo API. It is safe for concurrent use.
+type Server struct {
+	mux *http.ServeMux
+
+	mu            sync.Mutex
+	accounts      []*account
+	failures      []*Failure
+	accessToken   string
+	refreshToken  string
+	issuedTokens  int
+	issuedIDs     int
+	tokenLifetime time.Duration
+	requests      []string
+}
+
+// New returns an empty Server, which accepts requests with any access token until SetCredentials is called.
+func New() *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		tokenLifetime: 6 * time.Hour,
+	}
+	s.mux.HandleFunc("POST /oauth2/token", s.handleToken)
+	s.mux.HandleFunc("GET /ping/whoami", s.authenticated(s.handleWhoAmI))
+	s.mux.HandleFunc("GET /accounts", s.authenticated(s.handleAccounts))
+	s.mux.HandleFunc("GET /balance", s.authenticated(s.handleBalance))
+	s.mux.HandleFunc("GET /pots", s.authenticated(s.handlePots))
+	s.mux.HandleFunc("GET /transactions", s.authenticated(s.handleTransactions))
+	s.mux.HandleFunc("GET /transactions/{transactionID}", s.authenticated(s.handleTransaction))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	failure := s.takeFailure(r)
+	s.mu.Unlock()
+
+	if failure != nil {
+		for key, values := range failure.Header {
+			w.Header()[key] = values
+		}
+		if strings.HasPrefix(failure.Body, "{") {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(failure.StatusCode)
+		w.Write([]byte(failure.Body))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetCredentials requires requests to carry the given access token, which can be rotated using the refresh token.
+func (s *Server) SetCredentials(accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken, s.refreshToken = accessToken, refreshToken
+}
+
+// AddAccount seeds an account. A zero ID is replaced with a generated one.
+func (s *Server) AddAccount(a Account) Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == "" {
+		a.ID = s.newID("acc")
+	}
+	if a.Type == "" {
+		a.Type = monzo.UkRetail
+	}
+	if a.Currency == "" {
+		a.Currency = "GBP"
+	}
+	s.accounts = append(s.accounts, &account{Account: a})
+	return a
+}
+
+// SetBalance replaces the available balance of a seeded account.
+func (s *Server) SetBalance(accountID string, balance int64) {
+	s.withAccount(accountID, func(a *account) {
+		a.Balance = balance
+	})
+}
+
+// AddPots seeds pots into an account. A zero ID is replaced with a generated one.
+func (s *Server) AddPots(accountID string, pots ...Pot) []Pot {
+	s.withAccount(accountID, func(a *account) {
+		for i := range pots {
+			if pots[i].ID == "" {
+				pots[i].ID = s.newID("pot")
+			}
+		}
+		a.pots = append(a.pots, pots...)
+	})
+	return pots
+}
+
+// AddTransactions seeds transactions into an account. A zero ID is replaced with a generated one.
+// Transactions are served oldest first, whatever order they are seeded in.
+func (s *Server) AddTransactions(accountID string, transactions ...Transaction) []Transaction {
+	s.withAccount(accountID, func(a *account) {
+		for i := range transactions {
+			if transactions[i].ID == "" {
+				transactions[i].ID = s.newID("tx")
+			}
+		}
+		a.transactions = append(a.transactions, transactions...)
+		slices.SortStableFunc(a.transactions, func(a, b Transaction) int { return a.Created.Compare(b.Created) })
+	})
+	return transactions
+}
+
+// InjectFailure makes matching requests fail, in the order failures were injected.
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the method and path of every request received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// newID returns a new ID with the given prefix. It must be called with mu held.
+func (s *Server) newID(prefix string) string {
+	s.issuedIDs++
+	return fmt.Sprintf("%s_%024d", prefix, s.issuedIDs)
+}
+
+func (s *Server) withAccount(accountID string, f func(a *account)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.accounts {
+		if a.ID == accountID {
+			f(a)
+			return
+		}
+	}
+	panic(fmt.Sprintf("monzofake: account %s has not been seeded", accountID))
+}
+
+// takeFailure returns the first Failure matching the request, if any. It must be called with mu held.
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if failure.Method != "" && failure.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, failure.PathPrefix) {
+			continue
+		}
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = slices.Delete(s.failures, i, i+1)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		accessToken := s.accessToken
+		s.mu.Unlock()
+
+		if accessToken != "" && r.Header.Get("Authorization") != "Bearer "+accessToken {
+			writeError(w, http.StatusUnauthorized, "unauthorized.bad_access_token", "The access token is invalid")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.PostForm.Get("grant_type") != "refresh_token" || s.refreshToken == "" || r.PostForm.Get("refresh_token") != s.refreshToken {
+		writeError(w, http.StatusUnauthorized, "unauthorized.bad_refresh_token", "The refresh token is invalid")
+		return
+	}
+
+	s.issuedTokens++
+	s.accessToken = fmt.Sprintf("fake-access-token-%d", s.issuedTokens)
+	s.refreshToken = fmt.Sprintf("fake-refresh-token-%d", s.issuedTokens)
+	writeJSON(w, monzo.AccessToken{
+		AccessToken:  &s.accessToken,
+		RefreshToken: &s.refreshToken,
+		TokenType:    ptr("Bearer"),
+		ExpiresIn:    ptr(int64(s.tokenLifetime.Seconds())),
+	})
+}
+
+func (s *Server) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, monzo.WhoAmI{
+		Authenticated: ptr(true),
+		ClientId:      ptr("oauth2client_fake"),
+		UserId:        ptr("user_fake"),
+	})
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountType := monzo.AccountType(r.URL.Query().Get("account_type"))
+	accounts := make([]monzo.Account, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		if accountType != "" && accountType != a.Type {
+			continue
+		}
+		owners := make([]monzo.AccountOwner, 0, len(a.OwnerNames))
+		for _, name := range a.OwnerNames {
+			owners = append(owners, monzo.AccountOwner{PreferredName: &name})
+		}
+		accounts = append(accounts, monzo.Account{
+			Id:            a.ID,
+			Description:   &a.Description,
+			Type:          &a.Type,
+			Currency:      &a.Currency,
+			Created:       &a.Created,
+			Closed:        &a.Closed,
+			Owners:        &owners,
+			AccountNumber: &a.AccountNumber,
+			SortCode:      &a.SortCode,
+		})
+	}
+	writeJSON(w, monzo.Accounts{Accounts: &accounts})
+}
+
+func (s *Server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, "account_id", func(a *account) any {
+		total := a.Balance
+		for _, pot := range a.pots {
+			if !pot.Deleted {
+				total += pot.Balance
+			}
+		}
+		return monzo.Balance{
+			Balance:      a.Balance,
+			TotalBalance: total,
+			Currency:     &a.Currency,
+			SpendToday:   ptr(int64(0)),
+		}
+	})
+}
+
+func (s *Server) handlePots(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, "current_account_id", func(a *account) any {
+		pots := make([]monzo.Pot, 0, len(a.pots))
+		for _, pot := range a.pots {
+			pots = append(pots, monzo.Pot{
+				Id:         pot.ID,
+				Name:       &pot.Name,
+				Style:      ptr("beach_ball"),
+				Balance:    pot.Balance,
+				Currency:   &a.Currency,
+				GoalAmount: &pot.Goal,
+				Created:    &a.Created,
+				Updated:    &a.Created,
+				Deleted:    &pot.Deleted,
+			})
+		}
+		return monzo.Pots{Pots: &pots}
+	})
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit := 100
+	if query.Has("limit") {
+		parsed, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || parsed < 1 || parsed > 100 {
+			writeError(w, http.StatusBadRequest, "bad_request.bad_param.limit", "limit must be between 1 and 100")
+			return
+		}
+		limit = parsed
+	}
+	var before time.Time
+	if query.Has("before") {
+		parsed, err := time.Parse(time.RFC3339, query.Get("before"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request.bad_param.before", err.Error())
+			return
+		}
+		before = parsed
+	}
+	expandMerchant := slices.Contains(query["expand[]"], "merchant")
+
+	s.serveAccount(w, r, "account_id", func(a *account) any {
+		start := 0
+		if since := query.Get("since"); since != "" {
+			// since is either a time, or the ID of the last transaction of the previous page.
+			if sinceTime, err := time.Parse(time.RFC3339, since); err == nil {
+				start = slices.IndexFunc(a.transactions, func(t Transaction) bool { return !t.Created.Before(sinceTime) })
+			} else {
+				start = slices.IndexFunc(a.transactions, func(t Transaction) bool { return t.ID == since }) + 1
+			}
+			if start < 0 {
+				start = len(a.transactions)
+			}
+		}
+
+		transactions := make([]monzo.Transaction, 0, limit)
+		for _, t := range a.transactions[start:] {
+			if len(transactions) == limit || (!before.IsZero() && !t.Created.Before(before)) {
+				break
+			}
+			transactions = append(transactions, toTransaction(a, t, expandMerchant))
+		}
+		return monzo.Transactions{Transactions: &transactions}
+	})
+}
+
+func (s *Server) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expandMerchant := slices.Contains(r.URL.Query()["expand[]"], "merchant")
+	for _, a := range s.accounts {
+		for _, t := range a.transactions {
+			if t.ID == r.PathValue("transactionID") {
+				transaction := toTransaction(a, t, expandMerchant)
+				writeJSON(w, monzo.TransactionResponse{Transaction: &transaction})
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "transaction not found")
+}
+
+// serveAccount writes the response built from the account in the given query parameter,
+// or 404 Not Found if it is missing.
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, param string, respond func(a *account) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accountID := r.URL.Query().Get(param)
+	if accountID == "" {
+		writeError(w, http.StatusBadRequest, "bad_request.missing_param."+param, param+" is required")
+		return
+	}
+	for _, a := range s.accounts {
+		if a.ID == accountID {
+			writeJSON(w, respond(a))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "not_found", "account not found")
+}
+
+func toTransaction(a *account, t Transaction, expandMerchant bool) monzo.Transaction {
+	settled := ""
+	if t.Settled {
+		settled = t.Created.Format(time.RFC3339)
+	}
+	category := t.Category
+	if category == "" {
+		category = "general"
+	}
+	transaction := monzo.Transaction{
+		Id:                t.ID,
+		AccountId:         &a.ID,
+		Amount:            t.Amount,
+		Currency:          &a.Currency,
+		Created:           t.Created,
+		Settled:           &settled,
+		Updated:           &t.Created,
+		Description:       &t.Description,
+		Notes:             &t.Notes,
+		Category:          &category,
+		IsLoad:            ptr(t.Amount > 0),
+		IncludeInSpending: ptr(t.Amount < 0),
+		Metadata:          &map[string]string{},
+	}
+	if t.DeclineReason != "" {
+		transaction.DeclineReason = ptr(monzo.TransactionDeclineReason(t.DeclineReason))
+	}
+	if expandMerchant && t.MerchantName != "" {
+		transaction.Merchant = &monzo.Merchant{
+			Id:       ptr("merch_" + strings.ToLower(strings.ReplaceAll(t.MerchantName, " ", "_"))),
+			Name:     &t.MerchantName,
+			Category: &category,
+		}
+	}
+	if t.CounterpartyName != "" {
+		transaction.Counterparty = &monzo.Counterparty{Name: &t.CounterpartyName}
+	}
+	return transaction
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(monzo.ErrorResponse{Code: &code, Message: &message})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
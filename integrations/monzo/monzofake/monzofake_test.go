@@ -0,0 +1,122 @@
+package monzofake_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/monzo"
+	"github.com/andrewthowell/budgit/integrations/monzo/monzofake"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMonzoFake(t *testing.T) {
+	suite.Run(t, new(monzoFakeSuite))
+}
+
+type monzoFakeSuite struct {
+	suite.Suite
+
+	fake   *monzofake.Server
+	server *httptest.Server
+	client *monzo.ClientWithResponses
+}
+
+func (s *monzoFakeSuite) SetupTest() {
+	s.fake = monzofake.New()
+	s.server = httptest.NewServer(s.fake)
+
+	client, err := monzo.NewClientWithResponses(s.server.URL)
+	s.Require().NoError(err)
+	s.client = client
+}
+
+func (s *monzoFakeSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *monzoFakeSuite) TestTransactions() {
+	account := s.fake.AddAccount(monzofake.Account{Description: "Personal"})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	transactions := s.fake.AddTransactions(account.ID,
+		monzofake.Transaction{Amount: 1000, CounterpartyName: "Employer", Created: now.AddDate(0, 0, -1)},
+		monzofake.Transaction{Amount: -320, MerchantName: "Pret", Created: now.AddDate(0, 0, -2), Settled: true},
+	)
+	expand := &[]monzo.ListTransactionsParamsExpand{"merchant"}
+
+	s.Run("OldestFirstWithMerchant", func() {
+		resp, err := s.client.ListTransactionsWithResponse(context.Background(), &monzo.ListTransactionsParams{AccountId: account.ID, Expand: expand})
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200.Transactions, 2)
+
+		transaction := (*resp.JSON200.Transactions)[0]
+		s.Equal(transactions[1].ID, transaction.Id)
+		s.Equal(int64(-320), transaction.Amount)
+		s.Equal("Pret", *transaction.Merchant.Name)
+		s.NotEmpty(*transaction.Settled)
+		s.Empty(*(*resp.JSON200.Transactions)[1].Settled)
+	})
+	s.Run("PaginatedBySinceID", func() {
+		limit := int32(1)
+		resp, err := s.client.ListTransactionsWithResponse(context.Background(), &monzo.ListTransactionsParams{AccountId: account.ID, Limit: &limit})
+		s.Require().NoError(err)
+		s.Require().Len(*resp.JSON200.Transactions, 1)
+
+		since := (*resp.JSON200.Transactions)[0].Id
+		resp, err = s.client.ListTransactionsWithResponse(context.Background(), &monzo.ListTransactionsParams{AccountId: account.ID, Limit: &limit, Since: &since})
+		s.Require().NoError(err)
+		s.Require().Len(*resp.JSON200.Transactions, 1)
+		s.Equal(transactions[0].ID, (*resp.JSON200.Transactions)[0].Id)
+	})
+	s.Run("SinceTime", func() {
+		since := now.AddDate(0, 0, -1).Format(time.RFC3339)
+		resp, err := s.client.ListTransactionsWithResponse(context.Background(), &monzo.ListTransactionsParams{AccountId: account.ID, Since: &since})
+		s.Require().NoError(err)
+		s.Require().Len(*resp.JSON200.Transactions, 1)
+		s.Equal(transactions[0].ID, (*resp.JSON200.Transactions)[0].Id)
+	})
+	s.Run("UnknownAccount", func() {
+		resp, err := s.client.ListTransactionsWithResponse(context.Background(), &monzo.ListTransactionsParams{AccountId: "acc_unknown"})
+		s.Require().NoError(err)
+		s.Equal(http.StatusNotFound, resp.StatusCode())
+	})
+}
+
+func (s *monzoFakeSuite) TestBalanceAndPots() {
+	account := s.fake.AddAccount(monzofake.Account{Description: "Personal", Balance: 1000})
+	s.fake.AddPots(account.ID,
+		monzofake.Pot{Name: "Holiday", Balance: 500},
+		monzofake.Pot{Name: "Old", Balance: 200, Deleted: true},
+	)
+
+	balance, err := s.client.GetBalanceWithResponse(context.Background(), &monzo.GetBalanceParams{AccountId: account.ID})
+	s.Require().NoError(err)
+	s.Require().NotNil(balance.JSON200)
+	s.Equal(int64(1000), balance.JSON200.Balance)
+	s.Equal(int64(1500), balance.JSON200.TotalBalance)
+
+	pots, err := s.client.ListPotsWithResponse(context.Background(), &monzo.ListPotsParams{CurrentAccountId: account.ID})
+	s.Require().NoError(err)
+	s.Require().NotNil(pots.JSON200)
+	s.Require().Len(*pots.JSON200.Pots, 2)
+	s.Equal("Holiday", *(*pots.JSON200.Pots)[0].Name)
+	s.True(*(*pots.JSON200.Pots)[1].Deleted)
+}
+
+func (s *monzoFakeSuite) TestCredentials() {
+	s.fake.SetCredentials("access", "refresh")
+
+	resp, err := s.client.ListAccountsWithResponse(context.Background(), nil)
+	s.Require().NoError(err)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode())
+
+	resp, err = s.client.ListAccountsWithResponse(context.Background(), nil, func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer access")
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode())
+}
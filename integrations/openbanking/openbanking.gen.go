@@ -0,0 +1,1470 @@
+// Package openbanking provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+package openbanking
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for OBAccountAccountSubType.
+const (
+	ChargeCard     OBAccountAccountSubType = "ChargeCard"
+	CreditCard     OBAccountAccountSubType = "CreditCard"
+	CurrentAccount OBAccountAccountSubType = "CurrentAccount"
+	EMoney         OBAccountAccountSubType = "EMoney"
+	Loan           OBAccountAccountSubType = "Loan"
+	Mortgage       OBAccountAccountSubType = "Mortgage"
+	PrePaidCard    OBAccountAccountSubType = "PrePaidCard"
+	Savings        OBAccountAccountSubType = "Savings"
+)
+
+// Defines values for OBAccountAccountType.
+const (
+	Business OBAccountAccountType = "Business"
+	Personal OBAccountAccountType = "Personal"
+)
+
+// Defines values for OBAccountStatus.
+const (
+	OBAccountStatusDeleted  OBAccountStatus = "Deleted"
+	OBAccountStatusDisabled OBAccountStatus = "Disabled"
+	OBAccountStatusEnabled  OBAccountStatus = "Enabled"
+	OBAccountStatusPending  OBAccountStatus = "Pending"
+	OBAccountStatusProForma OBAccountStatus = "ProForma"
+)
+
+// Defines values for OBBalanceType.
+const (
+	ClosingAvailable       OBBalanceType = "ClosingAvailable"
+	ClosingBooked          OBBalanceType = "ClosingBooked"
+	ClosingCleared         OBBalanceType = "ClosingCleared"
+	Expected               OBBalanceType = "Expected"
+	ForwardAvailable       OBBalanceType = "ForwardAvailable"
+	Information            OBBalanceType = "Information"
+	InterimAvailable       OBBalanceType = "InterimAvailable"
+	InterimBooked          OBBalanceType = "InterimBooked"
+	InterimCleared         OBBalanceType = "InterimCleared"
+	OpeningAvailable       OBBalanceType = "OpeningAvailable"
+	OpeningBooked          OBBalanceType = "OpeningBooked"
+	OpeningCleared         OBBalanceType = "OpeningCleared"
+	PreviouslyClosedBooked OBBalanceType = "PreviouslyClosedBooked"
+)
+
+// Defines values for OBCreditDebitCode.
+const (
+	Credit OBCreditDebitCode = "Credit"
+	Debit  OBCreditDebitCode = "Debit"
+)
+
+// Defines values for OBDirectDebitDirectDebitStatusCode.
+const (
+	OBDirectDebitDirectDebitStatusCodeActive   OBDirectDebitDirectDebitStatusCode = "Active"
+	OBDirectDebitDirectDebitStatusCodeInactive OBDirectDebitDirectDebitStatusCode = "Inactive"
+)
+
+// Defines values for OBStandingOrderStandingOrderStatusCode.
+const (
+	OBStandingOrderStandingOrderStatusCodeActive   OBStandingOrderStandingOrderStatusCode = "Active"
+	OBStandingOrderStandingOrderStatusCodeInactive OBStandingOrderStandingOrderStatusCode = "Inactive"
+)
+
+// Defines values for OBTransactionStatus.
+const (
+	OBTransactionStatusBooked   OBTransactionStatus = "Booked"
+	OBTransactionStatusPending  OBTransactionStatus = "Pending"
+	OBTransactionStatusRejected OBTransactionStatus = "Rejected"
+)
+
+// Links defines model for Links.
+type Links struct {
+	First *string `json:"First,omitempty"`
+	Last  *string `json:"Last,omitempty"`
+	Next  *string `json:"Next,omitempty"`
+	Prev  *string `json:"Prev,omitempty"`
+	Self  string  `json:"Self"`
+}
+
+// Meta defines model for Meta.
+type Meta struct {
+	FirstAvailableDateTime *time.Time `json:"FirstAvailableDateTime,omitempty"`
+	LastAvailableDateTime  *time.Time `json:"LastAvailableDateTime,omitempty"`
+	TotalPages             *int32     `json:"TotalPages,omitempty"`
+}
+
+// OBAccount defines model for OBAccount.
+type OBAccount struct {
+	Account        *[]OBCashAccount         `json:"Account,omitempty"`
+	AccountId      string                   `json:"AccountId"`
+	AccountSubType *OBAccountAccountSubType `json:"AccountSubType,omitempty"`
+	AccountType    *OBAccountAccountType    `json:"AccountType,omitempty"`
+	Currency       string                   `json:"Currency"`
+	Description    *string                  `json:"Description,omitempty"`
+	Nickname       *string                  `json:"Nickname,omitempty"`
+	OpeningDate    *openapi_types.Date      `json:"OpeningDate,omitempty"`
+	Status         *OBAccountStatus         `json:"Status,omitempty"`
+}
+
+// OBAccountAccountSubType defines model for OBAccount.AccountSubType.
+type OBAccountAccountSubType string
+
+// OBAccountAccountType defines model for OBAccount.AccountType.
+type OBAccountAccountType string
+
+// OBAccountStatus defines model for OBAccount.Status.
+type OBAccountStatus string
+
+// OBActiveOrHistoricCurrencyAndAmount defines model for OBActiveOrHistoricCurrencyAndAmount.
+type OBActiveOrHistoricCurrencyAndAmount struct {
+	// Amount A decimal amount, without sign.
+	Amount   string `json:"Amount"`
+	Currency string `json:"Currency"`
+}
+
+// OBBalanceType defines model for OBBalanceType.
+type OBBalanceType string
+
+// OBCashAccount defines model for OBCashAccount.
+type OBCashAccount struct {
+	Identification          *string `json:"Identification,omitempty"`
+	Name                    *string `json:"Name,omitempty"`
+	SchemeName              *string `json:"SchemeName,omitempty"`
+	SecondaryIdentification *string `json:"SecondaryIdentification,omitempty"`
+}
+
+// OBCashBalance defines model for OBCashBalance.
+type OBCashBalance struct {
+	AccountId            string                              `json:"AccountId"`
+	Amount               OBActiveOrHistoricCurrencyAndAmount `json:"Amount"`
+	CreditDebitIndicator OBCreditDebitCode                   `json:"CreditDebitIndicator"`
+	DateTime             time.Time                           `json:"DateTime"`
+	Type                 OBBalanceType                       `json:"Type"`
+}
+
+// OBCreditDebitCode defines model for OBCreditDebitCode.
+type OBCreditDebitCode string
+
+// OBDirectDebit defines model for OBDirectDebit.
+type OBDirectDebit struct {
+	AccountId               string                               `json:"AccountId"`
+	DirectDebitId           *string                              `json:"DirectDebitId,omitempty"`
+	DirectDebitStatusCode   *OBDirectDebitDirectDebitStatusCode  `json:"DirectDebitStatusCode,omitempty"`
+	MandateIdentification   string                               `json:"MandateIdentification"`
+	Name                    string                               `json:"Name"`
+	PreviousPaymentAmount   *OBActiveOrHistoricCurrencyAndAmount `json:"PreviousPaymentAmount,omitempty"`
+	PreviousPaymentDateTime *time.Time                           `json:"PreviousPaymentDateTime,omitempty"`
+}
+
+// OBDirectDebitDirectDebitStatusCode defines model for OBDirectDebit.DirectDebitStatusCode.
+type OBDirectDebitDirectDebitStatusCode string
+
+// OBError defines model for OBError.
+type OBError struct {
+	ErrorCode string  `json:"ErrorCode"`
+	Message   string  `json:"Message"`
+	Path      *string `json:"Path,omitempty"`
+}
+
+// OBErrorResponse defines model for OBErrorResponse.
+type OBErrorResponse struct {
+	Code    string    `json:"Code"`
+	Errors  []OBError `json:"Errors"`
+	Id      *string   `json:"Id,omitempty"`
+	Message *string   `json:"Message,omitempty"`
+}
+
+// OBMerchantDetails defines model for OBMerchantDetails.
+type OBMerchantDetails struct {
+	MerchantCategoryCode *string `json:"MerchantCategoryCode,omitempty"`
+	MerchantName         *string `json:"MerchantName,omitempty"`
+}
+
+// OBReadAccount defines model for OBReadAccount.
+type OBReadAccount struct {
+	Data struct {
+		Account *[]OBAccount `json:"Account,omitempty"`
+	} `json:"Data"`
+	Links *Links `json:"Links,omitempty"`
+	Meta  *Meta  `json:"Meta,omitempty"`
+}
+
+// OBReadBalance defines model for OBReadBalance.
+type OBReadBalance struct {
+	Data struct {
+		Balance []OBCashBalance `json:"Balance"`
+	} `json:"Data"`
+	Links *Links `json:"Links,omitempty"`
+	Meta  *Meta  `json:"Meta,omitempty"`
+}
+
+// OBReadDirectDebit defines model for OBReadDirectDebit.
+type OBReadDirectDebit struct {
+	Data struct {
+		DirectDebit *[]OBDirectDebit `json:"DirectDebit,omitempty"`
+	} `json:"Data"`
+	Links *Links `json:"Links,omitempty"`
+	Meta  *Meta  `json:"Meta,omitempty"`
+}
+
+// OBReadStandingOrder defines model for OBReadStandingOrder.
+type OBReadStandingOrder struct {
+	Data struct {
+		StandingOrder *[]OBStandingOrder `json:"StandingOrder,omitempty"`
+	} `json:"Data"`
+	Links *Links `json:"Links,omitempty"`
+	Meta  *Meta  `json:"Meta,omitempty"`
+}
+
+// OBReadTransaction defines model for OBReadTransaction.
+type OBReadTransaction struct {
+	Data struct {
+		Transaction *[]OBTransaction `json:"Transaction,omitempty"`
+	} `json:"Data"`
+	Links *Links `json:"Links,omitempty"`
+	Meta  *Meta  `json:"Meta,omitempty"`
+}
+
+// OBStandingOrder defines model for OBStandingOrder.
+type OBStandingOrder struct {
+	AccountId            string         `json:"AccountId"`
+	CreditorAccount      *OBCashAccount `json:"CreditorAccount,omitempty"`
+	FinalPaymentDateTime *time.Time     `json:"FinalPaymentDateTime,omitempty"`
+	FirstPaymentDateTime *time.Time     `json:"FirstPaymentDateTime,omitempty"`
+
+	// Frequency The frequency, in the form of the OBIE frequency grammar, e.g. IntrvlMnthDay:01:15.
+	Frequency               string                                  `json:"Frequency"`
+	NextPaymentAmount       *OBActiveOrHistoricCurrencyAndAmount    `json:"NextPaymentAmount,omitempty"`
+	NextPaymentDateTime     *time.Time                              `json:"NextPaymentDateTime,omitempty"`
+	Reference               *string                                 `json:"Reference,omitempty"`
+	StandingOrderId         *string                                 `json:"StandingOrderId,omitempty"`
+	StandingOrderStatusCode *OBStandingOrderStandingOrderStatusCode `json:"StandingOrderStatusCode,omitempty"`
+}
+
+// OBStandingOrderStandingOrderStatusCode defines model for OBStandingOrder.StandingOrderStatusCode.
+type OBStandingOrderStandingOrderStatusCode string
+
+// OBTransaction defines model for OBTransaction.
+type OBTransaction struct {
+	AccountId              string                              `json:"AccountId"`
+	Amount                 OBActiveOrHistoricCurrencyAndAmount `json:"Amount"`
+	BookingDateTime        time.Time                           `json:"BookingDateTime"`
+	CreditDebitIndicator   OBCreditDebitCode                   `json:"CreditDebitIndicator"`
+	CreditorAccount        *OBCashAccount                      `json:"CreditorAccount,omitempty"`
+	DebtorAccount          *OBCashAccount                      `json:"DebtorAccount,omitempty"`
+	MerchantDetails        *OBMerchantDetails                  `json:"MerchantDetails,omitempty"`
+	Status                 OBTransactionStatus                 `json:"Status"`
+	TransactionId          *string                             `json:"TransactionId,omitempty"`
+	TransactionInformation *string                             `json:"TransactionInformation,omitempty"`
+	TransactionReference   *string                             `json:"TransactionReference,omitempty"`
+	ValueDateTime          *time.Time                          `json:"ValueDateTime,omitempty"`
+}
+
+// OBTransactionStatus defines model for OBTransaction.Status.
+type OBTransactionStatus string
+
+// AccountId defines model for AccountId.
+type AccountId = string
+
+// FinancialID defines model for FinancialID.
+type FinancialID = string
+
+// InteractionID defines model for InteractionID.
+type InteractionID = string
+
+// Error defines model for Error.
+type Error = OBErrorResponse
+
+// GetAccountsParams defines parameters for GetAccounts.
+type GetAccountsParams struct {
+	// XFapiFinancialId The unique identifier of the ASPSP, required by some ASPSPs.
+	XFapiFinancialId *FinancialID `json:"x-fapi-financial-id,omitempty"`
+
+	// XFapiInteractionId An RFC 4122 UUID used as a correlation ID.
+	XFapiInteractionId *InteractionID `json:"x-fapi-interaction-id,omitempty"`
+}
+
+// GetAccountBalancesParams defines parameters for GetAccountBalances.
+type GetAccountBalancesParams struct {
+	// XFapiFinancialId The unique identifier of the ASPSP, required by some ASPSPs.
+	XFapiFinancialId *FinancialID `json:"x-fapi-financial-id,omitempty"`
+
+	// XFapiInteractionId An RFC 4122 UUID used as a correlation ID.
+	XFapiInteractionId *InteractionID `json:"x-fapi-interaction-id,omitempty"`
+}
+
+// GetAccountDirectDebitsParams defines parameters for GetAccountDirectDebits.
+type GetAccountDirectDebitsParams struct {
+	// XFapiFinancialId The unique identifier of the ASPSP, required by some ASPSPs.
+	XFapiFinancialId *FinancialID `json:"x-fapi-financial-id,omitempty"`
+
+	// XFapiInteractionId An RFC 4122 UUID used as a correlation ID.
+	XFapiInteractionId *InteractionID `json:"x-fapi-interaction-id,omitempty"`
+}
+
+// GetAccountStandingOrdersParams defines parameters for GetAccountStandingOrders.
+type GetAccountStandingOrdersParams struct {
+	// XFapiFinancialId The unique identifier of the ASPSP, required by some ASPSPs.
+	XFapiFinancialId *FinancialID `json:"x-fapi-financial-id,omitempty"`
+
+	// XFapiInteractionId An RFC 4122 UUID used as a correlation ID.
+	XFapiInteractionId *InteractionID `json:"x-fapi-interaction-id,omitempty"`
+}
+
+// GetAccountTransactionsParams defines parameters for GetAccountTransactions.
+type GetAccountTransactionsParams struct {
+	FromBookingDateTime *time.Time `form:"fromBookingDateTime,omitempty" json:"fromBookingDateTime,omitempty"`
+	ToBookingDateTime   *time.Time `form:"toBookingDateTime,omitempty" json:"toBookingDateTime,omitempty"`
+
+	// XFapiFinancialId The unique identifier of the ASPSP, required by some ASPSPs.
+	XFapiFinancialId *FinancialID `json:"x-fapi-financial-id,omitempty"`
+
+	// XFapiInteractionId An RFC 4122 UUID used as a correlation ID.
+	XFapiInteractionId *InteractionID `json:"x-fapi-interaction-id,omitempty"`
+}
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetAccounts request
+	GetAccounts(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountBalances request
+	GetAccountBalances(ctx context.Context, accountId AccountId, params *GetAccountBalancesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountDirectDebits request
+	GetAccountDirectDebits(ctx context.Context, accountId AccountId, params *GetAccountDirectDebitsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountStandingOrders request
+	GetAccountStandingOrders(ctx context.Context, accountId AccountId, params *GetAccountStandingOrdersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccountTransactions request
+	GetAccountTransactions(ctx context.Context, accountId AccountId, params *GetAccountTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetAccounts(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountBalances(ctx context.Context, accountId AccountId, params *GetAccountBalancesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountBalancesRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountDirectDebits(ctx context.Context, accountId AccountId, params *GetAccountDirectDebitsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountDirectDebitsRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountStandingOrders(ctx context.Context, accountId AccountId, params *GetAccountStandingOrdersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountStandingOrdersRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccountTransactions(ctx context.Context, accountId AccountId, params *GetAccountTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountTransactionsRequest(c.Server, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetAccountsRequest generates requests for GetAccounts
+func NewGetAccountsRequest(server string, params *GetAccountsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFapiFinancialId != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-financial-id", runtime.ParamLocationHeader, *params.XFapiFinancialId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-financial-id", headerParam0)
+		}
+
+		if params.XFapiInteractionId != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-interaction-id", runtime.ParamLocationHeader, *params.XFapiInteractionId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-interaction-id", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetAccountBalancesRequest generates requests for GetAccountBalances
+func NewGetAccountBalancesRequest(server string, accountId AccountId, params *GetAccountBalancesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "AccountId", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/balances", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFapiFinancialId != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-financial-id", runtime.ParamLocationHeader, *params.XFapiFinancialId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-financial-id", headerParam0)
+		}
+
+		if params.XFapiInteractionId != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-interaction-id", runtime.ParamLocationHeader, *params.XFapiInteractionId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-interaction-id", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetAccountDirectDebitsRequest generates requests for GetAccountDirectDebits
+func NewGetAccountDirectDebitsRequest(server string, accountId AccountId, params *GetAccountDirectDebitsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "AccountId", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/direct-debits", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFapiFinancialId != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-financial-id", runtime.ParamLocationHeader, *params.XFapiFinancialId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-financial-id", headerParam0)
+		}
+
+		if params.XFapiInteractionId != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-interaction-id", runtime.ParamLocationHeader, *params.XFapiInteractionId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-interaction-id", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetAccountStandingOrdersRequest generates requests for GetAccountStandingOrders
+func NewGetAccountStandingOrdersRequest(server string, accountId AccountId, params *GetAccountStandingOrdersParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "AccountId", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/standing-orders", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFapiFinancialId != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-financial-id", runtime.ParamLocationHeader, *params.XFapiFinancialId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-financial-id", headerParam0)
+		}
+
+		if params.XFapiInteractionId != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-interaction-id", runtime.ParamLocationHeader, *params.XFapiInteractionId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-interaction-id", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetAccountTransactionsRequest generates requests for GetAccountTransactions
+func NewGetAccountTransactionsRequest(server string, accountId AccountId, params *GetAccountTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "AccountId", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/accounts/%s/transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.FromBookingDateTime != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "fromBookingDateTime", runtime.ParamLocationQuery, *params.FromBookingDateTime); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ToBookingDateTime != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "toBookingDateTime", runtime.ParamLocationQuery, *params.ToBookingDateTime); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.XFapiFinancialId != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-financial-id", runtime.ParamLocationHeader, *params.XFapiFinancialId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-financial-id", headerParam0)
+		}
+
+		if params.XFapiInteractionId != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "x-fapi-interaction-id", runtime.ParamLocationHeader, *params.XFapiInteractionId)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("x-fapi-interaction-id", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetAccountsWithResponse request
+	GetAccountsWithResponse(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsResponse, error)
+
+	// GetAccountBalancesWithResponse request
+	GetAccountBalancesWithResponse(ctx context.Context, accountId AccountId, params *GetAccountBalancesParams, reqEditors ...RequestEditorFn) (*GetAccountBalancesResponse, error)
+
+	// GetAccountDirectDebitsWithResponse request
+	GetAccountDirectDebitsWithResponse(ctx context.Context, accountId AccountId, params *GetAccountDirectDebitsParams, reqEditors ...RequestEditorFn) (*GetAccountDirectDebitsResponse, error)
+
+	// GetAccountStandingOrdersWithResponse request
+	GetAccountStandingOrdersWithResponse(ctx context.Context, accountId AccountId, params *GetAccountStandingOrdersParams, reqEditors ...RequestEditorFn) (*GetAccountStandingOrdersResponse, error)
+
+	// GetAccountTransactionsWithResponse request
+	GetAccountTransactionsWithResponse(ctx context.Context, accountId AccountId, params *GetAccountTransactionsParams, reqEditors ...RequestEditorFn) (*GetAccountTransactionsResponse, error)
+}
+
+type GetAccountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OBReadAccount
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountBalancesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OBReadBalance
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountBalancesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountBalancesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountDirectDebitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OBReadDirectDebit
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountDirectDebitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountDirectDebitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountStandingOrdersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OBReadStandingOrder
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountStandingOrdersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountStandingOrdersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *OBReadTransaction
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON429      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetAccountsWithResponse request returning *GetAccountsResponse
+func (c *ClientWithResponses) GetAccountsWithResponse(ctx context.Context, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsResponse, error) {
+	rsp, err := c.GetAccounts(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountsResponse(rsp)
+}
+
+// GetAccountBalancesWithResponse request returning *GetAccountBalancesResponse
+func (c *ClientWithResponses) GetAccountBalancesWithResponse(ctx context.Context, accountId AccountId, params *GetAccountBalancesParams, reqEditors ...RequestEditorFn) (*GetAccountBalancesResponse, error) {
+	rsp, err := c.GetAccountBalances(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountBalancesResponse(rsp)
+}
+
+// GetAccountDirectDebitsWithResponse request returning *GetAccountDirectDebitsResponse
+func (c *ClientWithResponses) GetAccountDirectDebitsWithResponse(ctx context.Context, accountId AccountId, params *GetAccountDirectDebitsParams, reqEditors ...RequestEditorFn) (*GetAccountDirectDebitsResponse, error) {
+	rsp, err := c.GetAccountDirectDebits(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountDirectDebitsResponse(rsp)
+}
+
+// GetAccountStandingOrdersWithResponse request returning *GetAccountStandingOrdersResponse
+func (c *ClientWithResponses) GetAccountStandingOrdersWithResponse(ctx context.Context, accountId AccountId, params *GetAccountStandingOrdersParams, reqEditors ...RequestEditorFn) (*GetAccountStandingOrdersResponse, error) {
+	rsp, err := c.GetAccountStandingOrders(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountStandingOrdersResponse(rsp)
+}
+
+// GetAccountTransactionsWithResponse request returning *GetAccountTransactionsResponse
+func (c *ClientWithResponses) GetAccountTransactionsWithResponse(ctx context.Context, accountId AccountId, params *GetAccountTransactionsParams, reqEditors ...RequestEditorFn) (*GetAccountTransactionsResponse, error) {
+	rsp, err := c.GetAccountTransactions(ctx, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountTransactionsResponse(rsp)
+}
+
+// ParseGetAccountsResponse parses an HTTP response from a GetAccountsWithResponse call
+func ParseGetAccountsResponse(rsp *http.Response) (*GetAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OBReadAccount
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountBalancesResponse parses an HTTP response from a GetAccountBalancesWithResponse call
+func ParseGetAccountBalancesResponse(rsp *http.Response) (*GetAccountBalancesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountBalancesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OBReadBalance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountDirectDebitsResponse parses an HTTP response from a GetAccountDirectDebitsWithResponse call
+func ParseGetAccountDirectDebitsResponse(rsp *http.Response) (*GetAccountDirectDebitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountDirectDebitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OBReadDirectDebit
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountStandingOrdersResponse parses an HTTP response from a GetAccountStandingOrdersWithResponse call
+func ParseGetAccountStandingOrdersResponse(rsp *http.Response) (*GetAccountStandingOrdersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountStandingOrdersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OBReadStandingOrder
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountTransactionsResponse parses an HTTP response from a GetAccountTransactionsWithResponse call
+func ParseGetAccountTransactionsResponse(rsp *http.Response) (*GetAccountTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest OBReadTransaction
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	}
+
+	return response, nil
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+xaW2/bOhL+KwS3D+cA8i1pH9ZvcZycNdo0RpzswzZegJbGNhuZVEnKjZH1f1+MqAtt",
+	"U740PQctTt8scmY4l29GnJFfaCgXiRQgjKbdF5owxRZgQGVPF2EoU2EGET5wQbs0YWZOAyrYAmjX2Q+o",
+	"gi8pVxDRrlEpBFSHc1gwZDSrBIm1UVzM6Hod0GsumAg5iwd9JIhAh4onhks84n4OJBX8SwqERyAMn3JQ",
+	"RE6JmQO5GA1Hw4AUh5HJimi5yNd1kwZWzTmwCFSl6HNjyhLemBbnNnhE96s4EAYUC1Enn5IXgtxdX5K3",
+	"nbMz8vAw6JNUQ0SYJoyEUimIGRKSQf+QSrw655BSa3SyTqTQkEXnSimp8EcohQFh8CdLkpiH2eGtzxpV",
+	"fXEkvlEwpV36j1YV9Jbd1a3bXibvLj/BnrdptD0Q13MmlPmBi6fsR6JkAspwq9w1V9p4rAjoB1az8RGe",
+	"/RtDBUvvxgjiqT98FRo/WapxUFDJyWcIDbLfgGE1ml8sGY/ZJIY+M3DPMWIvdCrVghnapREz0DC4Gvjt",
+	"ewX7vTQsHrIZ6A0eLsz5WUWPsJmBDcaOXbe9PDF3jXM2uIGFPoyKS6bnBVd1GFOKrfB5o0TsGJPvjtLJ",
+	"fbb1QkGkCwzK5ZypGVwyhZi/VBBxUzykSoEwxZkBvbqRAlY0oB8kEzSgN1KZGZuh94YKhoxHOeeILbmY",
+	"aSfWO5psq9FLNRegNcoCpaVgsZfdKhWuvFb23SzxAZuHTzbrPZu3CQguZgiUHZD48DEyzKTataEPMRhA",
+	"B/S5RtDhzytR/BqCiJAXvSWvUbzHwq2UcQt7afq4BmqGL+FW/YtrIxUPC/ILEV0sakBYrm8VVRJByBcs",
+	"JiyjCMhXbuYyNUTzmcBKCs9skcSoQqfdfNemAb6QDCjk/u/jY/TSCTrn6zf/q34/Pjbt73frN/RAYCvp",
+	"f/SGm7I/XTT+M345D859Uradt8iBe8BzPRYzEcJOZsRSczErSwgNiqWelE8QVc+XMTBlo/2cQGgxcC3V",
+	"V6Yil30gLKrQy/mbjS82CbKlUn7+XMnPQery5EslT/5c8WDV5jLV8QrVhSin9GXXZpXZwcsgvwbY95o/",
+	"xerSa4SFDIrtKsIP75u3vcFVcySVuZQR5Id/TBeT7C29KwhCKSKmVge18ZdktDCPeG1ZrquiZb7sL9WH",
+	"U3Fd1No+TLgZiAitkOqwaIcLvZVVvdNfbTnS9x/lpsW+ulSlmc+i/DRHTX8SbhvmJmK2hSJwtwa4fa4g",
+	"tPynhtVhPUxhy/62ijbiWcYy+9On5Q0TGJfXpFGRzEO2WuDL+Xsickv2qbjaAxG/4bmZfjyUl+rNSGbL",
+	"pfd3qsgdaJmqEJofpbmWqYh88L8Brdmsxr/YVx28yVZaVNL2mFHe5XfMKSzZ0SPj0ydcD/OeYPdiWAPp",
+	"eids2ZqbmSvkt/IGVDhnwvTBMB57upCC4JIZmEm1qrW7IKxJAH9JvwMW1b60+szXXJx+/669e/t0Kpux",
+	"fTItkdP/7CPOaLZjk9k2rvVJ7WvO7xOH/ISepODy+cXVtaAb/7Du2vsK8btsi+VIt7lcPxGcRoZlHcyt",
+	"ikAd66EdpiN9tMn3E3npXjGh7SDpWB9tsRzpIZfrJ/HPAQTtv6bZa6BUTuk+aWKCw874G+82gZ1GfTs3",
+	"Oqhob3fHrNNiOyBcZPNVFFvMWvFmU5GQmWKLBVMBgeasSQbCqGV8I8y8z1bddqfbebfZoHsIaOAf+v0Z",
+	"l0pH7uluu4MpoMialtJF0yA6TPPtd/c9d9sqtH7M760Hf2G/iW1/Pt86LQjfrVF9bQL3YfIKbs8ddT//",
+	"NoN35leOXarp3h18tjMgXwvooKEm6C6FMy86QLo/U/7N4hS+Yz93qOXP/bQLut0UWQdUQ5gqblZ2RJS5",
+	"dwJMgbpIbTOWxQSZ7HKl6tyYxH4g4WIqd2srzsIuhgOiEwjLvhNra1ZXdTrRYIoq+/CeIDnpMYEqk9+w",
+	"7P5OcrOJEwsyArXkIWiCspfnzc6jyD47TVakl0azBjdNcrUEtbKfw8hvEyaeficcKzIWQhSfKYDFiamI",
+	"aFBL0LimgeCnPU1SEYEi3GgivwoyYRrIw92H5qN4FNdSkYVUQLij05JrbroEPaK7rZZMQEysJelTc8bN",
+	"PJ00uWwpYFHjq+IGGvjVS3MD5y10KDd5I73phcJ8JiLiwA0tpwFdgtLW0+fNTrPTRqzhySzh2Vq72bHj",
+	"23kW1Raz0rKHGWQ5jNWQFelA/4Die4OmwcZH0E/+dK1IWu7HzHVwkHzzw+J6vPVR76zd/o6f9NwO1fNB",
+	"7/Y9eu5tu10nqVStVfb5b9udk6jPT6E+++fR1JjAKV5IVjZ+GbKLQGcPIdILgxcXXGJhCFoTI2lADZtp",
+	"p7hoOkZ5JU5aL2XVWbcmtoE8Bjy9gvRUDJXHHYOgHx1wZWf+EwCu/favhGcBJaz9TBRodfBYAmgPHqOs",
+	"i29E+PI7BpRO1/83B+bG/OMXOLfAaXFFLK5qEWp9SHI47YGpzjughsQW6BigbvRMf3Oobo2hfoF1C6wF",
+	"uohFVy1cCz+SHFR7AGuqa6aL1k2334FOY7xMKLwwz7hgBqIgU0nAs8E1IBPAE2MuniAiUyUXJJtuNXEm",
+	"gUOSugS4dzX4geEf5H8G/JKCWlX/aENLtxsv9/9sxzV/ftlGvlryn5+0G5PRXym7lbJuftXm60YKjNdu",
+	"m54lgdugfxpjTLMeNk+RVMW0S7MmtJF3oS3sk1uM64Sux+v/DwAuYNe+7yoAAA==",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
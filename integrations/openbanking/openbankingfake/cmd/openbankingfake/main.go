@@ -0,0 +1,28 @@
+// Command openbankingfake serves a fake Open Banking ASPSP seeded with demo data, so Budg-it can be demoed without
+// a bank.
+//
+// Point an Open Banking integration at it, with any API token:
+//
+//	go run ./integrations/openbanking/openbankingfake/cmd/openbankingfake -addr localhost:8083
+//	INTEGRATIONS=bank BANK_TYPE=openbanking BANK_URL=http://localhost:8083 BANK_API_TOKEN=demo go run .
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/openbanking/openbankingfake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8083", "address to listen on")
+	flag.Parse()
+
+	server := openbankingfake.New()
+	server.SeedDemo(time.Now())
+
+	log.Printf("Serving fake Open Banking API on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
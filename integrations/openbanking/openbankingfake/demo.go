@@ -0,0 +1,53 @@
+package openbankingfake
+
+import (
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/openbanking"
+)
+
+// SeedDemo seeds the Server with a current account and a savings account, each with a month of transactions
+// before now, and the standing orders and direct debits of the current account.
+func (s *Server) SeedDemo(now time.Time) {
+	current := s.AddAccount(Account{
+		ID:               "22289",
+		Nickname:         "Bills",
+		SubType:          openbanking.CurrentAccount,
+		BookedBalance:    123456,
+		AvailableBalance: 122556,
+	})
+	savings := s.AddAccount(Account{
+		ID:               "31820",
+		Nickname:         "Rainy Day",
+		SubType:          openbanking.Savings,
+		BookedBalance:    500000,
+		AvailableBalance: 500000,
+	})
+	s.AddAccount(Account{
+		ID:       "40011",
+		Nickname: "Closed Account",
+		Status:   openbanking.OBAccountStatusDisabled,
+	})
+
+	day := func(daysAgo int) time.Time {
+		return now.AddDate(0, 0, -daysAgo).Truncate(time.Hour)
+	}
+	s.AddTransactions(current.ID,
+		Transaction{Amount: 210000, CounterpartyName: "Employer Ltd", Reference: "SALARY", Information: "BGC EMPLOYER LTD", BookingTime: day(28)},
+		Transaction{Amount: -95000, CounterpartyName: "Landlord", Reference: "RENT", Information: "SO LANDLORD", BookingTime: day(27)},
+		Transaction{Amount: -4599, MerchantName: "Supermarket", Information: "CARD PAYMENT SUPERMARKET", BookingTime: day(12)},
+		Transaction{Amount: -5400, CounterpartyName: "Energy Co", Reference: "ENERGY-123", Information: "DD ENERGY CO", BookingTime: day(8)},
+		Transaction{Amount: -900, MerchantName: "Coffee Shop", Information: "CARD PAYMENT COFFEE SHOP", BookingTime: day(0), Pending: true},
+	)
+	s.AddTransactions(savings.ID,
+		Transaction{Amount: 25000, CounterpartyName: "Bills", Reference: "SAVINGS", Information: "TFR FROM BILLS", BookingTime: day(20)},
+		Transaction{Amount: 312, Information: "INTEREST", BookingTime: day(1)},
+	)
+	s.AddStandingOrders(current.ID,
+		StandingOrder{Reference: "RENT", CreditorName: "Landlord", Amount: 95000, NextPayment: day(-3), Frequency: "IntrvlMnthDay:01:01"},
+		StandingOrder{Reference: "GYM", CreditorName: "Old Gym", Amount: 3000, NextPayment: day(-10), Inactive: true},
+	)
+	s.AddDirectDebits(current.ID,
+		DirectDebit{Mandate: "ENERGY-123", Name: "Energy Co", PreviousAmount: 5400, PreviousPayment: day(8)},
+	)
+}
@@ -0,0 +1,522 @@
+// Package openbankingfake is an in-process mock ASPSP (bank) serving the UK Open Banking Account Information API,
+// for tests and demos without a live bank. It serves the generated openbanking types, seeded with accounts,
+// balances, transactions, standing orders and direct debits, and can be made to fail requests.
+package openbankingfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/openbanking"
+)
+
+// Account is an account to seed the Server with.
+type Account struct {
+	ID       string
+	Nickname string
+	SubType  openbanking.OBAccountAccountSubType
+	Currency string
+	Status   openbanking.OBAccountStatus
+	// BookedBalance and AvailableBalance are served as the InterimBooked and InterimAvailable balances.
+	BookedBalance    int64
+	AvailableBalance int64
+}
+
+// Transaction is a transaction of an account, to seed the Server with.
+// Amount is signed, negative amounts being debits.
+type Transaction struct {
+	ID               string
+	Amount           int64
+	Reference        string
+	Information      string
+	MerchantName     string
+	CounterpartyName string
+	BookingTime      time.Time
+	Pending          bool
+}
+
+// StandingOrder is a standing order of an account, to seed the Server with.
+type StandingOrder struct {
+	ID           string
+	Frequency    string
+	Reference    string
+	CreditorName string
+	NextPayment  time.Time
+	Amount       int64
+	Inactive     bool
+}
+
+// DirectDebit is a direct debit mandate of an account, to seed the Server with.
+type DirectDebit struct {
+	ID              string
+	Mandate         string
+	Name            string
+	PreviousPayment time.Time
+	PreviousAmount  int64
+	Inactive        bool
+}
+
+// Failure is a failure injected into the Server, returned instead of the normal response.
+type Failure struct {
+	// Method and PathPrefix restrict the requests that fail. Empty values match all requests.
+	Method     string
+	PathPrefix string
+	StatusCode int
+	Header     http.Header
+	Body       string
+	// Times is the number of matching requests that fail. Zero fails every matching request.
+	Times int
+}
+
+type account struct {
+	Account
+	transactions   []Transaction
+	standingOrders []StandingOrder
+	directDebits   []DirectDebit
+}
+
+// Server is a mock ASPSP. It is safe for concurrent use.
+type Server struct {
+	mux *http.ServeMux
+
+	mu          sync.Mutex
+	accounts    []*account
+	failures    []*Failure
+	accessToken string
+	financialID string
+	pageSize    int
+	issuedIDs   int
+	requests    []string
+}
+
+// New returns an empty Server, which accepts requests with any access token until SetCredentials is called.
+// Transactions are served in pages of 25.
+func New() *Server {
+	s := &Server{
+		mux:      http.NewServeMux(),
+		pageSize: 25,
+	}
+	s.mux.HandleFunc("GET /accounts", s.authenticated(s.handleAccounts))
+	s.mux.HandleFunc("GET /accounts/{accountID}/balances", s.authenticated(s.handleBalances))
+	s.mux.HandleFunc("GET /accounts/{accountID}/transactions", s.authenticated(s.handleTransactions))
+	s.mux.HandleFunc("GET /accounts/{accountID}/standing-orders", s.authenticated(s.handleStandingOrders))
+	s.mux.HandleFunc("GET /accounts/{accountID}/direct-debits", s.authenticated(s.handleDirectDebits))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	failure := s.takeFailure(r)
+	s.mu.Unlock()
+
+	if failure != nil {
+		for key, values := range failure.Header {
+			w.Header()[key] = values
+		}
+		if strings.HasPrefix(failure.Body, "{") {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(failure.StatusCode)
+		w.Write([]byte(failure.Body))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetCredentials requires requests to carry the given access token.
+func (s *Server) SetCredentials(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken = accessToken
+}
+
+// SetFinancialID requires requests to carry the given x-fapi-financial-id header.
+func (s *Server) SetFinancialID(financialID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.financialID = financialID
+}
+
+// SetPageSize sets the number of transactions served in each page.
+func (s *Server) SetPageSize(pageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = pageSize
+}
+
+// AddAccount seeds an account. A zero ID is replaced with a generated one.
+func (s *Server) AddAccount(a Account) Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.ID == "" {
+		a.ID = s.newID("acc")
+	}
+	if a.SubType == "" {
+		a.SubType = openbanking.CurrentAccount
+	}
+	if a.Currency == "" {
+		a.Currency = "GBP"
+	}
+	if a.Status == "" {
+		a.Status = openbanking.OBAccountStatusEnabled
+	}
+	s.accounts = append(s.accounts, &account{Account: a})
+	return a
+}
+
+// AddTransactions seeds transactions into an account. A zero ID is replaced with a generated one.
+// Transactions are served most recent first, whatever order they are seeded in.
+func (s *Server) AddTransactions(accountID string, transactions ...Transaction) []Transaction {
+	s.withAccount(accountID, func(a *account) {
+		for i := range transactions {
+			if transactions[i].ID == "" {
+				transactions[i].ID = s.newID("txn")
+			}
+		}
+		a.transactions = append(a.transactions, transactions...)
+		slices.SortStableFunc(a.transactions, func(a, b Transaction) int { return b.BookingTime.Compare(a.BookingTime) })
+	})
+	return transactions
+}
+
+// AddStandingOrders seeds standing orders into an account. A zero ID is replaced with a generated one.
+func (s *Server) AddStandingOrders(accountID string, orders ...StandingOrder) []StandingOrder {
+	s.withAccount(accountID, func(a *account) {
+		for i := range orders {
+			if orders[i].ID == "" {
+				orders[i].ID = s.newID("so")
+			}
+			if orders[i].Frequency == "" {
+				orders[i].Frequency = "IntrvlMnthDay:01:01"
+			}
+		}
+		a.standingOrders = append(a.standingOrders, orders...)
+	})
+	return orders
+}
+
+// AddDirectDebits seeds direct debits into an account. A zero ID is replaced with a generated one.
+func (s *Server) AddDirectDebits(accountID string, debits ...DirectDebit) []DirectDebit {
+	s.withAccount(accountID, func(a *account) {
+		for i := range debits {
+			if debits[i].ID == "" {
+				debits[i].ID = s.newID("dd")
+			}
+		}
+		a.directDebits = append(a.directDebits, debits...)
+	})
+	return debits
+}
+
+// InjectFailure makes matching requests fail, in the order failures were injected.
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the method and path of every request received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// newID returns a new ID with the given prefix. It must be called with mu held.
+func (s *Server) newID(prefix string) string {
+	s.issuedIDs++
+	return fmt.Sprintf("%s-%06d", prefix, s.issuedIDs)
+}
+
+func (s *Server) withAccount(accountID string, f func(a *account)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.accounts {
+		if a.ID == accountID {
+			f(a)
+			return
+		}
+	}
+	panic(fmt.Sprintf("openbankingfake: account %s has not been seeded", accountID))
+}
+
+// takeFailure returns the first Failure matching the request, if any. It must be called with mu held.
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if failure.Method != "" && failure.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, failure.PathPrefix) {
+			continue
+		}
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = slices.Delete(s.failures, i, i+1)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		accessToken, financialID := s.accessToken, s.financialID
+		s.mu.Unlock()
+
+		if accessToken != "" && r.Header.Get("Authorization") != "Bearer "+accessToken {
+			writeError(w, http.StatusUnauthorized, "UK.OBIE.Unauthorized", "The access token is invalid")
+			return
+		}
+		if financialID != "" && r.Header.Get("x-fapi-financial-id") != financialID {
+			writeError(w, http.StatusForbidden, "UK.OBIE.Header.Invalid", "x-fapi-financial-id is invalid")
+			return
+		}
+		if interactionID := r.Header.Get("x-fapi-interaction-id"); interactionID != "" {
+			w.Header().Set("x-fapi-interaction-id", interactionID)
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := make([]openbanking.OBAccount, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		accounts = append(accounts, openbanking.OBAccount{
+			AccountId:      a.ID,
+			Status:         &a.Status,
+			Currency:       a.Currency,
+			AccountType:    ptr(openbanking.Personal),
+			AccountSubType: &a.SubType,
+			Nickname:       &a.Nickname,
+			Account: &[]openbanking.OBCashAccount{{
+				SchemeName:     ptr("UK.OBIE.SortCodeAccountNumber"),
+				Identification: ptr("00000012345678"),
+				Name:           &a.Nickname,
+			}},
+		})
+	}
+	resp := openbanking.OBReadAccount{Links: selfLinks(r)}
+	resp.Data.Account = &accounts
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleBalances(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		now := time.Now().UTC().Truncate(time.Second)
+		balance := func(balanceType openbanking.OBBalanceType, minorUnits int64) openbanking.OBCashBalance {
+			amount, indicator := toAmount(a.Currency, minorUnits)
+			return openbanking.OBCashBalance{
+				AccountId:            a.ID,
+				Amount:               amount,
+				CreditDebitIndicator: indicator,
+				Type:                 balanceType,
+				DateTime:             now,
+			}
+		}
+		resp := openbanking.OBReadBalance{Links: selfLinks(r)}
+		resp.Data.Balance = []openbanking.OBCashBalance{
+			balance(openbanking.InterimBooked, a.BookedBalance),
+			balance(openbanking.InterimAvailable, a.AvailableBalance),
+		}
+		return resp
+	})
+}
+
+func (s *Server) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var from, to time.Time
+	for name, t := range map[string]*time.Time{"fromBookingDateTime": &from, "toBookingDateTime": &to} {
+		if !query.Has(name) {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, query.Get(name))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "UK.OBIE.Field.InvalidDate", fmt.Sprintf("invalid %s: %s", name, err))
+			return
+		}
+		*t = parsed
+	}
+	page := 1
+	if query.Has("page") {
+		parsed, err := strconv.Atoi(query.Get("page"))
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "UK.OBIE.Field.Invalid", "invalid page")
+			return
+		}
+		page = parsed
+	}
+
+	s.serveAccount(w, r, func(a *account) any {
+		matching := make([]Transaction, 0, len(a.transactions))
+		for _, t := range a.transactions {
+			if (from.IsZero() || !t.BookingTime.Before(from)) && (to.IsZero() || !t.BookingTime.After(to)) {
+				matching = append(matching, t)
+			}
+		}
+
+		start, end := min((page-1)*s.pageSize, len(matching)), min(page*s.pageSize, len(matching))
+		transactions := make([]openbanking.OBTransaction, 0, end-start)
+		for _, t := range matching[start:end] {
+			transactions = append(transactions, toTransaction(a, t))
+		}
+
+		resp := openbanking.OBReadTransaction{Links: selfLinks(r)}
+		resp.Data.Transaction = &transactions
+		if end < len(matching) {
+			next := *r.URL
+			nextQuery := next.Query()
+			nextQuery.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = nextQuery.Encode()
+			resp.Links.Next = ptr(absoluteURL(r, next.RequestURI()))
+		}
+		return resp
+	})
+}
+
+func (s *Server) handleStandingOrders(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		orders := make([]openbanking.OBStandingOrder, 0, len(a.standingOrders))
+		for _, order := range a.standingOrders {
+			amount, _ := toAmount(a.Currency, order.Amount)
+			status := openbanking.OBStandingOrderStandingOrderStatusCodeActive
+			if order.Inactive {
+				status = openbanking.OBStandingOrderStandingOrderStatusCodeInactive
+			}
+			orders = append(orders, openbanking.OBStandingOrder{
+				AccountId:               a.ID,
+				StandingOrderId:         &order.ID,
+				Frequency:               order.Frequency,
+				Reference:               &order.Reference,
+				NextPaymentDateTime:     &order.NextPayment,
+				NextPaymentAmount:       &amount,
+				StandingOrderStatusCode: &status,
+				CreditorAccount:         &openbanking.OBCashAccount{Name: &order.CreditorName},
+			})
+		}
+		resp := openbanking.OBReadStandingOrder{Links: selfLinks(r)}
+		resp.Data.StandingOrder = &orders
+		return resp
+	})
+}
+
+func (s *Server) handleDirectDebits(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		debits := make([]openbanking.OBDirectDebit, 0, len(a.directDebits))
+		for _, debit := range a.directDebits {
+			amount, _ := toAmount(a.Currency, debit.PreviousAmount)
+			status := openbanking.OBDirectDebitDirectDebitStatusCodeActive
+			if debit.Inactive {
+				status = openbanking.OBDirectDebitDirectDebitStatusCodeInactive
+			}
+			debits = append(debits, openbanking.OBDirectDebit{
+				AccountId:               a.ID,
+				DirectDebitId:           &debit.ID,
+				MandateIdentification:   debit.Mandate,
+				DirectDebitStatusCode:   &status,
+				Name:                    debit.Name,
+				PreviousPaymentDateTime: &debit.PreviousPayment,
+				PreviousPaymentAmount:   &amount,
+			})
+		}
+		resp := openbanking.OBReadDirectDebit{Links: selfLinks(r)}
+		resp.Data.DirectDebit = &debits
+		return resp
+	})
+}
+
+// serveAccount writes the response built from the account in the path, or 404 Not Found if it is missing.
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, respond func(a *account) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		if a.ID == r.PathValue("accountID") {
+			writeJSON(w, respond(a))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "UK.OBIE.Resource.NotFound", "account not found")
+}
+
+func toTransaction(a *account, t Transaction) openbanking.OBTransaction {
+	amount, indicator := toAmount(a.Currency, t.Amount)
+	status := openbanking.OBTransactionStatusBooked
+	if t.Pending {
+		status = openbanking.OBTransactionStatusPending
+	}
+	transaction := openbanking.OBTransaction{
+		AccountId:              a.ID,
+		TransactionId:          &t.ID,
+		TransactionReference:   &t.Reference,
+		Amount:                 amount,
+		CreditDebitIndicator:   indicator,
+		Status:                 status,
+		BookingDateTime:        t.BookingTime,
+		ValueDateTime:          &t.BookingTime,
+		TransactionInformation: &t.Information,
+	}
+	if t.MerchantName != "" {
+		transaction.MerchantDetails = &openbanking.OBMerchantDetails{MerchantName: &t.MerchantName}
+	}
+	if t.CounterpartyName != "" {
+		counterparty := &openbanking.OBCashAccount{Name: &t.CounterpartyName}
+		if indicator == openbanking.Credit {
+			transaction.DebtorAccount = counterparty
+		} else {
+			transaction.CreditorAccount = counterparty
+		}
+	}
+	return transaction
+}
+
+// toAmount returns the unsigned decimal amount and credit or debit indicator of a signed amount in minor units.
+func toAmount(currency string, minorUnits int64) (openbanking.OBActiveOrHistoricCurrencyAndAmount, openbanking.OBCreditDebitCode) {
+	indicator := openbanking.Credit
+	if minorUnits < 0 {
+		indicator, minorUnits = openbanking.Debit, -minorUnits
+	}
+	return openbanking.OBActiveOrHistoricCurrencyAndAmount{
+		Amount:   fmt.Sprintf("%d.%02d", minorUnits/100, minorUnits%100),
+		Currency: currency,
+	}, indicator
+}
+
+func selfLinks(r *http.Request) *openbanking.Links {
+	return &openbanking.Links{Self: absoluteURL(r, r.URL.RequestURI())}
+}
+
+func absoluteURL(r *http.Request, requestURI string) string {
+	return "http://" + r.Host + requestURI
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(openbanking.OBErrorResponse{
+		Code:   strconv.Itoa(statusCode),
+		Errors: []openbanking.OBError{{ErrorCode: code, Message: message}},
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
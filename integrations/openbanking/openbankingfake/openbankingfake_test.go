@@ -0,0 +1,123 @@
+package openbankingfake_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/openbanking"
+	"github.com/andrewthowell/budgit/integrations/openbanking/openbankingfake"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOpenBankingFake(t *testing.T) {
+	suite.Run(t, new(openBankingFakeSuite))
+}
+
+type openBankingFakeSuite struct {
+	suite.Suite
+
+	fake   *openbankingfake.Server
+	server *httptest.Server
+	client *openbanking.ClientWithResponses
+}
+
+func (s *openBankingFakeSuite) SetupTest() {
+	s.fake = openbankingfake.New()
+	s.server = httptest.NewServer(s.fake)
+
+	client, err := openbanking.NewClientWithResponses(s.server.URL)
+	s.Require().NoError(err)
+	s.client = client
+}
+
+func (s *openBankingFakeSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *openBankingFakeSuite) TestBalances() {
+	account := s.fake.AddAccount(openbankingfake.Account{Nickname: "Bills", BookedBalance: -1050, AvailableBalance: 20000})
+
+	resp, err := s.client.GetAccountBalancesWithResponse(context.Background(), account.ID, nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.JSON200)
+	s.Require().Len(resp.JSON200.Data.Balance, 2)
+
+	booked := resp.JSON200.Data.Balance[0]
+	s.Equal(openbanking.InterimBooked, booked.Type)
+	s.Equal("10.50", booked.Amount.Amount)
+	s.Equal(openbanking.Debit, booked.CreditDebitIndicator)
+
+	available := resp.JSON200.Data.Balance[1]
+	s.Equal(openbanking.InterimAvailable, available.Type)
+	s.Equal("200.00", available.Amount.Amount)
+	s.Equal(openbanking.Credit, available.CreditDebitIndicator)
+}
+
+func (s *openBankingFakeSuite) TestTransactions() {
+	account := s.fake.AddAccount(openbankingfake.Account{Nickname: "Bills"})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	transactions := s.fake.AddTransactions(account.ID,
+		openbankingfake.Transaction{Amount: -320, MerchantName: "Pret", BookingTime: now.AddDate(0, 0, -2)},
+		openbankingfake.Transaction{Amount: 1000, CounterpartyName: "Employer", BookingTime: now.AddDate(0, 0, -1), Pending: true},
+	)
+
+	s.Run("MostRecentFirst", func() {
+		resp, err := s.client.GetAccountTransactionsWithResponse(context.Background(), account.ID, nil)
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200.Data.Transaction, 2)
+
+		pending, booked := (*resp.JSON200.Data.Transaction)[0], (*resp.JSON200.Data.Transaction)[1]
+		s.Equal(transactions[1].ID, *pending.TransactionId)
+		s.Equal(openbanking.OBTransactionStatusPending, pending.Status)
+		s.Equal("Employer", *pending.DebtorAccount.Name)
+		s.Equal(openbanking.OBTransactionStatusBooked, booked.Status)
+		s.Equal("3.20", booked.Amount.Amount)
+		s.Equal("Pret", *booked.MerchantDetails.MerchantName)
+	})
+	s.Run("PaginatedByNextLink", func() {
+		s.fake.SetPageSize(1)
+		defer s.fake.SetPageSize(25)
+
+		resp, err := s.client.GetAccountTransactionsWithResponse(context.Background(), account.ID, nil)
+		s.Require().NoError(err)
+		s.Require().Len(*resp.JSON200.Data.Transaction, 1)
+		s.Require().NotNil(resp.JSON200.Links.Next)
+
+		next, err := http.Get(*resp.JSON200.Links.Next)
+		s.Require().NoError(err)
+		nextResp, err := openbanking.ParseGetAccountTransactionsResponse(next)
+		s.Require().NoError(err)
+		s.Require().Len(*nextResp.JSON200.Data.Transaction, 1)
+		s.Equal(transactions[0].ID, *(*nextResp.JSON200.Data.Transaction)[0].TransactionId)
+		s.Nil(nextResp.JSON200.Links.Next)
+	})
+	s.Run("FromBookingDateTime", func() {
+		from := now.AddDate(0, 0, -1)
+		resp, err := s.client.GetAccountTransactionsWithResponse(context.Background(), account.ID, &openbanking.GetAccountTransactionsParams{FromBookingDateTime: &from})
+		s.Require().NoError(err)
+		s.Require().Len(*resp.JSON200.Data.Transaction, 1)
+		s.Equal(transactions[1].ID, *(*resp.JSON200.Data.Transaction)[0].TransactionId)
+	})
+	s.Run("UnknownAccount", func() {
+		resp, err := s.client.GetAccountTransactionsWithResponse(context.Background(), "unknown", nil)
+		s.Require().NoError(err)
+		s.Equal(http.StatusNotFound, resp.StatusCode())
+	})
+}
+
+func (s *openBankingFakeSuite) TestFinancialID() {
+	s.fake.SetFinancialID("0015800001041RHAAY")
+
+	resp, err := s.client.GetAccountsWithResponse(context.Background(), nil)
+	s.Require().NoError(err)
+	s.Equal(http.StatusForbidden, resp.StatusCode())
+
+	financialID := "0015800001041RHAAY"
+	resp, err = s.client.GetAccountsWithResponse(context.Background(), &openbanking.GetAccountsParams{XFapiFinancialId: &financialID})
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode())
+}
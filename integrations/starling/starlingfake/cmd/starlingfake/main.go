@@ -0,0 +1,27 @@
+// Command starlingfake serves a fake Starling API seeded with demo data, so Budg-it can be demoed without credentials.
+//
+// Point the Starling integration at it, with any API token:
+//
+//	go run ./integrations/starling/starlingfake/cmd/starlingfake -addr localhost:8081
+//	STARLING_URL=http://localhost:8081 STARLING_API_TOKEN=demo go run .
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8081", "address to listen on")
+	flag.Parse()
+
+	server := starlingfake.New()
+	server.SeedDemo(time.Now())
+
+	log.Printf("Serving fake Starling API on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -0,0 +1,50 @@
+package starlingfake
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeedDemo seeds the Server with a personal and a joint account, each with a month of feed items before now,
+// spaces and a standing order.
+func (s *Server) SeedDemo(now time.Time) {
+	personal := s.AddAccount(Account{
+		UID:              uuid.MustParse("00000000-0000-4000-8000-000000000001"),
+		DefaultCategory:  uuid.MustParse("00000000-0000-4000-8000-000000000101"),
+		Name:             "Personal",
+		CreatedAt:        now.AddDate(-1, 0, 0),
+		ClearedBalance:   123456,
+		EffectiveBalance: 120206,
+	})
+	joint := s.AddAccount(Account{
+		UID:              uuid.MustParse("00000000-0000-4000-8000-000000000002"),
+		DefaultCategory:  uuid.MustParse("00000000-0000-4000-8000-000000000102"),
+		Name:             "Joint",
+		CreatedAt:        now.AddDate(-1, 0, 0),
+		ClearedBalance:   250000,
+		EffectiveBalance: 250000,
+	})
+
+	day := func(daysAgo int) time.Time {
+		return now.AddDate(0, 0, -daysAgo).Truncate(time.Hour)
+	}
+	s.AddFeedItems(personal.UID,
+		FeedItem{Amount: 250000, CounterPartyName: "Employer Ltd", Reference: "SALARY", SpendingCategory: "INCOME", TransactionTime: day(28), Settled: true},
+		FeedItem{Amount: -85000, CounterPartyName: "Landlord", Reference: "RENT", SpendingCategory: "BILLS_AND_SERVICES", TransactionTime: day(27), Settled: true},
+		FeedItem{Amount: -6523, CounterPartyName: "Supermarket", SpendingCategory: "GROCERIES", TransactionTime: day(14), Settled: true},
+		FeedItem{Amount: -320, CounterPartyName: "Pret A Manger", SpendingCategory: "EATING_OUT", TransactionTime: day(2), Settled: true},
+		FeedItem{Amount: -3250, CounterPartyName: "Train Company", SpendingCategory: "TRANSPORT", TransactionTime: day(0)},
+	)
+	s.AddFeedItems(joint.UID,
+		FeedItem{Amount: 100000, CounterPartyName: "Personal", Reference: "JOINT", SpendingCategory: "TRANSFERS", TransactionTime: day(26), Settled: true},
+		FeedItem{Amount: -12000, CounterPartyName: "Energy Co", SpendingCategory: "BILLS_AND_SERVICES", TransactionTime: day(20), Settled: true},
+	)
+	s.AddSpaces(personal.UID,
+		Space{Name: "Holiday", Balance: 50000, SavingsGoal: true},
+		Space{Name: "Bills", Balance: 12000},
+	)
+	s.AddStandingOrders(personal.UID,
+		StandingOrder{Amount: 100000, Reference: "JOINT", NextDate: now.AddDate(0, 0, 3).Truncate(24 * time.Hour)},
+	)
+}
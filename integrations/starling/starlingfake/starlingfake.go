@@ -0,0 +1,749 @@
+// Package starlingfake is an in-process fake of the Starling API, for tests and demos without a live bank.
+// It serves the generated starling types, seeded with accounts, balances, feed items, spaces and standing orders,
+// and can be made to fail requests to exercise error handling.
+package starlingfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/starling"
+	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Account is a Starling account to seed the Server with.
+type Account struct {
+	UID              uuid.UUID
+	DefaultCategory  uuid.UUID
+	Name             string
+	Currency         string
+	CreatedAt        time.Time
+	ClearedBalance   int64
+	EffectiveBalance int64
+}
+
+// FeedItem is a transaction in the feed of a Starling account, to seed the Server with.
+// Amount is signed, negative amounts being outbound.
+type FeedItem struct {
+	UID              uuid.UUID
+	CategoryUID      uuid.UUID
+	Amount           int64
+	CounterPartyName string
+	Reference        string
+	UserNote         string
+	SpendingCategory string
+	TransactionTime  time.Time
+	Settled          bool
+	Attachments      []Attachment
+	Receipts         []Receipt
+}
+
+// Attachment is a file attached to a FeedItem.
+type Attachment struct {
+	UID uuid.UUID
+	// Type is the Starling attachment type, PDF or IMAGE.
+	Type        string
+	ContentType string
+	Content     []byte
+}
+
+// Receipt is an itemised receipt of a FeedItem.
+type Receipt struct {
+	UID          uuid.UUID
+	Identifier   string
+	ProviderName string
+	Items        []ReceiptItem
+	Total        float32
+}
+
+// ReceiptItem is a line of a Receipt.
+type ReceiptItem struct {
+	Description string
+	Amount      float32
+	Quantity    int32
+}
+
+// Space is a spending space or savings goal of a Starling account, to seed the Server with.
+type Space struct {
+	UID         uuid.UUID
+	Name        string
+	Balance     int64
+	SavingsGoal bool
+}
+
+// StandingOrder is a standing order from a Starling account, to seed the Server with.
+type StandingOrder struct {
+	UID         uuid.UUID
+	CategoryUID uuid.UUID
+	PayeeUID    uuid.UUID
+	Amount      int64
+	Reference   string
+	Frequency   string
+	NextDate    time.Time
+}
+
+// Failure is a failure injected into the Server, returned instead of the normal response.
+type Failure struct {
+	// Method and PathPrefix restrict the requests that fail. Empty values match all requests.
+	Method     string
+	PathPrefix string
+	StatusCode int
+	Header     http.Header
+	Body       string
+	// Times is the number of matching requests that fail. Zero fails every matching request.
+	Times int
+}
+
+type account struct {
+	Account
+	feedItems      []FeedItem
+	spaces         []Space
+	standingOrders []StandingOrder
+}
+
+// Server is a fake Starling API. It is safe for concurrent use.
+type Server struct {
+	mux *http.ServeMux
+
+	mu            sync.Mutex
+	accounts      []*account
+	failures      []*Failure
+	accessToken   string
+	refreshToken  string
+	issuedTokens  int
+	tokenLifetime time.Duration
+	requests      []string
+}
+
+// New returns an empty Server, which accepts requests with any access token until SetCredentials is called.
+func New() *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		tokenLifetime: time.Hour,
+	}
+	s.mux.HandleFunc("POST /oauth/access-token", s.handleAccessToken)
+	s.mux.HandleFunc("GET /api/v2/identity/token", s.authenticated(s.handleTokenIdentity))
+	s.mux.HandleFunc("GET /api/v2/accounts", s.authenticated(s.handleAccounts))
+	s.mux.HandleFunc("GET /api/v2/accounts/{accountUid}/balance", s.authenticated(s.handleBalance))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}", s.authenticated(s.handleFeedItems))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}/transactions-between", s.authenticated(s.handleFeedItemsBetween))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}", s.authenticated(s.handleFeedItem))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}/attachments", s.authenticated(s.handleAttachments))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}/attachments/{feedItemAttachmentUid}", s.authenticated(s.handleDownloadAttachment))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}/receipts", s.authenticated(s.handleReceipts))
+	s.mux.HandleFunc("PUT /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}/user-note", s.authenticated(s.handleUpdateUserNote))
+	s.mux.HandleFunc("PUT /api/v2/feed/account/{accountUid}/category/{categoryUid}/{feedItemUid}/spending-category", s.authenticated(s.handleUpdateSpendingCategory))
+	s.mux.HandleFunc("GET /api/v2/feed/account/{accountUid}/settled-transactions-between", s.authenticated(s.handleSettledFeedItemsBetween))
+	s.mux.HandleFunc("GET /api/v2/account/{accountUid}/spaces", s.authenticated(s.handleSpaces))
+	s.mux.HandleFunc("GET /api/v2/payments/local/account/{accountUid}/category/{categoryUid}/standing-orders", s.authenticated(s.handleStandingOrders))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	failure := s.takeFailure(r)
+	s.mu.Unlock()
+
+	if failure != nil {
+		for key, values := range failure.Header {
+			w.Header()[key] = values
+		}
+		if strings.HasPrefix(failure.Body, "{") {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(failure.StatusCode)
+		w.Write([]byte(failure.Body))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// SetCredentials requires requests to carry the given access token, which can be rotated using the refresh token.
+func (s *Server) SetCredentials(accessToken, refreshToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken, s.refreshToken = accessToken, refreshToken
+}
+
+// AddAccount seeds an account. A zero UID or DefaultCategory is replaced with a random one.
+func (s *Server) AddAccount(a Account) Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if a.UID == uuid.Nil {
+		a.UID = uuid.New()
+	}
+	if a.DefaultCategory == uuid.Nil {
+		a.DefaultCategory = uuid.New()
+	}
+	if a.Currency == "" {
+		a.Currency = "GBP"
+	}
+	s.accounts = append(s.accounts, &account{Account: a})
+	return a
+}
+
+// SetBalance replaces the balance of a seeded account.
+func (s *Server) SetBalance(accountUID uuid.UUID, cleared, effective int64) {
+	s.withAccount(accountUID, func(a *account) {
+		a.ClearedBalance, a.EffectiveBalance = cleared, effective
+	})
+}
+
+// AddFeedItems seeds feed items into an account. A zero CategoryUID is replaced with the account's default category,
+// and zero UIDs of the items, their attachments and receipts with generated ones.
+func (s *Server) AddFeedItems(accountUID uuid.UUID, items ...FeedItem) []FeedItem {
+	s.withAccount(accountUID, func(a *account) {
+		for i := range items {
+			if items[i].UID == uuid.Nil {
+				items[i].UID = uuid.New()
+			}
+			if items[i].CategoryUID == uuid.Nil {
+				items[i].CategoryUID = a.DefaultCategory
+			}
+			for j := range items[i].Attachments {
+				if items[i].Attachments[j].UID == uuid.Nil {
+					items[i].Attachments[j].UID = uuid.New()
+				}
+			}
+			for j := range items[i].Receipts {
+				if items[i].Receipts[j].UID == uuid.Nil {
+					items[i].Receipts[j].UID = uuid.New()
+				}
+			}
+		}
+		a.feedItems = append(a.feedItems, items...)
+	})
+	return items
+}
+
+// AddSpaces seeds spaces into an account.
+func (s *Server) AddSpaces(accountUID uuid.UUID, spaces ...Space) []Space {
+	s.withAccount(accountUID, func(a *account) {
+		for i := range spaces {
+			if spaces[i].UID == uuid.Nil {
+				spaces[i].UID = uuid.New()
+			}
+		}
+		a.spaces = append(a.spaces, spaces...)
+	})
+	return spaces
+}
+
+// AddStandingOrders seeds standing orders into an account.
+func (s *Server) AddStandingOrders(accountUID uuid.UUID, orders ...StandingOrder) []StandingOrder {
+	s.withAccount(accountUID, func(a *account) {
+		for i := range orders {
+			if orders[i].UID == uuid.Nil {
+				orders[i].UID = uuid.New()
+			}
+			if orders[i].CategoryUID == uuid.Nil {
+				orders[i].CategoryUID = a.DefaultCategory
+			}
+			if orders[i].Frequency == "" {
+				orders[i].Frequency = "MONTHLY"
+			}
+		}
+		a.standingOrders = append(a.standingOrders, orders...)
+	})
+	return orders
+}
+
+// FeedItem returns a feed item of an account, as updated by any requests.
+func (s *Server) FeedItem(accountUID, feedItemUID uuid.UUID) (FeedItem, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.accounts {
+		if a.UID != accountUID {
+			continue
+		}
+		for _, item := range a.feedItems {
+			if item.UID == feedItemUID {
+				return item, true
+			}
+		}
+	}
+	return FeedItem{}, false
+}
+
+// InjectFailure makes matching requests fail, in the order failures were injected.
+func (s *Server) InjectFailure(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the method and path of every request received, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *Server) withAccount(accountUID uuid.UUID, f func(a *account)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.accounts {
+		if a.UID == accountUID {
+			f(a)
+			return
+		}
+	}
+	panic(fmt.Sprintf("starlingfake: account %s has not been seeded", accountUID))
+}
+
+// takeFailure returns the first Failure matching the request, if any. It must be called with mu held.
+func (s *Server) takeFailure(r *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if failure.Method != "" && failure.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, failure.PathPrefix) {
+			continue
+		}
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = slices.Delete(s.failures, i, i+1)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func (s *Server) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		accessToken := s.accessToken
+		s.mu.Unlock()
+
+		if accessToken != "" && r.Header.Get("Authorization") != "Bearer "+accessToken {
+			writeError(w, http.StatusUnauthorized, "invalid_token")
+			return
+		}
+		handler(w, r)
+	}
+}
+
+func (s *Server) handleAccessToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.PostForm.Get("grant_type") != "refresh_token" || s.refreshToken == "" || r.PostForm.Get("refresh_token") != s.refreshToken {
+		writeError(w, http.StatusBadRequest, "invalid_grant")
+		return
+	}
+
+	s.issuedTokens++
+	s.accessToken = fmt.Sprintf("fake-access-token-%d", s.issuedTokens)
+	s.refreshToken = fmt.Sprintf("fake-refresh-token-%d", s.issuedTokens)
+	writeJSON(w, map[string]any{
+		"access_token":  s.accessToken,
+		"refresh_token": s.refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int64(s.tokenLifetime.Seconds()),
+	})
+}
+
+func (s *Server) handleTokenIdentity(w http.ResponseWriter, r *http.Request) {
+	expiresAt := time.Now().Add(s.tokenLifetime)
+	expiresIn := int64(s.tokenLifetime.Seconds())
+	writeJSON(w, starling.IdentityV2{
+		Authenticated:    ptr(true),
+		ExpiresAt:        &expiresAt,
+		ExpiresInSeconds: &expiresIn,
+		Scopes:           &[]string{"account:read", "balance:read", "transaction:read", "space:read", "standing-order:read"},
+	})
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	accounts := make([]starling.AccountV2, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		accounts = append(accounts, starling.AccountV2{
+			AccountUid:      &a.UID,
+			AccountType:     ptr(starling.AccountV2AccountType("PRIMARY")),
+			CreatedAt:       &a.CreatedAt,
+			Currency:        ptr(starling.AccountV2Currency(a.Currency)),
+			DefaultCategory: &a.DefaultCategory,
+			Name:            &a.Name,
+		})
+	}
+	writeJSON(w, starling.Accounts{Accounts: &accounts})
+}
+
+func (s *Server) handleBalance(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		amount := func(minorUnits int64) *starling.SignedCurrencyAndAmount {
+			return &starling.SignedCurrencyAndAmount{Currency: a.Currency, MinorUnits: minorUnits}
+		}
+		return starling.BalanceV2{
+			Amount:                amount(a.EffectiveBalance),
+			ClearedBalance:        amount(a.ClearedBalance),
+			EffectiveBalance:      amount(a.EffectiveBalance),
+			PendingTransactions:   amount(a.EffectiveBalance - a.ClearedBalance),
+			TotalClearedBalance:   amount(a.ClearedBalance),
+			TotalEffectiveBalance: amount(a.EffectiveBalance),
+		}
+	})
+}
+
+func (s *Server) handleFeedItems(w http.ResponseWriter, r *http.Request) {
+	changesSince, ok := parseTimeParam(w, r, "changesSince")
+	if !ok {
+		return
+	}
+	s.serveFeedItems(w, r, func(item FeedItem) bool {
+		return item.CategoryUID.String() == r.PathValue("categoryUid") && !item.TransactionTime.Before(changesSince)
+	})
+}
+
+func (s *Server) handleFeedItemsBetween(w http.ResponseWriter, r *http.Request) {
+	min, ok := parseTimeParam(w, r, "minTransactionTimestamp")
+	if !ok {
+		return
+	}
+	max, ok := parseTimeParam(w, r, "maxTransactionTimestamp")
+	if !ok {
+		return
+	}
+	s.serveFeedItems(w, r, func(item FeedItem) bool {
+		return item.CategoryUID.String() == r.PathValue("categoryUid") && between(item.TransactionTime, min, max)
+	})
+}
+
+func (s *Server) handleSettledFeedItemsBetween(w http.ResponseWriter, r *http.Request) {
+	min, ok := parseTimeParam(w, r, "minTransactionTimestamp")
+	if !ok {
+		return
+	}
+	max, ok := parseTimeParam(w, r, "maxTransactionTimestamp")
+	if !ok {
+		return
+	}
+	s.serveFeedItems(w, r, func(item FeedItem) bool {
+		return item.Settled && between(item.TransactionTime, min, max)
+	})
+}
+
+func (s *Server) handleFeedItem(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		for _, item := range a.feedItems {
+			if item.UID.String() == r.PathValue("feedItemUid") && item.CategoryUID.String() == r.PathValue("categoryUid") {
+				return toFeedItem(a, item)
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Server) handleAttachments(w http.ResponseWriter, r *http.Request) {
+	s.serveFeedItem(w, r, func(item FeedItem) any {
+		attachments := make([]starling.FeedItemAttachment, 0, len(item.Attachments))
+		for _, attachment := range item.Attachments {
+			attachments = append(attachments, starling.FeedItemAttachment{
+				FeedItemUid:            &item.UID,
+				FeedItemAttachmentUid:  &attachment.UID,
+				FeedItemAttachmentType: ptr(starling.FeedItemAttachmentFeedItemAttachmentType(attachment.Type)),
+			})
+		}
+		return starling.FeedItemAttachments{FeedItemAttachments: &attachments}
+	})
+}
+
+func (s *Server) handleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.feedItemInPath(r)
+	if ok {
+		for _, attachment := range item.Attachments {
+			if attachment.UID.String() == r.PathValue("feedItemAttachmentUid") {
+				w.Header().Set("Content-Type", attachment.ContentType)
+				w.Write(attachment.Content)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND")
+}
+
+func (s *Server) handleReceipts(w http.ResponseWriter, r *http.Request) {
+	s.serveFeedItem(w, r, func(item FeedItem) any {
+		receipts := make([]starling.Receipt, 0, len(item.Receipts))
+		for _, receipt := range item.Receipts {
+			items := make([]starling.ReceiptItem, 0, len(receipt.Items))
+			for _, receiptItem := range receipt.Items {
+				items = append(items, starling.ReceiptItem{
+					Description: receiptItem.Description,
+					Amount:      receiptItem.Amount,
+					Quantity:    &receiptItem.Quantity,
+				})
+			}
+			receipts = append(receipts, starling.Receipt{
+				FeedItemUid:       &item.UID,
+				ReceiptUid:        &receipt.UID,
+				ReceiptIdentifier: receipt.Identifier,
+				ProviderName:      &receipt.ProviderName,
+				MetadataSource:    starling.ReceiptMetadataSourcePARTNER,
+				Items:             items,
+				PaymentMethods:    []starling.ReceiptPaymentMethod{},
+				TotalAmount:       receipt.Total,
+			})
+		}
+		return receipts
+	})
+}
+
+// serveFeedItem writes the response built from the feed item in the path, or 404 Not Found if it is missing.
+func (s *Server) serveFeedItem(w http.ResponseWriter, r *http.Request, respond func(item FeedItem) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.feedItemInPath(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND")
+		return
+	}
+	writeJSON(w, respond(item))
+}
+
+// feedItemInPath returns the feed item in the path of a request. It must be called with mu held.
+func (s *Server) feedItemInPath(r *http.Request) (FeedItem, bool) {
+	for _, a := range s.accounts {
+		if a.UID.String() != r.PathValue("accountUid") {
+			continue
+		}
+		for _, item := range a.feedItems {
+			if item.UID.String() == r.PathValue("feedItemUid") && item.CategoryUID.String() == r.PathValue("categoryUid") {
+				return item, true
+			}
+		}
+	}
+	return FeedItem{}, false
+}
+
+func (s *Server) handleUpdateUserNote(w http.ResponseWriter, r *http.Request) {
+	body := starling.UserNoteWrapper{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %s", err))
+		return
+	}
+	s.updateFeedItem(w, r, func(item *FeedItem) {
+		item.UserNote = body.UserNote
+	})
+}
+
+func (s *Server) handleUpdateSpendingCategory(w http.ResponseWriter, r *http.Request) {
+	body := starling.UpdateSpendingCategory{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %s", err))
+		return
+	}
+	spendingCategories, err := spendingCategories()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if !slices.Contains(spendingCategories, any(string(body.SpendingCategory))) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid spendingCategory %q", body.SpendingCategory))
+		return
+	}
+	s.updateFeedItem(w, r, func(item *FeedItem) {
+		item.SpendingCategory = string(body.SpendingCategory)
+	})
+}
+
+// spendingCategories are the spending categories the API accepts, as listed by its specification.
+var spendingCategories = sync.OnceValues(func() ([]any, error) {
+	spec, err := starling.GetSwagger()
+	if err != nil {
+		return nil, err
+	}
+	return spec.Components.Schemas["UpdateSpendingCategory"].Value.Properties["spendingCategory"].Value.Enum, nil
+})
+
+// updateFeedItem applies an update to the feed item in the path, or writes 404 Not Found if it is missing.
+func (s *Server) updateFeedItem(w http.ResponseWriter, r *http.Request, update func(item *FeedItem)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		if a.UID.String() != r.PathValue("accountUid") {
+			continue
+		}
+		for i := range a.feedItems {
+			item := &a.feedItems[i]
+			if item.UID.String() == r.PathValue("feedItemUid") && item.CategoryUID.String() == r.PathValue("categoryUid") {
+				update(item)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND")
+}
+
+func (s *Server) handleSpaces(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		spaces := starling.Spaces{
+			SavingsGoals:   []starling.SavingsGoalOrdered{},
+			SpendingSpaces: []starling.SpendingSpace{},
+		}
+		for i, space := range a.spaces {
+			balance := starling.CurrencyAndAmount{Currency: a.Currency, MinorUnits: space.Balance}
+			if space.SavingsGoal {
+				spaces.SavingsGoals = append(spaces.SavingsGoals, starling.SavingsGoalOrdered{
+					SavingsGoalUid: &space.UID,
+					Name:           &space.Name,
+					SortOrder:      ptr(int32(i)),
+					State:          "ACTIVE",
+					TotalSaved:     &balance,
+				})
+				continue
+			}
+			spaces.SpendingSpaces = append(spaces.SpendingSpaces, starling.SpendingSpace{
+				SpaceUid:          space.UID,
+				Name:              space.Name,
+				Balance:           balance,
+				SortOrder:         ptr(int32(i)),
+				SpendingSpaceType: starling.SpendingSpaceSpendingSpaceTypeSPARE,
+				State:             "ACTIVE",
+			})
+		}
+		return spaces
+	})
+}
+
+func (s *Server) handleStandingOrders(w http.ResponseWriter, r *http.Request) {
+	s.serveAccount(w, r, func(a *account) any {
+		orders := make([]starling.StandingOrder, 0, len(a.standingOrders))
+		for _, order := range a.standingOrders {
+			if order.CategoryUID.String() != r.PathValue("categoryUid") {
+				continue
+			}
+			orders = append(orders, starling.StandingOrder{
+				PaymentOrderUid: &order.UID,
+				CategoryUid:     &order.CategoryUID,
+				PayeeUid:        &order.PayeeUID,
+				Amount:          &starling.CurrencyAndAmount{Currency: a.Currency, MinorUnits: order.Amount},
+				Reference:       &order.Reference,
+				NextDate:        &openapi_types.Date{Time: order.NextDate},
+				StandingOrderRecurrence: &starling.StandingOrderRecurrence{
+					Frequency: starling.StandingOrderRecurrenceFrequency(order.Frequency),
+					StartDate: openapi_types.Date{Time: order.NextDate},
+				},
+			})
+		}
+		return starling.StandingOrdersResponse{StandingOrders: &orders}
+	})
+}
+
+// serveAccount writes the response built from the account in the path, or 404 Not Found if either is missing.
+func (s *Server) serveAccount(w http.ResponseWriter, r *http.Request, respond func(a *account) any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.accounts {
+		if a.UID.String() != r.PathValue("accountUid") {
+			continue
+		}
+		resp := respond(a)
+		if resp == nil {
+			break
+		}
+		writeJSON(w, resp)
+		return
+	}
+	writeError(w, http.StatusNotFound, "NOT_FOUND")
+}
+
+func (s *Server) serveFeedItems(w http.ResponseWriter, r *http.Request, include func(item FeedItem) bool) {
+	s.serveAccount(w, r, func(a *account) any {
+		items := make([]starling.FeedItem, 0, len(a.feedItems))
+		for _, item := range a.feedItems {
+			if include(item) {
+				items = append(items, toFeedItem(a, item))
+			}
+		}
+		return starling.FeedItems{FeedItems: &items}
+	})
+}
+
+func toFeedItem(a *account, item FeedItem) starling.FeedItem {
+	direction, minorUnits := starling.FeedItemDirectionIN, item.Amount
+	if item.Amount < 0 {
+		direction, minorUnits = starling.FeedItemDirectionOUT, -item.Amount
+	}
+	status := starling.FeedItemStatusPENDING
+	var settlementTime *time.Time
+	if item.Settled {
+		status, settlementTime = starling.FeedItemStatusSETTLED, &item.TransactionTime
+	}
+	spendingCategory := item.SpendingCategory
+	if spendingCategory == "" {
+		spendingCategory = "GENERAL"
+	}
+	return starling.FeedItem{
+		FeedItemUid:      &item.UID,
+		CategoryUid:      &item.CategoryUID,
+		Amount:           &starling.CurrencyAndAmount{Currency: a.Currency, MinorUnits: minorUnits},
+		SourceAmount:     &starling.CurrencyAndAmount{Currency: a.Currency, MinorUnits: minorUnits},
+		Direction:        &direction,
+		Status:           &status,
+		CounterPartyName: &item.CounterPartyName,
+		Reference:        &item.Reference,
+		UserNote:         &item.UserNote,
+		SpendingCategory: ptr(starling.FeedItemSpendingCategory(spendingCategory)),
+		TransactionTime:  &item.TransactionTime,
+		SettlementTime:   settlementTime,
+		UpdatedAt:        &item.TransactionTime,
+		HasAttachment:    ptr(len(item.Attachments) > 0),
+		HasReceipt:       ptr(len(item.Receipts) > 0),
+	}
+}
+
+func parseTimeParam(w http.ResponseWriter, r *http.Request, name string) (time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, r.URL.Query().Get(name))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %s", name, err))
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func between(t, min, max time.Time) bool {
+	return !t.Before(min) && t.Before(max)
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(starling.ErrorResponse{
+		Errors:  &[]starling.ErrorDetail{{Message: &message}},
+		Success: ptr(false),
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,178 @@
+package starlingfake_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/andrewthowell/budgit/integrations/starling"
+	"github.com/andrewthowell/budgit/integrations/starling/starlingfake"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestStarlingFake(t *testing.T) {
+	suite.Run(t, new(starlingFakeSuite))
+}
+
+type starlingFakeSuite struct {
+	suite.Suite
+
+	fake   *starlingfake.Server
+	server *httptest.Server
+	client *starling.ClientWithResponses
+}
+
+func (s *starlingFakeSuite) SetupTest() {
+	s.fake = starlingfake.New()
+	s.server = httptest.NewServer(s.fake)
+
+	client, err := starling.NewClientWithResponses(s.server.URL)
+	s.Require().NoError(err)
+	s.client = client
+}
+
+func (s *starlingFakeSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *starlingFakeSuite) TestFeedItems() {
+	account := s.fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := s.fake.AddFeedItems(account.UID,
+		starlingfake.FeedItem{Amount: -320, CounterPartyName: "Pret", TransactionTime: now.AddDate(0, 0, -2), Settled: true},
+		starlingfake.FeedItem{Amount: 1000, CounterPartyName: "Employer", TransactionTime: now.AddDate(0, 0, -1)},
+	)
+
+	s.Run("ChangesSince", func() {
+		resp, err := s.client.QueryFeedItemsWithResponse(context.Background(), account.UID, account.DefaultCategory, &starling.QueryFeedItemsParams{ChangesSince: now.AddDate(0, 0, -1)})
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200.FeedItems, 1)
+
+		item := (*resp.JSON200.FeedItems)[0]
+		s.Equal(items[1].UID, *item.FeedItemUid)
+		s.Equal(starling.FeedItemDirectionIN, *item.Direction)
+		s.Equal(int64(1000), item.Amount.MinorUnits)
+	})
+	s.Run("SettledBetween", func() {
+		resp, err := s.client.QueryFeedItemsWithTransactionTimesBetweenWithResponse(context.Background(), account.UID, &starling.QueryFeedItemsWithTransactionTimesBetweenParams{
+			MinTransactionTimestamp: now.AddDate(0, 0, -7),
+			MaxTransactionTimestamp: now,
+		})
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200.FeedItems, 1)
+
+		item := (*resp.JSON200.FeedItems)[0]
+		s.Equal("Pret", *item.CounterPartyName)
+		s.Equal(starling.FeedItemDirectionOUT, *item.Direction)
+		s.Equal(int64(320), item.Amount.MinorUnits)
+		s.Equal(starling.FeedItemStatusSETTLED, *item.Status)
+	})
+	s.Run("UnknownAccount", func() {
+		resp, err := s.client.GetFeedItemWithResponse(context.Background(), items[0].CategoryUID, items[0].CategoryUID, items[0].UID)
+		s.Require().NoError(err)
+		s.Equal(http.StatusNotFound, resp.StatusCode())
+	})
+}
+
+func (s *starlingFakeSuite) TestUpdateFeedItem() {
+	account := s.fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	item := s.fake.AddFeedItems(account.UID, starlingfake.FeedItem{Amount: -320, CounterPartyName: "Pret"})[0]
+
+	noteResp, err := s.client.UpdateUserNoteWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID, starling.UserNoteWrapper{UserNote: "coffee"})
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, noteResp.StatusCode())
+
+	categoryResp, err := s.client.ChangeTransactionCategoryWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID, starling.UpdateSpendingCategory{
+		SpendingCategory: starling.UpdateSpendingCategorySpendingCategoryEATINGOUT,
+	})
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, categoryResp.StatusCode())
+
+	updated, ok := s.fake.FeedItem(account.UID, item.UID)
+	s.Require().True(ok)
+	s.Equal("coffee", updated.UserNote)
+	s.Equal("EATING_OUT", updated.SpendingCategory)
+
+	invalidResp, err := s.client.ChangeTransactionCategoryWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID, starling.UpdateSpendingCategory{
+		SpendingCategory: "WEEKLY_SHOP",
+	})
+	s.Require().NoError(err)
+	s.Equal(http.StatusBadRequest, invalidResp.StatusCode())
+	updated, ok = s.fake.FeedItem(account.UID, item.UID)
+	s.Require().True(ok)
+	s.Equal("EATING_OUT", updated.SpendingCategory, "expected a spending category Starling does not have to be rejected")
+}
+
+func (s *starlingFakeSuite) TestSpacesAndStandingOrders() {
+	account := s.fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	s.fake.AddSpaces(account.UID,
+		starlingfake.Space{Name: "Holiday", Balance: 500, SavingsGoal: true},
+		starlingfake.Space{Name: "Bills", Balance: 120},
+	)
+	s.fake.AddStandingOrders(account.UID, starlingfake.StandingOrder{Amount: 1000, Reference: "RENT"})
+
+	spaces, err := s.client.GetSpacesWithResponse(context.Background(), account.UID)
+	s.Require().NoError(err)
+	s.Require().NotNil(spaces.JSON200)
+	s.Require().Len(spaces.JSON200.SavingsGoals, 1)
+	s.Require().Len(spaces.JSON200.SpendingSpaces, 1)
+	s.Equal("Holiday", *spaces.JSON200.SavingsGoals[0].Name)
+	s.Equal(int64(120), spaces.JSON200.SpendingSpaces[0].Balance.MinorUnits)
+
+	orders, err := s.client.ListStandingOrdersWithResponse(context.Background(), account.UID, account.DefaultCategory)
+	s.Require().NoError(err)
+	s.Require().NotNil(orders.JSON200)
+	s.Require().Len(*orders.JSON200.StandingOrders, 1)
+	s.Equal("RENT", *(*orders.JSON200.StandingOrders)[0].Reference)
+}
+
+func (s *starlingFakeSuite) TestCredentials() {
+	s.fake.SetCredentials("access", "refresh")
+
+	resp, err := s.client.GetAccountsWithResponse(context.Background())
+	s.Require().NoError(err)
+	s.Equal(http.StatusUnauthorized, resp.StatusCode())
+
+	resp, err = s.client.GetAccountsWithResponse(context.Background(), func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer access")
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, resp.StatusCode())
+}
+
+func (s *starlingFakeSuite) TestAttachmentsAndReceipts() {
+	account := s.fake.AddAccount(starlingfake.Account{Name: "Personal"})
+	item := s.fake.AddFeedItems(account.UID, starlingfake.FeedItem{
+		Amount:           -320,
+		CounterPartyName: "Pret",
+		Attachments:      []starlingfake.Attachment{{Type: "PDF", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}},
+		Receipts:         []starlingfake.Receipt{{Identifier: "R-1", ProviderName: "Pret", Total: 3.2, Items: []starlingfake.ReceiptItem{{Description: "Coffee", Amount: 3.2, Quantity: 1}}}},
+	})[0]
+
+	s.Run("ListAttachments", func() {
+		resp, err := s.client.GetFeedItemAttachmentsWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID)
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200.FeedItemAttachments, 1)
+		s.Equal(item.Attachments[0].UID, *(*resp.JSON200.FeedItemAttachments)[0].FeedItemAttachmentUid)
+	})
+	s.Run("DownloadAttachment", func() {
+		resp, err := s.client.DownloadFeedItemAttachmentWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID, item.Attachments[0].UID)
+		s.Require().NoError(err)
+		s.Equal(http.StatusOK, resp.StatusCode())
+		s.Equal("application/pdf", resp.HTTPResponse.Header.Get("Content-Type"))
+		s.Equal([]byte("%PDF-1.4"), resp.Body)
+	})
+	s.Run("Receipts", func() {
+		resp, err := s.client.GetReceiptsWithResponse(context.Background(), account.UID, account.DefaultCategory, item.UID)
+		s.Require().NoError(err)
+		s.Require().NotNil(resp.JSON200)
+		s.Require().Len(*resp.JSON200, 1)
+		s.Equal(item.Receipts[0].UID, *(*resp.JSON200)[0].ReceiptUid)
+	})
+}
@@ -2,12 +2,22 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/andrewthowell/budgit/budgit/api"
+	"github.com/andrewthowell/budgit/budgit/attachmentstore"
+	"github.com/andrewthowell/budgit/budgit/cli"
 	"github.com/andrewthowell/budgit/budgit/clients"
 	"github.com/andrewthowell/budgit/budgit/db"
+	"github.com/andrewthowell/budgit/budgit/migrations"
 	"github.com/andrewthowell/budgit/budgit/svc"
-	"github.com/jackc/pgx/v5"
+	"github.com/andrewthowell/budgit/budgit/web"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
@@ -15,23 +25,53 @@ import (
 )
 
 type Config struct {
-	DB       *DBConfig     `required:"true" envconfig:"db"`
-	Logger   *LoggerConfig `required:"true" envconfig:"logger"`
-	Starling *ClientConfig `required:"true" envconfig:"starling"`
+	DB     *DBConfig     `required:"true" envconfig:"db"`
+	Logger *LoggerConfig `required:"true" envconfig:"logger"`
+	// Addr is the address the API is served on.
+	Addr string `default:"localhost:8080" envconfig:"addr"`
+	// AttachmentsDir is the directory the content of attachments is stored in.
+	AttachmentsDir string `default:"attachments" envconfig:"attachments_dir"`
+	// IntegrationIDs are the IDs of the integrations to run. Each is configured by variables prefixed with its ID,
+	// e.g. STARLING_JOINT_URL for the integration "starling_joint".
+	IntegrationIDs []string                      `default:"starling" envconfig:"integrations"`
+	Integrations   map[string]*IntegrationConfig `ignored:"true"`
 }
 
 func (c Config) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddObject("DB", c.DB)
 	enc.AddObject("Logger", c.Logger)
-	enc.AddObject("Starling", c.Starling)
+	enc.AddString("Addr", c.Addr)
+	enc.AddString("AttachmentsDir", c.AttachmentsDir)
+	for _, id := range c.IntegrationIDs {
+		enc.AddObject(id, c.Integrations[id])
+	}
 	return nil
 }
 
 type DBConfig struct {
+	// User is the role budgit runs as. It must be neither a superuser nor have BYPASSRLS for the row-level security
+	// of budgets to apply, see the budgit_app role created by the migrations.
 	User     string `required:"true" envconfig:"user"`
 	Password string `required:"true" envconfig:"password"`
 	Host     string `required:"true" envconfig:"host"`
 	Port     string `required:"true" envconfig:"port"`
+	// MigrateUser is the role which applies the migrations. It must own the tables, as migrations alter them and lift
+	// the row-level security forced on them to copy rows of every budget. It defaults to User.
+	MigrateUser     string `envconfig:"migrate_user"`
+	MigratePassword string `envconfig:"migrate_password"`
+}
+
+// url returns the URL of the budgit database as a role.
+func (c DBConfig) url(user, password string) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/budgit?sslmode=disable", user, password, c.Host, c.Port)
+}
+
+// migrateURL returns the URL of the budgit database as the role which applies the migrations.
+func (c DBConfig) migrateURL() string {
+	if c.MigrateUser == "" {
+		return c.url(c.User, c.Password)
+	}
+	return c.url(c.MigrateUser, c.MigratePassword)
 }
 
 func (c DBConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -39,6 +79,8 @@ func (c DBConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("Password", "**REDACTED**")
 	enc.AddString("Host", c.Host)
 	enc.AddString("Port", c.Port)
+	enc.AddString("MigrateUser", c.MigrateUser)
+	enc.AddString("MigratePassword", "**REDACTED**")
 	return nil
 }
 
@@ -51,56 +93,155 @@ func (c LoggerConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	return nil
 }
 
-type ClientConfig struct {
-	URL      string `required:"true" envconfig:"url"`
-	APIToken string `required:"true" envconfig:"api_token"`
+type IntegrationConfig struct {
+	// Type is the type of integration, see clients.Registry. It defaults to the integration's ID.
+	Type string `envconfig:"type"`
+	URL  string `required:"true" envconfig:"url"`
+	// APIToken is a personal access token. It is used when OAuth is not configured.
+	APIToken string `envconfig:"api_token"`
+	// Fields concerning OAuth. Optional.
+	ClientID       string `envconfig:"client_id"`
+	ClientSecret   string `envconfig:"client_secret"`
+	RefreshToken   string `envconfig:"refresh_token"`
+	TokenStorePath string `envconfig:"token_store_path"`
+	TokenStoreKey  string `envconfig:"token_store_key"`
+	// TokenURL overrides the OAuth token endpoint of the integration, and is required by Open Banking integrations.
+	TokenURL string `envconfig:"token_url"`
+	// FinancialID identifies the bank of an Open Banking integration, for banks which require it.
+	FinancialID string `envconfig:"financial_id"`
 }
 
-func (c ClientConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+func (c IntegrationConfig) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("Type", c.Type)
 	enc.AddString("URL", c.URL)
 	enc.AddString("APIToken", "**REDACTED**")
+	enc.AddString("ClientID", c.ClientID)
+	enc.AddString("ClientSecret", "**REDACTED**")
+	enc.AddString("RefreshToken", "**REDACTED**")
+	enc.AddString("TokenStorePath", c.TokenStorePath)
+	enc.AddString("TokenStoreKey", "**REDACTED**")
+	enc.AddString("TokenURL", c.TokenURL)
+	enc.AddString("FinancialID", c.FinancialID)
 	return nil
 }
 
+func (c IntegrationConfig) toClientConfig(id string) (clients.IntegrationConfig, error) {
+	var key []byte
+	if c.TokenStorePath != "" {
+		decoded, err := hex.DecodeString(c.TokenStoreKey)
+		if err != nil {
+			return clients.IntegrationConfig{}, fmt.Errorf("decoding token store key of integration %q: %w", id, err)
+		}
+		key = decoded
+	}
+	return clients.IntegrationConfig{
+		ID:             id,
+		Type:           c.Type,
+		URL:            c.URL,
+		APIToken:       c.APIToken,
+		ClientID:       c.ClientID,
+		ClientSecret:   c.ClientSecret,
+		RefreshToken:   c.RefreshToken,
+		TokenStorePath: c.TokenStorePath,
+		TokenStoreKey:  key,
+		TokenURL:       c.TokenURL,
+		FinancialID:    c.FinancialID,
+	}, nil
+}
+
 func main() {
+	os.Exit(run())
+}
+
+// run runs the command line, returning its exit code, so that deferred calls run before exiting.
+func run() int {
 	config, err := loadConfigFromEnv()
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return cli.ExitError
 	}
 
 	log, err := newLogger(config)
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return cli.ExitError
 	}
 	defer log.Sync()
 
-	log.Infow("Starting Budgit", zap.Any("config", config))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	url := fmt.Sprintf("postgres://%s:%s@%s:%s/budgit?sslmode=disable", config.DB.User, config.DB.Password, config.DB.Host, config.DB.Port)
-	conn, err := pgx.Connect(context.Background(), url)
-	if err != nil {
-		log.Panic("Connecting to Postgres", zap.Error(err))
-	}
-	defer conn.Close(context.Background())
-
-	starlingClient, err := clients.NewStarlingClient(log, config.Starling.URL, config.Starling.APIToken)
-	if err != nil {
-		log.Panic("Connecting to Starling", zap.Error(err))
-	}
+	url := config.DB.url(config.DB.User, config.DB.Password)
+	var pool *pgxpool.Pool
+	defer func() {
+		if pool != nil {
+			pool.Close()
+		}
+	}()
+	newService := func(ctx context.Context) (*svc.Service, error) {
+		var err error
+		pool, err = pgxpool.New(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to Postgres: %w", err)
+		}
 
-	db := db.New(log)
-	service := svc.New(log, conn, db, []svc.Integration{starlingClient})
+		clientConfigs := make([]clients.IntegrationConfig, 0, len(config.IntegrationIDs))
+		for _, id := range config.IntegrationIDs {
+			clientConfig, err := config.Integrations[id].toClientConfig(id)
+			if err != nil {
+				return nil, fmt.Errorf("configuring integrations: %w", err)
+			}
+			clientConfigs = append(clientConfigs, clientConfig)
+		}
+		integrations, err := clients.NewRegistry(log).Build(clientConfigs...)
+		if err != nil {
+			return nil, fmt.Errorf("connecting to integrations: %w", err)
+		}
 
-	accounts, err := service.LoadAccountsFromIntegration(context.Background(), starlingClient.ID())
-	if err != nil {
-		log.Panic("Loading accounts from Starling", zap.Error(err))
-	}
-	fmt.Println(len(accounts), "accounts")
-	for _, account := range accounts {
-		fmt.Println(fmt.Sprintf("%+v", account))
+		attachments, err := attachmentstore.NewFileStore(config.AttachmentsDir)
+		if err != nil {
+			return nil, fmt.Errorf("opening attachment store: %w", err)
+		}
+		return svc.New(log, pool, db.New(log), integrations, attachments), nil
 	}
 
-	log.Info("Exiting Budgit")
+	app := &cli.App{
+		Service: func(ctx context.Context) (cli.Service, error) {
+			return newService(ctx)
+		},
+		Migrator: func(ctx context.Context) (cli.Migrator, error) {
+			return migrations.New(config.DB.migrateURL())
+		},
+		Serve: func(ctx context.Context) error {
+			log.Infow("Starting Budgit", zap.Any("config", config))
+			service, err := newService(ctx)
+			if err != nil {
+				return err
+			}
+			bypassesRLS, err := db.New(log).BypassesRowLevelSecurity(ctx, pool)
+			if err != nil {
+				return err
+			}
+			if bypassesRLS {
+				log.Warnw("Postgres role is a superuser or has BYPASSRLS, so row-level security does not separate budgets", zap.String("user", config.DB.User))
+			}
+			server, err := api.New(log, service)
+			if err != nil {
+				return err
+			}
+			webHandler, err := web.New(log, service, time.Now)
+			if err != nil {
+				return err
+			}
+			server.Mount("/", webHandler)
+			if err := server.ListenAndServe(ctx, config.Addr); err != nil {
+				return err
+			}
+			log.Info("Exiting Budgit")
+			return nil
+		},
+	}
+	return app.Execute(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
 }
 
 func newLogger(config *Config) (*zap.SugaredLogger, error) {
@@ -126,5 +267,17 @@ func loadConfigFromEnv() (*Config, error) {
 
 	config := &Config{}
 	envconfig.MustProcess("", config)
+
+	config.Integrations = make(map[string]*IntegrationConfig, len(config.IntegrationIDs))
+	for _, id := range config.IntegrationIDs {
+		integrationConfig := &IntegrationConfig{}
+		if err := envconfig.Process(id, integrationConfig); err != nil {
+			return nil, fmt.Errorf("loading config of integration %q from env: %w", id, err)
+		}
+		if integrationConfig.Type == "" {
+			integrationConfig.Type = id
+		}
+		config.Integrations[id] = integrationConfig
+	}
 	return config, nil
 }